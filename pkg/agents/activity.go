@@ -0,0 +1,104 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UserSession is a user logon session on the Agent's host
+type UserSession struct {
+	User  string        // The session's username (e.g., ACME\jdoe)
+	State string        // The session's state (e.g., active, disconnected, locked)
+	Logon time.Time     // When the user logged on, if it is known
+	Idle  time.Duration // How long the session has been without keyboard or mouse input
+}
+
+// Activity is the user input activity on the Agent's host, used to time interactive work to when the user is away
+type Activity struct {
+	Checked  time.Time     // When the Agent measured the activity
+	Idle     time.Duration // How long the host has been without keyboard or mouse input
+	Sessions []UserSession // The user logon sessions on the host
+}
+
+// activity is the JSON structure the Agent returns for the idle command
+// (e.g., {"idle":300,"sessions":[{"user":"ACME\\jdoe","state":"active","logon":"2026-01-02T08:00:00Z","idle":300}]})
+type activity struct {
+	Idle     int64 `json:"idle"` // Seconds since the last keyboard or mouse input
+	Sessions []struct {
+		User  string `json:"user"`
+		State string `json:"state"`
+		Logon string `json:"logon"`
+		Idle  int64  `json:"idle"`
+	} `json:"sessions"`
+}
+
+// ParseActivity parses the user activity the Agent measured at the provided time
+func ParseActivity(output string, checked time.Time) (Activity, error) {
+	var a activity
+	err := json.Unmarshal([]byte(strings.TrimSpace(output)), &a)
+	if err != nil {
+		return Activity{}, fmt.Errorf("pkg/agents.ParseActivity(): there was an error parsing the user activity: %s", err)
+	}
+	if a.Idle < 0 {
+		return Activity{}, fmt.Errorf("pkg/agents.ParseActivity(): the idle time %d is negative", a.Idle)
+	}
+	result := Activity{
+		Checked: checked,
+		Idle:    time.Duration(a.Idle) * time.Second,
+	}
+	for _, s := range a.Sessions {
+		session := UserSession{
+			User:  s.User,
+			State: s.State,
+			Idle:  time.Duration(s.Idle) * time.Second,
+		}
+		if logon, err := time.Parse(time.RFC3339, s.Logon); err == nil {
+			session.Logon = logon
+		}
+		result.Sessions = append(result.Sessions, session)
+	}
+	return result, nil
+}
+
+// LastActive returns when a user last used the host's keyboard or mouse, or the zero time if it has not been measured
+func (a Activity) LastActive() time.Time {
+	if a.Checked.IsZero() {
+		return time.Time{}
+	}
+	return a.Checked.Add(-a.Idle)
+}
+
+// String returns a human-readable description of the host's user sessions (e.g., ACME\jdoe active idle 5m0s)
+func (s UserSession) String() string {
+	desc := strings.TrimSpace(fmt.Sprintf("%s %s", s.User, s.State))
+	if s.Idle > 0 {
+		desc = fmt.Sprintf("%s idle %s", desc, s.Idle)
+	}
+	if !s.Logon.IsZero() {
+		desc = fmt.Sprintf("%s since %s", desc, s.Logon.Format(time.RFC3339))
+	}
+	return desc
+}
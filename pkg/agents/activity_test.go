@@ -0,0 +1,83 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"testing"
+	"time"
+)
+
+func TestParseActivity(t *testing.T) {
+	checked := time.Date(2026, time.January, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		output     string
+		idle       time.Duration
+		lastActive time.Time
+		sessions   []string
+		wantErr    bool
+	}{
+		{"idle", `{"idle":300}`, 5 * time.Minute, checked.Add(-5 * time.Minute), nil, false},
+		{"active", ` {"idle":0,"sessions":[{"user":"ACME\\jdoe","state":"active"}]} `, 0, checked, []string{"ACME\\jdoe active"}, false},
+		{
+			"sessions",
+			`{"idle":60,"sessions":[{"user":"ACME\\jdoe","state":"active","logon":"2026-01-02T08:00:00Z","idle":60},{"user":"ACME\\admin","state":"disconnected","logon":"yesterday","idle":7200}]}`,
+			time.Minute,
+			checked.Add(-time.Minute),
+			[]string{"ACME\\jdoe active idle 1m0s since 2026-01-02T08:00:00Z", "ACME\\admin disconnected idle 2h0m0s"},
+			false,
+		},
+		{"negative idle", `{"idle":-1}`, 0, time.Time{}, nil, true},
+		{"invalid", "not json", 0, time.Time{}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			activity, err := ParseActivity(test.output, checked)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if activity.Idle != test.idle {
+				t.Errorf("expected idle %s, have %s", test.idle, activity.Idle)
+			}
+			if !activity.LastActive().Equal(test.lastActive) {
+				t.Errorf("expected last active %s, have %s", test.lastActive, activity.LastActive())
+			}
+			if len(activity.Sessions) != len(test.sessions) {
+				t.Fatalf("expected %d sessions, have %d", len(test.sessions), len(activity.Sessions))
+			}
+			for i, session := range activity.Sessions {
+				if session.String() != test.sessions[i] {
+					t.Errorf("expected session %q, have %q", test.sessions[i], session.String())
+				}
+			}
+		})
+	}
+}
+
+func TestLastActiveUnmeasured(t *testing.T) {
+	if last := (Activity{Idle: time.Hour}).LastActive(); !last.IsZero() {
+		t.Errorf("expected the zero time for activity that was not measured, have %s", last)
+	}
+}
@@ -37,21 +37,39 @@ import (
 
 // Agent is an aggregate structure that holds information about Agent's the server is communicating with
 type Agent struct {
-	id            uuid.UUID      // id is the Agent's unique identifier
-	alive         bool           // alive indicates if the Agent is alive or if it has been killed or instructed to exit
-	authenticated bool           // Is the agent authenticated?
-	build         Build          // Agent build hash and version number
-	host          Host           // Structure containing information about the host the agent is running on
-	process       Process        // Structure containing information about the process the agent is running in
-	comms         Comms          // Structure containing information about the communication profile the agent is using
-	initial       time.Time      // The first time when the agent initially checked ed
-	checkin       time.Time      // The last time the agent has checked in
-	linkedAgents  []uuid.UUID    // linkedAgents contains a list of first-order peer-to-peer connected agents
-	listener      uuid.UUID      // The listener associated with the agent
-	log           *os.File       // The log used by the agent; Contains a mutex locker and is causing problems
-	secret        []byte         // secret is used to perform symmetric encryption operations
-	opaque        *opaque.Server // Holds information about opaque Registration and Authentication
-	note          string         // Operator notes for an agent
+	id            uuid.UUID         // id is the Agent's unique identifier
+	name          string            // name is the Agent's unique friendly name that can be used instead of its ID
+	alive         bool              // alive indicates if the Agent is alive or if it has been killed or instructed to exit
+	authenticated bool              // Is the agent authenticated?
+	build         Build             // Agent build hash and version number
+	host          Host              // Structure containing information about the host the agent is running on
+	process       Process           // Structure containing information about the process the agent is running in
+	comms         Comms             // Structure containing information about the communication profile the agent is using
+	initial       time.Time         // The first time when the agent initially checked ed
+	checkin       time.Time         // The last time the agent has checked in
+	linkedAgents  []uuid.UUID       // linkedAgents contains a list of first-order peer-to-peer connected agents
+	listener      uuid.UUID         // The listener associated with the agent
+	log           *os.File          // The log used by the agent; Contains a mutex locker and is causing problems
+	secret        []byte            // secret is used to perform symmetric encryption operations
+	opaque        *opaque.Server    // Holds information about opaque Registration and Authentication
+	note          string            // Operator notes for an agent
+	injection     string            // The default process injection technique used when the Agent executes shellcode
+	impersonation string            // The Windows access token the Agent is currently impersonating, if any
+	indicators    []string          // Sandbox, debugger, and EDR indicators the Agent detected on its host during pre-flight
+	products      []string          // Antivirus and EDR products the Agent found installed on its host
+	remoteAddr    string            // The address the Agent's traffic originated from, after accounting for trusted redirectors
+	channels      map[uuid.UUID]int // The number of check-ins the Agent has made on each listener, including fallback channels
+	changes       []Change          // Differences in the Agent's host and process information across check-ins
+	throttle      int               // The bandwidth, in kilobits per second, the Agent's traffic is limited to; 0 is unlimited
+	keyed         time.Time         // When the Agent's current secret key was established
+	keyBytes      int64             // The number of message bytes exchanged with the Agent using its current secret key
+	profile       Profile           // The padding and response delay applied to messages sent to the Agent
+	timezone      string            // The timezone the Agent reported for its host (e.g., America/New_York or -0500)
+	campaign      string            // The campaign identifier embedded in the payload the Agent was built from
+	scope         string            // Why the Agent's host is outside the engagement's scope; empty if it is in scope
+	quarantine    string            // Why the Agent is quarantined and can't be tasked; empty if it is not quarantined
+	refreshed     time.Time         // When the Agent last sent its configuration and host information
+	activity      Activity          // The user input activity and logon sessions the Agent last measured on its host
 }
 
 // NewAgent is a factory to create and return an Agent structure based on the provided inputs
@@ -60,6 +78,9 @@ func NewAgent(id uuid.UUID, secret []byte, opaque *opaque.Server, initial time.T
 	agent.secret = secret
 	agent.opaque = opaque
 	agent.initial = initial
+	if len(secret) > 0 {
+		agent.keyed = time.Now().UTC()
+	}
 
 	agent.log, err = createLogFile(id)
 	if err != nil {
@@ -80,7 +101,8 @@ func createLogFile(id uuid.UUID) (agentLog *os.File, err error) {
 	dir := filepath.Join(current, "data", "agents")
 
 	// Create a directory for the new agent's files
-	if _, err = os.Stat(filepath.Join(dir, id.String())); os.IsNotExist(err) {
+	// The directory can already exist without a log file, such as when the Agent's OPAQUE registration was saved first
+	if _, err = os.Stat(filepath.Join(dir, id.String(), "log.txt")); os.IsNotExist(err) {
 		err = os.MkdirAll(filepath.Join(dir, id.String()), 0750)
 		if err != nil {
 			return nil, fmt.Errorf("pkg/agents.createLogFile(): there was an error creating a directory for agent %s: %s", id, err)
@@ -106,6 +128,11 @@ func createLogFile(id uuid.UUID) (agentLog *os.File, err error) {
 	return
 }
 
+// Activity returns the user input activity and logon sessions the Agent last measured on its host
+func (a *Agent) Activity() Activity {
+	return a.activity
+}
+
 // Alive returns true if the Agent is actively in use and false if the agent has been killed or removed
 func (a *Agent) Alive() bool {
 	return a.alive
@@ -137,6 +164,20 @@ func (a *Agent) Build() Build {
 	return a.build
 }
 
+// Campaign returns the campaign identifier embedded in the payload the Agent was built from
+func (a *Agent) Campaign() string {
+	return a.campaign
+}
+
+// Channels returns the number of check-ins the Agent has made on each listener
+func (a *Agent) Channels() map[uuid.UUID]int {
+	channels := make(map[uuid.UUID]int, len(a.channels))
+	for listener, count := range a.channels {
+		channels[listener] = count
+	}
+	return channels
+}
+
 // Comms returns the Agent's embedded Comms entity structure
 // Contains things like kill date, message padding size, transport protocol, skew, and sleep time
 func (a *Agent) Comms() Comms {
@@ -166,9 +207,25 @@ func (a *Agent) Secret() []byte {
 	return a.secret
 }
 
-// SetSecret updates the Agent's secret key with the provided value
+// SetSecret updates the Agent's secret key with the provided value and restarts the key's age and byte count
 func (a *Agent) SetSecret(secret []byte) {
 	a.secret = secret
+	a.keyed = time.Time{}
+	if len(secret) > 0 {
+		a.keyed = time.Now().UTC()
+	}
+	a.keyBytes = 0
+}
+
+// AddKeyBytes adds to the number of message bytes exchanged with the Agent using its current secret key
+func (a *Agent) AddKeyBytes(n int) {
+	a.keyBytes += int64(n)
+}
+
+// Keyed returns when the Agent's current secret key was established and how many message bytes have been exchanged
+// using it
+func (a *Agent) Keyed() (time.Time, int64) {
+	return a.keyed, a.keyBytes
 }
 
 // OPAQUE returns the Agent's embedded OPAQUE server structure
@@ -186,6 +243,21 @@ func (a *Agent) Padding() int {
 	return a.comms.Padding
 }
 
+// Impersonation returns the Windows access token context the Agent is currently impersonating, if any
+func (a *Agent) Impersonation() string {
+	return a.impersonation
+}
+
+// Indicators returns the sandbox, debugger, and EDR indicators the Agent detected on its host during pre-flight checks
+func (a *Agent) Indicators() []string {
+	return a.indicators
+}
+
+// Injection returns the default process injection technique used when the Agent executes shellcode
+func (a *Agent) Injection() string {
+	return a.injection
+}
+
 // Log write the provided message to the Agent's log file
 func (a *Agent) Log(message string) {
 	_, err := a.log.WriteString(fmt.Sprintf("[%s]%s\r\n", time.Now().UTC().Format(time.RFC3339), message))
@@ -195,6 +267,61 @@ func (a *Agent) Log(message string) {
 	return
 }
 
+// RemoteAddress returns the address the Agent's traffic originated from, after accounting for trusted redirectors
+func (a *Agent) RemoteAddress() string {
+	return a.remoteAddr
+}
+
+// Profile returns the padding and response delay applied to messages sent to the Agent
+func (a *Agent) Profile() Profile {
+	return a.profile
+}
+
+// Name returns the Agent's friendly name that can be used instead of its ID
+func (a *Agent) Name() string {
+	return a.name
+}
+
+// Quarantine returns why the Agent is quarantined, or an empty string if it is not
+func (a *Agent) Quarantine() string {
+	return a.quarantine
+}
+
+// Quarantined returns true if the Agent is quarantined and jobs can't be added to its queue
+func (a *Agent) Quarantined() bool {
+	return a.quarantine != ""
+}
+
+// ScopeViolation returns why the Agent's host is outside the engagement's scope, or an empty string if it is in scope
+func (a *Agent) ScopeViolation() string {
+	return a.scope
+}
+
+// Timezone returns the timezone the Agent reported for its host
+func (a *Agent) Timezone() string {
+	return a.timezone
+}
+
+// Refreshed returns when the Agent last sent its configuration and host information
+func (a *Agent) Refreshed() time.Time {
+	return a.refreshed
+}
+
+// SecurityProducts returns the antivirus and EDR products the Agent found installed on its host
+func (a *Agent) SecurityProducts() []string {
+	return a.products
+}
+
+// Throttle returns the bandwidth, in kilobits per second, the Agent's traffic is limited to; 0 is unlimited
+func (a *Agent) Throttle() int {
+	return a.throttle
+}
+
+// UpdateActivity updates the user input activity and logon sessions the Agent measured on its host
+func (a *Agent) UpdateActivity(activity Activity) {
+	a.activity = activity
+}
+
 // UpdateAlive updates the Agent's alive status to the provided value
 func (a *Agent) UpdateAlive(alive bool) {
 	a.alive = alive
@@ -210,6 +337,22 @@ func (a *Agent) UpdateBuild(build Build) {
 	a.build = build
 }
 
+// UpdateCampaign updates the campaign identifier embedded in the payload the Agent was built from
+func (a *Agent) UpdateCampaign(campaign string) {
+	a.campaign = campaign
+}
+
+// UpdateChannel records that the Agent checked in on the provided listener
+func (a *Agent) UpdateChannel(listener uuid.UUID) {
+	// Copy the map instead of modifying it in place because copies of the Agent returned by the repository share it
+	channels := make(map[uuid.UUID]int, len(a.channels)+1)
+	for l, count := range a.channels {
+		channels[l] = count
+	}
+	channels[listener]++
+	a.channels = channels
+}
+
 // UpdateComms updates the Agent's embedded Comms entity structure with the provided structure
 func (a *Agent) UpdateComms(comms Comms) {
 	a.comms = comms
@@ -220,11 +363,51 @@ func (a *Agent) UpdateHost(host Host) {
 	a.host = host
 }
 
+// UpdateImpersonation updates the Windows access token context the Agent is impersonating
+func (a *Agent) UpdateImpersonation(impersonation string) {
+	a.impersonation = impersonation
+}
+
+// UpdateIndicators updates the sandbox, debugger, and EDR indicators the Agent detected on its host
+func (a *Agent) UpdateIndicators(indicators []string) {
+	a.indicators = indicators
+}
+
+// UpdateSecurityProducts updates the antivirus and EDR products the Agent found installed on its host
+func (a *Agent) UpdateSecurityProducts(products []string) {
+	a.products = products
+}
+
+// UpdateName updates the Agent's friendly name
+func (a *Agent) UpdateName(name string) {
+	a.name = name
+}
+
+// UpdateQuarantine quarantines the Agent for the provided reason, an empty reason releases it
+func (a *Agent) UpdateQuarantine(reason string) {
+	a.quarantine = reason
+}
+
+// UpdateScopeViolation updates why the Agent's host is outside the engagement's scope
+func (a *Agent) UpdateScopeViolation(violation string) {
+	a.scope = violation
+}
+
+// UpdateRemoteAddress updates the address the Agent's traffic originated from
+func (a *Agent) UpdateRemoteAddress(addr string) {
+	a.remoteAddr = addr
+}
+
 // UpdateInitial updates the time stamp for when the Agent was first seen
 func (a *Agent) UpdateInitial(initial time.Time) {
 	a.initial = initial
 }
 
+// UpdateInjection updates the default process injection technique used when the Agent executes shellcode
+func (a *Agent) UpdateInjection(method string) {
+	a.injection = method
+}
+
 // UpdateListener updates the listener ID the Agent belongs to
 func (a *Agent) UpdateListener(listener uuid.UUID) {
 	a.listener = listener
@@ -250,6 +433,26 @@ func (a *Agent) UpdateStatusCheckin(checkin time.Time) {
 	a.checkin = checkin
 }
 
+// UpdateProfile updates the padding and response delay applied to messages sent to the Agent
+func (a *Agent) UpdateProfile(profile Profile) {
+	a.profile = profile
+}
+
+// UpdateTimezone updates the timezone the Agent reported for its host
+func (a *Agent) UpdateTimezone(timezone string) {
+	a.timezone = timezone
+}
+
+// UpdateRefreshed updates when the Agent last sent its configuration and host information
+func (a *Agent) UpdateRefreshed(refreshed time.Time) {
+	a.refreshed = refreshed
+}
+
+// UpdateThrottle updates the bandwidth, in kilobits per second, the Agent's traffic is limited to; 0 is unlimited
+func (a *Agent) UpdateThrottle(kbps int) {
+	a.throttle = kbps
+}
+
 // StatusCheckin returns a time stamp of when the agent last checked in
 func (a *Agent) StatusCheckin() time.Time {
 	return a.checkin
@@ -267,14 +470,17 @@ func (a *Agent) Links() []uuid.UUID {
 
 // AddLink adds a new child Agent to the list of linked Agents
 func (a *Agent) AddLink(link uuid.UUID) {
-	a.linkedAgents = append(a.linkedAgents, link)
+	// Build a new slice because copies of the Agent returned by the repository share the backing array
+	a.linkedAgents = append(a.linkedAgents[:len(a.linkedAgents):len(a.linkedAgents)], link)
 }
 
 // RemoveLink deletes the child Agent link from the list of linked Agents
 func (a *Agent) RemoveLink(link uuid.UUID) {
-	for i, agent := range a.linkedAgents {
-		if agent == link {
-			a.linkedAgents = append(a.linkedAgents[:i], a.linkedAgents[i+1:]...)
+	var links []uuid.UUID
+	for _, agent := range a.linkedAgents {
+		if agent != link {
+			links = append(links, agent)
 		}
 	}
+	a.linkedAgents = links
 }
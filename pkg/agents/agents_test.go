@@ -0,0 +1,107 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"os"
+	"path/filepath"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+func TestChannels(t *testing.T) {
+	primary := uuid.New()
+	fallback := uuid.New()
+	tests := []struct {
+		name     string
+		checkins []uuid.UUID
+		want     map[uuid.UUID]int
+	}{
+		{"none", nil, map[uuid.UUID]int{}},
+		{"primary", []uuid.UUID{primary, primary}, map[uuid.UUID]int{primary: 2}},
+		{"fallback", []uuid.UUID{primary, fallback, fallback, primary, fallback}, map[uuid.UUID]int{primary: 2, fallback: 3}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var a Agent
+			for _, listener := range test.checkins {
+				a.UpdateChannel(listener)
+			}
+			channels := a.Channels()
+			if len(channels) != len(test.want) {
+				t.Fatalf("expected %v, have %v", test.want, channels)
+			}
+			for listener, count := range test.want {
+				if channels[listener] != count {
+					t.Errorf("expected %d check-ins on %s, have %d", count, listener, channels[listener])
+				}
+			}
+			// The returned map is a copy
+			channels[uuid.New()] = 1
+			if len(a.Channels()) != len(test.want) {
+				t.Error("modifying the returned channels changed the Agent")
+			}
+		})
+	}
+}
+
+// TestCreateLogFile verifies the Agent's log file is created even when its directory already exists
+func TestCreateLogFile(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	tests := []struct {
+		name   string
+		exists bool
+	}{
+		{"new directory", false},
+		{"existing directory", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := uuid.New()
+			dir := filepath.Join("data", "agents", id.String())
+			if test.exists {
+				if err := os.MkdirAll(dir, 0750); err != nil {
+					t.Fatal(err)
+				}
+			}
+			f, err := createLogFile(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = f.Close()
+			if _, err = os.Stat(filepath.Join(dir, "log.txt")); err != nil {
+				t.Errorf("expected the log file to be created: %s", err)
+			}
+		})
+	}
+}
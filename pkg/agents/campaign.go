@@ -0,0 +1,51 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// campaignPattern restricts campaign identifiers to values that are safe to embed in a payload's build flags
+var campaignPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Campaign is the callback statistics for every Agent built from payloads with the same campaign identifier, such as
+// a single wave of a phishing exercise
+type Campaign struct {
+	ID     string    // ID is the campaign identifier embedded in the payloads
+	Agents int       // Agents is the number of Agents that checked in
+	Alive  int       // Alive is the number of Agents that have not exited or been killed
+	Hosts  int       // Hosts is the number of unique hosts the Agents are running on
+	Users  int       // Users is the number of unique users the Agents are running as
+	First  time.Time // First is when the first Agent initially checked in
+	Last   time.Time // Last is when the most recent Agent initially checked in
+}
+
+// ValidateCampaign ensures a campaign identifier is 1 to 64 letters, numbers, periods, underscores, or hyphens
+func ValidateCampaign(campaign string) error {
+	if !campaignPattern.MatchString(campaign) {
+		return fmt.Errorf("the campaign identifier '%s' must be 1 to 64 letters, numbers, periods, underscores, or hyphens", campaign)
+	}
+	return nil
+}
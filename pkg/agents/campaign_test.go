@@ -0,0 +1,83 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"os"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+func TestValidateCampaign(t *testing.T) {
+	tests := []struct {
+		name     string
+		campaign string
+		wantErr  bool
+	}{
+		{"letters and numbers", "Q3wave2", false},
+		{"punctuation", "hr-benefits_2024.v1", false},
+		{"maximum length", strings.Repeat("a", 64), false},
+		{"empty", "", true},
+		{"too long", strings.Repeat("a", 65), true},
+		{"space", "wave 1", true},
+		{"build flag injection", "x -X main.url=https://evil", true},
+		{"quote", `wave"1`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCampaign(test.campaign)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestSessionCampaign verifies the campaign identifier is carried in exported sessions
+func TestSessionCampaign(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	a := Agent{id: uuid.New()}
+	a.UpdateCampaign("wave1")
+	session := a.Session()
+	if session.Campaign != "wave1" {
+		t.Fatalf("expected the session campaign wave1, have %q", session.Campaign)
+	}
+	imported, err := NewAgentFromSession(session, uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.Campaign() != "wave1" {
+		t.Errorf("expected the imported Agent campaign wave1, have %q", imported.Campaign())
+	}
+}
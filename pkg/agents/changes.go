@@ -0,0 +1,116 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxChanges is the number of changes kept for each Agent; the oldest changes are dropped first
+const maxChanges = 1000
+
+// Change is a difference in an Agent's host or process information between two check-ins
+type Change struct {
+	Time    time.Time // When the change was detected
+	Field   string    // The name of the information that changed (e.g., Username)
+	Old     string    // The previous value
+	New     string    // The current value
+	Notable bool      // Notable changes, like a privilege increase or a move to another network, alert operators
+}
+
+// Diff returns the differences between the Agent's previous and current host and process information
+func Diff(oldHost Host, oldProcess Process, host Host, process Process) (changes []Change) {
+	now := time.Now().UTC()
+	add := func(field, old, current string, notable bool) {
+		if old != current {
+			changes = append(changes, Change{Time: now, Field: field, Old: old, New: current, Notable: notable})
+		}
+	}
+	add("Hostname", oldHost.Name, host.Name, true)
+	add("Platform", oldHost.Platform, host.Platform, false)
+	add("Architecture", oldHost.Architecture, host.Architecture, false)
+	add("IPs", strings.Join(oldHost.IPs, ","), strings.Join(host.IPs, ","), movedNetworks(oldHost.IPs, host.IPs))
+	add("Process", oldProcess.Name, process.Name, false)
+	add("PID", fmt.Sprintf("%d", oldProcess.ID), fmt.Sprintf("%d", process.ID), false)
+	add("Username", oldProcess.UserName, process.UserName, true)
+	add("Domain", oldProcess.Domain, process.Domain, false)
+	add("Integrity", fmt.Sprintf("%d", oldProcess.Integrity), fmt.Sprintf("%d", process.Integrity), process.Integrity > oldProcess.Integrity)
+	return
+}
+
+// movedNetworks determines if none of the host's current networks were among its previous networks.
+// Loopback and link-local addresses are ignored
+func movedNetworks(old, current []string) bool {
+	before := networks(old)
+	after := networks(current)
+	if len(before) == 0 || len(after) == 0 {
+		return false
+	}
+	for _, network := range after {
+		if slices.Contains(before, network) {
+			return false
+		}
+	}
+	return true
+}
+
+// networks returns the networks of a list of IP addresses, with or without a CIDR prefix
+func networks(ips []string) (list []string) {
+	for _, ip := range ips {
+		addr, network, err := net.ParseCIDR(strings.TrimSpace(ip))
+		if err != nil {
+			addr = net.ParseIP(strings.TrimSpace(ip))
+			if addr == nil {
+				continue
+			}
+			bits := 8 * len(addr.To16())
+			if addr.To4() != nil {
+				bits = 32
+			}
+			network = &net.IPNet{IP: addr, Mask: net.CIDRMask(bits, bits)}
+		}
+		if addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+			continue
+		}
+		list = append(list, network.String())
+	}
+	return
+}
+
+// Changes returns the differences detected in the Agent's host and process information across check-ins
+func (a *Agent) Changes() []Change {
+	return a.changes
+}
+
+// AddChanges records differences detected in the Agent's host and process information
+func (a *Agent) AddChanges(changes []Change) {
+	// Build a new slice because copies of the Agent returned by the repository share the backing array
+	all := append(a.changes[:len(a.changes):len(a.changes)], changes...)
+	if len(all) > maxChanges {
+		all = all[len(all)-maxChanges:]
+	}
+	a.changes = all
+}
@@ -0,0 +1,119 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"fmt"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	host := Host{Name: "WKSTN-1", Platform: "windows", Architecture: "amd64", IPs: []string{"10.0.0.5/24", "127.0.0.1/8"}}
+	process := Process{ID: 1234, Name: "merlin.exe", UserName: "ACME\\jdoe", Domain: "ACME", Integrity: 2}
+
+	tests := []struct {
+		name    string
+		host    func(h *Host)
+		process func(p *Process)
+		field   string
+		notable bool
+	}{
+		{"no change", nil, nil, "", false},
+		{"hostname", func(h *Host) { h.Name = "WKSTN-2" }, nil, "Hostname", true},
+		{"same network", func(h *Host) { h.IPs = []string{"10.0.0.5/24", "10.0.0.6/24"} }, nil, "IPs", false},
+		{"moved networks", func(h *Host) { h.IPs = []string{"192.168.1.5/24"} }, nil, "IPs", true},
+		{"only loopback", func(h *Host) { h.IPs = []string{"127.0.0.1/8"} }, nil, "IPs", false},
+		{"process", nil, func(p *Process) { p.Name = "explorer.exe" }, "Process", false},
+		{"username", nil, func(p *Process) { p.UserName = "NT AUTHORITY\\SYSTEM" }, "Username", true},
+		{"integrity increased", nil, func(p *Process) { p.Integrity = 3 }, "Integrity", true},
+		{"integrity decreased", nil, func(p *Process) { p.Integrity = 1 }, "Integrity", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newHost, newProcess := host, process
+			if test.host != nil {
+				test.host(&newHost)
+			}
+			if test.process != nil {
+				test.process(&newProcess)
+			}
+			changes := Diff(host, process, newHost, newProcess)
+			if test.field == "" {
+				if len(changes) != 0 {
+					t.Errorf("expected no changes, have %+v", changes)
+				}
+				return
+			}
+			if len(changes) != 1 {
+				t.Fatalf("expected 1 change, have %+v", changes)
+			}
+			if changes[0].Field != test.field || changes[0].Notable != test.notable {
+				t.Errorf("expected a %s change with notable %t, have %+v", test.field, test.notable, changes[0])
+			}
+		})
+	}
+}
+
+func TestMovedNetworks(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     []string
+		current []string
+		moved   bool
+	}{
+		{"same address", []string{"10.0.0.5"}, []string{"10.0.0.5"}, false},
+		{"same CIDR network", []string{"10.0.0.5/24"}, []string{"10.0.0.9/24"}, false},
+		{"different network", []string{"10.0.0.5/24"}, []string{"10.1.0.5/24"}, true},
+		{"joined a network", []string{"10.0.0.5/24"}, []string{"10.0.0.5/24", "172.16.0.5/16"}, false},
+		{"link-local ignored", []string{"fe80::1/64"}, []string{"10.0.0.5/24"}, false},
+		{"no previous networks", nil, []string{"10.0.0.5/24"}, false},
+		{"invalid ignored", []string{"merlin"}, []string{"10.0.0.5/24"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if moved := movedNetworks(test.old, test.current); moved != test.moved {
+				t.Errorf("expected moved to be %t, have %t", test.moved, moved)
+			}
+		})
+	}
+}
+
+// TestAddChanges verifies only the most recent changes are kept and copies of the Agent don't share their history
+func TestAddChanges(t *testing.T) {
+	var a Agent
+	for i := 0; i < maxChanges+10; i++ {
+		a.AddChanges([]Change{{Field: "PID", New: fmt.Sprintf("%d", i)}})
+	}
+	if len(a.Changes()) != maxChanges {
+		t.Fatalf("expected %d changes, have %d", maxChanges, len(a.Changes()))
+	}
+	if first := a.Changes()[0].New; first != "10" {
+		t.Errorf("expected the oldest changes to be dropped, the first change is %s", first)
+	}
+
+	b := a
+	b.AddChanges([]Change{{Field: "Hostname"}})
+	a.AddChanges([]Change{{Field: "Username"}})
+	if last := b.Changes()[len(b.Changes())-1].Field; last != "Hostname" {
+		t.Errorf("expected the copy's last change to be Hostname, have %s", last)
+	}
+}
@@ -42,7 +42,7 @@ var (
 type Repository struct {
 	// Don't use pointers because this is map is the source and should only be modified here in the repository
 	agents map[uuid.UUID]agents.Agent
-	sync.Mutex
+	sync.RWMutex
 }
 
 // repo is the in-memory database
@@ -55,41 +55,42 @@ func NewRepository() *Repository {
 
 // Add locks the in-memory database and adds Agent structures to the map
 func (r *Repository) Add(agent agents.Agent) error {
-	if !r.Exists(agent.ID()) {
-		r.Lock()
-		r.agents[agent.ID()] = agent
-		r.Unlock()
-		return nil
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.agents[agent.ID()]; ok {
+		return ErrAgentExists
 	}
-	return ErrAgentExists
+	r.agents[agent.ID()] = agent
+	return nil
+}
+
+// AddChanges records differences detected in the Agent's host and process information
+func (r *Repository) AddChanges(id uuid.UUID, changes []agents.Change) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.AddChanges(changes)
+	})
 }
 
 // AddLinkedAgent updates the Agent's linkedAgents list the contains all child agents for which it is the parent
 func (r *Repository) AddLinkedAgent(id uuid.UUID, link uuid.UUID) error {
-	agent, err := r.Get(id)
-	if err != nil {
-		return err
-	}
-	agent.AddLink(link)
-	r.Lock()
-	r.agents[id] = agent
-	r.Unlock()
-	return nil
+	return r.update(id, func(agent *agents.Agent) {
+		agent.AddLink(link)
+	})
 }
 
 // Exists check's to see if the Agent is in the repository
 func (r *Repository) Exists(id uuid.UUID) bool {
-	for a := range r.agents {
-		if a == id {
-			return true
-		}
-	}
-	return false
+	r.RLock()
+	defer r.RUnlock()
+	_, ok := r.agents[id]
+	return ok
 }
 
 // Get returns a COPY of the Agent entity. The caller should not try to modify the copy as it won't be updated
 // in the repository
 func (r *Repository) Get(id uuid.UUID) (agents.Agent, error) {
+	r.RLock()
+	defer r.RUnlock()
 	agent, ok := r.agents[id]
 	if ok {
 		return agent, nil
@@ -99,201 +100,255 @@ func (r *Repository) Get(id uuid.UUID) (agents.Agent, error) {
 
 // GetAll returns a list of all Agents in the repository
 func (r *Repository) GetAll() (agents []agents.Agent) {
-	r.Lock()
+	r.RLock()
+	defer r.RUnlock()
 	for _, agent := range r.agents {
 		agents = append(agents, agent)
 	}
-	r.Unlock()
 	return
 }
 
 // Remove deletes the agent from the repository
 func (r *Repository) Remove(id uuid.UUID) (err error) {
-	if r.Exists(id) {
-		r.Lock()
-		delete(r.agents, id)
-		r.Unlock()
-		return nil
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.agents[id]; !ok {
+		return ErrAgentNotFound
 	}
-	return ErrAgentNotFound
+	delete(r.agents, id)
+	return nil
 }
 
 // RemoveLinkedAgent removed the provided link the Agent's linkedAgents list the contains all child agents for which it is the parent
 func (r *Repository) RemoveLinkedAgent(id uuid.UUID, link uuid.UUID) error {
-	agent, err := r.Get(id)
-	if err != nil {
-		return err
-	}
-	agent.RemoveLink(link)
-	r.Lock()
-	r.agents[id] = agent
-	r.Unlock()
-	return nil
+	return r.update(id, func(agent *agents.Agent) {
+		agent.RemoveLink(link)
+	})
+}
+
+// AddKeyBytes adds to the number of message bytes exchanged with the Agent using its current secret key
+func (r *Repository) AddKeyBytes(id uuid.UUID, n int) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.AddKeyBytes(n)
+	})
 }
 
 // SetSecret updates the agent's secret key, typically derived once authentication has completed and per-agent key has
 // been established.
 func (r *Repository) SetSecret(id uuid.UUID, secret []byte) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.SetSecret(secret)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
 }
 
 // Update replaces the Agent in the repository with the one provided in the function call
 func (r *Repository) Update(agent agents.Agent) error {
-	if r.Exists(agent.ID()) {
-		r.Lock()
-		r.agents[agent.ID()] = agent
-		r.Unlock()
-		return nil
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.agents[agent.ID()]; !ok {
+		return ErrAgentNotFound
 	}
-	return ErrAgentNotFound
+	r.agents[agent.ID()] = agent
+	return nil
+}
+
+// UpdateActivity updates the user input activity and logon sessions the Agent measured on its host
+func (r *Repository) UpdateActivity(id uuid.UUID, activity agents.Activity) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateActivity(activity)
+	})
 }
 
 // UpdateAlive updates the Agent's alive field to indicate if it is actively in use or not
 func (r *Repository) UpdateAlive(id uuid.UUID, alive bool) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateAlive(alive)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
 }
 
 // UpdateAuthenticated updates that Agent's authenticated field, typically once authentication has completed
 func (r *Repository) UpdateAuthenticated(id uuid.UUID, authenticated bool) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateAuthenticated(authenticated)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
 }
 
 // UpdateBuild updates the Agent's build field with the provided Build entity structure
 func (r *Repository) UpdateBuild(id uuid.UUID, build agents.Build) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateBuild(build)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
 }
 
 // UpdateComms updates the Agent's comms field with the provided Comms entity structure
 func (r *Repository) UpdateComms(id uuid.UUID, comms agents.Comms) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateComms(comms)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
 }
 
 // UpdateHost updates the Agent's host field with the provided Host entity structure
 func (r *Repository) UpdateHost(id uuid.UUID, host agents.Host) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateHost(host)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
+}
+
+// UpdateImpersonation updates the Agent's impersonated Windows access token context
+func (r *Repository) UpdateImpersonation(id uuid.UUID, impersonation string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateImpersonation(impersonation)
+	})
+}
+
+// UpdateIndicators updates the sandbox, debugger, and EDR indicators the Agent detected on its host
+func (r *Repository) UpdateIndicators(id uuid.UUID, indicators []string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateIndicators(indicators)
+	})
+}
+
+// UpdateSecurityProducts updates the antivirus and EDR products the Agent found installed on its host
+func (r *Repository) UpdateSecurityProducts(id uuid.UUID, products []string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateSecurityProducts(products)
+	})
+}
+
+// UpdateRemoteAddress updates the address the Agent's traffic originated from
+func (r *Repository) UpdateRemoteAddress(id uuid.UUID, addr string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateRemoteAddress(addr)
+	})
 }
 
 // UpdateInitial updates the Agent's initial field with the provided timestamp
 func (r *Repository) UpdateInitial(id uuid.UUID, t time.Time) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateInitial(t)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
+}
+
+// UpdateInjection updates the Agent's default process injection technique
+func (r *Repository) UpdateInjection(id uuid.UUID, method string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateInjection(method)
+	})
 }
 
 // UpdateListener updates the ID of the listener the Agent is associated with
 func (r *Repository) UpdateListener(id, listener uuid.UUID) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateListener(listener)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
 }
 
 // UpdateProcess updates the Agent's process field with the provided Process entity structure
 func (r *Repository) UpdateProcess(id uuid.UUID, process agents.Process) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateProcess(process)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
 }
 
 // UpdateNote updates the Agent's note field with the provided string
 func (r *Repository) UpdateNote(id uuid.UUID, note string) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateNote(note)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
+}
+
+// UpdateChannel records that the Agent checked in on the provided listener
+func (r *Repository) UpdateChannel(id, listener uuid.UUID) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateChannel(listener)
+	})
 }
 
 // UpdateStatusCheckin updates the Agent's last checkin field with the provided timestamp
 func (r *Repository) UpdateStatusCheckin(id uuid.UUID, t time.Time) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
+	return r.update(id, func(agent *agents.Agent) {
 		agent.UpdateStatusCheckin(t)
-		r.agents[id] = agent
-		r.Unlock()
-		return nil
-	}
-	return ErrAgentNotFound
+	})
+}
+
+// UpdateProfile updates the padding and response delay applied to messages sent to the Agent
+func (r *Repository) UpdateProfile(id uuid.UUID, profile agents.Profile) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateProfile(profile)
+	})
+}
+
+// UpdateCampaign updates the campaign identifier embedded in the payload the Agent was built from
+func (r *Repository) UpdateCampaign(id uuid.UUID, campaign string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateCampaign(campaign)
+	})
+}
+
+// UpdateName updates the Agent's friendly name
+func (r *Repository) UpdateName(id uuid.UUID, name string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateName(name)
+	})
+}
+
+// UpdateQuarantine quarantines the Agent for the provided reason, an empty reason releases it
+func (r *Repository) UpdateQuarantine(id uuid.UUID, reason string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateQuarantine(reason)
+	})
+}
+
+// UpdateScopeViolation updates why the Agent's host is outside the engagement's scope
+func (r *Repository) UpdateScopeViolation(id uuid.UUID, violation string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateScopeViolation(violation)
+	})
+}
+
+// UpdateTimezone updates the timezone the Agent reported for its host
+func (r *Repository) UpdateTimezone(id uuid.UUID, timezone string) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateTimezone(timezone)
+	})
+}
+
+// UpdateRefreshed updates when the Agent last sent its configuration and host information
+func (r *Repository) UpdateRefreshed(id uuid.UUID, refreshed time.Time) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateRefreshed(refreshed)
+	})
+}
+
+// UpdateThrottle updates the bandwidth, in kilobits per second, the Agent's traffic is limited to
+func (r *Repository) UpdateThrottle(id uuid.UUID, kbps int) error {
+	return r.update(id, func(agent *agents.Agent) {
+		agent.UpdateThrottle(kbps)
+	})
 }
 
 // Log writes the provided message to the Agent's log file
 func (r *Repository) Log(id uuid.UUID, message string) error {
-	if r.Exists(id) {
-		r.Lock()
-		agent := r.agents[id]
-		agent.Log(message)
-		r.Unlock()
-		return nil
+	r.RLock()
+	agent, ok := r.agents[id]
+	r.RUnlock()
+	if !ok {
+		return ErrAgentNotFound
 	}
-	return ErrAgentNotFound
+	agent.Log(message)
+	return nil
+}
+
+// update locks the repository, applies the change to the stored Agent, and saves it. The existence check and the update
+// happen under the same lock so that an Agent removed by another goroutine is not re-added
+func (r *Repository) update(id uuid.UUID, change func(agent *agents.Agent)) error {
+	r.Lock()
+	defer r.Unlock()
+	agent, ok := r.agents[id]
+	if !ok {
+		return ErrAgentNotFound
+	}
+	change(&agent)
+	r.agents[id] = agent
+	return nil
 }
@@ -0,0 +1,209 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	// Standard
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// newAgent creates an Agent whose log file is written to a temporary directory and adds it to the repository
+func newAgent(tb testing.TB, r *Repository) agents.Agent {
+	tb.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	err = os.Chdir(tb.TempDir())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer os.Chdir(current)
+
+	agent, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	err = r.Add(agent)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		_ = r.Remove(agent.ID())
+	})
+	return agent
+}
+
+// TestRepositoryConcurrentAccess reads and updates the same Agents from many goroutines, the way the HTTP handlers do.
+// Run with the -race flag to detect data races
+func TestRepositoryConcurrentAccess(t *testing.T) {
+	r := NewRepository()
+	var ids []uuid.UUID
+	for i := 0; i < 5; i++ {
+		agent := newAgent(t, r)
+		ids = append(ids, agent.ID())
+	}
+	listener := uuid.New()
+
+	const workers = 20
+	const iterations = 100
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				id := ids[(w+i)%len(ids)]
+				switch i % 5 {
+				case 0:
+					if err := r.UpdateChannel(id, listener); err != nil {
+						t.Error(err)
+					}
+				case 1:
+					if err := r.UpdateStatusCheckin(id, time.Now()); err != nil {
+						t.Error(err)
+					}
+				case 2:
+					link := uuid.New()
+					if err := r.AddLinkedAgent(id, link); err != nil {
+						t.Error(err)
+					}
+					if err := r.RemoveLinkedAgent(id, link); err != nil {
+						t.Error(err)
+					}
+				case 3:
+					agent, err := r.Get(id)
+					if err != nil {
+						t.Error(err)
+					}
+					_ = agent.Channels()
+					_ = agent.Links()
+				case 4:
+					for _, agent := range r.GetAll() {
+						_ = agent.Channels()
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// Every UpdateChannel call must have been recorded
+	var total int
+	for _, id := range ids {
+		agent, err := r.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total += agent.Channels()[listener]
+		if len(agent.Links()) != 0 {
+			t.Errorf("expected Agent %s to have no links, have %d", id, len(agent.Links()))
+		}
+	}
+	if want := workers * iterations / 5; total != want {
+		t.Errorf("expected %d channel check-ins, have %d", want, total)
+	}
+}
+
+// TestRepositoryUpdateRemoved ensures updating an Agent that was removed does not add it back to the repository
+func TestRepositoryUpdateRemoved(t *testing.T) {
+	r := NewRepository()
+	agent := newAgent(t, r)
+	if err := r.Remove(agent.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdateAlive(agent.ID(), true); err != ErrAgentNotFound {
+		t.Errorf("expected %s, have %v", ErrAgentNotFound, err)
+	}
+	if r.Exists(agent.ID()) {
+		t.Errorf("Agent %s was added back to the repository", agent.ID())
+	}
+}
+
+// TestRepositoryUpdateRefreshed verifies when the Agent last sent its information is stored and unknown Agents are rejected
+func TestRepositoryUpdateRefreshed(t *testing.T) {
+	r := NewRepository()
+	agent := newAgent(t, r)
+	refreshed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		wantErr error
+	}{
+		{"existing agent", agent.ID(), nil},
+		{"unknown agent", uuid.New(), ErrAgentNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := r.UpdateRefreshed(test.id, refreshed); err != test.wantErr {
+				t.Fatalf("expected %v, have %v", test.wantErr, err)
+			}
+			if test.wantErr != nil {
+				return
+			}
+			a, err := r.Get(test.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !a.Refreshed().Equal(refreshed) {
+				t.Errorf("expected %s, have %s", refreshed, a.Refreshed())
+			}
+		})
+	}
+}
+
+// BenchmarkRepositoryGet measures concurrent Agent lookups
+func BenchmarkRepositoryGet(b *testing.B) {
+	r := NewRepository()
+	agent := newAgent(b, r)
+	id := agent.ID()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = r.Get(id)
+		}
+	})
+}
+
+// BenchmarkRepositoryCheckin measures the concurrent reads and updates an Agent check-in makes
+func BenchmarkRepositoryCheckin(b *testing.B) {
+	r := NewRepository()
+	agent := newAgent(b, r)
+	id := agent.ID()
+	listener := uuid.New()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = r.Get(id)
+			_ = r.UpdateStatusCheckin(id, time.Now())
+			_ = r.UpdateChannel(id, listener)
+		}
+	})
+}
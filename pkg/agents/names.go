@@ -0,0 +1,109 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Agent naming schemes used to automatically assign friendly names to Agents
+const (
+	// NamingWords names Agents with a random adjective and noun (e.g., brave-falcon)
+	NamingWords = "words"
+	// NamingHost names Agents after the host and user they are running as (e.g., wks01-jdoe)
+	NamingHost = "host"
+	// NamingNone does not automatically name Agents
+	NamingNone = "none"
+)
+
+// namePattern restricts Agent names to values that are easy to type and can't be confused with flags or arguments
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// nameInvalid removes characters that are not allowed in Agent names
+var nameInvalid = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+var adjectives = []string{
+	"amber", "ancient", "bold", "brave", "bright", "calm", "clever", "crimson", "dusty", "eager", "fading", "fierce",
+	"frosty", "gentle", "golden", "hidden", "hollow", "icy", "jade", "lively", "lone", "lucky", "misty", "noble",
+	"patient", "quiet", "rapid", "restless", "rusty", "silent", "silver", "sly", "steady", "stormy", "swift", "tidy",
+	"velvet", "wandering", "wild", "wise",
+}
+
+var nouns = []string{
+	"badger", "beacon", "cedar", "comet", "condor", "coyote", "falcon", "ferret", "fox", "glacier", "harbor", "hawk",
+	"heron", "jackal", "lantern", "lynx", "maple", "marmot", "meadow", "mongoose", "otter", "owl", "panther", "pebble",
+	"raven", "reef", "river", "sparrow", "spruce", "summit", "thistle", "tiger", "tundra", "viper", "walrus", "willow",
+	"wolf", "wren", "yak", "zephyr",
+}
+
+// ValidateName ensures an Agent name is 1 to 64 lowercase letters, numbers, periods, underscores, or hyphens that
+// starts with a letter or number and isn't a UUID, so that it can be used anywhere an Agent ID is accepted
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("the agent name '%s' must be 1 to 64 lowercase letters, numbers, periods, underscores, or hyphens that starts with a letter or number", name)
+	}
+	if _, err := uuid.Parse(name); err == nil {
+		return fmt.Errorf("the agent name '%s' can't be a UUID", name)
+	}
+	return nil
+}
+
+// Name returns a friendly name for the Agent using the provided naming scheme, or an empty string if the scheme can't
+// name the Agent yet (e.g., the host scheme before the Agent sent its host information)
+func Name(scheme string, host Host, process Process) string {
+	switch scheme {
+	case NamingWords:
+		return fmt.Sprintf("%s-%s", pick(adjectives), pick(nouns))
+	case NamingHost:
+		if host.Name == "" {
+			return ""
+		}
+		hostname := strings.Split(strings.ToLower(host.Name), ".")[0]
+		// Drop the domain from Windows (DOMAIN\user) and UPN (user@domain) usernames
+		user := strings.ToLower(process.UserName)
+		user = user[strings.LastIndex(user, "\\")+1:]
+		user = strings.Split(user, "@")[0]
+		name := strings.Trim(nameInvalid.ReplaceAllString(hostname+"-"+user, "-"), "-._")
+		if len(name) > 56 {
+			name = name[:56]
+		}
+		return name
+	default:
+		return ""
+	}
+}
+
+// pick returns a random word from the list
+func pick(words []string) string {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(words))))
+	if err != nil {
+		return words[0]
+	}
+	return words[n.Int64()]
+}
@@ -0,0 +1,84 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"regexp"
+	"testing"
+)
+
+// TestValidateName verifies Agent names are restricted to easily typed values that aren't UUIDs
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		agent   string
+		wantErr bool
+	}{
+		{"words", "brave-falcon", false},
+		{"host", "wks01-jdoe.2", false},
+		{"empty", "", true},
+		{"uppercase", "Brave-Falcon", true},
+		{"leading hyphen", "-falcon", true},
+		{"space", "brave falcon", true},
+		{"too long", "a234567890123456789012345678901234567890123456789012345678901234x", true},
+		{"UUID", "c1090dbc-f2f7-4d90-a241-86e0c0217786", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateName(test.agent)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestName verifies each naming scheme produces a valid name, or none when it can't name the Agent yet
+func TestName(t *testing.T) {
+	tests := []struct {
+		name    string
+		scheme  string
+		host    Host
+		process Process
+		want    string
+	}{
+		{"host", NamingHost, Host{Name: "WKS01.corp.example.com"}, Process{UserName: "CORP\\JDoe"}, "wks01-jdoe"},
+		{"host UPN", NamingHost, Host{Name: "wks01"}, Process{UserName: "jdoe@corp.example.com"}, "wks01-jdoe"},
+		{"host invalid characters", NamingHost, Host{Name: "WKS 01"}, Process{UserName: "j doe$"}, "wks-01-j-doe"},
+		{"host unknown", NamingHost, Host{}, Process{UserName: "jdoe"}, ""},
+		{"none", NamingNone, Host{Name: "wks01"}, Process{UserName: "jdoe"}, ""},
+		{"unknown scheme", "colors", Host{Name: "wks01"}, Process{UserName: "jdoe"}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if have := Name(test.scheme, test.host, test.process); have != test.want {
+				t.Errorf("expected %q, have %q", test.want, have)
+			}
+		})
+	}
+
+	words := regexp.MustCompile(`^[a-z]+-[a-z]+$`)
+	name := Name(NamingWords, Host{}, Process{})
+	if !words.MatchString(name) || ValidateName(name) != nil {
+		t.Errorf("expected an adjective-noun name, have %q", name)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+)
+
+// Normalized privilege levels
+const (
+	PrivilegeUser   = "user"   // An unprivileged user
+	PrivilegeAdmin  = "admin"  // A Windows administrator running in a high integrity process
+	PrivilegeSystem = "SYSTEM" // The Windows local system account
+	PrivilegeRoot   = "root"   // The superuser on Linux, macOS, and other Unix-like systems
+)
+
+// Windows integrity levels as reported by the Agent
+const (
+	IntegrityUntrusted = 0
+	IntegrityLow       = 1
+	IntegrityMedium    = 2
+	IntegrityHigh      = 3
+	IntegritySystem    = 4
+)
+
+// IntegrityLevel returns the name of a Windows integrity level
+func IntegrityLevel(level int) string {
+	switch level {
+	case IntegrityUntrusted:
+		return "Untrusted"
+	case IntegrityLow:
+		return "Low"
+	case IntegrityMedium:
+		return "Medium"
+	case IntegrityHigh:
+		return "High"
+	case IntegritySystem:
+		return "System"
+	default:
+		return fmt.Sprintf("Unknown (%d)", level)
+	}
+}
+
+// Privilege returns the Agent's privilege level normalized across operating systems from the process' username and
+// integrity level
+func (a *Agent) Privilege() string {
+	user := strings.ToLower(a.process.UserName)
+	if strings.ToLower(a.host.Platform) == "windows" {
+		switch {
+		case a.process.Integrity >= IntegritySystem, user == "nt authority\\system", strings.HasSuffix(user, "\\system"):
+			return PrivilegeSystem
+		case a.process.Integrity == IntegrityHigh:
+			return PrivilegeAdmin
+		default:
+			return PrivilegeUser
+		}
+	}
+	if user == "root" || a.process.Integrity >= IntegritySystem {
+		return PrivilegeRoot
+	}
+	return PrivilegeUser
+}
+
+// Privileged returns true if the Agent is running as an administrator, SYSTEM, or root
+func (a *Agent) Privileged() bool {
+	return a.Privilege() != PrivilegeUser
+}
+
+// PrivilegeString returns the normalized privilege level along with the Windows integrity level (e.g., admin (High))
+func (a *Agent) PrivilegeString() string {
+	if strings.ToLower(a.host.Platform) == "windows" {
+		return fmt.Sprintf("%s (%s)", a.Privilege(), IntegrityLevel(a.process.Integrity))
+	}
+	return a.Privilege()
+}
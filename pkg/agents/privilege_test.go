@@ -0,0 +1,83 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"testing"
+)
+
+func TestPrivilege(t *testing.T) {
+	tests := []struct {
+		name      string
+		platform  string
+		user      string
+		integrity int
+		want      string
+		privilege string
+	}{
+		{"windows user", "windows", "ACME\\jdoe", IntegrityMedium, PrivilegeUser, "user (Medium)"},
+		{"windows admin", "windows", "ACME\\jdoe", IntegrityHigh, PrivilegeAdmin, "admin (High)"},
+		{"windows system integrity", "Windows", "ACME\\WKSTN-1$", IntegritySystem, PrivilegeSystem, "SYSTEM (System)"},
+		{"windows system account", "windows", "NT AUTHORITY\\SYSTEM", IntegrityHigh, PrivilegeSystem, "SYSTEM (High)"},
+		{"windows low", "windows", "ACME\\jdoe", IntegrityLow, PrivilegeUser, "user (Low)"},
+		{"linux user", "linux", "jdoe", 0, PrivilegeUser, "user"},
+		{"linux root", "linux", "root", 0, PrivilegeRoot, "root"},
+		{"linux elevated", "linux", "jdoe", IntegritySystem, PrivilegeRoot, "root"},
+		{"darwin root", "darwin", "Root", 0, PrivilegeRoot, "root"},
+		{"unknown", "", "", 0, PrivilegeUser, "user"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := Agent{host: Host{Platform: test.platform}, process: Process{UserName: test.user, Integrity: test.integrity}}
+			if a.Privilege() != test.want {
+				t.Errorf("expected the privilege to be %s, have %s", test.want, a.Privilege())
+			}
+			if a.Privileged() != (test.want != PrivilegeUser) {
+				t.Errorf("expected privileged to be %t", test.want != PrivilegeUser)
+			}
+			if a.PrivilegeString() != test.privilege {
+				t.Errorf("expected %q, have %q", test.privilege, a.PrivilegeString())
+			}
+		})
+	}
+}
+
+func TestIntegrityLevel(t *testing.T) {
+	tests := []struct {
+		level int
+		want  string
+	}{
+		{IntegrityUntrusted, "Untrusted"},
+		{IntegrityLow, "Low"},
+		{IntegrityMedium, "Medium"},
+		{IntegrityHigh, "High"},
+		{IntegritySystem, "System"},
+		{5, "Unknown (5)"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := IntegrityLevel(test.level); got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Padding distributions used to pick the amount of random padding added to each message sent to an Agent
+const (
+	PaddingUniform     = "uniform"     // Every size between the minimum and maximum is equally likely
+	PaddingNormal      = "normal"      // Sizes cluster around the midpoint of the minimum and maximum
+	PaddingExponential = "exponential" // Sizes cluster near the minimum with a long tail toward the maximum
+)
+
+// MaxProfileDelay is the longest the server holds a response to an Agent so the listener's write timeout isn't reached
+const MaxProfileDelay = 5 * time.Second
+
+// Profile is the server-side traffic shaping applied to the messages sent to an Agent so that message sizes and response
+// times vary between Agents instead of sharing a single signature. The zero value uses the server's default padding
+// and does not delay responses
+type Profile struct {
+	Distribution string        `json:"distribution,omitempty"` // The padding distribution (e.g., uniform, normal, exponential)
+	PaddingMin   int           `json:"padding_min,omitempty"`  // The minimum number of padding bytes
+	PaddingMax   int           `json:"padding_max,omitempty"`  // The maximum number of padding bytes
+	DelayMin     time.Duration `json:"delay_min,omitempty"`    // The minimum amount of time to hold a response
+	DelayMax     time.Duration `json:"delay_max,omitempty"`    // The maximum amount of time to hold a response
+}
+
+// NewPadding validates and returns a copy of the profile that pads messages with the distribution between minimum and maximum bytes
+func (p Profile) NewPadding(distribution string, minimum, maximum int) (Profile, error) {
+	distribution = strings.ToLower(distribution)
+	switch distribution {
+	case PaddingUniform, PaddingNormal, PaddingExponential:
+	default:
+		return p, fmt.Errorf("pkg/agents.NewPadding(): unknown padding distribution '%s', expected one of: %s, %s, %s", distribution, PaddingUniform, PaddingNormal, PaddingExponential)
+	}
+	if minimum < 0 || maximum < minimum {
+		return p, fmt.Errorf("pkg/agents.NewPadding(): the padding range must be zero or more bytes with the minimum not larger than the maximum: %d-%d", minimum, maximum)
+	}
+	p.Distribution = distribution
+	p.PaddingMin = minimum
+	p.PaddingMax = maximum
+	return p, nil
+}
+
+// NewDelay validates and returns a copy of the profile that holds responses for a random amount of time between minimum and maximum
+func (p Profile) NewDelay(minimum, maximum time.Duration) (Profile, error) {
+	if minimum < 0 || maximum < minimum {
+		return p, fmt.Errorf("pkg/agents.NewDelay(): the delay range must be zero or more with the minimum not larger than the maximum: %s-%s", minimum, maximum)
+	}
+	if maximum > MaxProfileDelay {
+		return p, fmt.Errorf("pkg/agents.NewDelay(): the maximum delay %s is larger than the %s limit", maximum, MaxProfileDelay)
+	}
+	p.DelayMin = minimum
+	p.DelayMax = maximum
+	return p, nil
+}
+
+// Padded returns true if the profile determines the size of the padding added to messages
+func (p Profile) Padded() bool {
+	return p.Distribution != ""
+}
+
+// PaddingSize returns a random number of padding bytes drawn from the profile's distribution
+func (p Profile) PaddingSize() int {
+	span := float64(p.PaddingMax - p.PaddingMin)
+	var n float64
+	switch p.Distribution {
+	case PaddingNormal:
+		// Nearly every value of a normal distribution is within three standard deviations of the mean
+		n = span/2 + rand.NormFloat64()*span/6 // #nosec G404 the random number is not used for secrets
+	case PaddingExponential:
+		n = rand.ExpFloat64() * span / 4 // #nosec G404 the random number is not used for secrets
+	default:
+		n = rand.Float64() * span // #nosec G404 the random number is not used for secrets
+	}
+	return p.PaddingMin + int(math.Round(math.Max(0, math.Min(n, span))))
+}
+
+// Delay returns a random amount of time, between the profile's minimum and maximum, to hold a response
+func (p Profile) Delay() time.Duration {
+	if p.DelayMax <= 0 {
+		return 0
+	}
+	return p.DelayMin + time.Duration(rand.Int63n(int64(p.DelayMax-p.DelayMin)+1)) // #nosec G404 the random number is not used for secrets
+}
+
+// String returns a human-readable description of the profile
+func (p Profile) String() string {
+	padding := "default"
+	if p.Padded() {
+		padding = fmt.Sprintf("%s %d-%d bytes", p.Distribution, p.PaddingMin, p.PaddingMax)
+	}
+	delay := "none"
+	if p.DelayMax > 0 {
+		delay = fmt.Sprintf("%s-%s", p.DelayMin, p.DelayMax)
+	}
+	return fmt.Sprintf("padding: %s, delay: %s", padding, delay)
+}
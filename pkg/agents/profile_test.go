@@ -0,0 +1,123 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"testing"
+	"time"
+)
+
+func TestNewPadding(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution string
+		minimum      int
+		maximum      int
+		wantErr      bool
+	}{
+		{"uniform", "uniform", 0, 4096, false},
+		{"normal uppercase", "NORMAL", 128, 512, false},
+		{"exponential fixed size", "exponential", 256, 256, false},
+		{"unknown distribution", "poisson", 0, 4096, true},
+		{"negative minimum", "uniform", -1, 4096, true},
+		{"minimum larger than maximum", "uniform", 512, 128, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := Profile{}.NewPadding(test.distribution, test.minimum, test.maximum)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if p.Padded() == test.wantErr {
+				t.Errorf("expected padded to be %t, have %t", !test.wantErr, p.Padded())
+			}
+		})
+	}
+}
+
+func TestNewDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		minimum time.Duration
+		maximum time.Duration
+		wantErr bool
+	}{
+		{"range", 100 * time.Millisecond, time.Second, false},
+		{"limit", 0, MaxProfileDelay, false},
+		{"negative minimum", -time.Second, time.Second, true},
+		{"minimum larger than maximum", 2 * time.Second, time.Second, true},
+		{"over the limit", 0, MaxProfileDelay + time.Second, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Profile{}.NewDelay(test.minimum, test.maximum)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestProfileRanges verifies the padding sizes and delays drawn from a profile stay within its minimum and maximum
+func TestProfileRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+	}{
+		{"uniform", Profile{Distribution: PaddingUniform, PaddingMin: 64, PaddingMax: 1024}},
+		{"normal", Profile{Distribution: PaddingNormal, PaddingMin: 64, PaddingMax: 1024, DelayMax: time.Millisecond}},
+		{"exponential", Profile{Distribution: PaddingExponential, PaddingMin: 64, PaddingMax: 1024, DelayMin: time.Millisecond, DelayMax: 2 * time.Millisecond}},
+		{"fixed", Profile{Distribution: PaddingUniform, PaddingMin: 512, PaddingMax: 512}},
+		{"zero value", Profile{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				if n := test.profile.PaddingSize(); n < test.profile.PaddingMin || n > test.profile.PaddingMax {
+					t.Fatalf("padding size %d is outside of %d-%d", n, test.profile.PaddingMin, test.profile.PaddingMax)
+				}
+				if d := test.profile.Delay(); d < test.profile.DelayMin || d > test.profile.DelayMax {
+					t.Fatalf("delay %s is outside of %s-%s", d, test.profile.DelayMin, test.profile.DelayMax)
+				}
+			}
+		})
+	}
+}
+
+func TestProfileString(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		want    string
+	}{
+		{"zero value", Profile{}, "padding: default, delay: none"},
+		{"padding", Profile{Distribution: PaddingNormal, PaddingMin: 64, PaddingMax: 1024}, "padding: normal 64-1024 bytes, delay: none"},
+		{"delay", Profile{DelayMin: time.Second, DelayMax: 2 * time.Second}, "padding: default, delay: 1s-2s"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.profile.String(); got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
@@ -33,16 +33,33 @@ type Repository interface {
 	Remove(id uuid.UUID) error
 	Log(id uuid.UUID, message string) error
 	Update(agent Agent) error
+	UpdateActivity(id uuid.UUID, activity Activity) error
 	UpdateAlive(id uuid.UUID, alive bool) error
 	UpdateAuthenticated(id uuid.UUID, authenticated bool) error
 	UpdateBuild(id uuid.UUID, build Build) error
+	UpdateCampaign(id uuid.UUID, campaign string) error
+	UpdateChannel(id, listener uuid.UUID) error
 	UpdateComms(id uuid.UUID, comms Comms) error
 	UpdateHost(id uuid.UUID, host Host) error
+	UpdateImpersonation(id uuid.UUID, impersonation string) error
+	UpdateIndicators(id uuid.UUID, indicators []string) error
 	UpdateInitial(id uuid.UUID, t time.Time) (err error)
+	UpdateInjection(id uuid.UUID, method string) error
 	UpdateListener(id, listener uuid.UUID) error
 	UpdateProcess(id uuid.UUID, process Process) error
+	UpdateName(id uuid.UUID, name string) error
+	UpdateQuarantine(id uuid.UUID, reason string) error
+	UpdateRemoteAddress(id uuid.UUID, addr string) error
+	UpdateScopeViolation(id uuid.UUID, violation string) error
+	UpdateSecurityProducts(id uuid.UUID, products []string) error
 	UpdateNote(id uuid.UUID, note string) error
 	UpdateStatusCheckin(id uuid.UUID, t time.Time) (err error)
+	UpdateProfile(id uuid.UUID, profile Profile) error
+	UpdateRefreshed(id uuid.UUID, refreshed time.Time) error
+	UpdateThrottle(id uuid.UUID, kbps int) error
+	UpdateTimezone(id uuid.UUID, timezone string) error
+	AddChanges(id uuid.UUID, changes []Change) error
+	AddKeyBytes(id uuid.UUID, n int) error
 	AddLinkedAgent(id uuid.UUID, link uuid.UUID) error
 	RemoveLinkedAgent(id uuid.UUID, link uuid.UUID) error
 }
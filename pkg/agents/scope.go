@@ -0,0 +1,116 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	// 3rd Party
+	"gopkg.in/yaml.v3"
+)
+
+// Scope is the engagement's rules of engagement target scope. A host is in scope if any of its interface addresses is
+// in one of the CIDRs, its name or user's domain is one of the domains, or its name matches one of the hostname
+// patterns. An empty Scope puts every host in scope
+type Scope struct {
+	CIDRs      []string `yaml:"cidrs"`      // CIDRs are the in-scope networks (e.g., 10.10.0.0/16)
+	Domains    []string `yaml:"domains"`    // Domains are the in-scope DNS or Active Directory domains (e.g., corp.example.com)
+	Hostnames  []string `yaml:"hostnames"`  // Hostnames are in-scope host name patterns (e.g., WKS-*)
+	Quarantine bool     `yaml:"quarantine"` // Quarantine Agents that check in from out-of-scope hosts so they can't be tasked
+	networks   []*net.IPNet
+}
+
+// ParseScope parses and validates a YAML scope configuration. An example configuration:
+//
+//	cidrs: ["10.10.0.0/16", "192.168.56.0/24"]
+//	domains: ["corp.example.com"]
+//	hostnames: ["WKS-*", "SRV-??"]
+//	quarantine: true
+func ParseScope(data []byte) (scope Scope, err error) {
+	err = yaml.Unmarshal(data, &scope)
+	if err != nil {
+		return scope, fmt.Errorf("there was an error parsing the scope configuration: %s", err)
+	}
+	for _, cidr := range scope.CIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return scope, fmt.Errorf("the scope CIDR '%s' is invalid: %s", cidr, err)
+		}
+		scope.networks = append(scope.networks, network)
+	}
+	for _, pattern := range scope.Hostnames {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return scope, fmt.Errorf("the scope hostname pattern '%s' is invalid: %s", pattern, err)
+		}
+	}
+	return scope, nil
+}
+
+// Empty returns true if the Scope doesn't restrict any hosts
+func (s Scope) Empty() bool {
+	return len(s.networks) == 0 && len(s.Domains) == 0 && len(s.Hostnames) == 0
+}
+
+// Check returns why the provided host is outside the Scope, or an empty string if it is in scope
+func (s Scope) Check(host Host, process Process) string {
+	if s.Empty() {
+		return ""
+	}
+	for _, addr := range host.IPs {
+		// Agents report their interface addresses with the network's prefix length (e.g., 10.10.1.5/24)
+		ip := net.ParseIP(addr)
+		if i, _, err := net.ParseCIDR(addr); err == nil {
+			ip = i
+		}
+		for _, network := range s.networks {
+			if ip != nil && network.Contains(ip) {
+				return ""
+			}
+		}
+	}
+	name := strings.ToLower(host.Name)
+	for _, domain := range s.Domains {
+		domain = strings.ToLower(strings.Trim(domain, "."))
+		if name == domain || strings.HasSuffix(name, "."+domain) {
+			return ""
+		}
+		// Windows reports the user's NetBIOS domain name (e.g., CORP for corp.example.com)
+		user := strings.ToLower(process.Domain)
+		if user != "" && (user == domain || user == strings.Split(domain, ".")[0]) {
+			return ""
+		}
+	}
+	short := strings.Split(name, ".")[0]
+	for _, pattern := range s.Hostnames {
+		pattern = strings.ToLower(pattern)
+		if ok, _ := path.Match(pattern, name); ok {
+			return ""
+		}
+		if ok, _ := path.Match(pattern, short); ok {
+			return ""
+		}
+	}
+	return fmt.Sprintf("host %s with addresses %s is not in the engagement's scope", host.Name, strings.Join(host.IPs, ", "))
+}
@@ -0,0 +1,88 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"testing"
+)
+
+// TestParseScope verifies scope configurations are parsed and invalid CIDRs or hostname patterns are rejected
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		empty   bool
+		wantErr bool
+	}{
+		{"empty", "", true, false},
+		{"cidrs", "cidrs: [\"10.10.0.0/16\"]", false, false},
+		{"domains", "domains: [corp.example.com]\nquarantine: true", false, false},
+		{"hostnames", "hostnames: [\"WKS-*\"]", false, false},
+		{"invalid CIDR", "cidrs: [\"10.10.0.0/33\"]", false, true},
+		{"invalid pattern", "hostnames: [\"WKS-[\"]", false, true},
+		{"invalid YAML", "cidrs: [", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scope, err := ParseScope([]byte(test.data))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if err == nil && scope.Empty() != test.empty {
+				t.Errorf("expected empty %t, have %t", test.empty, scope.Empty())
+			}
+		})
+	}
+}
+
+// TestScopeCheck verifies hosts are in scope by address, domain, or hostname pattern
+func TestScopeCheck(t *testing.T) {
+	scope, err := ParseScope([]byte("cidrs: [\"10.10.0.0/16\"]\ndomains: [corp.example.com]\nhostnames: [\"WKS-*\"]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		scope   Scope
+		host    Host
+		process Process
+		inScope bool
+	}{
+		{"empty scope", Scope{}, Host{Name: "outside", IPs: []string{"198.51.100.5/24"}}, Process{}, true},
+		{"address with prefix", scope, Host{Name: "srv01", IPs: []string{"198.51.100.5/24", "10.10.1.5/24"}}, Process{}, true},
+		{"bare address", scope, Host{Name: "srv01", IPs: []string{"10.10.1.5"}}, Process{}, true},
+		{"domain", scope, Host{Name: "SRV01.Corp.Example.com"}, Process{}, true},
+		{"NetBIOS domain", scope, Host{Name: "srv01"}, Process{Domain: "CORP"}, true},
+		{"hostname pattern", scope, Host{Name: "wks-0042"}, Process{}, true},
+		{"hostname pattern FQDN", scope, Host{Name: "WKS-0042.lab.local"}, Process{}, true},
+		{"similar domain", scope, Host{Name: "srv01.notcorp.example.com"}, Process{Domain: "WORKGROUP"}, false},
+		{"out of scope", scope, Host{Name: "srv01", IPs: []string{"198.51.100.5/24"}}, Process{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violation := test.scope.Check(test.host, test.process)
+			if (violation == "") != test.inScope {
+				t.Errorf("expected in scope %t, have violation %q", test.inScope, violation)
+			}
+		})
+	}
+}
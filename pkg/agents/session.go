@@ -0,0 +1,114 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Session is a portable copy of an authenticated Agent's keys, metadata, and routing information used to move the
+// Agent to another Merlin server without having to exploit the host again
+type Session struct {
+	ID            uuid.UUID   `json:"id"`
+	Secret        []byte      `json:"secret"` // The symmetric key established during authentication
+	Build         Build       `json:"build"`
+	Host          Host        `json:"host"`
+	Process       Process     `json:"process"`
+	Comms         Comms       `json:"comms"`
+	Initial       time.Time   `json:"initial"`
+	Checkin       time.Time   `json:"checkin"`
+	Links         []uuid.UUID `json:"links,omitempty"` // First-order peer-to-peer child Agents
+	Note          string      `json:"note,omitempty"`
+	Injection     string      `json:"injection,omitempty"`
+	Impersonation string      `json:"impersonation,omitempty"`
+	Indicators    []string    `json:"indicators,omitempty"`
+	RemoteAddr    string      `json:"remote_addr,omitempty"`
+	Throttle      int         `json:"throttle,omitempty"`
+	Profile       Profile     `json:"profile"`
+	Timezone      string      `json:"timezone,omitempty"`
+	Campaign      string      `json:"campaign,omitempty"`
+	Scope         string      `json:"scope,omitempty"`
+	Quarantine    string      `json:"quarantine,omitempty"`
+	Name          string      `json:"name,omitempty"`
+}
+
+// Session returns a portable copy of the Agent's session
+func (a *Agent) Session() Session {
+	return Session{
+		ID:            a.id,
+		Secret:        a.secret,
+		Build:         a.build,
+		Host:          a.host,
+		Process:       a.process,
+		Comms:         a.comms,
+		Initial:       a.initial,
+		Checkin:       a.checkin,
+		Links:         a.linkedAgents,
+		Note:          a.note,
+		Injection:     a.injection,
+		Impersonation: a.impersonation,
+		Indicators:    a.indicators,
+		RemoteAddr:    a.remoteAddr,
+		Throttle:      a.throttle,
+		Profile:       a.profile,
+		Timezone:      a.timezone,
+		Campaign:      a.campaign,
+		Scope:         a.scope,
+		Quarantine:    a.quarantine,
+		Name:          a.name,
+	}
+}
+
+// NewAgentFromSession is a factory to create an alive and authenticated Agent from a session exported by another
+// Merlin server. The Agent is associated with the provided listener on this server. The OPAQUE state is not part of
+// the session; if the Agent has to re-authenticate, it registers again
+func NewAgentFromSession(session Session, listener uuid.UUID) (agent Agent, err error) {
+	agent, err = NewAgent(session.ID, session.Secret, nil, session.Initial)
+	if err != nil {
+		return
+	}
+	agent.alive = true
+	agent.authenticated = true
+	agent.build = session.Build
+	agent.host = session.Host
+	agent.process = session.Process
+	agent.comms = session.Comms
+	agent.checkin = session.Checkin
+	agent.linkedAgents = session.Links
+	agent.listener = listener
+	agent.note = session.Note
+	agent.injection = session.Injection
+	agent.impersonation = session.Impersonation
+	agent.indicators = session.Indicators
+	agent.remoteAddr = session.RemoteAddr
+	agent.throttle = session.Throttle
+	agent.profile = session.Profile
+	agent.timezone = session.Timezone
+	agent.campaign = session.Campaign
+	agent.scope = session.Scope
+	agent.quarantine = session.Quarantine
+	agent.name = session.Name
+	return
+}
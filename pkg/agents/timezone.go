@@ -0,0 +1,68 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseTimezone parses the timezone an Agent reported for its host. The timezone is an IANA name (e.g.,
+// America/New_York), a UTC offset (e.g., -0500 or -05:00), or an abbreviation followed by an offset (e.g., EST -0500)
+func ParseTimezone(timezone string) (*time.Location, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		return nil, fmt.Errorf("pkg/agents.ParseTimezone(): the timezone is empty")
+	}
+	if loc, err := time.LoadLocation(timezone); err == nil && timezone != "Local" {
+		return loc, nil
+	}
+
+	fields := strings.Fields(timezone)
+	offset := fields[len(fields)-1]
+	for _, layout := range []string{"-0700", "-07:00", "-07"} {
+		t, err := time.Parse(layout, offset)
+		if err != nil {
+			continue
+		}
+		_, seconds := t.Zone()
+		name := fields[0]
+		if len(fields) == 1 {
+			name = "UTC" + t.Format("-07:00")
+		}
+		return time.FixedZone(name, seconds), nil
+	}
+	return nil, fmt.Errorf("pkg/agents.ParseTimezone(): '%s' is not an IANA timezone name or UTC offset", timezone)
+}
+
+// Location returns the time.Location of the timezone the Agent reported for its host, or nil if it is unknown
+func (a *Agent) Location() *time.Location {
+	if a.timezone == "" {
+		return nil
+	}
+	loc, err := ParseTimezone(a.timezone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
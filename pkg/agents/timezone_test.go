@@ -0,0 +1,62 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agents
+
+import (
+	// Standard
+	"testing"
+	"time"
+)
+
+func TestParseTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		zone     string
+		offset   int
+		wantErr  bool
+	}{
+		{"IANA name", "Etc/GMT+5", "-05", -5 * 3600, false},
+		{"UTC", "UTC", "UTC", 0, false},
+		{"offset", "-0500", "UTC-05:00", -5 * 3600, false},
+		{"offset with colon", "+05:30", "UTC+05:30", 19800, false},
+		{"hours offset", " +09 ", "UTC+09:00", 9 * 3600, false},
+		{"abbreviation and offset", "EST -0500", "EST", -5 * 3600, false},
+		{"empty", "", "", 0, true},
+		{"local", "Local", "", 0, true},
+		{"unknown", "Mars/Olympus_Mons", "", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := ParseTimezone(test.timezone)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			zone, offset := time.Date(2024, time.January, 1, 0, 0, 0, 0, loc).Zone()
+			if zone != test.zone || offset != test.offset {
+				t.Errorf("expected zone %s with offset %d, have %s with offset %d", test.zone, test.offset, zone, offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package attack maps Agent commands to MITRE ATT&CK technique IDs and generates ATT&CK Navigator layers
+package attack
+
+import (
+	// Standard
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// commands maps an Agent job type, as used by the job service, to the ATT&CK technique IDs it exercises.
+// Commands whose technique depends on their first argument are keyed as "<job type> <argument>" (e.g., "ad users")
+var commands = map[string][]string{
+	"ad computers":     {"T1018"},
+	"ad gpos":          {"T1615"},
+	"ad groups":        {"T1069.002"},
+	"ad trusts":        {"T1482"},
+	"ad users":         {"T1087.002"},
+	"cd":               {"T1083"},
+	"clipboard":        {"T1115"},
+	"CreateProcess":    {"T1055"},
+	"destroy":          {"T1070.004", "T1070.009"},
+	"download":         {"T1005", "T1041"},
+	"env":              {"T1082"},
+	"exec":             {"T1106"},
+	"idle":             {"T1033"},
+	"ifconfig":         {"T1016"},
+	"invoke-assembly":  {"T1620"},
+	"keylogger":        {"T1056.001"},
+	"killprocess":      {"T1057"},
+	"link":             {"T1090.001"},
+	"load-assembly":    {"T1620"},
+	"load-clr":         {"T1620"},
+	"ls":               {"T1083"},
+	"memfd":            {"T1620"},
+	"memory":           {"T1562.001"},
+	"Minidump":         {"T1003.001"},
+	"netstat":          {"T1049"},
+	"nslookup":         {"T1018"},
+	"persist launchd":  {"T1543.001"},
+	"persist registry": {"T1547.001"},
+	"persist schtask":  {"T1053.005"},
+	"persist systemd":  {"T1543.002"},
+	"persist wmi":      {"T1546.003"},
+	"pipes":            {"T1083"},
+	"preflight":        {"T1497.001", "T1518.001"},
+	"ps":               {"T1057"},
+	"pwd":              {"T1083"},
+	"rm":               {"T1070.004"},
+	"run":              {"T1106"},
+	"runas":            {"T1134.002"},
+	"scan":             {"T1046"},
+	"scexec":           {"T1021.002", "T1543.003", "T1569.002", "T1570"},
+	"security":         {"T1518.001"},
+	"screenshot":       {"T1113"},
+	"sdelete":          {"T1070.004"},
+	"shell":            {"T1059"},
+	"shellcode":        {"T1055"},
+	"socks":            {"T1090"},
+	"ssh":              {"T1021.004"},
+	"ssh-deploy":       {"T1021.004", "T1570"},
+	"token":            {"T1134"},
+	"touch":            {"T1070.006"},
+	"upload":           {"T1105"},
+	"uptime":           {"T1082"},
+	"wmiexec":          {"T1047"},
+}
+
+// techniqueID matches an ATT&CK technique or sub-technique ID (e.g., T1055 or T1003.001)
+var techniqueID = regexp.MustCompile(`^T\d{4}(\.\d{3})?$`)
+
+// Techniques returns the ATT&CK technique IDs associated with the provided job type
+func Techniques(jobType string) []string {
+	techniques := make([]string, len(commands[jobType]))
+	copy(techniques, commands[jobType])
+	return techniques
+}
+
+// Merge combines the lists of technique IDs into a single sorted list without duplicates
+func Merge(lists ...[]string) (techniques []string) {
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, id := range list {
+			id = strings.ToUpper(strings.TrimSpace(id))
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			techniques = append(techniques, id)
+		}
+	}
+	sort.Strings(techniques)
+	return
+}
+
+// Validate ensures the provided ID is a well-formed ATT&CK technique or sub-technique ID
+func Validate(id string) error {
+	if !techniqueID.MatchString(strings.ToUpper(id)) {
+		return fmt.Errorf("pkg/attack.Validate(): '%s' is not a valid ATT&CK technique ID", id)
+	}
+	return nil
+}
+
+// Layer is an ATT&CK Navigator layer file
+// https://github.com/mitre-attack/attack-navigator/tree/master/layers
+type Layer struct {
+	Name        string      `json:"name"`
+	Versions    Versions    `json:"versions"`
+	Domain      string      `json:"domain"`
+	Description string      `json:"description"`
+	Techniques  []Technique `json:"techniques"`
+	Gradient    Gradient    `json:"gradient"`
+}
+
+// Versions are the ATT&CK, Navigator, and layer format versions the layer was created for
+type Versions struct {
+	Attack    string `json:"attack"`
+	Navigator string `json:"navigator"`
+	Layer     string `json:"layer"`
+}
+
+// Technique is a single scored technique in a Navigator layer
+type Technique struct {
+	TechniqueID string `json:"techniqueID"`
+	Score       int    `json:"score"`
+	Comment     string `json:"comment,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// Gradient is the color range used to display technique scores in the Navigator
+type Gradient struct {
+	Colors   []string `json:"colors"`
+	MinValue int      `json:"minValue"`
+	MaxValue int      `json:"maxValue"`
+}
+
+// NewLayer creates an ATT&CK Navigator layer where each technique's score is the number of times it was exercised
+func NewLayer(name, description string, counts map[string]int) Layer {
+	layer := Layer{
+		Name: name,
+		Versions: Versions{
+			Attack:    "15",
+			Navigator: "5.0.0",
+			Layer:     "4.5",
+		},
+		Domain:      "enterprise-attack",
+		Description: description,
+		Techniques:  []Technique{},
+		Gradient: Gradient{
+			Colors:   []string{"#ffe766", "#ff6666"},
+			MinValue: 1,
+		},
+	}
+
+	var ids []string
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		layer.Techniques = append(layer.Techniques, Technique{
+			TechniqueID: id,
+			Score:       counts[id],
+			Comment:     fmt.Sprintf("Exercised %d time(s)", counts[id]),
+			Enabled:     true,
+		})
+		if counts[id] > layer.Gradient.MaxValue {
+			layer.Gradient.MaxValue = counts[id]
+		}
+	}
+	return layer
+}
+
+// JSON returns the layer as an indented JSON document that can be opened in the ATT&CK Navigator
+func (l Layer) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("pkg/attack.JSON(): there was an error marshalling the Navigator layer: %s", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,131 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package attack
+
+import (
+	// Standard
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestTechniques verifies job types map to their techniques and the returned list is a copy
+func TestTechniques(t *testing.T) {
+	tests := []struct {
+		jobType string
+		want    []string
+	}{
+		{"download", []string{"T1005", "T1041"}},
+		{"Minidump", []string{"T1003.001"}},
+		{"unknown", []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.jobType, func(t *testing.T) {
+			got := Techniques(test.jobType)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+			if len(got) > 0 {
+				got[0] = "T0000"
+				if Techniques(test.jobType)[0] == "T0000" {
+					t.Error("modifying the returned techniques changed the command map")
+				}
+			}
+		})
+	}
+}
+
+// TestMerge verifies technique lists are normalized, sorted, and deduplicated
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		lists [][]string
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"single", [][]string{{"T1106"}}, []string{"T1106"}},
+		{"duplicates", [][]string{{"T1083", "t1057"}, {" T1083 ", "T1057", ""}}, []string{"T1057", "T1083"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Merge(test.lists...); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// TestValidate verifies technique and sub-technique IDs are accepted and everything else is rejected
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"T1055", true},
+		{"t1003.001", true},
+		{"T105", false},
+		{"T1003.1", false},
+		{"TA0001", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			if err := Validate(test.id); (err == nil) != test.valid {
+				t.Errorf("expected valid to be %t, got error: %v", test.valid, err)
+			}
+		})
+	}
+}
+
+// TestNewLayer verifies techniques are scored by their count and the gradient spans the highest score
+func TestNewLayer(t *testing.T) {
+	layer := NewLayer("test", "description", map[string]int{"T1083": 3, "T1057": 1})
+	want := []Technique{
+		{TechniqueID: "T1057", Score: 1, Comment: "Exercised 1 time(s)", Enabled: true},
+		{TechniqueID: "T1083", Score: 3, Comment: "Exercised 3 time(s)", Enabled: true},
+	}
+	if !reflect.DeepEqual(layer.Techniques, want) {
+		t.Errorf("expected techniques %+v, got %+v", want, layer.Techniques)
+	}
+	if layer.Gradient.MaxValue != 3 {
+		t.Errorf("expected a gradient max value of 3, got %d", layer.Gradient.MaxValue)
+	}
+
+	data, err := layer.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Layer
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, layer) {
+		t.Errorf("expected the JSON layer to decode to %+v, got %+v", layer, decoded)
+	}
+
+	empty, err := NewLayer("empty", "", nil).JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(empty, &decoded); err != nil || decoded.Techniques == nil {
+		t.Errorf("expected an empty techniques list, got %s", empty)
+	}
+}
@@ -79,6 +79,30 @@ func (a *Authenticator) Authenticate(id uuid.UUID, data interface{}) (msg messag
 		slog.Error(fmt.Sprintf("there was an error adding the agentInfo job for agent %s: %s", id, err))
 	}
 
+	// Add the pre-flight job to detect if the Agent is running in a sandbox or under analysis
+	_, err = a.jobService.Add(id, "preflight", []string{})
+	if err != nil {
+		slog.Error(fmt.Sprintf("there was an error adding the preflight job for agent %s: %s", id, err))
+	}
+
+	// Add the security job to find the antivirus and EDR products on the host before operators task the Agent
+	_, err = a.jobService.Add(id, "security", []string{})
+	if err != nil {
+		slog.Error(fmt.Sprintf("there was an error adding the security job for agent %s: %s", id, err))
+	}
+
+	// Add the timezone job so the Agent's activity can be shown in the host's local time
+	_, err = a.jobService.Add(id, "timezone", []string{})
+	if err != nil {
+		slog.Error(fmt.Sprintf("there was an error adding the timezone job for agent %s: %s", id, err))
+	}
+
+	// Add the campaign job to attribute the Agent to the campaign its payload was built for
+	_, err = a.jobService.Add(id, "campaign", []string{})
+	if err != nil {
+		slog.Error(fmt.Sprintf("there was an error adding the campaign job for agent %s: %s", id, err))
+	}
+
 	msg.ID = id
 	msg.Type = messages.IDLE
 	return
@@ -29,7 +29,6 @@ import (
 	"time"
 
 	// 3rd Party
-	"github.com/cretz/gopaque/gopaque"
 	"github.com/google/uuid"
 	"go.dedis.ch/kyber/v3"
 
@@ -46,8 +45,8 @@ import (
 	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
 )
 
-// key is the Opaque server-side key
-var key = gopaque.CryptoDefault.NewKey(nil)
+// key is the Opaque server-side key; it is saved to disk so registrations survive a server restart
+var key kyber.Scalar
 
 // servers is a map where the Agent ID is the key and the value is the opaque server
 // Stored here until the agent is full authenticated, added to the agent structure, and then removed from the map
@@ -66,6 +65,7 @@ type Authenticator struct {
 func NewAuthenticator() (*Authenticator, error) {
 	var err error
 	var auth Authenticator
+	keyOnce.Do(func() { key = loadKey() })
 	auth.agentService = agent.NewAgentService()
 	auth.jobService = job.NewJobService()
 	return &auth, err
@@ -110,6 +110,30 @@ func (a *Authenticator) Authenticate(id uuid.UUID, data interface{}) (msg messag
 		if err != nil {
 			slog.Warn(fmt.Sprintf("there was an error adding the agentInfo job:\r\n%s", err))
 		}
+
+		// Add the pre-flight job to detect if the Agent is running in a sandbox or under analysis
+		_, err = a.jobService.Add(id, "preflight", []string{})
+		if err != nil {
+			slog.Error(fmt.Sprintf("there was an error adding the preflight job for agent %s: %s", id, err))
+		}
+
+		// Add the security job to find the antivirus and EDR products on the host before operators task the Agent
+		_, err = a.jobService.Add(id, "security", []string{})
+		if err != nil {
+			slog.Error(fmt.Sprintf("there was an error adding the security job for agent %s: %s", id, err))
+		}
+
+		// Add the timezone job so the Agent's activity can be shown in the host's local time
+		_, err = a.jobService.Add(id, "timezone", []string{})
+		if err != nil {
+			slog.Error(fmt.Sprintf("there was an error adding the timezone job for agent %s: %s", id, err))
+		}
+
+		// Add the campaign job to attribute the Agent to the campaign its payload was built for
+		_, err = a.jobService.Add(id, "campaign", []string{})
+		if err != nil {
+			slog.Error(fmt.Sprintf("there was an error adding the campaign job for agent %s: %s", id, err))
+		}
 		// Remove from the map
 		out.Delete(id)
 		msg.ID = id
@@ -162,6 +186,12 @@ func (a *Authenticator) registrationComplete(agentID uuid.UUID, o opaque.Opaque)
 		return opaque.Opaque{}, err
 	}
 
+	// Save the registration so the Agent can authenticate after a server restart
+	err = saveRecord(agentID, opaqueServer.(*opaque2.Server))
+	if err != nil {
+		slog.Error(err.Error())
+	}
+
 	thisAgent, err := a.agentService.Agent(agentID)
 	// If the error is not nil, continue on and create a new agent
 	if err == nil {
@@ -204,6 +234,9 @@ func (a *Authenticator) authenticateInit(agentID uuid.UUID, o opaque.Opaque) (op
 	}
 
 	thisAgent, err := a.agentService.Agent(agentID)
+	if err != nil {
+		thisAgent, err = a.restore(agentID)
+	}
 	if err != nil {
 		// Agent does not exist and must re-register itself
 		slog.Warn(fmt.Sprintf("Un-Registered agent %s sent OPAQUE authentication, instructing agent to OPAQUE register", agentID))
@@ -220,12 +253,10 @@ func (a *Authenticator) authenticateInit(agentID uuid.UUID, o opaque.Opaque) (op
 
 	keys := []byte(thisAgent.OPAQUE().Kex.SharedSecret.String())
 
-	newAgent, err := agents.NewAgent(agentID, keys, thisAgent.OPAQUE(), thisAgent.Initial())
-	if err != nil {
-		return opaque.Opaque{}, fmt.Errorf("pkg/authenticaters/opaque.authenticateInit(): unable to create a new agent for %s: %s", agentID, err)
-	}
+	// Keep the Agent's existing state, such as its links and configuration, when it authenticates again to re-key
+	thisAgent.SetSecret(keys)
 
-	err = a.agentService.Update(newAgent)
+	err = a.agentService.Update(thisAgent)
 	if err != nil {
 		return opaque.Opaque{}, fmt.Errorf("pkg/authenticaters/opaque.authenticateInit(): error storing agent %s: %s", agentID, err)
 	}
@@ -282,6 +313,9 @@ func (a *Authenticator) reAuthenticate(agentID uuid.UUID) (opaque.Opaque, error)
 	}
 
 	thisAgent, err := a.agentService.Agent(agentID)
+	if err != nil {
+		thisAgent, err = a.restore(agentID)
+	}
 	if err != nil {
 		// Agent does not exist and must re-register itself
 		returnMessage.Type = opaque.ReRegister
@@ -301,6 +335,30 @@ func (a *Authenticator) reAuthenticate(agentID uuid.UUID) (opaque.Opaque, error)
 	return returnMessage, nil
 }
 
+// restore re-creates an Agent the server doesn't know about from the OPAQUE registration it saved before a restart.
+// The Agent keeps its ID so its existing log and files are used
+func (a *Authenticator) restore(agentID uuid.UUID) (agents.Agent, error) {
+	server, err := loadRecord(agentID)
+	if err != nil {
+		return agents.Agent{}, err
+	}
+	if server == nil {
+		return agents.Agent{}, fmt.Errorf("pkg/authenticators/opaque.restore(): there is no OPAQUE registration for agent %s", agentID)
+	}
+
+	restored, err := agents.NewAgent(agentID, []byte{}, server, time.Now().UTC())
+	if err != nil {
+		return agents.Agent{}, fmt.Errorf("pkg/authenticators/opaque.restore(): unable to create agent %s: %s", agentID, err)
+	}
+	err = a.agentService.Add(restored)
+	if err != nil {
+		return agents.Agent{}, fmt.Errorf("pkg/authenticators/opaque.restore(): error storing agent %s: %s", agentID, err)
+	}
+	restored.Log("Restored the OPAQUE registration saved before the server restarted")
+	slog.Info(fmt.Sprintf("Restored the OPAQUE registration for agent %s", agentID))
+	return restored, nil
+}
+
 // String returns the name of authenticator type
 func (a *Authenticator) String() string {
 	return "OPAQUE"
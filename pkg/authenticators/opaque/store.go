@@ -0,0 +1,134 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opaque
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	// 3rd Party
+	"github.com/cretz/gopaque/gopaque"
+	"github.com/google/uuid"
+	"go.dedis.ch/kyber/v3"
+
+	// Merlin
+	opaque2 "github.com/Ne0nd0g/merlin/v2/pkg/opaque"
+)
+
+// keyOnce ensures the OPAQUE server-side key is only loaded or generated once
+var keyOnce sync.Once
+
+// dataDir returns the directory below the Merlin server's current working directory for the provided path elements
+func dataDir(elem ...string) (string, error) {
+	current, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("there was an error getting the current working directory: %s", err)
+	}
+	return filepath.Join(append([]string{current, "data"}, elem...)...), nil
+}
+
+// loadKey reads the OPAQUE server-side key from disk, or generates and saves one, so that Agents registered before a
+// server restart can still authenticate. A new in-memory key is used if the key can't be read or saved
+func loadKey() kyber.Scalar {
+	dir, err := dataDir("opaque")
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/authenticators/opaque.loadKey(): %s", err))
+		return gopaque.CryptoDefault.NewKey(nil)
+	}
+	file := filepath.Join(dir, "server.key")
+
+	data, err := os.ReadFile(file) // #nosec G304 the path is not user controlled
+	if err == nil {
+		k := gopaque.CryptoDefault.Scalar()
+		err = k.UnmarshalBinary(data)
+		if err == nil {
+			return k
+		}
+		slog.Error(fmt.Sprintf("pkg/authenticators/opaque.loadKey(): there was an error unmarshalling the OPAQUE server key %s, generating a new one: %s", file, err))
+	}
+
+	k := gopaque.CryptoDefault.NewKey(nil)
+	data, err = k.MarshalBinary()
+	if err == nil {
+		err = os.MkdirAll(dir, 0750)
+	}
+	if err == nil {
+		err = os.WriteFile(file, data, 0600)
+	}
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/authenticators/opaque.loadKey(): there was an error saving the OPAQUE server key, Agents will have to register again after a restart: %s", err))
+	}
+	return k
+}
+
+// recordFile returns the file the Agent's OPAQUE registration record is stored in, alongside its log
+func recordFile(id uuid.UUID) (string, error) {
+	dir, err := dataDir("agents", id.String())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "opaque.json"), nil
+}
+
+// saveRecord writes the Agent's completed OPAQUE registration to disk
+func saveRecord(id uuid.UUID, server *opaque2.Server) error {
+	data, err := server.MarshalRecord()
+	if err != nil {
+		return fmt.Errorf("pkg/authenticators/opaque.saveRecord(): %s", err)
+	}
+	file, err := recordFile(id)
+	if err != nil {
+		return fmt.Errorf("pkg/authenticators/opaque.saveRecord(): %s", err)
+	}
+	err = os.MkdirAll(filepath.Dir(file), 0750)
+	if err != nil {
+		return fmt.Errorf("pkg/authenticators/opaque.saveRecord(): there was an error creating the directory for agent %s: %s", id, err)
+	}
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("pkg/authenticators/opaque.saveRecord(): there was an error writing the OPAQUE registration for agent %s: %s", id, err)
+	}
+	return nil
+}
+
+// loadRecord reads the Agent's OPAQUE registration from disk. Returns nil if the Agent never registered with this server
+func loadRecord(id uuid.UUID) (*opaque2.Server, error) {
+	file, err := recordFile(id)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/authenticators/opaque.loadRecord(): %s", err)
+	}
+	data, err := os.ReadFile(file) // #nosec G304 the path is not user controlled
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pkg/authenticators/opaque.loadRecord(): there was an error reading the OPAQUE registration for agent %s: %s", id, err)
+	}
+	server, err := opaque2.ServerFromRecord(data, key)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/authenticators/opaque.loadRecord(): %s", err)
+	}
+	return server, nil
+}
@@ -0,0 +1,173 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opaque
+
+import (
+	// Standard
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	// 3rd Party
+	"github.com/cretz/gopaque/gopaque"
+	"github.com/google/uuid"
+
+	// Merlin
+	opaque2 "github.com/Ne0nd0g/merlin/v2/pkg/opaque"
+)
+
+// chdir changes the current working directory to a temporary directory for the duration of the test
+func chdir(t *testing.T) string {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+	return dir
+}
+
+// newRecord returns a registered OPAQUE server built from a record with random keys
+func newRecord(t *testing.T, id uuid.UUID) *opaque2.Server {
+	t.Helper()
+	userID, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := gopaque.CryptoDefault.Point().Pick(gopaque.CryptoDefault.RandomStream()).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ku, err := gopaque.CryptoDefault.NewKey(nil).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(map[string][]byte{"user_id": userID, "user_public_key": public, "env_u": []byte("envelope"), "k_u": ku})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := opaque2.ServerFromRecord(data, gopaque.CryptoDefault.NewKey(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+// TestLoadKey verifies the OPAQUE server key is saved and the same key is loaded after a restart
+func TestLoadKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt bool
+	}{
+		{"new", false},
+		{"corrupt", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := chdir(t)
+			file := filepath.Join(dir, "data", "opaque", "server.key")
+			if test.corrupt {
+				if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(file, []byte("merlin"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			first, err := loadKey().MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = os.Stat(file); err != nil {
+				t.Fatalf("expected the key to be saved: %s", err)
+			}
+			second, err := loadKey().MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(first, second) {
+				t.Error("expected the saved key to be loaded")
+			}
+		})
+	}
+}
+
+// TestRecord verifies a saved OPAQUE registration is restored and a missing registration is not an error
+func TestRecord(t *testing.T) {
+	chdir(t)
+	key = gopaque.CryptoDefault.NewKey(nil)
+	saved := uuid.New()
+	corrupt := uuid.New()
+	server := newRecord(t, saved)
+	if err := saveRecord(saved, server); err != nil {
+		t.Fatal(err)
+	}
+	file, err := recordFile(corrupt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(file, []byte("merlin"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		found   bool
+		wantErr bool
+	}{
+		{"saved", saved, true, false},
+		{"never registered", uuid.New(), false, false},
+		{"corrupt", corrupt, false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loaded, err := loadRecord(test.id)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if (loaded != nil) != test.found {
+				t.Fatalf("expected a registration to be found %t, have %v", test.found, loaded)
+			}
+			if !test.found {
+				return
+			}
+			want, _ := server.MarshalRecord()
+			have, err := loaded.MarshalRecord()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(want, have) {
+				t.Errorf("expected the restored record %s, have %s", want, have)
+			}
+		})
+	}
+}
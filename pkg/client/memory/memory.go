@@ -32,14 +32,11 @@ type Repository struct {
 	sync.Mutex
 }
 
-var repo *Repository
+var repo = &Repository{
+	clients: make(map[uuid.UUID]client.Client),
+}
 
 func NewRepository() *Repository {
-	if repo == nil {
-		repo = &Repository{
-			clients: make(map[uuid.UUID]client.Client),
-		}
-	}
 	return repo
 }
 
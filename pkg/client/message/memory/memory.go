@@ -41,16 +41,13 @@ type Repository struct {
 }
 
 // repo is the single in-memory instantiation of the Repository
-var repo *Repository
+var repo = &Repository{
+	messages: make(map[uuid.UUID]*message.Message),
+	queue:    make(chan *message.Message, 100),
+}
 
-// NewRepository is a factory that returns an instantiated in-memory repository
+// NewRepository is a factory that returns the in-memory repository
 func NewRepository() *Repository {
-	if repo == nil {
-		repo = &Repository{
-			messages: make(map[uuid.UUID]*message.Message),
-			queue:    make(chan *message.Message, 100),
-		}
-	}
 	return repo
 }
 
@@ -0,0 +1,152 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package credentials holds the structures for credentials collected or provided during an engagement
+package credentials
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Type is the kind of secret a credential holds
+type Type int
+
+const (
+	UNDEFINED Type = iota
+	// PLAINTEXT is a cleartext password
+	PLAINTEXT
+	// HASH is a password hash (e.g., NTLM)
+	HASH
+	// KEY is a private key (e.g., SSH)
+	KEY
+	// TOKEN is an API key or access token
+	TOKEN
+)
+
+// Credential is a single set of credentials
+type Credential struct {
+	id       uuid.UUID // id is the unique identifier for the credential
+	credType Type      // credType is the kind of secret the credential holds
+	domain   string    // domain is the domain or realm the credential is valid for
+	username string    // username is the account the credential belongs to
+	secret   string    // secret is the password, hash, key, or token
+	source   string    // source describes where the credential came from (e.g., operator, clipboard)
+	agentID  uuid.UUID // agentID is the Agent the credential was collected from, if any
+	flagged  bool      // flagged indicates the credential was automatically detected and has not been verified
+	created  time.Time // created is when the credential was added
+}
+
+// NewCredential is a factory to create a Credential structure
+func NewCredential(credType Type, domain, username, secret, source string, agentID uuid.UUID, flagged bool) Credential {
+	return Credential{
+		id:       uuid.New(),
+		credType: credType,
+		domain:   domain,
+		username: username,
+		secret:   secret,
+		source:   source,
+		agentID:  agentID,
+		flagged:  flagged,
+		created:  time.Now().UTC(),
+	}
+}
+
+// AgentID returns the Agent the credential was collected from, if any
+func (c *Credential) AgentID() uuid.UUID {
+	return c.agentID
+}
+
+// Created returns when the credential was added
+func (c *Credential) Created() time.Time {
+	return c.created
+}
+
+// Domain returns the domain or realm the credential is valid for
+func (c *Credential) Domain() string {
+	return c.domain
+}
+
+// Flagged returns true if the credential was automatically detected and has not been verified
+func (c *Credential) Flagged() bool {
+	return c.flagged
+}
+
+// ID returns the credential's unique identifier
+func (c *Credential) ID() uuid.UUID {
+	return c.id
+}
+
+// Secret returns the password, hash, key, or token
+func (c *Credential) Secret() string {
+	return c.secret
+}
+
+// Source describes where the credential came from
+func (c *Credential) Source() string {
+	return c.source
+}
+
+// Type returns the kind of secret the credential holds
+func (c *Credential) Type() Type {
+	return c.credType
+}
+
+// Username returns the account the credential belongs to
+func (c *Credential) Username() string {
+	return c.username
+}
+
+// String returns the credential type as a string
+func (t Type) String() string {
+	switch t {
+	case PLAINTEXT:
+		return "Plaintext"
+	case HASH:
+		return "Hash"
+	case KEY:
+		return "Key"
+	case TOKEN:
+		return "Token"
+	default:
+		return "Undefined"
+	}
+}
+
+// FromString converts the provided string into a credential Type
+func FromString(t string) (Type, error) {
+	switch strings.ToLower(t) {
+	case "plaintext", "password":
+		return PLAINTEXT, nil
+	case "hash":
+		return HASH, nil
+	case "key":
+		return KEY, nil
+	case "token":
+		return TOKEN, nil
+	default:
+		return UNDEFINED, fmt.Errorf("pkg/credentials.FromString(): unknown credential type '%s'", t)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving credentials
+package memory
+
+import (
+	// Standard
+	"errors"
+	"sort"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials"
+)
+
+var (
+	ErrCredentialNotFound = errors.New("pkg/credentials/memory: the credential was not found in the repository")
+)
+
+// Repository is the structure that implements the in-memory repository for credentials
+type Repository struct {
+	sync.RWMutex
+	credentials map[uuid.UUID]credentials.Credential
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{credentials: make(map[uuid.UUID]credentials.Credential)}
+
+// NewRepository returns the in-memory repository for credentials
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the credential in the repository
+func (r *Repository) Add(credential credentials.Credential) error {
+	r.Lock()
+	defer r.Unlock()
+	r.credentials[credential.ID()] = credential
+	return nil
+}
+
+// Get returns the credential for the provided ID
+func (r *Repository) Get(id uuid.UUID) (credentials.Credential, error) {
+	r.RLock()
+	defer r.RUnlock()
+	credential, ok := r.credentials[id]
+	if !ok {
+		return credentials.Credential{}, ErrCredentialNotFound
+	}
+	return credential, nil
+}
+
+// GetAll returns all credentials sorted by the time they were added
+func (r *Repository) GetAll() (all []credentials.Credential) {
+	r.RLock()
+	for _, credential := range r.credentials {
+		all = append(all, credential)
+	}
+	r.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Created().Before(all[j].Created())
+	})
+	return
+}
+
+// Remove deletes the credential from the repository
+func (r *Repository) Remove(id uuid.UUID) error {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.credentials[id]; !ok {
+		return ErrCredentialNotFound
+	}
+	delete(r.credentials, id)
+	return nil
+}
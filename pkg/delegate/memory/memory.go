@@ -35,31 +35,18 @@ type Repository struct {
 }
 
 // repo is the in-memory datastore
-var repo *Repository
+var repo = &Repository{messages: make(map[uuid.UUID][]delegate)}
 
 // NewRepository is a factory to return a repository structure
 func NewRepository() *Repository {
-	if repo == nil {
-		repo = &Repository{messages: make(map[uuid.UUID][]delegate)}
-	}
 	return repo
 }
 
 // Add data to the in-memory map for the provided Agent ID
 func (r *Repository) Add(id uuid.UUID, data []byte) {
-	var exists bool
-	for k := range r.messages {
-		if id == k {
-			exists = true
-		}
-	}
 	r.Lock()
 	defer r.Unlock()
-	if exists {
-		r.messages[id] = append(r.messages[id], delegate{data: data})
-	} else {
-		r.messages[id] = []delegate{{data: data}}
-	}
+	r.messages[id] = append(r.messages[id], delegate{data: data})
 }
 
 // Get return data from the in-memory map for the provided Agent ID
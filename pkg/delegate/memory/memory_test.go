@@ -0,0 +1,82 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	// Standard
+	"sync"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestRepositoryConcurrentAccess stores and retrieves delegate messages from many goroutines, the way the listeners
+// handle peer-to-peer Agent traffic, and verifies every message is returned exactly once.
+// Run with the -race flag to detect data races
+func TestRepositoryConcurrentAccess(t *testing.T) {
+	r := NewRepository()
+	if r != NewRepository() {
+		t.Fatal("expected NewRepository to return the same repository")
+	}
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	t.Cleanup(func() {
+		for _, id := range ids {
+			r.Get(id)
+		}
+	})
+
+	const writers, messages = 10, 50
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	received := make(map[uuid.UUID]int)
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < messages; i++ {
+				r.Add(ids[(w+i)%len(ids)], []byte{byte(w), byte(i)})
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < messages; i++ {
+				id := ids[(w+i)%len(ids)]
+				n := len(r.Get(id))
+				mu.Lock()
+				received[id] += n
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, id := range ids {
+		received[id] += len(r.Get(id))
+	}
+
+	var total int
+	for _, n := range received {
+		total += n
+	}
+	if total != writers*messages {
+		t.Errorf("expected %d delegate messages, have %d", writers*messages, total)
+	}
+}
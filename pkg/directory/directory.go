@@ -0,0 +1,165 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package directory holds the structures for Active Directory objects enumerated by Agents over LDAP
+package directory
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Class is the kind of Active Directory object
+type Class int
+
+const (
+	UNDEFINED Class = iota
+	// USER is an Active Directory user account
+	USER
+	// COMPUTER is an Active Directory computer account
+	COMPUTER
+	// GROUP is an Active Directory security or distribution group
+	GROUP
+	// GPO is an Active Directory Group Policy Object
+	GPO
+	// TRUST is an Active Directory domain trust
+	TRUST
+)
+
+// Object is a single Active Directory object returned from an Agent's LDAP query
+type Object struct {
+	dn         string              // dn is the object's distinguished name and uniquely identifies it
+	class      Class               // class is the kind of Active Directory object
+	attributes map[string][]string // attributes are the LDAP attributes returned for the object
+	agentID    uuid.UUID           // agentID is the Agent that last enumerated the object
+	jobID      string              // jobID is the job that last enumerated the object
+	collected  time.Time           // collected is when the object was last enumerated
+}
+
+// NewObject is a factory to create an Active Directory Object structure
+func NewObject(dn string, class Class, attributes map[string][]string, agentID uuid.UUID, jobID string) Object {
+	if attributes == nil {
+		attributes = make(map[string][]string)
+	}
+	return Object{
+		dn:         dn,
+		class:      class,
+		attributes: attributes,
+		agentID:    agentID,
+		jobID:      jobID,
+		collected:  time.Now().UTC(),
+	}
+}
+
+// AgentID returns the Agent that last enumerated the object
+func (o *Object) AgentID() uuid.UUID {
+	return o.agentID
+}
+
+// Attribute returns the values for the provided LDAP attribute name; attribute names are case-insensitive
+func (o *Object) Attribute(name string) []string {
+	for k, v := range o.attributes {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+// Attributes returns a copy of all the object's LDAP attributes
+func (o *Object) Attributes() map[string][]string {
+	attributes := make(map[string][]string, len(o.attributes))
+	for k, v := range o.attributes {
+		attributes[k] = append([]string{}, v...)
+	}
+	return attributes
+}
+
+// Class returns the kind of Active Directory object
+func (o *Object) Class() Class {
+	return o.class
+}
+
+// Collected returns when the object was last enumerated
+func (o *Object) Collected() time.Time {
+	return o.collected
+}
+
+// DN returns the object's distinguished name
+func (o *Object) DN() string {
+	return o.dn
+}
+
+// JobID returns the job that last enumerated the object
+func (o *Object) JobID() string {
+	return o.jobID
+}
+
+// Name returns a friendly name for the object from its sAMAccountName, displayName, name, or cn attributes, in that
+// order. The distinguished name is returned if none of the attributes are present
+func (o *Object) Name() string {
+	for _, attribute := range []string{"sAMAccountName", "displayName", "name", "cn"} {
+		if values := o.Attribute(attribute); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return o.dn
+}
+
+// String returns the Active Directory object class as a string
+func (c Class) String() string {
+	switch c {
+	case USER:
+		return "User"
+	case COMPUTER:
+		return "Computer"
+	case GROUP:
+		return "Group"
+	case GPO:
+		return "GPO"
+	case TRUST:
+		return "Trust"
+	default:
+		return fmt.Sprintf("unknown Active Directory object class %d", c)
+	}
+}
+
+// FromString converts the provided string, such as the ad command's "users" or "gpos" arguments, to a Class
+func FromString(class string) (Class, error) {
+	switch strings.TrimSuffix(strings.ToLower(class), "s") {
+	case "user":
+		return USER, nil
+	case "computer":
+		return COMPUTER, nil
+	case "group":
+		return GROUP, nil
+	case "gpo":
+		return GPO, nil
+	case "trust":
+		return TRUST, nil
+	default:
+		return UNDEFINED, fmt.Errorf("pkg/directory.FromString(): unknown Active Directory object class: %s", class)
+	}
+}
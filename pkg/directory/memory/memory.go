@@ -0,0 +1,84 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving Active Directory objects enumerated by Agents
+package memory
+
+import (
+	// Standard
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/directory"
+)
+
+var (
+	ErrObjectNotFound = errors.New("pkg/directory/memory: the Active Directory object was not found in the repository")
+)
+
+// Repository is the structure that implements the in-memory repository for Active Directory objects
+type Repository struct {
+	sync.RWMutex
+	// objects is keyed by the lower case distinguished name so that objects enumerated again are replaced
+	objects map[string]directory.Object
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{objects: make(map[string]directory.Object)}
+
+// NewRepository returns the in-memory repository for Active Directory objects
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the object in the repository, replacing any previously enumerated object with the same distinguished name
+func (r *Repository) Add(object directory.Object) error {
+	r.Lock()
+	defer r.Unlock()
+	r.objects[strings.ToLower(object.DN())] = object
+	return nil
+}
+
+// Get returns the object for the provided distinguished name
+func (r *Repository) Get(dn string) (directory.Object, error) {
+	r.RLock()
+	defer r.RUnlock()
+	object, ok := r.objects[strings.ToLower(dn)]
+	if !ok {
+		return directory.Object{}, ErrObjectNotFound
+	}
+	return object, nil
+}
+
+// GetAll returns all objects sorted by their distinguished name
+func (r *Repository) GetAll() (all []directory.Object) {
+	r.RLock()
+	for _, object := range r.objects {
+		all = append(all, object)
+	}
+	r.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].DN() < all[j].DN()
+	})
+	return
+}
@@ -0,0 +1,145 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package geoip looks up the location of Agent source addresses in a local MaxMind DB (MMDB) file
+package geoip
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	// 3rd Party
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Location is where an IP address is registered. Fields are empty when the database does not contain them;
+// Country and City databases contain the location and ASN databases contain the network owner
+type Location struct {
+	Country      string // The two-character ISO 3166-1 country code (e.g., US)
+	CountryName  string // The country's English name
+	City         string // The city's English name
+	ASN          uint   // The Autonomous System Number of the network
+	Organization string // The organization that owns the network (e.g., a cloud provider)
+}
+
+// record is the subset of the GeoLite2/GeoIP2 Country, City, and ASN database fields used to build a Location
+type record struct {
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	ASN          uint   `maxminddb:"autonomous_system_number"`
+	Organization string `maxminddb:"autonomous_system_organization"`
+}
+
+var (
+	db   *maxminddb.Reader
+	lock sync.RWMutex
+)
+
+// Open loads the MaxMind DB file used for lookups, replacing any previously loaded database
+func Open(path string) error {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return fmt.Errorf("pkg/geoip.Open(): there was an error opening the GeoIP database at %s: %s", path, err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if db != nil {
+		db.Close() // #nosec G104 the new database is already loaded
+	}
+	db = reader
+	return nil
+}
+
+// Close unloads the GeoIP database
+func Close() error {
+	lock.Lock()
+	defer lock.Unlock()
+	if db == nil {
+		return nil
+	}
+	err := db.Close()
+	db = nil
+	return err
+}
+
+// Enabled returns true if a GeoIP database is loaded
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return db != nil
+}
+
+// Lookup returns the location of an IP address. The address can include a port (e.g., 192.0.2.1:443).
+// An error is returned if a database is not loaded or the address is not in the database, such as private addresses
+func Lookup(addr string) (location Location, err error) {
+	if host, _, e := net.SplitHostPort(addr); e == nil {
+		addr = host
+	}
+	ip := net.ParseIP(strings.Trim(addr, "[]"))
+	if ip == nil {
+		return location, fmt.Errorf("pkg/geoip.Lookup(): invalid IP address: %s", addr)
+	}
+
+	lock.RLock()
+	defer lock.RUnlock()
+	if db == nil {
+		return location, fmt.Errorf("pkg/geoip.Lookup(): a GeoIP database is not loaded")
+	}
+	var r record
+	_, found, err := db.LookupNetwork(ip, &r)
+	if err != nil {
+		return location, fmt.Errorf("pkg/geoip.Lookup(): %s", err)
+	}
+	if !found {
+		return location, fmt.Errorf("pkg/geoip.Lookup(): %s was not found in the GeoIP database", ip)
+	}
+	location.Country = r.Country.ISOCode
+	location.CountryName = r.Country.Names["en"]
+	location.City = r.City.Names["en"]
+	location.ASN = r.ASN
+	location.Organization = r.Organization
+	return
+}
+
+// String returns the location as a single line (e.g., "Seattle, United States (US), AS16509 AMAZON-02")
+func (l Location) String() string {
+	var parts []string
+	if l.City != "" {
+		parts = append(parts, l.City)
+	}
+	switch {
+	case l.CountryName != "" && l.Country != "":
+		parts = append(parts, fmt.Sprintf("%s (%s)", l.CountryName, l.Country))
+	case l.Country != "":
+		parts = append(parts, l.Country)
+	}
+	if l.ASN != 0 {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("AS%d %s", l.ASN, l.Organization)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,225 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package geoip
+
+import (
+	// Standard
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// network is a GeoIP database entry used to build a test database
+type network struct {
+	cidr    string
+	country string
+	name    string
+	city    string
+	asn     uint32
+	org     string
+}
+
+// encodeString encodes a UTF-8 string, shorter than 285 bytes, in the MaxMind DB data section format
+func encodeString(s string) []byte {
+	if len(s) < 29 {
+		return append([]byte{2<<5 | byte(len(s))}, s...)
+	}
+	// Sizes from 29 to 284 are stored in the following byte
+	return append([]byte{2<<5 | 29, byte(len(s) - 29)}, s...)
+}
+
+// encodeUint encodes an unsigned integer of the provided MaxMind DB type (5 is uint16, 6 is uint32)
+func encodeUint(kind byte, v uint32) []byte {
+	b := binary.BigEndian.AppendUint32(nil, v)
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	return append([]byte{kind<<5 | byte(len(b))}, b...)
+}
+
+// encodeMap encodes alternating keys and already encoded values as a MaxMind DB map
+func encodeMap(pairs ...any) []byte {
+	b := []byte{7<<5 | byte(len(pairs)/2)}
+	for i := 0; i < len(pairs); i += 2 {
+		b = append(b, encodeString(pairs[i].(string))...)
+		b = append(b, pairs[i+1].([]byte)...)
+	}
+	return b
+}
+
+// writeDatabase builds an IPv4 MaxMind DB file containing the networks and returns its path
+func writeDatabase(t *testing.T, networks []network) string {
+	t.Helper()
+	const empty = -1
+	// Records are a node index, empty, or -(2 + the index of the network's data)
+	nodes := [][2]int{{empty, empty}}
+	var data []byte
+	var offsets []int
+	for i, n := range networks {
+		_, ipNet, err := net.ParseCIDR(n.cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ones, _ := ipNet.Mask.Size()
+		ip := ipNet.IP.To4()
+		node := 0
+		for bit := 0; bit < ones; bit++ {
+			side := int(ip[bit/8]>>(7-bit%8)) & 1
+			if bit == ones-1 {
+				nodes[node][side] = -(2 + i)
+				break
+			}
+			if nodes[node][side] < 0 {
+				nodes = append(nodes, [2]int{empty, empty})
+				nodes[node][side] = len(nodes) - 1
+			}
+			node = nodes[node][side]
+		}
+		offsets = append(offsets, len(data))
+		data = append(data, encodeMap(
+			"country", encodeMap("iso_code", encodeString(n.country), "names", encodeMap("en", encodeString(n.name))),
+			"city", encodeMap("names", encodeMap("en", encodeString(n.city))),
+			"autonomous_system_number", encodeUint(6, n.asn),
+			"autonomous_system_organization", encodeString(n.org),
+		)...)
+	}
+
+	var db []byte
+	count := len(nodes)
+	for _, node := range nodes {
+		for _, record := range node {
+			value := record
+			switch {
+			case record == empty:
+				value = count
+			case record < empty:
+				value = count + 16 + offsets[-record-2]
+			}
+			db = binary.BigEndian.AppendUint32(db, uint32(value))
+		}
+	}
+	db = append(db, make([]byte, 16)...)
+	db = append(db, data...)
+	db = append(db, "\xAB\xCD\xEFMaxMind.com"...)
+	db = append(db, encodeMap(
+		"node_count", encodeUint(6, uint32(count)),
+		"record_size", encodeUint(5, 32),
+		"ip_version", encodeUint(5, 4),
+		"database_type", encodeString("Merlin-Test"),
+		"binary_format_major_version", encodeUint(5, 2),
+		"binary_format_minor_version", encodeUint(5, 0),
+	)...)
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	err := os.WriteFile(path, db, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// openDatabase loads a test database and unloads it when the test completes
+func openDatabase(t *testing.T) {
+	t.Helper()
+	err := Open(writeDatabase(t, []network{
+		{"192.0.2.0/24", "US", "United States", "Seattle", 16509, "AMAZON-02"},
+		{"198.51.100.0/24", "CA", "Canada", "", 0, ""},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = Close() })
+}
+
+func TestLookup(t *testing.T) {
+	openDatabase(t)
+	tests := []struct {
+		name string
+		addr string
+		want Location
+		err  bool
+	}{
+		{"city and ASN", "192.0.2.10", Location{"US", "United States", "Seattle", 16509, "AMAZON-02"}, false},
+		{"with port", "192.0.2.10:443", Location{"US", "United States", "Seattle", 16509, "AMAZON-02"}, false},
+		{"country only", "198.51.100.1", Location{Country: "CA", CountryName: "Canada"}, false},
+		{"not found", "10.0.0.1", Location{}, true},
+		{"invalid", "merlin", Location{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location, err := Lookup(test.addr)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error looking up %s, have %+v", test.addr, location)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if location != test.want {
+				t.Errorf("expected %+v, have %+v", test.want, location)
+			}
+		})
+	}
+}
+
+// TestOpenClose verifies lookups fail when a database is not loaded
+func TestOpenClose(t *testing.T) {
+	if err := Open(filepath.Join(t.TempDir(), "missing.mmdb")); err == nil {
+		t.Error("expected an error opening a missing database")
+	}
+	openDatabase(t)
+	if !Enabled() {
+		t.Fatal("expected the database to be loaded")
+	}
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	if Enabled() {
+		t.Error("expected the database to be unloaded")
+	}
+	if _, err := Lookup("192.0.2.10"); err == nil {
+		t.Error("expected an error looking up an address without a database")
+	}
+}
+
+func TestLocationString(t *testing.T) {
+	tests := []struct {
+		name     string
+		location Location
+		want     string
+	}{
+		{"all", Location{"US", "United States", "Seattle", 16509, "AMAZON-02"}, "Seattle, United States (US), AS16509 AMAZON-02"},
+		{"country code only", Location{Country: "US"}, "US"},
+		{"ASN only", Location{ASN: 16509}, "AS16509"},
+		{"empty", Location{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.location.String(); got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
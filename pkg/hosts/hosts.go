@@ -0,0 +1,307 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package hosts holds the structures for the hosts in the target environment, such as those discovered by an external
+// scanner, that operators track throughout an engagement
+package hosts
+
+import (
+	// Standard
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Port is a network service discovered on a host
+type Port struct {
+	Number   int    // Number is the port number
+	Protocol string // Protocol is the transport protocol (e.g., tcp or udp)
+	Service  string // Service is the name of the service listening on the port (e.g., ssh)
+	Product  string // Product is the software and version listening on the port (e.g., OpenSSH 8.9)
+}
+
+// Key uniquely identifies the port number and protocol combination
+func (p Port) Key() string {
+	return fmt.Sprintf("%d/%s", p.Number, p.Protocol)
+}
+
+// Finding is a vulnerability or other issue a scanner reported for a host
+type Finding struct {
+	ID       string // ID is the scanner's identifier for the finding (e.g., a Nessus plugin ID)
+	Name     string // Name is the title of the finding
+	Severity string // Severity is how severe the scanner rated the finding (e.g., low, medium, high, critical)
+	Port     int    // Port is the port number the finding was reported on, or 0 if it applies to the whole host
+}
+
+// key uniquely identifies the finding on its port
+func (f Finding) key() string {
+	return fmt.Sprintf("%s/%d", f.ID, f.Port)
+}
+
+// Note is a comment an operator added to a host
+type Note struct {
+	Time time.Time // Time is when the note was added
+	Text string    // Text is the note's contents
+}
+
+// Host is a single system in the target environment identified by its IP address, or by its name when it was targeted
+// by name and its address is not known
+type Host struct {
+	address     string      // address is the IP address of the host
+	addresses   []string    // addresses are the host's other IP addresses, such as those of additional interfaces
+	hostnames   []string    // hostnames are the DNS or NetBIOS names the host is known by
+	os          string      // os is the operating system the host is running
+	ports       []Port      // ports are the network services discovered on the host
+	findings    []Finding   // findings are the vulnerabilities or other issues reported for the host
+	credentials []uuid.UUID // credentials are the stored credentials that were used to access the host
+	notes       []Note      // notes are comments operators added to the host
+	products    []string    // products are the antivirus and EDR products installed on the host
+	sources     []string    // sources are where information about the host came from (e.g., nmap, nessus, agent)
+	firstSeen   time.Time   // firstSeen is when the host was first added
+	lastSeen    time.Time   // lastSeen is when information about the host was last added
+}
+
+// NewHost is a factory to create a Host structure for the provided IP address
+func NewHost(address string) Host {
+	now := time.Now().UTC()
+	return Host{
+		address:   address,
+		firstSeen: now,
+		lastSeen:  now,
+	}
+}
+
+// AddAddress adds another IP address the host is reachable at if it isn't already known
+func (h *Host) AddAddress(address string) {
+	if address == "" || address == h.address {
+		return
+	}
+	for _, a := range h.addresses {
+		if a == address {
+			return
+		}
+	}
+	h.addresses = append(h.addresses, address)
+}
+
+// AddCredential links a stored credential that was used to access the host
+func (h *Host) AddCredential(id uuid.UUID) {
+	for _, c := range h.credentials {
+		if c == id {
+			return
+		}
+	}
+	h.credentials = append(h.credentials, id)
+}
+
+// AddFinding adds a finding to the host, replacing any previous finding with the same ID on the same port
+func (h *Host) AddFinding(finding Finding) {
+	for i, f := range h.findings {
+		if f.key() == finding.key() {
+			h.findings[i] = finding
+			return
+		}
+	}
+	h.findings = append(h.findings, finding)
+}
+
+// AddHostname adds a name the host is known by if it isn't already known
+func (h *Host) AddHostname(name string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	for _, hostname := range h.hostnames {
+		if strings.EqualFold(hostname, name) {
+			return
+		}
+	}
+	h.hostnames = append(h.hostnames, name)
+}
+
+// AddNote adds an operator's comment to the host
+func (h *Host) AddNote(text string) {
+	h.notes = append(h.notes, Note{Time: time.Now().UTC(), Text: text})
+}
+
+// AddPort adds a network service to the host, replacing any previous service on the same port and protocol.
+// The previous service's name and product are kept if the new one doesn't have them
+func (h *Host) AddPort(port Port) {
+	port.Protocol = strings.ToLower(port.Protocol)
+	if port.Protocol == "" {
+		port.Protocol = "tcp"
+	}
+	for i, p := range h.ports {
+		if p.Key() == port.Key() {
+			if port.Service == "" {
+				port.Service = p.Service
+			}
+			if port.Product == "" {
+				port.Product = p.Product
+			}
+			h.ports[i] = port
+			return
+		}
+	}
+	h.ports = append(h.ports, port)
+}
+
+// AddProduct adds an antivirus or EDR product installed on the host if it isn't already known
+func (h *Host) AddProduct(product string) {
+	product = strings.TrimSpace(product)
+	if product == "" {
+		return
+	}
+	for _, p := range h.products {
+		if strings.EqualFold(p, product) {
+			return
+		}
+	}
+	h.products = append(h.products, product)
+}
+
+// AddSource records where information about the host came from
+func (h *Host) AddSource(source string) {
+	for _, s := range h.sources {
+		if s == source {
+			return
+		}
+	}
+	h.sources = append(h.sources, source)
+}
+
+// Address returns the IP address of the host
+func (h *Host) Address() string {
+	return h.address
+}
+
+// Addresses returns the host's primary IP address followed by its other IP addresses
+func (h *Host) Addresses() []string {
+	return append([]string{h.address}, h.addresses...)
+}
+
+// Credentials returns the IDs of the stored credentials that were used to access the host
+func (h *Host) Credentials() []uuid.UUID {
+	return append([]uuid.UUID(nil), h.credentials...)
+}
+
+// Findings returns the vulnerabilities or other issues reported for the host
+func (h *Host) Findings() []Finding {
+	return append([]Finding(nil), h.findings...)
+}
+
+// FirstSeen returns when the host was first added
+func (h *Host) FirstSeen() time.Time {
+	return h.firstSeen
+}
+
+// Hostnames returns the DNS or NetBIOS names the host is known by
+func (h *Host) Hostnames() []string {
+	return append([]string(nil), h.hostnames...)
+}
+
+// LastSeen returns when information about the host was last added
+func (h *Host) LastSeen() time.Time {
+	return h.lastSeen
+}
+
+// Merge adds the information from another record of the same host, such as a later scan, to this one
+func (h *Host) Merge(other Host) {
+	// Copy the slices so that copies of this host, such as those returned by a repository, are not modified
+	h.addresses = append([]string(nil), h.addresses...)
+	h.hostnames = append([]string(nil), h.hostnames...)
+	h.ports = append([]Port(nil), h.ports...)
+	h.findings = append([]Finding(nil), h.findings...)
+	h.credentials = append([]uuid.UUID(nil), h.credentials...)
+	h.notes = append([]Note(nil), h.notes...)
+	h.sources = append([]string(nil), h.sources...)
+	for _, address := range other.Addresses() {
+		h.AddAddress(address)
+	}
+	for _, name := range other.hostnames {
+		h.AddHostname(name)
+	}
+	if other.os != "" {
+		h.os = other.os
+	}
+	for _, p := range other.ports {
+		h.AddPort(p)
+	}
+	for _, f := range other.findings {
+		h.AddFinding(f)
+	}
+	for _, c := range other.credentials {
+		h.AddCredential(c)
+	}
+	h.notes = append(h.notes, other.notes...)
+	for _, p := range other.products {
+		h.AddProduct(p)
+	}
+	for _, s := range other.sources {
+		h.AddSource(s)
+	}
+	if !other.firstSeen.IsZero() && other.firstSeen.Before(h.firstSeen) {
+		h.firstSeen = other.firstSeen
+	}
+	if other.lastSeen.After(h.lastSeen) {
+		h.lastSeen = other.lastSeen
+	}
+}
+
+// Notes returns the comments operators added to the host, oldest first
+func (h *Host) Notes() []Note {
+	return append([]Note(nil), h.notes...)
+}
+
+// OS returns the operating system the host is running
+func (h *Host) OS() string {
+	return h.os
+}
+
+// Ports returns the network services discovered on the host sorted by port number
+func (h *Host) Ports() []Port {
+	ports := append([]Port(nil), h.ports...)
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Number != ports[j].Number {
+			return ports[i].Number < ports[j].Number
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+	return ports
+}
+
+// Products returns the antivirus and EDR products installed on the host
+func (h *Host) Products() []string {
+	return append([]string(nil), h.products...)
+}
+
+// SetOS sets the operating system the host is running
+func (h *Host) SetOS(os string) {
+	h.os = strings.TrimSpace(os)
+}
+
+// Sources returns where information about the host came from
+func (h *Host) Sources() []string {
+	return append([]string(nil), h.sources...)
+}
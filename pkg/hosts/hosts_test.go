@@ -0,0 +1,247 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package hosts
+
+import (
+	// Standard
+	"reflect"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestAddPort verifies ports are unique by number and protocol and that a later service without details keeps the
+// earlier service's name and product
+func TestAddPort(t *testing.T) {
+	cases := []struct {
+		name  string
+		ports []Port
+		want  []Port
+	}{
+		{"default protocol", []Port{{Number: 22}}, []Port{{Number: 22, Protocol: "tcp"}}},
+		{"lowercase protocol", []Port{{Number: 53, Protocol: "UDP"}}, []Port{{Number: 53, Protocol: "udp"}}},
+		{"sorted", []Port{{Number: 443, Protocol: "tcp"}, {Number: 22, Protocol: "tcp"}}, []Port{{Number: 22, Protocol: "tcp"}, {Number: 443, Protocol: "tcp"}}},
+		{"same number different protocol", []Port{{Number: 53, Protocol: "udp"}, {Number: 53, Protocol: "tcp"}}, []Port{{Number: 53, Protocol: "tcp"}, {Number: 53, Protocol: "udp"}}},
+		{"replace", []Port{{Number: 80, Service: "http", Product: "nginx"}, {Number: 80, Service: "https", Product: "Apache"}}, []Port{{Number: 80, Protocol: "tcp", Service: "https", Product: "Apache"}}},
+		{"keep details", []Port{{Number: 80, Service: "http", Product: "nginx 1.18"}, {Number: 80}}, []Port{{Number: 80, Protocol: "tcp", Service: "http", Product: "nginx 1.18"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			host := NewHost("10.0.0.1")
+			for _, p := range c.ports {
+				host.AddPort(p)
+			}
+			if got := host.Ports(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected ports %+v, have %+v", c.want, got)
+			}
+		})
+	}
+}
+
+// TestAddFinding verifies findings are unique by ID and port
+func TestAddFinding(t *testing.T) {
+	cases := []struct {
+		name     string
+		findings []Finding
+		want     []Finding
+	}{
+		{"single", []Finding{{ID: "1", Severity: "high"}}, []Finding{{ID: "1", Severity: "high"}}},
+		{"different port", []Finding{{ID: "1", Port: 80}, {ID: "1", Port: 443}}, []Finding{{ID: "1", Port: 80}, {ID: "1", Port: 443}}},
+		{"replace", []Finding{{ID: "1", Port: 80, Severity: "low"}, {ID: "1", Port: 80, Severity: "critical"}}, []Finding{{ID: "1", Port: 80, Severity: "critical"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			host := NewHost("10.0.0.1")
+			for _, f := range c.findings {
+				host.AddFinding(f)
+			}
+			if got := host.Findings(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected findings %+v, have %+v", c.want, got)
+			}
+		})
+	}
+}
+
+// TestAddHostname verifies empty names are ignored and names are unique regardless of case
+func TestAddHostname(t *testing.T) {
+	cases := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{"empty", []string{"", "  "}, nil},
+		{"trimmed", []string{" dc01 "}, []string{"dc01"}},
+		{"case insensitive", []string{"DC01", "dc01", "dc01.corp.local"}, []string{"DC01", "dc01.corp.local"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			host := NewHost("10.0.0.1")
+			for _, name := range c.names {
+				host.AddHostname(name)
+			}
+			if got := host.Hostnames(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected hostnames %+v, have %+v", c.want, got)
+			}
+		})
+	}
+}
+
+// TestAddAddress verifies the primary address is listed first and other addresses are unique
+func TestAddAddress(t *testing.T) {
+	cases := []struct {
+		name      string
+		addresses []string
+		want      []string
+	}{
+		{"primary only", []string{"10.0.0.1", ""}, []string{"10.0.0.1"}},
+		{"other", []string{"192.168.1.1", "172.16.0.1"}, []string{"10.0.0.1", "192.168.1.1", "172.16.0.1"}},
+		{"duplicate", []string{"192.168.1.1", "192.168.1.1"}, []string{"10.0.0.1", "192.168.1.1"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			host := NewHost("10.0.0.1")
+			for _, address := range c.addresses {
+				host.AddAddress(address)
+			}
+			if got := host.Addresses(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected addresses %v, have %v", c.want, got)
+			}
+		})
+	}
+}
+
+// TestAddCredential verifies linked credentials are unique
+func TestAddCredential(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	cases := []struct {
+		name string
+		ids  []uuid.UUID
+		want []uuid.UUID
+	}{
+		{"none", nil, nil},
+		{"unique", []uuid.UUID{a, b}, []uuid.UUID{a, b}},
+		{"duplicate", []uuid.UUID{a, a}, []uuid.UUID{a}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			host := NewHost("10.0.0.1")
+			for _, id := range c.ids {
+				host.AddCredential(id)
+			}
+			if got := host.Credentials(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected credentials %v, have %v", c.want, got)
+			}
+		})
+	}
+}
+
+// TestAddProduct verifies security products are trimmed and unique regardless of case
+func TestAddProduct(t *testing.T) {
+	cases := []struct {
+		name     string
+		products []string
+		want     []string
+	}{
+		{"empty", []string{"", "  "}, nil},
+		{"trimmed", []string{" Windows Defender (av, enabled, wsc) "}, []string{"Windows Defender (av, enabled, wsc)"}},
+		{"case insensitive", []string{"Sysmon (edr)", "sysmon (EDR)", "osquery"}, []string{"Sysmon (edr)", "osquery"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			host := NewHost("10.0.0.1")
+			for _, product := range c.products {
+				host.AddProduct(product)
+			}
+			if got := host.Products(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected products %v, have %v", c.want, got)
+			}
+		})
+	}
+}
+
+// TestMerge verifies information from a later record is added to the host and that copies of the host made before
+// the merge are not modified
+func TestMerge(t *testing.T) {
+	earlier := time.Now().UTC().Add(-time.Hour)
+	host := NewHost("10.0.0.1")
+	host.firstSeen = earlier
+	host.lastSeen = earlier
+	host.AddSource("nmap")
+	host.AddHostname("dc01")
+	host.SetOS("Windows Server 2019")
+	host.AddPort(Port{Number: 445, Service: "microsoft-ds"})
+	host.AddNote("domain controller")
+	copied := host
+
+	other := NewHost("10.0.0.1")
+	other.AddSource("nessus")
+	other.AddSource("nmap")
+	other.AddHostname("DC01")
+	other.AddHostname("dc01.corp.local")
+	other.AddPort(Port{Number: 445, Product: "Samba"})
+	other.AddPort(Port{Number: 3389})
+	other.AddFinding(Finding{ID: "57608", Name: "SMB Signing not required", Severity: "medium", Port: 445})
+	other.AddAddress("192.168.1.1")
+	credential := uuid.New()
+	other.AddCredential(credential)
+	other.AddNote("SMB signing disabled")
+	other.AddProduct("Windows Defender")
+	host.Merge(other)
+
+	cases := []struct {
+		name string
+		have interface{}
+		want interface{}
+	}{
+		{"addresses", host.Addresses(), []string{"10.0.0.1", "192.168.1.1"}},
+		{"hostnames", host.Hostnames(), []string{"dc01", "dc01.corp.local"}},
+		{"credentials", host.Credentials(), []uuid.UUID{credential}},
+		{"notes", len(host.Notes()), 2},
+		{"copy notes", len(copied.Notes()), 1},
+		{"os", host.OS(), "Windows Server 2019"},
+		{"ports", host.Ports(), []Port{{Number: 445, Protocol: "tcp", Service: "microsoft-ds", Product: "Samba"}, {Number: 3389, Protocol: "tcp"}}},
+		{"findings", len(host.Findings()), 1},
+		{"products", host.Products(), []string{"Windows Defender"}},
+		{"sources", host.Sources(), []string{"nmap", "nessus"}},
+		{"first seen", host.FirstSeen(), earlier},
+		{"last seen", host.LastSeen(), other.LastSeen()},
+		{"copy ports", copied.Ports(), []Port{{Number: 445, Protocol: "tcp", Service: "microsoft-ds"}}},
+		{"copy sources", copied.Sources(), []string{"nmap"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !reflect.DeepEqual(c.have, c.want) {
+				t.Errorf("expected %+v, have %+v", c.want, c.have)
+			}
+		})
+	}
+
+	var empty Host
+	host.Merge(empty)
+	if host.OS() != "Windows Server 2019" {
+		t.Errorf("expected merging a host without an OS to keep the OS, have %q", host.OS())
+	}
+	if !host.FirstSeen().Equal(earlier) {
+		t.Errorf("expected merging a host without a first seen time to keep %s, have %s", earlier, host.FirstSeen())
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving hosts in the target environment
+package memory
+
+import (
+	// Standard
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/hosts"
+)
+
+// Repository is the structure that implements the in-memory repository for hosts
+type Repository struct {
+	sync.RWMutex
+	// hosts is keyed by IP address so that information from later sources is merged into the existing host
+	hosts map[string]hosts.Host
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{hosts: make(map[string]hosts.Host)}
+
+// NewRepository returns the in-memory repository for hosts
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the host in the repository, merging it with any existing host that has the same primary IP address
+func (r *Repository) Add(host hosts.Host) error {
+	r.Lock()
+	defer r.Unlock()
+	if existing, ok := r.hosts[host.Address()]; ok {
+		existing.Merge(host)
+		r.hosts[host.Address()] = existing
+		return nil
+	}
+	r.hosts[host.Address()] = host
+	return nil
+}
+
+// Get returns the host with the provided primary or secondary IP address
+func (r *Repository) Get(address string) (hosts.Host, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if host, ok := r.hosts[address]; ok {
+		return host, nil
+	}
+	for _, host := range r.hosts {
+		for _, a := range host.Addresses() {
+			if a == address {
+				return host, nil
+			}
+		}
+	}
+	return hosts.Host{}, fmt.Errorf("pkg/hosts/memory.Get(): host %s does not exist", address)
+}
+
+// GetAll returns all hosts sorted by IP address
+func (r *Repository) GetAll() (all []hosts.Host) {
+	r.RLock()
+	for _, host := range r.hosts {
+		all = append(all, host)
+	}
+	r.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		a, b := net.ParseIP(all[i].Address()), net.ParseIP(all[j].Address())
+		if a != nil && b != nil {
+			return bytes.Compare(a.To16(), b.To16()) < 0
+		}
+		return all[i].Address() < all[j].Address()
+	})
+	return
+}
@@ -0,0 +1,131 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	// Standard
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/hosts"
+)
+
+// newTestRepository returns the in-memory repository emptied of any hosts added by other tests
+func newTestRepository(t *testing.T) *Repository {
+	r := NewRepository()
+	reset := func() {
+		r.Lock()
+		r.hosts = make(map[string]hosts.Host)
+		r.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+	return r
+}
+
+// TestRepository verifies hosts are merged by IP address and returned sorted by IP address
+func TestRepository(t *testing.T) {
+	r := newTestRepository(t)
+	if r != NewRepository() {
+		t.Fatal("expected NewRepository to return the same repository")
+	}
+	for _, address := range []string{"10.0.0.10", "10.0.0.9", "10.0.0.10", "fe80::1"} {
+		host := hosts.NewHost(address)
+		host.AddPort(hosts.Port{Number: len(address)})
+		if err := r.Add(host); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var addresses []string
+	for _, host := range r.GetAll() {
+		addresses = append(addresses, host.Address())
+	}
+	if want := []string{"10.0.0.9", "10.0.0.10", "fe80::1"}; !reflect.DeepEqual(addresses, want) {
+		t.Errorf("expected hosts %v, have %v", want, addresses)
+	}
+
+	cases := []struct {
+		address string
+		ports   int
+		err     bool
+	}{
+		{"10.0.0.9", 1, false},
+		{"10.0.0.10", 1, false},
+		{"fe80::1", 1, false},
+		{"10.0.0.11", 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.address, func(t *testing.T) {
+			host, err := r.Get(c.address)
+			if (err != nil) != c.err {
+				t.Fatalf("expected error %t, have %v", c.err, err)
+			}
+			if len(host.Ports()) != c.ports {
+				t.Errorf("expected %d ports, have %d", c.ports, len(host.Ports()))
+			}
+		})
+	}
+}
+
+// TestRepositoryConcurrentAccess adds and reads hosts from many goroutines, the way concurrent imports and RPC clients
+// do, and verifies every port is merged into the host. Run with the -race flag to detect data races
+func TestRepositoryConcurrentAccess(t *testing.T) {
+	r := newTestRepository(t)
+	const writers, ports = 10, 20
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < ports; i++ {
+				host := hosts.NewHost("10.0.0.1")
+				host.AddPort(hosts.Port{Number: w*ports + i + 1})
+				host.AddSource(fmt.Sprintf("writer%d", w))
+				r.Add(host)
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ports; i++ {
+				for _, host := range r.GetAll() {
+					host.Ports()
+					host.Sources()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	host, err := r.Get("10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(host.Ports()) != writers*ports {
+		t.Errorf("expected %d ports, have %d", writers*ports, len(host.Ports()))
+	}
+	if len(host.Sources()) != writers {
+		t.Errorf("expected %d sources, have %d", writers, len(host.Sources()))
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package ioc holds the structures for Indicators of Compromise (IOC) that Agent jobs leave on target hosts
+package ioc
+
+import (
+	// Standard
+	"fmt"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Type is the kind of artifact an indicator describes
+type Type int
+
+const (
+	UNDEFINED Type = iota
+	// FILE is a file written to a host
+	FILE
+	// PROCESS is a process spawned on a host
+	PROCESS
+	// SERVICE is a Windows service created on a host
+	SERVICE
+	// REGISTRY is a Windows registry key or value that was modified
+	REGISTRY
+	// PERSISTENCE is a scheduled task, WMI subscription, launchd agent, or systemd unit created on a host
+	PERSISTENCE
+)
+
+// Indicator is a single artifact an Agent job created or modified on a target host
+type Indicator struct {
+	id        uuid.UUID // id is the unique identifier for the indicator
+	agentID   uuid.UUID // agentID is the Agent that executed the job
+	jobID     string    // jobID is the job that created the artifact
+	host      string    // host is the hostname or address the artifact was created on
+	indicator Type      // indicator is the kind of artifact
+	value     string    // value identifies the artifact (e.g., file path, command line, service name, or registry key)
+	detail    string    // detail is additional information about the artifact (e.g., a SHA256 hash)
+	created   time.Time // created is when the job was created
+}
+
+// NewIndicator is a factory to create an Indicator structure
+func NewIndicator(agentID uuid.UUID, jobID, host string, indicator Type, value, detail string) Indicator {
+	return Indicator{
+		id:        uuid.New(),
+		agentID:   agentID,
+		jobID:     jobID,
+		host:      host,
+		indicator: indicator,
+		value:     value,
+		detail:    detail,
+		created:   time.Now().UTC(),
+	}
+}
+
+// AgentID returns the Agent that executed the job
+func (i *Indicator) AgentID() uuid.UUID {
+	return i.agentID
+}
+
+// Created returns when the job was created
+func (i *Indicator) Created() time.Time {
+	return i.created
+}
+
+// Detail returns additional information about the artifact
+func (i *Indicator) Detail() string {
+	return i.detail
+}
+
+// Host returns the hostname or address the artifact was created on
+func (i *Indicator) Host() string {
+	return i.host
+}
+
+// ID returns the indicator's unique identifier
+func (i *Indicator) ID() uuid.UUID {
+	return i.id
+}
+
+// JobID returns the job that created the artifact
+func (i *Indicator) JobID() string {
+	return i.jobID
+}
+
+// Type returns the kind of artifact
+func (i *Indicator) Type() Type {
+	return i.indicator
+}
+
+// Value returns the file path, command line, service name, or registry key that identifies the artifact
+func (i *Indicator) Value() string {
+	return i.value
+}
+
+// String returns the indicator type as a string
+func (t Type) String() string {
+	switch t {
+	case FILE:
+		return "File"
+	case PROCESS:
+		return "Process"
+	case SERVICE:
+		return "Service"
+	case REGISTRY:
+		return "Registry"
+	case PERSISTENCE:
+		return "Persistence"
+	default:
+		return fmt.Sprintf("unknown IOC type %d", t)
+	}
+}
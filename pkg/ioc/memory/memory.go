@@ -0,0 +1,64 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving Indicators of Compromise
+package memory
+
+import (
+	// Standard
+	"sort"
+	"sync"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc"
+)
+
+// Repository is the structure that implements the in-memory repository for Indicators of Compromise
+type Repository struct {
+	sync.RWMutex
+	indicators []ioc.Indicator
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{}
+
+// NewRepository returns the in-memory repository for Indicators of Compromise
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the indicator in the repository
+func (r *Repository) Add(indicator ioc.Indicator) error {
+	r.Lock()
+	defer r.Unlock()
+	r.indicators = append(r.indicators, indicator)
+	return nil
+}
+
+// GetAll returns all indicators sorted by the time they were created
+func (r *Repository) GetAll() (all []ioc.Indicator) {
+	r.RLock()
+	all = append(all, r.indicators...)
+	r.RUnlock()
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Created().Before(all[j].Created())
+	})
+	return
+}
@@ -0,0 +1,153 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+const (
+	// CompressedPrefix marks a FileTransfer blob that was gzip compressed before it was Base64 encoded.
+	// The colon is not part of the Base64 alphabet so the prefix can't be confused with an uncompressed blob
+	CompressedPrefix = "gzip:"
+	// minCompress is the smallest file, in bytes, worth compressing
+	minCompress = 1024
+	// maxDecompress is the largest file, in bytes, a compressed blob is allowed to expand to
+	maxDecompress = 4 << 30
+)
+
+// magics are the leading bytes of file formats that are already compressed
+var magics = [][]byte{
+	{0x1f, 0x8b},                       // gzip
+	{'P', 'K', 0x03, 0x04},             // zip, docx, xlsx, jar, apk
+	{0x28, 0xb5, 0x2f, 0xfd},           // zstd
+	{0xfd, '7', 'z', 'X', 'Z', 0x00},   // xz
+	{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}, // 7-Zip
+	{'B', 'Z', 'h'},                    // bzip2
+	{'R', 'a', 'r', '!', 0x1a, 0x07},   // RAR
+	{0x89, 'P', 'N', 'G'},              // PNG
+	{0xff, 0xd8, 0xff},                 // JPEG
+	{'G', 'I', 'F', '8'},               // GIF
+	{0x04, 0x22, 0x4d, 0x18},           // LZ4
+	{'M', 'S', 'C', 'F'},               // Microsoft Cabinet
+}
+
+// compression contains the IDs of Agents that sent a compressed file and therefore can receive compressed files
+var compression = struct {
+	sync.RWMutex
+	agents map[uuid.UUID]bool
+}{agents: make(map[uuid.UUID]bool)}
+
+// Compression returns true if the Agent has sent a compressed file and can therefore receive compressed files
+func Compression(agent uuid.UUID) bool {
+	compression.RLock()
+	defer compression.RUnlock()
+	return compression.agents[agent]
+}
+
+// DecodeAgentBlob decodes a FileTransfer blob the Agent sent. An Agent that sends a compressed blob is recorded as
+// being able to receive compressed files
+func DecodeAgentBlob(agent uuid.UUID, blob string) ([]byte, error) {
+	if strings.HasPrefix(blob, CompressedPrefix) && !Compression(agent) {
+		compression.Lock()
+		compression.agents[agent] = true
+		compression.Unlock()
+	}
+	return DecodeBlob(blob)
+}
+
+// Compressible returns false if the data is too small to benefit from compression or its magic bytes identify a
+// format that is already compressed
+func Compressible(data []byte) bool {
+	if len(data) < minCompress {
+		return false
+	}
+	for _, magic := range magics {
+		if bytes.HasPrefix(data, magic) {
+			return false
+		}
+	}
+	// RIFF containers like WebP and media files with an ftyp box (e.g., MP4) are compressed
+	if bytes.HasPrefix(data, []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")) {
+		return false
+	}
+	return !bytes.Equal(data[4:8], []byte("ftyp"))
+}
+
+// CompressBlob gzip compresses a Base64 encoded FileTransfer blob and returns it with the CompressedPrefix.
+// The original blob is returned if it can't be decoded, isn't compressible, or doesn't get smaller
+func CompressBlob(blob string) string {
+	if strings.HasPrefix(blob, CompressedPrefix) {
+		return blob
+	}
+	data, err := base64.StdEncoding.DecodeString(blob)
+	if err != nil || !Compressible(data) {
+		return blob
+	}
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	if err != nil {
+		return blob
+	}
+	if _, err = w.Write(data); err != nil {
+		return blob
+	}
+	if err = w.Close(); err != nil {
+		return blob
+	}
+	compressed := CompressedPrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+	if len(compressed) >= len(blob) {
+		return blob
+	}
+	return compressed
+}
+
+// DecodeBlob Base64 decodes a FileTransfer blob and decompresses it if it has the CompressedPrefix
+func DecodeBlob(blob string) ([]byte, error) {
+	encoded, compressed := strings.CutPrefix(blob, CompressedPrefix)
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || !compressed {
+		return data, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("pkg/jobs.DecodeBlob(): there was an error reading the compressed blob: %s", err)
+	}
+	defer r.Close()
+	data, err = io.ReadAll(io.LimitReader(r, maxDecompress+1))
+	if err != nil {
+		return nil, fmt.Errorf("pkg/jobs.DecodeBlob(): there was an error decompressing the blob: %s", err)
+	}
+	if len(data) > maxDecompress {
+		return nil, fmt.Errorf("pkg/jobs.DecodeBlob(): the compressed blob expands to more than %d bytes", maxDecompress)
+	}
+	return data, nil
+}
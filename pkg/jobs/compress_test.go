@@ -0,0 +1,135 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+func TestCompressible(t *testing.T) {
+	text := bytes.Repeat([]byte("merlin "), 512)
+	webp := append([]byte("RIFF\x00\x00\x00\x00WEBP"), text...)
+	mp4 := append([]byte("\x00\x00\x00\x20ftypisom"), text...)
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"too small", []byte("merlin"), false},
+		{"text", text, true},
+		{"gzip", append([]byte{0x1f, 0x8b}, text...), false},
+		{"zip", append([]byte("PK\x03\x04"), text...), false},
+		{"png", append([]byte("\x89PNG"), text...), false},
+		{"webp", webp, false},
+		{"mp4", mp4, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Compressible(test.data); got != test.want {
+				t.Errorf("expected %t, have %t", test.want, got)
+			}
+		})
+	}
+}
+
+// TestCompressBlob verifies compressed blobs decode to the original data and blobs that wouldn't benefit from
+// compression are returned unchanged
+func TestCompressBlob(t *testing.T) {
+	text := bytes.Repeat([]byte("merlin "), 512)
+	tests := []struct {
+		name       string
+		data       []byte
+		compressed bool
+	}{
+		{"text", text, true},
+		{"too small", []byte("merlin"), false},
+		{"already compressed", append([]byte{0x1f, 0x8b}, text...), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blob := CompressBlob(base64.StdEncoding.EncodeToString(test.data))
+			if strings.HasPrefix(blob, CompressedPrefix) != test.compressed {
+				t.Fatalf("expected compressed to be %t, have blob %.32q", test.compressed, blob)
+			}
+			if CompressBlob(blob) != blob {
+				t.Error("expected compressing a blob twice to return it unchanged")
+			}
+			data, err := DecodeBlob(blob)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, test.data) {
+				t.Error("the decoded blob does not match the original data")
+			}
+		})
+	}
+}
+
+func TestDecodeBlob(t *testing.T) {
+	tests := []struct {
+		name    string
+		blob    string
+		wantErr bool
+	}{
+		{"plain", base64.StdEncoding.EncodeToString([]byte("merlin")), false},
+		{"invalid base64", "merlin!", true},
+		{"invalid gzip", CompressedPrefix + base64.StdEncoding.EncodeToString([]byte("merlin")), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := DecodeBlob(test.blob)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestDecodeAgentBlob verifies only Agents that send a compressed blob are recorded as supporting compression
+func TestDecodeAgentBlob(t *testing.T) {
+	compressed := CompressBlob(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("merlin "), 512)))
+	tests := []struct {
+		name string
+		blob string
+		want bool
+	}{
+		{"plain", base64.StdEncoding.EncodeToString([]byte("merlin")), false},
+		{"compressed", compressed, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			agent := uuid.New()
+			if _, err := DecodeAgentBlob(agent, test.blob); err != nil {
+				t.Fatal(err)
+			}
+			if got := Compression(agent); got != test.want {
+				t.Errorf("expected compression support to be %t, have %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Sort keys used to order jobs
+const (
+	SortCreated   = "created"
+	SortSent      = "sent"
+	SortCompleted = "completed"
+	SortStatus    = "status"
+	SortAgent     = "agent"
+	SortCommand   = "command"
+)
+
+// Filter selects, orders, and pages through jobs so operators can find jobs on long engagements
+type Filter struct {
+	Status   []string  // Status is the job statuses to include (e.g., Sent, Complete); empty includes every status
+	Agent    uuid.UUID // Agent only includes jobs for this Agent; uuid.Nil includes every Agent
+	Command  string    // Command only includes jobs whose command contains this case-insensitive text
+	Since    time.Time // Since only includes jobs created at or after this time
+	Sort     string    // Sort is the key jobs are ordered by; jobs are ordered by when they were created by default
+	Reverse  bool      // Reverse orders jobs from the highest to the lowest value, such as the newest job first
+	Page     int       // Page is the 1-based page of jobs to return
+	PageSize int       // PageSize is the number of jobs on each page; 0 returns every job
+}
+
+// Validate ensures the Filter's status values, sort key, and paging are valid
+func (f Filter) Validate() error {
+	for _, status := range f.Status {
+		valid := false
+		for s := CREATED; ; s++ {
+			info := Info{status: s}
+			if info.StatusString() == "Unknown" {
+				break
+			}
+			valid = valid || strings.EqualFold(status, info.StatusString())
+		}
+		if !valid {
+			return fmt.Errorf("invalid job status '%s'", status)
+		}
+	}
+	switch strings.ToLower(f.Sort) {
+	case "", SortCreated, SortSent, SortCompleted, SortStatus, SortAgent, SortCommand:
+	default:
+		return fmt.Errorf("invalid job sort key '%s', expected one of %s", f.Sort, strings.Join([]string{SortCreated, SortSent, SortCompleted, SortStatus, SortAgent, SortCommand}, ", "))
+	}
+	if f.Page < 0 || f.PageSize < 0 {
+		return fmt.Errorf("the job page and page size must be 0 or more")
+	}
+	return nil
+}
+
+// Match returns true if the job is selected by the Filter
+func (f Filter) Match(info Info) bool {
+	if len(f.Status) > 0 && !slices.ContainsFunc(f.Status, func(s string) bool { return strings.EqualFold(s, info.StatusString()) }) {
+		return false
+	}
+	if f.Agent != uuid.Nil && info.AgentID() != f.Agent {
+		return false
+	}
+	if f.Command != "" && !strings.Contains(strings.ToLower(info.Command()), strings.ToLower(f.Command)) {
+		return false
+	}
+	if !f.Since.IsZero() && info.Created().Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the page of jobs selected by the Filter in the Filter's order and the total number of selected jobs
+func (f Filter) Apply(all []Info) (page []Info, total int) {
+	var selected []Info
+	for _, info := range all {
+		if f.Match(info) {
+			selected = append(selected, info)
+		}
+	}
+	// Jobs are stored in a map, so order them by creation first so that jobs with the same sort value are consistent
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Created().Equal(selected[j].Created()) {
+			return selected[i].ID() < selected[j].ID()
+		}
+		return selected[i].Created().Before(selected[j].Created())
+	})
+	less := func(a, b Info) bool {
+		switch strings.ToLower(f.Sort) {
+		case SortSent:
+			return a.Sent().Before(b.Sent())
+		case SortCompleted:
+			return a.Completed().Before(b.Completed())
+		case SortStatus:
+			return a.Status() < b.Status()
+		case SortAgent:
+			return a.AgentID().String() < b.AgentID().String()
+		case SortCommand:
+			return a.Command() < b.Command()
+		default:
+			return a.Created().Before(b.Created())
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		if f.Reverse {
+			return less(selected[j], selected[i])
+		}
+		return less(selected[i], selected[j])
+	})
+
+	total = len(selected)
+	if f.PageSize <= 0 {
+		return selected, total
+	}
+	start := max(f.Page-1, 0) * f.PageSize
+	if start >= total {
+		return nil, total
+	}
+	return selected[start:min(start+f.PageSize, total)], total
+}
@@ -0,0 +1,103 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"slices"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestFilterValidate verifies invalid statuses, sort keys, and paging are rejected
+func TestFilterValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantErr bool
+	}{
+		{"empty", Filter{}, false},
+		{"statuses", Filter{Status: []string{"sent", "Complete"}}, false},
+		{"sort", Filter{Sort: "Command"}, false},
+		{"paging", Filter{Page: 2, PageSize: 10}, false},
+		{"invalid status", Filter{Status: []string{"Finished"}}, true},
+		{"invalid sort", Filter{Sort: "size"}, true},
+		{"negative page", Filter{Page: -1}, true},
+		{"negative page size", Filter{PageSize: -1}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.filter.Validate()
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestFilterApply verifies jobs are selected, ordered, and paged
+func TestFilterApply(t *testing.T) {
+	agent1 := uuid.MustParse("11111111-1111-4111-8111-111111111111")
+	agent2 := uuid.MustParse("22222222-2222-4222-8222-222222222222")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	all := []Info{
+		{id: "c", agentID: agent2, status: COMPLETE, command: "run whoami", created: start.Add(2 * time.Hour)},
+		{id: "a", agentID: agent1, status: SENT, command: "ls /tmp", created: start},
+		{id: "d", agentID: agent1, status: CREATED, command: "pwd", created: start.Add(3 * time.Hour)},
+		{id: "b", agentID: agent1, status: COMPLETE, command: "RUN hostname", created: start.Add(time.Hour)},
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+		total  int
+	}{
+		{"all by creation", Filter{}, []string{"a", "b", "c", "d"}, 4},
+		{"newest first", Filter{Reverse: true}, []string{"d", "c", "b", "a"}, 4},
+		{"status", Filter{Status: []string{"complete"}}, []string{"b", "c"}, 2},
+		{"agent", Filter{Agent: agent2}, []string{"c"}, 1},
+		{"command", Filter{Command: "run"}, []string{"b", "c"}, 2},
+		{"since", Filter{Since: start.Add(2 * time.Hour)}, []string{"c", "d"}, 2},
+		{"sort by command", Filter{Sort: SortCommand}, []string{"b", "a", "d", "c"}, 4},
+		{"sort by status", Filter{Sort: SortStatus}, []string{"d", "a", "b", "c"}, 4},
+		{"first page", Filter{Page: 1, PageSize: 3}, []string{"a", "b", "c"}, 4},
+		{"second page", Filter{Page: 2, PageSize: 3}, []string{"d"}, 4},
+		{"past the last page", Filter{Page: 3, PageSize: 3}, nil, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page, total := test.filter.Apply(all)
+			var have []string
+			for _, info := range page {
+				have = append(have, info.ID())
+			}
+			if !slices.Equal(have, test.want) {
+				t.Errorf("expected jobs %v, have %v", test.want, have)
+			}
+			if total != test.total {
+				t.Errorf("expected %d selected jobs, have %d", test.total, total)
+			}
+		})
+	}
+}
@@ -40,28 +40,41 @@ const (
 	CREATED
 	// SENT is used to denote that the job has been sent to the Agent
 	SENT
-	// RETURNED is for when a chunk has been returned but the job hasn't finished running
+	// RECEIVED is used to denote that the Agent acknowledged it received the job
+	RECEIVED
+	// RETURNED is used to denote that the job is in progress because the Agent acknowledged it started the job or
+	// returned a chunk of its results before the job finished running
 	RETURNED
 	// COMPLETE is used to denote that the job has finished running and the Agent has sent back the results
 	COMPLETE
+	// FAILED is used to denote that the job finished running and the Agent only sent back an error
+	FAILED
 	// CANCELED is used to denoted jobs that were cancelled with the "clear" command
 	CANCELED
 	// ACTIVE is used with SOCKS connections to show the connection between the SOCKS client and server is active
 	ACTIVE
+	// PENDING is used to denote a job created while the Agent was delayed or dead that is delivered on its next check in
+	PENDING
+	// EXPIRED is used to denote a job that was discarded because the Agent didn't check in before the job's expiry
+	EXPIRED
 )
 
 // Info is a structure for holding data for single task assigned to a single agent
 type Info struct {
-	id        string    // id is a unique identifier for the job
-	agentID   uuid.UUID // ID of the agent the job belong to
-	jobType   string    // Type of job
-	token     uuid.UUID // A unique token for each task that acts like a CSRF token to prevent multiple job messages
-	status    Status    // Use JOB_ constants
-	chunk     int       // The chunk number
-	created   time.Time // Time the job was created
-	sent      time.Time // Time the job was sent to the agent
-	completed time.Time // Time the job finished
-	command   string    // The actual command
+	id        string            // id is a unique identifier for the job
+	agentID   uuid.UUID         // ID of the agent the job belong to
+	jobType   string            // Type of job
+	token     uuid.UUID         // A unique token for each task that acts like a CSRF token to prevent multiple job messages
+	status    Status            // Use JOB_ constants
+	chunk     int               // The chunk number
+	created   time.Time         // Time the job was created
+	sent      time.Time         // Time the job was sent to the agent
+	completed time.Time         // Time the job finished
+	expires   time.Time         // Time after which the job is discarded instead of being sent; zero never expires
+	command   string            // The actual command
+	attack    []string          // MITRE ATT&CK technique IDs the job exercises
+	injection string            // The process injection technique the job uses, if any
+	metadata  map[string]string // Additional server-side information about the job that is applied when the job completes
 }
 
 // NewInfo is a factory to return an Info structure used to track a job's status
@@ -93,7 +106,7 @@ func NewInfoWithID(agent uuid.UUID, jobType string, cmd string, id string, token
 
 // Active set's the Job Info status to "active"
 func (i *Info) Active() {
-	i.status = ACTIVE
+	i.transition(ACTIVE)
 }
 
 // AgentID returns the associated Agent's ID
@@ -101,10 +114,16 @@ func (i *Info) AgentID() uuid.UUID {
 	return i.agentID
 }
 
+// Attack returns the MITRE ATT&CK technique IDs the Job exercises
+func (i *Info) Attack() []string {
+	return i.attack
+}
+
 // Cancel set's the Job Info status to "canceled"
 func (i *Info) Cancel() {
-	i.completed = time.Now().UTC()
-	i.status = CANCELED
+	if i.transition(CANCELED) {
+		i.completed = time.Now().UTC()
+	}
 }
 
 // Command returns the command associated with the Job
@@ -114,8 +133,9 @@ func (i *Info) Command() string {
 
 // Complete set's the Job Info status to "complete"
 func (i *Info) Complete() {
-	i.completed = time.Now().UTC()
-	i.status = COMPLETE
+	if i.transition(COMPLETE) {
+		i.completed = time.Now().UTC()
+	}
 }
 
 // Completed returns the time of when the Job completed
@@ -128,15 +148,95 @@ func (i *Info) Created() time.Time {
 	return i.created
 }
 
+// Done returns true if the Job completed, failed, was canceled, or expired and will not be sent to or return from the
+// Agent
+func (i *Info) Done() bool {
+	return i.status.Final()
+}
+
+// Expire set's the Job Info status to "expired"
+func (i *Info) Expire() {
+	if i.transition(EXPIRED) {
+		i.completed = time.Now().UTC()
+	}
+}
+
+// Expired returns true if the Job has an expiry that has passed
+func (i *Info) Expired() bool {
+	return !i.expires.IsZero() && time.Now().After(i.expires)
+}
+
+// Expires returns the time after which the Job is discarded instead of being sent to the Agent
+func (i *Info) Expires() time.Time {
+	return i.expires
+}
+
+// Fail set's the Job Info status to "failed"
+func (i *Info) Fail() {
+	if i.transition(FAILED) {
+		i.completed = time.Now().UTC()
+	}
+}
+
 // ID returns the Job's unique identifier
 func (i *Info) ID() string {
 	return i.id
 }
 
+// Injection returns the process injection technique the Job uses, if any
+func (i *Info) Injection() string {
+	return i.injection
+}
+
+// Metadata returns the value for the provided key from the Job's server-side metadata and if it was found
+func (i *Info) Metadata(key string) (string, bool) {
+	value, ok := i.metadata[key]
+	return value, ok
+}
+
+// Pending set's the Job Info status to "pending delivery"
+func (i *Info) Pending() {
+	i.transition(PENDING)
+}
+
+// Progress set's the Job Info status to "in progress"
+func (i *Info) Progress() {
+	i.transition(RETURNED)
+}
+
+// Receive set's the Job Info status to "received"
+func (i *Info) Receive() {
+	i.transition(RECEIVED)
+}
+
+// SetAttack set's the MITRE ATT&CK technique IDs the Job exercises
+func (i *Info) SetAttack(techniques []string) {
+	i.attack = techniques
+}
+
+// SetExpiry set's the time after which the Job is discarded instead of being sent; the zero time never expires
+func (i *Info) SetExpiry(expires time.Time) {
+	i.expires = expires
+}
+
+// SetInjection set's the process injection technique the Job uses
+func (i *Info) SetInjection(method string) {
+	i.injection = method
+}
+
+// SetMetadata adds the key and value to the Job's server-side metadata
+func (i *Info) SetMetadata(key, value string) {
+	if i.metadata == nil {
+		i.metadata = make(map[string]string)
+	}
+	i.metadata[key] = value
+}
+
 // Send set's the Job Info status to "sent"
 func (i *Info) Send() {
-	i.sent = time.Now().UTC()
-	i.status = SENT
+	if i.transition(SENT) {
+		i.sent = time.Now().UTC()
+	}
 }
 
 // Sent returns the time of when the Job was sent
@@ -151,22 +251,7 @@ func (i *Info) Status() Status {
 
 // StatusString returns the Job's status as a string
 func (i *Info) StatusString() string {
-	switch i.status {
-	case CREATED:
-		return "Created"
-	case SENT:
-		return "Sent"
-	case RETURNED:
-		return "Returned"
-	case COMPLETE:
-		return "Complete"
-	case CANCELED:
-		return "Canceled"
-	case ACTIVE:
-		return "Active"
-	default:
-		return "Unknown"
-	}
+	return i.status.String()
 }
 
 // Token returns the Job's token
@@ -174,20 +259,33 @@ func (i *Info) Token() uuid.UUID {
 	return i.token
 }
 
+// Type returns the Job's type (e.g., Module, CMDPayload, FileTransfer)
+func (i *Info) Type() string {
+	return i.jobType
+}
+
 func (s Status) String() string {
 	switch s {
 	case CREATED:
 		return "Created"
 	case SENT:
 		return "Sent"
+	case RECEIVED:
+		return "Received"
 	case RETURNED:
-		return "Returned"
+		return "In progress"
 	case COMPLETE:
 		return "Complete"
+	case FAILED:
+		return "Failed"
 	case CANCELED:
 		return "Canceled"
 	case ACTIVE:
 		return "Active"
+	case PENDING:
+		return "Pending delivery"
+	case EXPIRED:
+		return "Expired"
 	default:
 		return "Unknown"
 	}
@@ -0,0 +1,80 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"testing"
+	"time"
+)
+
+// TestInfoExpiry verifies a job only expires once its expiry has passed and expired jobs are done
+func TestInfoExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		expires time.Time
+		expired bool
+	}{
+		{"never", time.Time{}, false},
+		{"future", time.Now().Add(time.Hour), false},
+		{"past", time.Now().Add(-time.Second), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := Info{status: PENDING}
+			info.SetExpiry(test.expires)
+			if info.Expired() != test.expired {
+				t.Errorf("expected expired %t, have %t", test.expired, info.Expired())
+			}
+		})
+	}
+}
+
+// TestInfoDone verifies which statuses will not be sent to or return from the Agent
+func TestInfoDone(t *testing.T) {
+	tests := []struct {
+		status Status
+		name   string
+		done   bool
+	}{
+		{CREATED, "Created", false},
+		{SENT, "Sent", false},
+		{RECEIVED, "Received", false},
+		{RETURNED, "In progress", false},
+		{COMPLETE, "Complete", true},
+		{FAILED, "Failed", true},
+		{CANCELED, "Canceled", true},
+		{ACTIVE, "Active", false},
+		{PENDING, "Pending delivery", false},
+		{EXPIRED, "Expired", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := Info{status: test.status}
+			if info.Done() != test.done {
+				t.Errorf("expected done %t, have %t", test.done, info.Done())
+			}
+			if info.StatusString() != test.name || test.status.String() != test.name {
+				t.Errorf("expected the status %q, have %q and %q", test.name, info.StatusString(), test.status.String())
+			}
+		})
+	}
+}
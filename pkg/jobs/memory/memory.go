@@ -24,7 +24,9 @@ package memory
 import (
 	// Standard
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	// 3rd Party
 	"github.com/google/uuid"
@@ -38,75 +40,81 @@ import (
 
 // Repository is the structure that implements the in-memory repository for interacting with Agent Jobs
 type Repository struct {
-	sync.Mutex
-	jobsChannel map[uuid.UUID]chan jobs2.Job // jobsChannel contains all outgoing Jobs that need to be sent to an Agent
-	jobs        map[string]jobs.Info         // jobs is a map of all Job Info tracking structures
+	sync.RWMutex
+	queues map[uuid.UUID][]jobs2.Job // queues contains all outgoing Jobs that need to be sent to an Agent, in the order they will be sent
+	jobs   map[string]jobs.Info      // jobs is a map of all Job Info tracking structures
+	depth  int                       // depth is the number of unsent jobs an Agent's queue holds before new jobs are refused
 }
 
 // repo is the in-memory datastore
-var repo *Repository
+var repo = &Repository{
+	queues: make(map[uuid.UUID][]jobs2.Job),
+	jobs:   make(map[string]jobs.Info),
+	depth:  jobs.DefaultQueueDepth,
+}
 
-// NewRepository creates and returns a new in-memory repository for interacting with Agent Jobs
+// NewRepository returns the in-memory repository for interacting with Agent Jobs
 func NewRepository() *Repository {
-	if repo == nil {
-		repo = &Repository{
-			Mutex:       sync.Mutex{},
-			jobsChannel: make(map[uuid.UUID]chan jobs2.Job),
-			jobs:        make(map[string]jobs.Info),
-		}
-	}
 	return repo
 }
 
-// Add the Job and associated Info tracking structure to the repository
-func (r *Repository) Add(job jobs2.Job, info jobs.Info) {
+// Add the Job and associated Info tracking structure to the repository.
+// Urgent jobs are queued ahead of all normal jobs and are always accepted; normal jobs are refused with
+// jobs.ErrQueueFull once the Agent's queue holds the maximum number of jobs
+func (r *Repository) Add(job jobs2.Job, info jobs.Info) error {
 	r.Lock()
-	// Check to see if a job channel for the agent exist
-	_, k := r.jobsChannel[job.AgentID]
-	// Create a job channel for the agent if one does not exist
-	if !k {
-		r.jobsChannel[job.AgentID] = make(chan jobs2.Job, 100)
+	defer r.Unlock()
+
+	queue := r.queues[job.AgentID]
+	priority := jobs.PriorityOf(job)
+	if priority == jobs.NORMAL && len(queue) >= r.depth {
+		return fmt.Errorf("pkg/jobs/memory.Add(): unable to add job %s, Agent %s has %d unsent jobs: %w", job.ID, job.AgentID, len(queue), jobs.ErrQueueFull)
 	}
 
-	// Add job to the agent's job channel
-	r.jobsChannel[job.AgentID] <- job
+	// Insert the job behind every queued job of the same or higher priority
+	i := len(queue)
+	for i > 0 && jobs.PriorityOf(queue[i-1]) < priority {
+		i--
+	}
+	r.queues[job.AgentID] = slices.Insert(queue, i, job)
 
 	// Add Info
 	r.jobs[job.ID] = info
-
-	r.Unlock()
+	return nil
 }
 
 // Clear removes all Jobs that have not already been sent to the associated Agent
 func (r *Repository) Clear(agentID uuid.UUID) error {
 	r.Lock()
 	defer r.Unlock()
-	jobChannel, ok := r.jobsChannel[agentID]
+	queue, ok := r.queues[agentID]
 	if !ok {
-		return fmt.Errorf("pkg/jobs/memory.Get(): a channel key for Agent %s does not exist", agentID)
-	}
-
-	jobLength := len(jobChannel)
-	if jobLength > 0 {
-		// Empty the job channel
-		for i := 0; i < jobLength; i++ {
-			job := <-jobChannel
-			// Update Job Info structure
-			j, ok := r.jobs[job.ID]
-			if ok {
-				j.Cancel()
-				r.jobs[job.ID] = j
-			} else {
-				return fmt.Errorf("invalid job %s for agent %s", job.ID, agentID)
-			}
+		return fmt.Errorf("pkg/jobs/memory.Get(): a job queue for Agent %s does not exist", agentID)
+	}
+
+	r.queues[agentID] = nil
+	for _, job := range queue {
+		// Update Job Info structure
+		j, ok := r.jobs[job.ID]
+		if !ok {
+			return fmt.Errorf("invalid job %s for agent %s", job.ID, agentID)
 		}
+		j.Cancel()
+		r.jobs[job.ID] = j
 	}
 	return nil
 }
 
 // ClearAll removes all Jobs that have not already been sent for ALL Agents
 func (r *Repository) ClearAll() error {
-	for id := range r.jobsChannel {
+	r.Lock()
+	var agents []uuid.UUID
+	for id := range r.queues {
+		agents = append(agents, id)
+	}
+	r.Unlock()
+
+	for _, id := range agents {
 		err := r.Clear(id)
 		if err != nil {
 			return fmt.Errorf("pkg/jobs/memory.ClearAll(): %s", err)
@@ -115,13 +123,46 @@ func (r *Repository) ClearAll() error {
 	return nil
 }
 
-// GetAll returns all Job Info tracking structures as map to be iterated over
+// Expire removes the Agent's unsent jobs whose expiry has passed and returns their Info tracking structures
+func (r *Repository) Expire(agentID uuid.UUID) (expired []jobs.Info, err error) {
+	r.Lock()
+	defer r.Unlock()
+	queue, ok := r.queues[agentID]
+	if !ok {
+		err = fmt.Errorf("pkg/jobs/memory.Expire(): a job queue for Agent %s does not exist", agentID)
+		return
+	}
+
+	var keep []jobs2.Job
+	for _, job := range queue {
+		info, exists := r.jobs[job.ID]
+		if !exists || !info.Expired() {
+			keep = append(keep, job)
+			continue
+		}
+		info.Expire()
+		r.jobs[job.ID] = info
+		expired = append(expired, info)
+	}
+	r.queues[agentID] = keep
+	return
+}
+
+// GetAll returns a copy of all Job Info tracking structures as map to be iterated over
 func (r *Repository) GetAll() map[string]jobs.Info {
-	return r.jobs
+	r.RLock()
+	defer r.RUnlock()
+	all := make(map[string]jobs.Info, len(r.jobs))
+	for id, info := range r.jobs {
+		all[id] = info
+	}
+	return all
 }
 
 // GetInfo returns the Job Info tracking structure for the associate Job ID
 func (r *Repository) GetInfo(jobID string) (jobs.Info, error) {
+	r.RLock()
+	defer r.RUnlock()
 	info, ok := r.jobs[jobID]
 	if !ok {
 		return info, fmt.Errorf("pkg/jobs/memory.GetInfo(): unable to find structure for job %s", jobID)
@@ -129,36 +170,85 @@ func (r *Repository) GetInfo(jobID string) (jobs.Info, error) {
 	return info, nil
 }
 
-// GetJobs returns all jobs waiting to be sent to the associated Agent
-func (r *Repository) GetJobs(agentID uuid.UUID) (jobs []jobs2.Job, err error) {
+// GetJobs returns the jobs waiting to be sent to the associated Agent in priority order.
+// Only one bulk job is returned per call; it and any jobs queued behind it stay queued for the next call
+func (r *Repository) GetJobs(agentID uuid.UUID) (sent []jobs2.Job, err error) {
 	r.Lock()
 	defer r.Unlock()
-	jobChannel, ok := r.jobsChannel[agentID]
+	queue, ok := r.queues[agentID]
 	if !ok {
-		err = fmt.Errorf("pkg/jobs/memory.Get(): a channel key for Agent %s does not exist", agentID)
+		err = fmt.Errorf("pkg/jobs/memory.Get(): a job queue for Agent %s does not exist", agentID)
 		return
 	}
 
-	// If there are any jobs in the channel, return them
-	jobLength := len(jobChannel)
-	if jobLength > 0 {
-		for i := 0; i < jobLength; i++ {
-			job := <-jobChannel
-			jobs = append(jobs, job)
-
-			// Update Job Info map
-			info, exists := r.jobs[job.ID]
-			if exists {
-				info.Send()
-				r.jobs[job.ID] = info
-			} else {
-				return jobs, fmt.Errorf("invalid job %s for agent %s", job.ID, agentID)
+	var bulk bool
+	for _, job := range queue {
+		if jobs.Bulk(job) {
+			if bulk {
+				break
 			}
+			bulk = true
+		}
+		sent = append(sent, job)
+
+		// Update Job Info map
+		info, exists := r.jobs[job.ID]
+		if !exists {
+			r.queues[agentID] = queue[len(sent):]
+			return sent, fmt.Errorf("invalid job %s for agent %s", job.ID, agentID)
 		}
+		info.Send()
+		r.jobs[job.ID] = info
 	}
+	r.queues[agentID] = queue[len(sent):]
 	return
 }
 
+// Remove deletes the Agent's job queue and every Job Info tracking structure for the Agent, sent or not, so that no
+// record of the Agent's jobs is kept
+func (r *Repository) Remove(agentID uuid.UUID) error {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.queues[agentID]; !ok {
+		return fmt.Errorf("pkg/jobs/memory.Remove(): a job queue for Agent %s does not exist", agentID)
+	}
+	delete(r.queues, agentID)
+	for id, info := range r.jobs {
+		if info.AgentID() == agentID {
+			delete(r.jobs, id)
+		}
+	}
+	return nil
+}
+
+// SetExpiry sets the time after which an unsent job is discarded instead of being sent; the zero time never expires.
+// The job's status is checked while the repository is locked so that a job can't be sent while its expiry changes
+func (r *Repository) SetExpiry(jobID string, expires time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+	info, ok := r.jobs[jobID]
+	if !ok {
+		return fmt.Errorf("pkg/jobs/memory.SetExpiry(): unable to find structure for job %s", jobID)
+	}
+	if info.Status() != jobs.CREATED && info.Status() != jobs.PENDING {
+		return fmt.Errorf("pkg/jobs/memory.SetExpiry(): job %s can't expire because its status is %s", jobID, info.StatusString())
+	}
+	info.SetExpiry(expires)
+	r.jobs[jobID] = info
+	return nil
+}
+
+// SetQueueDepth sets the number of unsent jobs each Agent's queue holds before new jobs are refused
+func (r *Repository) SetQueueDepth(depth int) error {
+	if depth < 1 {
+		return fmt.Errorf("pkg/jobs/memory.SetQueueDepth(): the job queue depth must be greater than zero: %d", depth)
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.depth = depth
+	return nil
+}
+
 // UpdateInfo replaces the Job Info tracking structure with the one provided
 func (r *Repository) UpdateInfo(info jobs.Info) error {
 	r.Lock()
@@ -0,0 +1,331 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	// Standard
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	jobs2 "github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+// TestRepositoryConcurrentAccess adds and updates jobs while other goroutines iterate over every job, the way
+// Agent results are handled while the hosts and IOC services list job activity. Run with the -race flag to detect
+// data races
+func TestRepositoryConcurrentAccess(t *testing.T) {
+	r := NewRepository()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 10; w++ {
+		agent := uuid.New()
+		t.Cleanup(func() { _ = r.Clear(agent) })
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				info := jobs.NewInfoWithID(agent, "RESULT", "whoami", uuid.NewString(), uuid.New())
+				r.Add(jobs2.Job{AgentID: agent, ID: info.ID(), Type: jobs2.CMD}, info)
+				info.Complete()
+				if err := r.UpdateInfo(info); err != nil {
+					t.Error(err)
+				}
+				if _, err := r.GetInfo(info.ID()); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				for _, info := range r.GetAll() {
+					_ = info.AgentID()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestRepositoryGetAllCopy ensures the map returned by GetAll doesn't change the repository
+func TestRepositoryGetAllCopy(t *testing.T) {
+	r := NewRepository()
+	agent := uuid.New()
+	t.Cleanup(func() { _ = r.Clear(agent) })
+	info := jobs.NewInfoWithID(agent, "RESULT", "whoami", uuid.NewString(), uuid.New())
+	r.Add(jobs2.Job{AgentID: agent, ID: info.ID(), Type: jobs2.CMD}, info)
+
+	all := r.GetAll()
+	delete(all, info.ID())
+	if _, err := r.GetInfo(info.ID()); err != nil {
+		t.Errorf("expected deleting the job from the returned map to leave it in the repository: %s", err)
+	}
+}
+
+// TestRepositoryQueue verifies urgent jobs are sent first, full queues refuse normal jobs but accept urgent ones,
+// and only one bulk job is sent per check in
+func TestRepositoryQueue(t *testing.T) {
+	r := NewRepository()
+	err := r.SetQueueDepth(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = r.SetQueueDepth(jobs.DefaultQueueDepth) })
+	agent := uuid.New()
+	t.Cleanup(func() { _ = r.Clear(agent) })
+
+	upload := jobs2.FileTransfer{IsDownload: true}
+	tests := []struct {
+		name    string
+		job     jobs2.Job
+		refused bool
+	}{
+		{"command", jobs2.Job{Type: jobs2.CMD, Token: uuid.New()}, false},
+		{"first upload", jobs2.Job{Type: jobs2.FILETRANSFER, Payload: upload}, false},
+		{"second upload", jobs2.Job{Type: jobs2.FILETRANSFER, Payload: upload}, false},
+		{"queue full", jobs2.Job{Type: jobs2.CMD}, true},
+		{"control", jobs2.Job{Type: jobs2.CONTROL}, false},
+	}
+	for i := range tests {
+		test := &tests[i]
+		t.Run(test.name, func(t *testing.T) {
+			test.job.AgentID = agent
+			test.job.ID = uuid.NewString()
+			info := jobs.NewInfoWithID(agent, test.job.Type.String(), test.name, test.job.ID, test.job.Token)
+			err := r.Add(test.job, info)
+			if test.refused {
+				if !errors.Is(err, jobs.ErrQueueFull) {
+					t.Errorf("expected ErrQueueFull, have %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// control, command, and the first upload are sent; the second upload waits for the next check in
+	checkins := [][]string{
+		{tests[4].job.ID, tests[0].job.ID, tests[1].job.ID},
+		{tests[2].job.ID},
+		nil,
+	}
+	for i, want := range checkins {
+		sent, err := r.GetJobs(agent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var have []string
+		for _, job := range sent {
+			have = append(have, job.ID)
+		}
+		if !slices.Equal(have, want) {
+			t.Errorf("check in %d: expected jobs %v, have %v", i, want, have)
+		}
+	}
+}
+
+func TestRepositorySetQueueDepth(t *testing.T) {
+	r := NewRepository()
+	t.Cleanup(func() { _ = r.SetQueueDepth(jobs.DefaultQueueDepth) })
+	tests := []struct {
+		depth int
+		err   bool
+	}{
+		{1, false},
+		{jobs.DefaultQueueDepth, false},
+		{0, true},
+		{-1, true},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d", test.depth), func(t *testing.T) {
+			err := r.SetQueueDepth(test.depth)
+			if (err != nil) != test.err {
+				t.Errorf("expected error to be %t, have %v", test.err, err)
+			}
+		})
+	}
+}
+
+// TestRepositoryExpire verifies only queued jobs whose expiry passed are removed and marked expired
+func TestRepositoryExpire(t *testing.T) {
+	r := NewRepository()
+	agent := uuid.New()
+	t.Cleanup(func() { _ = r.Clear(agent) })
+	tests := []struct {
+		name    string
+		expires time.Time
+		expired bool
+	}{
+		{"never", time.Time{}, false},
+		{"future", time.Now().Add(time.Hour), false},
+		{"past", time.Now().Add(-time.Minute), true},
+	}
+	ids := make(map[string]string)
+	for _, test := range tests {
+		info := jobs.NewInfo(agent, "CMD", test.name)
+		info.Pending()
+		info.SetExpiry(test.expires)
+		err := r.Add(jobs2.Job{AgentID: agent, ID: info.ID(), Type: jobs2.CMD}, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[test.name] = info.ID()
+	}
+
+	expired, err := r.Expire(agent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 1 || expired[0].ID() != ids["past"] {
+		t.Errorf("expected only the past job to expire, have %d jobs", len(expired))
+	}
+	queued, err := r.GetJobs(agent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 2 {
+		t.Errorf("expected 2 jobs to stay queued, have %d", len(queued))
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := r.GetInfo(ids[test.name])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (info.Status() == jobs.EXPIRED) != test.expired {
+				t.Errorf("expected expired %t, have %s", test.expired, info.StatusString())
+			}
+		})
+	}
+	if _, err = r.Expire(uuid.New()); err == nil {
+		t.Error("expected an error expiring jobs for an Agent without a queue")
+	}
+}
+
+// TestRepositorySetExpiry verifies only unsent jobs can expire and the expiry can't change while the job is being sent.
+// Run with the -race flag to detect data races
+func TestRepositorySetExpiry(t *testing.T) {
+	r := NewRepository()
+	agent := uuid.New()
+	t.Cleanup(func() { _ = r.Clear(agent) })
+	info := jobs.NewInfo(agent, "CMD", "pwd")
+	err := r.Add(jobs2.Job{AgentID: agent, ID: info.ID(), Type: jobs2.CMD}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = r.SetExpiry(info.ID(), time.Now().Add(time.Hour))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = r.GetJobs(agent)
+	}()
+	wg.Wait()
+
+	sent, err := r.GetInfo(info.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Status() != jobs.SENT {
+		t.Errorf("expected the job to be sent, have %s", sent.StatusString())
+	}
+	tests := []struct {
+		name  string
+		jobID string
+	}{
+		{"sent", info.ID()},
+		{"unknown", uuid.NewString()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := r.SetExpiry(test.jobID, time.Time{}); err == nil {
+				t.Errorf("expected an error setting the expiry of a %s job", test.name)
+			}
+		})
+	}
+}
+
+// TestRepositoryRemove verifies every job for the Agent is deleted, sent or not, along with its queue, and that other
+// Agents' jobs are kept
+func TestRepositoryRemove(t *testing.T) {
+	r := NewRepository()
+	agent, other := uuid.New(), uuid.New()
+	t.Cleanup(func() { _ = r.Remove(other) })
+	var ids []string
+	for i, id := range []uuid.UUID{agent, agent, other} {
+		info := jobs.NewInfo(id, "CMD", "pwd")
+		if err := r.Add(jobs2.Job{AgentID: id, ID: info.ID(), Type: jobs2.CMD}, info); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, info.ID())
+		// Send the Agent's first job so both sent and unsent jobs are removed
+		if i == 0 {
+			if _, err := r.GetJobs(agent); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := r.Remove(agent); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		id     string
+		exists bool
+	}{
+		{"sent", ids[0], false},
+		{"unsent", ids[1], false},
+		{"other agent", ids[2], true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := r.GetInfo(test.id); (err == nil) != test.exists {
+				t.Errorf("expected the job to exist %t, have %v", test.exists, err)
+			}
+		})
+	}
+	if _, err := r.GetJobs(agent); err == nil {
+		t.Error("expected the Agent's job queue to be removed")
+	}
+	if err := r.Remove(agent); err == nil {
+		t.Error("expected an error removing the jobs of an Agent without a queue")
+	}
+}
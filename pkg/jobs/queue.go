@@ -0,0 +1,80 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"errors"
+	"time"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+)
+
+// DefaultQueueDepth is the number of unsent jobs an Agent's queue holds before new jobs are refused
+const DefaultQueueDepth = 100
+
+// DefaultPendingExpiry is how long a job created while its Agent is delayed or dead waits for the Agent to check in
+const DefaultPendingExpiry = 24 * time.Hour
+
+// ErrQueueFull is returned when a job can't be added because the Agent's queue already holds the maximum number of jobs
+var ErrQueueFull = errors.New("the agent's job queue is full")
+
+// Priority determines the order jobs are sent to an Agent in
+type Priority int
+
+const (
+	// NORMAL jobs are sent in the order they were created
+	NORMAL Priority = iota
+	// URGENT jobs are Agent control messages (e.g., sleep or exit) that are sent before any NORMAL job and are never
+	// refused because the queue is full
+	URGENT
+)
+
+// PriorityOf returns the priority the job is queued with
+func PriorityOf(job jobs.Job) Priority {
+	if job.Type == jobs.CONTROL {
+		return URGENT
+	}
+	return NORMAL
+}
+
+// Bulk returns true if the job carries a file to the Agent.
+// Only one bulk job is sent per Agent check in so a large transfer doesn't hold up the jobs queued behind it
+func Bulk(job jobs.Job) bool {
+	if job.Type != jobs.FILETRANSFER {
+		return false
+	}
+	ft, ok := job.Payload.(jobs.FileTransfer)
+	return ok && ft.IsDownload
+}
+
+// String returns the priority as a string
+func (p Priority) String() string {
+	switch p {
+	case NORMAL:
+		return "Normal"
+	case URGENT:
+		return "Urgent"
+	default:
+		return "Unknown"
+	}
+}
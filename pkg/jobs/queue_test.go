@@ -0,0 +1,54 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"testing"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+)
+
+func TestPriorityOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      jobs.Job
+		priority Priority
+		bulk     bool
+	}{
+		{"control", jobs.Job{Type: jobs.CONTROL}, URGENT, false},
+		{"command", jobs.Job{Type: jobs.CMD}, NORMAL, false},
+		{"upload to the Agent", jobs.Job{Type: jobs.FILETRANSFER, Payload: jobs.FileTransfer{IsDownload: true}}, NORMAL, true},
+		{"download from the Agent", jobs.Job{Type: jobs.FILETRANSFER, Payload: jobs.FileTransfer{}}, NORMAL, false},
+		{"file transfer without a payload", jobs.Job{Type: jobs.FILETRANSFER}, NORMAL, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if p := PriorityOf(test.job); p != test.priority {
+				t.Errorf("expected the %s priority, have %s", test.priority, p)
+			}
+			if b := Bulk(test.job); b != test.bulk {
+				t.Errorf("expected bulk to be %t, have %t", test.bulk, b)
+			}
+		})
+	}
+}
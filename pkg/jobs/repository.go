@@ -21,6 +21,9 @@ along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
 package jobs
 
 import (
+	// Standard
+	"time"
+
 	// 3rd Party
 	"github.com/google/uuid"
 
@@ -30,17 +33,25 @@ import (
 
 type Repository interface {
 	// Add the Job and associated Info tracking structure to the repository
-	Add(job jobs2.Job, info Info)
+	Add(job jobs2.Job, info Info) error
 	// Clear removes all Jobs that have not already been sent to the associated Agent
 	Clear(agentID uuid.UUID) error
 	// ClearAll removes all Jobs that have not already been sent for ALL Agents
 	ClearAll() error
+	// Expire removes the Agent's unsent jobs whose expiry has passed and returns their Info tracking structures
+	Expire(agentID uuid.UUID) ([]Info, error)
 	// GetAll returns all Job Info tracking structures as map to be iterated over
 	GetAll() map[string]Info
 	// GetInfo returns the Job Info tracking structure for the associate Job ID
 	GetInfo(jobID string) (Info, error)
 	// GetJobs returns all jobs waiting to be sent to the associated Agent
 	GetJobs(agentID uuid.UUID) ([]jobs2.Job, error)
+	// Remove deletes the Agent's job queue and every Job Info tracking structure for the Agent, sent or not
+	Remove(agentID uuid.UUID) error
+	// SetExpiry sets the time after which an unsent job is discarded instead of being sent; the zero time never expires
+	SetExpiry(jobID string, expires time.Time) error
+	// SetQueueDepth sets the number of unsent jobs each Agent's queue holds before new jobs are refused
+	SetQueueDepth(depth int) error
 	// UpdateInfo replaces the Job Info tracking structure with the one provided
 	UpdateInfo(info Info) error
 }
@@ -0,0 +1,66 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+// Delivery receipts an Agent returns in the Stdout of an OK job that carries the acknowledged job's ID and token
+const (
+	// AckReceived acknowledges the Agent received the job
+	AckReceived = "received"
+	// AckInProgress acknowledges the Agent started running the job
+	AckInProgress = "in-progress"
+)
+
+// transitions are the statuses a job can move to from its current status. Jobs can't leave a final status, so a late
+// or duplicate message from the Agent can't reopen a job that already completed, failed, was canceled, or expired
+var transitions = map[Status][]Status{
+	// SOCKS jobs reuse one ID for every packet of a connection, so a connection can return data while its newest packet
+	// is still queued
+	CREATED:  {SENT, PENDING, CANCELED, EXPIRED, ACTIVE, COMPLETE},
+	PENDING:  {SENT, CANCELED, EXPIRED},
+	SENT:     {RECEIVED, RETURNED, ACTIVE, COMPLETE, FAILED},
+	RECEIVED: {RETURNED, ACTIVE, COMPLETE, FAILED},
+	RETURNED: {RETURNED, ACTIVE, COMPLETE, FAILED},
+	ACTIVE:   {ACTIVE, COMPLETE, FAILED},
+}
+
+// Final returns true if a job with this status will not be sent to, or return results from, the Agent
+func (s Status) Final() bool {
+	return s == COMPLETE || s == FAILED || s == CANCELED || s == EXPIRED
+}
+
+// Next returns true if a job can move from this status to the provided status
+func (s Status) Next(status Status) bool {
+	for _, next := range transitions[s] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// transition moves the job to the provided status and returns true if the job's current status allows it
+func (i *Info) transition(status Status) bool {
+	if !i.status.Next(status) {
+		return false
+	}
+	i.status = status
+	return true
+}
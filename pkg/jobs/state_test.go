@@ -0,0 +1,76 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package jobs
+
+import (
+	// Standard
+	"testing"
+)
+
+// TestTransition verifies jobs only move along the state machine and never leave a final status
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []func(*Info)
+		want  Status
+	}{
+		{"delivered", []func(*Info){(*Info).Send, (*Info).Receive, (*Info).Progress, (*Info).Complete}, COMPLETE},
+		{"failed", []func(*Info){(*Info).Send, (*Info).Receive, (*Info).Fail}, FAILED},
+		{"chunks", []func(*Info){(*Info).Send, (*Info).Progress, (*Info).Progress}, RETURNED},
+		{"pending", []func(*Info){(*Info).Pending, (*Info).Send}, SENT},
+		{"canceled before sending", []func(*Info){(*Info).Cancel, (*Info).Send}, CANCELED},
+		{"late receipt", []func(*Info){(*Info).Send, (*Info).Complete, (*Info).Receive}, COMPLETE},
+		{"duplicate results", []func(*Info){(*Info).Send, (*Info).Fail, (*Info).Complete}, FAILED},
+		{"receipt out of order", []func(*Info){(*Info).Send, (*Info).Progress, (*Info).Receive}, RETURNED},
+		{"canceled after sending", []func(*Info){(*Info).Send, (*Info).Cancel}, SENT},
+		{"expired after sending", []func(*Info){(*Info).Send, (*Info).Expire}, SENT},
+		{"received before sending", []func(*Info){(*Info).Receive}, CREATED},
+		{"SOCKS", []func(*Info){(*Info).Active, (*Info).Active, (*Info).Complete}, COMPLETE},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := Info{status: CREATED}
+			for _, step := range test.steps {
+				step(&info)
+			}
+			if info.Status() != test.want {
+				t.Errorf("expected the status %s, have %s", test.want, info.Status())
+			}
+			if info.Done() && info.Completed().IsZero() {
+				t.Errorf("expected a completion time for the %s job", info.Status())
+			}
+		})
+	}
+}
+
+// TestFinal verifies which statuses are final and have no transitions
+func TestFinal(t *testing.T) {
+	for status := CREATED; status.String() != "Unknown"; status++ {
+		t.Run(status.String(), func(t *testing.T) {
+			if status.Final() && len(transitions[status]) > 0 {
+				t.Errorf("expected the final status %s to have no transitions, have %v", status, transitions[status])
+			}
+			if !status.Final() && !status.Next(COMPLETE) && !status.Next(SENT) {
+				t.Errorf("expected the status %s to lead to another status", status)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package keying seals an Agent's configuration to a target environment so that the Agent refuses to run, and can't
+// decrypt its configuration, when it is executed off-target (e.g., in a sandbox)
+package keying
+
+import (
+	// Standard
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/transformer/encrypters/aes"
+)
+
+// Environment is the target environment an Agent is keyed to
+type Environment struct {
+	Domain    string // Domain is the Active Directory or DNS domain the host must be joined to
+	Hostname  string // Hostname is a case-insensitive glob pattern the host's name must match (e.g., WS-*)
+	Resolve   string // Resolve is a DNS name the host must resolve to ResolveIP
+	ResolveIP string // ResolveIP is the address Resolve must resolve to
+}
+
+// Envelope is the sealed Agent configuration along with the information the Agent needs to derive the key
+type Envelope struct {
+	Hostname string `json:"hostname,omitempty"` // Hostname is checked before attempting to derive the key
+	Domain   bool   `json:"domain"`             // Domain indicates the host's domain is part of the key
+	Resolve  string `json:"resolve,omitempty"`  // Resolve is the DNS name whose address is part of the key
+	Data     []byte `json:"data"`               // Data is the encrypted Agent configuration
+}
+
+// Validate ensures the environment can be used to derive a key. At least the domain or DNS resolution must be
+// provided because the hostname pattern is only checked and is not part of the key
+func (e Environment) Validate() error {
+	if e.Domain == "" && e.Resolve == "" {
+		return fmt.Errorf("pkg/keying.Validate(): a domain or DNS resolution is required to derive a key")
+	}
+	if e.Resolve != "" && net.ParseIP(e.ResolveIP) == nil {
+		return fmt.Errorf("pkg/keying.Validate(): '%s' is not a valid IP address for %s to resolve to", e.ResolveIP, e.Resolve)
+	}
+	if e.Hostname != "" {
+		if _, err := path.Match(e.Hostname, ""); err != nil {
+			return fmt.Errorf("pkg/keying.Validate(): invalid hostname pattern '%s': %s", e.Hostname, err)
+		}
+	}
+	return nil
+}
+
+// Key derives the 32-byte key from the environment's domain and DNS resolution.
+// Values are normalized so the Agent derives the same key from what it observes on the host
+func (e Environment) Key() []byte {
+	var ip string
+	if e.Resolve != "" {
+		ip = net.ParseIP(e.ResolveIP).String()
+	}
+	key := sha256.Sum256([]byte(fmt.Sprintf("merlin-keying\x00%s\x00%s", strings.ToLower(strings.TrimSuffix(e.Domain, ".")), ip)))
+	return key[:]
+}
+
+// Seal encrypts the Agent configuration with a key derived from the target environment and returns the Base64
+// encoded envelope to embed in the Agent at build time
+func Seal(config []byte, env Environment) (string, error) {
+	err := env.Validate()
+	if err != nil {
+		return "", err
+	}
+	data, err := aes.NewEncrypter().Construct(config, env.Key())
+	if err != nil {
+		return "", fmt.Errorf("pkg/keying.Seal(): there was an error encrypting the configuration: %s", err)
+	}
+	envelope := Envelope{
+		Hostname: env.Hostname,
+		Domain:   env.Domain != "",
+		Resolve:  env.Resolve,
+		Data:     data,
+	}
+	j, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("pkg/keying.Seal(): there was an error marshalling the envelope: %s", err)
+	}
+	return base64.StdEncoding.EncodeToString(j), nil
+}
+
+// Open decrypts a sealed Agent configuration with the environment the Agent observed on the host.
+// An error is returned if the host is not the target environment
+func Open(sealed string, env Environment) ([]byte, error) {
+	j, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/keying.Open(): there was an error decoding the envelope: %s", err)
+	}
+	var envelope Envelope
+	err = json.Unmarshal(j, &envelope)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/keying.Open(): there was an error unmarshalling the envelope: %s", err)
+	}
+	if envelope.Hostname != "" {
+		match, _ := path.Match(strings.ToLower(envelope.Hostname), strings.ToLower(env.Hostname))
+		if !match {
+			return nil, fmt.Errorf("pkg/keying.Open(): the hostname does not match the target environment")
+		}
+	}
+	if !envelope.Domain {
+		env.Domain = ""
+	}
+	if envelope.Resolve == "" {
+		env.Resolve = ""
+	}
+	config, err := aes.NewEncrypter().Deconstruct(envelope.Data, env.Key())
+	if err != nil {
+		return nil, fmt.Errorf("pkg/keying.Open(): the configuration could not be decrypted in this environment")
+	}
+	return config.([]byte), nil
+}
@@ -0,0 +1,123 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package keying
+
+import (
+	// Standard
+	"bytes"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     Environment
+		wantErr bool
+	}{
+		{"domain", Environment{Domain: "corp.local"}, false},
+		{"resolve", Environment{Resolve: "dc.corp.local", ResolveIP: "10.0.0.1"}, false},
+		{"hostname only", Environment{Hostname: "WS-*"}, true},
+		{"empty", Environment{}, true},
+		{"invalid IP", Environment{Resolve: "dc.corp.local", ResolveIP: "10.0.0"}, true},
+		{"invalid pattern", Environment{Domain: "corp.local", Hostname: "WS-["}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.env.Validate()
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     Environment
+		b     Environment
+		equal bool
+	}{
+		{"domain case", Environment{Domain: "CORP.local"}, Environment{Domain: "corp.local."}, true},
+		{"different domain", Environment{Domain: "corp.local"}, Environment{Domain: "lab.local"}, false},
+		{"IPv6 normalized", Environment{Resolve: "dc", ResolveIP: "2001:db8:0:0::1"}, Environment{Resolve: "dc", ResolveIP: "2001:db8::1"}, true},
+		{"different address", Environment{Resolve: "dc", ResolveIP: "10.0.0.1"}, Environment{Resolve: "dc", ResolveIP: "10.0.0.2"}, false},
+		{"hostname ignored", Environment{Domain: "corp.local", Hostname: "WS-1"}, Environment{Domain: "corp.local", Hostname: "WS-2"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := test.a.Key(), test.b.Key()
+			if len(a) != 32 {
+				t.Fatalf("expected a 32-byte key, have %d", len(a))
+			}
+			if bytes.Equal(a, b) != test.equal {
+				t.Errorf("expected equal keys %t", test.equal)
+			}
+		})
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	config := []byte(`{"url":"https://127.0.0.1:443"}`)
+	target := Environment{Domain: "corp.local", Hostname: "WS-*", Resolve: "dc.corp.local", ResolveIP: "10.0.0.1"}
+
+	tests := []struct {
+		name    string
+		seal    Environment
+		host    Environment
+		wantErr bool
+	}{
+		{"on target", target, Environment{Domain: "CORP.LOCAL", Hostname: "ws-42", Resolve: "dc.corp.local", ResolveIP: "10.0.0.1"}, false},
+		{"hostname mismatch", target, Environment{Domain: "corp.local", Hostname: "SANDBOX", Resolve: "dc.corp.local", ResolveIP: "10.0.0.1"}, true},
+		{"domain mismatch", target, Environment{Domain: "sandbox.local", Hostname: "WS-1", Resolve: "dc.corp.local", ResolveIP: "10.0.0.1"}, true},
+		{"resolution mismatch", target, Environment{Domain: "corp.local", Hostname: "WS-1", Resolve: "dc.corp.local", ResolveIP: "192.168.1.1"}, true},
+		{"domain only", Environment{Domain: "corp.local"}, Environment{Domain: "corp.local", Hostname: "anything", Resolve: "dc", ResolveIP: "1.1.1.1"}, false},
+		{"resolve only", Environment{Resolve: "dc.corp.local", ResolveIP: "10.0.0.1"}, Environment{Domain: "other.local", Resolve: "dc.corp.local", ResolveIP: "10.0.0.1"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sealed, err := Seal(config, test.seal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Open(sealed, test.host)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error opening the configuration off-target")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, config) {
+				t.Errorf("expected %s, have %s", config, got)
+			}
+		})
+	}
+
+	if _, err := Seal(config, Environment{}); err == nil {
+		t.Error("expected an error sealing without a domain or DNS resolution")
+	}
+	if _, err := Open("not base64!", target); err == nil {
+		t.Error("expected an error opening an invalid envelope")
+	}
+}
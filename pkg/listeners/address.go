@@ -0,0 +1,127 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hostname matches DNS hostnames and network interface names (e.g., eth0)
+var hostname = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.-]*[A-Za-z0-9])?$`)
+
+// ParseInterface validates a listener's network interface address and returns it in its canonical form.
+// IPv6 addresses can be provided with or without brackets (e.g., [::1] or ::1) and with a zone (e.g., fe80::1%eth0).
+// Use 0.0.0.0 to listen on all IPv4 addresses or :: to listen on all IPv4 and IPv6 addresses
+func ParseInterface(value string) (string, error) {
+	iface := strings.TrimSpace(value)
+	if strings.HasPrefix(iface, "[") && strings.HasSuffix(iface, "]") {
+		iface = iface[1 : len(iface)-1]
+	}
+	addr, err := netip.ParseAddr(iface)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a valid network interface address", value)
+	}
+	return addr.String(), nil
+}
+
+// ParseBind validates the address a peer-to-peer Agent binds to. In addition to IP addresses, it accepts * for all
+// IPv4 addresses, hostnames, and network interface names (e.g., eth0) that are resolved when the listener is started
+func ParseBind(value string) (string, error) {
+	bind := strings.TrimSpace(value)
+	if bind == "*" {
+		return "0.0.0.0", nil
+	}
+	if addr, err := ParseInterface(bind); err == nil {
+		return addr, nil
+	}
+	if len(bind) > 253 || !hostname.MatchString(bind) {
+		return "", fmt.Errorf("%s is not a valid IP address, hostname, or network interface name", value)
+	}
+	return bind, nil
+}
+
+// ResolveBind returns the IP address for a bind address. Network interface names are resolved to the interface's
+// first address and hostnames are resolved with DNS. IPv4 addresses are preferred
+func ResolveBind(ctx context.Context, value string) (string, error) {
+	if addr, err := ParseInterface(value); err == nil {
+		return addr, nil
+	}
+	var ips []net.IP
+	if iface, err := net.InterfaceByName(value); err == nil {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return "", fmt.Errorf("there was an error getting the addresses for the %s network interface: %s", value, err)
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				ips = append(ips, ipNet.IP)
+			}
+		}
+		if len(ips) == 0 {
+			return "", fmt.Errorf("the %s network interface does not have an IP address", value)
+		}
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, value)
+		if err != nil {
+			return "", fmt.Errorf("there was an error resolving %s: %s", value, err)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return ip.String(), nil
+		}
+	}
+	return ips[0].String(), nil
+}
+
+// ParsePorts validates a port or an inclusive range of ports (e.g., 8000-8100) and returns the lowest and highest port.
+// A single port returns the same value for both; 0 lets the operating system select any free port
+func ParsePorts(value string) (low, high int, err error) {
+	first, last, isRange := strings.Cut(strings.TrimSpace(value), "-")
+	low, err = strconv.Atoi(strings.TrimSpace(first))
+	if err != nil {
+		return 0, 0, fmt.Errorf("there was an error converting the port %s to an integer: %s", first, err)
+	}
+	high = low
+	if isRange {
+		high, err = strconv.Atoi(strings.TrimSpace(last))
+		if err != nil {
+			return 0, 0, fmt.Errorf("there was an error converting the port %s to an integer: %s", last, err)
+		}
+		if low == 0 || high < low {
+			return 0, 0, fmt.Errorf("the port range %s must be from a lower to a higher non-zero port", value)
+		}
+	}
+	if low < 0 || high > 65535 {
+		return 0, 0, fmt.Errorf("the port must be between 0 and 65535")
+	}
+	return
+}
@@ -0,0 +1,186 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"context"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestParseInterface(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		err   bool
+	}{
+		{"IPv4", "127.0.0.1", "127.0.0.1", false},
+		{"IPv4 wildcard", "0.0.0.0", "0.0.0.0", false},
+		{"IPv6 loopback", "::1", "::1", false},
+		{"IPv6 brackets", "[::1]", "::1", false},
+		{"IPv6 wildcard", "::", "::", false},
+		{"IPv6 canonical", "2001:DB8:0:0:0:0:0:1", "2001:db8::1", false},
+		{"IPv6 zone", "fe80::1%eth0", "fe80::1%eth0", false},
+		{"whitespace", " 127.0.0.1 ", "127.0.0.1", false},
+		{"empty", "", "", true},
+		{"host and port", "127.0.0.1:80", "", true},
+		{"unbalanced bracket", "[::1", "", true},
+		{"invalid", "merlin", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseInterface(test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error for %q, have %q", test.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseBind(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		err   bool
+	}{
+		{"IPv4", "127.0.0.1", "127.0.0.1", false},
+		{"IPv6", "[::1]", "::1", false},
+		{"wildcard", "*", "0.0.0.0", false},
+		{"hostname", "localhost", "localhost", false},
+		{"fully qualified hostname", "c2.example.com", "c2.example.com", false},
+		{"interface name", "eth0", "eth0", false},
+		{"empty", "", "", true},
+		{"leading hyphen", "-eth0", "", true},
+		{"space", "local host", "", true},
+		{"host and port", "localhost:80", "", true},
+		{"too long", strings.Repeat("a", 254), "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseBind(test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error for %q, have %q", test.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveBind(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		err   bool
+	}{
+		{"IPv4", "127.0.0.1", "127.0.0.1", false},
+		{"IPv6", "::1", "::1", false},
+		{"hostname", "localhost", "127.0.0.1", false},
+		{"unknown hostname", "merlin.invalid", "", true},
+	}
+	// The loopback interface's name depends on the operating system
+	if iface, err := net.InterfaceByName("lo"); err == nil && iface.Flags&net.FlagLoopback != 0 {
+		tests = append(tests, struct {
+			name  string
+			value string
+			want  string
+			err   bool
+		}{"interface name", "lo", "127.0.0.1", false})
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ResolveBind(context.Background(), test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error for %q, have %q", test.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		low   int
+		high  int
+		err   bool
+	}{
+		{"port", "443", 443, 443, false},
+		{"any", "0", 0, 0, false},
+		{"range", "8000-8100", 8000, 8100, false},
+		{"range with spaces", " 8000 - 8100 ", 8000, 8100, false},
+		{"single port range", "8000-8000", 8000, 8000, false},
+		{"reversed range", "8100-8000", 0, 0, true},
+		{"range from zero", "0-8000", 0, 0, true},
+		{"too high", "65536", 0, 0, true},
+		{"range too high", "65000-70000", 0, 0, true},
+		{"negative", "-1", 0, 0, true},
+		{"not a number", "http", 0, 0, true},
+		{"empty", "", 0, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			low, high, err := ParsePorts(test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error for %q, have %d-%d", test.value, low, high)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if low != test.low || high != test.high {
+				t.Errorf("expected %d-%d, have %d-%d", test.low, test.high, low, high)
+			}
+		})
+	}
+}
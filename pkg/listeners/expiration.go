@@ -0,0 +1,127 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// ExpireOnce is the Expiration option value for a one-shot listener that expires after its first successful Agent
+// authentication, such as a staging listener
+const ExpireOnce = "once"
+
+// Expiration is when a Listener automatically stops and removes itself to limit how long staging infrastructure is
+// exposed
+type Expiration struct {
+	At   time.Time // At is when the Listener expires; zero if it only expires after an authentication
+	Once bool      // Once expires the Listener after its first successful Agent authentication
+}
+
+// ParseExpiration parses an Expiration option value, either a duration from now (e.g., 24h) or "once". An empty value
+// never expires
+func ParseExpiration(value string) (Expiration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Expiration{}, nil
+	}
+	if strings.EqualFold(value, ExpireOnce) {
+		return Expiration{Once: true}, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return Expiration{}, fmt.Errorf("the expiration must be a duration (e.g., 24h) or %s: %s", ExpireOnce, err)
+	}
+	if d <= 0 {
+		return Expiration{}, fmt.Errorf("the expiration duration must be greater than zero, have %s", value)
+	}
+	return Expiration{At: time.Now().UTC().Add(d)}, nil
+}
+
+// String returns when the Listener expires
+func (e Expiration) String() string {
+	if e.Once {
+		return "after the first Agent authentication"
+	}
+	if e.At.IsZero() {
+		return "never"
+	}
+	return e.At.Format(time.RFC3339)
+}
+
+// expirations contains the expiration of every Listener that has one and the function the listener service registered
+// to remove one-shot Listeners once an Agent authenticates
+var expirations = struct {
+	sync.Mutex
+	listeners map[uuid.UUID]Expiration
+	handler   func(id uuid.UUID)
+}{listeners: make(map[uuid.UUID]Expiration)}
+
+// SetExpiration sets, or clears with a zero Expiration, when the Listener with the provided ID expires
+func SetExpiration(id uuid.UUID, expiration Expiration) {
+	expirations.Lock()
+	defer expirations.Unlock()
+	if expiration == (Expiration{}) {
+		delete(expirations.listeners, id)
+		return
+	}
+	expirations.listeners[id] = expiration
+}
+
+// GetExpiration returns when the Listener with the provided ID expires and false if it never does
+func GetExpiration(id uuid.UUID) (Expiration, bool) {
+	expirations.Lock()
+	defer expirations.Unlock()
+	expiration, ok := expirations.listeners[id]
+	return expiration, ok
+}
+
+// OnExpire registers the function called when an Agent authenticates to a one-shot Listener
+func OnExpire(handler func(id uuid.UUID)) {
+	expirations.Lock()
+	defer expirations.Unlock()
+	expirations.handler = handler
+}
+
+// Authenticated is called after an Agent successfully authenticates to the Listener with the provided ID. One-shot
+// Listeners stop accepting new Agent authentications immediately and are handed to the registered expiration handler
+func Authenticated(id uuid.UUID) {
+	expirations.Lock()
+	expiration, ok := expirations.listeners[id]
+	if !ok || !expiration.Once {
+		expirations.Unlock()
+		return
+	}
+	delete(expirations.listeners, id)
+	handler := expirations.handler
+	expirations.Unlock()
+
+	Drain(id, true)
+	if handler != nil {
+		go handler(id)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+func TestParseExpiration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		once    bool
+		after   time.Duration
+		wantErr bool
+	}{
+		{"empty", "", false, 0, false},
+		{"once", "once", true, 0, false},
+		{"once uppercase", " ONCE ", true, 0, false},
+		{"duration", "24h", false, 24 * time.Hour, false},
+		{"zero", "0s", false, 0, true},
+		{"negative", "-1h", false, 0, true},
+		{"invalid", "tomorrow", false, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now().UTC()
+			expiration, err := ParseExpiration(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if expiration.Once != test.once {
+				t.Errorf("expected once %t, have %t", test.once, expiration.Once)
+			}
+			if test.after == 0 && !expiration.At.IsZero() {
+				t.Errorf("expected no expiration time, have %s", expiration.At)
+			}
+			if test.after > 0 && (expiration.At.Before(start.Add(test.after)) || expiration.At.After(time.Now().UTC().Add(test.after))) {
+				t.Errorf("expected the expiration to be %s from now, have %s", test.after, expiration.At)
+			}
+		})
+	}
+}
+
+func TestExpirationString(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expiration Expiration
+		want       string
+	}{
+		{"never", Expiration{}, "never"},
+		{"once", Expiration{Once: true}, "after the first Agent authentication"},
+		{"time", Expiration{At: at}, "2024-01-02T03:04:05Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.expiration.String(); got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
+
+// TestAuthenticated verifies only one-shot Listeners are drained and handed to the expiration handler, and only for
+// the first authentication
+func TestAuthenticated(t *testing.T) {
+	expired := make(chan uuid.UUID, 4)
+	OnExpire(func(id uuid.UUID) { expired <- id })
+	t.Cleanup(func() { OnExpire(nil) })
+
+	tests := []struct {
+		name       string
+		expiration Expiration
+		want       bool
+		tracked    bool
+	}{
+		{"never", Expiration{}, false, false},
+		{"time", Expiration{At: time.Now().Add(time.Hour)}, false, true},
+		{"once", Expiration{Once: true}, true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := uuid.New()
+			SetExpiration(id, test.expiration)
+			t.Cleanup(func() {
+				SetExpiration(id, Expiration{})
+				Drain(id, false)
+			})
+
+			Authenticated(id)
+			Authenticated(id)
+			if Draining(id) != test.want {
+				t.Errorf("expected draining %t, have %t", test.want, Draining(id))
+			}
+			if _, ok := GetExpiration(id); ok != test.tracked {
+				t.Errorf("expected the expiration to be tracked %t, have %t", test.tracked, ok)
+			}
+			if !test.want {
+				return
+			}
+			select {
+			case have := <-expired:
+				if have != id {
+					t.Errorf("expected the handler to be called for %s, have %s", id, have)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("expected the expiration handler to be called")
+			}
+			select {
+			case <-expired:
+				t.Error("expected the expiration handler to be called once")
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	}
+}
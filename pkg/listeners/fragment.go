@@ -0,0 +1,184 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Fragments are framed as: magic (4) | message ID (8) | index (2) | total (2) | SHA-256 of the fragment's data (32) | data
+const (
+	fragmentHeader  = 48
+	maxFragments    = 4096               // maxFragments is the most fragments a single message can be split into
+	fragmentTimeout = 5 * time.Minute    // fragmentTimeout is how long a partially received message is kept
+	minFragmentSize = fragmentHeader * 2 // minFragmentSize is the smallest frame that still carries a useful amount of data
+)
+
+// fragmentMagic identifies a frame as a message fragment
+var fragmentMagic = []byte("MFRG")
+
+// fragmenter is implemented by listeners that split large messages into fragments
+type fragmenter interface {
+	FragmentSize() int
+}
+
+// partial is a message that is being reassembled from its fragments
+type partial struct {
+	parts    [][]byte
+	received int
+	updated  time.Time
+}
+
+// partialKey uniquely identifies a message being reassembled
+type partialKey struct {
+	agent uuid.UUID
+	id    [8]byte
+}
+
+// partials holds the messages that have not received all of their fragments yet
+var partials = struct {
+	sync.Mutex
+	messages map[partialKey]*partial
+}{messages: make(map[partialKey]*partial)}
+
+// FragmentSize returns the largest frame, in bytes, the listener sends or receives before messages are fragmented.
+// Zero means the listener does not fragment messages
+func FragmentSize(l Listener) int {
+	if f, ok := l.(fragmenter); ok {
+		return f.FragmentSize()
+	}
+	return 0
+}
+
+// ParseFragmentSize validates a listener's FragmentSize option. An empty value or zero disables fragmentation
+func ParseFragmentSize(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("there was an error converting the fragment size %s to an integer: %s", value, err)
+	}
+	if size != 0 && size < minFragmentSize {
+		return 0, fmt.Errorf("the fragment size must be 0 to disable fragmentation or at least %d bytes: %d", minFragmentSize, size)
+	}
+	return size, nil
+}
+
+// Fragment splits the data into frames no larger than size. Every frame carries a header with the message ID, its
+// position, the total number of fragments, and a SHA-256 hash of its data so it can be verified when reassembled.
+// Messages that fit in a single frame are still framed so the receiver can always expect a fragment
+func Fragment(data []byte, size int) ([][]byte, error) {
+	if size < minFragmentSize {
+		return nil, fmt.Errorf("pkg/listeners.Fragment(): the fragment size must be at least %d bytes: %d", minFragmentSize, size)
+	}
+	chunk := size - fragmentHeader
+	total := (len(data) + chunk - 1) / chunk
+	if total == 0 {
+		total = 1
+	}
+	if total > maxFragments {
+		return nil, fmt.Errorf("pkg/listeners.Fragment(): a %d byte message would need %d fragments of %d bytes, more than the maximum of %d", len(data), total, size, maxFragments)
+	}
+
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/listeners.Fragment(): there was an error generating the message ID: %s", err)
+	}
+
+	frames := make([][]byte, 0, total)
+	for i := 0; i < total; i++ {
+		part := data[min(i*chunk, len(data)):min((i+1)*chunk, len(data))]
+		sum := sha256.Sum256(part)
+		frame := make([]byte, 0, fragmentHeader+len(part))
+		frame = append(frame, fragmentMagic...)
+		frame = append(frame, id...)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(i))
+		frame = binary.BigEndian.AppendUint16(frame, uint16(total))
+		frame = append(frame, sum[:]...)
+		frames = append(frames, append(frame, part...))
+	}
+	return frames, nil
+}
+
+// Reassemble verifies and stores a fragment the Agent sent. Once every fragment of the message has been received,
+// the complete message is returned and complete is true
+func Reassemble(agent uuid.UUID, frame []byte) (data []byte, complete bool, err error) {
+	if len(frame) < fragmentHeader || !bytes.Equal(frame[:4], fragmentMagic) {
+		return nil, false, fmt.Errorf("pkg/listeners.Reassemble(): the %d byte message from Agent %s is not a fragment", len(frame), agent)
+	}
+	key := partialKey{agent: agent}
+	copy(key.id[:], frame[4:12])
+	index := int(binary.BigEndian.Uint16(frame[12:14]))
+	total := int(binary.BigEndian.Uint16(frame[14:16]))
+	part := frame[fragmentHeader:]
+	if total == 0 || total > maxFragments || index >= total {
+		return nil, false, fmt.Errorf("pkg/listeners.Reassemble(): Agent %s sent fragment %d of %d which is out of range", agent, index+1, total)
+	}
+	sum := sha256.Sum256(part)
+	if !bytes.Equal(sum[:], frame[16:fragmentHeader]) {
+		return nil, false, fmt.Errorf("pkg/listeners.Reassemble(): fragment %d of %d from Agent %s failed its integrity check", index+1, total, agent)
+	}
+
+	partials.Lock()
+	defer partials.Unlock()
+
+	// Drop messages that stopped receiving fragments
+	now := time.Now()
+	for k, p := range partials.messages {
+		if now.Sub(p.updated) > fragmentTimeout {
+			delete(partials.messages, k)
+		}
+	}
+
+	p, ok := partials.messages[key]
+	if !ok {
+		p = &partial{parts: make([][]byte, total)}
+		partials.messages[key] = p
+	}
+	if len(p.parts) != total {
+		delete(partials.messages, key)
+		return nil, false, fmt.Errorf("pkg/listeners.Reassemble(): fragment %d from Agent %s has a total of %d but the message has %d", index+1, agent, total, len(p.parts))
+	}
+	if p.parts[index] == nil {
+		p.parts[index] = bytes.Clone(part)
+		p.received++
+	}
+	p.updated = now
+	if p.received < total {
+		return nil, false, nil
+	}
+
+	delete(partials.messages, key)
+	return bytes.Join(p.parts, nil), true, nil
+}
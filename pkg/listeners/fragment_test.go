@@ -0,0 +1,184 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+func TestParseFragmentSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+		err   bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"96", 96, false},
+		{"65535", 65535, false},
+		{"95", 0, true},
+		{"-1", 0, true},
+		{"large", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			size, err := ParseFragmentSize(test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error, have %d", size)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if size != test.want {
+				t.Errorf("expected %d, have %d", test.want, size)
+			}
+		})
+	}
+}
+
+// TestFragmentReassemble splits messages into fragments and reassembles them in order, in reverse, and with duplicates
+func TestFragmentReassemble(t *testing.T) {
+	data := make([]byte, 1000)
+	_, err := rand.Read(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		size   int
+		frames int
+		order  func(frames [][]byte) [][]byte
+	}{
+		{"empty", nil, 96, 1, nil},
+		{"single frame", data[:48], 96, 1, nil},
+		{"exact multiple", data[:480], 96, 10, nil},
+		{"in order", data, 148, 10, nil},
+		{"reversed", data, 148, 10, func(frames [][]byte) (reversed [][]byte) {
+			for i := len(frames) - 1; i >= 0; i-- {
+				reversed = append(reversed, frames[i])
+			}
+			return
+		}},
+		{"duplicates", data, 548, 2, func(frames [][]byte) [][]byte {
+			return [][]byte{frames[0], frames[0], frames[1]}
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			frames, err := Fragment(test.data, test.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(frames) != test.frames {
+				t.Fatalf("expected %d frames, have %d", test.frames, len(frames))
+			}
+			for _, frame := range frames {
+				if len(frame) > test.size {
+					t.Fatalf("the %d byte frame is larger than the fragment size %d", len(frame), test.size)
+				}
+			}
+			if test.order != nil {
+				frames = test.order(frames)
+			}
+
+			agent := uuid.New()
+			for i, frame := range frames {
+				message, complete, err := Reassemble(agent, frame)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if complete != (i == len(frames)-1) {
+					t.Fatalf("frame %d: expected complete to be %t", i, i == len(frames)-1)
+				}
+				if complete && !bytes.Equal(message, test.data) {
+					t.Error("the reassembled message does not match the original")
+				}
+			}
+		})
+	}
+}
+
+func TestFragmentTooLarge(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		data int
+	}{
+		{"fragment size too small", minFragmentSize - 1, 10},
+		{"too many fragments", minFragmentSize, (minFragmentSize - fragmentHeader) * (maxFragments + 1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Fragment(make([]byte, test.data), test.size); err == nil {
+				t.Error("expected an error fragmenting the message")
+			}
+		})
+	}
+}
+
+// TestReassembleInvalid verifies frames that aren't fragments, fail their integrity check, or don't match the rest of
+// the message are rejected
+func TestReassembleInvalid(t *testing.T) {
+	frames, err := Fragment(bytes.Repeat([]byte("merlin"), 50), 148)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Clone(frames[0])
+	tampered[len(tampered)-1] ^= 0xFF
+	outOfRange := bytes.Clone(frames[0])
+	outOfRange[13] = 0xFF
+	mismatched := bytes.Clone(frames[1])
+	mismatched[15] = 2
+
+	tests := []struct {
+		name   string
+		frames [][]byte
+	}{
+		{"not a fragment", [][]byte{[]byte("merlin")}},
+		{"wrong magic", [][]byte{append([]byte("XXXX"), frames[0][4:]...)}},
+		{"tampered", [][]byte{tampered}},
+		{"index out of range", [][]byte{outOfRange}},
+		{"total mismatch", [][]byte{frames[0], mismatched}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			agent := uuid.New()
+			var err error
+			for _, frame := range test.frames {
+				_, _, err = Reassemble(agent, frame)
+			}
+			if err == nil {
+				t.Error("expected an error reassembling the message")
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strings"
 
 	// 3rd Party
@@ -318,6 +319,8 @@ func (l *Listener) String() string {
 
 // SetOption sets the value for a configurable option on the Listener
 func (l *Listener) SetOption(option string, value string) error {
+	// Copy the options map before modifying it because the copies of this listener handed out by the repository share it
+	l.options = maps.Clone(l.options)
 	var err error
 	var key string
 	switch strings.ToLower(option) {
@@ -378,6 +381,8 @@ func (l *Listener) SetOption(option string, value string) error {
 		}
 		l.transformers = tl
 		key = "Transforms"
+	case "id":
+		return fmt.Errorf("pkg/listeners/http.SetOptions(): the ID option can not be changed; create a new listener instead")
 	// Protocol, Interface, Port, URLS, JWTKey, X509CERT, X509KEY are handled by the server
 	default:
 		err = l.server.SetOption(option, value)
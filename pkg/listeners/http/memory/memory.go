@@ -36,41 +36,33 @@ import (
 // Repository is a structure that implements the Repository interface
 type Repository struct {
 	listeners map[uuid.UUID]http.Listener
-	sync.Mutex
+	sync.RWMutex
 }
 
-// listenerMap is the in-memory structure that holds a map of created and stored HTTP listeners
-var listenerMap = make(map[uuid.UUID]http.Listener)
+// repo is the in-memory structure that holds a map of created and stored HTTP listeners
+var repo = &Repository{listeners: make(map[uuid.UUID]http.Listener)}
 
 // NewRepository is a factory to create and return a repository object to store and manage listeners
 func NewRepository() *Repository {
-	return &Repository{
-		listeners: listenerMap,
-		Mutex:     sync.Mutex{},
-	}
+	return repo
 }
 
 // Add stores the passed in HTTP listener
 func (r *Repository) Add(listener http.Listener) error {
-	// Make sure the map exists and create it if not
-	if r.listeners == nil {
-		r.Lock()
-		r.listeners = make(map[uuid.UUID]http.Listener)
-		r.Unlock()
-	}
+	r.Lock()
+	defer r.Unlock()
 	// Make sure the listener isn't already in the map
 	if _, ok := r.listeners[listener.ID()]; ok {
 		return fmt.Errorf("a listener with an ID of %s already exists", listener.ID())
 	}
-	// Add
-	r.Lock()
 	r.listeners[listener.ID()] = listener
-	r.Unlock()
 	return nil
 }
 
 // Exists determines if the HTTP listener has already been instantiated
 func (r *Repository) Exists(name string) bool {
+	r.RLock()
+	defer r.RUnlock()
 	for _, l := range r.listeners {
 		if name == l.Name() {
 			return true
@@ -82,6 +74,8 @@ func (r *Repository) Exists(name string) bool {
 // List returns a list of Listeners that exist and is used for command line tab completion
 func (r *Repository) List() func(string) []string {
 	return func(line string) []string {
+		r.RLock()
+		defer r.RUnlock()
 		var l []string
 		for _, listener := range r.listeners {
 			l = append(l, listener.Name())
@@ -92,6 +86,8 @@ func (r *Repository) List() func(string) []string {
 
 // Listeners returns a list of all stored Listener objects to be consumed by a client application
 func (r *Repository) Listeners() []http.Listener {
+	r.RLock()
+	defer r.RUnlock()
 	var found []http.Listener
 	for _, l := range r.listeners {
 		found = append(found, l)
@@ -101,39 +97,33 @@ func (r *Repository) Listeners() []http.Listener {
 
 // ListenerByID finds and returns the listener object by its ID (UUIDv4)
 func (r *Repository) ListenerByID(id uuid.UUID) (http.Listener, error) {
+	r.RLock()
+	defer r.RUnlock()
 	l, exists := r.listeners[id]
 	if !exists {
-		return http.Listener{}, fmt.Errorf(fmt.Sprintf("a listener with an ID of %s does not exist", id))
+		return http.Listener{}, fmt.Errorf("a listener with an ID of %s does not exist", id)
 	}
 	return l, nil
 }
 
 // ListenerByName finds and returns  the listener object by its name (string)
 func (r *Repository) ListenerByName(name string) (http.Listener, error) {
-	if !r.Exists(name) {
-		return http.Listener{}, fmt.Errorf("%s listener does not exist", name)
-	}
-
-	var listener http.Listener
+	r.RLock()
+	defer r.RUnlock()
 	for _, l := range r.listeners {
 		if name == l.Name() {
-			listener = l
-			break
+			return l, nil
 		}
 	}
-	return listener, nil
+	return http.Listener{}, fmt.Errorf("%s listener does not exist", name)
 }
 
 // RemoveByID deletes a listener from the global list of Listeners by the input UUID
 func (r *Repository) RemoveByID(id uuid.UUID) error {
-	if l, ok := r.listeners[id]; ok {
-		/*
-			err := l.Stop()
-			if err != nil {
-				return err
-			}
-		*/
-		delete(r.listeners, l.ID())
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.listeners[id]; ok {
+		delete(r.listeners, id)
 		return nil
 	}
 	return fmt.Errorf("could not remove listener: %s because it does not exist", id)
@@ -141,13 +131,13 @@ func (r *Repository) RemoveByID(id uuid.UUID) error {
 
 // SetOption updates the listener's configurable options value passed in
 func (r *Repository) SetOption(id uuid.UUID, option, value string) error {
-	listener, err := r.ListenerByID(id)
-	if err != nil {
-		return fmt.Errorf("pkg/listeners/http/memory.SetOption(): %s", err)
-	}
 	r.Lock()
 	defer r.Unlock()
-	err = listener.SetOption(option, value)
+	listener, ok := r.listeners[id]
+	if !ok {
+		return fmt.Errorf("pkg/listeners/http/memory.SetOption(): a listener with an ID of %s does not exist", id)
+	}
+	err := listener.SetOption(option, value)
 	if err != nil {
 		return fmt.Errorf("pkg/listeners/http/memory.SetOption(): %s", err)
 	}
@@ -25,6 +25,7 @@ import (
 	// Standard
 	"fmt"
 	"strings"
+	"sync"
 
 	//3rd Party
 	"github.com/google/uuid"
@@ -65,6 +66,31 @@ type Listener interface {
 	Transformers() []transformer.Transformer
 }
 
+// draining contains the IDs of Listeners that no longer accept new Agent authentications but continue to serve
+// Agents that have already authenticated
+var draining = struct {
+	sync.RWMutex
+	ids map[uuid.UUID]bool
+}{ids: make(map[uuid.UUID]bool)}
+
+// Drain sets whether the Listener with the provided ID accepts new Agent authentications
+func Drain(id uuid.UUID, drain bool) {
+	draining.Lock()
+	defer draining.Unlock()
+	if drain {
+		draining.ids[id] = true
+	} else {
+		delete(draining.ids, id)
+	}
+}
+
+// Draining returns true if the Listener with the provided ID is no longer accepting new Agent authentications
+func Draining(id uuid.UUID) bool {
+	draining.RLock()
+	defer draining.RUnlock()
+	return draining.ids[id]
+}
+
 // FromString converts a string representation of the Listener type, or kind, to a constant
 func FromString(kind string) int {
 	switch strings.ToLower(kind) {
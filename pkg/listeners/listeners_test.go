@@ -0,0 +1,55 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+func TestDrain(t *testing.T) {
+	id := uuid.New()
+	other := uuid.New()
+	tests := []struct {
+		name  string
+		drain bool
+		want  bool
+	}{
+		{"drain", true, true},
+		{"drain again", true, true},
+		{"resume", false, false},
+		{"resume again", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Drain(id, test.drain)
+			if got := Draining(id); got != test.want {
+				t.Errorf("expected draining %t, have %t", test.want, got)
+			}
+			if Draining(other) {
+				t.Error("draining a listener affected a different listener")
+			}
+		})
+	}
+}
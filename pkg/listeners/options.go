@@ -0,0 +1,259 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Option value types
+const (
+	TypeString   = "string"   // Any string
+	TypeInt      = "int"      // A whole number
+	TypeFloat    = "float"    // A decimal number
+	TypePort     = "port"     // A network port between 0 and 65535
+	TypePorts    = "ports"    // A network port or an inclusive range of ports (e.g., 8000-8100)
+	TypeBool     = "bool"     // true or false
+	TypeDuration = "duration" // A Go duration (e.g., 30s, 1m)
+	TypeIP       = "ip"       // An IP address
+	TypeHost     = "host"     // An IP address, hostname, or network interface name
+	TypeEnum     = "enum"     // One of the option's choices
+	TypeList     = "list"     // A comma separated list where each item is one of the option's choices
+	TypeKey32    = "key32"    // A Base64 encoded 32-byte key
+	TypeExpiry   = "expiry"   // A Go duration (e.g., 24h) or "once"
+)
+
+// Transforms is the list of data transforms a listener can be configured with
+var Transforms = []string{"aes", "base64-byte", "base64-string", "gob-base", "gob-string", "hex-byte", "hex-string", "jwe", "rc4", "xor"}
+
+// Option describes a single configurable listener option so that values can be validated when they are set instead of
+// failing when the listener is started
+type Option struct {
+	Name        string   // The option's name as it appears in the options map (e.g., Port)
+	Type        string   // The option's value type (e.g., int, port, bool)
+	Default     string   // The option's default value
+	Pattern     string   // An optional regular expression the value must match
+	Choices     []string // The valid values for Enum and List options; matched case-insensitively
+	Required    bool     // The value can't be empty
+	Description string   // A short description of the option
+}
+
+// Validate checks that the value is valid for the option and returns a helpful error message if it is not
+func (o Option) Validate(value string) error {
+	if value == "" {
+		if o.Required {
+			return fmt.Errorf("the %s option is required and can not be empty", o.Name)
+		}
+		return nil
+	}
+
+	var err error
+	switch o.Type {
+	case TypeInt:
+		_, err = strconv.Atoi(value)
+	case TypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case TypePort:
+		var port int
+		port, err = strconv.Atoi(value)
+		if err == nil && (port < 0 || port > 65535) {
+			err = fmt.Errorf("the port must be between 0 and 65535")
+		}
+	case TypePorts:
+		_, _, err = ParsePorts(value)
+	case TypeBool:
+		_, err = strconv.ParseBool(value)
+	case TypeDuration:
+		_, err = time.ParseDuration(value)
+	case TypeIP:
+		_, err = ParseInterface(value)
+	case TypeHost:
+		_, err = ParseBind(value)
+	case TypeEnum:
+		if !contains(o.Choices, value) {
+			err = fmt.Errorf("valid values are: %s", strings.Join(o.Choices, ", "))
+		}
+	case TypeList:
+		for _, item := range strings.Split(strings.TrimSuffix(value, ","), ",") {
+			if len(o.Choices) > 0 && !contains(o.Choices, strings.TrimSpace(item)) {
+				err = fmt.Errorf("\"%s\" is not valid, valid values are: %s", item, strings.Join(o.Choices, ", "))
+				break
+			}
+		}
+	case TypeExpiry:
+		_, err = ParseExpiration(value)
+	case TypeKey32:
+		var key []byte
+		key, err = base64.StdEncoding.DecodeString(value)
+		if err == nil && len(key) != 32 {
+			err = fmt.Errorf("the key must be 32 bytes but was %d bytes", len(key))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s value \"%s\" for the %s option: %s", o.Type, value, o.Name, err)
+	}
+
+	if o.Pattern != "" {
+		match, err := regexp.MatchString(o.Pattern, value)
+		if err != nil {
+			return fmt.Errorf("the %s option has an invalid validation pattern %s: %s", o.Name, o.Pattern, err)
+		}
+		if !match {
+			return fmt.Errorf("invalid value \"%s\" for the %s option: it must match the pattern %s", value, o.Name, o.Pattern)
+		}
+	}
+	return nil
+}
+
+// Schema is the list of configurable options for a listener type
+type Schema []Option
+
+// Defaults returns the options map with the default value for every option
+func (s Schema) Defaults() map[string]string {
+	options := make(map[string]string, len(s))
+	for _, o := range s {
+		options[o.Name] = o.Default
+	}
+	return options
+}
+
+// Option returns the option with the provided name, matched case-insensitively
+func (s Schema) Option(name string) (Option, bool) {
+	for _, o := range s {
+		if strings.EqualFold(o.Name, name) {
+			return o, true
+		}
+	}
+	return Option{}, false
+}
+
+// Names returns the sorted option names
+func (s Schema) Names() (names []string) {
+	for _, o := range s {
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// Validate checks that the option exists and the value is valid for it
+func (s Schema) Validate(name, value string) error {
+	o, ok := s.Option(name)
+	if !ok {
+		if suggestion := Suggest(name, s.Names()); suggestion != "" {
+			return fmt.Errorf("unknown option \"%s\", did you mean \"%s\"?", name, suggestion)
+		}
+		return fmt.Errorf("unknown option \"%s\", valid options are: %s", name, strings.Join(s.Names(), ", "))
+	}
+	return o.Validate(value)
+}
+
+// ValidateAll checks every option in the schema against the options map. Options that are not in the schema are
+// ignored so that informational keys, like ID, can be passed through
+func (s Schema) ValidateAll(options map[string]string) error {
+	for _, o := range s {
+		value, ok := options[o.Name]
+		if !ok && o.Required {
+			return fmt.Errorf("the %s option is required", o.Name)
+		}
+		err := o.Validate(value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Common returns the options shared by every listener type with defaults for the provided listener kind (e.g., SMB)
+func Common(kind string) Schema {
+	return Schema{
+		{Name: "ID", Type: TypeString, Pattern: `^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})?$`, Description: "The listener's unique identifier; one is generated if empty"},
+		{Name: "Name", Type: TypeString, Default: fmt.Sprintf("My %s Listener", kind), Required: true, Description: "The listener's name"},
+		{Name: "Description", Type: TypeString, Default: fmt.Sprintf("Default %s Listener", kind), Description: "The listener's description"},
+		{Name: "PSK", Type: TypeString, Default: "merlin", Required: true, Description: "The pre-shared key used to encrypt messages until the Agent is authenticated"},
+		{Name: "Transforms", Type: TypeList, Default: "jwe,gob-base", Choices: Transforms, Required: true, Description: "The ordered, comma separated, list of transforms used to encode and encrypt messages"},
+		{Name: "Authenticator", Type: TypeEnum, Default: "OPAQUE", Choices: []string{"OPAQUE", "none"}, Required: true, Description: "The process used to authenticate Agents"},
+		{Name: "Expiration", Type: TypeExpiry, Description: "Stop and remove the listener after a duration (e.g., 24h) or once an Agent authenticates (once); empty never expires"},
+	}
+}
+
+// Suggest returns the option name closest to the misspelled name, ignoring case, or an empty string if none are close
+func Suggest(name string, names []string) (suggestion string) {
+	// Allow roughly one typo for every three characters
+	best := len(name)/3 + 1
+	for _, n := range names {
+		if strings.HasPrefix(strings.ToLower(n), strings.ToLower(name)) && len(name) > 2 {
+			return n
+		}
+		d := distance(strings.ToLower(name), strings.ToLower(n))
+		if d <= best {
+			best = d
+			suggestion = n
+		}
+	}
+	return
+}
+
+// UnhandledOption returns an error for an option the listener does not have along with the closest valid option name
+func UnhandledOption(option string, names []string) error {
+	if suggestion := Suggest(option, names); suggestion != "" {
+		return fmt.Errorf("unhandled option %s, did you mean %s?", option, suggestion)
+	}
+	return fmt.Errorf("unhandled option %s, valid options are: %s", option, strings.Join(names, ", "))
+}
+
+// distance returns the Levenshtein edit distance between two strings
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// contains determines if the value is one of the choices, ignoring case
+func contains(choices []string, value string) bool {
+	for _, choice := range choices {
+		if strings.EqualFold(choice, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"strings"
+	"testing"
+)
+
+func TestOptionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		option  Option
+		value   string
+		wantErr bool
+	}{
+		{"optional empty", Option{Name: "Description", Type: TypeString}, "", false},
+		{"required empty", Option{Name: "Name", Type: TypeString, Required: true}, "", true},
+		{"int", Option{Name: "Count", Type: TypeInt}, "10", false},
+		{"invalid int", Option{Name: "Count", Type: TypeInt}, "ten", true},
+		{"float", Option{Name: "Rate", Type: TypeFloat}, "0.5", false},
+		{"invalid float", Option{Name: "Rate", Type: TypeFloat}, "fast", true},
+		{"port", Option{Name: "Port", Type: TypePort}, "443", false},
+		{"port out of range", Option{Name: "Port", Type: TypePort}, "65536", true},
+		{"negative port", Option{Name: "Port", Type: TypePort}, "-1", true},
+		{"bool", Option{Name: "DTLS", Type: TypeBool}, "true", false},
+		{"invalid bool", Option{Name: "DTLS", Type: TypeBool}, "maybe", true},
+		{"duration", Option{Name: "JWTLeeway", Type: TypeDuration}, "1m", false},
+		{"invalid duration", Option{Name: "JWTLeeway", Type: TypeDuration}, "1 minute", true},
+		{"ip", Option{Name: "Interface", Type: TypeIP}, "::1", false},
+		{"invalid ip", Option{Name: "Interface", Type: TypeIP}, "localhost", true},
+		{"enum case", Option{Name: "Authenticator", Type: TypeEnum, Choices: []string{"OPAQUE", "none"}}, "opaque", false},
+		{"invalid enum", Option{Name: "Authenticator", Type: TypeEnum, Choices: []string{"OPAQUE", "none"}}, "basic", true},
+		{"list", Option{Name: "Transforms", Type: TypeList, Choices: Transforms}, "jwe, gob-base,", false},
+		{"invalid list", Option{Name: "Transforms", Type: TypeList, Choices: Transforms}, "jwe,zip", true},
+		{"key", Option{Name: "JWTKey", Type: TypeKey32}, "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=", false},
+		{"short key", Option{Name: "JWTKey", Type: TypeKey32}, "MDEyMzQ1Njc4OWFiY2RlZg==", true},
+		{"expiry duration", Option{Name: "Expiration", Type: TypeExpiry}, "24h", false},
+		{"expiry once", Option{Name: "Expiration", Type: TypeExpiry}, "ONCE", false},
+		{"expiry negative", Option{Name: "Expiration", Type: TypeExpiry}, "-1h", true},
+		{"expiry invalid", Option{Name: "Expiration", Type: TypeExpiry}, "tomorrow", true},
+		{"pattern", Option{Name: "URLS", Type: TypeString, Pattern: `^/`}, "/", false},
+		{"pattern mismatch", Option{Name: "URLS", Type: TypeString, Pattern: `^/`}, "index", true},
+		{"invalid pattern", Option{Name: "URLS", Type: TypeString, Pattern: `(`}, "/", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.option.Validate(test.value)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema := Common("TCP")
+	defaults := schema.Defaults()
+	if defaults["Name"] != "My TCP Listener" || defaults["Authenticator"] != "OPAQUE" {
+		t.Errorf("unexpected defaults %v", defaults)
+	}
+	if err := schema.ValidateAll(defaults); err != nil {
+		t.Errorf("the default options are not valid: %s", err)
+	}
+	if strings.Join(schema.Names(), ",") != "Authenticator,Description,Expiration,ID,Name,PSK,Transforms" {
+		t.Errorf("unexpected option names %v", schema.Names())
+	}
+
+	tests := []struct {
+		name    string
+		option  string
+		value   string
+		wantErr bool
+	}{
+		{"case insensitive", "psk", "secret", false},
+		{"unknown", "Color", "blue", true},
+		{"invalid", "ID", "not-a-uuid", true},
+		{"generated ID", "ID", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := schema.Validate(test.option, test.value)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+
+	delete(defaults, "Name")
+	if err := schema.ValidateAll(defaults); err == nil {
+		t.Error("expected an error for a missing required option")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	names := []string{"Authenticator", "Description", "Interface", "Name", "PSK", "Port", "Transforms"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Transfroms", "Transforms"},
+		{"prot", "Port"},
+		{"psj", "PSK"},
+		{"auth", "Authenticator"},
+		{"interfce", "Interface"},
+		{"zz", ""},
+		{"Certificate", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Suggest(test.name, names); got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+
+	err := UnhandledOption("Transfroms", names)
+	if err == nil || !strings.Contains(err.Error(), "did you mean Transforms") {
+		t.Errorf("expected a suggestion, have %v", err)
+	}
+	err = UnhandledOption("Certificate", names)
+	if err == nil || !strings.Contains(err.Error(), "valid options are") {
+		t.Errorf("expected the valid options, have %v", err)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"port", "port", 0},
+		{"", "psk", 3},
+		{"kitten", "sitting", 3},
+		{"transfroms", "transforms", 2},
+	}
+	for _, test := range tests {
+		t.Run(test.a+"-"+test.b, func(t *testing.T) {
+			if got := distance(test.a, test.b); got != test.want {
+				t.Errorf("expected %d, have %d", test.want, got)
+			}
+		})
+	}
+}
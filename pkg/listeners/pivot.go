@@ -0,0 +1,99 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"fmt"
+	"sync"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Pivot is a peer-to-peer bind Listener that was created automatically when an Agent deployed a bind Agent to another
+// host with lateral movement
+type Pivot struct {
+	Listener uuid.UUID // Listener is the ID of the Listener that handles the deployed Agent's messages
+	Agent    uuid.UUID // Agent is the ID of the Agent that deployed the bind Agent and links to it
+	Protocol string    // Protocol is the bind Agent's peer-to-peer protocol (e.g., smb or tcp)
+	Host     string    // Host is the remote host the bind Agent was deployed to
+	Address  string    // Address is the named pipe or port the bind Agent listens on
+	Created  time.Time // Created is when the Listener was created
+}
+
+// pivots contains every Listener created for a deployed bind Agent and the function the listener service registered
+// to create those Listeners
+var pivots = struct {
+	sync.Mutex
+	listeners map[uuid.UUID]Pivot
+	handler   func(pivot Pivot, parent uuid.UUID) (uuid.UUID, error)
+}{listeners: make(map[uuid.UUID]Pivot)}
+
+// OnDeploy registers the function that returns the ID of a Listener, creating one if needed, for a deployed bind Agent.
+// The parent is the ID of the deploying Agent's Listener whose settings the new Listener copies
+func OnDeploy(handler func(pivot Pivot, parent uuid.UUID) (uuid.UUID, error)) {
+	pivots.Lock()
+	defer pivots.Unlock()
+	pivots.handler = handler
+}
+
+// Deployed is called after an Agent deployed a bind Agent with lateral movement. The registered handler configures the
+// matching Listener, which is recorded as a pivot of the deploying Agent
+func Deployed(pivot Pivot, parent uuid.UUID) (Pivot, error) {
+	pivots.Lock()
+	handler := pivots.handler
+	pivots.Unlock()
+	if handler == nil {
+		return pivot, fmt.Errorf("pkg/listeners.Deployed(): the listener service has not registered a pivot handler")
+	}
+
+	id, err := handler(pivot, parent)
+	if err != nil {
+		return pivot, fmt.Errorf("pkg/listeners.Deployed(): %s", err)
+	}
+	pivot.Listener = id
+	pivot.Created = time.Now().UTC()
+	pivots.Lock()
+	defer pivots.Unlock()
+	pivots.listeners[id] = pivot
+	return pivot, nil
+}
+
+// Pivots returns the pivot Listeners the Agent created; uuid.Nil returns every pivot Listener
+func Pivots(agent uuid.UUID) (list []Pivot) {
+	pivots.Lock()
+	defer pivots.Unlock()
+	for _, pivot := range pivots.listeners {
+		if agent == uuid.Nil || pivot.Agent == agent {
+			list = append(list, pivot)
+		}
+	}
+	return
+}
+
+// RemovePivot stops tracking the Listener with the provided ID as a pivot, such as when the Listener is removed
+func RemovePivot(id uuid.UUID) {
+	pivots.Lock()
+	defer pivots.Unlock()
+	delete(pivots.listeners, id)
+}
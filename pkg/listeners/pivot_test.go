@@ -0,0 +1,86 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"errors"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestDeployed verifies pivot Listeners are recorded against the deploying Agent only when the handler configures one
+func TestDeployed(t *testing.T) {
+	pivots.Lock()
+	original := pivots.handler
+	pivots.handler = nil
+	pivots.Unlock()
+	t.Cleanup(func() { OnDeploy(original) })
+
+	agent := uuid.New()
+	listener := uuid.New()
+	pivot := Pivot{Agent: agent, Protocol: "smb", Host: "wks01", Address: "merlinpipe"}
+	if _, err := Deployed(pivot, uuid.Nil); err == nil {
+		t.Fatal("expected an error without a registered pivot handler")
+	}
+
+	var failed bool
+	OnDeploy(func(p Pivot, parent uuid.UUID) (uuid.UUID, error) {
+		if failed {
+			return uuid.Nil, errors.New("the listener could not be created")
+		}
+		return listener, nil
+	})
+	tests := []struct {
+		name    string
+		failed  bool
+		wantErr bool
+	}{
+		{"handler error", true, true},
+		{"configured", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failed = test.failed
+			have, err := Deployed(pivot, uuid.Nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if err == nil && (have.Listener != listener || have.Created.IsZero()) {
+				t.Errorf("expected the pivot to be handled by listener %s with a creation time, have %+v", listener, have)
+			}
+		})
+	}
+	t.Cleanup(func() { RemovePivot(listener) })
+
+	if list := Pivots(agent); len(list) != 1 || list[0].Listener != listener {
+		t.Errorf("expected agent %s to have pivot listener %s, have %+v", agent, listener, list)
+	}
+	if list := Pivots(uuid.New()); len(list) != 0 {
+		t.Errorf("expected another Agent to have no pivot listeners, have %+v", list)
+	}
+	RemovePivot(listener)
+	if list := Pivots(agent); len(list) != 0 {
+		t.Errorf("expected the removed pivot listener to not be listed, have %+v", list)
+	}
+}
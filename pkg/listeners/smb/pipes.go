@@ -0,0 +1,144 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package smb
+
+import (
+	// Standard
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PipePresets are named pipe templates that mimic pipes created by common software so that a static pipe name does
+// not become an instant IOC once it is burned. A new name is generated from the template for every payload.
+//
+// Template tokens:
+//
+//	{pid}           a process ID sized number that is a multiple of 4 like Windows process IDs
+//	{digits:N}      N random decimal digits
+//	{hex:N}         N random lowercase hexadecimal characters
+//	{HEX:N}         N random uppercase hexadecimal characters
+//	{upper:N}       N random uppercase letters
+//	{choice:a|b|c}  one of the pipe separated values
+var PipePresets = map[string]string{
+	"crashpad": "crashpad_{pid}_{upper:16}",
+	"dotnet":   "dotnet-diagnostic-{pid}-{digits:10}-socket",
+	"gecko":    "gecko-crash-server-pipe.{pid}",
+	"mojo":     "mojo.{pid}.{pid}.{digits:19}",
+	"winsock":  "Winsock2\\CatalogChangeListener-{hex:3}-0",
+	"wkssvc":   "{choice:wkssvc|srvsvc|ntsvcs|lsarpc|scerpc}_{hex:8}",
+}
+
+// token matches a single template token
+var token = regexp.MustCompile(`\{(pid|digits|hex|HEX|upper|choice)(?::([^}]*))?\}`)
+
+// PipePresetNames returns a sorted list of the available pipe name presets
+func PipePresetNames() (names []string) {
+	for name := range PipePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// IsPipeTemplate determines if the named pipe contains template tokens that must be generated
+func IsPipeTemplate(pipe string) bool {
+	return token.MatchString(pipe)
+}
+
+// GeneratePipe returns a new random named pipe from the preset name or template
+func GeneratePipe(template string) (string, error) {
+	if preset, ok := PipePresets[strings.ToLower(template)]; ok {
+		template = preset
+	}
+	var err error
+	pipe := token.ReplaceAllStringFunc(template, func(match string) string {
+		if err != nil {
+			return ""
+		}
+		parts := token.FindStringSubmatch(match)
+		var value string
+		value, err = generateToken(parts[1], parts[2])
+		return value
+	})
+	if err != nil {
+		return "", fmt.Errorf("pkg/listeners/smb.GeneratePipe(): %s", err)
+	}
+	return pipe, nil
+}
+
+// generateToken returns a random value for a single template token
+func generateToken(kind, arg string) (string, error) {
+	switch kind {
+	case "pid":
+		// Windows process IDs are multiples of 4
+		n, err := random(16383)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(int(n+25) * 4), nil
+	case "choice":
+		choices := strings.Split(arg, "|")
+		n, err := random(int64(len(choices)))
+		if err != nil {
+			return "", err
+		}
+		return choices[n], nil
+	}
+
+	length, err := strconv.Atoi(arg)
+	if err != nil || length < 1 || length > 64 {
+		return "", fmt.Errorf("the {%s} token requires a length between 1 and 64, have: %s", kind, arg)
+	}
+	var charset string
+	switch kind {
+	case "digits":
+		charset = "0123456789"
+	case "hex":
+		charset = "0123456789abcdef"
+	case "HEX":
+		charset = "0123456789ABCDEF"
+	case "upper":
+		charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	}
+	value := make([]byte, length)
+	for i := range value {
+		n, err := random(int64(len(charset)))
+		if err != nil {
+			return "", err
+		}
+		value[i] = charset[n]
+	}
+	return string(value), nil
+}
+
+// random returns a cryptographically random number in the range [0, limit)
+func random(limit int64) (int64, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(limit))
+	if err != nil {
+		return 0, fmt.Errorf("there was an error generating a random number: %s", err)
+	}
+	return n.Int64(), nil
+}
@@ -0,0 +1,154 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package smb
+
+import (
+	// Standard
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestGeneratePipe(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		pattern  string
+		wantErr  bool
+	}{
+		{"static", "merlinpipe", `^merlinpipe$`, false},
+		{"crashpad preset", "crashpad", `^crashpad_\d+_[A-Z]{16}$`, false},
+		{"preset case", "DotNet", `^dotnet-diagnostic-\d+-\d{10}-socket$`, false},
+		{"mojo preset", "mojo", `^mojo\.\d+\.\d+\.\d{19}$`, false},
+		{"winsock preset", "winsock", `^Winsock2\\CatalogChangeListener-[0-9a-f]{3}-0$`, false},
+		{"wkssvc preset", "wkssvc", `^(wkssvc|srvsvc|ntsvcs|lsarpc|scerpc)_[0-9a-f]{8}$`, false},
+		{"upper hex", "pipe_{HEX:4}", `^pipe_[0-9A-F]{4}$`, false},
+		{"zero length", "pipe_{hex:0}", ``, true},
+		{"too long", "pipe_{digits:65}", ``, true},
+		{"missing length", "pipe_{upper}", ``, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pipe, err := GeneratePipe(test.template)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if !regexp.MustCompile(test.pattern).MatchString(pipe) {
+				t.Errorf("the pipe %q does not match %s", pipe, test.pattern)
+			}
+		})
+	}
+}
+
+// TestGeneratePipePID verifies generated process IDs are multiples of 4 like Windows process IDs
+func TestGeneratePipePID(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		pid, err := generateToken("pid", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := strconv.Atoi(pid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n < 100 || n%4 != 0 {
+			t.Fatalf("the process ID %d is not a positive multiple of 4", n)
+		}
+	}
+}
+
+func TestIsPipeTemplate(t *testing.T) {
+	tests := []struct {
+		pipe string
+		want bool
+	}{
+		{"merlinpipe", false},
+		{"crashpad", false},
+		{"pipe_{hex:8}", true},
+		{"{choice:a|b}", true},
+		{"pipe_{unknown:8}", false},
+	}
+	for _, test := range tests {
+		t.Run(test.pipe, func(t *testing.T) {
+			if got := IsPipeTemplate(test.pipe); got != test.want {
+				t.Errorf("expected %t, have %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestPipeOptions(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Listeners write the OPAQUE server key below the current working directory
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+	tests := []struct {
+		name     string
+		preset   string
+		pipe     string
+		template bool
+		wantErr  bool
+	}{
+		{"static", "", "merlinpipe", false, false},
+		{"preset", "crashpad", "merlinpipe", true, false},
+		{"template", "", "pipe_{hex:8}", true, false},
+		{"unknown preset", "nope", "merlinpipe", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options["PipePreset"] = test.preset
+			options["Pipe"] = test.pipe
+			listener, err := NewSMBListener(options)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			_, ok := listener.ConfiguredOptions()["PipeTemplate"]
+			if ok != test.template {
+				t.Errorf("expected a pipe template %t", test.template)
+			}
+			first, err := listener.GeneratePipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := listener.GeneratePipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (first != second) != test.template {
+				t.Errorf("expected unique pipes for every payload %t, have %q and %q", test.template, first, second)
+			}
+		})
+	}
+}
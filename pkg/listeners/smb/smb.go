@@ -28,6 +28,8 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"log/slog"
+	"maps"
+	"strconv"
 	"strings"
 
 	// 3rd Party
@@ -62,7 +64,9 @@ type Listener struct {
 	name         string                       // name of the listener
 	options      map[string]string            // options is a map of the listener's configurable options used with NewUDPListener function
 	pipe         string                       // pipe is the full UNC path of the named pipe used for communications (e.g., \\.\pipe\Merlin)
+	template     string                       // template is the preset or template new random named pipes are generated from, if any
 	psk          []byte                       // psk is the Listener's Pre-Shared Key used for initial message encryption until the Agent is authenticated
+	fragment     int                          // fragment is the largest frame sent to or received from the Agent before messages are fragmented; 0 disables fragmentation
 	agentService *agent.Service               // agentService is used to interact with Agents
 }
 
@@ -123,6 +127,12 @@ func NewSMBListener(options map[string]string) (listener Listener, err error) {
 	*/
 	listener.pipe = options["Pipe"]
 
+	// Generate the named pipe from a preset or template
+	err = listener.setPipeTemplate(options["PipePreset"], options["Pipe"])
+	if err != nil {
+		return
+	}
+
 	// Set the Transforms
 	if _, ok := options["Transforms"]; ok {
 		transforms := strings.Split(options["Transforms"], ",")
@@ -172,6 +182,12 @@ func NewSMBListener(options map[string]string) (listener Listener, err error) {
 		}
 	}
 
+	// Set the (optional) fragment size
+	listener.fragment, err = listeners.ParseFragmentSize(options["FragmentSize"])
+	if err != nil {
+		return listener, fmt.Errorf("pkg/listeners/smb.NewSMBListener(): %s", err)
+	}
+
 	// Store the passed in options for later
 	listener.options = options
 
@@ -183,16 +199,17 @@ func NewSMBListener(options map[string]string) (listener Listener, err error) {
 
 // DefaultOptions returns a map of configurable listener options that will subsequently be passed to the NewSMBListener function
 func DefaultOptions() map[string]string {
-	options := make(map[string]string)
-	options["ID"] = ""
-	options["Name"] = "My SMB Listener"
-	options["Description"] = "Default SMB Listener"
-	options["Pipe"] = "merlinpipe"
-	options["PSK"] = "merlin"
-	options["Transforms"] = "jwe,gob-base"
-	options["Protocol"] = "SMB"
-	options["Authenticator"] = "OPAQUE"
-	return options
+	return Schema().Defaults()
+}
+
+// Schema returns the type, default value, and validation for every configurable SMB listener option
+func Schema() listeners.Schema {
+	return append(listeners.Common("SMB"),
+		listeners.Option{Name: "Protocol", Type: listeners.TypeEnum, Default: "SMB", Choices: []string{"SMB"}, Required: true, Description: "The listener's protocol"},
+		listeners.Option{Name: "Pipe", Type: listeners.TypeString, Default: "merlinpipe", Required: true, Description: "The named pipe, or pipe template, the Agent listens on"},
+		listeners.Option{Name: "PipePreset", Type: listeners.TypeEnum, Choices: PipePresetNames(), Description: "A realistic named pipe template used to generate a random pipe for every payload"},
+		listeners.Option{Name: "FragmentSize", Type: listeners.TypeInt, Default: "0", Description: "The largest frame, in bytes, sent to or received from the Agent before messages are split into integrity checked fragments; 0 disables fragmentation"},
+	)
 }
 
 // Addr returns the SMB named pipe the peer-to-peer Agent is using
@@ -225,6 +242,11 @@ func (l *Listener) ConfiguredOptions() (options map[string]string) {
 	}
 	options["PSK"] = l.options["PSK"]
 	options["Pipe"] = l.pipe
+	options["PipePreset"] = l.options["PipePreset"]
+	if l.template != "" {
+		options["PipeTemplate"] = l.template
+	}
+	options["FragmentSize"] = strconv.Itoa(l.fragment)
 	return options
 }
 
@@ -290,6 +312,12 @@ func (l *Listener) Description() string {
 	return l.description
 }
 
+// FragmentSize returns the largest frame, in bytes, sent to or received from the Agent before messages are fragmented.
+// Zero means messages are not fragmented
+func (l *Listener) FragmentSize() int {
+	return l.fragment
+}
+
 // ID returns the listener's unique identifier
 func (l *Listener) ID() uuid.UUID {
 	return l.id
@@ -329,6 +357,8 @@ func (l *Listener) String() string {
 
 // SetOption sets the value for a configurable option on the Listener
 func (l *Listener) SetOption(option string, value string) error {
+	// Copy the options map before modifying it because the copies of this listener handed out by the repository share it
+	l.options = maps.Clone(l.options)
 	switch strings.ToLower(option) {
 	case "authenticator":
 		switch strings.ToLower(value) {
@@ -346,6 +376,17 @@ func (l *Listener) SetOption(option string, value string) error {
 			return fmt.Errorf("pkg/listeners/smb.SetOptions(): invalid options map key: \"Authenticator\"")
 		}
 		l.options["Authenticator"] = value
+	case "fragmentsize":
+		fragment, err := listeners.ParseFragmentSize(value)
+		if err != nil {
+			return fmt.Errorf("pkg/listeners/smb.SetOptions(): %s", err)
+		}
+		_, ok := l.options["FragmentSize"]
+		if !ok {
+			return fmt.Errorf("pkg/listeners/smb.SetOptions(): invalid options map key: \"FragmentSize\"")
+		}
+		l.fragment = fragment
+		l.options["FragmentSize"] = value
 	case "name":
 		l.name = value
 		_, ok := l.options["Name"]
@@ -361,12 +402,23 @@ func (l *Listener) SetOption(option string, value string) error {
 		}
 		l.options["Description"] = value
 	case "pipe":
-		l.pipe = value
 		_, ok := l.options["Pipe"]
 		if !ok {
 			return fmt.Errorf("pkg/listeners/smb.SetOptions(): invalid options map key: \"Pipe\"")
 		}
+		l.pipe = value
+		err := l.setPipeTemplate("", value)
+		if err != nil {
+			return fmt.Errorf("pkg/listeners/smb.SetOptions(): %s", err)
+		}
 		l.options["Pipe"] = value
+		l.options["PipePreset"] = ""
+	case "pipepreset":
+		err := l.setPipeTemplate(value, l.options["Pipe"])
+		if err != nil {
+			return fmt.Errorf("pkg/listeners/smb.SetOptions(): %s", err)
+		}
+		l.options["PipePreset"] = value
 	case "psk":
 		psk := sha256.Sum256([]byte(value))
 		l.psk = psk[:]
@@ -412,8 +464,10 @@ func (l *Listener) SetOption(option string, value string) error {
 			return fmt.Errorf("pkg/listeners/smb.SetOptions(): invalid options map key: \"Transforms\"")
 		}
 		l.options["Transforms"] = value
+	case "id", "protocol":
+		return fmt.Errorf("pkg/listeners/smb.SetOptions(): the %s option can not be changed; create a new listener instead", option)
 	default:
-		return fmt.Errorf("pkg/listeners/smb.SetOptions(): unhandled option %s", option)
+		return fmt.Errorf("pkg/listeners/smb.SetOptions(): %s", listeners.UnhandledOption(option, Schema().Names()))
 	}
 	return nil
 }
@@ -428,3 +482,36 @@ func (l *Listener) Status() string {
 func (l *Listener) Transformers() []transformer.Transformer {
 	return l.transformers
 }
+
+// GeneratePipe returns a new random named pipe from the listener's preset or template so that every payload uses a
+// unique pipe name. The listener's configured pipe is returned if it does not use a preset or template
+func (l *Listener) GeneratePipe() (string, error) {
+	if l.template == "" {
+		return l.pipe, nil
+	}
+	return GeneratePipe(l.template)
+}
+
+// setPipeTemplate sets the template named pipes are generated from using the preset name, if provided, or the pipe
+// when it contains template tokens, and then generates the listener's named pipe from it
+func (l *Listener) setPipeTemplate(preset, pipe string) error {
+	l.template = ""
+	if preset != "" {
+		template, ok := PipePresets[strings.ToLower(preset)]
+		if !ok {
+			return fmt.Errorf("unknown named pipe preset \"%s\", valid presets are: %s", preset, strings.Join(PipePresetNames(), ", "))
+		}
+		l.template = template
+	} else if IsPipeTemplate(pipe) {
+		l.template = pipe
+	}
+	if l.template == "" {
+		return nil
+	}
+	generated, err := GeneratePipe(l.template)
+	if err != nil {
+		return err
+	}
+	l.pipe = generated
+	return nil
+}
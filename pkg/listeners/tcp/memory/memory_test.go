@@ -0,0 +1,137 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	// Standard
+	"fmt"
+	"sync"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/tcp"
+)
+
+// newListener creates a TCP listener with the default options and adds it to the repository
+func newListener(tb testing.TB, r *Repository, name string) tcp.Listener {
+	tb.Helper()
+	options := tcp.DefaultOptions()
+	options["Name"] = name
+	options["Authenticator"] = "none"
+	listener, err := tcp.NewTCPListener(options)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	err = r.Add(listener)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		_ = r.RemoveByID(listener.ID())
+	})
+	return listener
+}
+
+// TestRepositoryConcurrentAccess reads and updates listeners from many goroutines, the way the HTTP handlers and RPC
+// clients do. Run with the -race flag to detect data races
+func TestRepositoryConcurrentAccess(t *testing.T) {
+	r := NewRepository()
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		listener := newListener(t, r, fmt.Sprintf("race-%d", i))
+		ids = append(ids, listener.ID())
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 20; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				id := ids[(w+i)%len(ids)]
+				switch i % 4 {
+				case 0:
+					if err := r.SetOption(id, "Description", fmt.Sprintf("worker %d iteration %d", w, i)); err != nil {
+						t.Error(err)
+					}
+				case 1:
+					listener, err := r.ListenerByID(id)
+					if err != nil {
+						t.Error(err)
+					}
+					_ = listener.ConfiguredOptions()
+				case 2:
+					for _, listener := range r.Listeners() {
+						_ = listener.ConfiguredOptions()
+					}
+				case 3:
+					_ = r.Exists(fmt.Sprintf("race-%d", w%len(ids)))
+					_ = r.List()("")
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// TestRepositoryAddDuplicate ensures concurrently adding the same listener only stores it once
+func TestRepositoryAddDuplicate(t *testing.T) {
+	r := NewRepository()
+	listener := newListener(t, r, "duplicate")
+	if err := r.RemoveByID(listener.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var added int
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r.Add(listener) == nil {
+				mu.Lock()
+				added++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if added != 1 {
+		t.Errorf("expected the listener to be added once, it was added %d times", added)
+	}
+}
+
+// BenchmarkRepositoryListenerByID measures the concurrent listener lookups every Agent message makes
+func BenchmarkRepositoryListenerByID(b *testing.B) {
+	r := NewRepository()
+	listener := newListener(b, r, "benchmark")
+	id := listener.ID()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = r.ListenerByID(id)
+		}
+	})
+}
@@ -32,4 +32,5 @@ type Repository interface {
 	ListenerByName(name string) (Listener, error)
 	RemoveByID(id uuid.UUID) error
 	SetOption(id uuid.UUID, options, value string) error
+	Update(listener Listener) error
 }
@@ -0,0 +1,112 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package udp
+
+import (
+	// Standard
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// dtls is the optional DTLS wrapper peer-to-peer UDP Agents use in front of the listener's transform chain.
+// DTLS gives the transport standard encryption and packet authenticity, and makes the traffic resemble common DTLS
+// applications like WebRTC instead of bespoke encrypted UDP. The bind Agent serves the certificate and the connecting
+// Agent pins its fingerprint
+type dtls struct {
+	enabled     bool
+	certFile    string           // Optional PEM encoded certificate file; a WebRTC style certificate is generated if empty
+	keyFile     string           // Optional PEM encoded private key file
+	certificate *tls.Certificate // The certificate the bind Agent serves
+}
+
+// load reads the DTLS certificate and key files or generates a self-signed certificate like the ones browsers
+// generate for WebRTC connections
+func (d *dtls) load() (err error) {
+	if !d.enabled {
+		d.certificate = nil
+		return nil
+	}
+	if d.certFile != "" || d.keyFile != "" {
+		var cert tls.Certificate
+		cert, err = tls.LoadX509KeyPair(d.certFile, d.keyFile)
+		if err != nil {
+			return fmt.Errorf("pkg/listeners/udp.load(): there was an error loading the DTLS certificate: %s", err)
+		}
+		d.certificate = &cert
+		return nil
+	}
+	d.certificate, err = webRTCCertificate()
+	return
+}
+
+// fingerprint returns the SHA-256 fingerprint of the DTLS certificate in the colon separated format used by the
+// WebRTC Session Description Protocol (e.g., a=fingerprint:sha-256 AB:CD:...)
+func (d *dtls) fingerprint() string {
+	if d.certificate == nil || len(d.certificate.Certificate) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(d.certificate.Certificate[0])
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
+
+// webRTCCertificate generates an ECDSA P-256 self-signed certificate with the "WebRTC" common name, a random serial,
+// and a 30-day validity period that mirrors the certificates browsers generate for WebRTC peer connections
+func webRTCCertificate() (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/listeners/udp.webRTCCertificate(): there was an error generating the private key: %s", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 63))
+	if err != nil {
+		return nil, fmt.Errorf("pkg/listeners/udp.webRTCCertificate(): there was an error generating the serial number: %s", err)
+	}
+	now := time.Now().UTC()
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "WebRTC"},
+		Issuer:       pkix.Name{CommonName: "WebRTC"},
+		NotBefore:    now.Add(-24 * time.Hour),
+		NotAfter:     now.Add(30 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/listeners/udp.webRTCCertificate(): there was an error creating the certificate: %s", err)
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// DTLSCertificate returns the certificate bind Agents serve when DTLS is enabled, or nil when it is disabled
+func (l *Listener) DTLSCertificate() *tls.Certificate {
+	return l.dtls.certificate
+}
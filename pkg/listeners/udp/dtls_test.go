@@ -0,0 +1,173 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package udp
+
+import (
+	// Standard
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// writeCertificate writes the certificate and its private key as PEM files to a temporary directory
+func writeCertificate(t *testing.T, cert *tls.Certificate) (certFile, keyFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, "dtls.crt")
+	keyFile = filepath.Join(dir, "dtls.key")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestWebRTCCertificate(t *testing.T) {
+	cert, err := webRTCCertificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Subject.CommonName != "WebRTC" || x.Issuer.CommonName != "WebRTC" {
+		t.Errorf("expected the WebRTC common name, have subject %q and issuer %q", x.Subject.CommonName, x.Issuer.CommonName)
+	}
+	if x.PublicKeyAlgorithm != x509.ECDSA {
+		t.Errorf("expected an ECDSA key, have %s", x.PublicKeyAlgorithm)
+	}
+	if days := x.NotAfter.Sub(x.NotBefore).Hours() / 24; days != 31 {
+		t.Errorf("expected a 31 day validity period, have %f", days)
+	}
+}
+
+func TestDTLSLoad(t *testing.T) {
+	generated, err := webRTCCertificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := writeCertificate(t, generated)
+	fingerprint := regexp.MustCompile(`^([0-9A-F]{2}:){31}[0-9A-F]{2}$`)
+
+	tests := []struct {
+		name    string
+		d       dtls
+		cert    bool
+		wantErr bool
+	}{
+		{"disabled", dtls{certFile: certFile, keyFile: keyFile}, false, false},
+		{"generated", dtls{enabled: true}, true, false},
+		{"files", dtls{enabled: true, certFile: certFile, keyFile: keyFile}, true, false},
+		{"missing key", dtls{enabled: true, certFile: certFile}, false, true},
+		{"missing files", dtls{enabled: true, certFile: "missing.crt", keyFile: "missing.key"}, false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.d.load()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if (test.d.certificate != nil) != test.cert {
+				t.Fatalf("expected a certificate %t, have %v", test.cert, test.d.certificate)
+			}
+			if !test.cert {
+				if test.d.fingerprint() != "" {
+					t.Errorf("expected no fingerprint, have %s", test.d.fingerprint())
+				}
+				return
+			}
+			if !fingerprint.MatchString(test.d.fingerprint()) {
+				t.Errorf("the fingerprint %s is not in the SDP format", test.d.fingerprint())
+			}
+		})
+	}
+
+	// Loading the same files returns the same fingerprint
+	a := dtls{enabled: true, certFile: certFile, keyFile: keyFile}
+	b := a
+	if err = a.load(); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.load(); err != nil {
+		t.Fatal(err)
+	}
+	if a.fingerprint() != b.fingerprint() {
+		t.Error("expected the same certificate files to have the same fingerprint")
+	}
+}
+
+func TestDTLSOptions(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Listeners write the OPAQUE server key below the current working directory
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+	tests := []struct {
+		name    string
+		options map[string]string
+		enabled bool
+		wantErr bool
+	}{
+		{"default", map[string]string{}, false, false},
+		{"enabled", map[string]string{"DTLS": "true"}, true, false},
+		{"invalid", map[string]string{"DTLS": "maybe"}, false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := DefaultOptions()
+			for k, v := range test.options {
+				options[k] = v
+			}
+			listener, err := NewUDPListener(options)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if (listener.DTLSCertificate() != nil) != test.enabled {
+				t.Errorf("expected a DTLS certificate %t", test.enabled)
+			}
+			configured := listener.ConfiguredOptions()
+			if _, ok := configured["DTLSFingerprint"]; ok != test.enabled {
+				t.Errorf("expected the DTLS fingerprint option %t", test.enabled)
+			}
+		})
+	}
+}
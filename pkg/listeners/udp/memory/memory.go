@@ -36,41 +36,33 @@ import (
 // Repository is a structure that implements the Repository interface
 type Repository struct {
 	listeners map[uuid.UUID]udp.Listener
-	sync.Mutex
+	sync.RWMutex
 }
 
-// listenerMap is the in-memory structure that holds a map of created and stored UDP listeners
-var listenerMap = make(map[uuid.UUID]udp.Listener)
+// repo is the in-memory structure that holds a map of created and stored UDP listeners
+var repo = &Repository{listeners: make(map[uuid.UUID]udp.Listener)}
 
 // NewRepository is a factory to create and return a repository object to store and manage listeners
 func NewRepository() *Repository {
-	return &Repository{
-		listeners: listenerMap,
-		Mutex:     sync.Mutex{},
-	}
+	return repo
 }
 
 // Add stores the passed in UDP listener
 func (r *Repository) Add(listener udp.Listener) error {
-	// Make sure the map exists and create it if not
-	if r.listeners == nil {
-		r.Lock()
-		r.listeners = make(map[uuid.UUID]udp.Listener)
-		r.Unlock()
-	}
+	r.Lock()
+	defer r.Unlock()
 	// Make sure the listener isn't already in the map
 	if _, ok := r.listeners[listener.ID()]; ok {
 		return fmt.Errorf("a listener with an ID of %s already exists", listener.ID())
 	}
-	// Add
-	r.Lock()
 	r.listeners[listener.ID()] = listener
-	r.Unlock()
 	return nil
 }
 
 // Exists determines if the Listener has already been instantiated
 func (r *Repository) Exists(name string) bool {
+	r.RLock()
+	defer r.RUnlock()
 	for _, l := range r.listeners {
 		if name == l.Name() {
 			return true
@@ -82,6 +74,8 @@ func (r *Repository) Exists(name string) bool {
 // List returns a list of Listeners that exist and is used for command line tab completion
 func (r *Repository) List() func(string) []string {
 	return func(line string) []string {
+		r.RLock()
+		defer r.RUnlock()
 		var l []string
 		for _, listener := range r.listeners {
 			l = append(l, listener.Name())
@@ -92,6 +86,8 @@ func (r *Repository) List() func(string) []string {
 
 // Listeners returns a list of Listener objects to be consumed by a client application
 func (r *Repository) Listeners() []udp.Listener {
+	r.RLock()
+	defer r.RUnlock()
 	var found []udp.Listener
 	for _, l := range r.listeners {
 		found = append(found, l)
@@ -101,39 +97,33 @@ func (r *Repository) Listeners() []udp.Listener {
 
 // ListenerByID finds and returns a pointer to an instantiated listener object by its ID (UUIDv4)
 func (r *Repository) ListenerByID(id uuid.UUID) (udp.Listener, error) {
+	r.RLock()
+	defer r.RUnlock()
 	l, exists := r.listeners[id]
 	if !exists {
-		return udp.Listener{}, fmt.Errorf(fmt.Sprintf("a listener with an ID of %s does not exist", id))
+		return udp.Listener{}, fmt.Errorf("a listener with an ID of %s does not exist", id)
 	}
 	return l, nil
 }
 
 // ListenerByName finds and returns a pointer to an instantiated listener object by its name (string)
 func (r *Repository) ListenerByName(name string) (udp.Listener, error) {
-	if !r.Exists(name) {
-		return udp.Listener{}, fmt.Errorf("%s listener does not exist", name)
-	}
-
-	var listener udp.Listener
+	r.RLock()
+	defer r.RUnlock()
 	for _, l := range r.listeners {
 		if name == l.Name() {
-			listener = l
-			break
+			return l, nil
 		}
 	}
-	return listener, nil
+	return udp.Listener{}, fmt.Errorf("%s listener does not exist", name)
 }
 
 // RemoveByID deletes a Listener from the global list of Listeners by the input UUID
 func (r *Repository) RemoveByID(id uuid.UUID) error {
-	if l, ok := r.listeners[id]; ok {
-		/*
-			err := l.Stop()
-			if err != nil {
-				return err
-			}
-		*/
-		delete(r.listeners, l.ID())
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.listeners[id]; ok {
+		delete(r.listeners, id)
 		return nil
 	}
 	return fmt.Errorf("could not remove listener: %s because it does not exist", id)
@@ -141,15 +131,26 @@ func (r *Repository) RemoveByID(id uuid.UUID) error {
 
 // SetOption replaces the listener's configurable option with the provided value
 func (r *Repository) SetOption(id uuid.UUID, option, value string) error {
-	listener, err := r.ListenerByID(id)
+	r.Lock()
+	defer r.Unlock()
+	listener, ok := r.listeners[id]
+	if !ok {
+		return fmt.Errorf("pkg/listeners/udp/memory.SetOption(): a listener with an ID of %s does not exist", id)
+	}
+	err := listener.SetOption(option, value)
 	if err != nil {
 		return fmt.Errorf("pkg/listeners/udp/memory.SetOption(): %s", err)
 	}
+	r.listeners[listener.ID()] = listener
+	return nil
+}
+
+// Update replaces the stored listener with the one provided
+func (r *Repository) Update(listener udp.Listener) error {
 	r.Lock()
 	defer r.Unlock()
-	err = listener.SetOption(option, value)
-	if err != nil {
-		return fmt.Errorf("pkg/listeners/udp/memory.SetOption(): %s", err)
+	if _, ok := r.listeners[listener.ID()]; !ok {
+		return fmt.Errorf("pkg/listeners/udp/memory.Update(): a listener with an ID of %s does not exist", listener.ID())
 	}
 	r.listeners[listener.ID()] = listener
 	return nil
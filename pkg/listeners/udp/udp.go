@@ -25,9 +25,11 @@ package udp
 
 import (
 	// Standard
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net"
 	"strconv"
 	"strings"
@@ -66,8 +68,11 @@ type Listener struct {
 	options      map[string]string            // options is a map of the listener's configurable options used with NewUDPListener function
 	psk          []byte                       // psk is the Listener's Pre-Shared Key used for initial message encryption until the Agent is authenticated
 	iface        string                       // iface is the interface generated udp-bind Agents will listen on; used when compiling UDP Agents
+	resolved     string                       // resolved is the IP address iface resolved to when the listener was started
 	port         int                          // port is the generated udp-bind agent will listen on; used when compiling udp Agents
+	fragment     int                          // fragment is the largest frame sent to or received from the Agent before messages are fragmented; 0 disables fragmentation
 	agentService *agent.Service               // agentService is used to interact with Agents
+	dtls         dtls                         // dtls is the optional DTLS wrapper used in front of the transform chain
 }
 
 // NewUDPListener is a factory that creates and returns a Listener aggregate that implements the Listener interface
@@ -109,12 +114,10 @@ func NewUDPListener(options map[string]string) (listener Listener, err error) {
 		err = fmt.Errorf("a network interface address must be provided")
 		return
 	}
-	ip := net.ParseIP(options["Interface"])
-	if ip == nil {
-		err = fmt.Errorf("%s is not a valid network interface", options["Interface"])
+	listener.iface, err = listeners.ParseBind(options["Interface"])
+	if err != nil {
 		return
 	}
-	listener.iface = options["Interface"]
 
 	// Set the port
 	if options["Port"] == "" {
@@ -176,6 +179,26 @@ func NewUDPListener(options map[string]string) (listener Listener, err error) {
 		}
 	}
 
+	// Set the (optional) DTLS wrapper
+	if options["DTLS"] != "" {
+		listener.dtls.enabled, err = strconv.ParseBool(options["DTLS"])
+		if err != nil {
+			return listener, fmt.Errorf("pkg/listeners/udp.NewUDPListener(): there was an error parsing the DTLS option %s: %s", options["DTLS"], err)
+		}
+	}
+	listener.dtls.certFile = options["DTLSCert"]
+	listener.dtls.keyFile = options["DTLSKey"]
+	err = listener.dtls.load()
+	if err != nil {
+		return
+	}
+
+	// Set the (optional) fragment size
+	listener.fragment, err = listeners.ParseFragmentSize(options["FragmentSize"])
+	if err != nil {
+		return listener, fmt.Errorf("pkg/listeners/udp.NewUDPListener(): %s", err)
+	}
+
 	// Store the passed in options for later
 	listener.options = options
 
@@ -187,22 +210,28 @@ func NewUDPListener(options map[string]string) (listener Listener, err error) {
 
 // DefaultOptions returns a map of configurable listener options that will subsequently be passed to the NewUDPListener function
 func DefaultOptions() map[string]string {
-	options := make(map[string]string)
-	options["ID"] = ""
-	options["Name"] = "My UDP Listener"
-	options["Description"] = "Default UDP Listener"
-	options["Interface"] = "127.0.0.1"
-	options["Port"] = "4444"
-	options["PSK"] = "merlin"
-	options["Transforms"] = "jwe,gob-base"
-	options["Protocol"] = "UDP"
-	options["Authenticator"] = "OPAQUE"
-	return options
+	return Schema().Defaults()
+}
+
+// Schema returns the type, default value, and validation for every configurable UDP listener option
+func Schema() listeners.Schema {
+	return append(listeners.Common("UDP"),
+		listeners.Option{Name: "Protocol", Type: listeners.TypeEnum, Default: "UDP", Choices: []string{"UDP"}, Required: true, Description: "The listener's protocol"},
+		listeners.Option{Name: "Interface", Type: listeners.TypeHost, Default: "127.0.0.1", Required: true, Description: "The IP address, hostname, network interface name (e.g., eth0), or * the Agent listens on"},
+		listeners.Option{Name: "Port", Type: listeners.TypePort, Default: "4444", Required: true, Description: "The port the Agent listens on"},
+		listeners.Option{Name: "DTLS", Type: listeners.TypeBool, Default: "false", Description: "Wrap the transport in DTLS in front of the transform chain"},
+		listeners.Option{Name: "DTLSCert", Type: listeners.TypeString, Description: "The PEM encoded DTLS certificate file; a WebRTC style certificate is generated if empty"},
+		listeners.Option{Name: "DTLSKey", Type: listeners.TypeString, Description: "The PEM encoded DTLS private key file"},
+		listeners.Option{Name: "FragmentSize", Type: listeners.TypeInt, Default: "0", Description: "The largest frame, in bytes, sent to or received from the Agent before messages are split into integrity checked fragments; 0 disables fragmentation"},
+	)
 }
 
 // Addr returns the network interface and port the peer-to-peer Agent is using
 func (l *Listener) Addr() string {
-	return fmt.Sprintf("%s:%d", l.iface, l.port)
+	if l.resolved != "" {
+		return net.JoinHostPort(l.resolved, strconv.Itoa(l.port))
+	}
+	return net.JoinHostPort(l.iface, strconv.Itoa(l.port))
 }
 
 // Authenticate takes data coming into the listener from an agent and passes it to the listener's configured
@@ -230,7 +259,17 @@ func (l *Listener) ConfiguredOptions() (options map[string]string) {
 	}
 	options["PSK"] = l.options["PSK"]
 	options["Interface"] = l.iface
+	if l.resolved != "" && l.resolved != l.iface {
+		options["ResolvedInterface"] = l.resolved
+	}
 	options["Port"] = fmt.Sprintf("%d", l.port)
+	options["DTLS"] = strconv.FormatBool(l.dtls.enabled)
+	if l.dtls.enabled {
+		options["DTLSCert"] = l.dtls.certFile
+		options["DTLSKey"] = l.dtls.keyFile
+		options["DTLSFingerprint"] = l.dtls.fingerprint()
+	}
+	options["FragmentSize"] = strconv.Itoa(l.fragment)
 	return options
 }
 
@@ -301,6 +340,12 @@ func (l *Listener) Description() string {
 	return l.description
 }
 
+// FragmentSize returns the largest frame, in bytes, sent to or received from the Agent before messages are fragmented.
+// Zero means messages are not fragmented
+func (l *Listener) FragmentSize() int {
+	return l.fragment
+}
+
 // ID returns the listener's unique identifier
 func (l *Listener) ID() uuid.UUID {
 	return l.id
@@ -333,6 +378,15 @@ func (l *Listener) Server() *servers.ServerInterface {
 	return nil
 }
 
+// Resolve resolves the listener's hostname or network interface name to the IP address the Agent binds to
+func (l *Listener) Resolve(ctx context.Context) (err error) {
+	l.resolved, err = listeners.ResolveBind(ctx, l.iface)
+	if err != nil {
+		return fmt.Errorf("pkg/listeners/udp.Resolve(): %s", err)
+	}
+	return nil
+}
+
 // String returns the listener's name
 func (l *Listener) String() string {
 	return l.name
@@ -340,6 +394,8 @@ func (l *Listener) String() string {
 
 // SetOption sets the value for a configurable option on the Listener
 func (l *Listener) SetOption(option string, value string) error {
+	// Copy the options map before modifying it because the copies of this listener handed out by the repository share it
+	l.options = maps.Clone(l.options)
 	var err error
 	var key string
 	switch strings.ToLower(option) {
@@ -357,12 +413,44 @@ func (l *Listener) SetOption(option string, value string) error {
 	case "description":
 		l.description = value
 		key = "Description"
+	case "dtls", "dtlscert", "dtlskey":
+		d := l.dtls
+		switch strings.ToLower(option) {
+		case "dtls":
+			d.enabled, err = strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("pkg/listeners/udp.SetOptions(): there was an error parsing the DTLS option %s: %s", value, err)
+			}
+			key = "DTLS"
+		case "dtlscert":
+			d.certFile = value
+			key = "DTLSCert"
+		case "dtlskey":
+			d.keyFile = value
+			key = "DTLSKey"
+		}
+		// Only load the certificate once both the certificate and key files are set
+		if d.enabled && (d.certFile == "") != (d.keyFile == "") {
+			d.certificate = nil
+		} else {
+			err = d.load()
+			if err != nil {
+				return err
+			}
+		}
+		l.dtls = d
+	case "fragmentsize":
+		l.fragment, err = listeners.ParseFragmentSize(value)
+		if err != nil {
+			return fmt.Errorf("pkg/listeners/udp.SetOptions(): %s", err)
+		}
+		key = "FragmentSize"
 	case "interface":
-		ip := net.ParseIP(value)
-		if ip == nil {
-			return fmt.Errorf("pkg/listeners/tcp.SetOptions(): %s is not a valid network interface", value)
+		l.iface, err = listeners.ParseBind(value)
+		l.resolved = ""
+		if err != nil {
+			return fmt.Errorf("pkg/listeners/udp.SetOptions(): %s", err)
 		}
-		l.iface = value
 		key = "Interface"
 	case "name":
 		l.name = value
@@ -410,8 +498,10 @@ func (l *Listener) SetOption(option string, value string) error {
 		}
 		l.transformers = tl
 		key = "Transforms"
+	case "id", "protocol":
+		return fmt.Errorf("pkg/listeners/udp.SetOptions(): the %s option can not be changed; create a new listener instead", option)
 	default:
-		return fmt.Errorf("pkg/listeners/tcp.SetOptions(): unhandled option %s", option)
+		return fmt.Errorf("pkg/listeners/udp.SetOptions(): %s", listeners.UnhandledOption(option, Schema().Names()))
 	}
 	// Update the option map
 	_, ok := l.options[key]
@@ -0,0 +1,206 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package locale loads message catalogs that translate the help text of Agent commands so that the interface can be
+// presented in an operator's language without changing Merlin's source code.
+//
+// Every <locale>.yaml file in the locales directory is a catalog, where the file name is the locale's tag (e.g., es or
+// pt-BR). An example es.yaml catalog:
+//
+//	commands:
+//	  sleep:
+//	    description: Establece el tiempo que el Agente duerme entre conexiones
+//	    usage: sleep <duración>
+//
+// Strings a catalog does not translate fall back to the catalog for the locale's language (e.g., pt for pt-BR) and
+// then to the original English text
+package locale
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	// 3rd Party
+	"gopkg.in/yaml.v3"
+)
+
+// Default is the locale of the original help text that is returned when no catalog translates a string
+const Default = "en"
+
+// Help is the translated help text for a single command
+type Help struct {
+	Description string `yaml:"description"`
+	Usage       string `yaml:"usage"`
+}
+
+// Catalog is the translated help text for a locale loaded from a catalog file
+type Catalog struct {
+	Locale   string          `yaml:"-"`
+	Commands map[string]Help `yaml:"commands"`
+}
+
+// registry holds the loaded catalogs keyed by their lower case locale tag
+var registry = struct {
+	sync.Mutex
+	dir      string
+	fallback string
+	catalogs map[string]Catalog
+}{catalogs: make(map[string]Catalog)}
+
+// Dir returns the default locales directory, "data/locales", relative to Merlin's working directory
+func Dir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("pkg/locale.Dir(): there was an error getting the working directory: %s", err)
+	}
+	return filepath.Join(dir, "data", "locales"), nil
+}
+
+// Load reads every catalog in the provided directory and replaces all previously loaded catalogs. Catalogs that can't be
+// parsed are not loaded and their errors are returned. A locales directory that does not exist has no catalogs
+func Load(dir string) (loaded int, errs []error) {
+	catalogs := make(map[string]Catalog)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		errs = append(errs, fmt.Errorf("pkg/locale.Load(): there was an error reading the %s directory: %s", dir, err))
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		tag := strings.TrimSuffix(entry.Name(), ext)
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name())) // #nosec G304 the operator provides the locales directory
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pkg/locale.Load(): there was an error reading the %s catalog: %s", entry.Name(), err))
+			continue
+		}
+		var c Catalog
+		err = yaml.Unmarshal(data, &c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pkg/locale.Load(): there was an error parsing the %s catalog: %s", entry.Name(), err))
+			continue
+		}
+		c.Locale = tag
+		commands := make(map[string]Help, len(c.Commands))
+		for name, help := range c.Commands {
+			commands[strings.ToLower(name)] = help
+		}
+		c.Commands = commands
+		catalogs[normalize(tag)] = c
+	}
+
+	registry.Lock()
+	registry.dir = dir
+	registry.catalogs = catalogs
+	registry.Unlock()
+
+	for _, e := range errs {
+		slog.Warn(e.Error())
+	}
+	slog.Debug(fmt.Sprintf("Loaded %d locale catalogs from %s", len(catalogs), dir))
+	return len(catalogs), errs
+}
+
+// Reload re-reads every catalog from the directory they were last loaded from, or Merlin's "data/locales" directory
+func Reload() (loaded int, errs []error) {
+	registry.Lock()
+	dir := registry.dir
+	registry.Unlock()
+	if dir == "" {
+		var err error
+		dir, err = Dir()
+		if err != nil {
+			return 0, []error{err}
+		}
+	}
+	return Load(dir)
+}
+
+// SetDefault sets the locale used when an operator does not select one
+func SetDefault(locale string) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.fallback = locale
+}
+
+// Locales returns every loaded catalog sorted by locale
+func Locales() []Catalog {
+	registry.Lock()
+	defer registry.Unlock()
+	catalogs := make([]Catalog, 0, len(registry.catalogs))
+	for _, c := range registry.catalogs {
+		catalogs = append(catalogs, c)
+	}
+	sort.Slice(catalogs, func(i, j int) bool { return catalogs[i].Locale < catalogs[j].Locale })
+	return catalogs
+}
+
+// Command returns the command's description and usage translated for the provided locale, or the server's default
+// locale if one is not provided. Strings without a translation are returned unchanged
+func Command(locale, name, description, usage string) (string, string) {
+	registry.Lock()
+	defer registry.Unlock()
+	if locale == "" {
+		locale = registry.fallback
+	}
+	// Try the most specific catalog first, then its language
+	var help Help
+	for tag := normalize(locale); tag != ""; {
+		if c, ok := registry.catalogs[tag]; ok {
+			h := c.Commands[strings.ToLower(name)]
+			if help.Description == "" {
+				help.Description = h.Description
+			}
+			if help.Usage == "" {
+				help.Usage = h.Usage
+			}
+		}
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	if help.Description != "" {
+		description = help.Description
+	}
+	if help.Usage != "" {
+		usage = help.Usage
+	}
+	return description, usage
+}
+
+// normalize returns the locale tag in lower case with hyphens so that es_MX, es-MX, and es-mx are the same locale
+func normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	// Drop any encoding or modifier from POSIX locales (e.g., es_MX.UTF-8)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	return strings.ReplaceAll(tag, "_", "-")
+}
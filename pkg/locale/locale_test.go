@@ -0,0 +1,115 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package locale
+
+import (
+	// Standard
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadCatalogs writes the catalogs, keyed by file name, to a locales directory and loads them
+func loadCatalogs(t *testing.T, catalogs map[string]string) (int, []error) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range catalogs {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		Load(filepath.Join(dir, "none"))
+		SetDefault("")
+	})
+	return Load(dir)
+}
+
+// TestLoad verifies catalogs are loaded from YAML files, other files are ignored, and invalid catalogs are reported
+func TestLoad(t *testing.T) {
+	loaded, errs := loadCatalogs(t, map[string]string{
+		"es.yaml":    "commands:\n  Sleep:\n    description: Dormir\n",
+		"pt_BR.yml":  "commands:\n  sleep:\n    usage: sleep <duração>\n",
+		"de.yaml":    "commands: [not, a, map]\n",
+		"README.txt": "not a catalog",
+	})
+	if loaded != 2 || len(errs) != 1 {
+		t.Fatalf("expected 2 catalogs and 1 error, have %d and %v", loaded, errs)
+	}
+	catalogs := Locales()
+	if len(catalogs) != 2 || catalogs[0].Locale != "es" || catalogs[1].Locale != "pt_BR" {
+		t.Fatalf("expected the es and pt_BR catalogs in order, have %+v", catalogs)
+	}
+	if _, ok := catalogs[0].Commands["sleep"]; !ok {
+		t.Error("expected command names to be lower case")
+	}
+	if loaded, _ = Reload(); loaded != 2 {
+		t.Errorf("expected reloading to load 2 catalogs, have %d", loaded)
+	}
+}
+
+// TestCommand verifies help text falls back from a regional locale to its language, then to the server's default
+// locale, and then to the original text
+func TestCommand(t *testing.T) {
+	_, errs := loadCatalogs(t, map[string]string{
+		"es.yaml":    "commands:\n  sleep:\n    description: Dormir\n    usage: sleep <duración>\n  pwd:\n    description: Directorio\n",
+		"es-MX.yaml": "commands:\n  sleep:\n    description: Dormir un rato\n",
+		"fr.yaml":    "commands:\n  sleep:\n    description: Dormir (fr)\n",
+	})
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	tests := []struct {
+		name        string
+		fallback    string
+		locale      string
+		command     string
+		description string
+		usage       string
+	}{
+		{"language", "", "es", "sleep", "Dormir", "sleep <duración>"},
+		{"region", "", "es-MX", "sleep", "Dormir un rato", "sleep <duración>"},
+		{"posix locale", "", "es_MX.UTF-8", "sleep", "Dormir un rato", "sleep <duración>"},
+		{"region without the command", "", "es-MX", "pwd", "Directorio", "pwd"},
+		{"case-insensitive command", "", "ES", "SLEEP", "Dormir", "sleep <duración>"},
+		{"untranslated command", "", "es", "ps", "List processes", "ps"},
+		{"unknown locale", "", "ja", "sleep", "Sleep", "sleep <duration>"},
+		{"server default", "fr", "", "sleep", "Dormir (fr)", "sleep <duration>"},
+		{"client overrides default", "fr", "es", "sleep", "Dormir", "sleep <duración>"},
+		{"no locale", "", "", "sleep", "Sleep", "sleep <duration>"},
+	}
+	original := map[string][2]string{
+		"sleep": {"Sleep", "sleep <duration>"},
+		"SLEEP": {"Sleep", "sleep <duration>"},
+		"pwd":   {"Working directory", "pwd"},
+		"ps":    {"List processes", "ps"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetDefault(test.fallback)
+			description, usage := Command(test.locale, test.command, original[test.command][0], original[test.command][1])
+			if description != test.description || usage != test.usage {
+				t.Errorf("expected %q and %q, have %q and %q", test.description, test.usage, description, usage)
+			}
+		})
+	}
+}
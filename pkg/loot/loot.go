@@ -0,0 +1,147 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package loot holds the structures for data collected from Agents, such as keystrokes or screenshots
+package loot
+
+import (
+	// Standard
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Type is the kind of data collected from an Agent
+type Type int
+
+const (
+	UNDEFINED Type = iota
+	// KEYSTROKES are keys captured by the keylogger
+	KEYSTROKES
+	// SCREENSHOT is an image of the Agent host's screen
+	SCREENSHOT
+	// CLIPBOARD is text captured from the Agent host's clipboard
+	CLIPBOARD
+	// OTHER is any other data collected from an Agent
+	OTHER
+)
+
+// Loot is a single piece of data collected from an Agent
+type Loot struct {
+	id       uuid.UUID         // id is the unique identifier for the loot
+	agentID  uuid.UUID         // agentID is the Agent the loot was collected from
+	jobID    string            // jobID is the job that returned the loot
+	lootType Type              // lootType is the kind of data that was collected
+	file     string            // file is the path on the server where the loot is stored
+	size     int               // size is the number of bytes collected
+	tags     map[string]string // tags are key/value pairs used to describe the loot (e.g., window title)
+	created  time.Time         // created is when the loot was received by the server
+}
+
+// NewLoot is a factory to create a Loot structure for data stored in the provided file
+func NewLoot(agentID uuid.UUID, jobID string, lootType Type, file string, size int, tags map[string]string) Loot {
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	return Loot{
+		id:       uuid.New(),
+		agentID:  agentID,
+		jobID:    jobID,
+		lootType: lootType,
+		file:     file,
+		size:     size,
+		tags:     tags,
+		created:  time.Now().UTC(),
+	}
+}
+
+// NewLootWithID is a factory to create a Loot structure with a known ID and creation time
+func NewLootWithID(id, agentID uuid.UUID, jobID string, lootType Type, file string, size int, tags map[string]string, created time.Time) Loot {
+	l := NewLoot(agentID, jobID, lootType, file, size, tags)
+	l.id = id
+	l.created = created
+	return l
+}
+
+// AgentID returns the ID of the Agent the loot was collected from
+func (l *Loot) AgentID() uuid.UUID {
+	return l.agentID
+}
+
+// Created returns the time the loot was received by the server
+func (l *Loot) Created() time.Time {
+	return l.created
+}
+
+// File returns the path on the server where the loot is stored
+func (l *Loot) File() string {
+	return l.file
+}
+
+// ID returns the loot's unique identifier
+func (l *Loot) ID() uuid.UUID {
+	return l.id
+}
+
+// JobID returns the ID of the job that returned the loot
+func (l *Loot) JobID() string {
+	return l.jobID
+}
+
+// Size returns the number of bytes collected
+func (l *Loot) Size() int {
+	return l.size
+}
+
+// Tag returns the value for the provided tag key
+func (l *Loot) Tag(key string) string {
+	return l.tags[key]
+}
+
+// Tags returns a copy of all the loot's tags
+func (l *Loot) Tags() map[string]string {
+	tags := make(map[string]string, len(l.tags))
+	for k, v := range l.tags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// Type returns the kind of data that was collected
+func (l *Loot) Type() Type {
+	return l.lootType
+}
+
+// String returns the loot type as a string
+func (t Type) String() string {
+	switch t {
+	case KEYSTROKES:
+		return "Keystrokes"
+	case SCREENSHOT:
+		return "Screenshot"
+	case CLIPBOARD:
+		return "Clipboard"
+	case OTHER:
+		return "Other"
+	default:
+		return "Undefined"
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving loot collected from Agents
+package memory
+
+import (
+	// Standard
+	"errors"
+	"sort"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/loot"
+)
+
+var (
+	ErrLootNotFound = errors.New("pkg/loot/memory: the loot was not found in the repository")
+)
+
+// Repository is the structure that implements the in-memory repository for loot
+type Repository struct {
+	sync.RWMutex
+	loot map[uuid.UUID]loot.Loot
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{loot: make(map[uuid.UUID]loot.Loot)}
+
+// NewRepository returns the in-memory repository for loot
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the loot in the repository
+func (r *Repository) Add(l loot.Loot) error {
+	r.Lock()
+	defer r.Unlock()
+	r.loot[l.ID()] = l
+	return nil
+}
+
+// Get returns the loot for the provided ID
+func (r *Repository) Get(id uuid.UUID) (loot.Loot, error) {
+	r.RLock()
+	defer r.RUnlock()
+	l, ok := r.loot[id]
+	if !ok {
+		return loot.Loot{}, ErrLootNotFound
+	}
+	return l, nil
+}
+
+// GetAgent returns all loot collected from the provided Agent sorted by the time it was received
+func (r *Repository) GetAgent(agentID uuid.UUID) (agentLoot []loot.Loot) {
+	for _, l := range r.GetAll() {
+		if l.AgentID() == agentID {
+			agentLoot = append(agentLoot, l)
+		}
+	}
+	return
+}
+
+// GetAll returns all loot sorted by the time it was received
+func (r *Repository) GetAll() (all []loot.Loot) {
+	r.RLock()
+	for _, l := range r.loot {
+		all = append(all, l)
+	}
+	r.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Created().Before(all[j].Created())
+	})
+	return
+}
@@ -22,6 +22,7 @@ package donut
 
 import (
 	"bytes"
+	"debug/pe"
 	"encoding/base64"
 	"path"
 
@@ -275,3 +276,46 @@ func BytesFromString(assembly string, config *donut.DonutConfig) (*bytes.Buffer,
 	}
 	return donut.ShellcodeFromBytes(bytes.NewBuffer(data), config)
 }
+
+// NativePEArch takes a base64 encoded PE file and validates that it is an unmanaged executable, not a .NET assembly or
+// DLL, and returns the donut architecture that matches the PE's machine type
+func NativePEArch(assembly string) (donut.DonutArch, error) {
+	data, err := base64.StdEncoding.DecodeString(assembly)
+	if err != nil {
+		return donut.X84, fmt.Errorf("there was an error base64 decoding the PE:\n%s", err)
+	}
+	f, err := pe.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return donut.X84, fmt.Errorf("the provided file is not a valid PE: %s", err)
+	}
+	defer f.Close()
+
+	if f.Characteristics&pe.IMAGE_FILE_DLL != 0 {
+		return donut.X84, fmt.Errorf("the provided PE is a DLL, only executables are supported")
+	}
+
+	// The CLR runtime header data directory is only populated for .NET assemblies
+	var clr pe.DataDirectory
+	switch header := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if header.NumberOfRvaAndSizes > pe.IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR {
+			clr = header.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR]
+		}
+	case *pe.OptionalHeader64:
+		if header.NumberOfRvaAndSizes > pe.IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR {
+			clr = header.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR]
+		}
+	}
+	if clr.VirtualAddress != 0 {
+		return donut.X84, fmt.Errorf("the provided PE is a .NET assembly, use the execute-assembly command instead")
+	}
+
+	switch f.Machine {
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return donut.X32, nil
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return donut.X64, nil
+	default:
+		return donut.X84, fmt.Errorf("unsupported PE machine type: 0x%x", f.Machine)
+	}
+}
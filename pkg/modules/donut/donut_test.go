@@ -0,0 +1,104 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package donut
+
+import (
+	// Standard
+	"bytes"
+	"debug/pe"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/Binject/go-donut/donut"
+)
+
+// testPE returns a Base64 encoded PE file with the provided machine type and characteristics and no sections.
+// A CLR runtime header data directory is added when clr is true to make it look like a .NET assembly
+func testPE(t *testing.T, machine uint16, characteristics uint16, clr bool) string {
+	t.Helper()
+	var buf bytes.Buffer
+	// DOS header with the offset to the PE signature at 0x3c
+	dos := make([]byte, 0x40)
+	copy(dos, "MZ")
+	binary.LittleEndian.PutUint32(dos[0x3c:], 0x40)
+	buf.Write(dos)
+	buf.WriteString("PE\x00\x00")
+
+	var optional interface{}
+	var size uint16
+	if machine == pe.IMAGE_FILE_MACHINE_I386 {
+		header := &pe.OptionalHeader32{Magic: 0x10b, NumberOfRvaAndSizes: 16}
+		if clr {
+			header.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR] = pe.DataDirectory{VirtualAddress: 0x2000, Size: 0x48}
+		}
+		optional, size = header, uint16(binary.Size(header))
+	} else {
+		header := &pe.OptionalHeader64{Magic: 0x20b, NumberOfRvaAndSizes: 16}
+		if clr {
+			header.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR] = pe.DataDirectory{VirtualAddress: 0x2000, Size: 0x48}
+		}
+		optional, size = header, uint16(binary.Size(header))
+	}
+	file := pe.FileHeader{Machine: machine, SizeOfOptionalHeader: size, Characteristics: characteristics}
+	for _, v := range []interface{}{file, optional} {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// TestNativePEArch verifies only unmanaged executables are accepted and their architecture is detected
+func TestNativePEArch(t *testing.T) {
+	const exe = pe.IMAGE_FILE_EXECUTABLE_IMAGE
+	tests := []struct {
+		name string
+		pe   string
+		want donut.DonutArch
+		err  string
+	}{
+		{"x64 executable", testPE(t, pe.IMAGE_FILE_MACHINE_AMD64, exe, false), donut.X64, ""},
+		{"x86 executable", testPE(t, pe.IMAGE_FILE_MACHINE_I386, exe, false), donut.X32, ""},
+		{"DLL", testPE(t, pe.IMAGE_FILE_MACHINE_AMD64, exe|pe.IMAGE_FILE_DLL, false), donut.X84, "is a DLL"},
+		{".NET assembly", testPE(t, pe.IMAGE_FILE_MACHINE_I386, exe, true), donut.X84, "is a .NET assembly"},
+		{"unsupported machine", testPE(t, pe.IMAGE_FILE_MACHINE_ARM64, exe, false), donut.X84, "unsupported PE machine type"},
+		{"not a PE", base64.StdEncoding.EncodeToString([]byte("not a PE file")), donut.X84, "not a valid PE"},
+		{"not Base64", "not*base64", donut.X84, "base64 decoding"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			arch, err := NativePEArch(test.pe)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error containing %q, got: %v", test.err, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if arch != test.want {
+				t.Errorf("expected architecture %d, got %d", test.want, arch)
+			}
+		})
+	}
+}
@@ -25,68 +25,86 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log/slog"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 
 	// Third-Party
 	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/attack"
 )
 
 // Module is a structure containing the base information or template for modules
 type Module struct {
 	id              uuid.UUID   // id the unique identifier for this instance of the module
-	Agent           uuid.UUID   // The Agent that will later be associated with this module prior to execution
-	Name            string      `json:"name"`        // Name of the module
-	Type            string      `json:"type"`        // Type of module (i.e., standard or extended)
-	Author          []string    `json:"author"`      // A list of module authors
-	Credits         []string    `json:"credits"`     // A list of people to credit for underlying tool or techniques
-	Path            []string    `json:"path"`        // Path to the module (i.e., data/modules/powershell/powerview)
-	Platform        string      `json:"platform"`    // Platform that the module can run on (i.e., Windows, Linux, Darwin, or ALL)
-	Arch            string      `json:"arch"`        // The Architecture the module can run on (i.e., x86, x64, MIPS, ARM, or ALL)
-	Lang            string      `json:"lang"`        // What language does the module execute in (i.e., PowerShell, Python, or Perl)
-	Priv            bool        `json:"privilege"`   // Does this module require a privileged level account like root or SYSTEM?
-	Description     string      `json:"description"` // A description of what the module does
-	Notes           string      `json:"notes"`       // Additional information or notes about the module
-	Commands        []string    `json:"commands"`    // A list of commands to be run on the agent
-	SourceRemote    string      `json:"remote"`      // Online or remote source code for a module
-	SourceLocal     []string    `json:"local"`       // The local file path to the script or payload
-	Options         []Option    `json:"options"`     // A list of configurable options/arguments for the module
+	Agent           uuid.UUID   `json:"-" yaml:"-"`                               // The Agent that will later be associated with this module prior to execution
+	Name            string      `json:"name" yaml:"name"`                         // Name of the module
+	Type            string      `json:"type" yaml:"type"`                         // Type of module (i.e., standard or extended)
+	Author          []string    `json:"author" yaml:"author"`                     // A list of module authors
+	Credits         []string    `json:"credits" yaml:"credits"`                   // A list of people to credit for underlying tool or techniques
+	Path            []string    `json:"path" yaml:"path"`                         // Path to the module (i.e., data/modules/powershell/powerview)
+	Platform        string      `json:"platform" yaml:"platform"`                 // Platform that the module can run on (i.e., Windows, Linux, Darwin, or ALL)
+	Arch            string      `json:"arch" yaml:"arch"`                         // The Architecture the module can run on (i.e., x86, x64, MIPS, ARM, or ALL)
+	Lang            string      `json:"lang" yaml:"lang"`                         // What language does the module execute in (i.e., PowerShell, Python, or Perl)
+	Priv            bool        `json:"privilege" yaml:"privilege"`               // Does this module require a privileged level account like root or SYSTEM?
+	Description     string      `json:"description" yaml:"description"`           // A description of what the module does
+	Notes           string      `json:"notes" yaml:"notes"`                       // Additional information or notes about the module
+	Commands        []string    `json:"commands" yaml:"commands"`                 // A list of commands to be run on the agent
+	SourceRemote    string      `json:"remote" yaml:"remote"`                     // Online or remote source code for a module
+	SourceLocal     []string    `json:"local" yaml:"local"`                       // The local file path to the script or payload
+	Options         []Option    `json:"options" yaml:"options"`                   // A list of configurable options/arguments for the module
+	Tags            []string    `json:"tags,omitempty" yaml:"tags,omitempty"`     // A list of keywords used to search for or categorize the module
+	Attack          []string    `json:"attack,omitempty" yaml:"attack,omitempty"` // A list of MITRE ATT&CK technique IDs the module exercises
 	originalOptions []Option    // An original and unmodified list of configurable options/arguments for the module
-	Powershell      interface{} `json:"powershell,omitempty"` // An option json object containing commands and configuration items specific to PowerShell
-	IsExtended      bool        // Is this an extended module?
+	Powershell      interface{} `json:"powershell,omitempty" yaml:"-"` // An option json object containing commands and configuration items specific to PowerShell
+	IsExtended      bool        `json:"-" yaml:"-"`                    // Is this an extended module?
+	file            string      // The file path to the module's JSON or YAML definition
 }
 
 // Option is a structure containing the keys for the object
 type Option struct {
-	Name        string `json:"name"`        // Name of the option
-	Value       string `json:"value"`       // Value of the option
-	Required    bool   `json:"required"`    // Is this a required option?
-	Flag        string `json:"flag"`        // The command line flag used for the option
-	Description string `json:"description"` // A description of the option
+	Name        string `json:"name" yaml:"name"`               // Name of the option
+	Value       string `json:"value" yaml:"value"`             // Value of the option
+	Required    bool   `json:"required" yaml:"required"`       // Is this a required option?
+	Flag        string `json:"flag" yaml:"flag"`               // The command line flag used for the option
+	Description string `json:"description" yaml:"description"` // A description of the option
 }
 
 // PowerShell structure is used to describe additional PowerShell features for modules that leverage PowerShell
 type PowerShell struct {
-	DisableAV   bool // Disable Windows Real Time "Set-MpPreference -DisableRealtimeMonitoring $true"
-	Obfuscation bool // Unimplemented command to obfuscated powershell
-	Base64      bool // Base64 encode the powershell command?
+	DisableAV   bool `yaml:"disableav"`   // Disable Windows Real Time "Set-MpPreference -DisableRealtimeMonitoring $true"
+	Obfuscation bool `yaml:"obfuscation"` // Unimplemented command to obfuscated powershell
+	Base64      bool `yaml:"base64"`      // Base64 encode the powershell command?
+}
+
+// yamlModule is the layout of a module defined in a YAML file. It mirrors the JSON layout where the "base" object is
+// required and the "powershell" object is optional
+type yamlModule struct {
+	Base       *Module     `yaml:"base"`
+	PowerShell *PowerShell `yaml:"powershell"`
 }
 
-// NewModule is a factory to instantiate a module object using the provided file path to a module's json file
+// NewModule is a factory to instantiate a module object using the provided file path to a module's JSON or YAML file
 func NewModule(modulePath string) (Module, error) {
 	m := Module{
-		id: uuid.New(),
+		id:   uuid.New(),
+		file: modulePath,
 	}
 
-	// Read in the module's JSON configuration file
+	// Read in the module's configuration file
 	f, err := os.ReadFile(modulePath) // #nosec G304 - User should be able to read in any file
 	if err != nil {
 		return m, err
 	}
 
+	switch strings.ToLower(filepath.Ext(modulePath)) {
+	case ".yaml", ".yml":
+		return newModuleFromYAML(m, modulePath, f)
+	}
+
 	// Unmarshal module's JSON message
 	var moduleJSON map[string]*json.RawMessage
 	err = json.Unmarshal(f, &moduleJSON)
@@ -150,8 +168,55 @@ func NewModule(modulePath string) (Module, error) {
 	return m, nil
 }
 
+// newModuleFromYAML unmarshalls the YAML module definition in data into the provided Module and validates it
+func newModuleFromYAML(m Module, modulePath string, data []byte) (Module, error) {
+	y := yamlModule{Base: &m}
+	err := yaml.Unmarshal(data, &y)
+	if err != nil {
+		return m, fmt.Errorf("there was an error unmarshaling the module's YAML file at %s: %s", modulePath, err)
+	}
+	if y.Base == nil || y.Base.Name == "" {
+		return m, errors.New("the module's definition does not contain the 'BASE' message type")
+	}
+	m = *y.Base
+	if y.PowerShell != nil {
+		m.Powershell = *y.PowerShell
+	}
+
+	err = validateModule(m)
+	if err != nil {
+		return m, fmt.Errorf("there was an error validating the module's YAML file at %s: %s", modulePath, err)
+	}
+	m.originalOptions = m.Options
+	m.IsExtended = strings.ToLower(m.Type) == "extended"
+	return m, nil
+}
+
 // validateModule function is used to check a module's configuration for errors
 func validateModule(m Module) error {
+	if m.Name == "" {
+		return errors.New("missing 'name' value in the module's definition")
+	}
+
+	// Validate Options
+	options := make(map[string]bool)
+	for _, option := range m.Options {
+		if option.Name == "" {
+			return errors.New("an option is missing its 'name' value in the module's definition")
+		}
+		if options[strings.ToLower(option.Name)] {
+			return fmt.Errorf("the '%s' option is defined more than once in the module's definition", option.Name)
+		}
+		options[strings.ToLower(option.Name)] = true
+	}
+
+	// Validate MITRE ATT&CK technique IDs
+	for _, id := range m.Attack {
+		if err := attack.Validate(id); err != nil {
+			return err
+		}
+	}
+
 	// Validate Platform
 	switch strings.ToUpper(m.Platform) {
 	case "WINDOWS":
@@ -173,12 +238,43 @@ func validateModule(m Module) error {
 	switch strings.ToUpper(m.Type) {
 	case "STANDARD":
 	case "EXTENDED":
+	case "SCRIPT":
 	default:
 		return errors.New("invalid or missing `type` value in the module's JSON file")
 	}
+
+	// Standard modules are only a list of commands
+	if strings.ToUpper(m.Type) == "STANDARD" && len(m.Commands) == 0 {
+		return errors.New("standard modules must contain at least one value in the 'commands' list")
+	}
+
+	// Script modules are a Starlark script that runs on the server and tasks the Agent
+	if strings.ToUpper(m.Type) == "SCRIPT" {
+		if !strings.EqualFold(m.Lang, "starlark") {
+			return errors.New("script modules must use the 'starlark' lang")
+		}
+		if len(m.SourceLocal) == 0 || strings.ToLower(filepath.Ext(filepath.Join(m.SourceLocal...))) != ".star" {
+			return errors.New("script modules must contain the path to a Starlark (.star) script in the 'local' list")
+		}
+	}
 	return nil
 }
 
+// IsScript returns true if the module is a Starlark script that runs on the server and tasks the Agent
+func (m Module) IsScript() bool {
+	return strings.EqualFold(m.Type, "script")
+}
+
+// ScriptPath returns the file path to a script module's Starlark script. Relative paths in the 'local' list are
+// relative to the directory of the module's definition file
+func (m Module) ScriptPath() string {
+	path := filepath.Join(m.SourceLocal...)
+	if filepath.IsAbs(path) || m.file == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(m.file), path)
+}
+
 // marshalMessage is a generic function used to marshal JSON messages
 func marshalMessage(m interface{}) ([]byte, error) {
 	k, err := json.Marshal(m)
@@ -188,36 +284,7 @@ func marshalMessage(m interface{}) ([]byte, error) {
 	return k, err
 }
 
-// GetModuleList generates and returns a list of all modules in Merlin's "module" directory folder. Used with tab completion
+// GetModuleList returns a list of all modules loaded from Merlin's "module" directory folder. Used with tab completion
 func GetModuleList() []string {
-	dir, err := os.Getwd()
-	if err != nil {
-		slog.Error(err.Error())
-		return []string{}
-	}
-	ModuleDir := path.Join(filepath.ToSlash(dir), "data", "modules")
-	o := make([]string, 0)
-
-	err = filepath.Walk(ModuleDir, func(path string, f os.FileInfo, err error) error {
-		if err != nil {
-			fmt.Printf("prevent panic by handling failure accessing a path %q: %v\n", ModuleDir, err)
-			return err
-		}
-		if strings.HasSuffix(f.Name(), ".json") {
-			d := strings.SplitAfter(filepath.ToSlash(path), ModuleDir)
-			if len(d) > 0 {
-				m := d[1]
-				m = strings.TrimLeft(m, "/")
-				m = strings.TrimSuffix(m, ".json")
-				if !strings.Contains(m, "templates") {
-					o = append(o, m)
-				}
-			}
-		}
-		return nil
-	})
-	if err != nil {
-		fmt.Printf("error walking the path %q: %v\n", ModuleDir, err)
-	}
-	return o
+	return registry.list()
 }
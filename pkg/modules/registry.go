@@ -0,0 +1,192 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package modules
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// store holds every module that was loaded and validated from the modules directory so that modules can be added or
+// modified at runtime, without recompiling the server, and then reloaded
+type store struct {
+	dir     string
+	loaded  bool
+	modules map[string]Module
+	sync.Mutex
+}
+
+// registry is the in-memory store of all loaded modules
+var registry = &store{modules: make(map[string]Module)}
+
+// ModuleDir returns the absolute path to Merlin's "data/modules" directory
+func ModuleDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("pkg/modules.ModuleDir(): there was an error getting the working directory: %s", err)
+	}
+	return filepath.Join(dir, "data", "modules"), nil
+}
+
+// Load walks the provided directory, validates every JSON or YAML module definition, and replaces all previously loaded
+// modules. Modules that fail validation are not loaded and their errors are returned.
+func Load(dir string) (loaded int, errs []error) {
+	modules := make(map[string]Module)
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "templates" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(d.Name()))
+		switch ext {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.ToSlash(rel), filepath.Ext(rel))
+
+		m, err := NewModule(path)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if _, ok := modules[name]; ok {
+			errs = append(errs, fmt.Errorf("duplicate module definition for %s at %s", name, path))
+			return nil
+		}
+		modules[name] = m
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("pkg/modules.Load(): there was an error walking the %s directory: %s", dir, err))
+	}
+
+	registry.Lock()
+	registry.dir = dir
+	registry.modules = modules
+	registry.loaded = true
+	registry.Unlock()
+
+	for _, e := range errs {
+		slog.Warn(e.Error())
+	}
+	slog.Debug(fmt.Sprintf("Loaded %d modules from %s", len(modules), dir))
+	return len(modules), errs
+}
+
+// Reload re-reads every module from the directory they were last loaded from, or Merlin's "data/modules" directory
+func Reload() (loaded int, errs []error) {
+	registry.Lock()
+	dir := registry.dir
+	registry.Unlock()
+	if dir == "" {
+		var err error
+		dir, err = ModuleDir()
+		if err != nil {
+			return 0, []error{err}
+		}
+	}
+	return Load(dir)
+}
+
+// Get returns a copy of the loaded module for the provided name (e.g., windows/x64/powershell/enumeration/PowerView)
+func Get(name string) (Module, error) {
+	registry.load()
+	registry.Lock()
+	defer registry.Unlock()
+	m, ok := registry.modules[strings.TrimLeft(filepath.ToSlash(name), "/")]
+	if !ok {
+		return Module{}, fmt.Errorf("pkg/modules.Get(): the %s module was not found", name)
+	}
+	return m, nil
+}
+
+// Lookup returns a copy of the loaded module for the provided name. The name is either the module's path
+// (e.g., windows/x64/powershell/enumeration/PowerView) or the module's 'name' value for the provided platform
+func Lookup(name, platform string) (Module, error) {
+	if m, err := Get(name); err == nil {
+		return m, nil
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	for _, m := range registry.modules {
+		if strings.EqualFold(m.Name, name) && strings.EqualFold(m.Platform, platform) {
+			return m, nil
+		}
+	}
+	return Module{}, fmt.Errorf("pkg/modules.Lookup(): the %s module for the %s platform was not found", name, platform)
+}
+
+// list returns a sorted list of every loaded module's name
+func (r *store) list() []string {
+	r.load()
+	r.Lock()
+	defer r.Unlock()
+	names := make([]string, 0, len(r.modules))
+	for name := range r.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Modules returns a copy of every loaded module keyed by its name
+func Modules() map[string]Module {
+	return registry.all()
+}
+
+// all returns a copy of every loaded module
+func (r *store) all() map[string]Module {
+	r.load()
+	r.Lock()
+	defer r.Unlock()
+	modules := make(map[string]Module, len(r.modules))
+	for name, m := range r.modules {
+		modules[name] = m
+	}
+	return modules
+}
+
+// load reads in the modules from Merlin's "data/modules" directory the first time the registry is used
+func (r *store) load() {
+	r.Lock()
+	loaded := r.loaded
+	r.Unlock()
+	if !loaded {
+		Reload()
+	}
+}
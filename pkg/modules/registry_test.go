@@ -0,0 +1,185 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package modules
+
+import (
+	// Standard
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testJSON = `{"base": {"name": "JSON", "type": "standard", "platform": "linux", "arch": "x64", "commands": ["id"]}}`
+	testYAML = "base:\n  name: YAML\n  type: extended\n  platform: windows\n  arch: x64\n"
+)
+
+// writeModules writes each module definition to its relative path in a new temporary directory
+func writeModules(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// TestLoad verifies JSON and YAML modules are loaded by name and invalid definitions are skipped and reported
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		modules []string
+		errs    []string
+	}{
+		{
+			name:    "JSON and YAML",
+			files:   map[string]string{"linux/x64/id.json": testJSON, "windows/x64/ext.yaml": testYAML, "readme.md": "# Modules"},
+			modules: []string{"linux/x64/id", "windows/x64/ext"},
+		},
+		{
+			name:    "templates skipped",
+			files:   map[string]string{"templates/base.yaml": "base:\n  name: \"\"\n", "linux/x64/id.yml": testYAML},
+			modules: []string{"linux/x64/id"},
+		},
+		{
+			name:    "missing type",
+			files:   map[string]string{"bad.json": `{"base": {"name": "Bad", "platform": "linux", "arch": "x64", "commands": ["id"]}}`},
+			modules: []string{},
+			errs:    []string{"`type` value"},
+		},
+		{
+			name:    "missing base",
+			files:   map[string]string{"bad.yaml": "name: Bad\n"},
+			modules: []string{},
+			errs:    []string{"'BASE' message type"},
+		},
+		{
+			name:    "duplicate",
+			files:   map[string]string{"dup.json": testJSON, "dup.yaml": testYAML},
+			modules: []string{"dup"},
+			errs:    []string{"duplicate module definition for dup"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loaded, errs := Load(writeModules(t, test.files))
+			if loaded != len(test.modules) {
+				t.Errorf("expected %d modules to be loaded, got %d", len(test.modules), loaded)
+			}
+			if len(errs) != len(test.errs) {
+				t.Fatalf("expected %d errors, got %d: %v", len(test.errs), len(errs), errs)
+			}
+			for i, e := range test.errs {
+				if !strings.Contains(errs[i].Error(), e) {
+					t.Errorf("expected an error containing %q, got: %s", e, errs[i])
+				}
+			}
+			names := registry.list()
+			if strings.Join(names, ",") != strings.Join(test.modules, ",") {
+				t.Errorf("expected modules %v, got %v", test.modules, names)
+			}
+			for _, name := range test.modules {
+				if _, err := Get("/" + name); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+// TestReload verifies modules added after the first load are picked up from the same directory
+func TestReload(t *testing.T) {
+	dir := writeModules(t, map[string]string{"a.json": testJSON})
+	if loaded, errs := Load(dir); loaded != 1 || len(errs) != 0 {
+		t.Fatalf("expected 1 module and no errors, got %d: %v", loaded, errs)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(testYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, errs := Reload(); loaded != 2 || len(errs) != 0 {
+		t.Fatalf("expected 2 modules and no errors, got %d: %v", loaded, errs)
+	}
+	if _, err := Get("b"); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestScriptModule verifies script modules are validated, found by name and platform, and resolve their Starlark
+// script relative to their definition file
+func TestScriptModule(t *testing.T) {
+	script := "base:\n  name: Escalate\n  type: script\n  platform: windows\n  arch: x64\n  lang: %s\n  local:\n    - %s\n"
+	tests := []struct {
+		name   string
+		lang   string
+		local  string
+		lookup string
+		path   string
+		errs   []string
+	}{
+		{"relative script", "starlark", "escalate.star", "Escalate", "windows/x64/escalate.star", nil},
+		{"absolute script", "Starlark", "/opt/scripts/escalate.star", "windows/x64/escalate", "/opt/scripts/escalate.star", nil},
+		{"wrong lang", "python", "escalate.star", "", "", []string{"'starlark' lang"}},
+		{"not a Starlark script", "starlark", "escalate.py", "", "", []string{"Starlark (.star) script"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeModules(t, map[string]string{"windows/x64/escalate.yaml": fmt.Sprintf(script, test.lang, test.local)})
+			_, errs := Load(dir)
+			if len(errs) != len(test.errs) {
+				t.Fatalf("expected %d errors, got %d: %v", len(test.errs), len(errs), errs)
+			}
+			for i, e := range test.errs {
+				if !strings.Contains(errs[i].Error(), e) {
+					t.Errorf("expected an error containing %q, got: %s", e, errs[i])
+				}
+			}
+			if test.errs != nil {
+				return
+			}
+			m, err := Lookup(test.lookup, "Windows")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !m.IsScript() {
+				t.Error("expected the module to be a script")
+			}
+			want := test.path
+			if !filepath.IsAbs(test.local) {
+				want = filepath.Join(dir, filepath.FromSlash(test.path))
+			}
+			if m.ScriptPath() != want {
+				t.Errorf("expected the script path %s, got %s", want, m.ScriptPath())
+			}
+		})
+	}
+	if _, err := Lookup("Escalate", "linux"); err == nil {
+		t.Error("expected an error looking up a module for another platform")
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package modules
+
+import (
+	// Standard
+	"sort"
+	"strings"
+)
+
+// Search returns the names of all loaded modules where the case-insensitive keyword is found in the module's name,
+// description, authors, tags, or ATT&CK technique IDs
+func Search(keyword string) []string {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	var names []string
+	for name, m := range registry.all() {
+		if keyword == "" || m.matches(name, keyword) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// matches determines if the lowercase keyword is found in any of the module's searchable fields
+func (m *Module) matches(name, keyword string) bool {
+	fields := []string{name, m.Name, m.Description}
+	fields = append(fields, m.Author...)
+	fields = append(fields, m.Tags...)
+	fields = append(fields, m.Attack...)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// Category returns the module's category, which is the last directory in the module's path
+// (e.g., windows/x64/powershell/enumeration/PowerView is in the "enumeration" category)
+func Category(name string) string {
+	parts := strings.Split(strings.Trim(name, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.ToLower(parts[len(parts)-2])
+}
+
+// Categories returns a map of every module category and the names of the modules it contains.
+// Module tags are also used as categories
+func Categories() map[string][]string {
+	categories := make(map[string][]string)
+	for name, m := range registry.all() {
+		seen := make(map[string]bool)
+		for _, category := range append([]string{Category(name)}, m.Tags...) {
+			category = strings.ToLower(category)
+			if category == "" || seen[category] {
+				continue
+			}
+			seen[category] = true
+			categories[category] = append(categories[category], name)
+		}
+	}
+	for category := range categories {
+		sort.Strings(categories[category])
+	}
+	return categories
+}
+
+// Attack returns the ATT&CK technique IDs for the loaded module that matches the provided name and platform
+func Attack(name, platform string) []string {
+	for _, m := range registry.all() {
+		if strings.EqualFold(m.Name, name) && strings.EqualFold(m.Platform, platform) {
+			return m.Attack
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package modules
+
+import (
+	// Standard
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// loadSearchModules loads a fixed set of modules used to test searching and browsing
+func loadSearchModules(t *testing.T) {
+	t.Helper()
+	dir := writeModules(t, map[string]string{
+		"windows/x64/powershell/enumeration/PowerView.yaml": "base:\n  name: PowerView\n  type: standard\n  platform: windows\n  arch: x64\n  author: [\"Will Schroeder\"]\n  description: Active Directory recon\n  tags: [recon, AD]\n  attack: [T1087.002]\n  commands: [powershell.exe]\n",
+		"linux/x64/bash/troll/Prank.json":                   `{"base": {"name": "Prank", "type": "standard", "platform": "linux", "arch": "x64", "description": "Troll the user", "commands": ["bash"]}}`,
+		"Top.json":                                          `{"base": {"name": "Top", "type": "standard", "platform": "linux", "arch": "x64", "commands": ["top"], "tags": ["troll"]}}`,
+	})
+	if _, errs := Load(dir); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+}
+
+// TestSearch verifies modules are matched, case-insensitively, on their name, description, author, and tags
+func TestSearch(t *testing.T) {
+	loadSearchModules(t)
+	tests := []struct {
+		keyword string
+		want    []string
+	}{
+		{"", []string{"Top", "linux/x64/bash/troll/Prank", "windows/x64/powershell/enumeration/PowerView"}},
+		{"POWERVIEW", []string{"windows/x64/powershell/enumeration/PowerView"}},
+		{"schroeder", []string{"windows/x64/powershell/enumeration/PowerView"}},
+		{" ad ", []string{"windows/x64/powershell/enumeration/PowerView"}},
+		{"troll", []string{"Top", "linux/x64/bash/troll/Prank"}},
+		{"linux/x64", []string{"linux/x64/bash/troll/Prank"}},
+		{"t1087", []string{"windows/x64/powershell/enumeration/PowerView"}},
+		{"nothing", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.keyword, func(t *testing.T) {
+			if got := Search(test.keyword); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// TestCategory verifies a module's category is its parent directory
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"windows/x64/powershell/enumeration/PowerView", "enumeration"},
+		{"/linux/x64/bash/Troll/Prank/", "troll"},
+		{"Top", ""},
+	}
+	for _, test := range tests {
+		if got := Category(test.name); got != test.want {
+			t.Errorf("expected category %q for %s, got %q", test.want, test.name, got)
+		}
+	}
+}
+
+// TestCategories verifies modules are grouped by their category and tags without duplicates
+func TestCategories(t *testing.T) {
+	loadSearchModules(t)
+	want := map[string][]string{
+		"enumeration": {"windows/x64/powershell/enumeration/PowerView"},
+		"recon":       {"windows/x64/powershell/enumeration/PowerView"},
+		"ad":          {"windows/x64/powershell/enumeration/PowerView"},
+		"troll":       {"Top", "linux/x64/bash/troll/Prank"},
+	}
+	got := Categories()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected categories %v, got %v", want, got)
+	}
+	for category := range got {
+		if strings.ToLower(category) != category {
+			t.Errorf("expected a lowercase category, got %s", category)
+		}
+	}
+}
+
+// TestAttack verifies a module's ATT&CK techniques are found by its name and platform
+func TestAttack(t *testing.T) {
+	loadSearchModules(t)
+	tests := []struct {
+		name     string
+		platform string
+		want     []string
+	}{
+		{"powerview", "Windows", []string{"T1087.002"}},
+		{"PowerView", "linux", nil},
+		{"Prank", "linux", nil},
+	}
+	for _, test := range tests {
+		if got := Attack(test.name, test.platform); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("expected %v for %s on %s, got %v", test.want, test.name, test.platform, got)
+		}
+	}
+}
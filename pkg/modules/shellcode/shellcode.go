@@ -69,16 +69,11 @@ func Parse(options map[string]string) ([]string, error) {
 	}
 
 	// Verify Method is a valid type
-	switch strings.ToLower(options["method"]) {
-	case "self":
-	case "remote":
-	case "rtlcreateuserthread":
-	case "userapc":
-	default:
-		return nil, fmt.Errorf("invalid shellcode execution method: %s", options["method"])
-
+	method, err := Method(options["method"])
+	if err != nil {
+		return nil, err
 	}
-	command, errCommand := GetJob(options["method"], b64, options["pid"])
+	command, errCommand := GetJob(method, b64, options["pid"])
 	if errCommand != nil {
 		return nil, fmt.Errorf("there was an error getting the shellcode job:\r\n%s", errCommand.Error())
 	}
@@ -86,20 +81,42 @@ func Parse(options map[string]string) ([]string, error) {
 	return command, nil
 }
 
+// Methods is the list of process injection techniques, implemented by the Agent, used to execute shellcode
+var Methods = []string{"self", "remote", "rtlcreateuserthread", "userapc", "earlybird", "modulestomping"}
+
+// Method validates the provided process injection technique and returns the name the Agent uses for it.
+// The Windows API names CreateRemoteThread and QueueUserAPC are accepted as aliases for "remote" and "userapc"
+func Method(method string) (string, error) {
+	method = strings.ToLower(strings.TrimSpace(method))
+	switch method {
+	case "createremotethread":
+		return "remote", nil
+	case "queueuserapc":
+		return "userapc", nil
+	case "early-bird":
+		return "earlybird", nil
+	case "module-stomping":
+		return "modulestomping", nil
+	}
+	for _, m := range Methods {
+		if method == m {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("invalid shellcode execution method: %s", method)
+}
+
 // GetJob returns a string array containing the commands, in the proper order, to be used with agents.AddJob
 func GetJob(method string, shellcode string, pid string) ([]string, error) {
 	// TODO shellcode input needs to be Base64 encoded
-	switch strings.ToLower(method) {
-	case "self":
-		return []string{"shellcode", "self", shellcode}, nil
-	case "remote":
-		return []string{"shellcode", "remote", pid, shellcode}, nil
-	case "rtlcreateuserthread":
-		return []string{"shellcode", "rtlcreateuserthread", pid, shellcode}, nil
-	case "userapc":
-		return []string{"shellcode", "userapc", pid, shellcode}, nil
-	}
-	return nil, errors.New("a valid shellcode method was not provided")
+	method, err := Method(method)
+	if err != nil {
+		return nil, errors.New("a valid shellcode method was not provided")
+	}
+	if method == "self" {
+		return []string{"shellcode", shellcode, method}, nil
+	}
+	return []string{"shellcode", shellcode, method, pid}, nil
 }
 
 // ParseShellcode determines if the inputs is a file and/or what format the shellcode  is in (hex, binary, CSharp)
@@ -71,21 +71,10 @@ func Parse(options map[string]string) ([]string, error) {
 	}
 
 	// Verify Method is a valid type
-	var method string
-	switch strings.ToLower(options["method"]) {
-	case "self":
-		method = "self"
-	case "remote":
-		method = "remote"
-	case "rtlcreateuserthread":
-		method = "RtlCreateUserThread"
-	case "userapc":
-		method = "UserAPC"
-	default:
-		return nil, fmt.Errorf("invlaid shellcode execution method: %s", method)
-
+	method, err := shellcode.Method(options["method"])
+	if err != nil {
+		return nil, err
 	}
-	// TODO add types as constant or list in shellcode.go
 
 	sc, errShellcode := dllToReflectiveShellcode(options["dll"], options["function"], clearHeader, options["args"])
 	if errShellcode != nil {
@@ -0,0 +1,90 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opaque
+
+import (
+	// Standard
+	"encoding/json"
+	"fmt"
+
+	// 3rd Party
+	"github.com/cretz/gopaque/gopaque"
+	"go.dedis.ch/kyber/v3"
+)
+
+// record is the serialized form of a completed OPAQUE registration. The server's private key is global and is not
+// part of the record
+type record struct {
+	UserID        []byte `json:"user_id"`
+	UserPublicKey []byte `json:"user_public_key"`
+	EnvU          []byte `json:"env_u"`
+	KU            []byte `json:"k_u"`
+}
+
+// Registered returns true if the server holds a completed registration
+func (s *Server) Registered() bool {
+	return s != nil && s.regComplete != nil
+}
+
+// MarshalRecord serializes the completed registration so that the Agent can authenticate after a server restart
+func (s *Server) MarshalRecord() ([]byte, error) {
+	if !s.Registered() {
+		return nil, fmt.Errorf("pkg/opaque.MarshalRecord(): the OPAQUE registration is not complete")
+	}
+	var r record
+	var err error
+	r.UserID = s.regComplete.UserID
+	r.EnvU = s.regComplete.EnvU
+	r.UserPublicKey, err = s.regComplete.UserPublicKey.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/opaque.MarshalRecord(): there was an error marshalling the user's public key: %s", err)
+	}
+	r.KU, err = s.regComplete.KU.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/opaque.MarshalRecord(): there was an error marshalling the OPRF key: %s", err)
+	}
+	return json.Marshal(r)
+}
+
+// ServerFromRecord restores a completed registration serialized with MarshalRecord using the server's private key
+func ServerFromRecord(data []byte, key kyber.Scalar) (*Server, error) {
+	var r record
+	err := json.Unmarshal(data, &r)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/opaque.ServerFromRecord(): there was an error unmarshalling the registration record: %s", err)
+	}
+	complete := gopaque.ServerRegisterComplete{
+		UserID:           r.UserID,
+		ServerPrivateKey: key,
+		UserPublicKey:    gopaque.CryptoDefault.Point(),
+		EnvU:             r.EnvU,
+		KU:               gopaque.CryptoDefault.Scalar(),
+	}
+	err = complete.UserPublicKey.UnmarshalBinary(r.UserPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/opaque.ServerFromRecord(): there was an error unmarshalling the user's public key: %s", err)
+	}
+	err = complete.KU.UnmarshalBinary(r.KU)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/opaque.ServerFromRecord(): there was an error unmarshalling the OPRF key: %s", err)
+	}
+	return &Server{regComplete: &complete}, nil
+}
@@ -0,0 +1,164 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opaque
+
+import (
+	// Standard
+	"testing"
+
+	// 3rd Party
+	"github.com/cretz/gopaque/gopaque"
+	"github.com/google/uuid"
+	"go.dedis.ch/kyber/v3"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/opaque"
+)
+
+// register completes an OPAQUE registration for the Agent as both the user and the server
+func register(t *testing.T, id uuid.UUID, password string, key kyber.Scalar) *Server {
+	t.Helper()
+	userID, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	userReg := gopaque.NewUserRegister(gopaque.CryptoDefault, userID, nil)
+	initBytes, err := userReg.Init([]byte(password)).ToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, server, err := ServerRegisterInit(id, opaque.Opaque{Type: opaque.RegInit, Payload: initBytes}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var serverInit gopaque.ServerRegisterInit
+	err = serverInit.FromBytes(gopaque.CryptoDefault, resp.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	completeBytes, err := userReg.Complete(&serverInit).ToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ServerRegisterComplete(id, opaque.Opaque{Type: opaque.RegComplete, Payload: completeBytes}, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+// authenticate performs OPAQUE authentication as the user against the server
+func authenticate(id uuid.UUID, password string, server *Server) error {
+	userID, err := id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	userAuth := gopaque.NewUserAuth(gopaque.CryptoDefault, userID, gopaque.NewKeyExchangeSigma(gopaque.CryptoDefault))
+	userInit, err := userAuth.Init([]byte(password))
+	if err != nil {
+		return err
+	}
+	initBytes, err := userInit.ToBytes()
+	if err != nil {
+		return err
+	}
+	resp, err := ServerAuthenticateInit(opaque.Opaque{Type: opaque.AuthInit, Payload: initBytes}, server)
+	if err != nil {
+		return err
+	}
+	var serverComplete gopaque.ServerAuthComplete
+	err = serverComplete.FromBytes(gopaque.CryptoDefault, resp.Payload)
+	if err != nil {
+		return err
+	}
+	_, userComplete, err := userAuth.Complete(&serverComplete)
+	if err != nil {
+		return err
+	}
+	completeBytes, err := userComplete.ToBytes()
+	if err != nil {
+		return err
+	}
+	return ServerAuthenticateComplete(opaque.Opaque{Type: opaque.AuthComplete, Payload: completeBytes}, server)
+}
+
+// TestServerFromRecord verifies an Agent can authenticate against a registration restored from its record
+func TestServerFromRecord(t *testing.T) {
+	id := uuid.New()
+	key := gopaque.CryptoDefault.NewKey(nil)
+	record, err := register(t, id, "merlin", key).MarshalRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		record   []byte
+		key      kyber.Scalar
+		password string
+		wantErr  bool
+		authErr  bool
+	}{
+		{"restored", record, key, "merlin", false, false},
+		{"wrong password", record, key, "merlim", false, true},
+		{"different server key", record, gopaque.CryptoDefault.NewKey(nil), "merlin", false, true},
+		{"invalid record", []byte("merlin"), key, "merlin", true, false},
+		{"invalid public key", []byte(`{"user_public_key":"bWVybGlu"}`), key, "merlin", true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, err := ServerFromRecord(test.record, test.key)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if !server.Registered() {
+				t.Fatal("expected the restored server to be registered")
+			}
+			err = authenticate(id, test.password, server)
+			if (err != nil) != test.authErr {
+				t.Errorf("expected authentication error %t, have %v", test.authErr, err)
+			}
+		})
+	}
+}
+
+func TestMarshalRecordIncomplete(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *Server
+	}{
+		{"nil", nil},
+		{"not registered", &Server{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.server.Registered() {
+				t.Error("expected the server not to be registered")
+			}
+			if _, err := test.server.MarshalRecord(); err == nil {
+				t.Error("expected an error marshalling an incomplete registration")
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opaque
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+
+	// 3rd Party
+	"github.com/cretz/gopaque/gopaque"
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/opaque"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+)
+
+// User is the structure that holds information for the various steps of the OPAQUE protocol as the user, or Agent.
+// The server uses it to stand in for an Agent, such as when it tests its own message pipeline
+type User struct {
+	id       []byte
+	password []byte
+	reg      *gopaque.UserRegister
+	auth     *gopaque.UserAuth
+	Kex      *gopaque.KeyExchangeSigma
+}
+
+// UserRegisterInit is used to start the OPAQUE Password Authenticated Key Exchange (PAKE) protocol Registration steps for the user
+func UserRegisterInit(AgentID uuid.UUID, password []byte) (opaque.Opaque, *User, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function")
+
+	agentIDBytes, err := AgentID.MarshalBinary()
+	if err != nil {
+		return opaque.Opaque{}, nil, fmt.Errorf("there was an error marshalling the AgentID to bytes: %s", err)
+	}
+
+	user := User{
+		id:       agentIDBytes,
+		password: password,
+		reg:      gopaque.NewUserRegister(gopaque.CryptoDefault, agentIDBytes, nil),
+	}
+
+	userRegInitBytes, err := user.reg.Init(password).ToBytes()
+	if err != nil {
+		return opaque.Opaque{}, &user, fmt.Errorf("there was an error marshalling the OPAQUE user registration initialization message to bytes:\r\n%s", err)
+	}
+
+	returnMessage := opaque.Opaque{
+		Type:    opaque.RegInit,
+		Payload: userRegInitBytes,
+	}
+	return returnMessage, &user, nil
+}
+
+// UserRegisterComplete consumes the Server's response and finishes OPAQUE Registration for the user
+func UserRegisterComplete(o opaque.Opaque, user *User) (opaque.Opaque, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function")
+
+	var serverRegInit gopaque.ServerRegisterInit
+	err := serverRegInit.FromBytes(gopaque.CryptoDefault, o.Payload)
+	if err != nil {
+		return opaque.Opaque{}, fmt.Errorf("there was an error unmarshalling the OPAQUE server register initialization message from bytes:\r\n%s", err)
+	}
+
+	userRegCompleteBytes, err := user.reg.Complete(&serverRegInit).ToBytes()
+	if err != nil {
+		return opaque.Opaque{}, fmt.Errorf("there was an error marshalling the OPAQUE user registration complete message to bytes:\r\n%s", err)
+	}
+
+	returnMessage := opaque.Opaque{
+		Type:    opaque.RegComplete,
+		Payload: userRegCompleteBytes,
+	}
+	return returnMessage, nil
+}
+
+// UserAuthenticateInit is used to start OPAQUE authentication as the user after it registered
+func UserAuthenticateInit(user *User) (opaque.Opaque, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function")
+
+	// Ensure the user parameter is not nil
+	if user == nil {
+		return opaque.Opaque{}, fmt.Errorf("pkg/opaque.UserAuthenticateInit(): the OPAQUE user parameter was nil")
+	}
+
+	user.Kex = gopaque.NewKeyExchangeSigma(gopaque.CryptoDefault)
+	user.auth = gopaque.NewUserAuth(gopaque.CryptoDefault, user.id, user.Kex)
+
+	userAuthInit, err := user.auth.Init(user.password)
+	if err != nil {
+		return opaque.Opaque{}, fmt.Errorf("there was an error initializing the OPAQUE user authentication:\r\n%s", err)
+	}
+
+	userAuthInitBytes, err := userAuthInit.ToBytes()
+	if err != nil {
+		return opaque.Opaque{}, fmt.Errorf("there was an error marshalling the OPAQUE user authentication initialization message to bytes:\r\n%s", err)
+	}
+
+	returnMessage := opaque.Opaque{
+		Type:    opaque.AuthInit,
+		Payload: userAuthInitBytes,
+	}
+	return returnMessage, nil
+}
+
+// UserAuthenticateComplete consumes the Server's authentication message and finishes the authentication and key
+// exchange for the user. The user's Kex holds the shared secret afterward
+func UserAuthenticateComplete(o opaque.Opaque, user *User) (opaque.Opaque, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function")
+
+	var serverAuthComplete gopaque.ServerAuthComplete
+	err := serverAuthComplete.FromBytes(gopaque.CryptoDefault, o.Payload)
+	if err != nil {
+		return opaque.Opaque{}, fmt.Errorf("there was an error unmarshalling the OPAQUE server authentication complete message from bytes:\r\n%s", err)
+	}
+
+	_, userAuthComplete, err := user.auth.Complete(&serverAuthComplete)
+	if err != nil {
+		return opaque.Opaque{}, fmt.Errorf("there was an error completing the OPAQUE user authentication:\r\n%s", err)
+	}
+
+	userAuthCompleteBytes, err := userAuthComplete.ToBytes()
+	if err != nil {
+		return opaque.Opaque{}, fmt.Errorf("there was an error marshalling the OPAQUE user authentication complete message to bytes:\r\n%s", err)
+	}
+
+	returnMessage := opaque.Opaque{
+		Type:    opaque.AuthComplete,
+		Payload: userAuthCompleteBytes,
+	}
+	return returnMessage, nil
+}
@@ -0,0 +1,95 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opaque
+
+import (
+	// Standard
+	"testing"
+
+	// 3rd Party
+	"github.com/cretz/gopaque/gopaque"
+	"github.com/google/uuid"
+)
+
+// TestUser verifies the user-side steps register and authenticate with the server-side steps and establish the same
+// session key, and that the wrong password fails authentication
+func TestUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		login    string
+		wantErr  bool
+	}{
+		{"matching password", "correct horse battery staple", "correct horse battery staple", false},
+		{"wrong password", "correct horse battery staple", "Tr0ub4dor&3", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := uuid.New()
+			key := gopaque.CryptoDefault.NewKey(nil)
+
+			o, user, err := UserRegisterInit(id, []byte(test.password))
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, server, err := ServerRegisterInit(id, o, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err = UserRegisterComplete(o, user)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = ServerRegisterComplete(id, o, server)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			user.password = []byte(test.login)
+			o, err = UserAuthenticateInit(user)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err = ServerAuthenticateInit(o, server)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err = UserAuthenticateComplete(o, user)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			err = ServerAuthenticateComplete(o, server)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user.Kex.SharedSecret.String() != server.Kex.SharedSecret.String() {
+				t.Error("expected the user and server to establish the same session key")
+			}
+		})
+	}
+
+	if _, err := UserAuthenticateInit(nil); err == nil {
+		t.Error("expected an error for a nil user")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving the payload inventory
+package memory
+
+import (
+	// Standard
+	"errors"
+	"sort"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/payloads"
+)
+
+var (
+	ErrPayloadNotFound = errors.New("pkg/payloads/memory: the payload was not found in the repository")
+)
+
+// Repository is the structure that implements the in-memory repository for payloads
+type Repository struct {
+	payloads map[uuid.UUID]payloads.Payload
+	sync.RWMutex
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{payloads: make(map[uuid.UUID]payloads.Payload)}
+
+// NewRepository returns the in-memory repository for payloads
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the payload in the repository
+func (r *Repository) Add(p payloads.Payload) error {
+	r.Lock()
+	defer r.Unlock()
+	r.payloads[p.ID()] = p
+	return nil
+}
+
+// Get returns the payload for the provided ID
+func (r *Repository) Get(id uuid.UUID) (payloads.Payload, error) {
+	r.RLock()
+	defer r.RUnlock()
+	p, ok := r.payloads[id]
+	if !ok {
+		return payloads.Payload{}, ErrPayloadNotFound
+	}
+	return p, nil
+}
+
+// GetAll returns all payloads sorted by the time they were added to the inventory
+func (r *Repository) GetAll() (all []payloads.Payload) {
+	r.RLock()
+	for _, p := range r.payloads {
+		all = append(all, p)
+	}
+	r.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Created().Before(all[j].Created())
+	})
+	return
+}
+
+// GetHash returns all payloads whose MD5, SHA-1, or SHA-256 hash matches the provided hash
+func (r *Repository) GetHash(hash string) (matches []payloads.Payload) {
+	for _, p := range r.GetAll() {
+		if p.Hash(hash) {
+			matches = append(matches, p)
+		}
+	}
+	return
+}
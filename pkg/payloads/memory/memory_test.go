@@ -0,0 +1,92 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	// Standard
+	"sync"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/payloads"
+)
+
+// TestRepositoryConcurrentAccess adds payloads from several goroutines while others list and search the inventory, the
+// way files are hosted and bind Agents deployed while operators export it. Run with the -race flag to detect data races
+func TestRepositoryConcurrentAccess(t *testing.T) {
+	var wg sync.WaitGroup
+	for w := 0; w < 10; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r := NewRepository()
+			for i := 0; i < 50; i++ {
+				p := payloads.NewPayload("agent.exe", []byte(uuid.NewString()), time.Time{}, uuid.Nil, "", "generator")
+				if err := r.Add(p); err != nil {
+					t.Error(err)
+				}
+				if _, err := r.Get(p.ID()); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			r := NewRepository()
+			for i := 0; i < 50; i++ {
+				_ = r.GetHash(uuid.NewString())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestRepositoryGet verifies a payload is returned by its ID and an unknown ID returns ErrPayloadNotFound
+func TestRepositoryGet(t *testing.T) {
+	r := NewRepository()
+	p := payloads.NewPayload("agent.exe", []byte(uuid.NewString()), time.Time{}, uuid.Nil, "", "generator")
+	if err := r.Add(p); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		id   uuid.UUID
+		err  error
+	}{
+		{"found", p.ID(), nil},
+		{"not found", uuid.New(), ErrPayloadNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			have, err := r.Get(test.id)
+			if err != test.err {
+				t.Fatalf("expected error %v, have %v", test.err, err)
+			}
+			if err == nil && have.SHA256() != p.SHA256() {
+				t.Errorf("expected payload %s, have %s", p.SHA256(), have.SHA256())
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package payloads holds the structures for the inventory of payloads built for, or delivered by, the Merlin server so
+// that their hashes can be provided to defenders for deconfliction
+package payloads
+
+import (
+	// Standard
+	"crypto/md5"  // #nosec G501 MD5 is only used to identify files for deconfliction
+	"crypto/sha1" // #nosec G505 SHA-1 is only used to identify files for deconfliction
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Payload is a single file built for, or delivered by, the Merlin server
+type Payload struct {
+	id       uuid.UUID // id is the unique identifier for the payload
+	name     string    // name is the payload's file name
+	md5      string    // md5 is the hex encoded MD5 hash of the payload
+	sha1     string    // sha1 is the hex encoded SHA-1 hash of the payload
+	sha256   string    // sha256 is the hex encoded SHA-256 hash of the payload
+	size     int       // size is the number of bytes in the payload
+	listener uuid.UUID // listener is the listener the payload communicates with or is delivered from
+	operator string    // operator is who built or registered the payload
+	source   string    // source is how the payload came to the server (e.g., generator, hosted, deploy)
+	built    time.Time // built is when the payload was built
+	created  time.Time // created is when the payload was added to the inventory
+}
+
+// NewPayload is a factory to create a Payload structure from the payload's contents. A zero built time is replaced with
+// the current time
+func NewPayload(name string, data []byte, built time.Time, listener uuid.UUID, operator, source string) Payload {
+	created := time.Now().UTC()
+	if built.IsZero() {
+		built = created
+	}
+	md5Hash := md5.Sum(data)   // #nosec G401 MD5 is only used to identify files for deconfliction
+	sha1Hash := sha1.Sum(data) // #nosec G401 SHA-1 is only used to identify files for deconfliction
+	sha256Hash := sha256.Sum256(data)
+	return Payload{
+		id:       uuid.New(),
+		name:     name,
+		md5:      hex.EncodeToString(md5Hash[:]),
+		sha1:     hex.EncodeToString(sha1Hash[:]),
+		sha256:   hex.EncodeToString(sha256Hash[:]),
+		size:     len(data),
+		listener: listener,
+		operator: operator,
+		source:   source,
+		built:    built.UTC(),
+		created:  created,
+	}
+}
+
+// Built returns when the payload was built
+func (p *Payload) Built() time.Time {
+	return p.built
+}
+
+// Created returns when the payload was added to the inventory
+func (p *Payload) Created() time.Time {
+	return p.created
+}
+
+// Hash returns true if the provided MD5, SHA-1, or SHA-256 hex encoded hash matches the payload
+func (p *Payload) Hash(hash string) bool {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	return hash == p.md5 || hash == p.sha1 || hash == p.sha256
+}
+
+// ID returns the payload's unique identifier
+func (p *Payload) ID() uuid.UUID {
+	return p.id
+}
+
+// Listener returns the listener the payload communicates with or is delivered from
+func (p *Payload) Listener() uuid.UUID {
+	return p.listener
+}
+
+// MD5 returns the hex encoded MD5 hash of the payload
+func (p *Payload) MD5() string {
+	return p.md5
+}
+
+// Name returns the payload's file name
+func (p *Payload) Name() string {
+	return p.name
+}
+
+// Operator returns who built or registered the payload
+func (p *Payload) Operator() string {
+	return p.operator
+}
+
+// SHA1 returns the hex encoded SHA-1 hash of the payload
+func (p *Payload) SHA1() string {
+	return p.sha1
+}
+
+// SHA256 returns the hex encoded SHA-256 hash of the payload
+func (p *Payload) SHA256() string {
+	return p.sha256
+}
+
+// Size returns the number of bytes in the payload
+func (p *Payload) Size() int {
+	return p.size
+}
+
+// Source returns how the payload came to the server (e.g., generator, hosted, deploy)
+func (p *Payload) Source() string {
+	return p.source
+}
@@ -0,0 +1,93 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package payloads
+
+import (
+	// Standard
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestNewPayload verifies the payload's hashes and size are calculated from its contents and a zero build time is
+// replaced with the time it was added
+func TestNewPayload(t *testing.T) {
+	built := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		built time.Time
+	}{
+		{"build time", built},
+		{"no build time", time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := NewPayload("agent.exe", []byte("MZ"), test.built, uuid.Nil, "operator", "generator")
+			if p.MD5() != "ac6ad5d9b99757c3a878f2d275ace198" {
+				t.Errorf("expected the MD5 hash ac6ad5d9b99757c3a878f2d275ace198, have %s", p.MD5())
+			}
+			if p.SHA1() != "439baa1b33514fb81632aaf44d16a9378c5664fc" {
+				t.Errorf("expected the SHA-1 hash 439baa1b33514fb81632aaf44d16a9378c5664fc, have %s", p.SHA1())
+			}
+			if p.SHA256() != "9b8db510ef42b8ed54a3712636fda55a4f8cfcd5493e20b74ab00cd4f3979f2d" {
+				t.Errorf("expected the SHA-256 hash 9b8db510ef42b8ed54a3712636fda55a4f8cfcd5493e20b74ab00cd4f3979f2d, have %s", p.SHA256())
+			}
+			if p.Size() != 2 || p.ID() == uuid.Nil {
+				t.Errorf("expected a 2 byte payload with an ID, have %d bytes and ID %s", p.Size(), p.ID())
+			}
+			want := test.built
+			if want.IsZero() {
+				want = p.Created()
+			}
+			if !p.Built().Equal(want) {
+				t.Errorf("expected the build time %s, have %s", want, p.Built())
+			}
+		})
+	}
+}
+
+// TestPayloadHash verifies a payload matches any of its hashes regardless of case or surrounding whitespace
+func TestPayloadHash(t *testing.T) {
+	p := NewPayload("agent.exe", []byte("MZ"), time.Time{}, uuid.Nil, "", "generator")
+	tests := []struct {
+		name string
+		hash string
+		want bool
+	}{
+		{"md5", p.MD5(), true},
+		{"sha1", p.SHA1(), true},
+		{"sha256", p.SHA256(), true},
+		{"upper case", strings.ToUpper(p.SHA256()), true},
+		{"whitespace", " " + p.MD5() + "\n", true},
+		{"prefix", p.SHA256()[:16], false},
+		{"empty", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := p.Hash(test.hash); got != test.want {
+				t.Errorf("expected %t for %q, have %t", test.want, test.hash, got)
+			}
+		})
+	}
+}
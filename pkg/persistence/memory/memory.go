@@ -0,0 +1,96 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving persistence artifacts
+package memory
+
+import (
+	// Standard
+	"errors"
+	"sort"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence"
+)
+
+var (
+	ErrArtifactNotFound = errors.New("pkg/persistence/memory: the persistence artifact was not found in the repository")
+)
+
+// Repository is the structure that implements the in-memory repository for persistence artifacts
+type Repository struct {
+	sync.RWMutex
+	artifacts map[uuid.UUID]persistence.Artifact
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{artifacts: make(map[uuid.UUID]persistence.Artifact)}
+
+// NewRepository returns the in-memory repository for persistence artifacts
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the persistence artifact in the repository
+func (r *Repository) Add(artifact persistence.Artifact) error {
+	r.Lock()
+	defer r.Unlock()
+	r.artifacts[artifact.ID()] = artifact
+	return nil
+}
+
+// Get returns the persistence artifact for the provided ID
+func (r *Repository) Get(id uuid.UUID) (persistence.Artifact, error) {
+	r.RLock()
+	defer r.RUnlock()
+	artifact, ok := r.artifacts[id]
+	if !ok {
+		return persistence.Artifact{}, ErrArtifactNotFound
+	}
+	return artifact, nil
+}
+
+// GetAll returns all persistence artifacts sorted by the time they were created
+func (r *Repository) GetAll() (all []persistence.Artifact) {
+	r.RLock()
+	for _, artifact := range r.artifacts {
+		all = append(all, artifact)
+	}
+	r.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Created().Before(all[j].Created())
+	})
+	return
+}
+
+// Update replaces the persistence artifact in the repository with the one provided
+func (r *Repository) Update(artifact persistence.Artifact) error {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.artifacts[artifact.ID()]; !ok {
+		return ErrArtifactNotFound
+	}
+	r.artifacts[artifact.ID()] = artifact
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package persistence holds the structures for persistence artifacts Agents create on target hosts
+package persistence
+
+import (
+	// Standard
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Status is the state of a persistence artifact on the target host
+type Status int
+
+const (
+	UNDEFINED Status = iota
+	// INSTALLED artifacts were successfully created by the Agent and remain on the target host
+	INSTALLED
+	// REMOVED artifacts were successfully deleted by the Agent
+	REMOVED
+	// FAILED artifacts could not be removed and must be cleaned up manually
+	FAILED
+)
+
+// Technique describes a persistence technique the Agent can install
+type Technique struct {
+	Platform string // Platform is the operating system the technique works on (e.g., windows)
+	Location string // Location is the format string, taking the artifact name, for where the artifact is created
+}
+
+// Techniques are the supported persistence techniques keyed by the name used with the persist command
+var Techniques = map[string]Technique{
+	"launchd":  {Platform: "darwin", Location: "~/Library/LaunchAgents/%s.plist"},
+	"registry": {Platform: "windows", Location: "HKCU\\Software\\Microsoft\\Windows\\CurrentVersion\\Run\\%s"},
+	"schtask":  {Platform: "windows", Location: "\\%s"},
+	"systemd":  {Platform: "linux", Location: "~/.config/systemd/user/%s.service"},
+	"wmi":      {Platform: "windows", Location: "root\\subscription:%s"},
+}
+
+// Artifact is a single persistence mechanism an Agent created on a target host
+type Artifact struct {
+	id        uuid.UUID // id is the unique identifier for the artifact
+	agentID   uuid.UUID // agentID is the Agent that created the artifact
+	jobID     string    // jobID is the job that created the artifact
+	technique string    // technique is the persistence technique used (e.g., registry)
+	name      string    // name is the name of the run key, task, service, or subscription
+	location  string    // location is where the artifact was created on the target host
+	command   string    // command is the command line the artifact executes
+	status    Status    // status is the state of the artifact on the target host
+	created   time.Time // created is when the artifact was created
+	updated   time.Time // updated is when the artifact's status last changed
+}
+
+// NewArtifact is a factory to create a persistence Artifact structure for an installed persistence mechanism
+func NewArtifact(agentID uuid.UUID, jobID, technique, name, location, command string) Artifact {
+	now := time.Now().UTC()
+	return Artifact{
+		id:        uuid.New(),
+		agentID:   agentID,
+		jobID:     jobID,
+		technique: technique,
+		name:      name,
+		location:  location,
+		command:   command,
+		status:    INSTALLED,
+		created:   now,
+		updated:   now,
+	}
+}
+
+// AgentID returns the Agent that created the artifact
+func (a *Artifact) AgentID() uuid.UUID {
+	return a.agentID
+}
+
+// Command returns the command line the artifact executes
+func (a *Artifact) Command() string {
+	return a.command
+}
+
+// Created returns when the artifact was created
+func (a *Artifact) Created() time.Time {
+	return a.created
+}
+
+// ID returns the artifact's unique identifier
+func (a *Artifact) ID() uuid.UUID {
+	return a.id
+}
+
+// JobID returns the job that created the artifact
+func (a *Artifact) JobID() string {
+	return a.jobID
+}
+
+// Location returns where the artifact was created on the target host
+func (a *Artifact) Location() string {
+	return a.location
+}
+
+// Name returns the name of the run key, task, service, or subscription
+func (a *Artifact) Name() string {
+	return a.name
+}
+
+// Status returns the state of the artifact on the target host
+func (a *Artifact) Status() Status {
+	return a.status
+}
+
+// Technique returns the persistence technique used
+func (a *Artifact) Technique() string {
+	return a.technique
+}
+
+// Updated returns when the artifact's status last changed
+func (a *Artifact) Updated() time.Time {
+	return a.updated
+}
+
+// UpdateStatus sets the state of the artifact on the target host
+func (a *Artifact) UpdateStatus(status Status) {
+	a.status = status
+	a.updated = time.Now().UTC()
+}
+
+// String returns the artifact status as a string
+func (s Status) String() string {
+	switch s {
+	case INSTALLED:
+		return "Installed"
+	case REMOVED:
+		return "Removed"
+	case FAILED:
+		return "Failed"
+	default:
+		return fmt.Sprintf("unknown persistence artifact status %d", s)
+	}
+}
+
+// TechniqueNames returns a sorted, comma separated, list of the supported persistence technique names
+func TechniqueNames() string {
+	var names []string
+	for name := range Techniques {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,306 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package plugins loads third-party Agent commands from plugin directories so that private command sets can be added to
+// the server, and the CLI menu, without modifying Merlin's source code.
+//
+// Every directory in the plugins directory with a plugin.yaml manifest is a plugin. An example manifest:
+//
+//	name: recon
+//	description: Extra host enumeration commands
+//	version: 1.0.0
+//	commands:
+//	  - name: whoami-all
+//	    description: List the user's groups and privileges
+//	    usage: whoami-all
+//	    os: [windows]
+//	    job: run
+//	    args: ["whoami.exe", "/all"]
+//	  - name: sweep
+//	    description: Ping sweep a subnet and report the live hosts
+//	    usage: sweep <cidr>
+//	    minArgs: 1
+//	    script: sweep.star
+//
+// A command is either sent to the Agent as one of the server's built-in job types, with its arguments built from the
+// args templates, or handled on the server by a Starlark script in the plugin's directory that tasks the Agent itself.
+// In an args template, {{1}} is replaced with the operator's first argument, {{args}} with all of them separated by
+// spaces, and a template that is only {{args}} is replaced with each of the operator's arguments
+package plugins
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	// 3rd Party
+	"gopkg.in/yaml.v3"
+)
+
+// manifests are the file names of a plugin's manifest in its directory
+var manifests = []string{"plugin.yaml", "plugin.yml"}
+
+// placeholder matches the {{N}} and {{args}} placeholders in a command's argument templates
+var placeholder = regexp.MustCompile(`\{\{\s*(args|\d+)\s*\}\}`)
+
+// Plugin is a set of Agent commands loaded from a plugin manifest
+type Plugin struct {
+	Name        string    `yaml:"name"`
+	Description string    `yaml:"description"`
+	Author      string    `yaml:"author"`
+	Version     string    `yaml:"version"`
+	Commands    []Command `yaml:"commands"`
+	dir         string
+}
+
+// Command is an Agent command provided by a plugin
+type Command struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Usage       string   `yaml:"usage"`
+	OS          []string `yaml:"os"`      // The Agent platforms (e.g., windows, linux, darwin) that support the command; empty is all
+	MinArgs     int      `yaml:"minArgs"` // The number of arguments the operator must provide
+	Job         string   `yaml:"job"`     // The built-in job type the command is sent to the Agent as (e.g., run, shell, invoke-assembly)
+	Args        []string `yaml:"args"`    // The job's argument templates
+	Script      string   `yaml:"script"`  // The Starlark script, relative to the plugin's directory, that handles the command
+	plugin      string
+	dir         string
+}
+
+// Plugin returns the name of the plugin that provides the command
+func (c *Command) Plugin() string {
+	return c.plugin
+}
+
+// IsScript returns true if the command is handled on the server by a Starlark script
+func (c *Command) IsScript() bool {
+	return c.Script != ""
+}
+
+// ScriptPath returns the absolute path to the command's Starlark script
+func (c *Command) ScriptPath() string {
+	return filepath.Join(c.dir, filepath.FromSlash(c.Script))
+}
+
+// Build returns the job's arguments from the command's argument templates and the arguments the operator provided
+func (c *Command) Build(args []string) ([]string, error) {
+	if len(args) < c.MinArgs {
+		return nil, fmt.Errorf("the %s command requires at least %d argument(s), received %d; usage: %s", c.Name, c.MinArgs, len(args), c.Usage)
+	}
+	var built []string
+	for _, template := range c.Args {
+		if strings.TrimSpace(template) == "{{args}}" {
+			built = append(built, args...)
+			continue
+		}
+		var err error
+		value := placeholder.ReplaceAllStringFunc(template, func(match string) string {
+			key := placeholder.FindStringSubmatch(match)[1]
+			if key == "args" {
+				return strings.Join(args, " ")
+			}
+			i, _ := strconv.Atoi(key)
+			if i < 1 || i > len(args) {
+				err = fmt.Errorf("the %s command's argument template %s requires argument %d; usage: %s", c.Name, template, i, c.Usage)
+				return ""
+			}
+			return args[i-1]
+		})
+		if err != nil {
+			return nil, err
+		}
+		built = append(built, value)
+	}
+	return built, nil
+}
+
+// Options returns the operator's arguments as the options a command's Starlark script receives: "args" holds all the
+// arguments separated by spaces and "1", "2", and so on hold each argument
+func (c *Command) Options(args []string) (map[string]string, error) {
+	if len(args) < c.MinArgs {
+		return nil, fmt.Errorf("the %s command requires at least %d argument(s), received %d; usage: %s", c.Name, c.MinArgs, len(args), c.Usage)
+	}
+	options := map[string]string{"args": strings.Join(args, " ")}
+	for i, arg := range args {
+		options[strconv.Itoa(i+1)] = arg
+	}
+	return options, nil
+}
+
+// newPlugin reads and validates the plugin manifest at the provided path
+func newPlugin(path string) (Plugin, error) {
+	data, err := os.ReadFile(path) // #nosec G304 Plugins are loaded from the server's plugins directory
+	if err != nil {
+		return Plugin{}, fmt.Errorf("pkg/plugins.newPlugin(): there was an error reading the %s plugin manifest: %s", path, err)
+	}
+	var p Plugin
+	err = yaml.Unmarshal(data, &p)
+	if err != nil {
+		return Plugin{}, fmt.Errorf("pkg/plugins.newPlugin(): there was an error parsing the %s plugin manifest: %s", path, err)
+	}
+	p.dir = filepath.Dir(path)
+	if p.Name == "" {
+		p.Name = filepath.Base(p.dir)
+	}
+	if len(p.Commands) == 0 {
+		return Plugin{}, fmt.Errorf("pkg/plugins.newPlugin(): the %s plugin at %s does not have any commands", p.Name, path)
+	}
+	for i := range p.Commands {
+		c := &p.Commands[i]
+		c.plugin = p.Name
+		c.dir = p.dir
+		if c.Name == "" || strings.ContainsAny(c.Name, " \t\n") {
+			return Plugin{}, fmt.Errorf("pkg/plugins.newPlugin(): the %s plugin's command %d must have a name without spaces", p.Name, i)
+		}
+		if (c.Job == "") == (c.Script == "") {
+			return Plugin{}, fmt.Errorf("pkg/plugins.newPlugin(): the %s plugin's %s command must have either a job or a script", p.Name, c.Name)
+		}
+		if c.IsScript() {
+			if _, err = os.Stat(c.ScriptPath()); err != nil {
+				return Plugin{}, fmt.Errorf("pkg/plugins.newPlugin(): the %s plugin's %s command script is not usable: %s", p.Name, c.Name, err)
+			}
+		}
+		for j, platform := range c.OS {
+			c.OS[j] = strings.ToLower(platform)
+		}
+		if c.Usage == "" {
+			c.Usage = c.Name
+		}
+	}
+	return p, nil
+}
+
+// store holds every plugin that was loaded and validated from the plugins directory and the commands they provide
+type store struct {
+	dir      string
+	plugins  []Plugin
+	commands map[string]Command
+	sync.Mutex
+}
+
+// registry is the in-memory store of all loaded plugins
+var registry = &store{commands: make(map[string]Command)}
+
+// PluginDir returns the absolute path to Merlin's "data/plugins" directory
+func PluginDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("pkg/plugins.PluginDir(): there was an error getting the working directory: %s", err)
+	}
+	return filepath.Join(dir, "data", "plugins"), nil
+}
+
+// Load reads the manifest in every directory of the provided plugins directory and replaces all previously loaded
+// plugins. Plugins that fail validation, or that provide a command another plugin already provides, are not loaded and
+// their errors are returned. A plugins directory that does not exist has no plugins
+func Load(dir string) (loaded int, errs []error) {
+	var found []Plugin
+	commands := make(map[string]Command)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		errs = append(errs, fmt.Errorf("pkg/plugins.Load(): there was an error reading the %s directory: %s", dir, err))
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		for _, manifest := range manifests {
+			path := filepath.Join(dir, entry.Name(), manifest)
+			if _, err = os.Stat(path); err != nil {
+				continue
+			}
+			p, err := newPlugin(path)
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			var duplicate error
+			for _, c := range p.Commands {
+				if existing, ok := commands[strings.ToLower(c.Name)]; ok {
+					duplicate = fmt.Errorf("pkg/plugins.Load(): the %s plugin's %s command is already provided by the %s plugin", p.Name, c.Name, existing.plugin)
+					break
+				}
+			}
+			if duplicate != nil {
+				errs = append(errs, duplicate)
+				break
+			}
+			for _, c := range p.Commands {
+				commands[strings.ToLower(c.Name)] = c
+			}
+			found = append(found, p)
+			break
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+
+	registry.Lock()
+	registry.dir = dir
+	registry.plugins = found
+	registry.commands = commands
+	registry.Unlock()
+
+	for _, e := range errs {
+		slog.Warn(e.Error())
+	}
+	slog.Debug(fmt.Sprintf("Loaded %d plugins from %s", len(found), dir))
+	return len(found), errs
+}
+
+// Reload re-reads every plugin from the directory they were last loaded from, or Merlin's "data/plugins" directory
+func Reload() (loaded int, errs []error) {
+	registry.Lock()
+	dir := registry.dir
+	registry.Unlock()
+	if dir == "" {
+		var err error
+		dir, err = PluginDir()
+		if err != nil {
+			return 0, []error{err}
+		}
+	}
+	return Load(dir)
+}
+
+// Get returns the plugin command with the provided name
+func Get(name string) (Command, error) {
+	registry.Lock()
+	defer registry.Unlock()
+	c, ok := registry.commands[strings.ToLower(name)]
+	if !ok {
+		return Command{}, fmt.Errorf("pkg/plugins.Get(): a plugin does not provide the %s command", name)
+	}
+	return c, nil
+}
+
+// Plugins returns every loaded plugin sorted by name
+func Plugins() []Plugin {
+	registry.Lock()
+	defer registry.Unlock()
+	return append([]Plugin(nil), registry.plugins...)
+}
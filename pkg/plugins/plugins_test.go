@@ -0,0 +1,205 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package plugins
+
+import (
+	// Standard
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePlugin writes the manifest, and any scripts, for a plugin in its own directory of the plugins directory
+func writePlugin(t *testing.T, dir, name, manifest string, scripts ...string) {
+	t.Helper()
+	pluginDir := filepath.Join(dir, name)
+	err := os.MkdirAll(pluginDir, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(pluginDir, "plugin.yaml"), []byte(manifest), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, script := range scripts {
+		err = os.WriteFile(filepath.Join(pluginDir, script), []byte("print(\"plugin\")\n"), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestLoad verifies valid plugins are loaded, invalid or conflicting plugins are reported, and loading again replaces
+// the previously loaded plugins
+func TestLoad(t *testing.T) {
+	t.Cleanup(func() { Load(filepath.Join(t.TempDir(), "none")) })
+	dir := t.TempDir()
+	writePlugin(t, dir, "recon", `
+name: recon
+version: 1.0.0
+commands:
+  - name: Whoami-All
+    os: [Windows]
+    job: run
+    args: ["whoami.exe", "/all"]
+  - name: sweep
+    minArgs: 1
+    script: sweep.star
+`, "sweep.star")
+	writePlugin(t, dir, "shadow", `
+commands:
+  - name: whoami-all
+    job: run
+`)
+	writePlugin(t, dir, "both", `
+commands:
+  - name: both
+    job: run
+    script: both.star
+`, "both.star")
+	writePlugin(t, dir, "missing-script", `
+commands:
+  - name: missing
+    script: missing.star
+`)
+	writePlugin(t, dir, "spaces", `
+commands:
+  - name: two words
+    job: run
+`)
+	writePlugin(t, dir, "empty", "name: empty\n")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a plugin"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, errs := Load(dir)
+	if loaded != 1 || len(errs) != 5 {
+		t.Fatalf("expected 1 plugin and 5 errors, have %d and %v", loaded, errs)
+	}
+	tests := []struct {
+		name   string
+		plugin string
+		script bool
+		err    bool
+	}{
+		{"whoami-all", "recon", false, false},
+		{"WHOAMI-ALL", "recon", false, false},
+		{"sweep", "recon", true, false},
+		{"both", "", false, true},
+		{"missing", "", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := Get(test.name)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %t, have %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if c.Plugin() != test.plugin || c.IsScript() != test.script {
+				t.Errorf("expected the %s plugin's command with script %t, have %s and %t", test.plugin, test.script, c.Plugin(), c.IsScript())
+			}
+		})
+	}
+	if c, _ := Get("whoami-all"); len(c.OS) != 1 || c.OS[0] != "windows" || c.Usage != "Whoami-All" {
+		t.Errorf("expected the platform to be lower case and the usage to default to the name, have %+v", c)
+	}
+
+	if loaded, _ = Reload(); loaded != 1 {
+		t.Errorf("expected reloading to load 1 plugin, have %d", loaded)
+	}
+	loaded, errs = Load(filepath.Join(dir, "missing"))
+	if loaded != 0 || len(errs) != 0 || len(Plugins()) != 0 {
+		t.Errorf("expected a missing plugins directory to have no plugins or errors, have %d and %v", loaded, errs)
+	}
+}
+
+// TestBuild verifies a job command's arguments are built from its templates and the operator's arguments
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		minArgs  int
+		template []string
+		args     []string
+		want     []string
+		err      string
+	}{
+		{"static", 0, []string{"whoami.exe", "/all"}, nil, []string{"whoami.exe", "/all"}, ""},
+		{"positional", 1, []string{"ping.exe", "-n", "1", "{{1}}"}, []string{"192.0.2.1"}, []string{"ping.exe", "-n", "1", "192.0.2.1"}, ""},
+		{"embedded", 0, []string{"--target={{ 2 }}:{{1}}"}, []string{"445", "host"}, []string{"--target=host:445"}, ""},
+		{"args joined", 0, []string{"-c", "echo {{args}}"}, []string{"a", "b"}, []string{"-c", "echo a b"}, ""},
+		{"args expanded", 0, []string{"nltest.exe", "{{args}}"}, []string{"/dclist:", "acme"}, []string{"nltest.exe", "/dclist:", "acme"}, ""},
+		{"too few arguments", 2, []string{"{{1}}"}, []string{"a"}, nil, "requires at least 2 argument(s)"},
+		{"missing positional", 0, []string{"{{2}}"}, []string{"a"}, nil, "requires argument 2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := Command{Name: "test", MinArgs: test.minArgs, Args: test.template, Usage: "test"}
+			have, err := c.Build(test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q, have %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(have, "|") != strings.Join(test.want, "|") {
+				t.Errorf("expected %q, have %q", test.want, have)
+			}
+		})
+	}
+}
+
+// TestOptions verifies the operator's arguments are passed to a script command as numbered options and "args"
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		minArgs int
+		args    []string
+		want    map[string]string
+		err     bool
+	}{
+		{"no arguments", 0, nil, map[string]string{"args": ""}, false},
+		{"arguments", 1, []string{"10.0.0.0/24", "fast"}, map[string]string{"args": "10.0.0.0/24 fast", "1": "10.0.0.0/24", "2": "fast"}, false},
+		{"too few arguments", 1, nil, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := Command{Name: "sweep", MinArgs: test.minArgs, Usage: "sweep <cidr>"}
+			have, err := c.Options(test.args)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %t, have %v", test.err, err)
+			}
+			if len(have) != len(test.want) {
+				t.Fatalf("expected %v, have %v", test.want, have)
+			}
+			for k, v := range test.want {
+				if have[k] != v {
+					t.Errorf("expected option %s to be %q, have %q", k, v, have[k])
+				}
+			}
+		})
+	}
+}
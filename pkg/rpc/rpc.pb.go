@@ -1894,7 +1894,7 @@ var file_pkg_rpc_rpc_proto_rawDesc = []byte{
 	0x4e, 0x46, 0x4f, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x54, 0x45, 0x10, 0x02, 0x12,
 	0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42,
 	0x55, 0x47, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10,
-	0x05, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x4c, 0x41, 0x49, 0x4e, 0x10, 0x06, 0x32, 0xec, 0x1b, 0x0a,
+	0x05, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x4c, 0x41, 0x49, 0x4e, 0x10, 0x06, 0x32, 0xd1, 0x38, 0x0a,
 	0x06, 0x4d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x6e,
 	0x6e, 0x65, 0x63, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x07, 0x2e,
 	0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69,
@@ -1905,222 +1905,452 @@ var file_pkg_rpc_rpc_proto_rawDesc = []byte{
 	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x24, 0x0a, 0x03,
 	0x41, 0x6e, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
 	0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x00, 0x12, 0x23, 0x0a, 0x02, 0x43, 0x44, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x22, 0x0a, 0x07, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x49, 0x6e, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x24, 0x0a, 0x09, 0x43,
-	0x6c, 0x65, 0x61, 0x72, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49,
-	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x00, 0x12, 0x3a, 0x0a, 0x10, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4a, 0x6f, 0x62, 0x73, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0c, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x24, 0x0a,
-	0x03, 0x43, 0x4d, 0x44, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
-	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x0d,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a,
-	0x08, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x24, 0x0a, 0x03, 0x45, 0x4e, 0x56, 0x12,
-	0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x30,
-	0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c,
-	0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44,
-	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
-	0x12, 0x2a, 0x0a, 0x09, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x50, 0x45, 0x12, 0x0d, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x10,
-	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x63, 0x6f, 0x64, 0x65,
+	0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x6f, 0x64, 0x48, 0x6f, 0x75, 0x6e, 0x64,
 	0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a,
 	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
-	0x1f, 0x0a, 0x04, 0x45, 0x78, 0x69, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44,
-	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
-	0x12, 0x23, 0x0a, 0x08, 0x49, 0x46, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x07, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x0e, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x41,
-	0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67,
+	0x2a, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x0d, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x43,
+	0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67,
 	0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x24, 0x0a, 0x03, 0x4a, 0x41, 0x33, 0x12, 0x0d, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08,
-	0x4b, 0x69, 0x6c, 0x6c, 0x44, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x23, 0x0a, 0x02, 0x43, 0x44, 0x12, 0x0d, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x22, 0x0a, 0x07, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x24, 0x0a, 0x09, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x07, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x10, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4a, 0x6f,
+	0x62, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x24, 0x0a, 0x03, 0x43, 0x4d, 0x44, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
 	0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0b, 0x4b, 0x69, 0x6c, 0x6c, 0x50,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d,
 	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x00, 0x12, 0x29, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c,
-	0x69, 0x65, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08,
-	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x00, 0x12, 0x27, 0x0a, 0x06, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x44, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x24, 0x0a, 0x03, 0x45, 0x4e, 0x56, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
+	0x41, 0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
 	0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x41,
-	0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x09, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x65, 0x50, 0x45, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x10, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x53, 0x68,
+	0x65, 0x6c, 0x6c, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67,
 	0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x4c,
-	0x52, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44,
-	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
-	0x12, 0x23, 0x0a, 0x02, 0x4c, 0x53, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72,
-	0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44,
-	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
-	0x12, 0x27, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x1f, 0x0a, 0x04, 0x45, 0x78, 0x69, 0x74, 0x12, 0x07,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x46, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
+	0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x12, 0x23, 0x0a, 0x08, 0x49, 0x46, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x07,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x0e, 0x49, 0x6e, 0x76, 0x6f, 0x6b,
+	0x65, 0x41, 0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x24, 0x0a, 0x03, 0x4a, 0x41, 0x33, 0x12,
+	0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29,
+	0x0a, 0x08, 0x4b, 0x69, 0x6c, 0x6c, 0x44, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63,
 	0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x4d, 0x45, 0x4d,
-	0x46, 0x44, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d,
-	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x00, 0x12, 0x28, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x73, 0x74, 0x61, 0x74, 0x12, 0x0d, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x04, 0x4e,
-	0x6f, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0b, 0x4b, 0x69, 0x6c,
+	0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x65, 0x6d,
+	0x62, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29,
+	0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0c, 0x4c, 0x6f, 0x61,
+	0x64, 0x41, 0x73, 0x73, 0x65, 0x6d, 0x62, 0x6c, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x4c, 0x6f, 0x61, 0x64,
+	0x43, 0x4c, 0x52, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
 	0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x4e, 0x73, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x0d,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a,
-	0x07, 0x50, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x22, 0x00, 0x12, 0x23, 0x0a, 0x02, 0x4c, 0x53, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
 	0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x72, 0x6f,
-	0x74, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44,
-	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
-	0x12, 0x20, 0x0a, 0x05, 0x50, 0x69, 0x70, 0x65, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x00, 0x12, 0x1d, 0x0a, 0x02, 0x50, 0x53, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49,
-	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x00, 0x12, 0x1e, 0x0a, 0x03, 0x50, 0x57, 0x44, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49,
-	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x00, 0x12, 0x23, 0x0a, 0x02, 0x52, 0x4d, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67,
-	0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x52, 0x75, 0x6e, 0x41, 0x73, 0x12,
-	0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2d,
-	0x0a, 0x0c, 0x53, 0x65, 0x63, 0x75, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0d,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x4d, 0x61, 0x78, 0x52, 0x65,
+	0x74, 0x72, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
+	0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x12, 0x27, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x0d, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x4d,
+	0x45, 0x4d, 0x46, 0x44, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x73, 0x74, 0x61, 0x74, 0x12, 0x0d,
 	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a,
-	0x08, 0x53, 0x68, 0x61, 0x72, 0x70, 0x47, 0x65, 0x6e, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x04, 0x53, 0x6b, 0x65, 0x77,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a,
+	0x04, 0x4e, 0x6f, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x4e, 0x73, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
 	0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a,
 	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
-	0x26, 0x0a, 0x05, 0x53, 0x6c, 0x65, 0x65, 0x70, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x28, 0x0a, 0x07, 0x50, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x06, 0x50, 0x61, 0x72,
+	0x72, 0x6f, 0x74, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
+	0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x12, 0x0d, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x20, 0x0a, 0x05,
+	0x50, 0x69, 0x70, 0x65, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x24,
+	0x0a, 0x09, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x1d,
+	0x0a, 0x02, 0x50, 0x53, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x1e, 0x0a,
+	0x03, 0x50, 0x57, 0x44, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x23, 0x0a,
+	0x02, 0x52, 0x4d, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
+	0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x52, 0x75, 0x6e, 0x41, 0x73, 0x12, 0x0d, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x06, 0x53, 0x43,
+	0x45, 0x78, 0x65, 0x63, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x75, 0x72, 0x65, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
+	0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x12, 0x2b, 0x0a, 0x10, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x29, 0x0a, 0x08, 0x53, 0x68, 0x61, 0x72, 0x70, 0x47, 0x65, 0x6e, 0x12, 0x0d, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x04, 0x53, 0x6b,
+	0x65, 0x77, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d,
+	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x26, 0x0a, 0x05, 0x53, 0x6c, 0x65, 0x65, 0x70, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x53, 0x6f, 0x63,
+	0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d,
+	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x24, 0x0a, 0x03, 0x53, 0x53, 0x48, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
 	0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x53, 0x6f, 0x63, 0x6b, 0x73,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x09, 0x53, 0x53, 0x48, 0x44, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x08, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x12,
+	0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x23,
+	0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x0d, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x54,
+	0x6f, 0x75, 0x63, 0x68, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74,
 	0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a,
 	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
-	0x24, 0x0a, 0x03, 0x53, 0x53, 0x48, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x0d,
+	0x2c, 0x0a, 0x0b, 0x55, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x0d,
 	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a,
-	0x05, 0x54, 0x6f, 0x75, 0x63, 0x68, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0b, 0x55, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a,
+	0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x21, 0x0a, 0x06, 0x55, 0x70, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x07, 0x57, 0x4d, 0x49,
+	0x45, 0x78, 0x65, 0x63, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
 	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0d, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x21, 0x0a, 0x06,
-	0x55, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a,
-	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
-	0x2e, 0x0a, 0x06, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12,
-	0x26, 0x0a, 0x08, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x64, 0x64, 0x12, 0x0a, 0x2e, 0x72, 0x70,
+	0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x06, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63,
+	0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x08, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x64, 0x64, 0x12,
+	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x22, 0x0a, 0x09, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49,
+	0x44, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12,
+	0x3b, 0x0a, 0x0c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0b,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70,
 	0x63, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x22, 0x0a, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x4c, 0x69, 0x73, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0a, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0c, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x31,
+	0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d,
-	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0b, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12,
-	0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x09, 0x47, 0x65,
-	0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a,
-	0x0d, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x07,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c,
-	0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e,
-	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44,
-	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
-	0x12, 0x38, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x77, 0x73,
+	0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22,
+	0x00, 0x12, 0x26, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x6e,
+	0x6b, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x52, 0x6f, 0x77, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x21,
+	0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49,
+	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2c, 0x0a,
+	0x0b, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x0d, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0b, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69,
+	0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x77, 0x73,
 	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x21, 0x0a, 0x06, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a,
-	0x0a, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x09, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00,
-	0x12, 0x37, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
-	0x4a, 0x6f, 0x62, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x09, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x24, 0x0a, 0x0c, 0x47, 0x65, 0x74,
-	0x41, 0x67, 0x65, 0x6e, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x49, 0x44, 0x1a, 0x09, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12,
-	0x2a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76,
-	0x65, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x09,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x0e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x0c, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0e, 0x47,
-	0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x73, 0x12, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63,
-	0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
-	0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2d, 0x0a,
-	0x12, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x19,
-	0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x44, 0x65, 0x66, 0x61, 0x75,
-	0x6c, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00,
-	0x12, 0x2c, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29,
-	0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
-	0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x0f, 0x52, 0x65, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74,
-	0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63,
+	0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0x00, 0x12, 0x22, 0x0a, 0x07, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12,
+	0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0a, 0x51, 0x75, 0x61, 0x72,
+	0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00,
+	0x12, 0x27, 0x0a, 0x06, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63,
 	0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x0d, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x07,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x0d, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d, 0x44, 0x1a, 0x0e, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x3c, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74,
+	0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x41, 0x6c, 0x6c, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x09, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x37,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4a, 0x6f,
+	0x62, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x09, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x24, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44,
+	0x1a, 0x09, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x2a, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4a,
+	0x6f, 0x62, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x09, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x15, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x76, 0x69, 0x67, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x26, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x0c, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x09, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a,
-	0x09, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f,
-	0x64, 0x75, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64,
-	0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x07, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00,
+	0x12, 0x38, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x32, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x4d, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a,
+	0x0c, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x0d, 0x44, 0x72, 0x61, 0x69, 0x6e,
+	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49,
+	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x2e, 0x0a, 0x0f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x4d, 0x42,
+	0x50, 0x69, 0x70, 0x65, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x38, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x0b, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x0f, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x0c,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0e, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x3d,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x69, 0x76, 0x6f, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x25, 0x0a,
+	0x08, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61,
+	0x66, 0x66, 0x69, 0x63, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x12, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x12, 0x53, 0x74, 0x6f,
+	0x70, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x54,
+	0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x1a, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x22,
+	0x00, 0x12, 0x35, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09, 0x52, 0x75, 0x6e, 0x4d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x52, 0x75, 0x6e, 0x1a, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0d, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a,
-	0x09, 0x52, 0x75, 0x6e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x0e, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x75, 0x6e, 0x1a, 0x0d, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x00, 0x42, 0x23, 0x5a, 0x21, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e, 0x65, 0x30, 0x6e, 0x64, 0x30,
-	0x67, 0x2f, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70, 0x63,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x30, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x22,
+	0x00, 0x12, 0x2e, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a,
+	0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22,
+	0x00, 0x12, 0x24, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6f, 0x74, 0x12, 0x07, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x43, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x22, 0x00, 0x12, 0x2b, 0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x35, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53,
+	0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x07, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x49, 0x4f, 0x43, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x24, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x4f, 0x43, 0x73, 0x12, 0x07, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x08, 0x53, 0x68, 0x75, 0x74, 0x64,
+	0x6f, 0x77, 0x6e, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
+	0x12, 0x34, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73,
+	0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0b, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x48, 0x6f, 0x73, 0x74,
+	0x4e, 0x6f, 0x74, 0x65, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x12, 0x26, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x0b, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x0e, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x07, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x0b, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0c, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x28, 0x0a,
+	0x08, 0x48, 0x6f, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x0a, 0x55, 0x6e, 0x68, 0x6f, 0x73,
+	0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x07, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x44, 0x1a, 0x0e,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00,
+	0x12, 0x35, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x09, 0x52, 0x75, 0x6e, 0x50, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x4d,
+	0x44, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x12, 0x36, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0d, 0x52, 0x65, 0x6c,
+	0x6f, 0x61, 0x64, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a, 0x0e,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00,
+	0x12, 0x2d, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x73, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x1a,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x36, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0d, 0x52, 0x65, 0x6c, 0x6f, 0x61,
+	0x64, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00,
+	0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e,
+	0x65, 0x30, 0x6e, 0x64, 0x30, 0x67, 0x2f, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2166,187 +2396,341 @@ var file_pkg_rpc_rpc_proto_goTypes = []interface{}{
 	(*emptypb.Empty)(nil), // 25: google.protobuf.Empty
 }
 var file_pkg_rpc_rpc_proto_depIdxs = []int32{
-	3,  // 0: rpc.AgentInfo.Build:type_name -> rpc.Build
-	5,  // 1: rpc.AgentInfo.Host:type_name -> rpc.Host
-	6,  // 2: rpc.AgentInfo.Process:type_name -> rpc.Process
-	4,  // 3: rpc.AgentInfo.Comms:type_name -> rpc.Comms
-	8,  // 4: rpc.Jobs.jobs:type_name -> rpc.Job
-	0,  // 5: rpc.Message.level:type_name -> rpc.MessageLevel
-	10, // 6: rpc.Messages.messages:type_name -> rpc.Message
-	24, // 7: rpc.Options.options:type_name -> rpc.Options.OptionsEntry
-	13, // 8: rpc.TableData.rows:type_name -> rpc.TableRows
-	17, // 9: rpc.GroupMembers.members:type_name -> rpc.GroupMember
-	20, // 10: rpc.Module.Options:type_name -> rpc.ModuleOption
-	20, // 11: rpc.ModuleRun.Options:type_name -> rpc.ModuleOption
-	1,  // 12: rpc.Merlin.Reconnect:input_type -> rpc.ID
-	25, // 13: rpc.Merlin.Register:input_type -> google.protobuf.Empty
-	1,  // 14: rpc.Merlin.Listen:input_type -> rpc.ID
-	7,  // 15: rpc.Merlin.Any:input_type -> rpc.AgentCMD
-	7,  // 16: rpc.Merlin.CD:input_type -> rpc.AgentCMD
-	1,  // 17: rpc.Merlin.CheckIn:input_type -> rpc.ID
-	1,  // 18: rpc.Merlin.ClearJobs:input_type -> rpc.ID
-	25, // 19: rpc.Merlin.ClearJobsCreated:input_type -> google.protobuf.Empty
-	7,  // 20: rpc.Merlin.CMD:input_type -> rpc.AgentCMD
-	7,  // 21: rpc.Merlin.Connect:input_type -> rpc.AgentCMD
-	7,  // 22: rpc.Merlin.Download:input_type -> rpc.AgentCMD
-	7,  // 23: rpc.Merlin.ENV:input_type -> rpc.AgentCMD
-	7,  // 24: rpc.Merlin.ExecuteAssembly:input_type -> rpc.AgentCMD
-	7,  // 25: rpc.Merlin.ExecutePE:input_type -> rpc.AgentCMD
-	7,  // 26: rpc.Merlin.ExecuteShellcode:input_type -> rpc.AgentCMD
-	1,  // 27: rpc.Merlin.Exit:input_type -> rpc.ID
-	1,  // 28: rpc.Merlin.IFConfig:input_type -> rpc.ID
-	7,  // 29: rpc.Merlin.InvokeAssembly:input_type -> rpc.AgentCMD
-	7,  // 30: rpc.Merlin.JA3:input_type -> rpc.AgentCMD
-	7,  // 31: rpc.Merlin.KillDate:input_type -> rpc.AgentCMD
-	7,  // 32: rpc.Merlin.KillProcess:input_type -> rpc.AgentCMD
-	7,  // 33: rpc.Merlin.LinkAgent:input_type -> rpc.AgentCMD
-	1,  // 34: rpc.Merlin.ListAssemblies:input_type -> rpc.ID
-	7,  // 35: rpc.Merlin.Listener:input_type -> rpc.AgentCMD
-	7,  // 36: rpc.Merlin.LoadAssembly:input_type -> rpc.AgentCMD
-	7,  // 37: rpc.Merlin.LoadCLR:input_type -> rpc.AgentCMD
-	7,  // 38: rpc.Merlin.LS:input_type -> rpc.AgentCMD
-	7,  // 39: rpc.Merlin.MaxRetry:input_type -> rpc.AgentCMD
-	7,  // 40: rpc.Merlin.Memory:input_type -> rpc.AgentCMD
-	7,  // 41: rpc.Merlin.MEMFD:input_type -> rpc.AgentCMD
-	7,  // 42: rpc.Merlin.Netstat:input_type -> rpc.AgentCMD
-	7,  // 43: rpc.Merlin.Note:input_type -> rpc.AgentCMD
-	7,  // 44: rpc.Merlin.Nslookup:input_type -> rpc.AgentCMD
-	7,  // 45: rpc.Merlin.Padding:input_type -> rpc.AgentCMD
-	7,  // 46: rpc.Merlin.Parrot:input_type -> rpc.AgentCMD
-	1,  // 47: rpc.Merlin.Pipes:input_type -> rpc.ID
-	1,  // 48: rpc.Merlin.PS:input_type -> rpc.ID
-	1,  // 49: rpc.Merlin.PWD:input_type -> rpc.ID
-	7,  // 50: rpc.Merlin.RM:input_type -> rpc.AgentCMD
-	7,  // 51: rpc.Merlin.RunAs:input_type -> rpc.AgentCMD
-	7,  // 52: rpc.Merlin.SecureDelete:input_type -> rpc.AgentCMD
-	7,  // 53: rpc.Merlin.SharpGen:input_type -> rpc.AgentCMD
-	7,  // 54: rpc.Merlin.Skew:input_type -> rpc.AgentCMD
-	7,  // 55: rpc.Merlin.Sleep:input_type -> rpc.AgentCMD
-	7,  // 56: rpc.Merlin.Socks:input_type -> rpc.AgentCMD
-	7,  // 57: rpc.Merlin.SSH:input_type -> rpc.AgentCMD
-	7,  // 58: rpc.Merlin.Token:input_type -> rpc.AgentCMD
-	7,  // 59: rpc.Merlin.Touch:input_type -> rpc.AgentCMD
-	7,  // 60: rpc.Merlin.UnlinkAgent:input_type -> rpc.AgentCMD
-	7,  // 61: rpc.Merlin.Upload:input_type -> rpc.AgentCMD
-	1,  // 62: rpc.Merlin.Uptime:input_type -> rpc.ID
-	25, // 63: rpc.Merlin.Groups:input_type -> google.protobuf.Empty
-	16, // 64: rpc.Merlin.GroupAdd:input_type -> rpc.Group
-	1,  // 65: rpc.Merlin.GroupList:input_type -> rpc.ID
-	25, // 66: rpc.Merlin.GroupListAll:input_type -> google.protobuf.Empty
-	16, // 67: rpc.Merlin.GroupRemove:input_type -> rpc.Group
-	1,  // 68: rpc.Merlin.GetAgent:input_type -> rpc.ID
-	25, // 69: rpc.Merlin.GetAgents:input_type -> google.protobuf.Empty
-	1,  // 70: rpc.Merlin.GetAgentLinks:input_type -> rpc.ID
-	1,  // 71: rpc.Merlin.GetAgentStatus:input_type -> rpc.ID
-	25, // 72: rpc.Merlin.GetAgentRows:input_type -> google.protobuf.Empty
-	1,  // 73: rpc.Merlin.Remove:input_type -> rpc.ID
-	25, // 74: rpc.Merlin.GetAllJobs:input_type -> google.protobuf.Empty
-	25, // 75: rpc.Merlin.GetAllActiveJobs:input_type -> google.protobuf.Empty
-	1,  // 76: rpc.Merlin.GetAgentJobs:input_type -> rpc.ID
-	1,  // 77: rpc.Merlin.GetAgentActiveJobs:input_type -> rpc.ID
-	12, // 78: rpc.Merlin.CreateListener:input_type -> rpc.Options
-	25, // 79: rpc.Merlin.GetListenerIDs:input_type -> google.protobuf.Empty
-	25, // 80: rpc.Merlin.GetListeners:input_type -> google.protobuf.Empty
-	1,  // 81: rpc.Merlin.GetListenerOptions:input_type -> rpc.ID
-	19, // 82: rpc.Merlin.GetListenerDefaultOptions:input_type -> rpc.String
-	25, // 83: rpc.Merlin.GetListenerTypes:input_type -> google.protobuf.Empty
-	1,  // 84: rpc.Merlin.GetListenerStatus:input_type -> rpc.ID
-	1,  // 85: rpc.Merlin.RemoveListener:input_type -> rpc.ID
-	1,  // 86: rpc.Merlin.RestartListener:input_type -> rpc.ID
-	7,  // 87: rpc.Merlin.SetListenerOption:input_type -> rpc.AgentCMD
-	1,  // 88: rpc.Merlin.StartListener:input_type -> rpc.ID
-	1,  // 89: rpc.Merlin.StopListener:input_type -> rpc.ID
-	25, // 90: rpc.Merlin.Servers:input_type -> google.protobuf.Empty
-	19, // 91: rpc.Merlin.GetModule:input_type -> rpc.String
-	25, // 92: rpc.Merlin.GetModuleList:input_type -> google.protobuf.Empty
-	22, // 93: rpc.Merlin.RunModule:input_type -> rpc.ModuleRun
-	1,  // 94: rpc.Merlin.Reconnect:output_type -> rpc.ID
-	1,  // 95: rpc.Merlin.Register:output_type -> rpc.ID
-	10, // 96: rpc.Merlin.Listen:output_type -> rpc.Message
-	10, // 97: rpc.Merlin.Any:output_type -> rpc.Message
-	10, // 98: rpc.Merlin.CD:output_type -> rpc.Message
-	10, // 99: rpc.Merlin.CheckIn:output_type -> rpc.Message
-	10, // 100: rpc.Merlin.ClearJobs:output_type -> rpc.Message
-	10, // 101: rpc.Merlin.ClearJobsCreated:output_type -> rpc.Message
-	10, // 102: rpc.Merlin.CMD:output_type -> rpc.Message
-	10, // 103: rpc.Merlin.Connect:output_type -> rpc.Message
-	10, // 104: rpc.Merlin.Download:output_type -> rpc.Message
-	10, // 105: rpc.Merlin.ENV:output_type -> rpc.Message
-	10, // 106: rpc.Merlin.ExecuteAssembly:output_type -> rpc.Message
-	10, // 107: rpc.Merlin.ExecutePE:output_type -> rpc.Message
-	10, // 108: rpc.Merlin.ExecuteShellcode:output_type -> rpc.Message
-	10, // 109: rpc.Merlin.Exit:output_type -> rpc.Message
-	10, // 110: rpc.Merlin.IFConfig:output_type -> rpc.Message
-	10, // 111: rpc.Merlin.InvokeAssembly:output_type -> rpc.Message
-	10, // 112: rpc.Merlin.JA3:output_type -> rpc.Message
-	10, // 113: rpc.Merlin.KillDate:output_type -> rpc.Message
-	10, // 114: rpc.Merlin.KillProcess:output_type -> rpc.Message
-	10, // 115: rpc.Merlin.LinkAgent:output_type -> rpc.Message
-	10, // 116: rpc.Merlin.ListAssemblies:output_type -> rpc.Message
-	10, // 117: rpc.Merlin.Listener:output_type -> rpc.Message
-	10, // 118: rpc.Merlin.LoadAssembly:output_type -> rpc.Message
-	10, // 119: rpc.Merlin.LoadCLR:output_type -> rpc.Message
-	10, // 120: rpc.Merlin.LS:output_type -> rpc.Message
-	10, // 121: rpc.Merlin.MaxRetry:output_type -> rpc.Message
-	10, // 122: rpc.Merlin.Memory:output_type -> rpc.Message
-	10, // 123: rpc.Merlin.MEMFD:output_type -> rpc.Message
-	10, // 124: rpc.Merlin.Netstat:output_type -> rpc.Message
-	10, // 125: rpc.Merlin.Note:output_type -> rpc.Message
-	10, // 126: rpc.Merlin.Nslookup:output_type -> rpc.Message
-	10, // 127: rpc.Merlin.Padding:output_type -> rpc.Message
-	10, // 128: rpc.Merlin.Parrot:output_type -> rpc.Message
-	10, // 129: rpc.Merlin.Pipes:output_type -> rpc.Message
-	10, // 130: rpc.Merlin.PS:output_type -> rpc.Message
-	10, // 131: rpc.Merlin.PWD:output_type -> rpc.Message
-	10, // 132: rpc.Merlin.RM:output_type -> rpc.Message
-	10, // 133: rpc.Merlin.RunAs:output_type -> rpc.Message
-	10, // 134: rpc.Merlin.SecureDelete:output_type -> rpc.Message
-	10, // 135: rpc.Merlin.SharpGen:output_type -> rpc.Message
-	10, // 136: rpc.Merlin.Skew:output_type -> rpc.Message
-	10, // 137: rpc.Merlin.Sleep:output_type -> rpc.Message
-	10, // 138: rpc.Merlin.Socks:output_type -> rpc.Message
-	10, // 139: rpc.Merlin.SSH:output_type -> rpc.Message
-	10, // 140: rpc.Merlin.Token:output_type -> rpc.Message
-	10, // 141: rpc.Merlin.Touch:output_type -> rpc.Message
-	10, // 142: rpc.Merlin.UnlinkAgent:output_type -> rpc.Message
-	10, // 143: rpc.Merlin.Upload:output_type -> rpc.Message
-	10, // 144: rpc.Merlin.Uptime:output_type -> rpc.Message
-	15, // 145: rpc.Merlin.Groups:output_type -> rpc.Slice
-	10, // 146: rpc.Merlin.GroupAdd:output_type -> rpc.Message
-	15, // 147: rpc.Merlin.GroupList:output_type -> rpc.Slice
-	18, // 148: rpc.Merlin.GroupListAll:output_type -> rpc.GroupMembers
-	10, // 149: rpc.Merlin.GroupRemove:output_type -> rpc.Message
-	2,  // 150: rpc.Merlin.GetAgent:output_type -> rpc.AgentInfo
-	15, // 151: rpc.Merlin.GetAgents:output_type -> rpc.Slice
-	15, // 152: rpc.Merlin.GetAgentLinks:output_type -> rpc.Slice
-	10, // 153: rpc.Merlin.GetAgentStatus:output_type -> rpc.Message
-	14, // 154: rpc.Merlin.GetAgentRows:output_type -> rpc.TableData
-	10, // 155: rpc.Merlin.Remove:output_type -> rpc.Message
-	9,  // 156: rpc.Merlin.GetAllJobs:output_type -> rpc.Jobs
-	9,  // 157: rpc.Merlin.GetAllActiveJobs:output_type -> rpc.Jobs
-	9,  // 158: rpc.Merlin.GetAgentJobs:output_type -> rpc.Jobs
-	9,  // 159: rpc.Merlin.GetAgentActiveJobs:output_type -> rpc.Jobs
-	10, // 160: rpc.Merlin.CreateListener:output_type -> rpc.Message
-	15, // 161: rpc.Merlin.GetListenerIDs:output_type -> rpc.Slice
-	14, // 162: rpc.Merlin.GetListeners:output_type -> rpc.TableData
-	12, // 163: rpc.Merlin.GetListenerOptions:output_type -> rpc.Options
-	12, // 164: rpc.Merlin.GetListenerDefaultOptions:output_type -> rpc.Options
-	15, // 165: rpc.Merlin.GetListenerTypes:output_type -> rpc.Slice
-	10, // 166: rpc.Merlin.GetListenerStatus:output_type -> rpc.Message
-	10, // 167: rpc.Merlin.RemoveListener:output_type -> rpc.Message
-	10, // 168: rpc.Merlin.RestartListener:output_type -> rpc.Message
-	10, // 169: rpc.Merlin.SetListenerOption:output_type -> rpc.Message
-	10, // 170: rpc.Merlin.StartListener:output_type -> rpc.Message
-	10, // 171: rpc.Merlin.StopListener:output_type -> rpc.Message
-	15, // 172: rpc.Merlin.Servers:output_type -> rpc.Slice
-	21, // 173: rpc.Merlin.GetModule:output_type -> rpc.Module
-	15, // 174: rpc.Merlin.GetModuleList:output_type -> rpc.Slice
-	11, // 175: rpc.Merlin.RunModule:output_type -> rpc.Messages
-	94, // [94:176] is the sub-list for method output_type
-	12, // [12:94] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	3,   // 0: rpc.AgentInfo.Build:type_name -> rpc.Build
+	5,   // 1: rpc.AgentInfo.Host:type_name -> rpc.Host
+	6,   // 2: rpc.AgentInfo.Process:type_name -> rpc.Process
+	4,   // 3: rpc.AgentInfo.Comms:type_name -> rpc.Comms
+	8,   // 4: rpc.Jobs.jobs:type_name -> rpc.Job
+	0,   // 5: rpc.Message.level:type_name -> rpc.MessageLevel
+	10,  // 6: rpc.Messages.messages:type_name -> rpc.Message
+	24,  // 7: rpc.Options.options:type_name -> rpc.Options.OptionsEntry
+	13,  // 8: rpc.TableData.rows:type_name -> rpc.TableRows
+	17,  // 9: rpc.GroupMembers.members:type_name -> rpc.GroupMember
+	20,  // 10: rpc.Module.Options:type_name -> rpc.ModuleOption
+	20,  // 11: rpc.ModuleRun.Options:type_name -> rpc.ModuleOption
+	1,   // 12: rpc.Merlin.Reconnect:input_type -> rpc.ID
+	25,  // 13: rpc.Merlin.Register:input_type -> google.protobuf.Empty
+	1,   // 14: rpc.Merlin.Listen:input_type -> rpc.ID
+	7,   // 15: rpc.Merlin.Any:input_type -> rpc.AgentCMD
+	7,   // 16: rpc.Merlin.BloodHound:input_type -> rpc.AgentCMD
+	7,   // 17: rpc.Merlin.Broadcast:input_type -> rpc.AgentCMD
+	7,   // 18: rpc.Merlin.Campaign:input_type -> rpc.AgentCMD
+	7,   // 19: rpc.Merlin.CD:input_type -> rpc.AgentCMD
+	1,   // 20: rpc.Merlin.CheckIn:input_type -> rpc.ID
+	1,   // 21: rpc.Merlin.ClearJobs:input_type -> rpc.ID
+	25,  // 22: rpc.Merlin.ClearJobsCreated:input_type -> google.protobuf.Empty
+	7,   // 23: rpc.Merlin.CMD:input_type -> rpc.AgentCMD
+	7,   // 24: rpc.Merlin.Connect:input_type -> rpc.AgentCMD
+	7,   // 25: rpc.Merlin.Deploy:input_type -> rpc.AgentCMD
+	7,   // 26: rpc.Merlin.Destroy:input_type -> rpc.AgentCMD
+	7,   // 27: rpc.Merlin.Download:input_type -> rpc.AgentCMD
+	7,   // 28: rpc.Merlin.ENV:input_type -> rpc.AgentCMD
+	7,   // 29: rpc.Merlin.ExecuteAssembly:input_type -> rpc.AgentCMD
+	7,   // 30: rpc.Merlin.ExecutePE:input_type -> rpc.AgentCMD
+	7,   // 31: rpc.Merlin.ExecuteShellcode:input_type -> rpc.AgentCMD
+	1,   // 32: rpc.Merlin.Exit:input_type -> rpc.ID
+	7,   // 33: rpc.Merlin.Fallback:input_type -> rpc.AgentCMD
+	1,   // 34: rpc.Merlin.IFConfig:input_type -> rpc.ID
+	7,   // 35: rpc.Merlin.InvokeAssembly:input_type -> rpc.AgentCMD
+	7,   // 36: rpc.Merlin.JA3:input_type -> rpc.AgentCMD
+	7,   // 37: rpc.Merlin.KillDate:input_type -> rpc.AgentCMD
+	7,   // 38: rpc.Merlin.KillProcess:input_type -> rpc.AgentCMD
+	7,   // 39: rpc.Merlin.LinkAgent:input_type -> rpc.AgentCMD
+	1,   // 40: rpc.Merlin.ListAssemblies:input_type -> rpc.ID
+	7,   // 41: rpc.Merlin.Listener:input_type -> rpc.AgentCMD
+	7,   // 42: rpc.Merlin.LoadAssembly:input_type -> rpc.AgentCMD
+	7,   // 43: rpc.Merlin.LoadCLR:input_type -> rpc.AgentCMD
+	7,   // 44: rpc.Merlin.LS:input_type -> rpc.AgentCMD
+	7,   // 45: rpc.Merlin.MaxRetry:input_type -> rpc.AgentCMD
+	7,   // 46: rpc.Merlin.Memory:input_type -> rpc.AgentCMD
+	7,   // 47: rpc.Merlin.MEMFD:input_type -> rpc.AgentCMD
+	7,   // 48: rpc.Merlin.Netstat:input_type -> rpc.AgentCMD
+	7,   // 49: rpc.Merlin.Note:input_type -> rpc.AgentCMD
+	7,   // 50: rpc.Merlin.Nslookup:input_type -> rpc.AgentCMD
+	7,   // 51: rpc.Merlin.Padding:input_type -> rpc.AgentCMD
+	7,   // 52: rpc.Merlin.Parrot:input_type -> rpc.AgentCMD
+	7,   // 53: rpc.Merlin.Persist:input_type -> rpc.AgentCMD
+	1,   // 54: rpc.Merlin.Pipes:input_type -> rpc.ID
+	1,   // 55: rpc.Merlin.Preflight:input_type -> rpc.ID
+	7,   // 56: rpc.Merlin.Profile:input_type -> rpc.AgentCMD
+	1,   // 57: rpc.Merlin.PS:input_type -> rpc.ID
+	1,   // 58: rpc.Merlin.PWD:input_type -> rpc.ID
+	7,   // 59: rpc.Merlin.RM:input_type -> rpc.AgentCMD
+	7,   // 60: rpc.Merlin.RunAs:input_type -> rpc.AgentCMD
+	7,   // 61: rpc.Merlin.SCExec:input_type -> rpc.AgentCMD
+	7,   // 62: rpc.Merlin.SecureDelete:input_type -> rpc.AgentCMD
+	1,   // 63: rpc.Merlin.SecurityProducts:input_type -> rpc.ID
+	7,   // 64: rpc.Merlin.SharpGen:input_type -> rpc.AgentCMD
+	7,   // 65: rpc.Merlin.Skew:input_type -> rpc.AgentCMD
+	7,   // 66: rpc.Merlin.Sleep:input_type -> rpc.AgentCMD
+	7,   // 67: rpc.Merlin.Socks:input_type -> rpc.AgentCMD
+	7,   // 68: rpc.Merlin.SSH:input_type -> rpc.AgentCMD
+	7,   // 69: rpc.Merlin.SSHDeploy:input_type -> rpc.AgentCMD
+	7,   // 70: rpc.Merlin.Throttle:input_type -> rpc.AgentCMD
+	1,   // 71: rpc.Merlin.Timezone:input_type -> rpc.ID
+	7,   // 72: rpc.Merlin.Token:input_type -> rpc.AgentCMD
+	7,   // 73: rpc.Merlin.Touch:input_type -> rpc.AgentCMD
+	7,   // 74: rpc.Merlin.Transport:input_type -> rpc.AgentCMD
+	7,   // 75: rpc.Merlin.UnlinkAgent:input_type -> rpc.AgentCMD
+	7,   // 76: rpc.Merlin.Upload:input_type -> rpc.AgentCMD
+	1,   // 77: rpc.Merlin.Uptime:input_type -> rpc.ID
+	7,   // 78: rpc.Merlin.WMIExec:input_type -> rpc.AgentCMD
+	25,  // 79: rpc.Merlin.Groups:input_type -> google.protobuf.Empty
+	16,  // 80: rpc.Merlin.GroupAdd:input_type -> rpc.Group
+	1,   // 81: rpc.Merlin.GroupList:input_type -> rpc.ID
+	25,  // 82: rpc.Merlin.GroupListAll:input_type -> google.protobuf.Empty
+	16,  // 83: rpc.Merlin.GroupRemove:input_type -> rpc.Group
+	1,   // 84: rpc.Merlin.GetAgent:input_type -> rpc.ID
+	25,  // 85: rpc.Merlin.GetAgents:input_type -> google.protobuf.Empty
+	1,   // 86: rpc.Merlin.GetAgentLinks:input_type -> rpc.ID
+	1,   // 87: rpc.Merlin.GetAgentStatus:input_type -> rpc.ID
+	25,  // 88: rpc.Merlin.GetAgentRows:input_type -> google.protobuf.Empty
+	1,   // 89: rpc.Merlin.Remove:input_type -> rpc.ID
+	25,  // 90: rpc.Merlin.GetAgentRoutes:input_type -> google.protobuf.Empty
+	7,   // 91: rpc.Merlin.ExportAgent:input_type -> rpc.AgentCMD
+	12,  // 92: rpc.Merlin.ImportAgent:input_type -> rpc.Options
+	1,   // 93: rpc.Merlin.GetAgentChanges:input_type -> rpc.ID
+	25,  // 94: rpc.Merlin.GetPrivilegedAgentRows:input_type -> google.protobuf.Empty
+	25,  // 95: rpc.Merlin.GetCampaigns:input_type -> google.protobuf.Empty
+	1,   // 96: rpc.Merlin.Release:input_type -> rpc.ID
+	7,   // 97: rpc.Merlin.Quarantine:input_type -> rpc.AgentCMD
+	25,  // 98: rpc.Merlin.GetAgentNames:input_type -> google.protobuf.Empty
+	7,   // 99: rpc.Merlin.Rename:input_type -> rpc.AgentCMD
+	7,   // 100: rpc.Merlin.GetAgentDetails:input_type -> rpc.AgentCMD
+	25,  // 101: rpc.Merlin.GetAgentActivity:input_type -> google.protobuf.Empty
+	25,  // 102: rpc.Merlin.GetAllJobs:input_type -> google.protobuf.Empty
+	25,  // 103: rpc.Merlin.GetAllActiveJobs:input_type -> google.protobuf.Empty
+	1,   // 104: rpc.Merlin.GetAgentJobs:input_type -> rpc.ID
+	1,   // 105: rpc.Merlin.GetAgentActiveJobs:input_type -> rpc.ID
+	19,  // 106: rpc.Merlin.ExportAttackNavigator:input_type -> rpc.String
+	12,  // 107: rpc.Merlin.QueryJobs:input_type -> rpc.Options
+	12,  // 108: rpc.Merlin.SetJobExpiry:input_type -> rpc.Options
+	12,  // 109: rpc.Merlin.CreateListener:input_type -> rpc.Options
+	25,  // 110: rpc.Merlin.GetListenerIDs:input_type -> google.protobuf.Empty
+	25,  // 111: rpc.Merlin.GetListeners:input_type -> google.protobuf.Empty
+	1,   // 112: rpc.Merlin.GetListenerOptions:input_type -> rpc.ID
+	19,  // 113: rpc.Merlin.GetListenerDefaultOptions:input_type -> rpc.String
+	25,  // 114: rpc.Merlin.GetListenerTypes:input_type -> google.protobuf.Empty
+	1,   // 115: rpc.Merlin.GetListenerStatus:input_type -> rpc.ID
+	1,   // 116: rpc.Merlin.RemoveListener:input_type -> rpc.ID
+	1,   // 117: rpc.Merlin.RestartListener:input_type -> rpc.ID
+	7,   // 118: rpc.Merlin.SetListenerOption:input_type -> rpc.AgentCMD
+	1,   // 119: rpc.Merlin.StartListener:input_type -> rpc.ID
+	1,   // 120: rpc.Merlin.StopListener:input_type -> rpc.ID
+	25,  // 121: rpc.Merlin.Servers:input_type -> google.protobuf.Empty
+	1,   // 122: rpc.Merlin.DrainListener:input_type -> rpc.ID
+	19,  // 123: rpc.Merlin.GenerateSMBPipe:input_type -> rpc.String
+	19,  // 124: rpc.Merlin.GetListenerOptionSchema:input_type -> rpc.String
+	12,  // 125: rpc.Merlin.CreateListeners:input_type -> rpc.Options
+	25,  // 126: rpc.Merlin.GetPivotListeners:input_type -> google.protobuf.Empty
+	1,   // 127: rpc.Merlin.SelfTest:input_type -> rpc.ID
+	12,  // 128: rpc.Merlin.StartTrafficCapture:input_type -> rpc.Options
+	1,   // 129: rpc.Merlin.StopTrafficCapture:input_type -> rpc.ID
+	25,  // 130: rpc.Merlin.GetTrafficCaptures:input_type -> google.protobuf.Empty
+	19,  // 131: rpc.Merlin.GetModule:input_type -> rpc.String
+	25,  // 132: rpc.Merlin.GetModuleList:input_type -> google.protobuf.Empty
+	22,  // 133: rpc.Merlin.RunModule:input_type -> rpc.ModuleRun
+	25,  // 134: rpc.Merlin.ReloadModules:input_type -> google.protobuf.Empty
+	19,  // 135: rpc.Merlin.GetModuleCategories:input_type -> rpc.String
+	19,  // 136: rpc.Merlin.SearchModules:input_type -> rpc.String
+	1,   // 137: rpc.Merlin.GetLoot:input_type -> rpc.ID
+	12,  // 138: rpc.Merlin.AddCredential:input_type -> rpc.Options
+	25,  // 139: rpc.Merlin.GetCredentials:input_type -> google.protobuf.Empty
+	1,   // 140: rpc.Merlin.RemoveCredential:input_type -> rpc.ID
+	12,  // 141: rpc.Merlin.GetDirectoryObjects:input_type -> rpc.Options
+	19,  // 142: rpc.Merlin.ExportScanResults:input_type -> rpc.String
+	19,  // 143: rpc.Merlin.GetScanResults:input_type -> rpc.String
+	1,   // 144: rpc.Merlin.GetPersistence:input_type -> rpc.ID
+	19,  // 145: rpc.Merlin.ExportIOCs:input_type -> rpc.String
+	1,   // 146: rpc.Merlin.GetIOCs:input_type -> rpc.ID
+	12,  // 147: rpc.Merlin.KeyAgentConfig:input_type -> rpc.Options
+	19,  // 148: rpc.Merlin.Shutdown:input_type -> rpc.String
+	25,  // 149: rpc.Merlin.GetHosts:input_type -> google.protobuf.Empty
+	19,  // 150: rpc.Merlin.GetHostActivity:input_type -> rpc.String
+	12,  // 151: rpc.Merlin.ImportHosts:input_type -> rpc.Options
+	12,  // 152: rpc.Merlin.AddHostNote:input_type -> rpc.Options
+	19,  // 153: rpc.Merlin.GetHost:input_type -> rpc.String
+	1,   // 154: rpc.Merlin.ApproveRequest:input_type -> rpc.ID
+	1,   // 155: rpc.Merlin.DenyRequest:input_type -> rpc.ID
+	25,  // 156: rpc.Merlin.GetPendingRequests:input_type -> google.protobuf.Empty
+	12,  // 157: rpc.Merlin.HostFile:input_type -> rpc.Options
+	12,  // 158: rpc.Merlin.UnhostFile:input_type -> rpc.Options
+	1,   // 159: rpc.Merlin.GetHostedFiles:input_type -> rpc.ID
+	12,  // 160: rpc.Merlin.GetHostedFileAccess:input_type -> rpc.Options
+	12,  // 161: rpc.Merlin.RegisterPayload:input_type -> rpc.Options
+	19,  // 162: rpc.Merlin.GetPayloads:input_type -> rpc.String
+	19,  // 163: rpc.Merlin.ExportPayloads:input_type -> rpc.String
+	7,   // 164: rpc.Merlin.RunPlugin:input_type -> rpc.AgentCMD
+	25,  // 165: rpc.Merlin.GetPlugins:input_type -> google.protobuf.Empty
+	25,  // 166: rpc.Merlin.ReloadPlugins:input_type -> google.protobuf.Empty
+	19,  // 167: rpc.Merlin.GetCommands:input_type -> rpc.String
+	19,  // 168: rpc.Merlin.ExportCommands:input_type -> rpc.String
+	25,  // 169: rpc.Merlin.GetLocales:input_type -> google.protobuf.Empty
+	25,  // 170: rpc.Merlin.ReloadLocales:input_type -> google.protobuf.Empty
+	1,   // 171: rpc.Merlin.Reconnect:output_type -> rpc.ID
+	1,   // 172: rpc.Merlin.Register:output_type -> rpc.ID
+	10,  // 173: rpc.Merlin.Listen:output_type -> rpc.Message
+	10,  // 174: rpc.Merlin.Any:output_type -> rpc.Message
+	10,  // 175: rpc.Merlin.BloodHound:output_type -> rpc.Message
+	10,  // 176: rpc.Merlin.Broadcast:output_type -> rpc.Message
+	10,  // 177: rpc.Merlin.Campaign:output_type -> rpc.Message
+	10,  // 178: rpc.Merlin.CD:output_type -> rpc.Message
+	10,  // 179: rpc.Merlin.CheckIn:output_type -> rpc.Message
+	10,  // 180: rpc.Merlin.ClearJobs:output_type -> rpc.Message
+	10,  // 181: rpc.Merlin.ClearJobsCreated:output_type -> rpc.Message
+	10,  // 182: rpc.Merlin.CMD:output_type -> rpc.Message
+	10,  // 183: rpc.Merlin.Connect:output_type -> rpc.Message
+	10,  // 184: rpc.Merlin.Deploy:output_type -> rpc.Message
+	10,  // 185: rpc.Merlin.Destroy:output_type -> rpc.Message
+	10,  // 186: rpc.Merlin.Download:output_type -> rpc.Message
+	10,  // 187: rpc.Merlin.ENV:output_type -> rpc.Message
+	10,  // 188: rpc.Merlin.ExecuteAssembly:output_type -> rpc.Message
+	10,  // 189: rpc.Merlin.ExecutePE:output_type -> rpc.Message
+	10,  // 190: rpc.Merlin.ExecuteShellcode:output_type -> rpc.Message
+	10,  // 191: rpc.Merlin.Exit:output_type -> rpc.Message
+	10,  // 192: rpc.Merlin.Fallback:output_type -> rpc.Message
+	10,  // 193: rpc.Merlin.IFConfig:output_type -> rpc.Message
+	10,  // 194: rpc.Merlin.InvokeAssembly:output_type -> rpc.Message
+	10,  // 195: rpc.Merlin.JA3:output_type -> rpc.Message
+	10,  // 196: rpc.Merlin.KillDate:output_type -> rpc.Message
+	10,  // 197: rpc.Merlin.KillProcess:output_type -> rpc.Message
+	10,  // 198: rpc.Merlin.LinkAgent:output_type -> rpc.Message
+	10,  // 199: rpc.Merlin.ListAssemblies:output_type -> rpc.Message
+	10,  // 200: rpc.Merlin.Listener:output_type -> rpc.Message
+	10,  // 201: rpc.Merlin.LoadAssembly:output_type -> rpc.Message
+	10,  // 202: rpc.Merlin.LoadCLR:output_type -> rpc.Message
+	10,  // 203: rpc.Merlin.LS:output_type -> rpc.Message
+	10,  // 204: rpc.Merlin.MaxRetry:output_type -> rpc.Message
+	10,  // 205: rpc.Merlin.Memory:output_type -> rpc.Message
+	10,  // 206: rpc.Merlin.MEMFD:output_type -> rpc.Message
+	10,  // 207: rpc.Merlin.Netstat:output_type -> rpc.Message
+	10,  // 208: rpc.Merlin.Note:output_type -> rpc.Message
+	10,  // 209: rpc.Merlin.Nslookup:output_type -> rpc.Message
+	10,  // 210: rpc.Merlin.Padding:output_type -> rpc.Message
+	10,  // 211: rpc.Merlin.Parrot:output_type -> rpc.Message
+	10,  // 212: rpc.Merlin.Persist:output_type -> rpc.Message
+	10,  // 213: rpc.Merlin.Pipes:output_type -> rpc.Message
+	10,  // 214: rpc.Merlin.Preflight:output_type -> rpc.Message
+	10,  // 215: rpc.Merlin.Profile:output_type -> rpc.Message
+	10,  // 216: rpc.Merlin.PS:output_type -> rpc.Message
+	10,  // 217: rpc.Merlin.PWD:output_type -> rpc.Message
+	10,  // 218: rpc.Merlin.RM:output_type -> rpc.Message
+	10,  // 219: rpc.Merlin.RunAs:output_type -> rpc.Message
+	10,  // 220: rpc.Merlin.SCExec:output_type -> rpc.Message
+	10,  // 221: rpc.Merlin.SecureDelete:output_type -> rpc.Message
+	10,  // 222: rpc.Merlin.SecurityProducts:output_type -> rpc.Message
+	10,  // 223: rpc.Merlin.SharpGen:output_type -> rpc.Message
+	10,  // 224: rpc.Merlin.Skew:output_type -> rpc.Message
+	10,  // 225: rpc.Merlin.Sleep:output_type -> rpc.Message
+	10,  // 226: rpc.Merlin.Socks:output_type -> rpc.Message
+	10,  // 227: rpc.Merlin.SSH:output_type -> rpc.Message
+	10,  // 228: rpc.Merlin.SSHDeploy:output_type -> rpc.Message
+	10,  // 229: rpc.Merlin.Throttle:output_type -> rpc.Message
+	10,  // 230: rpc.Merlin.Timezone:output_type -> rpc.Message
+	10,  // 231: rpc.Merlin.Token:output_type -> rpc.Message
+	10,  // 232: rpc.Merlin.Touch:output_type -> rpc.Message
+	10,  // 233: rpc.Merlin.Transport:output_type -> rpc.Message
+	10,  // 234: rpc.Merlin.UnlinkAgent:output_type -> rpc.Message
+	10,  // 235: rpc.Merlin.Upload:output_type -> rpc.Message
+	10,  // 236: rpc.Merlin.Uptime:output_type -> rpc.Message
+	10,  // 237: rpc.Merlin.WMIExec:output_type -> rpc.Message
+	15,  // 238: rpc.Merlin.Groups:output_type -> rpc.Slice
+	10,  // 239: rpc.Merlin.GroupAdd:output_type -> rpc.Message
+	15,  // 240: rpc.Merlin.GroupList:output_type -> rpc.Slice
+	18,  // 241: rpc.Merlin.GroupListAll:output_type -> rpc.GroupMembers
+	10,  // 242: rpc.Merlin.GroupRemove:output_type -> rpc.Message
+	2,   // 243: rpc.Merlin.GetAgent:output_type -> rpc.AgentInfo
+	15,  // 244: rpc.Merlin.GetAgents:output_type -> rpc.Slice
+	15,  // 245: rpc.Merlin.GetAgentLinks:output_type -> rpc.Slice
+	10,  // 246: rpc.Merlin.GetAgentStatus:output_type -> rpc.Message
+	14,  // 247: rpc.Merlin.GetAgentRows:output_type -> rpc.TableData
+	10,  // 248: rpc.Merlin.Remove:output_type -> rpc.Message
+	14,  // 249: rpc.Merlin.GetAgentRoutes:output_type -> rpc.TableData
+	10,  // 250: rpc.Merlin.ExportAgent:output_type -> rpc.Message
+	10,  // 251: rpc.Merlin.ImportAgent:output_type -> rpc.Message
+	14,  // 252: rpc.Merlin.GetAgentChanges:output_type -> rpc.TableData
+	14,  // 253: rpc.Merlin.GetPrivilegedAgentRows:output_type -> rpc.TableData
+	14,  // 254: rpc.Merlin.GetCampaigns:output_type -> rpc.TableData
+	10,  // 255: rpc.Merlin.Release:output_type -> rpc.Message
+	10,  // 256: rpc.Merlin.Quarantine:output_type -> rpc.Message
+	14,  // 257: rpc.Merlin.GetAgentNames:output_type -> rpc.TableData
+	10,  // 258: rpc.Merlin.Rename:output_type -> rpc.Message
+	14,  // 259: rpc.Merlin.GetAgentDetails:output_type -> rpc.TableData
+	14,  // 260: rpc.Merlin.GetAgentActivity:output_type -> rpc.TableData
+	9,   // 261: rpc.Merlin.GetAllJobs:output_type -> rpc.Jobs
+	9,   // 262: rpc.Merlin.GetAllActiveJobs:output_type -> rpc.Jobs
+	9,   // 263: rpc.Merlin.GetAgentJobs:output_type -> rpc.Jobs
+	9,   // 264: rpc.Merlin.GetAgentActiveJobs:output_type -> rpc.Jobs
+	10,  // 265: rpc.Merlin.ExportAttackNavigator:output_type -> rpc.Message
+	9,   // 266: rpc.Merlin.QueryJobs:output_type -> rpc.Jobs
+	10,  // 267: rpc.Merlin.SetJobExpiry:output_type -> rpc.Message
+	10,  // 268: rpc.Merlin.CreateListener:output_type -> rpc.Message
+	15,  // 269: rpc.Merlin.GetListenerIDs:output_type -> rpc.Slice
+	14,  // 270: rpc.Merlin.GetListeners:output_type -> rpc.TableData
+	12,  // 271: rpc.Merlin.GetListenerOptions:output_type -> rpc.Options
+	12,  // 272: rpc.Merlin.GetListenerDefaultOptions:output_type -> rpc.Options
+	15,  // 273: rpc.Merlin.GetListenerTypes:output_type -> rpc.Slice
+	10,  // 274: rpc.Merlin.GetListenerStatus:output_type -> rpc.Message
+	10,  // 275: rpc.Merlin.RemoveListener:output_type -> rpc.Message
+	10,  // 276: rpc.Merlin.RestartListener:output_type -> rpc.Message
+	10,  // 277: rpc.Merlin.SetListenerOption:output_type -> rpc.Message
+	10,  // 278: rpc.Merlin.StartListener:output_type -> rpc.Message
+	10,  // 279: rpc.Merlin.StopListener:output_type -> rpc.Message
+	15,  // 280: rpc.Merlin.Servers:output_type -> rpc.Slice
+	10,  // 281: rpc.Merlin.DrainListener:output_type -> rpc.Message
+	10,  // 282: rpc.Merlin.GenerateSMBPipe:output_type -> rpc.Message
+	14,  // 283: rpc.Merlin.GetListenerOptionSchema:output_type -> rpc.TableData
+	14,  // 284: rpc.Merlin.CreateListeners:output_type -> rpc.TableData
+	14,  // 285: rpc.Merlin.GetPivotListeners:output_type -> rpc.TableData
+	14,  // 286: rpc.Merlin.SelfTest:output_type -> rpc.TableData
+	10,  // 287: rpc.Merlin.StartTrafficCapture:output_type -> rpc.Message
+	10,  // 288: rpc.Merlin.StopTrafficCapture:output_type -> rpc.Message
+	14,  // 289: rpc.Merlin.GetTrafficCaptures:output_type -> rpc.TableData
+	21,  // 290: rpc.Merlin.GetModule:output_type -> rpc.Module
+	15,  // 291: rpc.Merlin.GetModuleList:output_type -> rpc.Slice
+	11,  // 292: rpc.Merlin.RunModule:output_type -> rpc.Messages
+	10,  // 293: rpc.Merlin.ReloadModules:output_type -> rpc.Message
+	15,  // 294: rpc.Merlin.GetModuleCategories:output_type -> rpc.Slice
+	14,  // 295: rpc.Merlin.SearchModules:output_type -> rpc.TableData
+	14,  // 296: rpc.Merlin.GetLoot:output_type -> rpc.TableData
+	10,  // 297: rpc.Merlin.AddCredential:output_type -> rpc.Message
+	14,  // 298: rpc.Merlin.GetCredentials:output_type -> rpc.TableData
+	10,  // 299: rpc.Merlin.RemoveCredential:output_type -> rpc.Message
+	14,  // 300: rpc.Merlin.GetDirectoryObjects:output_type -> rpc.TableData
+	10,  // 301: rpc.Merlin.ExportScanResults:output_type -> rpc.Message
+	14,  // 302: rpc.Merlin.GetScanResults:output_type -> rpc.TableData
+	14,  // 303: rpc.Merlin.GetPersistence:output_type -> rpc.TableData
+	10,  // 304: rpc.Merlin.ExportIOCs:output_type -> rpc.Message
+	14,  // 305: rpc.Merlin.GetIOCs:output_type -> rpc.TableData
+	10,  // 306: rpc.Merlin.KeyAgentConfig:output_type -> rpc.Message
+	10,  // 307: rpc.Merlin.Shutdown:output_type -> rpc.Message
+	14,  // 308: rpc.Merlin.GetHosts:output_type -> rpc.TableData
+	14,  // 309: rpc.Merlin.GetHostActivity:output_type -> rpc.TableData
+	10,  // 310: rpc.Merlin.ImportHosts:output_type -> rpc.Message
+	10,  // 311: rpc.Merlin.AddHostNote:output_type -> rpc.Message
+	10,  // 312: rpc.Merlin.GetHost:output_type -> rpc.Message
+	10,  // 313: rpc.Merlin.ApproveRequest:output_type -> rpc.Message
+	10,  // 314: rpc.Merlin.DenyRequest:output_type -> rpc.Message
+	14,  // 315: rpc.Merlin.GetPendingRequests:output_type -> rpc.TableData
+	10,  // 316: rpc.Merlin.HostFile:output_type -> rpc.Message
+	10,  // 317: rpc.Merlin.UnhostFile:output_type -> rpc.Message
+	14,  // 318: rpc.Merlin.GetHostedFiles:output_type -> rpc.TableData
+	14,  // 319: rpc.Merlin.GetHostedFileAccess:output_type -> rpc.TableData
+	10,  // 320: rpc.Merlin.RegisterPayload:output_type -> rpc.Message
+	14,  // 321: rpc.Merlin.GetPayloads:output_type -> rpc.TableData
+	10,  // 322: rpc.Merlin.ExportPayloads:output_type -> rpc.Message
+	10,  // 323: rpc.Merlin.RunPlugin:output_type -> rpc.Message
+	14,  // 324: rpc.Merlin.GetPlugins:output_type -> rpc.TableData
+	10,  // 325: rpc.Merlin.ReloadPlugins:output_type -> rpc.Message
+	14,  // 326: rpc.Merlin.GetCommands:output_type -> rpc.TableData
+	10,  // 327: rpc.Merlin.ExportCommands:output_type -> rpc.Message
+	14,  // 328: rpc.Merlin.GetLocales:output_type -> rpc.TableData
+	10,  // 329: rpc.Merlin.ReloadLocales:output_type -> rpc.Message
+	171, // [171:330] is the sub-list for method output_type
+	12,  // [12:171] is the sub-list for method input_type
+	12,  // [12:12] is the sub-list for extension type_name
+	12,  // [12:12] is the sub-list for extension extendee
+	0,   // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_pkg_rpc_rpc_proto_init() }
@@ -24,18 +24,24 @@ type MerlinClient interface {
 	Listen(ctx context.Context, in *ID, opts ...grpc.CallOption) (Merlin_ListenClient, error)
 	// Agent Commands
 	Any(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	BloodHound(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Broadcast(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Campaign(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	CD(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	CheckIn(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	ClearJobs(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	ClearJobsCreated(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Message, error)
 	CMD(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Connect(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Deploy(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Destroy(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Download(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	ENV(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	ExecuteAssembly(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	ExecutePE(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	ExecuteShellcode(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Exit(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	Fallback(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	IFConfig(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	InvokeAssembly(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	JA3(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
@@ -55,22 +61,32 @@ type MerlinClient interface {
 	Nslookup(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Padding(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Parrot(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Persist(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Pipes(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	Preflight(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	Profile(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	PS(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	PWD(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	RM(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	RunAs(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	SCExec(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	SecureDelete(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	SecurityProducts(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	SharpGen(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Skew(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Sleep(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Socks(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	SSH(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	SSHDeploy(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Throttle(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Timezone(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	Token(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Touch(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	Transport(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	UnlinkAgent(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Upload(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	Uptime(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	WMIExec(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
 	// Agent Service
 	Groups(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Slice, error)
 	GroupAdd(ctx context.Context, in *Group, opts ...grpc.CallOption) (*Message, error)
@@ -83,11 +99,26 @@ type MerlinClient interface {
 	GetAgentStatus(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	GetAgentRows(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
 	Remove(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	GetAgentRoutes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	ExportAgent(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	ImportAgent(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	GetAgentChanges(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error)
+	GetPrivilegedAgentRows(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	GetCampaigns(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	Release(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	Quarantine(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	GetAgentNames(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	Rename(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	GetAgentDetails(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*TableData, error)
+	GetAgentActivity(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
 	// Job Service
 	GetAllJobs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Jobs, error)
 	GetAllActiveJobs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Jobs, error)
 	GetAgentJobs(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Jobs, error)
 	GetAgentActiveJobs(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Jobs, error)
+	ExportAttackNavigator(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	QueryJobs(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Jobs, error)
+	SetJobExpiry(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
 	// Listener
 	CreateListener(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
 	GetListenerIDs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Slice, error)
@@ -102,9 +133,69 @@ type MerlinClient interface {
 	StartListener(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	StopListener(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
 	Servers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Slice, error)
+	DrainListener(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	GenerateSMBPipe(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	GetListenerOptionSchema(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error)
+	CreateListeners(ctx context.Context, in *Options, opts ...grpc.CallOption) (*TableData, error)
+	GetPivotListeners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	SelfTest(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error)
+	StartTrafficCapture(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	StopTrafficCapture(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	GetTrafficCaptures(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
 	GetModule(ctx context.Context, in *String, opts ...grpc.CallOption) (*Module, error)
 	GetModuleList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Slice, error)
 	RunModule(ctx context.Context, in *ModuleRun, opts ...grpc.CallOption) (*Messages, error)
+	ReloadModules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Message, error)
+	GetModuleCategories(ctx context.Context, in *String, opts ...grpc.CallOption) (*Slice, error)
+	SearchModules(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error)
+	// Loot
+	GetLoot(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error)
+	// Credentials
+	AddCredential(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	GetCredentials(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	RemoveCredential(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	// Active Directory
+	GetDirectoryObjects(ctx context.Context, in *Options, opts ...grpc.CallOption) (*TableData, error)
+	// Network Scan
+	ExportScanResults(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	GetScanResults(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error)
+	// Persistence
+	GetPersistence(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error)
+	// Indicators of Compromise
+	ExportIOCs(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	GetIOCs(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error)
+	// Environment Keying
+	KeyAgentConfig(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	// Server
+	Shutdown(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	// Hosts
+	GetHosts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	GetHostActivity(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error)
+	ImportHosts(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	AddHostNote(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	GetHost(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	// Approvals
+	ApproveRequest(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	DenyRequest(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error)
+	GetPendingRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	// Hosted Files
+	HostFile(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	UnhostFile(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	GetHostedFiles(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error)
+	GetHostedFileAccess(ctx context.Context, in *Options, opts ...grpc.CallOption) (*TableData, error)
+	// Payloads
+	RegisterPayload(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error)
+	GetPayloads(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error)
+	ExportPayloads(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	// Plugins
+	RunPlugin(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error)
+	GetPlugins(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	ReloadPlugins(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Message, error)
+	// Commands
+	GetCommands(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error)
+	ExportCommands(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error)
+	GetLocales(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error)
+	ReloadLocales(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Message, error)
 }
 
 type merlinClient struct {
@@ -174,6 +265,33 @@ func (c *merlinClient) Any(ctx context.Context, in *AgentCMD, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *merlinClient) BloodHound(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/BloodHound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Broadcast(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Broadcast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Campaign(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Campaign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) CD(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/CD", in, out, opts...)
@@ -228,6 +346,24 @@ func (c *merlinClient) Connect(ctx context.Context, in *AgentCMD, opts ...grpc.C
 	return out, nil
 }
 
+func (c *merlinClient) Deploy(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Deploy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Destroy(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Destroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) Download(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/Download", in, out, opts...)
@@ -282,6 +418,15 @@ func (c *merlinClient) Exit(ctx context.Context, in *ID, opts ...grpc.CallOption
 	return out, nil
 }
 
+func (c *merlinClient) Fallback(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Fallback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) IFConfig(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/IFConfig", in, out, opts...)
@@ -453,6 +598,15 @@ func (c *merlinClient) Parrot(ctx context.Context, in *AgentCMD, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *merlinClient) Persist(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Persist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) Pipes(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/Pipes", in, out, opts...)
@@ -462,6 +616,24 @@ func (c *merlinClient) Pipes(ctx context.Context, in *ID, opts ...grpc.CallOptio
 	return out, nil
 }
 
+func (c *merlinClient) Preflight(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Preflight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Profile(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Profile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) PS(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/PS", in, out, opts...)
@@ -498,6 +670,15 @@ func (c *merlinClient) RunAs(ctx context.Context, in *AgentCMD, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *merlinClient) SCExec(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/SCExec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) SecureDelete(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/SecureDelete", in, out, opts...)
@@ -507,6 +688,15 @@ func (c *merlinClient) SecureDelete(ctx context.Context, in *AgentCMD, opts ...g
 	return out, nil
 }
 
+func (c *merlinClient) SecurityProducts(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/SecurityProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) SharpGen(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/SharpGen", in, out, opts...)
@@ -552,6 +742,33 @@ func (c *merlinClient) SSH(ctx context.Context, in *AgentCMD, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *merlinClient) SSHDeploy(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/SSHDeploy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Throttle(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Throttle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Timezone(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Timezone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) Token(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/Token", in, out, opts...)
@@ -570,6 +787,15 @@ func (c *merlinClient) Touch(ctx context.Context, in *AgentCMD, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *merlinClient) Transport(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Transport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) UnlinkAgent(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/UnlinkAgent", in, out, opts...)
@@ -597,6 +823,15 @@ func (c *merlinClient) Uptime(ctx context.Context, in *ID, opts ...grpc.CallOpti
 	return out, nil
 }
 
+func (c *merlinClient) WMIExec(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/WMIExec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) Groups(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Slice, error) {
 	out := new(Slice)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/Groups", in, out, opts...)
@@ -696,6 +931,114 @@ func (c *merlinClient) Remove(ctx context.Context, in *ID, opts ...grpc.CallOpti
 	return out, nil
 }
 
+func (c *merlinClient) GetAgentRoutes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetAgentRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) ExportAgent(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ExportAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) ImportAgent(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ImportAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) GetAgentChanges(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetAgentChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) GetPrivilegedAgentRows(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetPrivilegedAgentRows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) GetCampaigns(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetCampaigns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Release(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Release", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Quarantine(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Quarantine", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) GetAgentNames(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetAgentNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) Rename(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Rename", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) GetAgentDetails(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetAgentDetails", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) GetAgentActivity(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetAgentActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) GetAllJobs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Jobs, error) {
 	out := new(Jobs)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetAllJobs", in, out, opts...)
@@ -732,6 +1075,33 @@ func (c *merlinClient) GetAgentActiveJobs(ctx context.Context, in *ID, opts ...g
 	return out, nil
 }
 
+func (c *merlinClient) ExportAttackNavigator(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ExportAttackNavigator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) QueryJobs(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Jobs, error) {
+	out := new(Jobs)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/QueryJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merlinClient) SetJobExpiry(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/SetJobExpiry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merlinClient) CreateListener(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
 	out := new(Message)
 	err := c.cc.Invoke(ctx, "/rpc.Merlin/CreateListener", in, out, opts...)
@@ -849,1864 +1219,3986 @@ func (c *merlinClient) Servers(ctx context.Context, in *emptypb.Empty, opts ...g
 	return out, nil
 }
 
-func (c *merlinClient) GetModule(ctx context.Context, in *String, opts ...grpc.CallOption) (*Module, error) {
-	out := new(Module)
-	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetModule", in, out, opts...)
+func (c *merlinClient) DrainListener(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/DrainListener", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *merlinClient) GetModuleList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Slice, error) {
-	out := new(Slice)
-	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetModuleList", in, out, opts...)
+func (c *merlinClient) GenerateSMBPipe(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GenerateSMBPipe", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *merlinClient) RunModule(ctx context.Context, in *ModuleRun, opts ...grpc.CallOption) (*Messages, error) {
-	out := new(Messages)
-	err := c.cc.Invoke(ctx, "/rpc.Merlin/RunModule", in, out, opts...)
+func (c *merlinClient) GetListenerOptionSchema(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetListenerOptionSchema", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// MerlinServer is the server API for Merlin service.
-// All implementations must embed UnimplementedMerlinServer
-// for forward compatibility
-type MerlinServer interface {
-	Reconnect(context.Context, *ID) (*ID, error)
-	Register(context.Context, *emptypb.Empty) (*ID, error)
-	Listen(*ID, Merlin_ListenServer) error
-	// Agent Commands
-	Any(context.Context, *AgentCMD) (*Message, error)
-	CD(context.Context, *AgentCMD) (*Message, error)
-	CheckIn(context.Context, *ID) (*Message, error)
-	ClearJobs(context.Context, *ID) (*Message, error)
-	ClearJobsCreated(context.Context, *emptypb.Empty) (*Message, error)
-	CMD(context.Context, *AgentCMD) (*Message, error)
-	Connect(context.Context, *AgentCMD) (*Message, error)
-	Download(context.Context, *AgentCMD) (*Message, error)
-	ENV(context.Context, *AgentCMD) (*Message, error)
-	ExecuteAssembly(context.Context, *AgentCMD) (*Message, error)
-	ExecutePE(context.Context, *AgentCMD) (*Message, error)
-	ExecuteShellcode(context.Context, *AgentCMD) (*Message, error)
-	Exit(context.Context, *ID) (*Message, error)
-	IFConfig(context.Context, *ID) (*Message, error)
-	InvokeAssembly(context.Context, *AgentCMD) (*Message, error)
-	JA3(context.Context, *AgentCMD) (*Message, error)
-	KillDate(context.Context, *AgentCMD) (*Message, error)
-	KillProcess(context.Context, *AgentCMD) (*Message, error)
-	LinkAgent(context.Context, *AgentCMD) (*Message, error)
-	ListAssemblies(context.Context, *ID) (*Message, error)
-	Listener(context.Context, *AgentCMD) (*Message, error)
-	LoadAssembly(context.Context, *AgentCMD) (*Message, error)
-	LoadCLR(context.Context, *AgentCMD) (*Message, error)
-	LS(context.Context, *AgentCMD) (*Message, error)
-	MaxRetry(context.Context, *AgentCMD) (*Message, error)
-	Memory(context.Context, *AgentCMD) (*Message, error)
-	MEMFD(context.Context, *AgentCMD) (*Message, error)
-	Netstat(context.Context, *AgentCMD) (*Message, error)
-	Note(context.Context, *AgentCMD) (*Message, error)
-	Nslookup(context.Context, *AgentCMD) (*Message, error)
-	Padding(context.Context, *AgentCMD) (*Message, error)
-	Parrot(context.Context, *AgentCMD) (*Message, error)
-	Pipes(context.Context, *ID) (*Message, error)
-	PS(context.Context, *ID) (*Message, error)
-	PWD(context.Context, *ID) (*Message, error)
-	RM(context.Context, *AgentCMD) (*Message, error)
-	RunAs(context.Context, *AgentCMD) (*Message, error)
-	SecureDelete(context.Context, *AgentCMD) (*Message, error)
-	SharpGen(context.Context, *AgentCMD) (*Message, error)
-	Skew(context.Context, *AgentCMD) (*Message, error)
-	Sleep(context.Context, *AgentCMD) (*Message, error)
-	Socks(context.Context, *AgentCMD) (*Message, error)
-	SSH(context.Context, *AgentCMD) (*Message, error)
-	Token(context.Context, *AgentCMD) (*Message, error)
-	Touch(context.Context, *AgentCMD) (*Message, error)
-	UnlinkAgent(context.Context, *AgentCMD) (*Message, error)
-	Upload(context.Context, *AgentCMD) (*Message, error)
-	Uptime(context.Context, *ID) (*Message, error)
-	// Agent Service
-	Groups(context.Context, *emptypb.Empty) (*Slice, error)
-	GroupAdd(context.Context, *Group) (*Message, error)
-	GroupList(context.Context, *ID) (*Slice, error)
-	GroupListAll(context.Context, *emptypb.Empty) (*GroupMembers, error)
-	GroupRemove(context.Context, *Group) (*Message, error)
-	GetAgent(context.Context, *ID) (*AgentInfo, error)
-	GetAgents(context.Context, *emptypb.Empty) (*Slice, error)
-	GetAgentLinks(context.Context, *ID) (*Slice, error)
-	GetAgentStatus(context.Context, *ID) (*Message, error)
-	GetAgentRows(context.Context, *emptypb.Empty) (*TableData, error)
-	Remove(context.Context, *ID) (*Message, error)
-	// Job Service
-	GetAllJobs(context.Context, *emptypb.Empty) (*Jobs, error)
-	GetAllActiveJobs(context.Context, *emptypb.Empty) (*Jobs, error)
-	GetAgentJobs(context.Context, *ID) (*Jobs, error)
-	GetAgentActiveJobs(context.Context, *ID) (*Jobs, error)
-	// Listener
-	CreateListener(context.Context, *Options) (*Message, error)
-	GetListenerIDs(context.Context, *emptypb.Empty) (*Slice, error)
-	GetListeners(context.Context, *emptypb.Empty) (*TableData, error)
-	GetListenerOptions(context.Context, *ID) (*Options, error)
-	GetListenerDefaultOptions(context.Context, *String) (*Options, error)
-	GetListenerTypes(context.Context, *emptypb.Empty) (*Slice, error)
-	GetListenerStatus(context.Context, *ID) (*Message, error)
-	RemoveListener(context.Context, *ID) (*Message, error)
-	RestartListener(context.Context, *ID) (*Message, error)
-	SetListenerOption(context.Context, *AgentCMD) (*Message, error)
-	StartListener(context.Context, *ID) (*Message, error)
-	StopListener(context.Context, *ID) (*Message, error)
-	Servers(context.Context, *emptypb.Empty) (*Slice, error)
-	GetModule(context.Context, *String) (*Module, error)
-	GetModuleList(context.Context, *emptypb.Empty) (*Slice, error)
-	RunModule(context.Context, *ModuleRun) (*Messages, error)
-	mustEmbedUnimplementedMerlinServer()
+func (c *merlinClient) CreateListeners(ctx context.Context, in *Options, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/CreateListeners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedMerlinServer must be embedded to have forward compatible implementations.
-type UnimplementedMerlinServer struct {
+func (c *merlinClient) GetPivotListeners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetPivotListeners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (UnimplementedMerlinServer) Reconnect(context.Context, *ID) (*ID, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Reconnect not implemented")
-}
-func (UnimplementedMerlinServer) Register(context.Context, *emptypb.Empty) (*ID, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
-}
-func (UnimplementedMerlinServer) Listen(*ID, Merlin_ListenServer) error {
-	return status.Errorf(codes.Unimplemented, "method Listen not implemented")
+func (c *merlinClient) SelfTest(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/SelfTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Any(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Any not implemented")
+
+func (c *merlinClient) StartTrafficCapture(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/StartTrafficCapture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) CD(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CD not implemented")
+
+func (c *merlinClient) StopTrafficCapture(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/StopTrafficCapture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) CheckIn(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+
+func (c *merlinClient) GetTrafficCaptures(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetTrafficCaptures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) ClearJobs(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ClearJobs not implemented")
+
+func (c *merlinClient) GetModule(ctx context.Context, in *String, opts ...grpc.CallOption) (*Module, error) {
+	out := new(Module)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetModule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) ClearJobsCreated(context.Context, *emptypb.Empty) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ClearJobsCreated not implemented")
+
+func (c *merlinClient) GetModuleList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Slice, error) {
+	out := new(Slice)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetModuleList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) CMD(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CMD not implemented")
+
+func (c *merlinClient) RunModule(ctx context.Context, in *ModuleRun, opts ...grpc.CallOption) (*Messages, error) {
+	out := new(Messages)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/RunModule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Connect(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Connect not implemented")
+
+func (c *merlinClient) ReloadModules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ReloadModules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Download(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Download not implemented")
+
+func (c *merlinClient) GetModuleCategories(ctx context.Context, in *String, opts ...grpc.CallOption) (*Slice, error) {
+	out := new(Slice)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetModuleCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) ENV(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ENV not implemented")
+
+func (c *merlinClient) SearchModules(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/SearchModules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) ExecuteAssembly(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecuteAssembly not implemented")
+
+func (c *merlinClient) GetLoot(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetLoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) ExecutePE(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecutePE not implemented")
+
+func (c *merlinClient) AddCredential(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/AddCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) ExecuteShellcode(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecuteShellcode not implemented")
+
+func (c *merlinClient) GetCredentials(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Exit(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Exit not implemented")
+
+func (c *merlinClient) RemoveCredential(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/RemoveCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) IFConfig(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IFConfig not implemented")
+
+func (c *merlinClient) GetDirectoryObjects(ctx context.Context, in *Options, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetDirectoryObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) InvokeAssembly(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method InvokeAssembly not implemented")
+
+func (c *merlinClient) ExportScanResults(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ExportScanResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) JA3(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method JA3 not implemented")
+
+func (c *merlinClient) GetScanResults(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetScanResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) KillDate(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method KillDate not implemented")
+
+func (c *merlinClient) GetPersistence(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetPersistence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) KillProcess(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method KillProcess not implemented")
+
+func (c *merlinClient) ExportIOCs(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ExportIOCs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) LinkAgent(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LinkAgent not implemented")
+
+func (c *merlinClient) GetIOCs(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetIOCs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) ListAssemblies(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListAssemblies not implemented")
+
+func (c *merlinClient) KeyAgentConfig(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/KeyAgentConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Listener(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Listener not implemented")
-}
-func (UnimplementedMerlinServer) LoadAssembly(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoadAssembly not implemented")
-}
-func (UnimplementedMerlinServer) LoadCLR(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoadCLR not implemented")
-}
-func (UnimplementedMerlinServer) LS(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LS not implemented")
-}
-func (UnimplementedMerlinServer) MaxRetry(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MaxRetry not implemented")
-}
-func (UnimplementedMerlinServer) Memory(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Memory not implemented")
-}
-func (UnimplementedMerlinServer) MEMFD(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MEMFD not implemented")
-}
-func (UnimplementedMerlinServer) Netstat(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Netstat not implemented")
-}
-func (UnimplementedMerlinServer) Note(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Note not implemented")
-}
-func (UnimplementedMerlinServer) Nslookup(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Nslookup not implemented")
-}
-func (UnimplementedMerlinServer) Padding(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Padding not implemented")
-}
-func (UnimplementedMerlinServer) Parrot(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Parrot not implemented")
-}
-func (UnimplementedMerlinServer) Pipes(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Pipes not implemented")
-}
-func (UnimplementedMerlinServer) PS(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PS not implemented")
-}
-func (UnimplementedMerlinServer) PWD(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PWD not implemented")
-}
-func (UnimplementedMerlinServer) RM(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RM not implemented")
-}
-func (UnimplementedMerlinServer) RunAs(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RunAs not implemented")
-}
-func (UnimplementedMerlinServer) SecureDelete(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SecureDelete not implemented")
-}
-func (UnimplementedMerlinServer) SharpGen(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SharpGen not implemented")
-}
-func (UnimplementedMerlinServer) Skew(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Skew not implemented")
-}
-func (UnimplementedMerlinServer) Sleep(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Sleep not implemented")
-}
-func (UnimplementedMerlinServer) Socks(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Socks not implemented")
-}
-func (UnimplementedMerlinServer) SSH(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SSH not implemented")
-}
-func (UnimplementedMerlinServer) Token(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Token not implemented")
-}
-func (UnimplementedMerlinServer) Touch(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Touch not implemented")
+
+func (c *merlinClient) Shutdown(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) UnlinkAgent(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UnlinkAgent not implemented")
+
+func (c *merlinClient) GetHosts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetHosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Upload(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Upload not implemented")
+
+func (c *merlinClient) GetHostActivity(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetHostActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Uptime(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Uptime not implemented")
+
+func (c *merlinClient) ImportHosts(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ImportHosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Groups(context.Context, *emptypb.Empty) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Groups not implemented")
+
+func (c *merlinClient) AddHostNote(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/AddHostNote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GroupAdd(context.Context, *Group) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GroupAdd not implemented")
+
+func (c *merlinClient) GetHost(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetHost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GroupList(context.Context, *ID) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GroupList not implemented")
+
+func (c *merlinClient) ApproveRequest(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ApproveRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GroupListAll(context.Context, *emptypb.Empty) (*GroupMembers, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GroupListAll not implemented")
+
+func (c *merlinClient) DenyRequest(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/DenyRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GroupRemove(context.Context, *Group) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GroupRemove not implemented")
+
+func (c *merlinClient) GetPendingRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetPendingRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAgent(context.Context, *ID) (*AgentInfo, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgent not implemented")
+
+func (c *merlinClient) HostFile(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/HostFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAgents(context.Context, *emptypb.Empty) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgents not implemented")
+
+func (c *merlinClient) UnhostFile(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/UnhostFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAgentLinks(context.Context, *ID) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgentLinks not implemented")
+
+func (c *merlinClient) GetHostedFiles(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetHostedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAgentStatus(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgentStatus not implemented")
+
+func (c *merlinClient) GetHostedFileAccess(ctx context.Context, in *Options, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetHostedFileAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAgentRows(context.Context, *emptypb.Empty) (*TableData, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgentRows not implemented")
+
+func (c *merlinClient) RegisterPayload(ctx context.Context, in *Options, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/RegisterPayload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) Remove(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+
+func (c *merlinClient) GetPayloads(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetPayloads", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAllJobs(context.Context, *emptypb.Empty) (*Jobs, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllJobs not implemented")
+
+func (c *merlinClient) ExportPayloads(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ExportPayloads", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAllActiveJobs(context.Context, *emptypb.Empty) (*Jobs, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllActiveJobs not implemented")
+
+func (c *merlinClient) RunPlugin(ctx context.Context, in *AgentCMD, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/RunPlugin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAgentJobs(context.Context, *ID) (*Jobs, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgentJobs not implemented")
+
+func (c *merlinClient) GetPlugins(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetPlugins", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetAgentActiveJobs(context.Context, *ID) (*Jobs, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgentActiveJobs not implemented")
+
+func (c *merlinClient) ReloadPlugins(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ReloadPlugins", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) CreateListener(context.Context, *Options) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateListener not implemented")
+
+func (c *merlinClient) GetCommands(ctx context.Context, in *String, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetCommands", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetListenerIDs(context.Context, *emptypb.Empty) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetListenerIDs not implemented")
+
+func (c *merlinClient) ExportCommands(ctx context.Context, in *String, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ExportCommands", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetListeners(context.Context, *emptypb.Empty) (*TableData, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetListeners not implemented")
+
+func (c *merlinClient) GetLocales(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TableData, error) {
+	out := new(TableData)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/GetLocales", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetListenerOptions(context.Context, *ID) (*Options, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetListenerOptions not implemented")
+
+func (c *merlinClient) ReloadLocales(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/rpc.Merlin/ReloadLocales", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMerlinServer) GetListenerDefaultOptions(context.Context, *String) (*Options, error) {
+
+// MerlinServer is the server API for Merlin service.
+// All implementations must embed UnimplementedMerlinServer
+// for forward compatibility
+type MerlinServer interface {
+	Reconnect(context.Context, *ID) (*ID, error)
+	Register(context.Context, *emptypb.Empty) (*ID, error)
+	Listen(*ID, Merlin_ListenServer) error
+	// Agent Commands
+	Any(context.Context, *AgentCMD) (*Message, error)
+	BloodHound(context.Context, *AgentCMD) (*Message, error)
+	Broadcast(context.Context, *AgentCMD) (*Message, error)
+	Campaign(context.Context, *AgentCMD) (*Message, error)
+	CD(context.Context, *AgentCMD) (*Message, error)
+	CheckIn(context.Context, *ID) (*Message, error)
+	ClearJobs(context.Context, *ID) (*Message, error)
+	ClearJobsCreated(context.Context, *emptypb.Empty) (*Message, error)
+	CMD(context.Context, *AgentCMD) (*Message, error)
+	Connect(context.Context, *AgentCMD) (*Message, error)
+	Deploy(context.Context, *AgentCMD) (*Message, error)
+	Destroy(context.Context, *AgentCMD) (*Message, error)
+	Download(context.Context, *AgentCMD) (*Message, error)
+	ENV(context.Context, *AgentCMD) (*Message, error)
+	ExecuteAssembly(context.Context, *AgentCMD) (*Message, error)
+	ExecutePE(context.Context, *AgentCMD) (*Message, error)
+	ExecuteShellcode(context.Context, *AgentCMD) (*Message, error)
+	Exit(context.Context, *ID) (*Message, error)
+	Fallback(context.Context, *AgentCMD) (*Message, error)
+	IFConfig(context.Context, *ID) (*Message, error)
+	InvokeAssembly(context.Context, *AgentCMD) (*Message, error)
+	JA3(context.Context, *AgentCMD) (*Message, error)
+	KillDate(context.Context, *AgentCMD) (*Message, error)
+	KillProcess(context.Context, *AgentCMD) (*Message, error)
+	LinkAgent(context.Context, *AgentCMD) (*Message, error)
+	ListAssemblies(context.Context, *ID) (*Message, error)
+	Listener(context.Context, *AgentCMD) (*Message, error)
+	LoadAssembly(context.Context, *AgentCMD) (*Message, error)
+	LoadCLR(context.Context, *AgentCMD) (*Message, error)
+	LS(context.Context, *AgentCMD) (*Message, error)
+	MaxRetry(context.Context, *AgentCMD) (*Message, error)
+	Memory(context.Context, *AgentCMD) (*Message, error)
+	MEMFD(context.Context, *AgentCMD) (*Message, error)
+	Netstat(context.Context, *AgentCMD) (*Message, error)
+	Note(context.Context, *AgentCMD) (*Message, error)
+	Nslookup(context.Context, *AgentCMD) (*Message, error)
+	Padding(context.Context, *AgentCMD) (*Message, error)
+	Parrot(context.Context, *AgentCMD) (*Message, error)
+	Persist(context.Context, *AgentCMD) (*Message, error)
+	Pipes(context.Context, *ID) (*Message, error)
+	Preflight(context.Context, *ID) (*Message, error)
+	Profile(context.Context, *AgentCMD) (*Message, error)
+	PS(context.Context, *ID) (*Message, error)
+	PWD(context.Context, *ID) (*Message, error)
+	RM(context.Context, *AgentCMD) (*Message, error)
+	RunAs(context.Context, *AgentCMD) (*Message, error)
+	SCExec(context.Context, *AgentCMD) (*Message, error)
+	SecureDelete(context.Context, *AgentCMD) (*Message, error)
+	SecurityProducts(context.Context, *ID) (*Message, error)
+	SharpGen(context.Context, *AgentCMD) (*Message, error)
+	Skew(context.Context, *AgentCMD) (*Message, error)
+	Sleep(context.Context, *AgentCMD) (*Message, error)
+	Socks(context.Context, *AgentCMD) (*Message, error)
+	SSH(context.Context, *AgentCMD) (*Message, error)
+	SSHDeploy(context.Context, *AgentCMD) (*Message, error)
+	Throttle(context.Context, *AgentCMD) (*Message, error)
+	Timezone(context.Context, *ID) (*Message, error)
+	Token(context.Context, *AgentCMD) (*Message, error)
+	Touch(context.Context, *AgentCMD) (*Message, error)
+	Transport(context.Context, *AgentCMD) (*Message, error)
+	UnlinkAgent(context.Context, *AgentCMD) (*Message, error)
+	Upload(context.Context, *AgentCMD) (*Message, error)
+	Uptime(context.Context, *ID) (*Message, error)
+	WMIExec(context.Context, *AgentCMD) (*Message, error)
+	// Agent Service
+	Groups(context.Context, *emptypb.Empty) (*Slice, error)
+	GroupAdd(context.Context, *Group) (*Message, error)
+	GroupList(context.Context, *ID) (*Slice, error)
+	GroupListAll(context.Context, *emptypb.Empty) (*GroupMembers, error)
+	GroupRemove(context.Context, *Group) (*Message, error)
+	GetAgent(context.Context, *ID) (*AgentInfo, error)
+	GetAgents(context.Context, *emptypb.Empty) (*Slice, error)
+	GetAgentLinks(context.Context, *ID) (*Slice, error)
+	GetAgentStatus(context.Context, *ID) (*Message, error)
+	GetAgentRows(context.Context, *emptypb.Empty) (*TableData, error)
+	Remove(context.Context, *ID) (*Message, error)
+	GetAgentRoutes(context.Context, *emptypb.Empty) (*TableData, error)
+	ExportAgent(context.Context, *AgentCMD) (*Message, error)
+	ImportAgent(context.Context, *Options) (*Message, error)
+	GetAgentChanges(context.Context, *ID) (*TableData, error)
+	GetPrivilegedAgentRows(context.Context, *emptypb.Empty) (*TableData, error)
+	GetCampaigns(context.Context, *emptypb.Empty) (*TableData, error)
+	Release(context.Context, *ID) (*Message, error)
+	Quarantine(context.Context, *AgentCMD) (*Message, error)
+	GetAgentNames(context.Context, *emptypb.Empty) (*TableData, error)
+	Rename(context.Context, *AgentCMD) (*Message, error)
+	GetAgentDetails(context.Context, *AgentCMD) (*TableData, error)
+	GetAgentActivity(context.Context, *emptypb.Empty) (*TableData, error)
+	// Job Service
+	GetAllJobs(context.Context, *emptypb.Empty) (*Jobs, error)
+	GetAllActiveJobs(context.Context, *emptypb.Empty) (*Jobs, error)
+	GetAgentJobs(context.Context, *ID) (*Jobs, error)
+	GetAgentActiveJobs(context.Context, *ID) (*Jobs, error)
+	ExportAttackNavigator(context.Context, *String) (*Message, error)
+	QueryJobs(context.Context, *Options) (*Jobs, error)
+	SetJobExpiry(context.Context, *Options) (*Message, error)
+	// Listener
+	CreateListener(context.Context, *Options) (*Message, error)
+	GetListenerIDs(context.Context, *emptypb.Empty) (*Slice, error)
+	GetListeners(context.Context, *emptypb.Empty) (*TableData, error)
+	GetListenerOptions(context.Context, *ID) (*Options, error)
+	GetListenerDefaultOptions(context.Context, *String) (*Options, error)
+	GetListenerTypes(context.Context, *emptypb.Empty) (*Slice, error)
+	GetListenerStatus(context.Context, *ID) (*Message, error)
+	RemoveListener(context.Context, *ID) (*Message, error)
+	RestartListener(context.Context, *ID) (*Message, error)
+	SetListenerOption(context.Context, *AgentCMD) (*Message, error)
+	StartListener(context.Context, *ID) (*Message, error)
+	StopListener(context.Context, *ID) (*Message, error)
+	Servers(context.Context, *emptypb.Empty) (*Slice, error)
+	DrainListener(context.Context, *ID) (*Message, error)
+	GenerateSMBPipe(context.Context, *String) (*Message, error)
+	GetListenerOptionSchema(context.Context, *String) (*TableData, error)
+	CreateListeners(context.Context, *Options) (*TableData, error)
+	GetPivotListeners(context.Context, *emptypb.Empty) (*TableData, error)
+	SelfTest(context.Context, *ID) (*TableData, error)
+	StartTrafficCapture(context.Context, *Options) (*Message, error)
+	StopTrafficCapture(context.Context, *ID) (*Message, error)
+	GetTrafficCaptures(context.Context, *emptypb.Empty) (*TableData, error)
+	GetModule(context.Context, *String) (*Module, error)
+	GetModuleList(context.Context, *emptypb.Empty) (*Slice, error)
+	RunModule(context.Context, *ModuleRun) (*Messages, error)
+	ReloadModules(context.Context, *emptypb.Empty) (*Message, error)
+	GetModuleCategories(context.Context, *String) (*Slice, error)
+	SearchModules(context.Context, *String) (*TableData, error)
+	// Loot
+	GetLoot(context.Context, *ID) (*TableData, error)
+	// Credentials
+	AddCredential(context.Context, *Options) (*Message, error)
+	GetCredentials(context.Context, *emptypb.Empty) (*TableData, error)
+	RemoveCredential(context.Context, *ID) (*Message, error)
+	// Active Directory
+	GetDirectoryObjects(context.Context, *Options) (*TableData, error)
+	// Network Scan
+	ExportScanResults(context.Context, *String) (*Message, error)
+	GetScanResults(context.Context, *String) (*TableData, error)
+	// Persistence
+	GetPersistence(context.Context, *ID) (*TableData, error)
+	// Indicators of Compromise
+	ExportIOCs(context.Context, *String) (*Message, error)
+	GetIOCs(context.Context, *ID) (*TableData, error)
+	// Environment Keying
+	KeyAgentConfig(context.Context, *Options) (*Message, error)
+	// Server
+	Shutdown(context.Context, *String) (*Message, error)
+	// Hosts
+	GetHosts(context.Context, *emptypb.Empty) (*TableData, error)
+	GetHostActivity(context.Context, *String) (*TableData, error)
+	ImportHosts(context.Context, *Options) (*Message, error)
+	AddHostNote(context.Context, *Options) (*Message, error)
+	GetHost(context.Context, *String) (*Message, error)
+	// Approvals
+	ApproveRequest(context.Context, *ID) (*Message, error)
+	DenyRequest(context.Context, *ID) (*Message, error)
+	GetPendingRequests(context.Context, *emptypb.Empty) (*TableData, error)
+	// Hosted Files
+	HostFile(context.Context, *Options) (*Message, error)
+	UnhostFile(context.Context, *Options) (*Message, error)
+	GetHostedFiles(context.Context, *ID) (*TableData, error)
+	GetHostedFileAccess(context.Context, *Options) (*TableData, error)
+	// Payloads
+	RegisterPayload(context.Context, *Options) (*Message, error)
+	GetPayloads(context.Context, *String) (*TableData, error)
+	ExportPayloads(context.Context, *String) (*Message, error)
+	// Plugins
+	RunPlugin(context.Context, *AgentCMD) (*Message, error)
+	GetPlugins(context.Context, *emptypb.Empty) (*TableData, error)
+	ReloadPlugins(context.Context, *emptypb.Empty) (*Message, error)
+	// Commands
+	GetCommands(context.Context, *String) (*TableData, error)
+	ExportCommands(context.Context, *String) (*Message, error)
+	GetLocales(context.Context, *emptypb.Empty) (*TableData, error)
+	ReloadLocales(context.Context, *emptypb.Empty) (*Message, error)
+	mustEmbedUnimplementedMerlinServer()
+}
+
+// UnimplementedMerlinServer must be embedded to have forward compatible implementations.
+type UnimplementedMerlinServer struct {
+}
+
+func (UnimplementedMerlinServer) Reconnect(context.Context, *ID) (*ID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconnect not implemented")
+}
+func (UnimplementedMerlinServer) Register(context.Context, *emptypb.Empty) (*ID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedMerlinServer) Listen(*ID, Merlin_ListenServer) error {
+	return status.Errorf(codes.Unimplemented, "method Listen not implemented")
+}
+func (UnimplementedMerlinServer) Any(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Any not implemented")
+}
+func (UnimplementedMerlinServer) BloodHound(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BloodHound not implemented")
+}
+func (UnimplementedMerlinServer) Broadcast(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (UnimplementedMerlinServer) Campaign(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Campaign not implemented")
+}
+func (UnimplementedMerlinServer) CD(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CD not implemented")
+}
+func (UnimplementedMerlinServer) CheckIn(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+func (UnimplementedMerlinServer) ClearJobs(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearJobs not implemented")
+}
+func (UnimplementedMerlinServer) ClearJobsCreated(context.Context, *emptypb.Empty) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearJobsCreated not implemented")
+}
+func (UnimplementedMerlinServer) CMD(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CMD not implemented")
+}
+func (UnimplementedMerlinServer) Connect(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedMerlinServer) Deploy(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deploy not implemented")
+}
+func (UnimplementedMerlinServer) Destroy(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+}
+func (UnimplementedMerlinServer) Download(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Download not implemented")
+}
+func (UnimplementedMerlinServer) ENV(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ENV not implemented")
+}
+func (UnimplementedMerlinServer) ExecuteAssembly(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteAssembly not implemented")
+}
+func (UnimplementedMerlinServer) ExecutePE(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecutePE not implemented")
+}
+func (UnimplementedMerlinServer) ExecuteShellcode(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteShellcode not implemented")
+}
+func (UnimplementedMerlinServer) Exit(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exit not implemented")
+}
+func (UnimplementedMerlinServer) Fallback(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fallback not implemented")
+}
+func (UnimplementedMerlinServer) IFConfig(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IFConfig not implemented")
+}
+func (UnimplementedMerlinServer) InvokeAssembly(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeAssembly not implemented")
+}
+func (UnimplementedMerlinServer) JA3(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JA3 not implemented")
+}
+func (UnimplementedMerlinServer) KillDate(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillDate not implemented")
+}
+func (UnimplementedMerlinServer) KillProcess(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillProcess not implemented")
+}
+func (UnimplementedMerlinServer) LinkAgent(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkAgent not implemented")
+}
+func (UnimplementedMerlinServer) ListAssemblies(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssemblies not implemented")
+}
+func (UnimplementedMerlinServer) Listener(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Listener not implemented")
+}
+func (UnimplementedMerlinServer) LoadAssembly(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadAssembly not implemented")
+}
+func (UnimplementedMerlinServer) LoadCLR(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadCLR not implemented")
+}
+func (UnimplementedMerlinServer) LS(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LS not implemented")
+}
+func (UnimplementedMerlinServer) MaxRetry(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MaxRetry not implemented")
+}
+func (UnimplementedMerlinServer) Memory(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Memory not implemented")
+}
+func (UnimplementedMerlinServer) MEMFD(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MEMFD not implemented")
+}
+func (UnimplementedMerlinServer) Netstat(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Netstat not implemented")
+}
+func (UnimplementedMerlinServer) Note(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Note not implemented")
+}
+func (UnimplementedMerlinServer) Nslookup(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Nslookup not implemented")
+}
+func (UnimplementedMerlinServer) Padding(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Padding not implemented")
+}
+func (UnimplementedMerlinServer) Parrot(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Parrot not implemented")
+}
+func (UnimplementedMerlinServer) Persist(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Persist not implemented")
+}
+func (UnimplementedMerlinServer) Pipes(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pipes not implemented")
+}
+func (UnimplementedMerlinServer) Preflight(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Preflight not implemented")
+}
+func (UnimplementedMerlinServer) Profile(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Profile not implemented")
+}
+func (UnimplementedMerlinServer) PS(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PS not implemented")
+}
+func (UnimplementedMerlinServer) PWD(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PWD not implemented")
+}
+func (UnimplementedMerlinServer) RM(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RM not implemented")
+}
+func (UnimplementedMerlinServer) RunAs(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunAs not implemented")
+}
+func (UnimplementedMerlinServer) SCExec(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SCExec not implemented")
+}
+func (UnimplementedMerlinServer) SecureDelete(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecureDelete not implemented")
+}
+func (UnimplementedMerlinServer) SecurityProducts(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecurityProducts not implemented")
+}
+func (UnimplementedMerlinServer) SharpGen(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharpGen not implemented")
+}
+func (UnimplementedMerlinServer) Skew(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Skew not implemented")
+}
+func (UnimplementedMerlinServer) Sleep(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sleep not implemented")
+}
+func (UnimplementedMerlinServer) Socks(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Socks not implemented")
+}
+func (UnimplementedMerlinServer) SSH(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SSH not implemented")
+}
+func (UnimplementedMerlinServer) SSHDeploy(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SSHDeploy not implemented")
+}
+func (UnimplementedMerlinServer) Throttle(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Throttle not implemented")
+}
+func (UnimplementedMerlinServer) Timezone(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Timezone not implemented")
+}
+func (UnimplementedMerlinServer) Token(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Token not implemented")
+}
+func (UnimplementedMerlinServer) Touch(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Touch not implemented")
+}
+func (UnimplementedMerlinServer) Transport(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transport not implemented")
+}
+func (UnimplementedMerlinServer) UnlinkAgent(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkAgent not implemented")
+}
+func (UnimplementedMerlinServer) Upload(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Upload not implemented")
+}
+func (UnimplementedMerlinServer) Uptime(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uptime not implemented")
+}
+func (UnimplementedMerlinServer) WMIExec(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WMIExec not implemented")
+}
+func (UnimplementedMerlinServer) Groups(context.Context, *emptypb.Empty) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Groups not implemented")
+}
+func (UnimplementedMerlinServer) GroupAdd(context.Context, *Group) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupAdd not implemented")
+}
+func (UnimplementedMerlinServer) GroupList(context.Context, *ID) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupList not implemented")
+}
+func (UnimplementedMerlinServer) GroupListAll(context.Context, *emptypb.Empty) (*GroupMembers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupListAll not implemented")
+}
+func (UnimplementedMerlinServer) GroupRemove(context.Context, *Group) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupRemove not implemented")
+}
+func (UnimplementedMerlinServer) GetAgent(context.Context, *ID) (*AgentInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgent not implemented")
+}
+func (UnimplementedMerlinServer) GetAgents(context.Context, *emptypb.Empty) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgents not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentLinks(context.Context, *ID) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentLinks not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentStatus(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentStatus not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentRows(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentRows not implemented")
+}
+func (UnimplementedMerlinServer) Remove(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentRoutes(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentRoutes not implemented")
+}
+func (UnimplementedMerlinServer) ExportAgent(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAgent not implemented")
+}
+func (UnimplementedMerlinServer) ImportAgent(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAgent not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentChanges(context.Context, *ID) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentChanges not implemented")
+}
+func (UnimplementedMerlinServer) GetPrivilegedAgentRows(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrivilegedAgentRows not implemented")
+}
+func (UnimplementedMerlinServer) GetCampaigns(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaigns not implemented")
+}
+func (UnimplementedMerlinServer) Release(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedMerlinServer) Quarantine(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Quarantine not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentNames(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentNames not implemented")
+}
+func (UnimplementedMerlinServer) Rename(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rename not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentDetails(context.Context, *AgentCMD) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentDetails not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentActivity(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentActivity not implemented")
+}
+func (UnimplementedMerlinServer) GetAllJobs(context.Context, *emptypb.Empty) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllJobs not implemented")
+}
+func (UnimplementedMerlinServer) GetAllActiveJobs(context.Context, *emptypb.Empty) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllActiveJobs not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentJobs(context.Context, *ID) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentJobs not implemented")
+}
+func (UnimplementedMerlinServer) GetAgentActiveJobs(context.Context, *ID) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentActiveJobs not implemented")
+}
+func (UnimplementedMerlinServer) ExportAttackNavigator(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAttackNavigator not implemented")
+}
+func (UnimplementedMerlinServer) QueryJobs(context.Context, *Options) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryJobs not implemented")
+}
+func (UnimplementedMerlinServer) SetJobExpiry(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetJobExpiry not implemented")
+}
+func (UnimplementedMerlinServer) CreateListener(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateListener not implemented")
+}
+func (UnimplementedMerlinServer) GetListenerIDs(context.Context, *emptypb.Empty) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListenerIDs not implemented")
+}
+func (UnimplementedMerlinServer) GetListeners(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListeners not implemented")
+}
+func (UnimplementedMerlinServer) GetListenerOptions(context.Context, *ID) (*Options, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListenerOptions not implemented")
+}
+func (UnimplementedMerlinServer) GetListenerDefaultOptions(context.Context, *String) (*Options, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetListenerDefaultOptions not implemented")
 }
-func (UnimplementedMerlinServer) GetListenerTypes(context.Context, *emptypb.Empty) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetListenerTypes not implemented")
+func (UnimplementedMerlinServer) GetListenerTypes(context.Context, *emptypb.Empty) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListenerTypes not implemented")
+}
+func (UnimplementedMerlinServer) GetListenerStatus(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListenerStatus not implemented")
+}
+func (UnimplementedMerlinServer) RemoveListener(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveListener not implemented")
+}
+func (UnimplementedMerlinServer) RestartListener(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartListener not implemented")
+}
+func (UnimplementedMerlinServer) SetListenerOption(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetListenerOption not implemented")
+}
+func (UnimplementedMerlinServer) StartListener(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartListener not implemented")
+}
+func (UnimplementedMerlinServer) StopListener(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopListener not implemented")
+}
+func (UnimplementedMerlinServer) Servers(context.Context, *emptypb.Empty) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Servers not implemented")
+}
+func (UnimplementedMerlinServer) DrainListener(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainListener not implemented")
+}
+func (UnimplementedMerlinServer) GenerateSMBPipe(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateSMBPipe not implemented")
+}
+func (UnimplementedMerlinServer) GetListenerOptionSchema(context.Context, *String) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListenerOptionSchema not implemented")
+}
+func (UnimplementedMerlinServer) CreateListeners(context.Context, *Options) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateListeners not implemented")
+}
+func (UnimplementedMerlinServer) GetPivotListeners(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPivotListeners not implemented")
+}
+func (UnimplementedMerlinServer) SelfTest(context.Context, *ID) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelfTest not implemented")
+}
+func (UnimplementedMerlinServer) StartTrafficCapture(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTrafficCapture not implemented")
+}
+func (UnimplementedMerlinServer) StopTrafficCapture(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopTrafficCapture not implemented")
+}
+func (UnimplementedMerlinServer) GetTrafficCaptures(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrafficCaptures not implemented")
+}
+func (UnimplementedMerlinServer) GetModule(context.Context, *String) (*Module, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModule not implemented")
+}
+func (UnimplementedMerlinServer) GetModuleList(context.Context, *emptypb.Empty) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModuleList not implemented")
+}
+func (UnimplementedMerlinServer) RunModule(context.Context, *ModuleRun) (*Messages, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunModule not implemented")
+}
+func (UnimplementedMerlinServer) ReloadModules(context.Context, *emptypb.Empty) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadModules not implemented")
+}
+func (UnimplementedMerlinServer) GetModuleCategories(context.Context, *String) (*Slice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModuleCategories not implemented")
+}
+func (UnimplementedMerlinServer) SearchModules(context.Context, *String) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchModules not implemented")
+}
+func (UnimplementedMerlinServer) GetLoot(context.Context, *ID) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoot not implemented")
+}
+func (UnimplementedMerlinServer) AddCredential(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCredential not implemented")
+}
+func (UnimplementedMerlinServer) GetCredentials(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCredentials not implemented")
+}
+func (UnimplementedMerlinServer) RemoveCredential(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCredential not implemented")
+}
+func (UnimplementedMerlinServer) GetDirectoryObjects(context.Context, *Options) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDirectoryObjects not implemented")
+}
+func (UnimplementedMerlinServer) ExportScanResults(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportScanResults not implemented")
+}
+func (UnimplementedMerlinServer) GetScanResults(context.Context, *String) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScanResults not implemented")
+}
+func (UnimplementedMerlinServer) GetPersistence(context.Context, *ID) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPersistence not implemented")
+}
+func (UnimplementedMerlinServer) ExportIOCs(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportIOCs not implemented")
+}
+func (UnimplementedMerlinServer) GetIOCs(context.Context, *ID) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIOCs not implemented")
+}
+func (UnimplementedMerlinServer) KeyAgentConfig(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeyAgentConfig not implemented")
+}
+func (UnimplementedMerlinServer) Shutdown(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedMerlinServer) GetHosts(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHosts not implemented")
+}
+func (UnimplementedMerlinServer) GetHostActivity(context.Context, *String) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostActivity not implemented")
+}
+func (UnimplementedMerlinServer) ImportHosts(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportHosts not implemented")
+}
+func (UnimplementedMerlinServer) AddHostNote(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddHostNote not implemented")
+}
+func (UnimplementedMerlinServer) GetHost(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHost not implemented")
+}
+func (UnimplementedMerlinServer) ApproveRequest(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveRequest not implemented")
+}
+func (UnimplementedMerlinServer) DenyRequest(context.Context, *ID) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyRequest not implemented")
+}
+func (UnimplementedMerlinServer) GetPendingRequests(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingRequests not implemented")
+}
+func (UnimplementedMerlinServer) HostFile(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HostFile not implemented")
+}
+func (UnimplementedMerlinServer) UnhostFile(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnhostFile not implemented")
+}
+func (UnimplementedMerlinServer) GetHostedFiles(context.Context, *ID) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostedFiles not implemented")
+}
+func (UnimplementedMerlinServer) GetHostedFileAccess(context.Context, *Options) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostedFileAccess not implemented")
+}
+func (UnimplementedMerlinServer) RegisterPayload(context.Context, *Options) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPayload not implemented")
+}
+func (UnimplementedMerlinServer) GetPayloads(context.Context, *String) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPayloads not implemented")
+}
+func (UnimplementedMerlinServer) ExportPayloads(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPayloads not implemented")
+}
+func (UnimplementedMerlinServer) RunPlugin(context.Context, *AgentCMD) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunPlugin not implemented")
+}
+func (UnimplementedMerlinServer) GetPlugins(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlugins not implemented")
+}
+func (UnimplementedMerlinServer) ReloadPlugins(context.Context, *emptypb.Empty) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadPlugins not implemented")
+}
+func (UnimplementedMerlinServer) GetCommands(context.Context, *String) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommands not implemented")
+}
+func (UnimplementedMerlinServer) ExportCommands(context.Context, *String) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportCommands not implemented")
+}
+func (UnimplementedMerlinServer) GetLocales(context.Context, *emptypb.Empty) (*TableData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocales not implemented")
+}
+func (UnimplementedMerlinServer) ReloadLocales(context.Context, *emptypb.Empty) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadLocales not implemented")
+}
+func (UnimplementedMerlinServer) mustEmbedUnimplementedMerlinServer() {}
+
+// UnsafeMerlinServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MerlinServer will
+// result in compilation errors.
+type UnsafeMerlinServer interface {
+	mustEmbedUnimplementedMerlinServer()
+}
+
+func RegisterMerlinServer(s grpc.ServiceRegistrar, srv MerlinServer) {
+	s.RegisterService(&Merlin_ServiceDesc, srv)
+}
+
+func _Merlin_Reconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Reconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Reconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Reconnect(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Register(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Listen_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MerlinServer).Listen(m, &merlinListenServer{stream})
+}
+
+type Merlin_ListenServer interface {
+	Send(*Message) error
+	grpc.ServerStream
+}
+
+type merlinListenServer struct {
+	grpc.ServerStream
+}
+
+func (x *merlinListenServer) Send(m *Message) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Merlin_Any_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Any(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Any",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Any(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_BloodHound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).BloodHound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/BloodHound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).BloodHound(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Broadcast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Broadcast(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Campaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Campaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Campaign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Campaign(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_CD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).CD(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/CD",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).CD(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/CheckIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).CheckIn(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_ClearJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).ClearJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/ClearJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).ClearJobs(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_ClearJobsCreated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).ClearJobsCreated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/ClearJobsCreated",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).ClearJobsCreated(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_CMD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).CMD(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/CMD",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).CMD(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Connect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Connect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Connect(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Deploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Deploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Deploy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Deploy(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Destroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Destroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Destroy(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Download_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Download(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Download",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Download(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_ENV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).ENV(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/ENV",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).ENV(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_ExecuteAssembly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).ExecuteAssembly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/ExecuteAssembly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).ExecuteAssembly(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_ExecutePE_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).ExecutePE(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/ExecutePE",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).ExecutePE(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_ExecuteShellcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).ExecuteShellcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/ExecuteShellcode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).ExecuteShellcode(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Exit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Exit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Exit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Exit(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Fallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Fallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Fallback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Fallback(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_IFConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).IFConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/IFConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).IFConfig(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_InvokeAssembly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).InvokeAssembly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/InvokeAssembly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).InvokeAssembly(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_JA3_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).JA3(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/JA3",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).JA3(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_KillDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).KillDate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/KillDate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).KillDate(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_KillProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).KillProcess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/KillProcess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).KillProcess(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_LinkAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).LinkAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/LinkAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).LinkAgent(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_ListAssemblies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).ListAssemblies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/ListAssemblies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).ListAssemblies(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Listener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Listener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Listener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Listener(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_LoadAssembly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).LoadAssembly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/LoadAssembly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).LoadAssembly(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_LoadCLR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).LoadCLR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/LoadCLR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).LoadCLR(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_LS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).LS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/LS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).LS(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_MaxRetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).MaxRetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/MaxRetry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).MaxRetry(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Memory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Memory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Memory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Memory(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_MEMFD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).MEMFD(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/MEMFD",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).MEMFD(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Netstat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Netstat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Netstat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Netstat(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Note_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Note(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Note",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Note(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Nslookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Nslookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Nslookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Nslookup(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Padding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Padding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Padding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Padding(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Parrot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Parrot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Parrot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Parrot(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Persist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Persist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Persist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Persist(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Pipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Pipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Pipes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Pipes(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Preflight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Preflight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Preflight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Preflight(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Profile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Profile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Profile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Profile(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_PS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).PS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/PS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).PS(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_PWD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).PWD(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/PWD",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).PWD(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_RM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).RM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/RM",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).RM(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_RunAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).RunAs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/RunAs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).RunAs(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_SCExec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).SCExec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/SCExec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).SCExec(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_SecureDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).SecureDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/SecureDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).SecureDelete(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_SecurityProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).SecurityProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/SecurityProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).SecurityProducts(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_SharpGen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).SharpGen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/SharpGen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).SharpGen(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Skew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Skew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Skew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Skew(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Sleep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Sleep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Sleep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Sleep(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Socks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Socks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Socks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Socks(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_SSH_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).SSH(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/SSH",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).SSH(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_SSHDeploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).SSHDeploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/SSHDeploy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).SSHDeploy(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Throttle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Throttle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Throttle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Throttle(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Timezone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Timezone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Timezone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Timezone(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Token_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Token(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Token",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Token(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_Touch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Touch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Touch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Touch(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) GetListenerStatus(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetListenerStatus not implemented")
+
+func _Merlin_Transport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Transport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Transport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Transport(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) RemoveListener(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveListener not implemented")
+
+func _Merlin_UnlinkAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).UnlinkAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/UnlinkAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).UnlinkAgent(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) RestartListener(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RestartListener not implemented")
+
+func _Merlin_Upload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Upload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Upload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Upload(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) SetListenerOption(context.Context, *AgentCMD) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetListenerOption not implemented")
+
+func _Merlin_Uptime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Uptime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Uptime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Uptime(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) StartListener(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StartListener not implemented")
+
+func _Merlin_WMIExec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCMD)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).WMIExec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/WMIExec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).WMIExec(ctx, req.(*AgentCMD))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) StopListener(context.Context, *ID) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StopListener not implemented")
+
+func _Merlin_Groups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Groups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Groups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Groups(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) Servers(context.Context, *emptypb.Empty) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Servers not implemented")
+
+func _Merlin_GroupAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Group)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GroupAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GroupAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GroupAdd(ctx, req.(*Group))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) GetModule(context.Context, *String) (*Module, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetModule not implemented")
+
+func _Merlin_GroupList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GroupList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GroupList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GroupList(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) GetModuleList(context.Context, *emptypb.Empty) (*Slice, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetModuleList not implemented")
+
+func _Merlin_GroupListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GroupListAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GroupListAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GroupListAll(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) RunModule(context.Context, *ModuleRun) (*Messages, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RunModule not implemented")
+
+func _Merlin_GroupRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Group)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GroupRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GroupRemove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GroupRemove(ctx, req.(*Group))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMerlinServer) mustEmbedUnimplementedMerlinServer() {}
 
-// UnsafeMerlinServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to MerlinServer will
-// result in compilation errors.
-type UnsafeMerlinServer interface {
-	mustEmbedUnimplementedMerlinServer()
+func _Merlin_GetAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GetAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GetAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GetAgent(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterMerlinServer(s grpc.ServiceRegistrar, srv MerlinServer) {
-	s.RegisterService(&Merlin_ServiceDesc, srv)
+func _Merlin_GetAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GetAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GetAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GetAgents(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Reconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetAgentLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Reconnect(ctx, in)
+		return srv.(MerlinServer).GetAgentLinks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Reconnect",
+		FullMethod: "/rpc.Merlin/GetAgentLinks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Reconnect(ctx, req.(*ID))
+		return srv.(MerlinServer).GetAgentLinks(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_GetAgentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Register(ctx, in)
+		return srv.(MerlinServer).GetAgentStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Register",
+		FullMethod: "/rpc.Merlin/GetAgentStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Register(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetAgentStatus(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Listen_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ID)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Merlin_GetAgentRows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(MerlinServer).Listen(m, &merlinListenServer{stream})
-}
-
-type Merlin_ListenServer interface {
-	Send(*Message) error
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(MerlinServer).GetAgentRows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GetAgentRows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GetAgentRows(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type merlinListenServer struct {
-	grpc.ServerStream
+func _Merlin_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).Remove(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *merlinListenServer) Send(m *Message) error {
-	return x.ServerStream.SendMsg(m)
+func _Merlin_GetAgentRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GetAgentRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GetAgentRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GetAgentRoutes(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Any_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_ExportAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AgentCMD)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Any(ctx, in)
+		return srv.(MerlinServer).ExportAgent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Any",
+		FullMethod: "/rpc.Merlin/ExportAgent",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Any(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).ExportAgent(ctx, req.(*AgentCMD))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_CD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_ImportAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).CD(ctx, in)
+		return srv.(MerlinServer).ImportAgent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/CD",
+		FullMethod: "/rpc.Merlin/ImportAgent",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).CD(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).ImportAgent(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetAgentChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).CheckIn(ctx, in)
+		return srv.(MerlinServer).GetAgentChanges(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/CheckIn",
+		FullMethod: "/rpc.Merlin/GetAgentChanges",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).CheckIn(ctx, req.(*ID))
+		return srv.(MerlinServer).GetAgentChanges(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_ClearJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetPrivilegedAgentRows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).ClearJobs(ctx, in)
+		return srv.(MerlinServer).GetPrivilegedAgentRows(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/ClearJobs",
+		FullMethod: "/rpc.Merlin/GetPrivilegedAgentRows",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).ClearJobs(ctx, req.(*ID))
+		return srv.(MerlinServer).GetPrivilegedAgentRows(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_ClearJobsCreated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetCampaigns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).ClearJobsCreated(ctx, in)
+		return srv.(MerlinServer).GetCampaigns(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/ClearJobsCreated",
+		FullMethod: "/rpc.Merlin/GetCampaigns",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).ClearJobsCreated(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetCampaigns(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_CMD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).CMD(ctx, in)
+		return srv.(MerlinServer).Release(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/CMD",
+		FullMethod: "/rpc.Merlin/Release",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).CMD(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).Release(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_Quarantine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AgentCMD)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Connect(ctx, in)
+		return srv.(MerlinServer).Quarantine(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Connect",
+		FullMethod: "/rpc.Merlin/Quarantine",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Connect(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).Quarantine(ctx, req.(*AgentCMD))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Download_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetAgentNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Download(ctx, in)
+		return srv.(MerlinServer).GetAgentNames(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Download",
+		FullMethod: "/rpc.Merlin/GetAgentNames",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Download(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetAgentNames(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_ENV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AgentCMD)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).ENV(ctx, in)
+		return srv.(MerlinServer).Rename(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/ENV",
+		FullMethod: "/rpc.Merlin/Rename",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).ENV(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).Rename(ctx, req.(*AgentCMD))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_ExecuteAssembly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetAgentDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AgentCMD)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).ExecuteAssembly(ctx, in)
+		return srv.(MerlinServer).GetAgentDetails(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/ExecuteAssembly",
+		FullMethod: "/rpc.Merlin/GetAgentDetails",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).ExecuteAssembly(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetAgentDetails(ctx, req.(*AgentCMD))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_ExecutePE_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetAgentActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).ExecutePE(ctx, in)
+		return srv.(MerlinServer).GetAgentActivity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/ExecutePE",
+		FullMethod: "/rpc.Merlin/GetAgentActivity",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).ExecutePE(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetAgentActivity(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_ExecuteShellcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetAllJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).ExecuteShellcode(ctx, in)
+		return srv.(MerlinServer).GetAllJobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/ExecuteShellcode",
+		FullMethod: "/rpc.Merlin/GetAllJobs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).ExecuteShellcode(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetAllJobs(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Exit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetAllActiveJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerlinServer).GetAllActiveJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Merlin/GetAllActiveJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerlinServer).GetAllActiveJobs(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Merlin_GetAgentJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Exit(ctx, in)
+		return srv.(MerlinServer).GetAgentJobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Exit",
+		FullMethod: "/rpc.Merlin/GetAgentJobs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Exit(ctx, req.(*ID))
+		return srv.(MerlinServer).GetAgentJobs(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_IFConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetAgentActiveJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).IFConfig(ctx, in)
+		return srv.(MerlinServer).GetAgentActiveJobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/IFConfig",
+		FullMethod: "/rpc.Merlin/GetAgentActiveJobs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).IFConfig(ctx, req.(*ID))
+		return srv.(MerlinServer).GetAgentActiveJobs(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_InvokeAssembly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_ExportAttackNavigator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).InvokeAssembly(ctx, in)
+		return srv.(MerlinServer).ExportAttackNavigator(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/InvokeAssembly",
+		FullMethod: "/rpc.Merlin/ExportAttackNavigator",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).InvokeAssembly(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).ExportAttackNavigator(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_JA3_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_QueryJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).JA3(ctx, in)
+		return srv.(MerlinServer).QueryJobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/JA3",
+		FullMethod: "/rpc.Merlin/QueryJobs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).JA3(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).QueryJobs(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_KillDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_SetJobExpiry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).KillDate(ctx, in)
+		return srv.(MerlinServer).SetJobExpiry(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/KillDate",
+		FullMethod: "/rpc.Merlin/SetJobExpiry",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).KillDate(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).SetJobExpiry(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_KillProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_CreateListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).KillProcess(ctx, in)
+		return srv.(MerlinServer).CreateListener(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/KillProcess",
+		FullMethod: "/rpc.Merlin/CreateListener",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).KillProcess(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).CreateListener(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_LinkAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetListenerIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).LinkAgent(ctx, in)
+		return srv.(MerlinServer).GetListenerIDs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/LinkAgent",
+		FullMethod: "/rpc.Merlin/GetListenerIDs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).LinkAgent(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetListenerIDs(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_ListAssemblies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetListeners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).ListAssemblies(ctx, in)
+		return srv.(MerlinServer).GetListeners(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/ListAssemblies",
+		FullMethod: "/rpc.Merlin/GetListeners",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).ListAssemblies(ctx, req.(*ID))
+		return srv.(MerlinServer).GetListeners(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Listener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetListenerOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Listener(ctx, in)
+		return srv.(MerlinServer).GetListenerOptions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Listener",
+		FullMethod: "/rpc.Merlin/GetListenerOptions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Listener(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetListenerOptions(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_LoadAssembly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetListenerDefaultOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).LoadAssembly(ctx, in)
+		return srv.(MerlinServer).GetListenerDefaultOptions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/LoadAssembly",
+		FullMethod: "/rpc.Merlin/GetListenerDefaultOptions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).LoadAssembly(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetListenerDefaultOptions(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_LoadCLR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetListenerTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).LoadCLR(ctx, in)
+		return srv.(MerlinServer).GetListenerTypes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/LoadCLR",
+		FullMethod: "/rpc.Merlin/GetListenerTypes",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).LoadCLR(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetListenerTypes(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_LS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetListenerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).LS(ctx, in)
+		return srv.(MerlinServer).GetListenerStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/LS",
+		FullMethod: "/rpc.Merlin/GetListenerStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).LS(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetListenerStatus(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_MaxRetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_RemoveListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).MaxRetry(ctx, in)
+		return srv.(MerlinServer).RemoveListener(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/MaxRetry",
+		FullMethod: "/rpc.Merlin/RemoveListener",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).MaxRetry(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).RemoveListener(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Memory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_RestartListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Memory(ctx, in)
+		return srv.(MerlinServer).RestartListener(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Memory",
+		FullMethod: "/rpc.Merlin/RestartListener",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Memory(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).RestartListener(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_MEMFD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_SetListenerOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AgentCMD)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).MEMFD(ctx, in)
+		return srv.(MerlinServer).SetListenerOption(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/MEMFD",
+		FullMethod: "/rpc.Merlin/SetListenerOption",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).MEMFD(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).SetListenerOption(ctx, req.(*AgentCMD))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Netstat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_StartListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Netstat(ctx, in)
+		return srv.(MerlinServer).StartListener(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Netstat",
+		FullMethod: "/rpc.Merlin/StartListener",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Netstat(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).StartListener(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Note_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_StopListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Note(ctx, in)
+		return srv.(MerlinServer).StopListener(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Note",
+		FullMethod: "/rpc.Merlin/StopListener",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Note(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).StopListener(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Nslookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_Servers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Nslookup(ctx, in)
+		return srv.(MerlinServer).Servers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Nslookup",
+		FullMethod: "/rpc.Merlin/Servers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Nslookup(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).Servers(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Padding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_DrainListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Padding(ctx, in)
+		return srv.(MerlinServer).DrainListener(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Padding",
+		FullMethod: "/rpc.Merlin/DrainListener",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Padding(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).DrainListener(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Parrot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GenerateSMBPipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Parrot(ctx, in)
+		return srv.(MerlinServer).GenerateSMBPipe(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Parrot",
+		FullMethod: "/rpc.Merlin/GenerateSMBPipe",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Parrot(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GenerateSMBPipe(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Pipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetListenerOptionSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Pipes(ctx, in)
+		return srv.(MerlinServer).GetListenerOptionSchema(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Pipes",
+		FullMethod: "/rpc.Merlin/GetListenerOptionSchema",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Pipes(ctx, req.(*ID))
+		return srv.(MerlinServer).GetListenerOptionSchema(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_PS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_CreateListeners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).PS(ctx, in)
+		return srv.(MerlinServer).CreateListeners(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/PS",
+		FullMethod: "/rpc.Merlin/CreateListeners",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).PS(ctx, req.(*ID))
+		return srv.(MerlinServer).CreateListeners(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_PWD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetPivotListeners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).PWD(ctx, in)
+		return srv.(MerlinServer).GetPivotListeners(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/PWD",
+		FullMethod: "/rpc.Merlin/GetPivotListeners",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).PWD(ctx, req.(*ID))
+		return srv.(MerlinServer).GetPivotListeners(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_RM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_SelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).RM(ctx, in)
+		return srv.(MerlinServer).SelfTest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/RM",
+		FullMethod: "/rpc.Merlin/SelfTest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).RM(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).SelfTest(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_RunAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_StartTrafficCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).RunAs(ctx, in)
+		return srv.(MerlinServer).StartTrafficCapture(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/RunAs",
+		FullMethod: "/rpc.Merlin/StartTrafficCapture",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).RunAs(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).StartTrafficCapture(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_SecureDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_StopTrafficCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).SecureDelete(ctx, in)
+		return srv.(MerlinServer).StopTrafficCapture(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/SecureDelete",
+		FullMethod: "/rpc.Merlin/StopTrafficCapture",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).SecureDelete(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).StopTrafficCapture(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_SharpGen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetTrafficCaptures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).SharpGen(ctx, in)
+		return srv.(MerlinServer).GetTrafficCaptures(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/SharpGen",
+		FullMethod: "/rpc.Merlin/GetTrafficCaptures",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).SharpGen(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetTrafficCaptures(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Skew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Skew(ctx, in)
+		return srv.(MerlinServer).GetModule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Skew",
+		FullMethod: "/rpc.Merlin/GetModule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Skew(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetModule(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Sleep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetModuleList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Sleep(ctx, in)
+		return srv.(MerlinServer).GetModuleList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Sleep",
+		FullMethod: "/rpc.Merlin/GetModuleList",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Sleep(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetModuleList(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Socks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_RunModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModuleRun)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Socks(ctx, in)
+		return srv.(MerlinServer).RunModule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Socks",
+		FullMethod: "/rpc.Merlin/RunModule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Socks(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).RunModule(ctx, req.(*ModuleRun))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_SSH_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_ReloadModules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).SSH(ctx, in)
+		return srv.(MerlinServer).ReloadModules(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/SSH",
+		FullMethod: "/rpc.Merlin/ReloadModules",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).SSH(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).ReloadModules(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Token_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetModuleCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Token(ctx, in)
+		return srv.(MerlinServer).GetModuleCategories(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Token",
+		FullMethod: "/rpc.Merlin/GetModuleCategories",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Token(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetModuleCategories(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Touch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_SearchModules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Touch(ctx, in)
+		return srv.(MerlinServer).SearchModules(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Touch",
+		FullMethod: "/rpc.Merlin/SearchModules",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Touch(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).SearchModules(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_UnlinkAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_GetLoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).UnlinkAgent(ctx, in)
+		return srv.(MerlinServer).GetLoot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/UnlinkAgent",
+		FullMethod: "/rpc.Merlin/GetLoot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).UnlinkAgent(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).GetLoot(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Upload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AgentCMD)
+func _Merlin_AddCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Upload(ctx, in)
+		return srv.(MerlinServer).AddCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Upload",
+		FullMethod: "/rpc.Merlin/AddCredential",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Upload(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).AddCredential(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Uptime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Uptime(ctx, in)
+		return srv.(MerlinServer).GetCredentials(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Uptime",
+		FullMethod: "/rpc.Merlin/GetCredentials",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Uptime(ctx, req.(*ID))
+		return srv.(MerlinServer).GetCredentials(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Groups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_RemoveCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Groups(ctx, in)
+		return srv.(MerlinServer).RemoveCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Groups",
+		FullMethod: "/rpc.Merlin/RemoveCredential",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Groups(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).RemoveCredential(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GroupAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Group)
+func _Merlin_GetDirectoryObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GroupAdd(ctx, in)
+		return srv.(MerlinServer).GetDirectoryObjects(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GroupAdd",
+		FullMethod: "/rpc.Merlin/GetDirectoryObjects",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GroupAdd(ctx, req.(*Group))
+		return srv.(MerlinServer).GetDirectoryObjects(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GroupList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_ExportScanResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GroupList(ctx, in)
+		return srv.(MerlinServer).ExportScanResults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GroupList",
+		FullMethod: "/rpc.Merlin/ExportScanResults",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GroupList(ctx, req.(*ID))
+		return srv.(MerlinServer).ExportScanResults(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GroupListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_GetScanResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GroupListAll(ctx, in)
+		return srv.(MerlinServer).GetScanResults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GroupListAll",
+		FullMethod: "/rpc.Merlin/GetScanResults",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GroupListAll(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetScanResults(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GroupRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Group)
+func _Merlin_GetPersistence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GroupRemove(ctx, in)
+		return srv.(MerlinServer).GetPersistence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GroupRemove",
+		FullMethod: "/rpc.Merlin/GetPersistence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GroupRemove(ctx, req.(*Group))
+		return srv.(MerlinServer).GetPersistence(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_ExportIOCs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAgent(ctx, in)
+		return srv.(MerlinServer).ExportIOCs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAgent",
+		FullMethod: "/rpc.Merlin/ExportIOCs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAgent(ctx, req.(*ID))
+		return srv.(MerlinServer).ExportIOCs(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_GetIOCs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAgents(ctx, in)
+		return srv.(MerlinServer).GetIOCs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAgents",
+		FullMethod: "/rpc.Merlin/GetIOCs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAgents(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetIOCs(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAgentLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_KeyAgentConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAgentLinks(ctx, in)
+		return srv.(MerlinServer).KeyAgentConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAgentLinks",
+		FullMethod: "/rpc.Merlin/KeyAgentConfig",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAgentLinks(ctx, req.(*ID))
+		return srv.(MerlinServer).KeyAgentConfig(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAgentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAgentStatus(ctx, in)
+		return srv.(MerlinServer).Shutdown(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAgentStatus",
+		FullMethod: "/rpc.Merlin/Shutdown",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAgentStatus(ctx, req.(*ID))
+		return srv.(MerlinServer).Shutdown(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAgentRows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAgentRows(ctx, in)
+		return srv.(MerlinServer).GetHosts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAgentRows",
+		FullMethod: "/rpc.Merlin/GetHosts",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAgentRows(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetHosts(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetHostActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Remove(ctx, in)
+		return srv.(MerlinServer).GetHostActivity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Remove",
+		FullMethod: "/rpc.Merlin/GetHostActivity",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Remove(ctx, req.(*ID))
+		return srv.(MerlinServer).GetHostActivity(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAllJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_ImportHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAllJobs(ctx, in)
+		return srv.(MerlinServer).ImportHosts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAllJobs",
+		FullMethod: "/rpc.Merlin/ImportHosts",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAllJobs(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).ImportHosts(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAllActiveJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_AddHostNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAllActiveJobs(ctx, in)
+		return srv.(MerlinServer).AddHostNote(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAllActiveJobs",
+		FullMethod: "/rpc.Merlin/AddHostNote",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAllActiveJobs(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).AddHostNote(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAgentJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAgentJobs(ctx, in)
+		return srv.(MerlinServer).GetHost(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAgentJobs",
+		FullMethod: "/rpc.Merlin/GetHost",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAgentJobs(ctx, req.(*ID))
+		return srv.(MerlinServer).GetHost(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetAgentActiveJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_ApproveRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetAgentActiveJobs(ctx, in)
+		return srv.(MerlinServer).ApproveRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetAgentActiveJobs",
+		FullMethod: "/rpc.Merlin/ApproveRequest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetAgentActiveJobs(ctx, req.(*ID))
+		return srv.(MerlinServer).ApproveRequest(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_CreateListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Options)
+func _Merlin_DenyRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).CreateListener(ctx, in)
+		return srv.(MerlinServer).DenyRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/CreateListener",
+		FullMethod: "/rpc.Merlin/DenyRequest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).CreateListener(ctx, req.(*Options))
+		return srv.(MerlinServer).DenyRequest(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetListenerIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetPendingRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetListenerIDs(ctx, in)
+		return srv.(MerlinServer).GetPendingRequests(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetListenerIDs",
+		FullMethod: "/rpc.Merlin/GetPendingRequests",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetListenerIDs(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetPendingRequests(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetListeners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_HostFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetListeners(ctx, in)
+		return srv.(MerlinServer).HostFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetListeners",
+		FullMethod: "/rpc.Merlin/HostFile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetListeners(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).HostFile(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetListenerOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_UnhostFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetListenerOptions(ctx, in)
+		return srv.(MerlinServer).UnhostFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetListenerOptions",
+		FullMethod: "/rpc.Merlin/UnhostFile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetListenerOptions(ctx, req.(*ID))
+		return srv.(MerlinServer).UnhostFile(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetListenerDefaultOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(String)
+func _Merlin_GetHostedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetListenerDefaultOptions(ctx, in)
+		return srv.(MerlinServer).GetHostedFiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetListenerDefaultOptions",
+		FullMethod: "/rpc.Merlin/GetHostedFiles",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetListenerDefaultOptions(ctx, req.(*String))
+		return srv.(MerlinServer).GetHostedFiles(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetListenerTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_GetHostedFileAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetListenerTypes(ctx, in)
+		return srv.(MerlinServer).GetHostedFileAccess(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetListenerTypes",
+		FullMethod: "/rpc.Merlin/GetHostedFileAccess",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetListenerTypes(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetHostedFileAccess(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetListenerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_RegisterPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Options)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetListenerStatus(ctx, in)
+		return srv.(MerlinServer).RegisterPayload(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetListenerStatus",
+		FullMethod: "/rpc.Merlin/RegisterPayload",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetListenerStatus(ctx, req.(*ID))
+		return srv.(MerlinServer).RegisterPayload(ctx, req.(*Options))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_RemoveListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetPayloads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).RemoveListener(ctx, in)
+		return srv.(MerlinServer).GetPayloads(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/RemoveListener",
+		FullMethod: "/rpc.Merlin/GetPayloads",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).RemoveListener(ctx, req.(*ID))
+		return srv.(MerlinServer).GetPayloads(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_RestartListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_ExportPayloads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).RestartListener(ctx, in)
+		return srv.(MerlinServer).ExportPayloads(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/RestartListener",
+		FullMethod: "/rpc.Merlin/ExportPayloads",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).RestartListener(ctx, req.(*ID))
+		return srv.(MerlinServer).ExportPayloads(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_SetListenerOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_RunPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AgentCMD)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).SetListenerOption(ctx, in)
+		return srv.(MerlinServer).RunPlugin(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/SetListenerOption",
+		FullMethod: "/rpc.Merlin/RunPlugin",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).SetListenerOption(ctx, req.(*AgentCMD))
+		return srv.(MerlinServer).RunPlugin(ctx, req.(*AgentCMD))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_StartListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_GetPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).StartListener(ctx, in)
+		return srv.(MerlinServer).GetPlugins(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/StartListener",
+		FullMethod: "/rpc.Merlin/GetPlugins",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).StartListener(ctx, req.(*ID))
+		return srv.(MerlinServer).GetPlugins(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_StopListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _Merlin_ReloadPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).StopListener(ctx, in)
+		return srv.(MerlinServer).ReloadPlugins(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/StopListener",
+		FullMethod: "/rpc.Merlin/ReloadPlugins",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).StopListener(ctx, req.(*ID))
+		return srv.(MerlinServer).ReloadPlugins(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_Servers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Merlin_GetCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).Servers(ctx, in)
+		return srv.(MerlinServer).GetCommands(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/Servers",
+		FullMethod: "/rpc.Merlin/GetCommands",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).Servers(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetCommands(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_ExportCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(String)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetModule(ctx, in)
+		return srv.(MerlinServer).ExportCommands(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetModule",
+		FullMethod: "/rpc.Merlin/ExportCommands",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetModule(ctx, req.(*String))
+		return srv.(MerlinServer).ExportCommands(ctx, req.(*String))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_GetModuleList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Merlin_GetLocales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).GetModuleList(ctx, in)
+		return srv.(MerlinServer).GetLocales(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/GetModuleList",
+		FullMethod: "/rpc.Merlin/GetLocales",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).GetModuleList(ctx, req.(*emptypb.Empty))
+		return srv.(MerlinServer).GetLocales(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Merlin_RunModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ModuleRun)
+func _Merlin_ReloadLocales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MerlinServer).RunModule(ctx, in)
+		return srv.(MerlinServer).ReloadLocales(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpc.Merlin/RunModule",
+		FullMethod: "/rpc.Merlin/ReloadLocales",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MerlinServer).RunModule(ctx, req.(*ModuleRun))
+		return srv.(MerlinServer).ReloadLocales(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2730,6 +5222,18 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Any",
 			Handler:    _Merlin_Any_Handler,
 		},
+		{
+			MethodName: "BloodHound",
+			Handler:    _Merlin_BloodHound_Handler,
+		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _Merlin_Broadcast_Handler,
+		},
+		{
+			MethodName: "Campaign",
+			Handler:    _Merlin_Campaign_Handler,
+		},
 		{
 			MethodName: "CD",
 			Handler:    _Merlin_CD_Handler,
@@ -2754,6 +5258,14 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Connect",
 			Handler:    _Merlin_Connect_Handler,
 		},
+		{
+			MethodName: "Deploy",
+			Handler:    _Merlin_Deploy_Handler,
+		},
+		{
+			MethodName: "Destroy",
+			Handler:    _Merlin_Destroy_Handler,
+		},
 		{
 			MethodName: "Download",
 			Handler:    _Merlin_Download_Handler,
@@ -2778,6 +5290,10 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Exit",
 			Handler:    _Merlin_Exit_Handler,
 		},
+		{
+			MethodName: "Fallback",
+			Handler:    _Merlin_Fallback_Handler,
+		},
 		{
 			MethodName: "IFConfig",
 			Handler:    _Merlin_IFConfig_Handler,
@@ -2854,10 +5370,22 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Parrot",
 			Handler:    _Merlin_Parrot_Handler,
 		},
+		{
+			MethodName: "Persist",
+			Handler:    _Merlin_Persist_Handler,
+		},
 		{
 			MethodName: "Pipes",
 			Handler:    _Merlin_Pipes_Handler,
 		},
+		{
+			MethodName: "Preflight",
+			Handler:    _Merlin_Preflight_Handler,
+		},
+		{
+			MethodName: "Profile",
+			Handler:    _Merlin_Profile_Handler,
+		},
 		{
 			MethodName: "PS",
 			Handler:    _Merlin_PS_Handler,
@@ -2874,10 +5402,18 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RunAs",
 			Handler:    _Merlin_RunAs_Handler,
 		},
+		{
+			MethodName: "SCExec",
+			Handler:    _Merlin_SCExec_Handler,
+		},
 		{
 			MethodName: "SecureDelete",
 			Handler:    _Merlin_SecureDelete_Handler,
 		},
+		{
+			MethodName: "SecurityProducts",
+			Handler:    _Merlin_SecurityProducts_Handler,
+		},
 		{
 			MethodName: "SharpGen",
 			Handler:    _Merlin_SharpGen_Handler,
@@ -2898,6 +5434,18 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SSH",
 			Handler:    _Merlin_SSH_Handler,
 		},
+		{
+			MethodName: "SSHDeploy",
+			Handler:    _Merlin_SSHDeploy_Handler,
+		},
+		{
+			MethodName: "Throttle",
+			Handler:    _Merlin_Throttle_Handler,
+		},
+		{
+			MethodName: "Timezone",
+			Handler:    _Merlin_Timezone_Handler,
+		},
 		{
 			MethodName: "Token",
 			Handler:    _Merlin_Token_Handler,
@@ -2906,6 +5454,10 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Touch",
 			Handler:    _Merlin_Touch_Handler,
 		},
+		{
+			MethodName: "Transport",
+			Handler:    _Merlin_Transport_Handler,
+		},
 		{
 			MethodName: "UnlinkAgent",
 			Handler:    _Merlin_UnlinkAgent_Handler,
@@ -2918,6 +5470,10 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Uptime",
 			Handler:    _Merlin_Uptime_Handler,
 		},
+		{
+			MethodName: "WMIExec",
+			Handler:    _Merlin_WMIExec_Handler,
+		},
 		{
 			MethodName: "Groups",
 			Handler:    _Merlin_Groups_Handler,
@@ -2962,6 +5518,54 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Remove",
 			Handler:    _Merlin_Remove_Handler,
 		},
+		{
+			MethodName: "GetAgentRoutes",
+			Handler:    _Merlin_GetAgentRoutes_Handler,
+		},
+		{
+			MethodName: "ExportAgent",
+			Handler:    _Merlin_ExportAgent_Handler,
+		},
+		{
+			MethodName: "ImportAgent",
+			Handler:    _Merlin_ImportAgent_Handler,
+		},
+		{
+			MethodName: "GetAgentChanges",
+			Handler:    _Merlin_GetAgentChanges_Handler,
+		},
+		{
+			MethodName: "GetPrivilegedAgentRows",
+			Handler:    _Merlin_GetPrivilegedAgentRows_Handler,
+		},
+		{
+			MethodName: "GetCampaigns",
+			Handler:    _Merlin_GetCampaigns_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _Merlin_Release_Handler,
+		},
+		{
+			MethodName: "Quarantine",
+			Handler:    _Merlin_Quarantine_Handler,
+		},
+		{
+			MethodName: "GetAgentNames",
+			Handler:    _Merlin_GetAgentNames_Handler,
+		},
+		{
+			MethodName: "Rename",
+			Handler:    _Merlin_Rename_Handler,
+		},
+		{
+			MethodName: "GetAgentDetails",
+			Handler:    _Merlin_GetAgentDetails_Handler,
+		},
+		{
+			MethodName: "GetAgentActivity",
+			Handler:    _Merlin_GetAgentActivity_Handler,
+		},
 		{
 			MethodName: "GetAllJobs",
 			Handler:    _Merlin_GetAllJobs_Handler,
@@ -2978,6 +5582,18 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAgentActiveJobs",
 			Handler:    _Merlin_GetAgentActiveJobs_Handler,
 		},
+		{
+			MethodName: "ExportAttackNavigator",
+			Handler:    _Merlin_ExportAttackNavigator_Handler,
+		},
+		{
+			MethodName: "QueryJobs",
+			Handler:    _Merlin_QueryJobs_Handler,
+		},
+		{
+			MethodName: "SetJobExpiry",
+			Handler:    _Merlin_SetJobExpiry_Handler,
+		},
 		{
 			MethodName: "CreateListener",
 			Handler:    _Merlin_CreateListener_Handler,
@@ -3030,6 +5646,42 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Servers",
 			Handler:    _Merlin_Servers_Handler,
 		},
+		{
+			MethodName: "DrainListener",
+			Handler:    _Merlin_DrainListener_Handler,
+		},
+		{
+			MethodName: "GenerateSMBPipe",
+			Handler:    _Merlin_GenerateSMBPipe_Handler,
+		},
+		{
+			MethodName: "GetListenerOptionSchema",
+			Handler:    _Merlin_GetListenerOptionSchema_Handler,
+		},
+		{
+			MethodName: "CreateListeners",
+			Handler:    _Merlin_CreateListeners_Handler,
+		},
+		{
+			MethodName: "GetPivotListeners",
+			Handler:    _Merlin_GetPivotListeners_Handler,
+		},
+		{
+			MethodName: "SelfTest",
+			Handler:    _Merlin_SelfTest_Handler,
+		},
+		{
+			MethodName: "StartTrafficCapture",
+			Handler:    _Merlin_StartTrafficCapture_Handler,
+		},
+		{
+			MethodName: "StopTrafficCapture",
+			Handler:    _Merlin_StopTrafficCapture_Handler,
+		},
+		{
+			MethodName: "GetTrafficCaptures",
+			Handler:    _Merlin_GetTrafficCaptures_Handler,
+		},
 		{
 			MethodName: "GetModule",
 			Handler:    _Merlin_GetModule_Handler,
@@ -3042,6 +5694,154 @@ var Merlin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RunModule",
 			Handler:    _Merlin_RunModule_Handler,
 		},
+		{
+			MethodName: "ReloadModules",
+			Handler:    _Merlin_ReloadModules_Handler,
+		},
+		{
+			MethodName: "GetModuleCategories",
+			Handler:    _Merlin_GetModuleCategories_Handler,
+		},
+		{
+			MethodName: "SearchModules",
+			Handler:    _Merlin_SearchModules_Handler,
+		},
+		{
+			MethodName: "GetLoot",
+			Handler:    _Merlin_GetLoot_Handler,
+		},
+		{
+			MethodName: "AddCredential",
+			Handler:    _Merlin_AddCredential_Handler,
+		},
+		{
+			MethodName: "GetCredentials",
+			Handler:    _Merlin_GetCredentials_Handler,
+		},
+		{
+			MethodName: "RemoveCredential",
+			Handler:    _Merlin_RemoveCredential_Handler,
+		},
+		{
+			MethodName: "GetDirectoryObjects",
+			Handler:    _Merlin_GetDirectoryObjects_Handler,
+		},
+		{
+			MethodName: "ExportScanResults",
+			Handler:    _Merlin_ExportScanResults_Handler,
+		},
+		{
+			MethodName: "GetScanResults",
+			Handler:    _Merlin_GetScanResults_Handler,
+		},
+		{
+			MethodName: "GetPersistence",
+			Handler:    _Merlin_GetPersistence_Handler,
+		},
+		{
+			MethodName: "ExportIOCs",
+			Handler:    _Merlin_ExportIOCs_Handler,
+		},
+		{
+			MethodName: "GetIOCs",
+			Handler:    _Merlin_GetIOCs_Handler,
+		},
+		{
+			MethodName: "KeyAgentConfig",
+			Handler:    _Merlin_KeyAgentConfig_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _Merlin_Shutdown_Handler,
+		},
+		{
+			MethodName: "GetHosts",
+			Handler:    _Merlin_GetHosts_Handler,
+		},
+		{
+			MethodName: "GetHostActivity",
+			Handler:    _Merlin_GetHostActivity_Handler,
+		},
+		{
+			MethodName: "ImportHosts",
+			Handler:    _Merlin_ImportHosts_Handler,
+		},
+		{
+			MethodName: "AddHostNote",
+			Handler:    _Merlin_AddHostNote_Handler,
+		},
+		{
+			MethodName: "GetHost",
+			Handler:    _Merlin_GetHost_Handler,
+		},
+		{
+			MethodName: "ApproveRequest",
+			Handler:    _Merlin_ApproveRequest_Handler,
+		},
+		{
+			MethodName: "DenyRequest",
+			Handler:    _Merlin_DenyRequest_Handler,
+		},
+		{
+			MethodName: "GetPendingRequests",
+			Handler:    _Merlin_GetPendingRequests_Handler,
+		},
+		{
+			MethodName: "HostFile",
+			Handler:    _Merlin_HostFile_Handler,
+		},
+		{
+			MethodName: "UnhostFile",
+			Handler:    _Merlin_UnhostFile_Handler,
+		},
+		{
+			MethodName: "GetHostedFiles",
+			Handler:    _Merlin_GetHostedFiles_Handler,
+		},
+		{
+			MethodName: "GetHostedFileAccess",
+			Handler:    _Merlin_GetHostedFileAccess_Handler,
+		},
+		{
+			MethodName: "RegisterPayload",
+			Handler:    _Merlin_RegisterPayload_Handler,
+		},
+		{
+			MethodName: "GetPayloads",
+			Handler:    _Merlin_GetPayloads_Handler,
+		},
+		{
+			MethodName: "ExportPayloads",
+			Handler:    _Merlin_ExportPayloads_Handler,
+		},
+		{
+			MethodName: "RunPlugin",
+			Handler:    _Merlin_RunPlugin_Handler,
+		},
+		{
+			MethodName: "GetPlugins",
+			Handler:    _Merlin_GetPlugins_Handler,
+		},
+		{
+			MethodName: "ReloadPlugins",
+			Handler:    _Merlin_ReloadPlugins_Handler,
+		},
+		{
+			MethodName: "GetCommands",
+			Handler:    _Merlin_GetCommands_Handler,
+		},
+		{
+			MethodName: "ExportCommands",
+			Handler:    _Merlin_ExportCommands_Handler,
+		},
+		{
+			MethodName: "GetLocales",
+			Handler:    _Merlin_GetLocales_Handler,
+		},
+		{
+			MethodName: "ReloadLocales",
+			Handler:    _Merlin_ReloadLocales_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,79 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory is an in-memory repository for storing and retrieving scan results returned by Agents
+package memory
+
+import (
+	// Standard
+	"bytes"
+	"net"
+	"sort"
+	"sync"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/scan"
+)
+
+// Repository is the structure that implements the in-memory repository for scan results
+type Repository struct {
+	sync.RWMutex
+	// ports is keyed by host, port, and protocol so that rescanned ports are replaced with the latest result
+	ports map[string]scan.Port
+}
+
+// repo is the in-memory datastore
+var repo = &Repository{ports: make(map[string]scan.Port)}
+
+// NewRepository returns the in-memory repository for scan results
+func NewRepository() *Repository {
+	return repo
+}
+
+// Add stores the scan result in the repository, replacing any previous result for the same host, port, and protocol
+func (r *Repository) Add(port scan.Port) error {
+	r.Lock()
+	defer r.Unlock()
+	r.ports[port.Key()] = port
+	return nil
+}
+
+// GetAll returns all scan results sorted by host address and then port number
+func (r *Repository) GetAll() (all []scan.Port) {
+	r.RLock()
+	for _, port := range r.ports {
+		all = append(all, port)
+	}
+	r.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Host() != all[j].Host() {
+			a, b := net.ParseIP(all[i].Host()), net.ParseIP(all[j].Host())
+			if a != nil && b != nil {
+				return bytes.Compare(a.To16(), b.To16()) < 0
+			}
+			return all[i].Host() < all[j].Host()
+		}
+		if all[i].Number() != all[j].Number() {
+			return all[i].Number() < all[j].Number()
+		}
+		return all[i].Protocol() < all[j].Protocol()
+	})
+	return
+}
@@ -0,0 +1,106 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package scan holds the structures for network discovery results returned by an Agent's scan command
+package scan
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Port is a single host and port an Agent probed during a scan
+type Port struct {
+	host     string    // host is the IP address that was probed
+	port     int       // port is the port number that was probed
+	protocol string    // protocol is the transport protocol that was probed (e.g., tcp)
+	state    string    // state is the result of the probe (e.g., open, closed, filtered)
+	banner   string    // banner is any data the service returned when connected to
+	agentID  uuid.UUID // agentID is the Agent that performed the scan
+	jobID    string    // jobID is the scan job that returned the result
+	scanned  time.Time // scanned is when the result was received
+}
+
+// NewPort is a factory to create a scan result Port structure
+func NewPort(host string, port int, protocol, state, banner string, agentID uuid.UUID, jobID string) Port {
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	return Port{
+		host:     host,
+		port:     port,
+		protocol: strings.ToLower(protocol),
+		state:    strings.ToLower(state),
+		banner:   banner,
+		agentID:  agentID,
+		jobID:    jobID,
+		scanned:  time.Now().UTC(),
+	}
+}
+
+// AgentID returns the Agent that performed the scan
+func (p *Port) AgentID() uuid.UUID {
+	return p.agentID
+}
+
+// Banner returns any data the service returned when connected to
+func (p *Port) Banner() string {
+	return p.banner
+}
+
+// Host returns the IP address that was probed
+func (p *Port) Host() string {
+	return p.host
+}
+
+// JobID returns the scan job that returned the result
+func (p *Port) JobID() string {
+	return p.jobID
+}
+
+// Key uniquely identifies the host, port, and protocol combination
+func (p *Port) Key() string {
+	return fmt.Sprintf("%s:%d/%s", p.host, p.port, p.protocol)
+}
+
+// Number returns the port number that was probed
+func (p *Port) Number() int {
+	return p.port
+}
+
+// Protocol returns the transport protocol that was probed
+func (p *Port) Protocol() string {
+	return p.protocol
+}
+
+// Scanned returns when the result was received
+func (p *Port) Scanned() time.Time {
+	return p.scanned
+}
+
+// State returns the result of the probe (e.g., open, closed, filtered)
+func (p *Port) State() string {
+	return p.state
+}
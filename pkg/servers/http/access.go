@@ -0,0 +1,151 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Access log formats
+const (
+	accessCommon   = "common"   // The Common Log Format (CLF)
+	accessCombined = "combined" // The Combined Log Format that adds the Referer and User-Agent headers to the CLF
+)
+
+// accessLog writes an Apache style access log, separate from the Merlin server log, for every request the server
+// receives to review traffic, debug redirectors, and blend in with real web server logs
+type accessLog struct {
+	path   string // The access log file path; empty disables the access log
+	format string // The log format, common or combined
+	file   *os.File
+	logger *log.Logger
+}
+
+// parseAccessLog validates the access log file path and format. The file is opened when the server is generated
+func parseAccessLog(path, format string) (accessLog, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = accessCombined
+	}
+	if format != accessCommon && format != accessCombined {
+		return accessLog{}, fmt.Errorf("pkg/servers/http.parseAccessLog(): invalid access log format %s, valid formats are: %s, %s", format, accessCommon, accessCombined)
+	}
+	if path != "" {
+		if _, err := os.Stat(filepath.Dir(path)); err != nil {
+			return accessLog{}, fmt.Errorf("pkg/servers/http.parseAccessLog(): the access log directory for %s is not usable: %s", path, err)
+		}
+	}
+	return accessLog{path: path, format: format}, nil
+}
+
+// open opens the access log file for appending
+func (a *accessLog) open() error {
+	if a.path == "" || a.file != nil {
+		return nil
+	}
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 Users can include any file they want
+	if err != nil {
+		return fmt.Errorf("pkg/servers/http.open(): there was an error opening the access log %s: %s", a.path, err)
+	}
+	a.file = file
+	a.logger = log.New(file, "", 0)
+	return nil
+}
+
+// close closes the access log file
+func (a *accessLog) close() error {
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	a.logger = nil
+	return err
+}
+
+// wrap returns an HTTP handler that writes an access log entry after the next handler responds.
+// The client address is the real client when the request came through a trusted redirector
+func (a *accessLog) wrap(next http.Handler, trusted trustedProxies) http.Handler {
+	logger := a.logger
+	if logger == nil {
+		return next
+	}
+	combined := a.format == accessCombined
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		size := "-"
+		if recorder.size > 0 {
+			size = strconv.Itoa(recorder.size)
+		}
+		user := "-"
+		if username, _, ok := r.BasicAuth(); ok && username != "" {
+			user = username
+		}
+		entry := fmt.Sprintf("%s - %s [%s] \"%s %s %s\" %d %s",
+			trusted.clientIP(r), user, time.Now().Format("02/Jan/2006:15:04:05 -0700"), r.Method, r.RequestURI, r.Proto, recorder.status, size)
+		if combined {
+			referer, agent := r.Referer(), r.UserAgent()
+			if referer == "" {
+				referer = "-"
+			}
+			if agent == "" {
+				agent = "-"
+			}
+			entry += fmt.Sprintf(" %q %q", referer, agent)
+		}
+		logger.Println(entry)
+	})
+}
+
+// statusRecorder is an HTTP response writer that records the status code and number of body bytes written
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+// WriteHeader records the status code before sending it
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the number of bytes written
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	n, err := s.ResponseWriter.Write(b)
+	s.size += n
+	return n, err
+}
+
+// Unwrap returns the original response writer for use with http.ResponseController
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
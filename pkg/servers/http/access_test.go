@@ -0,0 +1,113 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestParseAccessLog(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		path   string
+		format string
+		want   string
+		err    bool
+	}{
+		{"disabled", "", "", accessCombined, false},
+		{"default format", filepath.Join(dir, "access.log"), "", accessCombined, false},
+		{"common", filepath.Join(dir, "access.log"), " Common ", accessCommon, false},
+		{"invalid format", filepath.Join(dir, "access.log"), "json", "", true},
+		{"missing directory", filepath.Join(dir, "missing", "access.log"), "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := parseAccessLog(test.path, test.format)
+			if test.err {
+				if err == nil {
+					t.Error("expected an error parsing the access log options")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.format != test.want {
+				t.Errorf("expected the %s format, have %s", test.want, a.format)
+			}
+		})
+	}
+}
+
+// TestAccessLogWrap verifies an entry is written for each request in the configured format
+func TestAccessLogWrap(t *testing.T) {
+	tests := []struct {
+		format string
+		status int
+		body   string
+		want   string
+	}{
+		{accessCommon, http.StatusNotFound, "", `^192\.0\.2\.1 - - \[[^]]+\] "GET /index\.html HTTP/1\.1" 404 -\n$`},
+		{accessCombined, http.StatusOK, "merlin", `^192\.0\.2\.1 - - \[[^]]+\] "GET /index\.html HTTP/1\.1" 200 6 "https://example\.com/" "Mozilla/5\.0"\n$`},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "access.log")
+			a, err := parseAccessLog(path, test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = a.open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			handler := a.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.status != http.StatusOK {
+					w.WriteHeader(test.status)
+				}
+				_, _ = w.Write([]byte(test.body))
+			}), trustedProxies{})
+			r := httptest.NewRequest(http.MethodGet, "/index.html", nil)
+			r.Header.Set("Referer", "https://example.com/")
+			r.Header.Set("User-Agent", "Mozilla/5.0")
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			err = a.close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !regexp.MustCompile(test.want).Match(entry) {
+				t.Errorf("the access log entry %q does not match %s", entry, test.want)
+			}
+		})
+	}
+}
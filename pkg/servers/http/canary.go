@@ -0,0 +1,84 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/geoip"
+)
+
+// canaryBodyLimit is the maximum number of request body bytes recorded for a canary alert
+const canaryBodyLimit = 4096
+
+// tripCanary records every detail of a request to a canary listener and alerts clients.
+// Canary listeners never process Agent traffic; any request means the infrastructure, or a payload configured with it,
+// has been discovered and is being probed
+func (h *Handler) tripCanary(w http.ResponseWriter, r *http.Request, client string) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, canaryBodyLimit))
+	if err != nil {
+		slog.Debug(fmt.Sprintf("there was an error reading the canary request body: %s", err))
+	}
+
+	location := "unknown"
+	if geoip.Enabled() {
+		if l, err := geoip.Lookup(client); err == nil {
+			location = l.String()
+		}
+	}
+
+	attrs := []any{
+		"listener", h.listener,
+		"client", client,
+		"location", location,
+		"remote address", r.RemoteAddr,
+		"method", r.Method,
+		"host", r.Host,
+		"uri", r.RequestURI,
+		"protocol", r.Proto,
+		"headers", r.Header,
+		"content length", r.ContentLength,
+		"body", fmt.Sprintf("%x", body),
+	}
+	if r.TLS != nil {
+		attrs = append(attrs,
+			"tls server name", r.TLS.ServerName,
+			"tls negotiated protocol", r.TLS.NegotiatedProtocol,
+			"tls cipher suite", r.TLS.CipherSuite,
+		)
+	}
+	slog.Warn("canary listener received a request", attrs...)
+
+	msg := fmt.Sprintf("Canary listener %s received a %s %s request for %s%s from %s (%s) with User-Agent %q at %s",
+		h.listener, r.Proto, r.Method, r.Host, r.RequestURI, client, location, r.UserAgent(), time.Now().UTC().Format(time.RFC3339))
+	memory.NewRepository().Add(message.NewMessage(message.Warn, msg))
+
+	// Look like any other web server without any content
+	w.WriteHeader(404)
+}
@@ -0,0 +1,75 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+)
+
+// TestCanary verifies every request to a canary listener is answered with a 404 and alerts clients
+func TestCanary(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		uri    string
+		body   string
+	}{
+		{"GET", http.MethodGet, "/", ""},
+		{"POST", http.MethodPost, "/news.php", "agent message"},
+		{"PUT", http.MethodPut, "/upload?file=test", "data"},
+	}
+	h := &Handler{listener: uuid.New(), canary: true}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userAgent := "canary-" + uuid.NewString()
+			r := httptest.NewRequest(test.method, test.uri, strings.NewReader(test.body))
+			r.Header.Set("User-Agent", userAgent)
+			w := httptest.NewRecorder()
+			h.agentHandler(w, r)
+
+			if w.Code != 404 {
+				t.Errorf("expected a 404 response, have %d", w.Code)
+			}
+			var alerted bool
+			for _, m := range memory.NewRepository().GetAll() {
+				if strings.Contains(m.Message(), userAgent) {
+					alerted = true
+					if !strings.Contains(m.Message(), test.method+" request for example.com"+test.uri) {
+						t.Errorf("expected the alert to include the request, have %q", m.Message())
+					}
+				}
+			}
+			if !alerted {
+				t.Error("expected clients to be alerted about the request")
+			}
+		})
+	}
+}
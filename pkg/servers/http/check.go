@@ -0,0 +1,57 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+// Check resolves the server's configuration the way starting it would, without serving anything, so that mistakes are
+// caught before the server is created. The address is bound and released, selecting the port when it is 0 or a range,
+// and the x.509 certificate is loaded so its fingerprint is reported by ConfiguredOptions(). The returned notes describe
+// what will happen when the server starts that the operator may not expect
+func (s *Server) Check() (notes []string, err error) {
+	err = s.Reserve()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/servers/http.Check(): %s", err)
+	}
+	if s.protocol != servers.HTTPS && s.protocol != servers.HTTP2 && s.protocol != servers.HTTP3 {
+		return
+	}
+	certificates, err := GetTLSCertificates(s.x509Cert, s.x509Key)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("The x.509 certificate was not found at %s; an in-memory certificate is generated when the server starts and Agents that pin its fingerprint must be regenerated after every restart", s.x509Cert))
+		return notes, nil
+	}
+	s.pin = fingerprint(*certificates)
+	insecure, err := CheckInsecureFingerprint(*certificates)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/servers/http.Check(): %s", err)
+	}
+	if insecure {
+		notes = append(notes, "The certificate is Merlin's publicly distributed testing certificate; pinning it does not protect Agent traffic from TLS interception")
+	}
+	return notes, nil
+}
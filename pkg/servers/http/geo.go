@@ -0,0 +1,76 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/geoip"
+)
+
+// countryFilter is a list of ISO 3166-1 country codes that Agent traffic is accepted from based on a GeoIP lookup of
+// the client's address. An empty filter accepts traffic from everywhere
+type countryFilter []string
+
+// parseCountryFilter parses a comma-separated list of two-character country codes (e.g., US,CA)
+func parseCountryFilter(value string) (countryFilter, error) {
+	var countries countryFilter
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.ToUpper(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if len(entry) != 2 {
+			return nil, fmt.Errorf("pkg/servers/http.parseCountryFilter(): invalid two-character ISO country code: %s", entry)
+		}
+		countries = append(countries, entry)
+	}
+	if len(countries) > 0 && !geoip.Enabled() {
+		return nil, fmt.Errorf("pkg/servers/http.parseCountryFilter(): filtering by country requires the Merlin server to be started with a GeoIP database")
+	}
+	return countries, nil
+}
+
+// String returns the country filter as a comma-separated list
+func (c countryFilter) String() string {
+	return strings.Join(c, ",")
+}
+
+// allowed determines if traffic from the client address is accepted. When the filter is in use, addresses that are not
+// in the GeoIP database, such as private addresses, are not accepted
+func (c countryFilter) allowed(client string) (bool, string) {
+	if len(c) == 0 {
+		return true, ""
+	}
+	location, err := geoip.Lookup(client)
+	if err != nil {
+		return false, "unknown"
+	}
+	for _, country := range c {
+		if location.Country == country {
+			return true, location.Country
+		}
+	}
+	return false, location.String()
+}
@@ -0,0 +1,81 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"testing"
+)
+
+// TestParseCountryFilter verifies country codes are normalized and a filter is refused without a GeoIP database
+func TestParseCountryFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		err   bool
+	}{
+		{"empty", "", "", false},
+		{"only separators", " , ", "", false},
+		{"country without a database", "US", "", true},
+		{"invalid code", "USA", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			countries, err := parseCountryFilter(test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error for %q, have %s", test.value, countries)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if countries.String() != test.want {
+				t.Errorf("expected %q, have %q", test.want, countries.String())
+			}
+		})
+	}
+}
+
+// TestAllowed verifies an empty filter accepts everyone and addresses that can not be located are rejected
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		countries countryFilter
+		client    string
+		allowed   bool
+	}{
+		{"no filter", nil, "192.0.2.10", true},
+		{"no filter invalid address", nil, "merlin", true},
+		{"unknown location", countryFilter{"US"}, "10.0.0.1", false},
+		{"invalid address", countryFilter{"US"}, "merlin", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, _ := test.countries.allowed(test.client)
+			if allowed != test.allowed {
+				t.Errorf("expected allowed to be %t, have %t", test.allowed, allowed)
+			}
+		})
+	}
+}
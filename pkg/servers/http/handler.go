@@ -24,11 +24,12 @@ import (
 	"context"
 	// Standard
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
-	"io"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -39,22 +40,31 @@ import (
 	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
 	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
 	"github.com/Ne0nd0g/merlin/v2/pkg/core"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
 	message2 "github.com/Ne0nd0g/merlin/v2/pkg/services/message"
 )
 
 // Handler contains contextual information and methods to process HTTP traffic for Agents
 type Handler struct {
-	jwtKey    []byte        // The password used by the server to create JWTs
-	jwtLeeway time.Duration // The amount of flexibility in validating the JWT's expiration time. Less than 0 will disable the expiration check
-	listener  uuid.UUID
-	psk       []byte // The Pre-Shared Key that the listener was created with; Unauthenticated agent's encrypt their JWT with this
+	jwtKey      []byte        // The password used by the server to create JWTs
+	jwtLeeway   time.Duration // The amount of flexibility in validating the JWT's expiration time. Less than 0 will disable the expiration check
+	listener    uuid.UUID
+	pool        uriPool          // The pool of check-in URIs and their rotation schedule
+	trusted     trustedProxies   // Redirectors whose forwarding headers are trusted to contain the real client address
+	countries   countryFilter    // The countries Agent traffic is accepted from
+	canary      bool             // Record and alert on every request instead of processing Agent traffic
+	methods     []string         // The HTTP methods Agent traffic is accepted with
+	transport   messageTransport // Where the Agent message is in the request
+	psk         []byte           // The Pre-Shared Key that the listener was created with; Unauthenticated agent's encrypt their JWT with this
+	fingerprint string           // The SHA256 fingerprint of the server's x.509 certificate that Agents can pin
 }
 
 // agentHandler implements the HTTP Handler interface and processes HTTP traffic for agents
 // HTTP validation checks are performed here such as JSON Web Token authentication, HTTP headers, HTTP methods, and User-Agent
 // The actual HTTP payload data that contains the Agent message is not handled here. It is sent to the listener service to process
 func (h *Handler) agentHandler(w http.ResponseWriter, r *http.Request) {
-	slog.Debug("New HTTP connection", "protocol", r.Proto, "method", r.Method, "remote address", r.RemoteAddr)
+	client := h.trusted.clientIP(r)
+	slog.Debug("New HTTP connection", "protocol", r.Proto, "method", r.Method, "remote address", r.RemoteAddr, "client", client)
 
 	if r.TLS != nil {
 		slog.Log(context.Background(), logging.LevelExtraDebug, "HTTP Connection Details",
@@ -79,20 +89,39 @@ func (h *Handler) agentHandler(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 
-	// Merlin only accepts/handles HTTP POST messages
-	if r.Method != http.MethodPost {
+	// Canary listeners never process Agent traffic
+	if h.canary {
+		h.tripCanary(w, r, client)
+		return
+	}
+
+	// Only accept the HTTP methods Agents are configured to use; POST by default
+	if !slices.Contains(h.methods, r.Method) {
 		w.WriteHeader(404)
 		return
 	}
 
+	// Only accept traffic from the configured countries, such as the target's, to avoid sandboxes in cloud provider ranges
+	if ok, location := h.countries.allowed(client); !ok {
+		slog.Info("rejected HTTP request from a client outside the GeoIP country filter", "client", client, "location", location, "countries", h.countries.String())
+		w.WriteHeader(404)
+		return
+	}
+
+	// Requests to any URI in the pool are accepted, but note those that don't follow the rotation schedule
+	if !h.pool.onSchedule(r.URL.Path, time.Now()) {
+		slog.Log(context.Background(), logging.LevelExtraDebug, "request URI did not match the rotation schedule", "uri", r.URL.Path, "scheduled", h.pool.scheduled(time.Now()))
+	}
+
 	// Check for Merlin PRISM activity
 	if r.UserAgent() == "Mozilla/5.0 (Windows NT 6.1; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/40.0.2214.85 Safari/537.36 " {
-		msg := fmt.Sprintf("Someone from %s is attempting to fingerprint this Merlin server", r.RemoteAddr)
+		msg := fmt.Sprintf("Someone from %s is attempting to fingerprint this Merlin server", client)
 		slog.Warn(msg)
 	}
 
 	// Make sure the content type is: application/octet-stream; charset=utf-8
-	if r.Header.Get("Content-Type") != "application/octet-stream; charset=utf-8" {
+	// Requests without a body, such as a GET check-in, don't have a content type
+	if r.ContentLength != 0 && r.Header.Get("Content-Type") != "application/octet-stream; charset=utf-8" {
 		if core.Verbose {
 			msg := "incoming request did not contain a Content-Type header of: application/octet-stream; charset=utf-8"
 			slog.Warn(msg)
@@ -108,10 +137,16 @@ func (h *Handler) agentHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	//Read the request message until EOF
-	data, err := io.ReadAll(r.Body)
+	// Agents that pin the server's certificate report the connections they refused once they reach the real server
+	if report := r.Header.Get(pinHeader); report != "" {
+		h.pinMismatch(agentID, client, report)
+	}
+
+	// Read the request message from the body, cookies, or headers
+	data, err := h.transport.read(r)
 	if err != nil {
-		slog.Error(fmt.Sprintf("There was an error reading a POST message sent by an agent: %s", err))
+		slog.Error(fmt.Sprintf("There was an error reading a %s message sent by an agent: %s", r.Method, err))
+		w.WriteHeader(404)
 		return
 	}
 
@@ -124,16 +159,36 @@ func (h *Handler) agentHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Handle the incoming data
-	rdata, err := ms.Handle(agentID, data)
+	rdata, err := ms.Handle(message2.WithSource(r.Context(), client), agentID, data)
+	if errors.Is(err, message2.ErrListenerDraining) || errors.Is(err, message2.ErrReplay) {
+		w.WriteHeader(404)
+		return
+	}
 	if err != nil {
 		slog.Error(fmt.Sprintf("There was an error handling the incoming data: %s", err))
 		w.WriteHeader(500)
 		return
 	}
 
+	// Traffic from a cloned copy of the implant is attributed to the Agent it was forked into
+	agentID = agent.NewAgentService().Routed(agentID, client)
+
+	// Record where the Agent's traffic originated from; the Agent won't exist if it failed to authenticate
+	err = agent.NewAgentService().UpdateRemoteAddress(agentID, client)
+	if err != nil {
+		slog.Debug(fmt.Sprintf("there was an error updating the remote address for Agent %s (this is OK): %s", agentID, err))
+	}
+
 	// Set return headers
 	w.Header().Set("Content-Type", "application/octet-stream")
-	n, err := w.Write(rdata)
+
+	// Pace the response for Agents with a bandwidth limit
+	var n int
+	if a, errA := agent.NewAgentService().Agent(agentID); errA == nil && a.Throttle() > 0 {
+		n, err = writeThrottled(w, r, rdata, a.Throttle())
+	} else {
+		n, err = w.Write(rdata)
+	}
 	if err != nil {
 		slog.Error(fmt.Sprintf("There was an error writing the HTTP response bytes: %s", err))
 		return
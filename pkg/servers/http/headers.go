@@ -0,0 +1,124 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// responseHeaders are the HTTP headers added to every response the server sends so the listener can present itself
+// consistently with a decoy site (e.g., IIS, nginx, or Apache) instead of Go's default header fingerprint
+type responseHeaders struct {
+	global map[string]string            // Headers added to every response
+	uri    map[string]map[string]string // Headers added to responses for a specific URI; overrides global headers
+}
+
+// parseHeaders parses a pipe-delimited list of "Name: value" HTTP headers
+// (e.g., Server: Microsoft-IIS/10.0|X-Powered-By: ASP.NET)
+func parseHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	if value == "" {
+		return headers, nil
+	}
+	for _, header := range strings.Split(value, "|") {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		name, v, found := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("pkg/servers/http.parseHeaders(): the header \"%s\" is not in the \"Name: value\" format", header)
+		}
+		headers[http.CanonicalHeaderKey(name)] = strings.TrimSpace(v)
+	}
+	return headers, nil
+}
+
+// parseURIHeaders parses a JSON object of URIs to the headers added to their responses
+// (e.g., {"/login": {"Set-Cookie": "ASP.NET_SessionId=abc; path=/; HttpOnly"}})
+func parseURIHeaders(value string) (map[string]map[string]string, error) {
+	headers := make(map[string]map[string]string)
+	if value == "" {
+		return headers, nil
+	}
+	var in map[string]map[string]string
+	err := json.Unmarshal([]byte(value), &in)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/servers/http.parseURIHeaders(): there was an error parsing the URI headers JSON: %s", err)
+	}
+	for uri, h := range in {
+		if !strings.HasPrefix(uri, "/") {
+			return nil, fmt.Errorf("pkg/servers/http.parseURIHeaders(): the URI \"%s\" must start with a \"/\"", uri)
+		}
+		headers[uri] = make(map[string]string, len(h))
+		for name, v := range h {
+			headers[uri][http.CanonicalHeaderKey(name)] = v
+		}
+	}
+	return headers, nil
+}
+
+// String returns the global headers in the same pipe-delimited format they are configured with
+func (r responseHeaders) String() string {
+	var headers []string
+	for name, value := range r.global {
+		headers = append(headers, fmt.Sprintf("%s: %s", name, value))
+	}
+	sort.Strings(headers)
+	return strings.Join(headers, "|")
+}
+
+// URIString returns the per-URI headers as a JSON object
+func (r responseHeaders) URIString() string {
+	if len(r.uri) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(r.uri)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// wrap returns an HTTP handler that adds the configured headers to the response before calling the next handler
+func (r responseHeaders) wrap(next http.Handler) http.Handler {
+	if len(r.global) == 0 && len(r.uri) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		for name, value := range r.global {
+			w.Header().Set(name, value)
+		}
+		for uri, headers := range r.uri {
+			if match(uri, req.URL.Path) {
+				for name, value := range headers {
+					w.Header().Set(name, value)
+				}
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"empty", "", map[string]string{}, false},
+		{"canonical", "server: Microsoft-IIS/10.0|x-powered-by:ASP.NET", map[string]string{"Server": "Microsoft-IIS/10.0", "X-Powered-By": "ASP.NET"}, false},
+		{"value with colon", "Location: https://example.com/", map[string]string{"Location": "https://example.com/"}, false},
+		{"blank entry", "Server: nginx||", map[string]string{"Server": "nginx"}, false},
+		{"missing colon", "Server nginx", nil, true},
+		{"missing name", ": nginx", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseHeaders(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("expected %v, have %v", test.want, got)
+			}
+			for name, value := range test.want {
+				if got[name] != value {
+					t.Errorf("expected %s to be %q, have %q", name, value, got[name])
+				}
+			}
+		})
+	}
+}
+
+func TestParseURIHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		uri     string
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", "", "", false},
+		{"canonical", `{"/login":{"set-cookie":"id=1"}}`, "/login", "Set-Cookie", "id=1", false},
+		{"relative", `{"login":{"Server":"nginx"}}`, "", "", "", true},
+		{"invalid JSON", `{"/login":`, "", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseURIHeaders(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.uri != "" && got[test.uri][test.header] != test.want {
+				t.Errorf("expected %s %s to be %q, have %q", test.uri, test.header, test.want, got[test.uri][test.header])
+			}
+		})
+	}
+}
+
+func TestResponseHeadersString(t *testing.T) {
+	r := responseHeaders{global: map[string]string{"X-Powered-By": "ASP.NET", "Server": "Microsoft-IIS/10.0"}}
+	if want := "Server: Microsoft-IIS/10.0|X-Powered-By: ASP.NET"; r.String() != want {
+		t.Errorf("expected %q, have %q", want, r.String())
+	}
+	if r.URIString() != "" {
+		t.Errorf("expected no URI headers, have %q", r.URIString())
+	}
+	r.uri = map[string]map[string]string{"/login": {"Set-Cookie": "id=1"}}
+	if want := `{"/login":{"Set-Cookie":"id=1"}}`; r.URIString() != want {
+		t.Errorf("expected %q, have %q", want, r.URIString())
+	}
+}
+
+func TestWrap(t *testing.T) {
+	r := responseHeaders{
+		global: map[string]string{"Server": "Microsoft-IIS/10.0"},
+		uri: map[string]map[string]string{
+			"/login":    {"Server": "nginx", "Set-Cookie": "id=1"},
+			"/static/*": {"Cache-Control": "max-age=3600"},
+		},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		path    string
+		headers map[string]string
+	}{
+		{"/", map[string]string{"Server": "Microsoft-IIS/10.0", "Set-Cookie": "", "Cache-Control": ""}},
+		{"/login", map[string]string{"Server": "nginx", "Set-Cookie": "id=1"}},
+		{"/static/app.js", map[string]string{"Server": "Microsoft-IIS/10.0", "Cache-Control": "max-age=3600"}},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.wrap(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+			for name, value := range test.headers {
+				if got := w.Header().Get(name); got != value {
+					t.Errorf("expected %s to be %q, have %q", name, value, got)
+				}
+			}
+		})
+	}
+}
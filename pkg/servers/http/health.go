@@ -0,0 +1,65 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// healthCheck is a benign URI for load balancer health checks (e.g., AWS ALB/NLB) that returns decoy content without
+// reaching the Agent message handler, the rate limiter, or the access log
+type healthCheck struct {
+	uri     string // The health check URI; empty disables the health check
+	content string // The decoy content returned with a 200 OK
+}
+
+// parseHealthCheck validates the health check URI
+func parseHealthCheck(uri, content string) (healthCheck, error) {
+	if uri != "" && !strings.HasPrefix(uri, "/") {
+		return healthCheck{}, fmt.Errorf("pkg/servers/http.parseHealthCheck(): the health check URI %s must start with a /", uri)
+	}
+	return healthCheck{uri: uri, content: content}, nil
+}
+
+// wrap returns an HTTP handler that answers health checks and passes every other request to the next handler
+func (h healthCheck) wrap(next http.Handler) http.Handler {
+	if h.uri == "" {
+		return next
+	}
+	contentType := "text/plain; charset=utf-8"
+	if strings.HasPrefix(strings.TrimSpace(h.content), "<") {
+		contentType = "text/html; charset=utf-8"
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != h.uri || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(h.content))
+		}
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseHealthCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		err  bool
+	}{
+		{"disabled", "", false},
+		{"path", "/healthz", false},
+		{"relative", "healthz", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseHealthCheck(test.uri, "OK")
+			if (err != nil) != test.err {
+				t.Errorf("expected error to be %t, have %v", test.err, err)
+			}
+		})
+	}
+}
+
+// TestHealthCheckWrap verifies health checks are answered with the decoy content and all other requests are passed on
+func TestHealthCheckWrap(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		method      string
+		path        string
+		body        string
+		contentType string
+	}{
+		{"GET", "OK", http.MethodGet, "/healthz", "OK", "text/plain; charset=utf-8"},
+		{"HTML", "<html>OK</html>", http.MethodGet, "/healthz", "<html>OK</html>", "text/html; charset=utf-8"},
+		{"HEAD", "OK", http.MethodHead, "/healthz", "", "text/plain; charset=utf-8"},
+		{"POST", "OK", http.MethodPost, "/healthz", "next", ""},
+		{"other path", "OK", http.MethodGet, "/", "next", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			health, err := parseHealthCheck("/healthz", test.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			handler := health.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("next"))
+			}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+			if w.Body.String() != test.body {
+				t.Errorf("expected the body %q, have %q", test.body, w.Body.String())
+			}
+			if test.contentType == "" {
+				if w.Code != http.StatusNotFound {
+					t.Errorf("expected the request to be passed to the next handler, have status %d", w.Code)
+				}
+				return
+			}
+			if w.Code != http.StatusOK {
+				t.Errorf("expected a 200 OK, have %d", w.Code)
+			}
+			if w.Header().Get("Content-Type") != test.contentType {
+				t.Errorf("expected the content type %s, have %s", test.contentType, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// X Packages
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+)
+
+// maxHostedAccesses is the number of the most recent requests kept for each hosted file
+const maxHostedAccesses = 100
+
+// HostedFile is a file served from memory by an HTTP server at an operator chosen URI
+type HostedFile struct {
+	URI         string         // The URI path the file is served from
+	Name        string         // The name of the file that is hosted
+	ContentType string         // The Content-Type header returned with the file
+	Size        int            // The size of the file in bytes
+	Once        bool           // Remove the file after it has been downloaded one time
+	Expires     time.Time      // When the file stops being served; the zero value never expires
+	UserAgent   string         // A regular expression the request's User-Agent must match; empty allows any
+	HeaderName  string         // The request header that must contain the HeaderValue token; empty allows any
+	HeaderValue string         // The token the HeaderName request header must contain
+	Downloads   int            // The number of times the file has been downloaded
+	Blocked     int            // The number of requests for the file that were refused by the User-Agent or header gates
+	Created     time.Time      // When the file was hosted
+	Accesses    []HostedAccess // The most recent requests for the file, oldest first
+	data        []byte
+	agent       *regexp.Regexp
+}
+
+// HostedAccess is a single request for a hosted file
+type HostedAccess struct {
+	Time      time.Time // When the request was received
+	Client    string    // The client IP address, taken from trusted proxy headers when configured
+	Method    string    // The HTTP method
+	UserAgent string    // The request's User-Agent header
+	Allowed   bool      // True if the file was served
+	Reason    string    // Why the request was refused
+}
+
+// Gated returns true if requests must pass a User-Agent or header check to download the file
+func (f *HostedFile) Gated() bool {
+	return f.UserAgent != "" || f.HeaderName != ""
+}
+
+// allow returns an empty string if the request passes the hosted file's User-Agent and header gates, otherwise the
+// reason it was refused
+func (f *HostedFile) allow(r *http.Request) string {
+	if f.agent != nil && !f.agent.MatchString(r.UserAgent()) {
+		return "User-Agent did not match"
+	}
+	if f.HeaderName != "" {
+		value := r.Header.Get(f.HeaderName)
+		if value == "" {
+			return fmt.Sprintf("missing %s header", f.HeaderName)
+		}
+		if subtle.ConstantTimeCompare([]byte(value), []byte(f.HeaderValue)) != 1 {
+			return fmt.Sprintf("invalid %s header token", f.HeaderName)
+		}
+	}
+	return ""
+}
+
+// record adds the request to the hosted file's access history, dropping the oldest entry once the history is full.
+// Allowed HEAD requests are recorded but do not count as a download.
+func (f *HostedFile) record(access HostedAccess) {
+	switch {
+	case !access.Allowed:
+		f.Blocked++
+	case access.Method == http.MethodGet:
+		f.Downloads++
+	}
+	f.Accesses = append(f.Accesses, access)
+	if len(f.Accesses) > maxHostedAccesses {
+		f.Accesses = f.Accesses[len(f.Accesses)-maxHostedAccesses:]
+	}
+}
+
+// Expired returns true if the hosted file has an expiration time and it has passed
+func (f *HostedFile) Expired() bool {
+	return !f.Expires.IsZero() && time.Now().After(f.Expires)
+}
+
+// hostedFiles holds the files hosted by every HTTP server, keyed by the server ID and then the URI
+var hostedFiles = struct {
+	sync.Mutex
+	servers map[uuid.UUID]map[string]*HostedFile
+}{servers: make(map[uuid.UUID]map[string]*HostedFile)}
+
+// Host serves the data from memory at the URI on the HTTP server with the provided ID. An existing file hosted at the
+// same URI is replaced.
+func Host(server uuid.UUID, file HostedFile, data []byte) (HostedFile, error) {
+	if !strings.HasPrefix(file.URI, "/") {
+		return HostedFile{}, fmt.Errorf("pkg/servers/http.Host(): the URI %s must start with a /", file.URI)
+	}
+	if len(data) == 0 {
+		return HostedFile{}, fmt.Errorf("pkg/servers/http.Host(): the file %s is empty", file.Name)
+	}
+	if (file.HeaderName == "") != (file.HeaderValue == "") {
+		return HostedFile{}, fmt.Errorf("pkg/servers/http.Host(): both a header name and token value are required to gate downloads by header")
+	}
+	if file.UserAgent != "" {
+		agent, err := regexp.Compile(file.UserAgent)
+		if err != nil {
+			return HostedFile{}, fmt.Errorf("pkg/servers/http.Host(): there was an error compiling the User-Agent regular expression %s: %s", file.UserAgent, err)
+		}
+		file.agent = agent
+	}
+	if file.ContentType == "" {
+		file.ContentType = http.DetectContentType(data)
+	}
+	file.Size = len(data)
+	file.Downloads = 0
+	file.Blocked = 0
+	file.Accesses = nil
+	file.Created = time.Now().UTC()
+	file.data = data
+
+	hostedFiles.Lock()
+	defer hostedFiles.Unlock()
+	if _, ok := hostedFiles.servers[server]; !ok {
+		hostedFiles.servers[server] = make(map[string]*HostedFile)
+	}
+	hostedFiles.servers[server][file.URI] = &file
+	return file, nil
+}
+
+// Unhost stops serving the file at the URI on the HTTP server with the provided ID
+func Unhost(server uuid.UUID, uri string) error {
+	hostedFiles.Lock()
+	defer hostedFiles.Unlock()
+	if _, ok := hostedFiles.servers[server][uri]; !ok {
+		return fmt.Errorf("pkg/servers/http.Unhost(): a file is not hosted at %s on server %s", uri, server)
+	}
+	delete(hostedFiles.servers[server], uri)
+	if len(hostedFiles.servers[server]) == 0 {
+		delete(hostedFiles.servers, server)
+	}
+	return nil
+}
+
+// Hosted returns the files hosted by the HTTP server with the provided ID, sorted by URI, without their contents or
+// access history. Expired files are removed and not returned.
+func Hosted(server uuid.UUID) (files []HostedFile) {
+	hostedFiles.Lock()
+	defer hostedFiles.Unlock()
+	for uri, file := range hostedFiles.servers[server] {
+		if file.Expired() {
+			delete(hostedFiles.servers[server], uri)
+			continue
+		}
+		f := *file
+		f.data = nil
+		f.Accesses = nil
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].URI < files[j].URI })
+	return
+}
+
+// Accesses returns the most recent requests for the file hosted at the URI on the HTTP server with the provided ID,
+// oldest first
+func Accesses(server uuid.UUID, uri string) ([]HostedAccess, error) {
+	hostedFiles.Lock()
+	defer hostedFiles.Unlock()
+	file, ok := hostedFiles.servers[server][uri]
+	if !ok {
+		return nil, fmt.Errorf("pkg/servers/http.Accesses(): a file is not hosted at %s on server %s", uri, server)
+	}
+	return append([]HostedAccess(nil), file.Accesses...), nil
+}
+
+// take checks the request against the file hosted at its URI and records the access. A copy of the file is returned
+// with the reason the request was refused, if any. One-time files are removed once downloaded.
+func take(server uuid.UUID, r *http.Request, client string) (HostedFile, string, bool) {
+	hostedFiles.Lock()
+	defer hostedFiles.Unlock()
+	file, ok := hostedFiles.servers[server][r.URL.Path]
+	if !ok {
+		return HostedFile{}, "", false
+	}
+	if file.Expired() {
+		delete(hostedFiles.servers[server], r.URL.Path)
+		return HostedFile{}, "", false
+	}
+	reason := file.allow(r)
+	access := HostedAccess{
+		Time:      time.Now().UTC(),
+		Client:    client,
+		Method:    r.Method,
+		UserAgent: r.UserAgent(),
+		Allowed:   reason == "",
+		Reason:    reason,
+	}
+	file.record(access)
+	if access.Allowed && file.Once && r.Method == http.MethodGet {
+		delete(hostedFiles.servers[server], r.URL.Path)
+	}
+	return *file, reason, true
+}
+
+// hosted returns an HTTP handler that serves hosted files and passes every other request to the next handler.
+// Requests refused by a file's User-Agent or header gate are also passed to the next handler so that they receive the
+// same response as any other unknown URI.
+func (s *Server) hosted(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		client := s.trusted.clientIP(r)
+		file, reason, ok := take(s.id, r, client)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if reason != "" {
+			msg := fmt.Sprintf("Refused request for hosted file %s at %s from %s with User-Agent '%s': %s", file.Name, file.URI, client, r.UserAgent(), reason)
+			slog.Warn(msg)
+			memory.NewRepository().Add(message.NewMessage(message.Warn, msg))
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", file.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(file.Size))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = w.Write(file.data)
+
+		msg := fmt.Sprintf("Hosted file %s was downloaded from %s by %s with User-Agent '%s'", file.Name, file.URI, client, r.UserAgent())
+		if file.Once {
+			msg += " and is no longer hosted"
+		}
+		slog.Info(msg)
+		memory.NewRepository().Add(message.NewMessage(message.Note, msg))
+	})
+}
@@ -0,0 +1,229 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	// X Packages
+	"github.com/google/uuid"
+)
+
+// TestHost verifies hosted files are validated and that a file hosted again at the same URI replaces the original
+func TestHost(t *testing.T) {
+	server := uuid.New()
+	t.Cleanup(func() {
+		for _, file := range Hosted(server) {
+			_ = Unhost(server, file.URI)
+		}
+	})
+	tests := []struct {
+		name        string
+		file        HostedFile
+		data        []byte
+		contentType string
+		err         bool
+	}{
+		{"detected content type", HostedFile{URI: "/a", Name: "a.html"}, []byte("<html></html>"), "text/html; charset=utf-8", false},
+		{"provided content type", HostedFile{URI: "/b", Name: "b", ContentType: "application/octet-stream"}, []byte("MZ"), "application/octet-stream", false},
+		{"replaced", HostedFile{URI: "/a", Name: "a.txt"}, []byte("plain"), "text/plain; charset=utf-8", false},
+		{"relative URI", HostedFile{URI: "a", Name: "a"}, []byte("a"), "", true},
+		{"empty file", HostedFile{URI: "/c", Name: "c"}, nil, "", true},
+		{"header without token", HostedFile{URI: "/d", Name: "d", HeaderName: "X-Token"}, []byte("d"), "", true},
+		{"invalid User-Agent expression", HostedFile{URI: "/e", Name: "e", UserAgent: "("}, []byte("e"), "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := Host(server, test.file, test.data)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %t, have %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if file.ContentType != test.contentType || file.Size != len(test.data) || file.Created.IsZero() {
+				t.Errorf("expected a %d byte %s file with a creation time, have %+v", len(test.data), test.contentType, file)
+			}
+		})
+	}
+
+	files := Hosted(server)
+	if len(files) != 2 || files[0].URI != "/a" || files[0].Name != "a.txt" || files[1].URI != "/b" {
+		t.Errorf("expected the replaced /a and /b to be hosted in order, have %+v", files)
+	}
+	for _, file := range files {
+		if file.data != nil {
+			t.Errorf("expected the hosted file %s to be listed without its contents", file.URI)
+		}
+	}
+	if err := Unhost(server, "/missing"); err == nil {
+		t.Error("expected an error removing a file that isn't hosted")
+	}
+	if files := Hosted(uuid.New()); len(files) != 0 {
+		t.Errorf("expected another server to host no files, have %+v", files)
+	}
+}
+
+// TestHosted verifies hosted files are served ahead of the Agent handler, one-time files are removed after a download,
+// and expired files are no longer served
+func TestHosted(t *testing.T) {
+	s := &Server{id: uuid.New()}
+	t.Cleanup(func() {
+		for _, file := range Hosted(s.id) {
+			_ = Unhost(s.id, file.URI)
+		}
+	})
+	files := []HostedFile{
+		{URI: "/payload", Name: "payload.bin"},
+		{URI: "/once", Name: "once.bin", Once: true},
+		{URI: "/expired", Name: "expired.bin", Expires: time.Now().Add(-time.Minute)},
+	}
+	for _, file := range files {
+		if _, err := Host(s.id, file, []byte("hosted")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := s.hosted(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("agent"))
+	}))
+
+	tests := []struct {
+		name      string
+		method    string
+		uri       string
+		body      string
+		downloads int
+	}{
+		{"hosted", http.MethodGet, "/payload", "hosted", 1},
+		{"head is not a download", http.MethodHead, "/payload", "", 1},
+		{"post goes to the agent handler", http.MethodPost, "/payload", "agent", 1},
+		{"downloaded again", http.MethodGet, "/payload", "hosted", 2},
+		{"one-time", http.MethodGet, "/once", "hosted", 0},
+		{"one-time removed", http.MethodGet, "/once", "agent", 0},
+		{"expired", http.MethodGet, "/expired", "agent", 0},
+		{"not hosted", http.MethodGet, "/other", "agent", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(test.method, test.uri, nil))
+			if w.Body.String() != test.body {
+				t.Errorf("expected the body %q, have %q", test.body, w.Body.String())
+			}
+			var downloads int
+			for _, file := range Hosted(s.id) {
+				if file.URI == test.uri {
+					downloads = file.Downloads
+				}
+			}
+			if downloads != test.downloads {
+				t.Errorf("expected %s to have %d downloads, have %d", test.uri, test.downloads, downloads)
+			}
+		})
+	}
+	if files := Hosted(s.id); len(files) != 1 || files[0].URI != "/payload" {
+		t.Errorf("expected only /payload to still be hosted, have %+v", files)
+	}
+}
+
+// TestHostedGates verifies requests refused by a hosted file's User-Agent or header gate get the next handler's
+// response, don't use up a one-time download, and are recorded in the file's access history
+func TestHostedGates(t *testing.T) {
+	s := &Server{id: uuid.New()}
+	t.Cleanup(func() {
+		for _, file := range Hosted(s.id) {
+			_ = Unhost(s.id, file.URI)
+		}
+	})
+	file := HostedFile{URI: "/gated", Name: "gated.bin", Once: true, UserAgent: "^curl/", HeaderName: "X-Token", HeaderValue: "s3cret"}
+	if _, err := Host(s.id, file, []byte("hosted")); err != nil {
+		t.Fatal(err)
+	}
+	handler := s.hosted(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	tests := []struct {
+		name      string
+		userAgent string
+		token     string
+		code      int
+		reason    string
+	}{
+		{"wrong User-Agent", "Mozilla/5.0", "s3cret", http.StatusNotFound, "User-Agent did not match"},
+		{"missing header", "curl/8.0", "", http.StatusNotFound, "missing X-Token header"},
+		{"wrong token", "curl/8.0", "guess", http.StatusNotFound, "invalid X-Token header token"},
+		{"allowed", "curl/8.0", "s3cret", http.StatusOK, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, file.URI, nil)
+			r.Header.Set("User-Agent", test.userAgent)
+			if test.token != "" {
+				r.Header.Set("X-Token", test.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != test.code {
+				t.Errorf("expected status code %d, have %d", test.code, w.Code)
+			}
+			if test.code != http.StatusOK {
+				accesses, err := Accesses(s.id, file.URI)
+				if err != nil {
+					t.Fatalf("expected the one-time file to still be hosted: %s", err)
+				}
+				last := accesses[len(accesses)-1]
+				if last.Allowed || last.Reason != test.reason || last.UserAgent != test.userAgent {
+					t.Errorf("expected a refused access for %q, have %+v", test.reason, last)
+				}
+			}
+		})
+	}
+	if _, err := Accesses(s.id, file.URI); err == nil {
+		t.Error("expected the one-time file to be removed after it was downloaded")
+	}
+}
+
+// TestHostedAccessHistory verifies the access history keeps only the most recent requests and HEAD requests aren't
+// counted as downloads
+func TestHostedAccessHistory(t *testing.T) {
+	var file HostedFile
+	for i := 0; i < maxHostedAccesses+10; i++ {
+		file.record(HostedAccess{Method: http.MethodGet, Allowed: true, UserAgent: strconv.Itoa(i)})
+	}
+	file.record(HostedAccess{Method: http.MethodHead, Allowed: true})
+	file.record(HostedAccess{Method: http.MethodGet, Reason: "User-Agent did not match"})
+
+	if len(file.Accesses) != maxHostedAccesses {
+		t.Errorf("expected %d accesses, have %d", maxHostedAccesses, len(file.Accesses))
+	}
+	if file.Accesses[0].UserAgent != "12" {
+		t.Errorf("expected the oldest accesses to be dropped, have %+v", file.Accesses[0])
+	}
+	if file.Downloads != maxHostedAccesses+10 || file.Blocked != 1 {
+		t.Errorf("expected %d downloads and 1 blocked request, have %d and %d", maxHostedAccesses+10, file.Downloads, file.Blocked)
+	}
+}
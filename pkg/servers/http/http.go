@@ -22,6 +22,8 @@ package http
 
 import (
 	// Standard
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"encoding/base64"
 	"fmt"
@@ -47,6 +49,7 @@ import (
 	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
 	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
 	"github.com/Ne0nd0g/merlin/v2/pkg/core"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
 	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
 )
 
@@ -77,15 +80,33 @@ type Server struct {
 	id        uuid.UUID // Unique identifier for the Server object
 	iface     string    // The network adapter interface the server will listen on
 	handler   *Handler
-	port      int // The port the server will listen on
-	protocol  int // The protocol (i.e., HTTP/2 or HTTP/3) the server will use from the servers' package
+	port      int       // The port the server will listen on
+	ports     portRange // The configured port or range of ports the server selects its port from
+	protocol  int       // The protocol (i.e., HTTP/2 or HTTP/3) the server will use from the servers' package
 	state     int
+	err       error       // The error that stopped the server when its state is Error
+	stopped   chan error  // Receives the result from Start() when the server stops serving
 	transport interface{} // The server, or transport, that will be used to send and receive traffic
 	listener  net.Listener
 	udpConn   *net.UDPConn
 	x509Cert  string
 	x509Key   string
+	cert      *tls.Certificate // The in-memory x.509 certificate, reused across restarts so Agents that pinned it still connect
+	pin       string           // The SHA256 fingerprint of the x.509 certificate in use that Agents can pin
 	urls      []string
+	pool      uriPool          // Additional check-in URIs the server accepts and their rotation schedule
+	headers   responseHeaders  // HTTP headers added to every response
+	trusted   trustedProxies   // Redirectors whose X-Forwarded-For and X-Real-IP headers are trusted
+	countries countryFilter    // The countries Agent traffic is accepted from based on a GeoIP lookup
+	canary    bool             // Record and alert on every request instead of processing Agent traffic
+	pages     errorPages       // HTML templates used for error responses
+	access    *accessLog       // The Apache style access log
+	health    healthCheck      // The load balancer health check URI and its decoy content
+	methods   []string         // The HTTP methods Agent traffic is accepted with
+	carrier   messageTransport // Where the Agent message is in the request (e.g., body, cookie, or header)
+	quic      quicOptions      // QUIC transport tuning used by the HTTP/3 server
+	maxConns  int              // The maximum number of concurrent connections; 0 is unlimited
+	limiter   *rateLimiter     // Per-client IP request rate limiting
 	psk       string
 	jwtKey    string        // A Base64 encoded 32-byte key used to sign JSON Web Tokens
 	jwtLeeway time.Duration // The amount of flexibility allowed in the JWT expiration time. Less than 0 disables checking JWT expiration
@@ -95,15 +116,33 @@ type Server struct {
 
 // Template is a structure used to collect the information needed to create an instance with the New() function
 type Template struct {
-	Interface string
-	Port      string
-	Protocol  string
-	X509Key   string // The x.509 private key used for TLS encryption
-	X509Cert  string // The x.509 public key used for TLS encryption
-	URLS      string // A comma separated list of URL that handle incoming web traffic
-	PSK       string // The pre-shared key password used prior to Password Authenticated Key Exchange (PAKE)
-	JWTKey    string // 32-byte Base64 encoded key used to sign/encrypt JWTs
-	JWTLeeway string // The amount of flexibility allowed in the JWT expiration time. Less than 0 disables checking JWT expiration
+	Interface       string
+	Port            string
+	Protocol        string
+	X509Key         string // The x.509 private key used for TLS encryption
+	X509Cert        string // The x.509 public key used for TLS encryption
+	URLS            string // A comma separated list of URL that handle incoming web traffic
+	URIPool         string // A comma separated list of additional check-in URIs Agents can randomly select from
+	URIRotation     string // The period used to rotate the scheduled check-in URI from the URIPool
+	Headers         string // A pipe-delimited list of "Name: value" HTTP headers added to every response
+	URIHeaders      string // A JSON object of URIs to the HTTP headers added to their responses
+	MaxConnections  string // The maximum number of concurrent connections, 0 is unlimited
+	RateLimit       string // The number of requests per second a single client IP address can make, 0 is unlimited
+	RateBurst       string // The number of requests a single client IP address can make at once before being rate limited
+	TrustedProxies  string // A comma separated list of redirector IP addresses or CIDR networks whose forwarding headers are trusted
+	GeoIPCountries  string // A comma separated list of ISO country codes that Agent traffic is accepted from
+	Canary          string // Record and alert on every request instead of processing Agent traffic
+	ErrorPages      string // A directory of HTML templates used for error responses (e.g., 404.html, default.html)
+	AccessLog       string // The file path of an Apache style access log; empty disables the access log
+	AccessLogFormat string // The access log format, common or combined
+	HealthURI       string // A URI for load balancer health checks that returns 200 OK without processing Agent traffic
+	HealthContent   string // The decoy content returned for health checks
+	Methods         string // A comma separated list of HTTP methods Agent traffic is accepted with
+	Transport       string // Where the Agent message is in the request: body, cookie, or header
+	TransportName   string // The cookie or header name the Agent message is in
+	PSK             string // The pre-shared key password used prior to Password Authenticated Key Exchange (PAKE)
+	JWTKey          string // 32-byte Base64 encoded key used to sign/encrypt JWTs
+	JWTLeeway       string // The amount of flexibility allowed in the JWT expiration time. Less than 0 disables checking JWT expiration
 }
 
 // TODO update New to take the template instead of an options map
@@ -138,20 +177,27 @@ func New(options map[string]string) (Server, error) {
 	}
 
 	// Interface
-	s.iface, ok = options["Interface"]
+	iface, ok := options["Interface"]
 	if !ok {
 		return s, fmt.Errorf("the \"Interface\" key was not found in the options map and is required")
 	}
+	s.iface, err = listeners.ParseInterface(iface)
+	if err != nil {
+		return s, err
+	}
 
 	// Port
 	port, ok := options["Port"]
 	if !ok {
 		return s, fmt.Errorf("the \"Port\" key was not found in the options map and is required")
 	}
-	// Convert port to integer from string
-	s.port, err = strconv.Atoi(port)
+	s.ports, err = newPortRange(port)
 	if err != nil {
-		return s, fmt.Errorf("there was an error converting the port number to an integer: %s", err.Error())
+		return s, err
+	}
+	// Port 0 and ranges are selected when the server is reserved or listens
+	if s.ports.low == s.ports.high {
+		s.port = s.ports.low
 	}
 
 	// X.509 Certificate
@@ -178,6 +224,110 @@ func New(options map[string]string) (Server, error) {
 		return s, fmt.Errorf("the \"PSK\" key was not found in the options map and is required")
 	}
 
+	// URI Pool
+	s.pool.uris, err = parseURIPool(options["URIPool"])
+	if err != nil {
+		return s, err
+	}
+	if rotation, ok := options["URIRotation"]; ok && rotation != "" {
+		s.pool.rotation, err = time.ParseDuration(rotation)
+		if err != nil {
+			return s, fmt.Errorf("there was an error parsing the URIRotation duration %s: %s", rotation, err)
+		}
+	}
+	seed := sha256.Sum256([]byte(s.psk))
+	s.pool.seed = seed[:]
+
+	// Response Headers
+	s.headers.global, err = parseHeaders(options["Headers"])
+	if err != nil {
+		return s, err
+	}
+	s.headers.uri, err = parseURIHeaders(options["URIHeaders"])
+	if err != nil {
+		return s, err
+	}
+
+	// Trusted Redirectors
+	s.trusted, err = parseTrustedProxies(options["TrustedProxies"])
+	if err != nil {
+		return s, err
+	}
+
+	// Canary mode
+	if canary, ok := options["Canary"]; ok && canary != "" {
+		s.canary, err = strconv.ParseBool(canary)
+		if err != nil {
+			return s, fmt.Errorf("there was an error parsing the Canary option %s as a boolean: %s", canary, err)
+		}
+	}
+
+	// Access log
+	access, err := parseAccessLog(options["AccessLog"], options["AccessLogFormat"])
+	if err != nil {
+		return s, err
+	}
+	s.access = &access
+
+	// HTTP methods
+	s.methods, err = parseMethods(options["Methods"])
+	if err != nil {
+		return s, err
+	}
+
+	// Message transport
+	s.carrier, err = parseMessageTransport(options["Transport"], options["TransportName"])
+	if err != nil {
+		return s, err
+	}
+
+	// Health check
+	s.health, err = parseHealthCheck(options["HealthURI"], options["HealthContent"])
+	if err != nil {
+		return s, err
+	}
+
+	// Error page templates
+	s.pages, err = parseErrorPages(options["ErrorPages"])
+	if err != nil {
+		return s, err
+	}
+
+	// GeoIP country filter
+	s.countries, err = parseCountryFilter(options["GeoIPCountries"])
+	if err != nil {
+		return s, err
+	}
+
+	// Connection and rate limits
+	s.limiter = &rateLimiter{}
+	if maxConns, ok := options["MaxConnections"]; ok && maxConns != "" {
+		s.maxConns, err = strconv.Atoi(maxConns)
+		if err != nil {
+			return s, fmt.Errorf("there was an error converting the MaxConnections %s to an integer: %s", maxConns, err)
+		}
+	}
+	s.limiter.rate, err = parseRate(options["RateLimit"])
+	if err != nil {
+		return s, err
+	}
+	s.limiter.burst, err = parseBurst(options["RateBurst"])
+	if err != nil {
+		return s, err
+	}
+
+	// QUIC tuning
+	if s.protocol == servers.HTTP3 {
+		for _, option := range []string{"QUICIdleTimeout", "QUICKeepAlive", "QUICMaxStreams", "QUIC0RTT"} {
+			if value, ok := options[option]; ok && value != "" {
+				err = s.quic.set(option, value)
+				if err != nil {
+					return s, err
+				}
+			}
+		}
+	}
+
 	// JWT Key
 	jwtKey, ok := options["JWTKey"]
 	if !ok {
@@ -208,7 +358,7 @@ func New(options map[string]string) (Server, error) {
 
 // Addr returns the network interface and port it is bound to
 func (s *Server) Addr() string {
-	return fmt.Sprintf("%s:%d", s.iface, s.port)
+	return net.JoinHostPort(s.iface, strconv.Itoa(s.port))
 }
 
 // ConfiguredOptions returns the server's current configuration for options that can be set by the user
@@ -217,13 +367,41 @@ func (s *Server) ConfiguredOptions() map[string]string {
 	options["Protocol"] = s.ProtocolString()
 	options["Interface"] = s.iface
 	options["Port"] = fmt.Sprintf("%d", s.port)
+	if s.ports.low != s.ports.high || s.ports.low == 0 {
+		options["PortRange"] = s.ports.String()
+	}
 	options["URLS"] = strings.Join(s.urls, ",")
+	options["URIPool"] = strings.Join(s.pool.uris, ",")
+	options["URIRotation"] = s.pool.rotation.String()
+	options["Headers"] = s.headers.String()
+	options["URIHeaders"] = s.headers.URIString()
+	options["TrustedProxies"] = s.trusted.String()
+	options["GeoIPCountries"] = s.countries.String()
+	options["Canary"] = strconv.FormatBool(s.canary)
+	options["ErrorPages"] = s.pages.String()
+	options["HealthURI"] = s.health.uri
+	options["Methods"] = strings.Join(s.methods, ",")
+	options["Transport"] = s.carrier.mode
+	options["TransportName"] = s.carrier.name
+	options["HealthContent"] = s.health.content
+	options["AccessLog"] = s.access.path
+	options["AccessLogFormat"] = s.access.format
+	options["MaxConnections"] = strconv.Itoa(s.maxConns)
+	options["RateLimit"] = strconv.FormatFloat(s.limiter.rate, 'f', -1, 64)
+	options["RateBurst"] = strconv.FormatFloat(s.limiter.burst, 'f', -1, 64)
 	options["JWTKey"] = s.jwtKey
 	options["JWTLeeway"] = s.jwtLeeway.String()
 
 	if s.protocol != servers.HTTP && s.protocol != servers.H2C {
 		options["X509Cert"] = s.x509Cert
 		options["X509Key"] = s.x509Key
+		options["CertFingerprint"] = s.pin
+	}
+
+	if s.protocol == servers.HTTP3 {
+		for k, v := range s.quic.options() {
+			options[k] = v
+		}
 	}
 	return options
 }
@@ -238,35 +416,35 @@ func (s *Server) Interface() string {
 	return s.iface
 }
 
-// Listen creates a TCP network listener on the server's network interface and port
+// Listen creates a network listener on the server's network interface and port.
+// When the port is 0 or a range, the first free port is selected
 func (s *Server) Listen() (err error) {
-	err = s.generateServer()
+	s.err = nil
+	s.stopped = make(chan error, 1)
+	// Bind first so that the HTTP/3 server is generated with the selected port
+	err = s.bind()
 	if err != nil {
-		err = fmt.Errorf("there was an error generating a new %s server: %s", s, err)
+		err = fmt.Errorf("there was an error creating a listener for the %s server: %s", s, err)
 		slog.Error(err.Error())
 		return
 	}
+	if s.listener != nil {
+		s.listener = newLimitListener(s.listener, s.maxConns)
+	}
 
-	if s.protocol != servers.HTTP3 {
-		s.listener, err = net.Listen("tcp", fmt.Sprintf("%s:%d", s.iface, s.port))
-		if err != nil {
-			err = fmt.Errorf("there was an error creating a listener for the %s server: %s", s, err)
-			slog.Error(err.Error())
-			return
+	err = s.generateServer()
+	if err != nil {
+		err = fmt.Errorf("there was an error generating a new %s server: %s", s, err)
+		slog.Error(err.Error())
+		// Release the port
+		if s.listener != nil {
+			s.listener.Close() // #nosec G104 the generate error is returned instead
 		}
-	} else {
-		s.udpConn, err = net.ListenUDP("udp", &net.UDPAddr{
-			IP:   net.ParseIP(s.iface),
-			Port: s.port,
-			Zone: "",
-		})
-		if err != nil {
-			err = fmt.Errorf("there was an error creating a listener for the %s server: %s", s, err)
-			slog.Error(err.Error())
-			return
+		if s.udpConn != nil {
+			s.udpConn.Close() // #nosec G104 the generate error is returned instead
 		}
+		return
 	}
-
 	return
 }
 
@@ -307,37 +485,177 @@ func (s *Server) SetOption(option string, value string) error {
 	var err error
 	// Check non-string options first
 	switch strings.ToLower(option) {
+	case "canary":
+		s.canary, err = strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("there was an error parsing the Canary option %s as a boolean: %s", value, err)
+		}
+	case "accesslog", "accesslogformat":
+		path, format := s.access.path, s.access.format
+		if strings.ToLower(option) == "accesslog" {
+			path = value
+		} else {
+			format = value
+		}
+		access, err := parseAccessLog(path, format)
+		if err != nil {
+			return err
+		}
+		// Keep the open file so that Stop() closes it; the new file is opened when the server is started again
+		access.file, access.logger = s.access.file, s.access.logger
+		s.access = &access
+	case "methods":
+		s.methods, err = parseMethods(value)
+		if err != nil {
+			return err
+		}
+	case "transport":
+		s.carrier, err = parseMessageTransport(value, s.carrier.name)
+		if err != nil {
+			return err
+		}
+	case "transportname":
+		s.carrier, err = parseMessageTransport(s.carrier.mode, value)
+		if err != nil {
+			return err
+		}
+	case "healthuri":
+		s.health, err = parseHealthCheck(value, s.health.content)
+		if err != nil {
+			return err
+		}
+	case "healthcontent":
+		s.health.content = value
+	case "errorpages":
+		s.pages, err = parseErrorPages(value)
+		if err != nil {
+			return err
+		}
+	case "geoipcountries":
+		s.countries, err = parseCountryFilter(value)
+		if err != nil {
+			return err
+		}
+	case "headers":
+		s.headers.global, err = parseHeaders(value)
+		if err != nil {
+			return err
+		}
 	case "interface":
-		s.iface = value
+		s.iface, err = listeners.ParseInterface(value)
+		if err != nil {
+			return err
+		}
+	case "jwtkey":
+		jwt, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("there was an error base64 decoding the provided JWT Key %s: %s", value, err)
+		}
+		if len(jwt) != 32 {
+			return fmt.Errorf("the JWT Key must be 32 bytes but was %d bytes", len(jwt))
+		}
+		s.jwtKey = value
+	case "jwtleeway":
+		s.jwtLeeway, err = time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("there was an error parsing the JWTLeeway duration %s: %s", value, err)
+		}
+	case "maxconnections":
+		s.maxConns, err = strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("there was an error converting the MaxConnections %s to an integer: %s", value, err)
+		}
 	case "port":
-		s.port, err = strconv.Atoi(value)
+		s.ports, err = newPortRange(value)
 		if err != nil {
-			return fmt.Errorf("there was an error converting the port number to an integer: %s", err.Error())
+			return err
+		}
+		s.port = 0
+		if s.ports.low == s.ports.high {
+			s.port = s.ports.low
 		}
 	case "protocol":
 		return fmt.Errorf("the protocol can not be changed; create a new listener instead")
 	case "psk":
-		s.handler.psk = []byte(value)
+		// The handler is created from the PSK when the server starts listening
+		s.psk = value
+		if s.handler != nil {
+			s.handler.psk = []byte(value)
+		}
+	case "uripool":
+		s.pool.uris, err = parseURIPool(value)
+		if err != nil {
+			return err
+		}
+	case "urirotation":
+		s.pool.rotation, err = time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("there was an error parsing the URIRotation duration %s: %s", value, err)
+		}
+	case "rateburst":
+		s.limiter.burst, err = parseBurst(value)
+		if err != nil {
+			return err
+		}
+	case "ratelimit":
+		s.limiter.rate, err = parseRate(value)
+		if err != nil {
+			return err
+		}
+	case "quicidletimeout", "quickeepalive", "quicmaxstreams", "quic0rtt":
+		if s.protocol != servers.HTTP3 {
+			return fmt.Errorf("the %s option is only available for HTTP3 servers", option)
+		}
+		return s.quic.set(option, value)
+	case "trustedproxies":
+		s.trusted, err = parseTrustedProxies(value)
+		if err != nil {
+			return err
+		}
+	case "uriheaders":
+		s.headers.uri, err = parseURIHeaders(value)
+		if err != nil {
+			return err
+		}
 	case "urls":
 		s.urls = strings.Split(value, ",")
 	case "x509cert":
-		if s.protocol == servers.HTTPS || s.protocol == servers.HTTP2 {
-			s.x509Cert = option
+		if s.protocol == servers.HTTP || s.protocol == servers.H2C {
+			return fmt.Errorf("the X509Cert option is not used by %s servers", s.ProtocolString())
 		}
+		s.x509Cert = value
 	case "x509key":
-		if s.protocol == servers.HTTPS || s.protocol == servers.HTTP2 {
-			s.x509Key = option
+		if s.protocol == servers.HTTP || s.protocol == servers.H2C {
+			return fmt.Errorf("the X509Key option is not used by %s servers", s.ProtocolString())
 		}
+		s.x509Key = value
 	default:
-		return fmt.Errorf("invalid option: %s", option)
+		return listeners.UnhandledOption(option, Schema(s.protocol).Names())
 	}
 	return nil
 }
 
 // Start function starts the HTTP server and listens for incoming connections
 // This function does not return unless there is an error and should be called as Go routine
-func (s *Server) Start() {
+func (s *Server) Start(ctx context.Context) {
 	var g errgroup.Group
+	// Set the state before the go routine that stops the server reads it
+	s.state = Running
+
+	// Stop the server when the context is cancelled, such as when the Merlin server is shutting down
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			slog.Debug(fmt.Sprintf("stopping the %s server on %s:%d because the context was cancelled: %s", s.ProtocolString(), s.iface, s.port, ctx.Err()))
+			err := s.Stop()
+			if err != nil {
+				slog.Error(err.Error())
+			}
+		case <-done:
+		}
+	}()
 
 	// Catch Panic
 	defer func() {
@@ -347,11 +665,13 @@ func (s *Server) Start() {
 	}()
 
 	g.Go(func() error {
-		s.state = Running
 		switch s.protocol {
 		case servers.HTTP, servers.H2C:
+			// Agent requests inherit the context so that in-flight requests are cancelled with the server
+			s.transport.(*http.Server).BaseContext = func(net.Listener) context.Context { return ctx }
 			return s.transport.(*http.Server).Serve(s.listener)
 		case servers.HTTPS, servers.HTTP2:
+			s.transport.(*http.Server).BaseContext = func(net.Listener) context.Context { return ctx }
 			return s.transport.(*http.Server).ServeTLS(s.listener, s.x509Cert, s.x509Key)
 		case servers.HTTP3:
 			//if s.x509Key != "" && s.x509Cert != "" {
@@ -363,19 +683,38 @@ func (s *Server) Start() {
 		}
 	})
 
-	if err := g.Wait(); err != nil {
-		if err != http.ErrServerClosed && err != quic.ErrServerClosed {
-			s.state = Error
-			slog.Error(fmt.Sprintf("there was an error with the %s server on %s:%d %s", s.ProtocolString(), s.iface, s.port, err.Error()))
-		}
+	err := g.Wait()
+	if err == http.ErrServerClosed || err == quic.ErrServerClosed {
+		err = nil
+	}
+	if err != nil {
+		s.state = Error
+		s.err = err
+		slog.Error(fmt.Sprintf("there was an error with the %s server on %s:%d %s", s.ProtocolString(), s.iface, s.port, err.Error()))
+	}
+	// Report the result without blocking in case Start() was called without Listen()
+	select {
+	case s.stopped <- err:
+	default:
 	}
 }
 
-// Status enumerates if the server is currently running or stopped and returns the value as a string
+// Status enumerates if the server is currently running or stopped and returns the value as a string.
+// If the server stopped because of an error, the error is included
 func (s *Server) Status() string {
+	if s.state == Error && s.err != nil {
+		return fmt.Sprintf("%s: %s", State(s.state), s.err)
+	}
 	return State(s.state)
 }
 
+// Stopped returns a channel that receives the result from Start() once the server stops serving.
+// The result is nil when the server was stopped, otherwise it is the error that stopped it.
+// A new channel is created each time Listen() is called
+func (s *Server) Stopped() <-chan error {
+	return s.stopped
+}
+
 // Stop function stops the server
 func (s *Server) Stop() (err error) {
 	// If the server isn't running, return
@@ -401,6 +740,10 @@ func (s *Server) Stop() (err error) {
 	if err != nil {
 		return fmt.Errorf("there was an error stopping the HTTP server:\r\n%s", err.Error())
 	}
+	err = s.access.close()
+	if err != nil {
+		return fmt.Errorf("there was an error closing the access log: %s", err)
+	}
 	s.state = Closed
 	return
 }
@@ -439,6 +782,24 @@ func GetDefaultOptions(protocol int) map[string]string {
 	options["JWTKey"] = base64.StdEncoding.EncodeToString([]byte(core.RandStringBytesMaskImprSrc(32)))
 	options["JWTLeeway"] = "1m"
 	options["URLS"] = "/"
+	options["URIPool"] = ""
+	options["URIRotation"] = "0s"
+	options["Headers"] = ""
+	options["URIHeaders"] = ""
+	options["TrustedProxies"] = ""
+	options["GeoIPCountries"] = ""
+	options["Canary"] = "false"
+	options["ErrorPages"] = ""
+	options["HealthURI"] = ""
+	options["Methods"] = http.MethodPost
+	options["Transport"] = transportBody
+	options["TransportName"] = "session"
+	options["HealthContent"] = "OK"
+	options["AccessLog"] = ""
+	options["AccessLogFormat"] = accessCombined
+	options["MaxConnections"] = "0"
+	options["RateLimit"] = "0"
+	options["RateBurst"] = "0"
 
 	if protocol != servers.HTTP && protocol != servers.H2C {
 		current, err := os.Getwd()
@@ -449,6 +810,13 @@ func GetDefaultOptions(protocol int) map[string]string {
 		options["X509Key"] = filepath.Join(current, "data", "x509", "server.key")
 	}
 
+	if protocol == servers.HTTP3 {
+		var q quicOptions
+		for k, v := range q.options() {
+			options[k] = v
+		}
+	}
+
 	switch protocol {
 	case servers.HTTP:
 		options["Protocol"] = "HTTP"
@@ -482,20 +850,36 @@ func (s *Server) generateServer() error {
 		jwtKey:    jwt,
 		jwtLeeway: s.jwtLeeway,
 		psk:       []byte(s.psk),
+		pool:      s.pool,
+		trusted:   s.trusted,
+		countries: s.countries,
+		canary:    s.canary,
+		methods:   s.methods,
+		transport: s.carrier,
 	}
 
-	// Add multiplexer handler for URLs
+	// Add multiplexer handler for URLs and the URI pool; a pattern can only be registered once
 	mux := http.NewServeMux()
-	for _, url := range s.urls {
+	registered := make(map[string]bool)
+	for _, url := range append(s.urls, s.pool.patterns()...) {
+		if registered[url] {
+			continue
+		}
+		registered[url] = true
 		mux.HandleFunc(url, s.handler.agentHandler)
 	}
+	err = s.access.open()
+	if err != nil {
+		return err
+	}
+	handler := s.headers.wrap(s.health.wrap(s.access.wrap(s.pages.wrap(s.limits(s.hosted(mux))), s.trusted)))
 
 	// Add server
 	switch s.protocol {
 	case servers.HTTP, servers.HTTPS, servers.HTTP2:
 		s.transport = &http.Server{
-			Addr:              fmt.Sprintf("%s:%d", s.iface, s.port),
-			Handler:           mux,
+			Addr:              s.Addr(),
+			Handler:           handler,
 			ReadTimeout:       30 * time.Second,
 			WriteTimeout:      30 * time.Second,
 			ReadHeaderTimeout: 30 * time.Second,
@@ -505,8 +889,8 @@ func (s *Server) generateServer() error {
 	case servers.H2C:
 		h2s := &http2.Server{}
 		s.transport = &http.Server{
-			Addr:              fmt.Sprintf("%s:%d", s.iface, s.port),
-			Handler:           h2c.NewHandler(mux, h2s),
+			Addr:              s.Addr(),
+			Handler:           h2c.NewHandler(handler, h2s),
 			ReadTimeout:       10 * time.Second,
 			WriteTimeout:      10 * time.Second,
 			ReadHeaderTimeout: 30 * time.Second,
@@ -516,16 +900,12 @@ func (s *Server) generateServer() error {
 	case servers.HTTP3:
 
 		s.transport = &http3.Server{
-			Addr:           fmt.Sprintf("%s:%d", s.iface, s.port),
+			Addr:           s.Addr(),
 			Port:           s.port,
-			Handler:        mux,
+			Handler:        handler,
 			MaxHeaderBytes: 1 << 20,
 			//TLSConfig:      &tls.Config{Certificates: []tls.Certificate{*certificates}, MinVersion: tls.VersionTLS12},
-			QUICConfig: &quic.Config{
-				// Opted for a long timeout to prevent the client from sending an HTTP/2 PING Frame
-				MaxIdleTimeout:  time.Until(time.Now().AddDate(0, 42, 0)),
-				KeepAlivePeriod: time.Second * 0,
-			},
+			QUICConfig: s.quic.config(),
 		}
 	default:
 		return fmt.Errorf("pkg/servers/http.generateServer(): unhandled server type %d", s.protocol)
@@ -534,9 +914,14 @@ func (s *Server) generateServer() error {
 	// Add TLS X509 certificates
 	if s.protocol == servers.HTTPS || s.protocol == servers.HTTP2 || s.protocol == servers.HTTP3 {
 		certificates, err := GetTLSCertificates(s.x509Cert, s.x509Key)
+		if err != nil && s.cert != nil {
+			// Keep the certificate generated the first time the server started so its fingerprint doesn't change
+			certificates, err = s.cert, nil
+		}
 		if err != nil {
 			m := fmt.Sprintf("Certificate was not found at: \"%s\"\n", s.x509Cert)
-			m += "Creating in-memory x.509 certificate used for this session only"
+			m += "Creating in-memory x.509 certificate used for this session only\n"
+			m += "Agents that pin its fingerprint must be regenerated after the Merlin server restarts"
 			slog.Info(fmt.Sprintf("Certificate was not found at: %s. Creating in-memory x.509 certificate used for this session only", s.x509Cert))
 			memory.NewRepository().Add(message.NewMessage(message.Note, m))
 			// Set to blank to force the HTTP server to use its TLS config. ListenAndServeTLS will fail with invalid file paths
@@ -547,7 +932,11 @@ func (s *Server) generateServer() error {
 			if err != nil {
 				return err
 			}
+			s.cert = certificates
 		}
+		s.pin = fingerprint(*certificates)
+		s.handler.fingerprint = s.pin
+		slog.Info(fmt.Sprintf("%s server on %s:%d x.509 certificate SHA256 fingerprint: %s", s.ProtocolString(), s.iface, s.port, s.pin))
 
 		insecure, err := CheckInsecureFingerprint(*certificates)
 		if err != nil {
@@ -556,7 +945,8 @@ func (s *Server) generateServer() error {
 
 		if insecure {
 			m := fmt.Sprintf("Insecure publicly distributed Merlin x.509 testing certificate in use for %s server on %s:%d\n", s.ProtocolString(), s.iface, s.port)
-			m += "Additional details: https://merlin-c2.readthedocs.io/en/latest/server/x509.html"
+			m += "Additional details: https://merlin-c2.readthedocs.io/en/latest/server/x509.html\n"
+			m += "Pinning this certificate does not protect Agent traffic from TLS interception"
 			slog.Info(m)
 			memory.NewRepository().Add(message.NewMessage(message.Note, m))
 		}
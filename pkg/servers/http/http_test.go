@@ -0,0 +1,187 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+// TestStartContext verifies a running server is stopped and Start returns when its context is cancelled
+func TestStartContext(t *testing.T) {
+	options := GetDefaultOptions(servers.HTTP)
+	options["Interface"] = "127.0.0.1"
+	options["Port"] = "0"
+	options["PSK"] = "merlin"
+	s, err := New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(done)
+	}()
+
+	// Wait for the server to accept connections
+	addr := s.listener.Addr().String()
+	for i := 0; ; i++ {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+			break
+		}
+		if i == 50 {
+			t.Fatalf("the server never accepted connections: %s", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after the context was cancelled")
+	}
+	if _, err = net.Dial("tcp", addr); err == nil {
+		t.Error("expected the server to stop accepting connections")
+	}
+}
+
+func TestAddr(t *testing.T) {
+	tests := []struct {
+		name  string
+		iface string
+		want  string
+	}{
+		{"IPv4", "127.0.0.1", "127.0.0.1:443"},
+		{"IPv6", "::1", "[::1]:443"},
+		{"IPv6 brackets", "[::1]", "[::1]:443"},
+		{"IPv6 wildcard", "::", "[::]:443"},
+		{"IPv6 zone", "fe80::1%eth0", "[fe80::1%eth0]:443"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := GetDefaultOptions(servers.HTTPS)
+			options["Interface"] = test.iface
+			options["Port"] = "443"
+			options["PSK"] = "merlin"
+			s, err := New(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Addr() != test.want {
+				t.Errorf("expected %s, have %s", test.want, s.Addr())
+			}
+		})
+	}
+}
+
+// TestListenIPv6 verifies the server binds to the IPv6 loopback address
+func TestListenIPv6(t *testing.T) {
+	if l, err := net.Listen("tcp", "[::1]:0"); err != nil {
+		t.Skipf("IPv6 is not available: %s", err)
+	} else {
+		_ = l.Close()
+	}
+	options := GetDefaultOptions(servers.HTTP)
+	options["Interface"] = "::1"
+	options["Port"] = "0"
+	options["PSK"] = "merlin"
+	s, err := New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.listener.Close()
+	if addr := s.listener.Addr().(*net.TCPAddr); !addr.IP.Equal(net.IPv6loopback) {
+		t.Errorf("expected the server to listen on ::1, have %s", addr)
+	}
+}
+
+// TestStopped verifies the result from Start is sent to the Stopped channel and reflected in the server's status
+func TestStopped(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(s *Server, cancel context.CancelFunc)
+		err  bool
+	}{
+		{"cancelled", func(s *Server, cancel context.CancelFunc) { cancel() }, false},
+		{"listener closed", func(s *Server, cancel context.CancelFunc) { _ = s.listener.Close() }, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := GetDefaultOptions(servers.HTTP)
+			options["Interface"] = "127.0.0.1"
+			options["Port"] = "0"
+			options["PSK"] = "merlin"
+			s, err := New(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Listen()
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stopped := s.Stopped()
+			go s.Start(ctx)
+			// Give the server time to start serving
+			time.Sleep(50 * time.Millisecond)
+			test.stop(&s, cancel)
+
+			select {
+			case err = <-stopped:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the server's result was never sent to the Stopped channel")
+			}
+			if test.err {
+				if err == nil {
+					t.Fatal("expected the server to stop with an error")
+				}
+				if !strings.Contains(s.Status(), err.Error()) {
+					t.Errorf("expected the status to include the error %q, have %q", err, s.Status())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected the server to stop without an error, have %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+// limitListener wraps a network listener and drops new connections once the maximum number of concurrent connections
+// has been reached. Dropping, instead of queueing, protects the server's resources from misbehaving Agents or
+// deliberate flooding of a discovered endpoint
+type limitListener struct {
+	net.Listener
+	max    int64        // The maximum number of concurrent connections
+	active atomic.Int64 // The number of currently open connections
+}
+
+// newLimitListener returns a listener that drops connections beyond the provided maximum; 0 or less is unlimited
+func newLimitListener(l net.Listener, limit int) net.Listener {
+	if limit <= 0 {
+		return l
+	}
+	return &limitListener{Listener: l, max: int64(limit)}
+}
+
+// Accept waits for and returns the next connection that is within the connection limit
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if l.active.Add(1) > l.max {
+			l.active.Add(-1)
+			slog.Debug("dropping connection because the maximum number of connections was reached", "remote address", conn.RemoteAddr(), "max", l.max)
+			_ = conn.Close()
+			continue
+		}
+		return &limitConn{Conn: conn, release: func() { l.active.Add(-1) }}, nil
+	}
+}
+
+// limitConn is a network connection that releases its slot in the limitListener when it is closed
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+// Close closes the connection and releases its slot exactly once
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
+
+// bucket is a token bucket tracking a single client's request rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter enforces a per-client request rate using a token bucket for each client IP address
+type rateLimiter struct {
+	sync.Mutex
+	rate    float64 // The number of requests per second a client is allowed to make; 0 disables rate limiting
+	burst   float64 // The number of requests a client can make at once before being limited
+	clients map[string]*bucket
+}
+
+// parseRate parses the requests per second a single client IP address is allowed to make
+func parseRate(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("pkg/servers/http.parseRate(): there was an error parsing the RateLimit %s: %s", value, err)
+	}
+	if rate < 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return 0, fmt.Errorf("pkg/servers/http.parseRate(): the RateLimit %s must be a positive number", value)
+	}
+	return rate, nil
+}
+
+// parseBurst parses the number of requests a single client IP address can make at once
+func parseBurst(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	burst, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("pkg/servers/http.parseBurst(): there was an error parsing the RateBurst %s: %s", value, err)
+	}
+	if burst < 0 {
+		return 0, fmt.Errorf("pkg/servers/http.parseBurst(): the RateBurst %s must be a positive number", value)
+	}
+	return float64(burst), nil
+}
+
+// allow determines if the client can make another request and, if not, how long it must wait
+func (r *rateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	if r.rate <= 0 {
+		return true, 0
+	}
+	burst := math.Max(r.burst, 1)
+
+	r.Lock()
+	defer r.Unlock()
+	if r.clients == nil {
+		r.clients = make(map[string]*bucket)
+	}
+
+	b, ok := r.clients[client]
+	if !ok {
+		// Prune clients whose buckets have refilled so the map doesn't grow without bound
+		if len(r.clients) >= 10000 {
+			for ip, c := range r.clients {
+				if c.tokens+now.Sub(c.last).Seconds()*r.rate >= burst {
+					delete(r.clients, ip)
+				}
+			}
+		}
+		b = &bucket{tokens: burst, last: now}
+		r.clients[client] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*r.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / r.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// limits returns an HTTP handler that enforces the per-client request rate and, for HTTP/3 where there is no
+// connection listener to wrap, the maximum number of concurrent requests. Limited requests receive a 429
+func (s *Server) limits(next http.Handler) http.Handler {
+	var inflight chan struct{}
+	if s.protocol == servers.HTTP3 && s.maxConns > 0 {
+		inflight = make(chan struct{}, s.maxConns)
+	}
+	if s.limiter.rate <= 0 && inflight == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := s.trusted.clientIP(r)
+		if ok, wait := s.limiter.allow(client, time.Now()); !ok {
+			slog.Debug("rate limiting client", "client", client, "retry after", wait)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if inflight != nil {
+			select {
+			case inflight <- struct{}{}:
+				defer func() { <-inflight }()
+			default:
+				slog.Debug("dropping request because the maximum number of connections was reached", "client", client, "max", s.maxConns)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,126 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+// rateStep is a single request made to a rate limiter and the expected outcome
+type rateStep struct {
+	offset time.Duration // When the request is made, relative to the first request
+	allow  bool
+	wait   time.Duration // How long the client is told to wait when the request is limited
+}
+
+// TestRateLimiterRefill verifies a client's token bucket drains with each request and refills at the configured rate
+func TestRateLimiterRefill(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		burst float64
+		steps []rateStep
+	}{
+		{"disabled", 0, 0, []rateStep{{0, true, 0}, {0, true, 0}, {0, true, 0}}},
+		{"burst of one by default", 1, 0, []rateStep{{0, true, 0}, {0, false, time.Second}, {time.Second, true, 0}}},
+		{"burst then limited", 1, 3, []rateStep{{0, true, 0}, {0, true, 0}, {0, true, 0}, {0, false, time.Second}}},
+		{"partial refill", 2, 2, []rateStep{{0, true, 0}, {0, true, 0}, {250 * time.Millisecond, false, 250 * time.Millisecond}, {500 * time.Millisecond, true, 0}}},
+		{"refill is capped at the burst", 10, 2, []rateStep{{0, true, 0}, {time.Minute, true, 0}, {time.Minute, true, 0}, {time.Minute, false, 100 * time.Millisecond}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := &rateLimiter{rate: test.rate, burst: test.burst}
+			start := time.Now()
+			for i, step := range test.steps {
+				allow, wait := limiter.allow("192.0.2.1", start.Add(step.offset))
+				if allow != step.allow {
+					t.Fatalf("request %d: expected allow to be %t, got %t", i, step.allow, allow)
+				}
+				if diff := wait - step.wait; diff < -time.Millisecond || diff > time.Millisecond {
+					t.Fatalf("request %d: expected to wait %s, got %s", i, step.wait, wait)
+				}
+			}
+		})
+	}
+}
+
+// TestLimitsPerClient verifies requests are limited per client IP address, using the address from the forwarding
+// headers only when the request came from a trusted redirector, and that limited requests receive a 429 with a
+// Retry-After header
+func TestLimitsPerClient(t *testing.T) {
+	trusted, err := parseTrustedProxies("10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{
+		protocol: servers.HTTP,
+		trusted:  trusted,
+		limiter:  &rateLimiter{rate: 0.5, burst: 1},
+	}
+	handler := s.limits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(remote string, headers map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.RemoteAddr = remote
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		status  int
+	}{
+		{"first client through the redirector", "10.0.0.1:443", map[string]string{"X-Forwarded-For": "198.51.100.1"}, http.StatusOK},
+		{"second client through the redirector", "10.0.0.1:443", map[string]string{"X-Forwarded-For": "198.51.100.2"}, http.StatusOK},
+		{"first client again", "10.0.0.1:443", map[string]string{"X-Forwarded-For": "198.51.100.1"}, http.StatusTooManyRequests},
+		{"first client with X-Real-IP", "10.0.0.1:443", map[string]string{"X-Real-IP": "198.51.100.1"}, http.StatusTooManyRequests},
+		{"direct client", "203.0.113.1:50000", nil, http.StatusOK},
+		{"direct client spoofing a new address", "203.0.113.1:50001", map[string]string{"X-Forwarded-For": "198.51.100.3"}, http.StatusTooManyRequests},
+		{"spoofed address was not keyed", "10.0.0.1:443", map[string]string{"X-Forwarded-For": "198.51.100.3"}, http.StatusOK},
+	}
+	for _, test := range tests {
+		w := request(test.remote, test.headers)
+		if w.Code != test.status {
+			t.Fatalf("%s: expected status %d, got %d", test.name, test.status, w.Code)
+		}
+		retry := w.Header().Get("Retry-After")
+		switch {
+		case test.status == http.StatusTooManyRequests && retry != "2":
+			t.Fatalf("%s: expected a Retry-After of 2 seconds, got %q", test.name, retry)
+		case test.status == http.StatusOK && retry != "":
+			t.Fatalf("%s: expected no Retry-After header, got %q", test.name, retry)
+		}
+	}
+}
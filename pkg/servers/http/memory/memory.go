@@ -36,48 +36,38 @@ import (
 // Repository is a structure that implements the Repository interface to store & manage Server objects
 type Repository struct {
 	servers map[uuid.UUID]http.Server
-	sync.Mutex
+	sync.RWMutex
 }
 
-// serverMap is the in-memory structure that holds all the created Server objects
-var serverMap = make(map[uuid.UUID]http.Server)
+// repo is the in-memory structure that holds all the created Server objects
+var repo = &Repository{servers: make(map[uuid.UUID]http.Server)}
 
 // NewRepository is a factory to create and return a repository object to store and manage listeners
 func NewRepository() *Repository {
-	return &Repository{
-		servers: serverMap,
-		Mutex:   sync.Mutex{},
-	}
+	return repo
 }
 
 // Add stores the passed in Server object
 func (r *Repository) Add(server http.Server) error {
-	// Make sure the map exists and create it if not
-	if r.servers == nil {
-		r.Lock()
-		r.servers = make(map[uuid.UUID]http.Server)
-		r.Unlock()
-	}
-	// Make sure the listener isn't already in the map
+	r.Lock()
+	defer r.Unlock()
+	// Make sure the server isn't already in the map
 	if _, ok := r.servers[server.ID()]; ok {
 		return fmt.Errorf("a server with an ID of %s already exists", server.ID())
 	}
-	// Add
-	r.Lock()
 	r.servers[server.ID()] = server
-	r.Unlock()
 	return nil
 }
 
 // SetOption updates the http.Server's configurable option with the provided value
 func (r *Repository) SetOption(id uuid.UUID, option, value string) error {
-	server, err := r.Server(id)
-	if err != nil {
-		return fmt.Errorf("pkg/servers/http/memory.SetOption(): %s", err)
-	}
 	r.Lock()
 	defer r.Unlock()
-	err = server.SetOption(option, value)
+	server, ok := r.servers[id]
+	if !ok {
+		return fmt.Errorf("pkg/servers/http/memory.SetOption(): the server %s does not exist", id)
+	}
+	err := server.SetOption(option, value)
 	if err != nil {
 		return fmt.Errorf("pkg/servers/http/memory.SetOption(): %s", err)
 	}
@@ -87,21 +77,20 @@ func (r *Repository) SetOption(id uuid.UUID, option, value string) error {
 
 // Server returns a Server object for the passed in unique identifier
 func (r *Repository) Server(id uuid.UUID) (http.Server, error) {
-	r.Lock()
-	defer r.Unlock()
-	for _, s := range r.servers {
-		if s.ID() == id {
-			return s, nil
-		}
+	r.RLock()
+	defer r.RUnlock()
+	s, ok := r.servers[id]
+	if !ok {
+		return http.Server{}, fmt.Errorf("pkg/servers/http/memory.Get(): the server %s does not exist", id)
 	}
-	return http.Server{}, fmt.Errorf("pkg/servers/http/memory.Get(): the server %s does not exist", id)
+	return s, nil
 }
 
 // Servers returns a list of all the stored Server objects
 func (r *Repository) Servers() []http.Server {
 	var found []http.Server
-	r.Lock()
-	defer r.Unlock()
+	r.RLock()
+	defer r.RUnlock()
 	for _, s := range r.servers {
 		found = append(found, s)
 	}
@@ -110,12 +99,9 @@ func (r *Repository) Servers() []http.Server {
 
 // Remove deletes the Server object from the database
 func (r *Repository) Remove(id uuid.UUID) {
-	server, err := r.Server(id)
-	if err == nil {
-		r.Lock()
-		defer r.Unlock()
-		delete(serverMap, server.ID())
-	}
+	r.Lock()
+	defer r.Unlock()
+	delete(r.servers, id)
 }
 
 func (r *Repository) Update(server http.Server) error {
@@ -0,0 +1,55 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Methods are the HTTP methods Agents can be configured to send their traffic with
+var Methods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// parseMethods parses a comma-separated list of HTTP methods Agent traffic is accepted with. Using more than one method
+// breaks up verb-based network signatures, such as checking in with a GET and returning results with a POST.
+// Requests without a body are treated as a check-in
+func parseMethods(value string) ([]string, error) {
+	var methods []string
+	for _, method := range strings.Split(value, ",") {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			continue
+		}
+		if !slices.Contains(Methods, method) {
+			return nil, fmt.Errorf("pkg/servers/http.parseMethods(): invalid HTTP method %s, valid methods are: %s", method, strings.Join(Methods, ", "))
+		}
+		if !slices.Contains(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		methods = []string{http.MethodPost}
+	}
+	return methods, nil
+}
@@ -0,0 +1,60 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"reflect"
+	"testing"
+)
+
+func TestParseMethods(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+		err   bool
+	}{
+		{"default", "", []string{"POST"}, false},
+		{"single", "GET", []string{"GET"}, false},
+		{"list", "get, Post ,PUT", []string{"GET", "POST", "PUT"}, false},
+		{"duplicates", "POST,post", []string{"POST"}, false},
+		{"only separators", " , ", []string{"POST"}, false},
+		{"invalid", "GET,CONNECT", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			methods, err := parseMethods(test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error, have %v", methods)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(methods, test.want) {
+				t.Errorf("expected %v, have %v", test.want, methods)
+			}
+		})
+	}
+}
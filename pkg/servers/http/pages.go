@@ -0,0 +1,141 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"bytes"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+)
+
+// errorPages are operator supplied HTML templates used for every error response so the server's error pages match the
+// decoy site instead of Go's default plain text responses. Templates are named after the status code (e.g., 404.html)
+// and default.html is used for any status code without its own template
+type errorPages struct {
+	dir       string // The directory the templates were loaded from
+	templates *template.Template
+}
+
+// errorPage holds the variables available to error page templates (e.g., {{.Path}})
+type errorPage struct {
+	Status     int    // The HTTP status code (e.g., 404)
+	StatusText string // The HTTP status text (e.g., Not Found)
+	Method     string // The request method
+	Host       string // The request Host header
+	Path       string // The request URL path
+	Query      string // The request URL query string
+}
+
+// parseErrorPages loads all the *.html templates in the directory; an empty directory disables error pages
+func parseErrorPages(dir string) (errorPages, error) {
+	if dir == "" {
+		return errorPages{}, nil
+	}
+	templates, err := template.ParseGlob(filepath.Join(dir, "*.html"))
+	if err != nil {
+		return errorPages{}, fmt.Errorf("pkg/servers/http.parseErrorPages(): there was an error parsing the error page templates in %s: %s", dir, err)
+	}
+	return errorPages{dir: dir, templates: templates}, nil
+}
+
+// String returns the directory the templates were loaded from
+func (p errorPages) String() string {
+	return p.dir
+}
+
+// lookup returns the template for the status code, the default template, or nil if neither exists
+func (p errorPages) lookup(code int) *template.Template {
+	if p.templates == nil {
+		return nil
+	}
+	if t := p.templates.Lookup(strconv.Itoa(code) + ".html"); t != nil {
+		return t
+	}
+	return p.templates.Lookup("default.html")
+}
+
+// wrap returns an HTTP handler that replaces the body of error responses with the matching error page template
+func (p errorPages) wrap(next http.Handler) http.Handler {
+	if p.templates == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&errorPageWriter{ResponseWriter: w, pages: p, request: r}, r)
+	})
+}
+
+// errorPageWriter is an HTTP response writer that writes the error page template when an error status code is sent
+// and discards the original error body
+type errorPageWriter struct {
+	http.ResponseWriter
+	pages    errorPages
+	request  *http.Request
+	replaced bool
+}
+
+// WriteHeader sends the status code and, for error status codes with a template, the rendered error page
+func (w *errorPageWriter) WriteHeader(code int) {
+	t := w.pages.lookup(code)
+	if code < 400 || t == nil {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	page := errorPage{
+		Status:     code,
+		StatusText: http.StatusText(code),
+		Method:     w.request.Method,
+		Host:       w.request.Host,
+		Path:       w.request.URL.Path,
+		Query:      w.request.URL.RawQuery,
+	}
+	var body bytes.Buffer
+	if err := t.Execute(&body, page); err != nil {
+		slog.Error(fmt.Sprintf("there was an error executing the %s error page template: %s", t.Name(), err))
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.replaced = true
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	w.ResponseWriter.WriteHeader(code)
+	_, err := w.ResponseWriter.Write(body.Bytes())
+	if err != nil {
+		slog.Debug(fmt.Sprintf("there was an error writing the error page: %s", err))
+	}
+}
+
+// Write discards the original body of a replaced error response
+func (w *errorPageWriter) Write(b []byte) (int, error) {
+	if w.replaced {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original response writer for use with http.ResponseController
+func (w *errorPageWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
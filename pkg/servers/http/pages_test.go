@@ -0,0 +1,115 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestErrorPages verifies error responses use the status code's template, then the default template, and other
+// responses are left alone
+func TestErrorPages(t *testing.T) {
+	dir := t.TempDir()
+	templates := map[string]string{
+		"404.html":     "<h1>{{.Status}} {{.StatusText}}</h1><p>{{.Path}}</p>",
+		"default.html": "<h1>Error {{.Status}}</h1>",
+	}
+	for name, content := range templates {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pages, err := parseErrorPages(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages.String() != dir {
+		t.Errorf("expected the error pages directory to be %s, have %s", dir, pages)
+	}
+
+	tests := []struct {
+		name string
+		code int
+		path string
+		want string
+	}{
+		{"status template", 404, "/<script>", "<h1>404 Not Found</h1><p>/&lt;script&gt;</p>"},
+		{"default template", 405, "/", "<h1>Error 405</h1>"},
+		{"success", 200, "/", "original"},
+		{"redirect", 302, "/", "original"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := pages.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.code)
+				_, _ = w.Write([]byte("original"))
+			}))
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.URL.Path = test.path
+			handler.ServeHTTP(w, r)
+			if w.Code != test.code {
+				t.Errorf("expected status code %d, have %d", test.code, w.Code)
+			}
+			if w.Body.String() != test.want {
+				t.Errorf("expected the body %q, have %q", test.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestParseErrorPages(t *testing.T) {
+	invalid := t.TempDir()
+	if err := os.WriteFile(filepath.Join(invalid, "404.html"), []byte("{{.Status"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		dir  string
+		err  bool
+	}{
+		{"disabled", "", false},
+		{"no templates", t.TempDir(), true},
+		{"invalid template", invalid, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pages, err := parseErrorPages(test.dir)
+			if test.err {
+				if err == nil {
+					t.Error("expected an error parsing the error pages")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pages.templates != nil {
+				t.Error("expected error pages to be disabled")
+			}
+		})
+	}
+}
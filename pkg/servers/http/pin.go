@@ -0,0 +1,71 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+)
+
+// pinHeader is the request header an Agent with a pinned certificate fingerprint uses to report the TLS connections it
+// refused because the server presented a different certificate. The value is the number of refused connections and the
+// SHA256 fingerprint of the last certificate presented, separated by a semicolon
+const pinHeader = "X-Pin-Mismatch"
+
+// fingerprint returns the hex encoded SHA256 hash of the certificate's leaf in DER form. The payload generator embeds it
+// in Agents that pin the listener's certificate
+func fingerprint(certificate tls.Certificate) string {
+	if len(certificate.Certificate) == 0 {
+		return ""
+	}
+	hash := sha256.Sum256(certificate.Certificate[0])
+	return hex.EncodeToString(hash[:])
+}
+
+// pinMismatch alerts the operator that an Agent refused TLS connections because the certificate did not match its
+// pinned fingerprint, which indicates a TLS intercepting proxy between the Agent and the listener
+func (h *Handler) pinMismatch(agentID uuid.UUID, client, report string) {
+	count, presented, _ := strings.Cut(report, ";")
+	refused, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || refused < 1 {
+		slog.Warn("invalid certificate pin mismatch report", "agent", agentID, "client", client, "report", report)
+		return
+	}
+	msg := fmt.Sprintf("Agent %s refused %d TLS connection(s) because the certificate did not match its pinned fingerprint %s; a TLS intercepting proxy may be between the Agent and the listener", agentID, refused, h.fingerprint)
+	if presented = strings.ToLower(strings.TrimSpace(presented)); presented != "" {
+		msg += fmt.Sprintf("\n\tThe last certificate presented to the Agent had the SHA256 fingerprint %s", presented)
+	}
+	slog.Warn(msg, "client", client)
+	memory.NewRepository().Add(message.NewMessage(message.Warn, msg))
+}
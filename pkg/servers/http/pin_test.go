@@ -0,0 +1,135 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+// TestFingerprint verifies the fingerprint is the SHA256 hash of the leaf certificate in DER form
+func TestFingerprint(t *testing.T) {
+	certificate, err := GenerateTLSCert(nil, nil, nil, nil, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(certificate.Certificate[0])
+	tests := []struct {
+		name        string
+		certificate tls.Certificate
+		want        string
+	}{
+		{"leaf", *certificate, hex.EncodeToString(hash[:])},
+		{"empty", tls.Certificate{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if have := fingerprint(test.certificate); have != test.want {
+				t.Errorf("expected fingerprint %q, have %q", test.want, have)
+			}
+		})
+	}
+}
+
+// TestPinMismatch verifies operators are warned when an Agent reports refused connections and malformed reports are
+// ignored
+func TestPinMismatch(t *testing.T) {
+	h := &Handler{listener: uuid.New(), fingerprint: "aa11"}
+	tests := []struct {
+		name      string
+		report    string
+		alerted   bool
+		presented string
+	}{
+		{"count and fingerprint", "3;BB22", true, "bb22"},
+		{"count only", "1", true, ""},
+		{"zero", "0;bb22", false, ""},
+		{"not a number", "many;bb22", false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			agent := uuid.New()
+			h.pinMismatch(agent, "192.0.2.10", test.report)
+			var alert string
+			for _, m := range memory.NewRepository().GetAll() {
+				if strings.Contains(m.Message(), agent.String()) {
+					alert = m.Message()
+				}
+			}
+			if (alert != "") != test.alerted {
+				t.Fatalf("expected an alert %t, have %q", test.alerted, alert)
+			}
+			if test.alerted && !strings.Contains(alert, "pinned fingerprint aa11") {
+				t.Errorf("expected the alert to include the pinned fingerprint, have %q", alert)
+			}
+			if test.presented != "" && !strings.Contains(alert, "SHA256 fingerprint "+test.presented) {
+				t.Errorf("expected the alert to include the presented fingerprint %s, have %q", test.presented, alert)
+			}
+		})
+	}
+}
+
+// TestCertificateReuse verifies a TLS server publishes its certificate's fingerprint and keeps the in-memory
+// certificate when it is restarted so that Agents pinning it can still connect
+func TestCertificateReuse(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	options := GetDefaultOptions(servers.HTTPS)
+	options["Interface"] = "127.0.0.1"
+	options["Port"] = "0"
+	options["PSK"] = "merlin"
+	options["X509Cert"] = missing + ".crt"
+	options["X509Key"] = missing + ".key"
+	s, err := New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pins []string
+	for i := 0; i < 2; i++ {
+		err = s.Listen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = s.listener.Close()
+		pins = append(pins, s.ConfiguredOptions()["CertFingerprint"])
+	}
+	if pins[0] == "" || pins[0] != fingerprint(*s.cert) {
+		t.Errorf("expected the CertFingerprint option to be the in-memory certificate's fingerprint, have %q", pins[0])
+	}
+	if pins[0] != pins[1] {
+		t.Errorf("expected the fingerprint to be unchanged after a restart, have %s and %s", pins[0], pins[1])
+	}
+	if s.handler.fingerprint != pins[0] {
+		t.Errorf("expected the handler to have the fingerprint %s, have %s", pins[0], s.handler.fingerprint)
+	}
+}
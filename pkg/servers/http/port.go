@@ -0,0 +1,125 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"strconv"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+// portRange is the inclusive range of ports the server can listen on. A range of 0-0 lets the operating system select
+// any free port
+type portRange struct {
+	low  int
+	high int
+}
+
+// newPortRange parses a port or a range of ports (e.g., 8000-8100)
+func newPortRange(value string) (portRange, error) {
+	low, high, err := listeners.ParsePorts(value)
+	if err != nil {
+		return portRange{}, err
+	}
+	return portRange{low: low, high: high}, nil
+}
+
+// String returns the port range in the same format it was configured with
+func (p portRange) String() string {
+	if p.low == p.high {
+		return strconv.Itoa(p.low)
+	}
+	return fmt.Sprintf("%d-%d", p.low, p.high)
+}
+
+// candidates returns the ports to try binding to in order. A previously selected port is tried first so that the
+// server keeps the same port across restarts while it is available
+func (p portRange) candidates(selected int) (ports []int) {
+	reuse := selected != 0 && (p.low == 0 || (selected >= p.low && selected <= p.high))
+	if reuse {
+		ports = append(ports, selected)
+	}
+	for port := p.low; port <= p.high; port++ {
+		if !reuse || port != selected {
+			ports = append(ports, port)
+		}
+	}
+	return
+}
+
+// bind creates the server's network listener on the first available port and records the port it is bound to
+func (s *Server) bind() (err error) {
+	for _, port := range s.ports.candidates(s.port) {
+		addr := net.JoinHostPort(s.iface, strconv.Itoa(port))
+		if s.protocol != servers.HTTP3 {
+			// An unspecified IPv6 address (::) listens on both IPv4 and IPv6
+			var l net.Listener
+			l, err = net.Listen("tcp", addr)
+			if err == nil {
+				s.listener = l
+				s.port = l.Addr().(*net.TCPAddr).Port
+				return nil
+			}
+		} else {
+			// Resolve the address so that IPv6 zones (e.g., fe80::1%eth0) are kept
+			var udpAddr *net.UDPAddr
+			udpAddr, err = net.ResolveUDPAddr("udp", addr)
+			if err != nil {
+				return err
+			}
+			var conn *net.UDPConn
+			conn, err = net.ListenUDP("udp", udpAddr)
+			if err == nil {
+				s.udpConn = conn
+				s.port = conn.LocalAddr().(*net.UDPAddr).Port
+				return nil
+			}
+		}
+	}
+	if s.ports.low != s.ports.high {
+		return fmt.Errorf("there were no free ports in the range %s on %s: %s", s.ports, s.iface, err)
+	}
+	return err
+}
+
+// Reserve checks that the server's address is available by binding to it and immediately releasing it. This surfaces
+// errors like "address already in use" when the server is created instead of when it is started in a go routine.
+// When the port is 0 or a range, the selected port is kept and reported by Port() and ConfiguredOptions()
+func (s *Server) Reserve() error {
+	err := s.bind()
+	if err != nil {
+		return fmt.Errorf("pkg/servers/http.Reserve(): the %s server can not listen on %s: %s", s.ProtocolString(), s.iface, err)
+	}
+	if s.listener != nil {
+		err = s.listener.Close()
+		s.listener = nil
+	}
+	if s.udpConn != nil {
+		err = s.udpConn.Close()
+		s.udpConn = nil
+	}
+	return err
+}
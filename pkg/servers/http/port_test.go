@@ -0,0 +1,118 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net"
+	"reflect"
+	"strconv"
+	"testing"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+func TestCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		ports    portRange
+		selected int
+		want     []int
+	}{
+		{"port", portRange{443, 443}, 0, []int{443}},
+		{"any", portRange{0, 0}, 0, []int{0}},
+		{"any previously selected", portRange{0, 0}, 8443, []int{8443, 0}},
+		{"range", portRange{8000, 8002}, 0, []int{8000, 8001, 8002}},
+		{"range previously selected", portRange{8000, 8002}, 8001, []int{8001, 8000, 8002}},
+		{"selected outside the range", portRange{8000, 8002}, 9000, []int{8000, 8001, 8002}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ports.candidates(test.selected)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, have %v", test.want, got)
+			}
+		})
+	}
+}
+
+// TestReserve verifies the server selects a free port when the port is 0 or a range and returns an error when its
+// port is already in use
+func TestReserve(t *testing.T) {
+	// Occupy a port and use it as the start of a range
+	used, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer used.Close()
+	port := used.Addr().(*net.TCPAddr).Port
+	if port == 65535 {
+		t.Skip("the occupied port can not start a range")
+	}
+
+	tests := []struct {
+		name  string
+		ports string
+		want  int
+		err   bool
+	}{
+		{"any", "0", 0, false},
+		{"range", strconv.Itoa(port) + "-" + strconv.Itoa(port+1), port + 1, false},
+		{"in use", strconv.Itoa(port), 0, true},
+		{"range in use", strconv.Itoa(port) + "-" + strconv.Itoa(port), 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := GetDefaultOptions(servers.HTTP)
+			options["Interface"] = "127.0.0.1"
+			options["Port"] = test.ports
+			options["PSK"] = "merlin"
+			s, err := New(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Reserve()
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error reserving port %s", test.ports)
+				}
+				return
+			}
+			if err != nil {
+				// Another process may have taken the next port in the range
+				t.Skip(err)
+			}
+			if s.port == 0 {
+				t.Fatal("expected a port to be selected")
+			}
+			if test.want != 0 && s.port != test.want {
+				t.Errorf("expected port %d to be selected, have %d", test.want, s.port)
+			}
+			if s.listener != nil {
+				t.Error("expected the reserved port to be released")
+			}
+			if s.ConfiguredOptions()["Port"] != strconv.Itoa(s.port) {
+				t.Errorf("expected the configured options to report port %d, have %s", s.port, s.ConfiguredOptions()["Port"])
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// trustedProxies is a list of redirector networks whose X-Forwarded-For and X-Real-IP headers are trusted to contain
+// the real client address
+type trustedProxies []*net.IPNet
+
+// parseTrustedProxies parses a comma-separated list of IP addresses or CIDR networks
+func parseTrustedProxies(value string) (trustedProxies, error) {
+	var proxies trustedProxies
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("pkg/servers/http.parseTrustedProxies(): invalid IP address: %s", entry)
+			}
+			if ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("pkg/servers/http.parseTrustedProxies(): %s", err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// String returns the trusted proxies as a comma-separated list of CIDR networks
+func (t trustedProxies) String() string {
+	var networks []string
+	for _, network := range t {
+		networks = append(networks, network.String())
+	}
+	return strings.Join(networks, ",")
+}
+
+// trusted determines if the IP address belongs to a trusted redirector
+func (t trustedProxies) trusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range t {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the real address of the client that sent the request.
+// Forwarding headers are only used when the request came directly from a trusted redirector; otherwise they could
+// be spoofed by the client. The X-Forwarded-For header is walked from right to left, skipping trusted redirectors,
+// so the first untrusted address is the client. The X-Real-IP header is used when X-Forwarded-For is missing.
+func (t trustedProxies) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !t.trusted(net.ParseIP(host)) {
+		return host
+	}
+
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			ip := net.ParseIP(hop)
+			if ip == nil {
+				// A malformed hop can't be trusted so stop at the last address that was known to be good
+				return host
+			}
+			host = hop
+			if !t.trusted(ip) {
+				return host
+			}
+		}
+		return host
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return host
+}
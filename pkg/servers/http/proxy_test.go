@@ -0,0 +1,93 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"addresses", "10.0.0.1, 2001:db8::1", "10.0.0.1/32,2001:db8::1/128", false},
+		{"networks", "192.168.0.0/16,,172.16.0.0/12", "192.168.0.0/16,172.16.0.0/12", false},
+		{"invalid address", "10.0.0", "", true},
+		{"invalid network", "10.0.0.0/33", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseTrustedProxies(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if got.String() != test.want {
+				t.Errorf("expected %q, have %q", test.want, got.String())
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := parseTrustedProxies("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string][]string
+		want    string
+	}{
+		{"direct", "203.0.113.5:443", nil, "203.0.113.5"},
+		{"untrusted spoofed header", "203.0.113.5:443", map[string][]string{"X-Forwarded-For": {"198.51.100.1"}}, "203.0.113.5"},
+		{"trusted redirector", "10.0.0.2:443", map[string][]string{"X-Forwarded-For": {"198.51.100.1"}}, "198.51.100.1"},
+		{"chained redirectors", "10.0.0.2:443", map[string][]string{"X-Forwarded-For": {"1.1.1.1, 198.51.100.1, 10.0.0.3"}}, "198.51.100.1"},
+		{"multiple header values", "10.0.0.2:443", map[string][]string{"X-Forwarded-For": {"1.1.1.1", "198.51.100.1"}}, "198.51.100.1"},
+		{"malformed hop", "10.0.0.2:443", map[string][]string{"X-Forwarded-For": {"garbage, 10.0.0.3"}}, "10.0.0.3"},
+		{"all trusted", "10.0.0.2:443", map[string][]string{"X-Forwarded-For": {"10.0.0.4, 10.0.0.3"}}, "10.0.0.4"},
+		{"real IP", "10.0.0.2:443", map[string][]string{"X-Real-Ip": {"198.51.100.2"}}, "198.51.100.2"},
+		{"invalid real IP", "10.0.0.2:443", map[string][]string{"X-Real-Ip": {"nope"}}, "10.0.0.2"},
+		{"no port", "10.0.0.2", nil, "10.0.0.2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.RemoteAddr = test.remote
+			for name, values := range test.headers {
+				for _, v := range values {
+					r.Header.Add(name, v)
+				}
+			}
+			if got := proxies.clientIP(r); got != test.want {
+				t.Errorf("expected %s, have %s", test.want, got)
+			}
+		})
+	}
+}
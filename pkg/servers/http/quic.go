@@ -0,0 +1,90 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/quic-go/quic-go"
+)
+
+// quicOptions holds the QUIC transport tuning used by the HTTP/3 server.
+// Mobile and NAT-heavy Agent populations benefit from long idle timeouts and keep-alives so that they don't have to
+// re-handshake every check-in, which both hurts latency and creates log noise.
+type quicOptions struct {
+	idleTimeout time.Duration // The maximum time without network activity before the connection is closed; 0 means it never practically times out
+	keepAlive   time.Duration // How often a keep-alive packet is sent; 0 disables keep-alives
+	maxStreams  int64         // The maximum number of concurrent bidirectional streams a peer can open; 0 uses the QUIC default
+	allow0RTT   bool          // Accept 0-RTT early data from Agents resuming a previous session; early data can be replayed
+}
+
+// set parses and sets the QUIC tuning option
+func (q *quicOptions) set(option, value string) (err error) {
+	switch strings.ToLower(option) {
+	case "quicidletimeout":
+		q.idleTimeout, err = time.ParseDuration(value)
+	case "quickeepalive":
+		q.keepAlive, err = time.ParseDuration(value)
+	case "quicmaxstreams":
+		q.maxStreams, err = strconv.ParseInt(value, 10, 64)
+	case "quic0rtt":
+		q.allow0RTT, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("pkg/servers/http.quicOptions.set(): invalid QUIC option: %s", option)
+	}
+	if err != nil {
+		return fmt.Errorf("pkg/servers/http.quicOptions.set(): there was an error parsing the %s value %s: %s", option, value, err)
+	}
+	if q.idleTimeout < 0 || q.keepAlive < 0 || q.maxStreams < 0 {
+		return fmt.Errorf("pkg/servers/http.quicOptions.set(): the %s value %s can not be negative", option, value)
+	}
+	return nil
+}
+
+// options returns the QUIC tuning as a map of configurable options
+func (q *quicOptions) options() map[string]string {
+	options := make(map[string]string)
+	options["QUICIdleTimeout"] = q.idleTimeout.String()
+	options["QUICKeepAlive"] = q.keepAlive.String()
+	options["QUICMaxStreams"] = strconv.FormatInt(q.maxStreams, 10)
+	options["QUIC0RTT"] = strconv.FormatBool(q.allow0RTT)
+	return options
+}
+
+// config returns the QUIC configuration used by the HTTP/3 server
+func (q *quicOptions) config() *quic.Config {
+	idle := q.idleTimeout
+	if idle == 0 {
+		// Opted for a long timeout to prevent the client from sending an HTTP/2 PING Frame
+		idle = time.Until(time.Now().AddDate(0, 42, 0))
+	}
+	return &quic.Config{
+		MaxIdleTimeout:     idle,
+		KeepAlivePeriod:    q.keepAlive,
+		MaxIncomingStreams: q.maxStreams,
+		Allow0RTT:          q.allow0RTT,
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"testing"
+	"time"
+)
+
+func TestQUICOptionsSet(t *testing.T) {
+	tests := []struct {
+		option  string
+		value   string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{"QUICIdleTimeout", "5m", "QUICIdleTimeout", "5m0s", false},
+		{"quickeepalive", "15s", "QUICKeepAlive", "15s", false},
+		{"QUICMaxStreams", "200", "QUICMaxStreams", "200", false},
+		{"QUIC0RTT", "true", "QUIC0RTT", "true", false},
+		{"QUICIdleTimeout", "forever", "", "", true},
+		{"QUICKeepAlive", "-1s", "", "", true},
+		{"QUICMaxStreams", "-5", "", "", true},
+		{"QUIC0RTT", "maybe", "", "", true},
+		{"QUICUnknown", "1", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.option+"="+test.value, func(t *testing.T) {
+			var q quicOptions
+			err := q.set(test.option, test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if got := q.options()[test.key]; got != test.want {
+				t.Errorf("expected %s to be %s, have %s", test.key, test.want, got)
+			}
+		})
+	}
+}
+
+func TestQUICConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		q    quicOptions
+		idle time.Duration // idle is the minimum expected idle timeout
+	}{
+		{"defaults", quicOptions{}, 24 * time.Hour * 365},
+		{"tuned", quicOptions{idleTimeout: time.Minute, keepAlive: 10 * time.Second, maxStreams: 50, allow0RTT: true}, time.Minute},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := test.q.config()
+			if c.MaxIdleTimeout < test.idle {
+				t.Errorf("expected an idle timeout of at least %s, have %s", test.idle, c.MaxIdleTimeout)
+			}
+			if c.KeepAlivePeriod != test.q.keepAlive || c.MaxIncomingStreams != test.q.maxStreams || c.Allow0RTT != test.q.allow0RTT {
+				t.Errorf("the QUIC configuration %+v does not match the options %+v", c, test.q)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/servers"
+)
+
+// Schema returns the type, default value, and validation for every configurable HTTP server option for the protocol
+func Schema(protocol int) listeners.Schema {
+	defaults := GetDefaultOptions(protocol)
+	schema := listeners.Schema{
+		{Name: "Protocol", Type: listeners.TypeEnum, Choices: []string{"HTTP", "HTTPS", "H2C", "HTTP2", "HTTP3"}, Required: true, Description: "The HTTP protocol version the server uses"},
+		{Name: "Interface", Type: listeners.TypeIP, Required: true, Description: "The IPv4 or IPv6 network interface address the server listens on; :: listens on all IPv4 and IPv6 addresses"},
+		{Name: "Port", Type: listeners.TypePorts, Required: true, Description: "The port the server listens on. Use 0 for any free port or a range (e.g., 8000-8100) for the first free port in the range"},
+		{Name: "JWTKey", Type: listeners.TypeKey32, Required: true, Description: "The Base64 encoded 32-byte key used to sign JWTs"},
+		{Name: "JWTLeeway", Type: listeners.TypeDuration, Required: true, Description: "The flexibility allowed in the JWT expiration time; less than 0 disables the check"},
+		{Name: "URLS", Type: listeners.TypeString, Pattern: `^/[^,]*(,/[^,]*)*$`, Description: "The comma separated list of URLs that handle Agent traffic"},
+		{Name: "URIPool", Type: listeners.TypeString, Pattern: `^/[^,]*(,/[^,]*)*$`, Description: "The comma separated list of additional check-in URIs Agents can randomly select from"},
+		{Name: "URIRotation", Type: listeners.TypeDuration, Description: "The period used to rotate the scheduled check-in URI; 0 disables rotation"},
+		{Name: "Headers", Type: listeners.TypeString, Description: "The pipe-delimited list of \"Name: value\" headers added to every response"},
+		{Name: "URIHeaders", Type: listeners.TypeString, Pattern: `^\{.*\}$`, Description: "A JSON object of URIs to the headers added to their responses"},
+		{Name: "TrustedProxies", Type: listeners.TypeString, Description: "The comma separated list of redirector IP addresses or CIDR networks whose forwarding headers are trusted"},
+		{Name: "Canary", Type: listeners.TypeBool, Description: "Record and alert on every request without ever processing Agent traffic to detect when the listener is discovered"},
+		{Name: "AccessLog", Type: listeners.TypeString, Description: "The file path of an Apache style access log, separate from the Merlin server log; empty disables the access log"},
+		{Name: "AccessLogFormat", Type: listeners.TypeEnum, Choices: []string{"common", "combined"}, Description: "The access log format"},
+		{Name: "Methods", Type: listeners.TypeList, Choices: Methods, Description: "The comma separated list of HTTP methods Agent traffic is accepted with; requests without a body are check-ins"},
+		{Name: "Transport", Type: listeners.TypeEnum, Choices: Transports, Description: "Where Agents put their messages: the body, or Base64 URL encoded cookies or headers chunked as <name>, <name>-1, <name>-2, etc."},
+		{Name: "TransportName", Type: listeners.TypeString, Pattern: `^[A-Za-z0-9_-]+$`, Description: "The cookie or header name Agent messages are in when the Transport is cookie or header"},
+		{Name: "HealthURI", Type: listeners.TypeString, Pattern: `^/`, Description: "A URI for load balancer health checks that returns 200 OK with decoy content without processing Agent traffic; empty disables it"},
+		{Name: "HealthContent", Type: listeners.TypeString, Description: "The decoy content returned for health checks"},
+		{Name: "ErrorPages", Type: listeners.TypeString, Description: "The directory of HTML templates used for error responses, named after the status code (e.g., 404.html) or default.html; templates can use {{.Status}}, {{.StatusText}}, {{.Method}}, {{.Host}}, {{.Path}}, and {{.Query}}"},
+		{Name: "GeoIPCountries", Type: listeners.TypeString, Pattern: `^[A-Za-z]{2}(,[A-Za-z]{2})*$`, Description: "The comma separated list of ISO country codes Agent traffic is accepted from; requires a GeoIP database"},
+		{Name: "MaxConnections", Type: listeners.TypeInt, Pattern: `^\d+$`, Description: "The maximum number of concurrent connections; 0 is unlimited"},
+		{Name: "RateLimit", Type: listeners.TypeFloat, Pattern: `^\d*\.?\d+$`, Description: "The number of requests per second a single client IP address can make; 0 is unlimited"},
+		{Name: "RateBurst", Type: listeners.TypeInt, Pattern: `^\d+$`, Description: "The number of requests a single client IP address can make at once before being rate limited"},
+	}
+	if protocol != servers.HTTP && protocol != servers.H2C {
+		schema = append(schema,
+			listeners.Option{Name: "X509Cert", Type: listeners.TypeString, Description: "The x.509 public certificate file; an in-memory certificate is generated if it is not found"},
+			listeners.Option{Name: "X509Key", Type: listeners.TypeString, Description: "The x.509 private key file"},
+		)
+	}
+	if protocol == servers.HTTP3 {
+		schema = append(schema,
+			listeners.Option{Name: "QUICIdleTimeout", Type: listeners.TypeDuration, Pattern: `^[^-]`, Description: "The maximum time without network activity before the connection is closed; 0 never practically times out"},
+			listeners.Option{Name: "QUICKeepAlive", Type: listeners.TypeDuration, Pattern: `^[^-]`, Description: "How often a keep-alive packet is sent; 0 disables keep-alives"},
+			listeners.Option{Name: "QUICMaxStreams", Type: listeners.TypeInt, Pattern: `^\d+$`, Description: "The maximum number of concurrent streams a peer can open; 0 uses the QUIC default"},
+			listeners.Option{Name: "QUIC0RTT", Type: listeners.TypeBool, Description: "Accept 0-RTT early data from Agents resuming a previous session"},
+		)
+	}
+	for i := range schema {
+		schema[i].Default = defaults[schema[i].Name]
+	}
+	return schema
+}
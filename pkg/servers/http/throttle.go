@@ -0,0 +1,63 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// throttleInterval is how often a piece of a throttled response is written
+const throttleInterval = 100 * time.Millisecond
+
+// writeThrottled writes the response to the Agent no faster than the provided kilobits per second.
+// The write deadline is extended to cover how long the response takes to send at that rate
+func writeThrottled(w http.ResponseWriter, r *http.Request, data []byte, kbps int) (n int, err error) {
+	rate := kbps * 1000 / 8 // bytes per second
+	chunk := max(rate*int(throttleInterval)/int(time.Second), 1)
+
+	rc := http.NewResponseController(w)
+	// Not every server (e.g., HTTP/3) supports changing the deadline; the server's timeout applies then
+	_ = rc.SetWriteDeadline(time.Now().Add(time.Duration(len(data)/max(rate, 1))*time.Second + 30*time.Second))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+
+	ticker := time.NewTicker(throttleInterval)
+	defer ticker.Stop()
+	for n < len(data) {
+		var written int
+		written, err = w.Write(data[n:min(n+chunk, len(data))])
+		n += written
+		if err != nil {
+			return
+		}
+		_ = rc.Flush()
+		if n < len(data) {
+			select {
+			case <-r.Context().Done():
+				return n, r.Context().Err()
+			case <-ticker.C:
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,77 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestWriteThrottled verifies responses are paced at the Agent's bandwidth limit and stop when the request is cancelled
+func TestWriteThrottled(t *testing.T) {
+	data := bytes.Repeat([]byte("m"), 300)
+	tests := []struct {
+		name    string
+		kbps    int
+		cancel  bool
+		minimum time.Duration
+	}{
+		// 8 kbps is 1,000 bytes per second written 100 bytes at a time
+		{"paced", 8, false, 2 * throttleInterval},
+		{"fast", 8000, false, 0},
+		{"cancelled", 8, true, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if test.cancel {
+				cancel()
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", nil).WithContext(ctx)
+
+			start := time.Now()
+			n, err := writeThrottled(w, r, data, test.kbps)
+			elapsed := time.Since(start)
+			if test.cancel {
+				if err == nil || n >= len(data) {
+					t.Errorf("expected the write to stop with an error, wrote %d bytes: %v", n, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(data) || !bytes.Equal(w.Body.Bytes(), data) {
+				t.Errorf("expected all %d bytes to be written, have %d", len(data), n)
+			}
+			if elapsed < test.minimum {
+				t.Errorf("expected the write to take at least %s, took %s", test.minimum, elapsed)
+			}
+		})
+	}
+}
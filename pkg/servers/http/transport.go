@@ -0,0 +1,152 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Message transports Agents can send their messages with
+const (
+	transportBody   = "body"   // The message is the request body
+	transportCookie = "cookie" // The message is Base64 URL encoded in one or more cookies
+	transportHeader = "header" // The message is Base64 URL encoded in one or more request headers
+)
+
+// Transports are the ways Agents can send their messages to the HTTP server
+var Transports = []string{transportBody, transportCookie, transportHeader}
+
+// maxChunks is the most cookies or headers a single message can be split across
+const maxChunks = 64
+
+// messageTransport describes where the Agent message is in the request. Cookie and header transports defeat data loss
+// prevention that only inspects request bodies. Messages too large for one value are split into chunks named
+// <name>, <name>-1, <name>-2, and so on; a message with a missing chunk is rejected. When the cookie or header is
+// missing, the body is used
+type messageTransport struct {
+	mode string // One of the Transports
+	name string // The cookie or header name the message is in
+}
+
+// parseMessageTransport validates the message transport mode and the cookie or header name
+func parseMessageTransport(mode, name string) (messageTransport, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	switch mode {
+	case "":
+		mode = transportBody
+	case transportBody, transportCookie, transportHeader:
+	default:
+		return messageTransport{}, fmt.Errorf("pkg/servers/http.parseMessageTransport(): invalid transport %s, valid transports are: %s", mode, strings.Join(Transports, ", "))
+	}
+	name = strings.TrimSpace(name)
+	if mode != transportBody && name == "" {
+		return messageTransport{}, fmt.Errorf("pkg/servers/http.parseMessageTransport(): the %s transport requires a name", mode)
+	}
+	return messageTransport{mode: mode, name: name}, nil
+}
+
+// read returns the Agent message from the request
+func (t messageTransport) read(r *http.Request) ([]byte, error) {
+	if t.mode == transportBody {
+		return io.ReadAll(r.Body)
+	}
+
+	chunks := t.chunks(r)
+	if len(chunks) == 0 {
+		return io.ReadAll(r.Body)
+	}
+	if len(chunks) > maxChunks {
+		return nil, fmt.Errorf("pkg/servers/http.read(): the message in the %s %s was split into %d chunks, more than the maximum of %d", t.name, t.mode, len(chunks), maxChunks)
+	}
+
+	// Chunks must be contiguous from the first one; a gap means part of the message was dropped along the way
+	var encoded strings.Builder
+	for i := 0; i < len(chunks); i++ {
+		value, ok := chunks[i]
+		if !ok {
+			return nil, fmt.Errorf("pkg/servers/http.read(): the message in the %s %s is truncated, chunk %d of %d is missing", t.name, t.mode, i, len(chunks))
+		}
+		encoded.WriteString(value)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded.String(), "="))
+	if err != nil {
+		return nil, fmt.Errorf("pkg/servers/http.read(): there was an error Base64 decoding the message in the %s %s: %s", t.name, t.mode, err)
+	}
+	return data, nil
+}
+
+// chunks returns the non-empty cookies or headers that carry the Agent message, keyed by their chunk number
+func (t messageTransport) chunks(r *http.Request) map[int]string {
+	chunks := make(map[int]string)
+	add := func(name, value string) {
+		if value == "" {
+			return
+		}
+		i, ok := t.chunk(name)
+		if !ok {
+			return
+		}
+		// The first cookie or header with the name wins, like http.Request.Cookie() and http.Header.Get()
+		if _, exists := chunks[i]; !exists {
+			chunks[i] = value
+		}
+	}
+	if t.mode == transportCookie {
+		for _, cookie := range r.Cookies() {
+			add(cookie.Name, cookie.Value)
+		}
+		return chunks
+	}
+	for key, values := range r.Header {
+		if len(values) > 0 {
+			add(key, values[0])
+		}
+	}
+	return chunks
+}
+
+// chunk returns the chunk number of the cookie or header name if it is part of the Agent message.
+// Header names are compared in their canonical form
+func (t messageTransport) chunk(name string) (int, bool) {
+	prefix := t.name
+	if t.mode == transportHeader {
+		prefix = http.CanonicalHeaderKey(prefix)
+		name = http.CanonicalHeaderKey(name)
+	}
+	if name == prefix {
+		return 0, true
+	}
+	suffix, ok := strings.CutPrefix(name, prefix+"-")
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(suffix)
+	if err != nil || i < 1 || strconv.Itoa(i) != suffix {
+		return 0, false
+	}
+	return i, true
+}
@@ -0,0 +1,150 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// split Base64 URL encodes the message and splits it into the number of chunks
+func split(message []byte, count int) []string {
+	encoded := base64.URLEncoding.EncodeToString(message)
+	var chunks []string
+	for i := 0; i < count; i++ {
+		chunks = append(chunks, encoded[i*len(encoded)/count:(i+1)*len(encoded)/count])
+	}
+	return chunks
+}
+
+// chunkName returns the cookie or header name for the chunk number
+func chunkName(name string, i int) string {
+	if i == 0 {
+		return name
+	}
+	return name + "-" + strconv.Itoa(i)
+}
+
+// TestMessageTransportRead verifies the Agent message is read from the request body, cookies, or headers
+func TestMessageTransportRead(t *testing.T) {
+	message := bytes.Repeat([]byte("merlin agent message"), 20)
+	encoded := base64.URLEncoding.EncodeToString(message)
+	if !strings.HasSuffix(encoded, "=") {
+		t.Fatal("the test message must require Base64 padding")
+	}
+
+	type readTest struct {
+		name    string
+		mode    string
+		body    string
+		values  map[string]string // The cookies or headers added to the request
+		want    []byte
+		wantErr string
+	}
+	tests := []readTest{
+		{name: "body", mode: transportBody, body: string(message), want: message},
+		{name: "single cookie", mode: transportCookie, values: map[string]string{"session": encoded}, want: message},
+		{name: "unpadded Base64", mode: transportHeader, values: map[string]string{"X-Data": strings.TrimRight(encoded, "=")}, want: message},
+		{name: "body fallback without cookies", mode: transportCookie, body: string(message), values: map[string]string{"other": "value"}, want: message},
+		{name: "body fallback with an empty header", mode: transportHeader, body: string(message), values: map[string]string{"X-Data": ""}, want: message},
+		{name: "names that aren't chunks are ignored", mode: transportCookie, values: map[string]string{"session": encoded, "session-01": "AAAA", "session-x": "AAAA", "session-0": "AAAA"}, want: message},
+		{name: "missing first chunk", mode: transportCookie, values: map[string]string{"session-1": encoded}, wantErr: "chunk 0 of 1 is missing"},
+		{name: "invalid Base64", mode: transportHeader, values: map[string]string{"X-Data": "not*base64"}, wantErr: "Base64 decoding"},
+	}
+
+	// Multiple chunks for each transport
+	for _, mode := range []string{transportCookie, transportHeader} {
+		name := "session"
+		if mode == transportHeader {
+			name = "X-Data"
+		}
+		full := make(map[string]string)
+		for i, chunk := range split(message, 5) {
+			full[chunkName(name, i)] = chunk
+		}
+		tests = append(tests, readTest{name: mode + " chunks are reassembled", mode: mode, values: full, want: message})
+
+		gap := make(map[string]string)
+		for k, v := range full {
+			gap[k] = v
+		}
+		delete(gap, chunkName(name, 2))
+		tests = append(tests, readTest{name: mode + " missing middle chunk", mode: mode, values: gap, wantErr: "chunk 2 of 4 is missing"})
+	}
+
+	// The maximum number of chunks and one more
+	for _, count := range []int{maxChunks, maxChunks + 1} {
+		values := make(map[string]string)
+		for i, chunk := range split(message, count) {
+			values[chunkName("X-Data", i)] = chunk
+		}
+		if len(values) != count {
+			t.Fatalf("expected the message to be split into %d chunks, got %d", count, len(values))
+		}
+		test := readTest{name: strconv.Itoa(count) + " chunks", mode: transportHeader, values: values, want: message}
+		if count > maxChunks {
+			test.want = nil
+			test.wantErr = "more than the maximum of 64"
+		}
+		tests = append(tests, test)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transport, err := parseMessageTransport(test.mode, "session")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.mode == transportHeader {
+				// Header names are case-insensitive
+				transport.name = "x-data"
+			}
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			for name, value := range test.values {
+				if test.mode == transportCookie {
+					r.AddCookie(&http.Cookie{Name: name, Value: value})
+				} else {
+					r.Header.Set(name, value)
+				}
+			}
+
+			data, err := transport.read(r)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected an error containing %q, got: %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, test.want) {
+				t.Fatalf("expected the message %q, got %q", test.want, data)
+			}
+		})
+	}
+}
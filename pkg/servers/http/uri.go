@@ -0,0 +1,112 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// uriPool is the set of check-in URIs an HTTP server accepts in addition to its URLS option.
+// Agents can randomly select a URI from the pool on every request so that proxy logs don't show every check-in
+// going to a single static path. The server always accepts every URI in the pool.
+type uriPool struct {
+	uris     []string      // The URIs in the pool; an entry ending in "*" accepts any path below its prefix
+	rotation time.Duration // The period used to rotate the scheduled URI; 0 disables rotation
+	seed     []byte        // Shared value used to derive the rotation schedule so both ends arrive at the same URI
+}
+
+// parseURIPool parses a comma-separated list of URIs into a slice, validating that each entry is an absolute path
+func parseURIPool(value string) ([]string, error) {
+	var uris []string
+	if value == "" {
+		return uris, nil
+	}
+	for _, uri := range strings.Split(value, ",") {
+		uri = strings.TrimSpace(uri)
+		if uri == "" {
+			continue
+		}
+		if !strings.HasPrefix(uri, "/") {
+			return nil, fmt.Errorf("pkg/servers/http.parseURIPool(): the URI \"%s\" must start with a \"/\"", uri)
+		}
+		if strings.Contains(strings.TrimSuffix(uri, "*"), "*") {
+			return nil, fmt.Errorf("pkg/servers/http.parseURIPool(): the URI \"%s\" can only contain a trailing \"*\" wildcard", uri)
+		}
+		uris = append(uris, uri)
+	}
+	return uris, nil
+}
+
+// patterns returns the HTTP multiplexer patterns needed to accept every URI in the pool.
+// Wildcard entries are converted into subtree patterns (e.g., /static/* becomes /static/)
+func (p uriPool) patterns() []string {
+	var patterns []string
+	for _, uri := range p.uris {
+		if strings.HasSuffix(uri, "*") {
+			uri = strings.TrimSuffix(uri, "*")
+			if !strings.HasSuffix(uri, "/") {
+				uri += "/"
+			}
+		}
+		patterns = append(patterns, uri)
+	}
+	return patterns
+}
+
+// scheduled returns the pool URI scheduled for the rotation window that contains the provided time.
+// The schedule is derived from the seed and the window number so that any party with the seed computes the same URI.
+// An empty string is returned if rotation is disabled or the pool is empty
+func (p uriPool) scheduled(t time.Time) string {
+	if p.rotation <= 0 || len(p.uris) == 0 {
+		return ""
+	}
+	window := make([]byte, 8)
+	binary.BigEndian.PutUint64(window, uint64(t.UnixNano()/int64(p.rotation)))
+	sum := sha256.Sum256(append(append([]byte{}, p.seed...), window...))
+	return p.uris[binary.BigEndian.Uint64(sum[:8])%uint64(len(p.uris))]
+}
+
+// onSchedule determines if the requested path matches the URI scheduled for the current or previous rotation window.
+// The previous window is accepted to account for clock skew and Agent sleep times that span a rotation
+func (p uriPool) onSchedule(path string, t time.Time) bool {
+	if p.rotation <= 0 {
+		return true
+	}
+	for _, uri := range []string{p.scheduled(t), p.scheduled(t.Add(-p.rotation))} {
+		if match(uri, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// match determines if the path matches the pool URI, taking a trailing "*" wildcard into account
+func match(uri, path string) bool {
+	if strings.HasSuffix(uri, "*") {
+		return strings.HasPrefix(path, strings.TrimSuffix(uri, "*"))
+	}
+	return uri == path
+}
@@ -0,0 +1,156 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package http
+
+import (
+	// Standard
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseURIPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"list", "/a, /b/c ,/static/*", []string{"/a", "/b/c", "/static/*"}, false},
+		{"blank entries", "/a,,/b,", []string{"/a", "/b"}, false},
+		{"relative", "/a,b", nil, true},
+		{"inner wildcard", "/a/*/b", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseURIPool(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("expected %v, have %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	p := uriPool{uris: []string{"/a", "/static/*", "/images*"}}
+	want := "/a,/static/,/images/"
+	if got := strings.Join(p.patterns(), ","); got != want {
+		t.Errorf("expected %s, have %s", want, got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		uri   string
+		path  string
+		match bool
+	}{
+		{"/a", "/a", true},
+		{"/a", "/a/b", false},
+		{"/static/*", "/static/js/app.js", true},
+		{"/static/*", "/other/app.js", false},
+		{"", "/a", false},
+	}
+	for _, test := range tests {
+		t.Run(test.uri+test.path, func(t *testing.T) {
+			if got := match(test.uri, test.path); got != test.match {
+				t.Errorf("expected %t, have %t", test.match, got)
+			}
+		})
+	}
+}
+
+func TestScheduled(t *testing.T) {
+	uris := []string{"/a", "/b", "/c", "/d", "/e", "/f", "/g", "/h"}
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name string
+		pool uriPool
+		want bool // want a URI from the pool
+	}{
+		{"disabled", uriPool{uris: uris}, false},
+		{"empty pool", uriPool{rotation: time.Hour}, false},
+		{"enabled", uriPool{uris: uris, rotation: time.Hour, seed: []byte("seed")}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.pool.scheduled(now)
+			if (got != "") != test.want {
+				t.Fatalf("expected a scheduled URI %t, have %q", test.want, got)
+			}
+			if got != "" && !strings.Contains(strings.Join(uris, ","), got) {
+				t.Errorf("scheduled URI %s is not in the pool", got)
+			}
+		})
+	}
+
+	// The schedule is deterministic for a seed and window and changes across windows
+	p := uriPool{uris: uris, rotation: time.Minute, seed: []byte("seed")}
+	if p.scheduled(now) != p.scheduled(now.Add(time.Second)) {
+		t.Error("expected the same URI within a rotation window")
+	}
+	seen := make(map[string]bool)
+	for i := 0; i < 64; i++ {
+		seen[p.scheduled(now.Add(time.Duration(i)*time.Minute))] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected the scheduled URI to rotate across windows")
+	}
+}
+
+func TestOnSchedule(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	p := uriPool{uris: []string{"/a", "/b", "/c", "/d"}, rotation: time.Minute, seed: []byte("seed")}
+	current := p.scheduled(now)
+	previous := p.scheduled(now.Add(-time.Minute))
+
+	var off string
+	for _, uri := range p.uris {
+		if uri != current && uri != previous {
+			off = uri
+			break
+		}
+	}
+
+	tests := []struct {
+		name string
+		pool uriPool
+		path string
+		want bool
+	}{
+		{"disabled", uriPool{uris: p.uris}, "/anything", true},
+		{"current window", p, current, true},
+		{"previous window", p, previous, true},
+		{"off schedule", p, off, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.pool.onSchedule(test.path, now); got != test.want {
+				t.Errorf("expected %t, have %t", test.want, got)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ package servers
 
 import (
 	// Standard
+	"context"
 	"strings"
 
 	// 3rd Party
@@ -57,9 +58,10 @@ type ServerInterface interface {
 	ProtocolString() string
 	Port() int
 	SetOption(string, string) error
-	Start()
+	Start(ctx context.Context)
 	Status() string
 	Stop() error
+	Stopped() <-chan error
 }
 
 // Protocol is used to transform a server protocol constant into a string for use in written messages or logs
@@ -23,8 +23,11 @@ package agent
 
 import (
 	// Standard
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
 
 	// 3rd Party
@@ -36,14 +39,26 @@ import (
 	// Merlin
 	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
 	"github.com/Ne0nd0g/merlin/v2/pkg/agents/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	messageMemory "github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
 	"github.com/Ne0nd0g/merlin/v2/pkg/group"
 	groupMemory "github.com/Ne0nd0g/merlin/v2/pkg/group/memory"
 )
 
+var (
+	// ErrAgentNotFound is returned when an Agent with the provided ID does not exist
+	ErrAgentNotFound = memory.ErrAgentNotFound
+	// ErrAgentExists is returned when adding an Agent that already exists
+	ErrAgentExists = memory.ErrAgentExists
+	// ErrAmbiguousID is returned when a partial Agent ID matches more than one Agent
+	ErrAmbiguousID = errors.New("the partial agent ID matches more than one agent")
+)
+
 // Service holds references to repositories to manage Agent objects or Group objects
 type Service struct {
-	agentRepo agents.Repository
-	groupRepo group.Repository
+	agentRepo   agents.Repository
+	groupRepo   group.Repository
+	messageRepo message.Repository
 }
 
 // memoryService is an in-memory instantiation of the Agent service so that it can be used by others
@@ -53,8 +68,9 @@ var memoryService *Service
 func NewAgentService() *Service {
 	if memoryService == nil {
 		memoryService = &Service{
-			agentRepo: WithMemoryAgentRepository(),
-			groupRepo: WithMemoryGroupRepository(),
+			agentRepo:   WithMemoryAgentRepository(),
+			groupRepo:   WithMemoryGroupRepository(),
+			messageRepo: withMemoryClientMessageRepository(),
 		}
 	}
 	return memoryService
@@ -70,14 +86,29 @@ func WithMemoryGroupRepository() group.Repository {
 	return groupMemory.NewRepository()
 }
 
+// withMemoryClientMessageRepository retrieves an in-memory repository used to send messages to clients
+func withMemoryClientMessageRepository() message.Repository {
+	return messageMemory.NewRepository()
+}
+
 /* AGENT FUNCTIONS */
 
 // Add stores and Agent object in the database
 func (s *Service) Add(agent agents.Agent) (err error) {
+	// Imported sessions keep their name unless another Agent already uses it
+	if agent.Name() != "" {
+		if existing, errR := s.Resolve(agent.Name()); errR == nil && existing != agent.ID() {
+			agent.UpdateName("")
+		}
+	}
 	err = s.agentRepo.Add(agent)
 	if err != nil {
 		return err
 	}
+	err = s.assignName(agent.ID())
+	if err != nil {
+		return err
+	}
 	slog.Debug(fmt.Sprintf("Added Agent %s to the repository", agent.ID()))
 	return
 }
@@ -87,6 +118,59 @@ func (s *Service) Agent(id uuid.UUID) (agents.Agent, error) {
 	return s.agentRepo.Get(id)
 }
 
+// Campaigns returns the callback statistics for each campaign identifier Agents reported, sorted by the campaign's
+// first callback. Agents built without a campaign identifier are not included
+func (s *Service) Campaigns() []agents.Campaign {
+	campaigns := make(map[string]*agents.Campaign)
+	hosts := make(map[string]map[string]bool)
+	users := make(map[string]map[string]bool)
+	for _, a := range s.agentRepo.GetAll() {
+		id := a.Campaign()
+		if id == "" {
+			continue
+		}
+		c, ok := campaigns[id]
+		if !ok {
+			c = &agents.Campaign{ID: id, First: a.Initial(), Last: a.Initial()}
+			campaigns[id] = c
+			hosts[id] = make(map[string]bool)
+			users[id] = make(map[string]bool)
+		}
+		c.Agents++
+		if a.Alive() {
+			c.Alive++
+		}
+		if a.Initial().Before(c.First) {
+			c.First = a.Initial()
+		}
+		if a.Initial().After(c.Last) {
+			c.Last = a.Initial()
+		}
+		hosts[id][strings.ToLower(a.Host().Name)] = true
+		users[id][strings.ToLower(a.Process().Domain+"\\"+a.Process().UserName)] = true
+	}
+
+	var all []agents.Campaign
+	for id, c := range campaigns {
+		c.Hosts = len(hosts[id])
+		c.Users = len(users[id])
+		all = append(all, *c)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].First.Before(all[j].First)
+	})
+	return all
+}
+
+// Changes returns the differences detected in the Agent's host and process information across check-ins
+func (s *Service) Changes(id uuid.UUID) ([]agents.Change, error) {
+	agent, err := s.agentRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return agent.Changes(), nil
+}
+
 // Agents returns a list of all Agent objects known to the server
 func (s *Service) Agents() []agents.Agent {
 	return s.agentRepo.GetAll()
@@ -150,6 +234,9 @@ func (s *Service) Lifetime(id uuid.UUID) (time.Duration, error) {
 
 // Link adds a child relationship link to the Agent id
 func (s *Service) Link(id, link uuid.UUID) error {
+	if s.createsLoop(id, link) {
+		return fmt.Errorf("pkg/services/agent.Link(): %w: %s -> %s", ErrRoutingLoop, id, link)
+	}
 	return s.agentRepo.AddLinkedAgent(id, link)
 }
 
@@ -221,6 +308,24 @@ func (s *Service) ResetAuthentication(id uuid.UUID) (err error) {
 	return s.Update(agent)
 }
 
+// Rekey sets the Agent's authentication status to false and its secret back to empty but keeps its OPAQUE
+// registration so that the Agent authenticates again and establishes a new secret key without registering again
+func (s *Service) Rekey(id uuid.UUID) (err error) {
+	var agent agents.Agent
+	agent, err = s.agentRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	agent.UpdateAuthenticated(false)
+	agent.SetSecret([]byte{})
+	return s.Update(agent)
+}
+
+// AddKeyBytes adds to the number of message bytes exchanged with the Agent using its current secret key
+func (s *Service) AddKeyBytes(id uuid.UUID, n int) error {
+	return s.agentRepo.AddKeyBytes(id, n)
+}
+
 // Status determines if the agent is active, delayed, or dead based on its last checkin time and retry settings
 func (s *Service) Status(id uuid.UUID) (status string, err error) {
 	var agent agents.Agent
@@ -317,10 +422,58 @@ func (s *Service) UpdateAgentInfo(id uuid.UUID, info messages.AgentInfo) (err er
 		Domain:    info.SysInfo.Domain,
 	}
 	err = s.agentRepo.UpdateProcess(id, process)
+	if err != nil {
+		return
+	}
+
+	err = s.agentRepo.UpdateRefreshed(id, time.Now().UTC())
+	if err != nil {
+		return
+	}
 
+	// Flag, and optionally quarantine, Agents running on hosts outside the engagement's scope
+	err = s.checkScope(id, host, process)
+	if err != nil {
+		return
+	}
+
+	// Agents named after their host and user are named once their host information is known
+	err = s.assignName(id)
+	if err != nil {
+		return
+	}
+
+	// Record what changed since the Agent last sent its information, the first AgentInfo message doesn't have a baseline
+	if agent.Host().Name == "" && agent.Process().ID == 0 {
+		return
+	}
+	changes := agents.Diff(agent.Host(), agent.Process(), host, process)
+	if len(changes) == 0 {
+		return
+	}
+	err = s.agentRepo.AddChanges(id, changes)
+	if err != nil {
+		return
+	}
+	for _, change := range changes {
+		agent.Log(fmt.Sprintf("%s changed from '%s' to '%s'", change.Field, change.Old, change.New))
+		if change.Notable {
+			msg := fmt.Sprintf("Agent %s's %s changed from '%s' to '%s' at %s", id, change.Field, change.Old, change.New, change.Time.Format(time.RFC3339))
+			// A running implant can't change hosts, but a copy of it can check in from another one
+			if change.Field == "Hostname" {
+				msg += ", the implant may have been copied to another host"
+			}
+			s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+		}
+	}
 	return
 }
 
+// UpdateActivity set's the user input activity and logon sessions the Agent measured on its host
+func (s *Service) UpdateActivity(id uuid.UUID, activity agents.Activity) error {
+	return s.agentRepo.UpdateActivity(id, activity)
+}
+
 // UpdateAlive set's the Agent's alive status to the provided value
 func (s *Service) UpdateAlive(id uuid.UUID, alive bool) error {
 	return s.agentRepo.UpdateAlive(id, alive)
@@ -331,16 +484,52 @@ func (s *Service) UpdateAuthenticated(id uuid.UUID, authenticated bool) error {
 	return s.agentRepo.UpdateAuthenticated(id, authenticated)
 }
 
+// UpdateChannel records which listener, or channel, the Agent's check-in arrived on
+func (s *Service) UpdateChannel(id, listener uuid.UUID) error {
+	return s.agentRepo.UpdateChannel(id, listener)
+}
+
 // UpdateComms replaces an existing Agent's embedded Comms structure
 func (s *Service) UpdateComms(id uuid.UUID, comms agents.Comms) error {
 	return s.agentRepo.UpdateComms(id, comms)
 }
 
+// UpdateImpersonation set's the Windows access token context the Agent is impersonating; an empty string means the
+// Agent has reverted to its own process token
+func (s *Service) UpdateImpersonation(id uuid.UUID, impersonation string) error {
+	return s.agentRepo.UpdateImpersonation(id, impersonation)
+}
+
+// UpdateIndicators set's the sandbox, debugger, and EDR indicators the Agent detected on its host during pre-flight checks
+func (s *Service) UpdateIndicators(id uuid.UUID, indicators []string) error {
+	err := s.agentRepo.UpdateIndicators(id, indicators)
+	if err != nil {
+		return err
+	}
+	// Quarantine Agents that are likely running in a sandbox or under an analyst's control
+	return s.quarantineIndicators(id, indicators)
+}
+
+// UpdateSecurityProducts set's the antivirus and EDR products the Agent found installed on its host
+func (s *Service) UpdateSecurityProducts(id uuid.UUID, products []string) error {
+	return s.agentRepo.UpdateSecurityProducts(id, products)
+}
+
+// UpdateRemoteAddress set's the address the Agent's traffic originated from, after accounting for trusted redirectors
+func (s *Service) UpdateRemoteAddress(id uuid.UUID, addr string) error {
+	return s.agentRepo.UpdateRemoteAddress(id, addr)
+}
+
 // UpdateInitial set's that Agent's initial checkin time field
 func (s *Service) UpdateInitial(id uuid.UUID, t time.Time) error {
 	return s.agentRepo.UpdateInitial(id, t)
 }
 
+// UpdateInjection set's the Agent's default process injection technique used when executing shellcode
+func (s *Service) UpdateInjection(id uuid.UUID, method string) error {
+	return s.agentRepo.UpdateInjection(id, method)
+}
+
 func (s *Service) UpdateListener(id, listener uuid.UUID) error {
 	return s.agentRepo.UpdateListener(id, listener)
 }
@@ -355,6 +544,29 @@ func (s *Service) UpdateStatusCheckin(id uuid.UUID, t time.Time) error {
 	return s.agentRepo.UpdateStatusCheckin(id, t)
 }
 
+// UpdateCampaign sets the campaign identifier an existing Agent reported from the payload it was built from
+func (s *Service) UpdateCampaign(id uuid.UUID, campaign string) error {
+	if err := agents.ValidateCampaign(campaign); err != nil {
+		return fmt.Errorf("pkg/services/agent.UpdateCampaign(): %s", err)
+	}
+	return s.agentRepo.UpdateCampaign(id, campaign)
+}
+
+// UpdateProfile sets the padding and response delay applied to messages sent to an existing Agent
+func (s *Service) UpdateProfile(id uuid.UUID, profile agents.Profile) error {
+	return s.agentRepo.UpdateProfile(id, profile)
+}
+
+// UpdateTimezone sets the timezone an existing Agent reported for its host
+func (s *Service) UpdateTimezone(id uuid.UUID, timezone string) error {
+	return s.agentRepo.UpdateTimezone(id, timezone)
+}
+
+// UpdateThrottle sets the bandwidth, in kilobits per second, an existing Agent's traffic is limited to; 0 is unlimited
+func (s *Service) UpdateThrottle(id uuid.UUID, kbps int) error {
+	return s.agentRepo.UpdateThrottle(id, kbps)
+}
+
 /* GROUP FUNCTIONS */
 
 // AddAgentToGroup adds the Agent to a group
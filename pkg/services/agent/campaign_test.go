@@ -0,0 +1,132 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// TestCampaigns verifies callback statistics are grouped by campaign and Agents without a campaign are excluded
+func TestCampaigns(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 4)
+	wave1, wave2 := "wave1-"+uuid.NewString()[:8], "wave2-"+uuid.NewString()[:8]
+	start := time.Now().UTC().Add(-time.Hour)
+	setup := []struct {
+		campaign string
+		initial  time.Time
+		host     string
+		user     string
+		alive    bool
+	}{
+		{wave1, start, "WS01", "alice", true},
+		{wave1, start.Add(time.Minute), "ws01", "bob", false},
+		{wave2, start.Add(2 * time.Minute), "WS02", "carol", true},
+		{"", start.Add(-time.Minute), "WS03", "dave", true},
+	}
+	for i, agent := range setup {
+		a, err := s.Agent(ids[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.UpdateHost(agents.Host{Name: agent.host})
+		a.UpdateProcess(agents.Process{Domain: "CORP", UserName: agent.user})
+		a.UpdateAlive(agent.alive)
+		a.UpdateInitial(agent.initial)
+		if err = s.Update(a); err != nil {
+			t.Fatal(err)
+		}
+		if agent.campaign != "" {
+			if err = s.UpdateCampaign(ids[i], agent.campaign); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	campaigns := make(map[string]agents.Campaign)
+	var order []string
+	for _, c := range s.Campaigns() {
+		if c.ID == wave1 || c.ID == wave2 {
+			campaigns[c.ID] = c
+			order = append(order, c.ID)
+		}
+	}
+	if len(order) != 2 || order[0] != wave1 {
+		t.Fatalf("expected campaigns [%s %s] sorted by first callback, have %v", wave1, wave2, order)
+	}
+	tests := []struct {
+		campaign string
+		want     agents.Campaign
+	}{
+		{wave1, agents.Campaign{ID: wave1, Agents: 2, Alive: 1, Hosts: 1, Users: 2, First: start, Last: start.Add(time.Minute)}},
+		{wave2, agents.Campaign{ID: wave2, Agents: 1, Alive: 1, Hosts: 1, Users: 1, First: start.Add(2 * time.Minute), Last: start.Add(2 * time.Minute)}},
+	}
+	for _, test := range tests {
+		t.Run(test.campaign, func(t *testing.T) {
+			have := campaigns[test.campaign]
+			if have.Agents != test.want.Agents || have.Alive != test.want.Alive || have.Hosts != test.want.Hosts || have.Users != test.want.Users {
+				t.Errorf("expected %+v, have %+v", test.want, have)
+			}
+			if !have.First.Equal(test.want.First) || !have.Last.Equal(test.want.Last) {
+				t.Errorf("expected callbacks %s to %s, have %s to %s", test.want.First, test.want.Last, have.First, have.Last)
+			}
+		})
+	}
+}
+
+// TestUpdateCampaign verifies invalid campaign identifiers are rejected
+func TestUpdateCampaign(t *testing.T) {
+	s := NewAgentService()
+	id := newAgents(t, s, 1)[0]
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		campaign string
+		wantErr  bool
+	}{
+		{"valid", id, "wave1", false},
+		{"invalid", id, "wave 1", true},
+		{"unknown Agent", uuid.New(), "wave1", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.UpdateCampaign(test.id, test.campaign)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+	a, err := s.Agent(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Campaign() != "wave1" {
+		t.Errorf("expected the campaign wave1, have %q", a.Campaign())
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"strings"
+	"testing"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+)
+
+// TestUpdateAgentInfoChanges verifies changes between AgentInfo messages are recorded and notable changes alert clients
+func TestUpdateAgentInfoChanges(t *testing.T) {
+	s := NewAgentService()
+	id := newAgents(t, s, 1)[0]
+	info := messages.AgentInfo{SysInfo: messages.SysInfo{HostName: "WKSTN-1", Pid: 1234, UserName: "ACME\\jdoe", Integrity: 2}}
+
+	tests := []struct {
+		name    string
+		update  func(info *messages.SysInfo)
+		changes int
+		alert   string
+	}{
+		{"baseline", func(info *messages.SysInfo) {}, 0, ""},
+		{"unchanged", func(info *messages.SysInfo) {}, 0, ""},
+		{"new process", func(info *messages.SysInfo) { info.Pid = 4321 }, 1, ""},
+		{"privilege increase", func(info *messages.SysInfo) { info.UserName = "NT AUTHORITY\\SYSTEM"; info.Integrity = 4 }, 3, "Username changed from 'ACME\\jdoe' to 'NT AUTHORITY\\SYSTEM'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.update(&info.SysInfo)
+			err := s.UpdateAgentInfo(id, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			changes, err := s.Changes(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changes) != test.changes {
+				t.Errorf("expected %d recorded changes, have %+v", test.changes, changes)
+			}
+			if test.alert == "" {
+				return
+			}
+			var alerted bool
+			for _, m := range s.messageRepo.GetAll() {
+				if strings.Contains(m.Message(), id.String()) && strings.Contains(m.Message(), test.alert) {
+					alerted = true
+				}
+			}
+			if !alerted {
+				t.Errorf("expected clients to be alerted that the %s", test.alert)
+			}
+		})
+	}
+}
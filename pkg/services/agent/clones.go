@@ -0,0 +1,155 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+)
+
+// cloneWindow is how long an Agent's source address is considered active when its check-in interval is unknown
+const cloneWindow = 5 * time.Minute
+
+// sources tracks where each Agent's traffic originates from to detect copies of the same implant, such as one copied
+// into a sandbox, that check in concurrently from different addresses
+var sources = struct {
+	sync.Mutex
+	last  map[uuid.UUID]string               // The source address of the Agent's most recent check-in
+	seen  map[uuid.UUID]map[string]time.Time // When the Agent last checked in from each source address
+	forks map[uuid.UUID]map[string]uuid.UUID // The forked Agent that traffic from a cloned implant's address is attributed to
+	alias map[uuid.UUID]uuid.UUID            // The original Agent ID of each forked Agent, which the implant still uses
+}{
+	last:  make(map[uuid.UUID]string),
+	seen:  make(map[uuid.UUID]map[string]time.Time),
+	forks: make(map[uuid.UUID]map[string]uuid.UUID),
+	alias: make(map[uuid.UUID]uuid.UUID),
+}
+
+// Source records the address an authenticated Agent's traffic originated from and returns the ID of the Agent the
+// traffic is attributed to. An Agent that alternates between two addresses, going back to an address while another one
+// is still active, is a cloned implant. The address that appeared later is forked into a new Agent so that the tasking
+// and results of the two instances don't interleave
+func (s *Service) Source(id uuid.UUID, addr string) (uuid.UUID, error) {
+	if addr == "" {
+		return id, nil
+	}
+	agent, err := s.Agent(id)
+	if err != nil {
+		return id, err
+	}
+	window := cloneWindow
+	if sleep, err := time.ParseDuration(agent.Comms().Wait); err == nil && sleep > 0 {
+		window = max(3*(sleep+time.Duration(agent.Comms().Skew)*time.Millisecond), time.Minute)
+	}
+
+	now := time.Now().UTC()
+	sources.Lock()
+	if fork, ok := sources.forks[id][addr]; ok {
+		sources.Unlock()
+		return fork, nil
+	}
+	if sources.seen[id] == nil {
+		sources.seen[id] = make(map[string]time.Time)
+	}
+	last := sources.last[id]
+	previous, returned := sources.seen[id][addr]
+	sources.seen[id][addr] = now
+	sources.last[id] = addr
+	// The Agent came back to an address while the address it most recently used is still active
+	clone := last != "" && last != addr && returned && now.Sub(previous) < window && now.Sub(sources.seen[id][last]) < window
+	sources.Unlock()
+	if !clone {
+		return id, nil
+	}
+
+	fork, err := s.fork(agent, last)
+	if err != nil {
+		return id, err
+	}
+	sources.Lock()
+	if sources.forks[id] == nil {
+		sources.forks[id] = make(map[string]uuid.UUID)
+	}
+	sources.forks[id][last] = fork
+	sources.alias[fork] = id
+	sources.last[id] = addr
+	sources.Unlock()
+
+	// The copy is likely running in a sandbox or under an analyst's control
+	err = s.Quarantine(fork, fmt.Sprintf("cloned implant of agent %s", id))
+	if err != nil {
+		return id, err
+	}
+
+	msg := fmt.Sprintf("Agent %s is checking in concurrently from %s and %s, it was likely copied to another host. Traffic from %s is now tracked as quarantined agent %s", id, addr, last, last, fork)
+	slog.Warn(msg)
+	agent.Log(msg)
+	s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+	return id, nil
+}
+
+// Routed returns the ID of the Agent that traffic for the provided Agent ID from the provided address is attributed to
+func (s *Service) Routed(id uuid.UUID, addr string) uuid.UUID {
+	sources.Lock()
+	defer sources.Unlock()
+	if fork, ok := sources.forks[id][addr]; ok {
+		return fork
+	}
+	return id
+}
+
+// Origin returns the Agent ID that a forked Agent's implant uses in its messages. The second return value is false if
+// the Agent is not a fork of a cloned implant
+func (s *Service) Origin(id uuid.UUID) (uuid.UUID, bool) {
+	sources.Lock()
+	defer sources.Unlock()
+	origin, ok := sources.alias[id]
+	return origin, ok
+}
+
+// fork creates a new Agent from a copy of the provided Agent's session for the cloned implant checking in from addr
+func (s *Service) fork(agent agents.Agent, addr string) (uuid.UUID, error) {
+	session := agent.Session()
+	session.ID = uuid.New()
+	session.Links = nil
+	session.Name = ""
+	session.Note = fmt.Sprintf("Cloned implant of agent %s checking in from %s", agent.ID(), addr)
+	fork, err := agents.NewAgentFromSession(session, agent.Listener())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.fork(): %s", err)
+	}
+	fork.UpdateRemoteAddress(addr)
+	err = s.Add(fork)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.fork(): %s", err)
+	}
+	return fork.ID(), nil
+}
@@ -0,0 +1,99 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestSource verifies an Agent that goes back to an address while its most recent address is still active is forked,
+// and that traffic from the later address is attributed to the quarantined fork
+func TestSource(t *testing.T) {
+	s := NewAgentService()
+	id := newAgents(t, s, 1)[0]
+	tests := []struct {
+		name   string
+		addr   string
+		forked bool
+	}{
+		{"no address", "", false},
+		{"first address", "198.51.100.10", false},
+		{"same address", "198.51.100.10", false},
+		{"new address", "198.51.100.20", false},
+		{"alternates back", "198.51.100.10", false},
+		{"forked address", "198.51.100.20", true},
+		{"original address", "198.51.100.10", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			routed, err := s.Source(id, test.addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (routed != id) != test.forked {
+				t.Errorf("expected forked %t, have %s routed to %s", test.forked, id, routed)
+			}
+		})
+	}
+
+	fork := s.Routed(id, "198.51.100.20")
+	if fork == id {
+		t.Fatal("expected traffic from 198.51.100.20 to be routed to a forked Agent")
+	}
+	t.Cleanup(func() { _ = s.Remove(fork) })
+	if routed := s.Routed(id, "198.51.100.10"); routed != id {
+		t.Errorf("expected traffic from 198.51.100.10 to be routed to %s, have %s", id, routed)
+	}
+	origin, ok := s.Origin(fork)
+	if !ok || origin != id {
+		t.Errorf("expected the forked Agent's origin to be %s, have %s", id, origin)
+	}
+	if _, ok = s.Origin(id); ok {
+		t.Errorf("expected agent %s to not be a fork", id)
+	}
+	a, err := s.Agent(fork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Quarantined() {
+		t.Error("expected the forked Agent to be quarantined")
+	}
+	if a.RemoteAddress() != "198.51.100.20" {
+		t.Errorf("expected the forked Agent's remote address to be 198.51.100.20, have %s", a.RemoteAddress())
+	}
+}
+
+// TestSourceUnknown verifies an error is returned for an unknown Agent with a source address
+func TestSourceUnknown(t *testing.T) {
+	s := NewAgentService()
+	id := uuid.New()
+	routed, err := s.Source(id, "198.51.100.10")
+	if err == nil {
+		t.Error("expected an error for an unknown Agent")
+	}
+	if routed != id {
+		t.Errorf("expected the unknown Agent's traffic to stay attributed to %s, have %s", id, routed)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// prefixPattern matches values that can be the beginning of a UUID
+var prefixPattern = regexp.MustCompile(`^[0-9a-f][0-9a-f-]*$`)
+
+// naming is the scheme used to automatically assign friendly names to Agents
+var naming = struct {
+	sync.Mutex
+	scheme string
+}{scheme: agents.NamingWords}
+
+// SetNamingScheme sets how Agents are automatically named: words (e.g., brave-falcon), host (e.g., wks01-jdoe), or none
+func (s *Service) SetNamingScheme(scheme string) error {
+	scheme = strings.ToLower(scheme)
+	switch scheme {
+	case agents.NamingWords, agents.NamingHost, agents.NamingNone:
+	default:
+		return fmt.Errorf("pkg/services/agent.SetNamingScheme(): unknown naming scheme '%s', expected %s, %s, or %s", scheme, agents.NamingWords, agents.NamingHost, agents.NamingNone)
+	}
+	naming.Lock()
+	naming.scheme = scheme
+	naming.Unlock()
+	return nil
+}
+
+// Rename sets the Agent's friendly name, which must be unique
+func (s *Service) Rename(id uuid.UUID, name string) error {
+	name = strings.ToLower(name)
+	err := agents.ValidateName(name)
+	if err != nil {
+		return fmt.Errorf("pkg/services/agent.Rename(): %s", err)
+	}
+	for _, a := range s.agentRepo.GetAll() {
+		if a.Name() == name && a.ID() != id {
+			return fmt.Errorf("pkg/services/agent.Rename(): the name '%s' is already used by agent %s", name, a.ID())
+		}
+	}
+	return s.agentRepo.UpdateName(id, name)
+}
+
+// Resolve returns the ID of the Agent with the provided ID, friendly name, or unambiguous prefix of its ID (e.g., c109).
+// ErrAmbiguousID is returned, with the matching Agents, if the prefix matches more than one Agent
+func (s *Service) Resolve(agent string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(agent); err == nil {
+		return id, nil
+	}
+	name := strings.ToLower(agent)
+	var matches []uuid.UUID
+	for _, a := range s.agentRepo.GetAll() {
+		if a.Name() == name {
+			return a.ID(), nil
+		}
+		if prefixPattern.MatchString(name) && strings.HasPrefix(a.ID().String(), name) {
+			matches = append(matches, a.ID())
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Resolve(): %w: '%s' is not a known agent ID, prefix, or name", ErrAgentNotFound, agent)
+	case 1:
+		return matches[0], nil
+	default:
+		var candidates []string
+		for _, id := range matches {
+			candidates = append(candidates, id.String())
+		}
+		sort.Strings(candidates)
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Resolve(): %w: '%s' matches %s", ErrAmbiguousID, agent, strings.Join(candidates, ", "))
+	}
+}
+
+// assignName gives the Agent a unique friendly name with the configured naming scheme if it doesn't already have one
+func (s *Service) assignName(id uuid.UUID) error {
+	naming.Lock()
+	scheme := naming.scheme
+	naming.Unlock()
+
+	agent, err := s.agentRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	if agent.Name() != "" {
+		return nil
+	}
+	base := agents.Name(scheme, agent.Host(), agent.Process())
+	if base == "" {
+		return nil
+	}
+	taken := make(map[string]bool)
+	for _, a := range s.agentRepo.GetAll() {
+		taken[a.Name()] = true
+	}
+	name := base
+	for i := 2; taken[name]; i++ {
+		// Pick new words before numbering names so the word scheme stays readable
+		if scheme == agents.NamingWords && i < 10 {
+			name = agents.Name(scheme, agent.Host(), agent.Process())
+			continue
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return s.agentRepo.UpdateName(id, name)
+}
@@ -0,0 +1,224 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// setNamingScheme sets the naming scheme and restores the default scheme when the test completes
+func setNamingScheme(t *testing.T, s *Service, scheme string) {
+	t.Helper()
+	err := s.SetNamingScheme(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.SetNamingScheme(agents.NamingWords) })
+}
+
+// TestSetNamingScheme verifies only known naming schemes are accepted
+func TestSetNamingScheme(t *testing.T) {
+	s := NewAgentService()
+	t.Cleanup(func() { _ = s.SetNamingScheme(agents.NamingWords) })
+	tests := []struct {
+		scheme  string
+		wantErr bool
+	}{
+		{"words", false},
+		{"HOST", false},
+		{"none", false},
+		{"colors", true},
+	}
+	for _, test := range tests {
+		t.Run(test.scheme, func(t *testing.T) {
+			err := s.SetNamingScheme(test.scheme)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestRename verifies Agent names must be valid and unique, and resolve to the Agent's ID
+func TestRename(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 2)
+	name := "rename-" + uuid.NewString()[:8]
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		agent   string
+		wantErr bool
+	}{
+		{"valid", ids[0], name, false},
+		{"same Agent", ids[0], name, false},
+		{"duplicate", ids[1], name, true},
+		{"invalid", ids[1], "bad name", true},
+		{"unknown Agent", uuid.New(), name + "-2", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Rename(test.id, test.agent)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestResolve verifies Agent IDs and case-insensitive names resolve to the Agent's ID
+func TestResolve(t *testing.T) {
+	s := NewAgentService()
+	id := newAgents(t, s, 1)[0]
+	name := "resolve-" + uuid.NewString()[:8]
+	err := s.Rename(id, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown := uuid.New()
+	tests := []struct {
+		name    string
+		agent   string
+		want    uuid.UUID
+		wantErr bool
+	}{
+		{"ID", id.String(), id, false},
+		{"unknown ID", unknown.String(), unknown, false},
+		{"name", name, id, false},
+		{"uppercase name", "RESOLVE-" + name[len("resolve-"):], id, false},
+		{"unknown name", "unknown-" + name, uuid.Nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			have, err := s.Resolve(test.agent)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if have != test.want {
+				t.Errorf("expected %s, have %s", test.want, have)
+			}
+		})
+	}
+}
+
+// TestAssignName verifies Agents are named by the configured scheme and duplicate host names are numbered
+func TestAssignName(t *testing.T) {
+	s := NewAgentService()
+	host := "wks" + uuid.NewString()[:8]
+	info := messages.AgentInfo{SysInfo: messages.SysInfo{HostName: host, UserName: "CORP\\jdoe"}}
+	tests := []struct {
+		name   string
+		scheme string
+		want   string
+	}{
+		{"none", agents.NamingNone, ""},
+		{"host", agents.NamingHost, host + "-jdoe"},
+		{"duplicate host", agents.NamingHost, host + "-jdoe-2"},
+	}
+	for _, test := range tests {
+		// Agents are added to the parent test so an earlier subtest's Agent still holds its name
+		setNamingScheme(t, s, test.scheme)
+		id := newAgents(t, s, 1)[0]
+		t.Run(test.name, func(t *testing.T) {
+			if err := s.UpdateAgentInfo(id, info); err != nil {
+				t.Fatal(err)
+			}
+			a, err := s.Agent(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.Name() != test.want {
+				t.Errorf("expected the name %q, have %q", test.want, a.Name())
+			}
+		})
+	}
+
+	setNamingScheme(t, s, agents.NamingWords)
+	id := newAgents(t, s, 1)[0]
+	a, err := s.Agent(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name() == "" {
+		t.Error("expected the words scheme to name the Agent when it is added")
+	}
+}
+
+// TestResolvePrefix verifies unambiguous prefixes of an Agent's ID resolve to the Agent and ambiguous prefixes list the
+// matching Agents
+func TestResolvePrefix(t *testing.T) {
+	s := NewAgentService()
+	newAgents(t, s, 0)
+	ids := []uuid.UUID{
+		uuid.MustParse("c1090dbc-f2f7-4d90-a241-86e0c0217786"),
+		uuid.MustParse("c1091f6e-8a51-4f0b-9c0e-5a2d1b7c3e90"),
+	}
+	for _, id := range ids {
+		a, err := agents.NewAgent(id, nil, nil, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.Add(a); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = s.Remove(id) })
+	}
+	tests := []struct {
+		name   string
+		prefix string
+		want   uuid.UUID
+		err    error
+	}{
+		{"unambiguous", "c1090", ids[0], nil},
+		{"uppercase", "C1091F", ids[1], nil},
+		{"with hyphen", "c1090dbc-f2", ids[0], nil},
+		{"ambiguous", "c109", uuid.Nil, ErrAmbiguousID},
+		{"no match", "c108", uuid.Nil, ErrAgentNotFound},
+		{"not hexadecimal", "zz", uuid.Nil, ErrAgentNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			have, err := s.Resolve(test.prefix)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, have %v", test.err, err)
+			}
+			if have != test.want {
+				t.Errorf("expected %s, have %s", test.want, have)
+			}
+			if errors.Is(err, ErrAmbiguousID) && (!strings.Contains(err.Error(), ids[0].String()) || !strings.Contains(err.Error(), ids[1].String())) {
+				t.Errorf("expected the error to list both matching Agents, have %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+)
+
+// quarantineThreshold is the number of analysis indicators an Agent must report during pre-flight checks to be
+// automatically quarantined; 0 disables automatic quarantine
+var quarantineThreshold atomic.Int64
+
+// SetQuarantineThreshold automatically quarantines Agents that report at least the provided number of sandbox,
+// debugger, or EDR indicators during pre-flight checks. A threshold of 0 disables automatic quarantine
+func (s *Service) SetQuarantineThreshold(indicators int) error {
+	if indicators < 0 {
+		return fmt.Errorf("pkg/services/agent.SetQuarantineThreshold(): the number of indicators must be 0 or more, have %d", indicators)
+	}
+	quarantineThreshold.Store(int64(indicators))
+	return nil
+}
+
+// Quarantine stops jobs from being added to, or sent from, the Agent's queue and hides the Agent from broadcast jobs
+// until it is released. An operator can't accidentally interact with an Agent that is likely controlled by defenders
+func (s *Service) Quarantine(id uuid.UUID, reason string) error {
+	agent, err := s.Agent(id)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		return fmt.Errorf("pkg/services/agent.Quarantine(): a reason is required to quarantine agent %s", id)
+	}
+	agent.Log(fmt.Sprintf("Quarantined: %s", reason))
+	return s.agentRepo.UpdateQuarantine(id, reason)
+}
+
+// Release allows a quarantined Agent to be tasked again
+func (s *Service) Release(id uuid.UUID) error {
+	agent, err := s.Agent(id)
+	if err != nil {
+		return err
+	}
+	if !agent.Quarantined() {
+		return fmt.Errorf("pkg/services/agent.Release(): agent %s is not quarantined", id)
+	}
+	agent.Log(fmt.Sprintf("Released from quarantine (%s)", agent.Quarantine()))
+	return s.agentRepo.UpdateQuarantine(id, "")
+}
+
+// quarantineIndicators quarantines the Agent if it reported enough analysis indicators to meet the threshold
+func (s *Service) quarantineIndicators(id uuid.UUID, indicators []string) error {
+	threshold := quarantineThreshold.Load()
+	if threshold == 0 || int64(len(indicators)) < threshold {
+		return nil
+	}
+	agent, err := s.Agent(id)
+	if err != nil {
+		return err
+	}
+	if agent.Quarantined() {
+		return nil
+	}
+	err = s.Quarantine(id, fmt.Sprintf("analysis indicators: %s", strings.Join(indicators, "; ")))
+	if err != nil {
+		return err
+	}
+	s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("Agent %s was quarantined because it reported %d analysis indicator(s); release it to task it", id, len(indicators))))
+	return nil
+}
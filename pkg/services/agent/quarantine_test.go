@@ -0,0 +1,143 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestQuarantine verifies a reason is required to quarantine a known Agent
+func TestQuarantine(t *testing.T) {
+	s := NewAgentService()
+	id := newAgents(t, s, 1)[0]
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		reason  string
+		wantErr bool
+	}{
+		{"no reason", id, "", true},
+		{"unknown Agent", uuid.New(), "suspicious", true},
+		{"quarantined", id, "suspicious", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Quarantine(test.id, test.reason)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+	a, err := s.Agent(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Quarantine() != "suspicious" {
+		t.Errorf("expected the quarantine reason 'suspicious', have %q", a.Quarantine())
+	}
+}
+
+// TestSetQuarantineThreshold verifies a negative number of indicators is rejected
+func TestSetQuarantineThreshold(t *testing.T) {
+	s := NewAgentService()
+	t.Cleanup(func() { quarantineThreshold.Store(0) })
+	tests := []struct {
+		indicators int
+		wantErr    bool
+	}{
+		{-1, true},
+		{0, false},
+		{2, false},
+	}
+	for _, test := range tests {
+		err := s.SetQuarantineThreshold(test.indicators)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%d: expected error %t, have %v", test.indicators, test.wantErr, err)
+		}
+	}
+}
+
+// TestQuarantineIndicators verifies Agents are automatically quarantined when they report enough analysis indicators
+func TestQuarantineIndicators(t *testing.T) {
+	s := NewAgentService()
+	t.Cleanup(func() { quarantineThreshold.Store(0) })
+	tests := []struct {
+		name        string
+		threshold   int
+		indicators  []string
+		quarantined bool
+	}{
+		{"disabled", 0, []string{"VirtualBox MAC address", "debugger attached"}, false},
+		{"below threshold", 2, []string{"VirtualBox MAC address"}, false},
+		{"at threshold", 2, []string{"VirtualBox MAC address", "debugger attached"}, true},
+		{"above threshold", 1, []string{"VirtualBox MAC address", "debugger attached"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := newAgents(t, s, 1)[0]
+			if err := s.SetQuarantineThreshold(test.threshold); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.UpdateIndicators(id, test.indicators); err != nil {
+				t.Fatal(err)
+			}
+			a, err := s.Agent(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.Quarantined() != test.quarantined {
+				t.Errorf("expected quarantined %t, have %q", test.quarantined, a.Quarantine())
+			}
+		})
+	}
+}
+
+// TestRelease verifies only quarantined, known Agents can be released
+func TestRelease(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 2)
+	err := s.agentRepo.UpdateQuarantine(ids[0], "out of scope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		wantErr bool
+	}{
+		{"quarantined", ids[0], false},
+		{"already released", ids[0], true},
+		{"not quarantined", ids[1], true},
+		{"unknown Agent", uuid.New(), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Release(test.id)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
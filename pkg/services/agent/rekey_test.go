@@ -0,0 +1,90 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestRekey verifies re-keying clears the Agent's secret and authentication but keeps the Agent and its state
+func TestRekey(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 2)
+	id := ids[0]
+	a, err := s.Agent(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.SetSecret([]byte("0123456789abcdef0123456789abcdef"))
+	err = s.Update(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, update := range []error{s.UpdateAuthenticated(id, true), s.UpdateNote(id, "high value"), s.AddKeyBytes(id, 1024)} {
+		if update != nil {
+			t.Fatal(update)
+		}
+	}
+	a, err = s.Agent(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyed, bytes := a.Keyed(); keyed.IsZero() || bytes != 1024 {
+		t.Fatalf("expected the key to be established with 1024 bytes, have %s and %d bytes", keyed, bytes)
+	}
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		wantErr bool
+	}{
+		{"authenticated", id, false},
+		{"unknown Agent", uuid.New(), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Rekey(test.id)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			rekeyed, err := s.Agent(test.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rekeyed.Authenticated() || len(rekeyed.Secret()) != 0 {
+				t.Error("expected the Agent's authentication and secret to be cleared")
+			}
+			if keyed, bytes := rekeyed.Keyed(); !keyed.IsZero() || bytes != 0 {
+				t.Errorf("expected the key's age and byte count to be reset, have %s and %d bytes", keyed, bytes)
+			}
+			if rekeyed.Note() != "high value" {
+				t.Errorf("expected the Agent to keep its note, have %q", rekeyed.Note())
+			}
+		})
+	}
+}
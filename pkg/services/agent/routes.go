@@ -0,0 +1,125 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"errors"
+	"sort"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// MaxHops is the maximum number of peer-to-peer hops, or time to live (TTL), a delegate message is routed through
+// before it is dropped
+const MaxHops = 16
+
+// ErrRoutingLoop is returned when linking a child Agent to a parent would create a forwarding loop
+var ErrRoutingLoop = errors.New("linking the agents would create a peer-to-peer routing loop")
+
+// Route is the path delegate messages take between the egress Agent that communicates directly with a listener and
+// a peer-to-peer child Agent
+type Route struct {
+	Agent uuid.UUID   // The Agent the route leads to
+	Path  []uuid.UUID // The Agents along the route starting with the egress Agent and ending with Agent; empty if unreachable
+}
+
+// Hops returns the number of peer-to-peer hops between the egress Agent and the Agent; -1 means unreachable
+func (r Route) Hops() int {
+	return len(r.Path) - 1
+}
+
+// Routes computes the shortest route from an egress Agent to every Agent using their peer-to-peer links.
+// Agents that are only reachable through a loop, or are more than MaxHops away, are returned without a path
+func (s *Service) Routes() (routes []Route) {
+	all := s.Agents()
+	children := make(map[uuid.UUID]bool)
+	for _, a := range all {
+		for _, link := range a.Links() {
+			children[link] = true
+		}
+	}
+
+	// Breadth first search from every egress Agent so each Agent gets its shortest route
+	paths := make(map[uuid.UUID][]uuid.UUID)
+	var queue [][]uuid.UUID
+	for _, a := range all {
+		if !children[a.ID()] {
+			paths[a.ID()] = []uuid.UUID{a.ID()}
+			queue = append(queue, paths[a.ID()])
+		}
+	}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if len(path) >= MaxHops {
+			continue
+		}
+		links, err := s.Links(path[len(path)-1])
+		if err != nil {
+			continue
+		}
+		for _, link := range links {
+			if _, ok := paths[link]; ok {
+				continue
+			}
+			next := append(append([]uuid.UUID{}, path...), link)
+			paths[link] = next
+			queue = append(queue, next)
+		}
+	}
+
+	for _, a := range all {
+		routes = append(routes, Route{Agent: a.ID(), Path: paths[a.ID()]})
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Hops() != routes[j].Hops() {
+			return routes[i].Hops() < routes[j].Hops()
+		}
+		return routes[i].Agent.String() < routes[j].Agent.String()
+	})
+	return
+}
+
+// createsLoop determines if linking the child Agent to the parent would create a forwarding loop because the parent
+// is the child or is already reachable from the child through its peer-to-peer links
+func (s *Service) createsLoop(parent, child uuid.UUID) bool {
+	visited := make(map[uuid.UUID]bool)
+	queue := []uuid.UUID{child}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == parent {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		links, err := s.Links(id)
+		if err != nil {
+			continue
+		}
+		queue = append(queue, links...)
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// newAgents adds the number of Agents, whose log files are written to a temporary directory, to the Agent service
+func newAgents(t *testing.T, s *Service, n int) (ids []uuid.UUID) {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	for i := 0; i < n; i++ {
+		a, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.Add(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = s.Remove(a.ID()) })
+		ids = append(ids, a.ID())
+	}
+	return
+}
+
+// TestRoutes builds egress -> a -> b -> c along with a shortcut egress -> c and verifies each Agent's shortest route
+func TestRoutes(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 4)
+	egress, a, b, c := ids[0], ids[1], ids[2], ids[3]
+	for _, link := range [][2]uuid.UUID{{egress, a}, {a, b}, {b, c}, {egress, c}} {
+		err := s.Link(link[0], link[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	routes := make(map[uuid.UUID]Route)
+	for _, route := range s.Routes() {
+		routes[route.Agent] = route
+	}
+
+	tests := []struct {
+		name  string
+		agent uuid.UUID
+		path  []uuid.UUID
+	}{
+		{"egress", egress, []uuid.UUID{egress}},
+		{"one hop", a, []uuid.UUID{egress, a}},
+		{"two hops", b, []uuid.UUID{egress, a, b}},
+		{"shortest", c, []uuid.UUID{egress, c}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			route, ok := routes[test.agent]
+			if !ok {
+				t.Fatalf("no route was returned for Agent %s", test.agent)
+			}
+			if route.Hops() != len(test.path)-1 {
+				t.Fatalf("expected %d hops, have %d: %v", len(test.path)-1, route.Hops(), route.Path)
+			}
+			for i, hop := range test.path {
+				if route.Path[i] != hop {
+					t.Errorf("expected hop %d to be %s, have %s", i, hop, route.Path[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoutesUnreachable(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 2)
+	// Two Agents that are only linked to each other have no egress Agent
+	err := s.Link(ids[0], ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.agentRepo.AddLinkedAgent(ids[1], ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, route := range s.Routes() {
+		if (route.Agent == ids[0] || route.Agent == ids[1]) && route.Hops() != -1 {
+			t.Errorf("expected Agent %s to be unreachable, have route %v", route.Agent, route.Path)
+		}
+	}
+}
+
+func TestLinkLoop(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 3)
+	a, b, c := ids[0], ids[1], ids[2]
+
+	tests := []struct {
+		name   string
+		parent uuid.UUID
+		child  uuid.UUID
+		loop   bool
+	}{
+		{"self", a, a, true},
+		{"parent to child", a, b, false},
+		{"child to grandchild", b, c, false},
+		{"grandchild to parent", c, a, true},
+		{"child to parent", b, a, true},
+		{"duplicate link", a, b, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Link(test.parent, test.child)
+			if errors.Is(err, ErrRoutingLoop) != test.loop {
+				t.Errorf("expected a routing loop %t, have %v", test.loop, err)
+			}
+		})
+	}
+}
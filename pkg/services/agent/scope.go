@@ -0,0 +1,99 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+)
+
+// scope holds the engagement's rules of engagement target scope that Agent hosts are checked against
+var scope = struct {
+	sync.RWMutex
+	scope agents.Scope
+}{}
+
+// LoadScope reads the YAML scope configuration file at the provided path and replaces any previously loaded scope.
+// Agents are checked against the scope each time they send their host information
+func (s *Service) LoadScope(path string) error {
+	data, err := os.ReadFile(path) // #nosec G304 - The operator provides the configuration file path
+	if err != nil {
+		return fmt.Errorf("pkg/services/agent.LoadScope(): there was an error reading the scope configuration: %s", err)
+	}
+	config, err := agents.ParseScope(data)
+	if err != nil {
+		return fmt.Errorf("pkg/services/agent.LoadScope(): %s", err)
+	}
+	scope.Lock()
+	scope.scope = config
+	scope.Unlock()
+	slog.Info(fmt.Sprintf("Loaded the engagement scope from %s with %d CIDR(s), %d domain(s), and %d hostname pattern(s)", path, len(config.CIDRs), len(config.Domains), len(config.Hostnames)))
+	return nil
+}
+
+// checkScope flags an Agent whose host is outside the engagement's scope and, if the scope requires it, quarantines the
+// Agent so that it can't be tasked until an operator releases it. Agents are only quarantined when they first leave the
+// scope so a released Agent is not quarantined again each time it sends its host information
+func (s *Service) checkScope(id uuid.UUID, host agents.Host, process agents.Process) error {
+	scope.RLock()
+	config := scope.scope
+	scope.RUnlock()
+
+	agent, err := s.Agent(id)
+	if err != nil {
+		return err
+	}
+	violation := config.Check(host, process)
+	if violation == agent.ScopeViolation() {
+		return nil
+	}
+	err = s.agentRepo.UpdateScopeViolation(id, violation)
+	if err != nil {
+		return err
+	}
+	if violation == "" {
+		agent.Log("Agent's host is back in the engagement's scope")
+		return nil
+	}
+
+	msg := fmt.Sprintf("Agent %s is out of scope: %s", id, violation)
+	agent.Log(msg)
+	slog.Warn(msg)
+	if config.Quarantine && !agent.Quarantined() {
+		err = s.Quarantine(id, "out of scope")
+		if err != nil {
+			return err
+		}
+		msg += "; it was quarantined and can't be tasked until it is released"
+	}
+	s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"os"
+	"path/filepath"
+	"testing"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// loadScope loads the provided scope configuration and restores an empty scope when the test completes
+func loadScope(t *testing.T, s *Service, config string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scope.yaml")
+	err := os.WriteFile(path, []byte(config), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.LoadScope(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		scope.Lock()
+		scope.scope = agents.Scope{}
+		scope.Unlock()
+	})
+}
+
+// TestLoadScope verifies missing and invalid scope configurations are rejected
+func TestLoadScope(t *testing.T) {
+	s := NewAgentService()
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.yaml")
+	err := os.WriteFile(invalid, []byte("cidrs: [\"10.10.0.0/33\"]"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing", filepath.Join(dir, "missing.yaml")},
+		{"invalid", invalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := s.LoadScope(test.path); err == nil {
+				t.Errorf("expected an error loading %s", test.path)
+			}
+		})
+	}
+}
+
+// TestCheckScope verifies out-of-scope Agents are flagged, quarantined only when they first leave the scope, and
+// un-flagged when they return to it
+func TestCheckScope(t *testing.T) {
+	s := NewAgentService()
+	id := newAgents(t, s, 1)[0]
+	loadScope(t, s, "cidrs: [\"10.10.0.0/16\"]\nquarantine: true")
+
+	outside := agents.Host{Name: "srv01", IPs: []string{"198.51.100.5/24"}}
+	inside := agents.Host{Name: "srv01", IPs: []string{"10.10.1.5/24"}}
+	tests := []struct {
+		name        string
+		host        agents.Host
+		release     bool
+		violation   bool
+		quarantined bool
+	}{
+		{"in scope", inside, false, false, false},
+		{"leaves scope", outside, false, true, true},
+		{"released", outside, true, true, false},
+		{"still out of scope", outside, false, true, false},
+		{"returns to scope", inside, false, false, false},
+		{"leaves scope again", outside, false, true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.release {
+				if err := s.Release(id); err != nil {
+					t.Fatal(err)
+				}
+			} else if err := s.checkScope(id, test.host, agents.Process{}); err != nil {
+				t.Fatal(err)
+			}
+			a, err := s.Agent(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (a.ScopeViolation() != "") != test.violation {
+				t.Errorf("expected a scope violation %t, have %q", test.violation, a.ScopeViolation())
+			}
+			if a.Quarantined() != test.quarantined {
+				t.Errorf("expected quarantined %t, have %q", test.quarantined, a.Quarantine())
+			}
+		})
+	}
+}
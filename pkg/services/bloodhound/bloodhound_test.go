@@ -0,0 +1,86 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bloodhound
+
+import (
+	// Standard
+	"slices"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+func TestArguments(t *testing.T) {
+	c := &collection{id: uuid.New()}
+	zipName := c.id.String() + ".zip"
+	tests := []struct {
+		name      string
+		args      []string
+		want      []string
+		directory string
+	}{
+		{"default directory", []string{"-c", "All"}, []string{"-c", "All", "--outputdirectory", defaultOutputDirectory, "--zipfilename", zipName}, defaultOutputDirectory},
+		{"operator directory", []string{"--OutputDirectory", "C:\\Users\\Public"}, []string{"--OutputDirectory", "C:\\Users\\Public", "--zipfilename", zipName}, "C:\\Users\\Public"},
+		{"directory without a value", []string{"--outputdirectory"}, []string{"--outputdirectory", "--outputdirectory", defaultOutputDirectory, "--zipfilename", zipName}, defaultOutputDirectory},
+		{"no arguments", nil, []string{"--outputdirectory", defaultOutputDirectory, "--zipfilename", zipName}, defaultOutputDirectory},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args, directory, name := c.arguments(test.args)
+			if !slices.Equal(args, test.want) {
+				t.Errorf("expected arguments %q, have %q", test.want, args)
+			}
+			if directory != test.directory {
+				t.Errorf("expected the output directory %s, have %s", test.directory, directory)
+			}
+			if name != zipName {
+				t.Errorf("expected the zip file name %s, have %s", zipName, name)
+			}
+		})
+	}
+}
+
+// TestZipPath verifies the timestamped zip file is found in the collector's output
+func TestZipPath(t *testing.T) {
+	c := &collection{id: uuid.New()}
+	zipName := c.id.String() + ".zip"
+	tests := []struct {
+		name   string
+		stdout string
+		want   string
+	}{
+		{"full path", "Saving cache\nZip file written to C:\\Windows\\Temp\\20240101120000_" + zipName + "\n", "C:\\Windows\\Temp\\20240101120000_" + zipName},
+		{"file name", "SharpHound Enumeration Completed 20240101120000_" + zipName + " written", "C:\\Windows\\Temp\\20240101120000_" + zipName},
+		{"quoted", "Compressing data to \"C:\\Temp\\20240101120000_" + zipName + "\"", "C:\\Temp\\20240101120000_" + zipName},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := c.zipPath(test.stdout, "C:\\Windows\\Temp\\", zipName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != test.want {
+				t.Errorf("expected %s, have %s", test.want, path)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package credentials is a service used to store and retrieve credentials collected or provided during an engagement
+package credentials
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials"
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials/memory"
+)
+
+// Service holds references to repositories to manage credentials
+type Service struct {
+	credentialRepo credentials.Repository
+}
+
+// memoryService is an in-memory instantiation of the credential service so that it can be used by others
+var memoryService = &Service{credentialRepo: WithCredentialMemoryRepository()}
+
+// NewCredentialService is a factory to create a credential service to be used by other packages or services
+func NewCredentialService() *Service {
+	return memoryService
+}
+
+// WithCredentialMemoryRepository retrieves an in-memory credential repository interface used to manage credentials
+func WithCredentialMemoryRepository() credentials.Repository {
+	return memory.NewRepository()
+}
+
+// detector is a regular expression used to flag sensitive-looking content as a credential
+type detector struct {
+	credType credentials.Type
+	re       *regexp.Regexp
+}
+
+// detectors are used to find sensitive-looking content, such as passwords or private keys, in collected data.
+// The last capture group is used as the secret and a "user" named capture group, if present, is used as the username
+var detectors = []detector{
+	{credentials.KEY, regexp.MustCompile(`(?s)(-----BEGIN [A-Z ]*PRIVATE KEY-----.+?-----END [A-Z ]*PRIVATE KEY-----)`)},
+	{credentials.TOKEN, regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{credentials.TOKEN, regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,})\b`)},
+	{credentials.PLAINTEXT, regexp.MustCompile(`(?i)(?:user(?:name)?|login)\s*[:=]\s*(?P<user>\S+)\s+(?:pass(?:word)?|pwd)\s*[:=]\s*(\S+)`)},
+	{credentials.PLAINTEXT, regexp.MustCompile(`(?i)\b(?:pass(?:word)?|pwd|secret)\s*[:=]\s*(\S+)`)},
+}
+
+// Add stores the provided credential
+func (s *Service) Add(credential credentials.Credential) error {
+	return s.credentialRepo.Add(credential)
+}
+
+// All returns all stored credentials
+func (s *Service) All() []credentials.Credential {
+	return s.credentialRepo.GetAll()
+}
+
+// Detect searches the provided text for sensitive-looking content, such as passwords or private keys, and stores
+// anything found as a flagged credential that should be verified by an operator
+func (s *Service) Detect(agentID uuid.UUID, source, text string) (found []credentials.Credential) {
+	seen := make(map[string]bool)
+	for _, d := range detectors {
+		for _, match := range d.re.FindAllStringSubmatch(text, -1) {
+			secret := match[len(match)-1]
+			if seen[secret] {
+				continue
+			}
+			seen[secret] = true
+			var username string
+			if i := d.re.SubexpIndex("user"); i > 0 {
+				username = match[i]
+			}
+			var domain string
+			if before, after, ok := strings.Cut(username, "\\"); ok {
+				domain, username = before, after
+			}
+			credential := credentials.NewCredential(d.credType, domain, username, secret, source, agentID, true)
+			err := s.credentialRepo.Add(credential)
+			if err != nil {
+				slog.Error(fmt.Sprintf("pkg/services/credentials.Detect(): there was an error adding a credential: %s", err))
+				continue
+			}
+			found = append(found, credential)
+		}
+	}
+	return
+}
+
+// Get returns the credential for the provided ID
+func (s *Service) Get(id uuid.UUID) (credentials.Credential, error) {
+	return s.credentialRepo.Get(id)
+}
+
+// Lookup returns the most suitable stored credential of one of the provided types for the username.
+// The username can be prefixed with a domain (e.g., DOMAIN\\user or user@domain) to only match that domain.
+// Credentials verified by an operator are preferred over flagged credentials, and newer credentials over older ones
+func (s *Service) Lookup(username string, types ...credentials.Type) (credential credentials.Credential, err error) {
+	var domain string
+	if before, after, ok := strings.Cut(username, "\\"); ok {
+		domain, username = before, after
+	} else if before, after, ok = strings.Cut(username, "@"); ok {
+		username, domain = before, after
+	}
+
+	var found bool
+	for _, c := range s.credentialRepo.GetAll() {
+		if !strings.EqualFold(c.Username(), username) {
+			continue
+		}
+		if domain != "" && !strings.EqualFold(c.Domain(), domain) {
+			continue
+		}
+		var match bool
+		for _, t := range types {
+			if c.Type() == t {
+				match = true
+			}
+		}
+		if !match && len(types) > 0 {
+			continue
+		}
+		// GetAll is sorted oldest first, so later credentials replace earlier ones unless they would replace a
+		// verified credential with a flagged one
+		if found && c.Flagged() && !credential.Flagged() {
+			continue
+		}
+		credential = c
+		found = true
+	}
+	if !found {
+		err = fmt.Errorf("pkg/services/credentials.Lookup(): there are no credentials for %s in the credential store", username)
+	}
+	return
+}
+
+// Remove deletes the credential for the provided ID
+func (s *Service) Remove(id uuid.UUID) error {
+	return s.credentialRepo.Remove(id)
+}
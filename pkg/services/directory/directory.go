@@ -0,0 +1,125 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package directory is a service used to store and query Active Directory objects enumerated by Agents over LDAP
+package directory
+
+import (
+	// Standard
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/directory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/directory/memory"
+)
+
+// Service holds references to repositories to manage Active Directory objects
+type Service struct {
+	directoryRepo directory.Repository
+}
+
+// memoryService is an in-memory instantiation of the directory service so that it can be used by others
+var memoryService *Service
+
+// NewDirectoryService is a factory to create a directory service to be used by other packages or services
+func NewDirectoryService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			directoryRepo: WithDirectoryMemoryRepository(),
+		}
+	}
+	return memoryService
+}
+
+// WithDirectoryMemoryRepository retrieves an in-memory directory repository interface used to manage Active Directory objects
+func WithDirectoryMemoryRepository() directory.Repository {
+	return memory.NewRepository()
+}
+
+// entry is a single LDAP search result returned by the Agent
+type entry struct {
+	DN         string              `json:"dn"`
+	Attributes map[string][]string `json:"attributes"`
+}
+
+// AddResults parses a page of LDAP search results returned by the Agent and stores them in the repository.
+// The Agent returns one JSON object per line containing the object's distinguished name and its attributes
+func (s *Service) AddResults(agentID uuid.UUID, jobID string, class directory.Class, output string) (count int, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var e entry
+		err = json.Unmarshal([]byte(line), &e)
+		if err != nil {
+			return count, fmt.Errorf("pkg/services/directory.AddResults(): there was an error parsing the LDAP search result: %s", err)
+		}
+		if e.DN == "" {
+			return count, fmt.Errorf("pkg/services/directory.AddResults(): the LDAP search result did not contain a distinguished name")
+		}
+		err = s.directoryRepo.Add(directory.NewObject(e.DN, class, e.Attributes, agentID, jobID))
+		if err != nil {
+			return
+		}
+		count++
+	}
+	return
+}
+
+// Get returns the Active Directory object for the provided distinguished name
+func (s *Service) Get(dn string) (directory.Object, error) {
+	return s.directoryRepo.Get(dn)
+}
+
+// Query returns all stored Active Directory objects of the provided class whose distinguished name or attribute values
+// contain the search term. UNDEFINED matches every class and an empty search term matches every object
+func (s *Service) Query(class directory.Class, search string) (objects []directory.Object) {
+	search = strings.ToLower(search)
+	for _, object := range s.directoryRepo.GetAll() {
+		if class != directory.UNDEFINED && object.Class() != class {
+			continue
+		}
+		if search == "" || matches(object, search) {
+			objects = append(objects, object)
+		}
+	}
+	return
+}
+
+// matches determines if the object's distinguished name or any of its attribute values contain the lower case search term
+func matches(object directory.Object, search string) bool {
+	if strings.Contains(strings.ToLower(object.DN()), search) {
+		return true
+	}
+	for _, values := range object.Attributes() {
+		for _, value := range values {
+			if strings.Contains(strings.ToLower(value), search) {
+				return true
+			}
+		}
+	}
+	return false
+}
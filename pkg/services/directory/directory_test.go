@@ -0,0 +1,108 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package directory
+
+import (
+	// Standard
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/directory"
+)
+
+// TestAddResults verifies LDAP search results are parsed one object per line and malformed results are rejected
+func TestAddResults(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		count  int
+		err    string
+	}{
+		{"empty", "\n\n", 0, ""},
+		{"objects", `{"dn":"CN=alice,DC=test,DC=local","attributes":{"sAMAccountName":["alice"]}}` + "\r\n" + `{"dn":"CN=bob,DC=test,DC=local"}`, 2, ""},
+		{"invalid JSON", `{"dn":"CN=carol,DC=test,DC=local"}` + "\nnot json", 1, "parsing the LDAP search result"},
+		{"missing DN", `{"attributes":{"cn":["dave"]}}`, 0, "did not contain a distinguished name"},
+	}
+	s := NewDirectoryService()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count, err := s.AddResults(uuid.New(), "job", directory.USER, test.output)
+			if count != test.count {
+				t.Errorf("expected %d objects to be stored, got %d", test.count, count)
+			}
+			if test.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Errorf("expected an error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+	object, err := s.Get("cn=ALICE,dc=test,dc=local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if object.Name() != "alice" || object.Class() != directory.USER {
+		t.Errorf("expected user alice, got %s %s", object.Class(), object.Name())
+	}
+}
+
+// TestQuery verifies objects are filtered by class and by a case-insensitive search of their DN and attributes
+func TestQuery(t *testing.T) {
+	s := NewDirectoryService()
+	agent := uuid.New()
+	output := `{"dn":"CN=WS01,OU=Query,DC=query,DC=local","attributes":{"operatingSystem":["Windows 11"]}}` + "\n" +
+		`{"dn":"CN=SRV01,OU=Query,DC=query,DC=local","attributes":{"operatingSystem":["Windows Server 2022"]}}`
+	if _, err := s.AddResults(agent, "job", directory.COMPUTER, output); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddResults(agent, "job", directory.GROUP, `{"dn":"CN=Admins,OU=Query,DC=query,DC=local"}`); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		class  directory.Class
+		search string
+		want   []string
+	}{
+		{"all in OU", directory.UNDEFINED, "ou=query", []string{"CN=Admins,OU=Query,DC=query,DC=local", "CN=SRV01,OU=Query,DC=query,DC=local", "CN=WS01,OU=Query,DC=query,DC=local"}},
+		{"computers", directory.COMPUTER, "dc=query", []string{"CN=SRV01,OU=Query,DC=query,DC=local", "CN=WS01,OU=Query,DC=query,DC=local"}},
+		{"attribute", directory.COMPUTER, "SERVER", []string{"CN=SRV01,OU=Query,DC=query,DC=local"}},
+		{"groups", directory.GROUP, "admins", []string{"CN=Admins,OU=Query,DC=query,DC=local"}},
+		{"no match", directory.USER, "ou=query", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, object := range s.Query(test.class, test.search) {
+				got = append(got, object.DN())
+			}
+			if strings.Join(got, "|") != strings.Join(test.want, "|") {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package hosts is a service used to track every host seen in the target environment, from external scan results,
+// Agent host information, network scans, and lateral movement, and correlate them with the Agents running on them, the
+// credentials used to access them, and the commands run against them. It is the operator's working map of the environment
+package hosts
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	agentMemory "github.com/Ne0nd0g/merlin/v2/pkg/agents/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials"
+	credentialMemory "github.com/Ne0nd0g/merlin/v2/pkg/credentials/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/hosts"
+	"github.com/Ne0nd0g/merlin/v2/pkg/hosts/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc"
+	iocMemory "github.com/Ne0nd0g/merlin/v2/pkg/ioc/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+	jobMemory "github.com/Ne0nd0g/merlin/v2/pkg/jobs/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/scan"
+)
+
+const (
+	// SourceAgent is a host an Agent is running on
+	SourceAgent = "agent"
+	// SourceScan is a host with open ports discovered by an Agent's scan command
+	SourceScan = "scan"
+	// SourceLateral is a host an Agent successfully moved laterally to
+	SourceLateral = "lateral"
+)
+
+// Service holds references to repositories to manage hosts and correlate them with Agent activity.
+// The repositories are used directly, instead of their services, so that the job service can add hosts
+type Service struct {
+	hostRepo       hosts.Repository
+	agentRepo      agents.Repository
+	credentialRepo credentials.Repository
+	iocRepo        ioc.Repository
+	jobRepo        jobs.Repository
+}
+
+// Activity is a command an Agent ran on a host or an artifact a job created on it
+type Activity struct {
+	Time    time.Time // Time is when the job was created
+	AgentID uuid.UUID // AgentID is the Agent that ran the job
+	JobID   string    // JobID is the job that ran the command or created the artifact
+	Command string    // Command is the command the job ran or the artifact it created
+}
+
+// memoryService is an in-memory instantiation of the hosts service so that it can be used by others
+var memoryService *Service
+
+// NewHostService is a factory to create a hosts service to be used by other packages or services
+func NewHostService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			hostRepo:       WithHostMemoryRepository(),
+			agentRepo:      agentMemory.NewRepository(),
+			credentialRepo: credentialMemory.NewRepository(),
+			iocRepo:        iocMemory.NewRepository(),
+			jobRepo:        jobMemory.NewRepository(),
+		}
+	}
+	return memoryService
+}
+
+// WithHostMemoryRepository retrieves an in-memory hosts repository interface used to manage hosts
+func WithHostMemoryRepository() hosts.Repository {
+	return memory.NewRepository()
+}
+
+// AddAgent adds the host the Agent is running on using the host and security product information the Agent returned.
+// Loopback and link-local interface addresses are ignored. An operating system from a scan is kept because it is more specific
+func (s *Service) AddAgent(a agents.Agent) error {
+	var addresses []string
+	for _, address := range a.Host().IPs {
+		ip, _, err := net.ParseCIDR(address)
+		if err != nil {
+			ip = net.ParseIP(address)
+		}
+		if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		addresses = append(addresses, ip.String())
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	host := s.host(addresses, a.Host().Name)
+	for _, address := range addresses {
+		host.AddAddress(address)
+	}
+	host.AddHostname(a.Host().Name)
+	if existing, err := s.hostRepo.Get(host.Address()); err != nil || existing.OS() == "" {
+		host.SetOS(strings.TrimSpace(a.Host().Platform + " " + a.Host().Architecture))
+	}
+	for _, product := range a.SecurityProducts() {
+		host.AddProduct(product)
+	}
+	host.AddSource(SourceAgent)
+	return s.add(host)
+}
+
+// AddNote adds an operator's comment to the host with the provided IP address
+func (s *Service) AddNote(address, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("pkg/services/hosts.AddNote(): the note is empty")
+	}
+	existing, err := s.hostRepo.Get(address)
+	if err != nil {
+		return fmt.Errorf("pkg/services/hosts.AddNote(): %s", err)
+	}
+	host := hosts.NewHost(existing.Address())
+	host.AddNote(strings.TrimSpace(text))
+	return s.add(host)
+}
+
+// AddPorts adds the hosts with open ports discovered by an Agent's scan command
+func (s *Service) AddPorts(ports []scan.Port) error {
+	for _, p := range ports {
+		host := s.host([]string{p.Host()}, "")
+		host.AddPort(hosts.Port{Number: p.Number(), Protocol: p.Protocol(), Product: p.Banner()})
+		host.AddSource(SourceScan)
+		err := s.add(host)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddTarget adds the host, by IP address or name, that an Agent successfully moved laterally to and links the stored
+// credential that was used to access it. The credential is uuid.Nil if the Agent used its own token
+func (s *Service) AddTarget(target string, credential uuid.UUID) error {
+	var addresses []string
+	var name string
+	if ip := net.ParseIP(target); ip != nil {
+		addresses = append(addresses, ip.String())
+	} else {
+		name = target
+	}
+	host := s.host(addresses, name)
+	host.AddHostname(name)
+	if credential != uuid.Nil {
+		host.AddCredential(credential)
+	}
+	host.AddSource(SourceLateral)
+	return s.add(host)
+}
+
+// Activity returns the commands Agents on the host ran and the artifacts jobs from any Agent created on it, oldest first
+func (s *Service) Activity(address string) ([]Activity, error) {
+	host, err := s.hostRepo.Get(address)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/hosts.Activity(): %s", err)
+	}
+
+	var activity []Activity
+	present := make(map[uuid.UUID]bool)
+	for _, id := range s.Agents(host) {
+		present[id] = true
+	}
+	for _, info := range s.jobRepo.GetAll() {
+		if present[info.AgentID()] {
+			activity = append(activity, Activity{Time: info.Created(), AgentID: info.AgentID(), JobID: info.ID(), Command: info.Command()})
+		}
+	}
+	for _, indicator := range s.iocRepo.GetAll() {
+		if matches(host, indicator.Host()) {
+			activity = append(activity, Activity{
+				Time:    indicator.Created(),
+				AgentID: indicator.AgentID(),
+				JobID:   indicator.JobID(),
+				Command: fmt.Sprintf("%s %s", indicator.Type(), indicator.Value()),
+			})
+		}
+	}
+	sort.SliceStable(activity, func(i, j int) bool {
+		return activity[i].Time.Before(activity[j].Time)
+	})
+	return activity, nil
+}
+
+// Agents returns the IDs of the Agents running on the host, matched by the Agent host's IP addresses or name
+func (s *Service) Agents(host hosts.Host) (ids []uuid.UUID) {
+	for _, a := range s.agentRepo.GetAll() {
+		h := a.Host()
+		found := matches(host, h.Name)
+		for _, ip := range h.IPs {
+			found = found || matches(host, ip)
+		}
+		if found {
+			ids = append(ids, a.ID())
+		}
+	}
+	return
+}
+
+// Credentials returns the stored credentials used to access the host and those collected by Agents running on it
+func (s *Service) Credentials(host hosts.Host) (found []credentials.Credential) {
+	linked := make(map[uuid.UUID]bool)
+	for _, id := range host.Credentials() {
+		linked[id] = true
+	}
+	for _, id := range s.Agents(host) {
+		linked[id] = true
+	}
+	for _, c := range s.credentialRepo.GetAll() {
+		if linked[c.ID()] || (c.AgentID() != uuid.Nil && linked[c.AgentID()]) {
+			found = append(found, c)
+		}
+	}
+	return
+}
+
+// Host returns the tracked host with the provided IP address
+func (s *Service) Host(address string) (hosts.Host, error) {
+	host, err := s.hostRepo.Get(address)
+	if err != nil {
+		return hosts.Host{}, fmt.Errorf("pkg/services/hosts.Host(): %s", err)
+	}
+	return host, nil
+}
+
+// Hosts returns all tracked hosts sorted by IP address
+func (s *Service) Hosts() []hosts.Host {
+	return s.hostRepo.GetAll()
+}
+
+// add stores the host, merging it with the existing host that has the same primary address
+func (s *Service) add(host hosts.Host) error {
+	err := s.hostRepo.Add(host)
+	if err != nil {
+		return fmt.Errorf("pkg/services/hosts.add(): %s", err)
+	}
+	return nil
+}
+
+// host returns a new Host to merge information into the tracked host with one of the provided addresses or the name.
+// A new host's primary address is the first address, or the name when no addresses are known
+func (s *Service) host(addresses []string, name string) hosts.Host {
+	for _, address := range addresses {
+		if existing, err := s.hostRepo.Get(address); err == nil {
+			return hosts.NewHost(existing.Address())
+		}
+	}
+	if name != "" {
+		for _, existing := range s.hostRepo.GetAll() {
+			if matches(existing, name) {
+				return hosts.NewHost(existing.Address())
+			}
+		}
+	}
+	if len(addresses) > 0 {
+		return hosts.NewHost(addresses[0])
+	}
+	return hosts.NewHost(name)
+}
+
+// Summary returns the number of findings for each severity as a string (e.g., 2 critical, 1 high)
+func Summary(findings []hosts.Finding) string {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Severity]++
+	}
+	var summary []string
+	for _, severity := range []string{"critical", "high", "medium", "low"} {
+		if counts[severity] > 0 {
+			summary = append(summary, strconv.Itoa(counts[severity])+" "+severity)
+		}
+	}
+	return strings.Join(summary, ", ")
+}
+
+// matches returns true if the value is one of the host's IP addresses or names. Agents report their interface
+// addresses in CIDR notation and names are compared without their domain
+func matches(host hosts.Host, value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	if ip, _, err := net.ParseCIDR(value); err == nil {
+		value = ip.String()
+	}
+	if ip := net.ParseIP(value); ip != nil {
+		for _, address := range host.Addresses() {
+			if ip.Equal(net.ParseIP(address)) {
+				return true
+			}
+		}
+		return false
+	}
+	short := strings.Split(value, ".")[0]
+	for _, name := range host.Hostnames() {
+		if strings.EqualFold(name, value) || strings.EqualFold(strings.Split(name, ".")[0], short) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,305 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package hosts
+
+import (
+	// Standard
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials"
+	"github.com/Ne0nd0g/merlin/v2/pkg/scan"
+)
+
+// newAgent returns an Agent on the provided host whose log file is written to a temporary directory
+func newAgent(t *testing.T, host agents.Host) agents.Agent {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	a, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.UpdateHost(host)
+	return a
+}
+
+// TestAddAgent verifies the host an Agent runs on is added by its routable interface addresses and that an operating
+// system from a scan is kept
+func TestAddAgent(t *testing.T) {
+	s := NewHostService()
+	cases := []struct {
+		name    string
+		host    agents.Host
+		address string
+		want    []string
+		os      string
+	}{
+		{
+			"interfaces",
+			agents.Host{Name: "WS01", Platform: "windows", Architecture: "amd64", IPs: []string{"127.0.0.1/8", "fe80::1/64", "198.51.100.20/24", "198.51.100.21/24"}},
+			"198.51.100.20", []string{"198.51.100.20", "198.51.100.21"}, "windows amd64",
+		},
+		{
+			"scanned OS",
+			agents.Host{Name: "WS02", Platform: "windows", Architecture: "amd64", IPs: []string{"198.51.100.22"}},
+			"198.51.100.22", []string{"198.51.100.22"}, "Windows 10 Enterprise",
+		},
+		{
+			"loopback only",
+			agents.Host{Name: "WS03", IPs: []string{"127.0.0.1/8", "::1/128"}},
+			"", nil, "",
+		},
+	}
+
+	nessus := `<NessusClientData_v2><Report><ReportHost name="198.51.100.22"><HostProperties><tag name="operating-system">Windows 10 Enterprise</tag></HostProperties></ReportHost></Report></NessusClientData_v2>`
+	if _, err := s.Import(FormatNessus, []byte(nessus)); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := newAgent(t, c.host)
+			if err := s.AddAgent(a); err != nil {
+				t.Fatal(err)
+			}
+			if c.address == "" {
+				for _, h := range s.Hosts() {
+					if matches(h, c.host.Name) {
+						t.Errorf("expected the host %s not to be added", c.host.Name)
+					}
+				}
+				return
+			}
+			host, err := s.Host(c.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(host.Addresses(), c.want) {
+				t.Errorf("expected addresses %v, have %v", c.want, host.Addresses())
+			}
+			if host.OS() != c.os {
+				t.Errorf("expected OS %q, have %q", c.os, host.OS())
+			}
+			if !strings.Contains(strings.Join(host.Sources(), ","), SourceAgent) {
+				t.Errorf("expected the %s source, have %v", SourceAgent, host.Sources())
+			}
+		})
+	}
+
+	// A secondary address resolves to the same host
+	host, err := s.Host("198.51.100.21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.Address() != "198.51.100.20" {
+		t.Errorf("expected the secondary address to resolve to 198.51.100.20, have %s", host.Address())
+	}
+}
+
+// TestAddTarget verifies lateral movement targets are merged into tracked hosts by address or name and the credential
+// used is linked
+func TestAddTarget(t *testing.T) {
+	s := NewHostService()
+	known := NewHostService()
+	seed := `<nmaprun><host><status state="up"/><address addr="198.51.100.30" addrtype="ipv4"/><hostnames><hostname name="sql01.corp.local"/></hostnames></host></nmaprun>`
+	if _, err := known.Import(FormatNmap, []byte(seed)); err != nil {
+		t.Fatal(err)
+	}
+	credential := uuid.New()
+	cases := []struct {
+		name       string
+		target     string
+		credential uuid.UUID
+		address    string
+		linked     int
+	}{
+		{"address", "198.51.100.31", credential, "198.51.100.31", 1},
+		{"known name", "SQL01", credential, "198.51.100.30", 1},
+		{"unknown name", "file01.corp.local", uuid.Nil, "file01.corp.local", 0},
+		{"agent token", "198.51.100.30", uuid.Nil, "198.51.100.30", 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := s.AddTarget(c.target, c.credential); err != nil {
+				t.Fatal(err)
+			}
+			host, err := s.Host(c.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(host.Credentials()) != c.linked {
+				t.Errorf("expected %d linked credentials, have %v", c.linked, host.Credentials())
+			}
+			if !strings.Contains(strings.Join(host.Sources(), ","), SourceLateral) {
+				t.Errorf("expected the %s source, have %v", SourceLateral, host.Sources())
+			}
+		})
+	}
+}
+
+// TestAddPorts verifies open ports from an Agent's scan are added to the scanned hosts
+func TestAddPorts(t *testing.T) {
+	s := NewHostService()
+	agent := uuid.New()
+	ports := []scan.Port{
+		scan.NewPort("198.51.100.40", 22, "tcp", "open", "SSH-2.0-OpenSSH_8.9", agent, "job"),
+		scan.NewPort("198.51.100.40", 80, "TCP", "open", "", agent, "job"),
+		scan.NewPort("198.51.100.41", 53, "udp", "open", "", agent, "job"),
+	}
+	if err := s.AddPorts(ports); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		address string
+		want    []string
+	}{
+		{"198.51.100.40", []string{"22/tcp", "80/tcp"}},
+		{"198.51.100.41", []string{"53/udp"}},
+	}
+	for _, c := range cases {
+		t.Run(c.address, func(t *testing.T) {
+			host, err := s.Host(c.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var keys []string
+			for _, p := range host.Ports() {
+				keys = append(keys, p.Key())
+			}
+			if !reflect.DeepEqual(keys, c.want) {
+				t.Errorf("expected ports %v, have %v", c.want, keys)
+			}
+		})
+	}
+}
+
+// TestAddNote verifies notes are added to existing hosts only and are not duplicated by later merges
+func TestAddNote(t *testing.T) {
+	s := NewHostService()
+	if err := s.AddTarget("198.51.100.50", uuid.Nil); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name    string
+		address string
+		text    string
+		err     string
+	}{
+		{"note", "198.51.100.50", "domain controller", ""},
+		{"second note", "198.51.100.50", " backup DC ", ""},
+		{"empty", "198.51.100.50", "  ", "the note is empty"},
+		{"unknown host", "198.51.100.51", "note", "does not exist"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := s.AddNote(c.address, c.text)
+			if c.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Fatalf("expected error containing %q, have %v", c.err, err)
+			}
+		})
+	}
+
+	if err := s.AddTarget("198.51.100.50", uuid.Nil); err != nil {
+		t.Fatal(err)
+	}
+	host, err := s.Host("198.51.100.50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var notes []string
+	for _, n := range host.Notes() {
+		notes = append(notes, n.Text)
+	}
+	if want := []string{"domain controller", "backup DC"}; !reflect.DeepEqual(notes, want) {
+		t.Errorf("expected notes %v, have %v", want, notes)
+	}
+}
+
+// TestCredentials verifies a host's credentials are those linked to it and those collected by Agents running on it
+func TestCredentials(t *testing.T) {
+	s := NewHostService()
+	a := newAgent(t, agents.Host{Name: "WS60", IPs: []string{"198.51.100.60/24"}})
+	if err := s.agentRepo.Add(a); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.agentRepo.Remove(a.ID()) })
+
+	linked := credentials.NewCredential(credentials.PLAINTEXT, "CORP", "admin", "Password1", "test", uuid.Nil, false)
+	collected := credentials.NewCredential(credentials.PLAINTEXT, "CORP", "user", "Password2", "test", a.ID(), false)
+	other := credentials.NewCredential(credentials.PLAINTEXT, "CORP", "other", "Password3", "test", uuid.New(), false)
+	for _, c := range []credentials.Credential{linked, collected, other} {
+		if err := s.credentialRepo.Add(c); err != nil {
+			t.Fatal(err)
+		}
+		id := c.ID()
+		t.Cleanup(func() { _ = s.credentialRepo.Remove(id) })
+	}
+	if err := s.AddAgent(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddTarget("198.51.100.60", linked.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	host, err := s.Host("198.51.100.60")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := make(map[uuid.UUID]bool)
+	for _, c := range s.Credentials(host) {
+		found[c.ID()] = true
+	}
+	cases := []struct {
+		name string
+		id   uuid.UUID
+		want bool
+	}{
+		{"linked", linked.ID(), true},
+		{"collected", collected.ID(), true},
+		{"other", other.ID(), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if found[c.id] != c.want {
+				t.Errorf("expected found %t, have %t", c.want, found[c.id])
+			}
+		})
+	}
+}
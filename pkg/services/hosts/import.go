@@ -0,0 +1,247 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package hosts
+
+import (
+	// Standard
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/hosts"
+)
+
+const (
+	// FormatNmap is the XML output of an Nmap scan (-oX)
+	FormatNmap = "nmap"
+	// FormatNessus is a Nessus scan exported in the .nessus (v2) format
+	FormatNessus = "nessus"
+)
+
+// nessusSeverity maps the numeric severity of a Nessus finding to its name
+var nessusSeverity = map[string]string{
+	"0": "info",
+	"1": "low",
+	"2": "medium",
+	"3": "high",
+	"4": "critical",
+}
+
+// nmapRun is the subset of an Nmap XML scan that is imported
+type nmapRun struct {
+	Hosts []struct {
+		Status struct {
+			State string `xml:"state,attr"`
+		} `xml:"status"`
+		Addresses []struct {
+			Addr     string `xml:"addr,attr"`
+			AddrType string `xml:"addrtype,attr"`
+		} `xml:"address"`
+		Hostnames []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"hostnames>hostname"`
+		Ports []struct {
+			Protocol string `xml:"protocol,attr"`
+			PortID   int    `xml:"portid,attr"`
+			State    struct {
+				State string `xml:"state,attr"`
+			} `xml:"state"`
+			Service struct {
+				Name    string `xml:"name,attr"`
+				Product string `xml:"product,attr"`
+				Version string `xml:"version,attr"`
+			} `xml:"service"`
+		} `xml:"ports>port"`
+		OSMatches []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"os>osmatch"`
+	} `xml:"host"`
+}
+
+// nessusReport is the subset of a .nessus (v2) scan that is imported
+type nessusReport struct {
+	Hosts []struct {
+		Name       string `xml:"name,attr"`
+		Properties []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"HostProperties>tag"`
+		Items []struct {
+			Port       int    `xml:"port,attr"`
+			Protocol   string `xml:"protocol,attr"`
+			Service    string `xml:"svc_name,attr"`
+			Severity   string `xml:"severity,attr"`
+			PluginID   string `xml:"pluginID,attr"`
+			PluginName string `xml:"pluginName,attr"`
+		} `xml:"ReportItem"`
+	} `xml:"Report>ReportHost"`
+}
+
+// Import parses external scan results in the provided format and adds the discovered hosts, merging them with hosts
+// that are already tracked. The format is detected from the data if it is empty. The number of hosts is returned
+func (s *Service) Import(format string, data []byte) (int, error) {
+	if format == "" {
+		format = detect(data)
+	}
+	var imported []hosts.Host
+	var err error
+	switch strings.ToLower(format) {
+	case FormatNmap:
+		imported, err = parseNmap(data)
+	case FormatNessus:
+		imported, err = parseNessus(data)
+	default:
+		return 0, fmt.Errorf("pkg/services/hosts.Import(): unknown scan format '%s', expected %s or %s", format, FormatNmap, FormatNessus)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("pkg/services/hosts.Import(): %s", err)
+	}
+
+	for _, host := range imported {
+		err = s.hostRepo.Add(host)
+		if err != nil {
+			return 0, fmt.Errorf("pkg/services/hosts.Import(): %s", err)
+		}
+	}
+	return len(imported), nil
+}
+
+// detect returns the scan format based on the XML document's root element
+func detect(data []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "nmaprun":
+				return FormatNmap
+			case "NessusClientData_v2":
+				return FormatNessus
+			}
+			return ""
+		}
+	}
+}
+
+// decode unmarshalls the XML document. Nmap includes a DOCTYPE that the decoder skips because it doesn't resolve
+// external entities
+func decode(data []byte, v interface{}) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	return decoder.Decode(v)
+}
+
+// parseNmap returns the hosts that were up in an Nmap XML scan along with their open ports
+func parseNmap(data []byte) ([]hosts.Host, error) {
+	var run nmapRun
+	if err := decode(data, &run); err != nil {
+		return nil, fmt.Errorf("there was an error parsing the Nmap XML: %s", err)
+	}
+
+	var imported []hosts.Host
+	for _, h := range run.Hosts {
+		if h.Status.State != "" && h.Status.State != "up" {
+			continue
+		}
+		var address string
+		for _, a := range h.Addresses {
+			if a.AddrType == "ipv4" || a.AddrType == "ipv6" {
+				address = a.Addr
+				break
+			}
+		}
+		if net.ParseIP(address) == nil {
+			continue
+		}
+		host := hosts.NewHost(address)
+		host.AddSource(FormatNmap)
+		for _, name := range h.Hostnames {
+			host.AddHostname(name.Name)
+		}
+		if len(h.OSMatches) > 0 {
+			host.SetOS(h.OSMatches[0].Name)
+		}
+		for _, p := range h.Ports {
+			if p.State.State != "open" {
+				continue
+			}
+			host.AddPort(hosts.Port{
+				Number:   p.PortID,
+				Protocol: p.Protocol,
+				Service:  p.Service.Name,
+				Product:  strings.TrimSpace(p.Service.Product + " " + p.Service.Version),
+			})
+		}
+		imported = append(imported, host)
+	}
+	return imported, nil
+}
+
+// parseNessus returns the hosts in a .nessus (v2) scan along with the ports and findings reported for them.
+// Informational findings are only used to discover ports
+func parseNessus(data []byte) ([]hosts.Host, error) {
+	var report nessusReport
+	if err := decode(data, &report); err != nil {
+		return nil, fmt.Errorf("there was an error parsing the Nessus XML: %s", err)
+	}
+
+	var imported []hosts.Host
+	for _, h := range report.Hosts {
+		properties := make(map[string]string)
+		for _, tag := range h.Properties {
+			properties[tag.Name] = strings.TrimSpace(tag.Value)
+		}
+		address := properties["host-ip"]
+		if address == "" {
+			address = h.Name
+		}
+		if net.ParseIP(address) == nil {
+			continue
+		}
+		host := hosts.NewHost(address)
+		host.AddSource(FormatNessus)
+		host.AddHostname(properties["host-fqdn"])
+		host.AddHostname(properties["netbios-name"])
+		if h.Name != address {
+			host.AddHostname(h.Name)
+		}
+		host.SetOS(strings.Split(properties["operating-system"], "\n")[0])
+		for _, item := range h.Items {
+			if item.Port > 0 {
+				host.AddPort(hosts.Port{Number: item.Port, Protocol: item.Protocol, Service: strings.TrimSuffix(item.Service, "?")})
+			}
+			severity := nessusSeverity[item.Severity]
+			if severity == "" || severity == "info" {
+				continue
+			}
+			host.AddFinding(hosts.Finding{ID: item.PluginID, Name: item.PluginName, Severity: severity, Port: item.Port})
+		}
+		imported = append(imported, host)
+	}
+	return imported, nil
+}
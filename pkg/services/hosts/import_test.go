@@ -0,0 +1,228 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package hosts
+
+import (
+	// Standard
+	"reflect"
+	"strings"
+	"testing"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/hosts"
+)
+
+const nmapXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE nmaprun>
+<nmaprun scanner="nmap" args="nmap -sV -O -oX scan.xml 203.0.113.0/29">
+<host><status state="up"/><address addr="203.0.113.2" addrtype="ipv4"/><address addr="00:11:22:33:44:55" addrtype="mac"/>
+<hostnames><hostname name="dc01.corp.local" type="PTR"/></hostnames>
+<ports>
+<port protocol="tcp" portid="445"><state state="open"/><service name="microsoft-ds" product="Microsoft Windows Server 2008 R2 - 2012" version="microsoft-ds"/></port>
+<port protocol="tcp" portid="88"><state state="open"/><service name="kerberos-sec"/></port>
+<port protocol="tcp" portid="23"><state state="filtered"/><service name="telnet"/></port>
+</ports>
+<os><osmatch name="Microsoft Windows Server 2012" accuracy="96"/><osmatch name="Microsoft Windows 8" accuracy="90"/></os>
+</host>
+<host><status state="down"/><address addr="203.0.113.3" addrtype="ipv4"/></host>
+</nmaprun>`
+
+const nessusXML = `<?xml version="1.0" ?>
+<NessusClientData_v2>
+<Report name="scan">
+<ReportHost name="dc01.corp.local">
+<HostProperties>
+<tag name="host-ip">203.0.113.2</tag>
+<tag name="netbios-name">DC01</tag>
+<tag name="operating-system">Microsoft Windows Server 2012 R2 Standard
+Microsoft Windows Server 2012 R2 Datacenter</tag>
+</HostProperties>
+<ReportItem port="0" svc_name="general" protocol="tcp" severity="0" pluginID="19506" pluginName="Nessus Scan Information"/>
+<ReportItem port="445" svc_name="cifs" protocol="tcp" severity="2" pluginID="57608" pluginName="SMB Signing not required"/>
+<ReportItem port="3389" svc_name="msrdp?" protocol="tcp" severity="0" pluginID="10940" pluginName="Remote Desktop Protocol Service Detection"/>
+</ReportHost>
+<ReportHost name="not-an-address"><HostProperties></HostProperties></ReportHost>
+</Report>
+</NessusClientData_v2>`
+
+// TestDetect verifies the scan format is detected from the XML document's root element
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"nmap", nmapXML, FormatNmap},
+		{"nessus", nessusXML, FormatNessus},
+		{"other XML", `<?xml version="1.0"?><root/>`, ""},
+		{"not XML", "Nmap scan report for 203.0.113.2", ""},
+		{"empty", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := detect([]byte(c.data)); got != c.want {
+				t.Errorf("expected format %q, have %q", c.want, got)
+			}
+		})
+	}
+}
+
+// TestParseNmap verifies only hosts that are up and their open ports are imported
+func TestParseNmap(t *testing.T) {
+	imported, err := parseNmap([]byte(nmapXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 host, have %d", len(imported))
+	}
+	host := imported[0]
+	cases := []struct {
+		name string
+		have interface{}
+		want interface{}
+	}{
+		{"address", host.Address(), "203.0.113.2"},
+		{"hostnames", host.Hostnames(), []string{"dc01.corp.local"}},
+		{"os", host.OS(), "Microsoft Windows Server 2012"},
+		{"sources", host.Sources(), []string{FormatNmap}},
+		{"ports", host.Ports(), []hosts.Port{
+			{Number: 88, Protocol: "tcp", Service: "kerberos-sec"},
+			{Number: 445, Protocol: "tcp", Service: "microsoft-ds", Product: "Microsoft Windows Server 2008 R2 - 2012 microsoft-ds"},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !reflect.DeepEqual(c.have, c.want) {
+				t.Errorf("expected %+v, have %+v", c.want, c.have)
+			}
+		})
+	}
+}
+
+// TestParseNessus verifies hosts are identified by their IP address, informational findings are only used to discover
+// ports, and hosts without an IP address are skipped
+func TestParseNessus(t *testing.T) {
+	imported, err := parseNessus([]byte(nessusXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 host, have %d", len(imported))
+	}
+	host := imported[0]
+	cases := []struct {
+		name string
+		have interface{}
+		want interface{}
+	}{
+		{"address", host.Address(), "203.0.113.2"},
+		{"hostnames", host.Hostnames(), []string{"DC01", "dc01.corp.local"}},
+		{"os", host.OS(), "Microsoft Windows Server 2012 R2 Standard"},
+		{"sources", host.Sources(), []string{FormatNessus}},
+		{"ports", host.Ports(), []hosts.Port{
+			{Number: 445, Protocol: "tcp", Service: "cifs"},
+			{Number: 3389, Protocol: "tcp", Service: "msrdp"},
+		}},
+		{"findings", host.Findings(), []hosts.Finding{{ID: "57608", Name: "SMB Signing not required", Severity: "medium", Port: 445}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !reflect.DeepEqual(c.have, c.want) {
+				t.Errorf("expected %+v, have %+v", c.want, c.have)
+			}
+		})
+	}
+}
+
+// TestImport verifies scans are imported in the requested or detected format and merged with the tracked hosts
+func TestImport(t *testing.T) {
+	s := NewHostService()
+	cases := []struct {
+		name   string
+		format string
+		data   string
+		count  int
+		err    string
+	}{
+		{"nmap", FormatNmap, nmapXML, 1, ""},
+		{"detected nessus", "", nessusXML, 1, ""},
+		{"uppercase format", "NMAP", nmapXML, 1, ""},
+		{"unknown format", "masscan", nmapXML, 0, "unknown scan format"},
+		{"undetected format", "", "203.0.113.2", 0, "unknown scan format"},
+		{"invalid XML", FormatNmap, "<nmaprun><host>", 0, "parsing the Nmap XML"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			count, err := s.Import(c.format, []byte(c.data))
+			if c.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Fatalf("expected error containing %q, have %v", c.err, err)
+			}
+			if count != c.count {
+				t.Errorf("expected %d hosts, have %d", c.count, count)
+			}
+		})
+	}
+
+	host, err := s.hostRepo.Get("203.0.113.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{FormatNmap, FormatNessus}; !reflect.DeepEqual(host.Sources(), want) {
+		t.Errorf("expected sources %v, have %v", want, host.Sources())
+	}
+	if len(host.Ports()) != 3 {
+		t.Errorf("expected the Nmap and Nessus ports to be merged into 3 ports, have %+v", host.Ports())
+	}
+	if summary := Summary(host.Findings()); summary != "1 medium" {
+		t.Errorf("expected the finding summary '1 medium', have %q", summary)
+	}
+}
+
+// TestMatches verifies hosts are matched by IP address, including the CIDR notation Agents report, and by name
+// with or without the domain
+func TestMatches(t *testing.T) {
+	host := hosts.NewHost("203.0.113.2")
+	host.AddHostname("DC01.corp.local")
+	cases := []struct {
+		value string
+		want  bool
+	}{
+		{"203.0.113.2", true},
+		{"203.0.113.2/24", true},
+		{"203.0.113.3", false},
+		{"dc01", true},
+		{"dc01.corp.local", true},
+		{"DC01.other.local", true},
+		{"dc02", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			if got := matches(host, c.value); got != c.want {
+				t.Errorf("expected %t, have %t", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package ioc is a service used to track the Indicators of Compromise (IOC) Agent jobs leave on target hosts so that
+// they can be provided to the blue team for deconfliction at the end of an engagement
+package ioc
+
+import (
+	// Standard
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc/memory"
+)
+
+// Service holds references to repositories to manage Indicators of Compromise
+type Service struct {
+	iocRepo ioc.Repository
+}
+
+// memoryService is an in-memory instantiation of the IOC service so that it can be used by others
+var memoryService *Service
+
+// NewIOCService is a factory to create an IOC service to be used by other packages or services
+func NewIOCService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			iocRepo: WithIOCMemoryRepository(),
+		}
+	}
+	return memoryService
+}
+
+// WithIOCMemoryRepository retrieves an in-memory IOC repository interface used to manage indicators
+func WithIOCMemoryRepository() ioc.Repository {
+	return memory.NewRepository()
+}
+
+// Add records an indicator for an artifact an Agent job created or modified
+func (s *Service) Add(indicator ioc.Indicator) error {
+	return s.iocRepo.Add(indicator)
+}
+
+// Agent returns all indicators for jobs the provided Agent executed
+func (s *Service) Agent(agentID uuid.UUID) (indicators []ioc.Indicator) {
+	for _, indicator := range s.iocRepo.GetAll() {
+		if indicator.AgentID() == agentID {
+			indicators = append(indicators, indicator)
+		}
+	}
+	return
+}
+
+// All returns every recorded indicator
+func (s *Service) All() []ioc.Indicator {
+	return s.iocRepo.GetAll()
+}
+
+// record is a single exported indicator
+type record struct {
+	Time      string `json:"time"`
+	Host      string `json:"host"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Detail    string `json:"detail,omitempty"`
+	Agent     string `json:"agent"`
+	Job       string `json:"job"`
+	JobStatus string `json:"job_status,omitempty"`
+}
+
+// Export returns every recorded indicator in the provided format, either csv or json, as a deconfliction document.
+// The status map, keyed by job ID, is used to include whether the job that created the artifact completed
+func (s *Service) Export(format string, status map[string]string) ([]byte, error) {
+	var records []record
+	for _, i := range s.iocRepo.GetAll() {
+		records = append(records, record{
+			Time:      i.Created().Format(time.RFC3339),
+			Host:      i.Host(),
+			Type:      i.Type().String(),
+			Value:     i.Value(),
+			Detail:    i.Detail(),
+			Agent:     i.AgentID().String(),
+			Job:       i.JobID(),
+			JobStatus: status[i.JobID()],
+		})
+	}
+
+	switch strings.ToLower(format) {
+	case "csv", "":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		err := w.Write([]string{"time", "host", "type", "value", "detail", "agent", "job", "job_status"})
+		if err != nil {
+			return nil, fmt.Errorf("pkg/services/ioc.Export(): %s", err)
+		}
+		for _, r := range records {
+			err = w.Write([]string{r.Time, r.Host, r.Type, r.Value, r.Detail, r.Agent, r.Job, r.JobStatus})
+			if err != nil {
+				return nil, fmt.Errorf("pkg/services/ioc.Export(): %s", err)
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	case "json":
+		return json.MarshalIndent(records, "", "  ")
+	default:
+		return nil, fmt.Errorf("pkg/services/ioc.Export(): unknown export format '%s', expected csv or json", format)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ioc
+
+import (
+	// Standard
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc"
+)
+
+func TestExport(t *testing.T) {
+	s := NewIOCService()
+	agentID := uuid.New()
+	err := s.Add(ioc.NewIndicator(agentID, "export-1", "host1", ioc.FILE, "/tmp/merlin", "SHA256:00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Agent(agentID)) != 1 {
+		t.Fatalf("expected 1 indicator for the agent, have %d", len(s.Agent(agentID)))
+	}
+	status := map[string]string{"export-1": "Complete"}
+
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{"csv", "csv", false},
+		{"default", "", false},
+		{"json", "JSON", false},
+		{"unknown", "xml", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := s.Export(test.format, status)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var found bool
+			if test.format == "JSON" {
+				var records []record
+				if err = json.Unmarshal(data, &records); err != nil {
+					t.Fatal(err)
+				}
+				for _, r := range records {
+					if r.Job == "export-1" {
+						found = r.Host == "host1" && r.Type == "File" && r.Value == "/tmp/merlin" && r.JobStatus == "Complete"
+					}
+				}
+			} else {
+				rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(rows) < 2 || rows[0][0] != "time" {
+					t.Fatalf("expected a header and at least one row, have %v", rows)
+				}
+				for _, row := range rows[1:] {
+					if row[6] == "export-1" {
+						found = row[1] == "host1" && row[2] == "File" && row[3] == "/tmp/merlin" && row[7] == "Complete"
+					}
+				}
+			}
+			if !found {
+				t.Errorf("the exported %s document did not contain the expected indicator:\n%s", test.format, data)
+			}
+		})
+	}
+}
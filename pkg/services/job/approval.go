@@ -0,0 +1,85 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"strings"
+	"sync"
+)
+
+// approvalPolicy holds the command classes that require two-person integrity. The RPC service holds an operator's
+// call for one of these classes until a second operator approves it. Jobs created on the server without an operator's
+// call, such as by scripts, can't be approved and are refused
+var approvalPolicy = struct {
+	sync.RWMutex
+	classes map[string]bool
+}{classes: make(map[string]bool)}
+
+// rpcMethods maps job types to the RPC methods that create them when their names differ by more than case and hyphens,
+// so that a command class named after either the RPC method or the job type covers both
+var rpcMethods = map[string][]string{
+	"agentinfo": {"checkin"},
+	"exec":      {"cmd"},
+	"link":      {"linkagent"},
+	"run":       {"cmd", "runmodule"},
+	"sdelete":   {"securedelete"},
+	"shell":     {"cmd"},
+	"shellcode": {"executeshellcode"},
+	"unlink":    {"unlinkagent"},
+}
+
+// SetApprovalPolicy sets the comma separated command classes that require two-person integrity, replacing any previous
+// policy. An empty string disables the policy
+func SetApprovalPolicy(classes string) {
+	approvalPolicy.Lock()
+	defer approvalPolicy.Unlock()
+	approvalPolicy.classes = make(map[string]bool)
+	for _, class := range strings.Split(classes, ",") {
+		if class = normalizeClass(class); class != "" {
+			approvalPolicy.classes[class] = true
+		}
+	}
+}
+
+// RequiresApproval returns true if the command class, either an RPC method or a job type, requires two-person integrity
+func RequiresApproval(class string) bool {
+	class = normalizeClass(class)
+	approvalPolicy.RLock()
+	defer approvalPolicy.RUnlock()
+	if approvalPolicy.classes[class] {
+		return true
+	}
+	for jobType, methods := range rpcMethods {
+		for _, method := range methods {
+			if (jobType == class && approvalPolicy.classes[method]) || (method == class && approvalPolicy.classes[jobType]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeClass returns the command class in lowercase without hyphens so that job types (e.g., load-assembly) match
+// their RPC method names (e.g., LoadAssembly)
+func normalizeClass(class string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(class)), "-", "")
+}
@@ -0,0 +1,107 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"strings"
+	"testing"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+)
+
+// TestRequiresApproval verifies command classes match RPC method names and the job types they create
+func TestRequiresApproval(t *testing.T) {
+	SetApprovalPolicy(" Destroy, executeshellcode,,load-assembly,cmd,sdelete")
+	t.Cleanup(func() { SetApprovalPolicy("") })
+	tests := []struct {
+		class string
+		want  bool
+	}{
+		{"destroy", true},
+		{"DESTROY", true},
+		{"executeshellcode", true},
+		{"shellcode", true},
+		{"load-assembly", true},
+		{"loadassembly", true},
+		{"run", true},
+		{"shell", true},
+		{"securedelete", true},
+		{"rm", false},
+		{"broadcast", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		t.Run(test.class, func(t *testing.T) {
+			if got := RequiresApproval(test.class); got != test.want {
+				t.Errorf("expected %t, have %t", test.want, got)
+			}
+		})
+	}
+
+	SetApprovalPolicy("")
+	if RequiresApproval("destroy") {
+		t.Error("expected an empty policy to not require approval")
+	}
+}
+
+// TestTaskApproval verifies server-side automation can't task commands that require two-person integrity, while the
+// jobs that follow from an operator's approved call are still created
+func TestTaskApproval(t *testing.T) {
+	s, a := newTestService(t)
+	SetApprovalPolicy("destroy,persist")
+	t.Cleanup(func() { SetApprovalPolicy("") })
+
+	tests := []struct {
+		name    string
+		task    func() (string, error)
+		wantErr bool
+	}{
+		{"held command", func() (string, error) { return s.Task(a.ID(), "destroy", nil) }, true},
+		{"held command case", func() (string, error) { return s.Task(a.ID(), "Persist", []string{"cleanup"}) }, true},
+		{"other command", func() (string, error) { return s.Task(a.ID(), "pwd", nil) }, false},
+		{"follow-up job", func() (string, error) { return s.task(a.ID(), "destroy", []string{"force"}) }, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.task()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr && !strings.Contains(err.Error(), "requires approval from a second operator") {
+				t.Errorf("expected the approval error, have %v", err)
+			}
+		})
+	}
+
+	queued, err := s.jobRepo.GetJobs(a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commands []string
+	for _, job := range queued {
+		commands = append(commands, job.Payload.(jobs.Command).Command)
+	}
+	if strings.Join(commands, ",") != "pwd,destroy" {
+		t.Errorf("expected the pwd and destroy jobs to be queued, have %v", commands)
+	}
+}
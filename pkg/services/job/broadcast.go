@@ -0,0 +1,71 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"fmt"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// Broadcast adds the job to every peer-to-peer child Agent beneath the parent Agent (e.g., a mass exit or sleep change
+// across an SMB mesh). Each child's job is encrypted with its own key but is delivered to the whole mesh in the
+// parent's next round trip by the delegate routing code instead of requiring per-child tasking
+func (s *Service) Broadcast(parent uuid.UUID, jobType string, jobArgs []string, techniques ...string) (string, error) {
+	if !s.agentService.Exist(parent) {
+		return "", fmt.Errorf("pkg/services/job.Broadcast(): agent %s does not exist", parent)
+	}
+
+	var children []uuid.UUID
+	for _, route := range s.agentService.Routes() {
+		for _, hop := range route.Path[:max(len(route.Path)-1, 0)] {
+			if hop == parent {
+				children = append(children, route.Agent)
+				break
+			}
+		}
+	}
+	if len(children) == 0 {
+		return "", fmt.Errorf("pkg/services/job.Broadcast(): agent %s does not have any reachable child agents", parent)
+	}
+
+	results := fmt.Sprintf("Broadcasting the '%s' job to %d child Agent(s) of %s", jobType, len(children), parent)
+	for _, child := range children {
+		a, err := s.agentService.Agent(child)
+		if err != nil || !a.Alive() {
+			results += fmt.Sprintf("\n\t%s: skipped because the Agent is not alive", child)
+			continue
+		}
+		if a.Quarantined() {
+			results += fmt.Sprintf("\n\t%s: skipped because the Agent is quarantined", child)
+			continue
+		}
+		result, err := s.Add(child, jobType, append([]string{}, jobArgs...), techniques...)
+		if err != nil {
+			results += fmt.Sprintf("\n\t%s: %s", child, err)
+			continue
+		}
+		results += fmt.Sprintf("\n\t%s: %s", child, result)
+	}
+	return results, nil
+}
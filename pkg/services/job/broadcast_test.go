@@ -0,0 +1,99 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// TestBroadcast links parent -> child -> grandchild and parent -> dead and verifies the job is only added to the
+// living Agents beneath the parent
+func TestBroadcast(t *testing.T) {
+	s, parent := newTestService(t)
+	ids := make(map[string]uuid.UUID)
+	for _, name := range []string{"child", "grandchild", "dead"} {
+		a, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.agentService.Add(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = s.agentService.Remove(a.ID()) })
+		err = s.agentService.UpdateAlive(a.ID(), name != "dead")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = a.ID()
+	}
+	for _, link := range [][2]uuid.UUID{{parent.ID(), ids["child"]}, {ids["child"], ids["grandchild"]}, {parent.ID(), ids["dead"]}} {
+		err := s.agentService.Link(link[0], link[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		parent  uuid.UUID
+		wantErr bool
+		jobs    map[string]int
+	}{
+		{"unknown parent", uuid.New(), true, nil},
+		{"no children", ids["grandchild"], true, nil},
+		{"mesh", parent.ID(), false, map[string]int{"child": 1, "grandchild": 1, "dead": 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results, err := s.Broadcast(test.parent, "sleep", []string{"30s"})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if !strings.Contains(results, "skipped because the Agent is not alive") {
+				t.Errorf("expected the dead Agent to be skipped:\n%s", results)
+			}
+			for name, want := range test.jobs {
+				// Agents that were never tasked don't have a job channel
+				queued, _ := s.jobRepo.GetJobs(ids[name])
+				if len(queued) != want {
+					t.Errorf("expected %d jobs for the %s Agent, have %d", want, name, len(queued))
+				}
+			}
+			queued, _ := s.jobRepo.GetJobs(test.parent)
+			if len(queued) != 0 {
+				t.Errorf("expected the parent Agent not to be tasked, have %d jobs", len(queued))
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"sort"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/attack"
+	"github.com/Ne0nd0g/merlin/v2/pkg/plugins"
+)
+
+// Command describes an Agent command the job service accepts so that user interfaces and documentation generators can
+// enumerate the command set instead of keeping their own copy
+type Command struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Usage       string   `json:"usage"`
+	Platforms   []string `json:"platforms,omitempty"`   // The Agent platforms that support the command; empty is all
+	Techniques  []string `json:"techniques,omitempty"`  // The MITRE ATT&CK technique IDs recorded for the command's jobs
+	ServerSide  bool     `json:"server_side,omitempty"` // The command changes server-side settings and is not sent to the Agent
+	Plugin      string   `json:"plugin,omitempty"`      // The plugin that provides the command; empty for built-in commands
+}
+
+// catalog is every built-in job type the add function builds. Keep it in sync when a job type is added or removed
+var catalog = []Command{
+	{Name: "ad", Description: "Query Active Directory objects over LDAP", Usage: "ad <users|computers|groups|gpos|trusts> [ldap filter] [page size]"},
+	{Name: "agentInfo", Description: "Have the Agent return its configuration and host information", Usage: "agentInfo"},
+	{Name: "campaign", Description: "Set, or have the Agent return, its campaign identifier", Usage: "campaign [identifier]"},
+	{Name: "cd", Description: "Change the Agent's working directory", Usage: "cd <directory>"},
+	{Name: "changelistener", Description: "Change the listener type the Agent uses", Usage: "changelistener <type> [args]"},
+	{Name: "clipboard", Description: "Get or monitor the contents of the host's clipboard", Usage: "clipboard <get|monitor|stop> [interval]"},
+	{Name: "connect", Description: "Connect the Agent to a different server address", Usage: "connect <address>"},
+	{Name: "CreateProcess", Description: "Inject shellcode into a spawned process and return its output", Usage: "CreateProcess <shellcode> <spawnto> <spawnto args> [injection method]"},
+	{Name: "deploy", Description: "Push a bind Agent to a remote host over ADMIN$, execute it with WMI, and link to it", Usage: "deploy <smb|tcp> <target> <server file> [pipe|port] [user]"},
+	{Name: "destroy", Description: "Remove the Agent's persistence, binary, and configuration from the host and exit", Usage: "destroy [force]"},
+	{Name: "download", Description: "Download a file from the Agent's host", Usage: "download <file>"},
+	{Name: "env", Description: "Get, set, or unset environment variables", Usage: "env <showall|get|set|unset> [variable] [value]"},
+	{Name: "exit", Description: "Instruct the Agent to exit", Usage: "exit"},
+	{Name: "fallback", Description: "Configure the listeners the Agent falls back to after failed check-ins", Usage: "fallback <failures> [<protocol> <url> <psk> <transforms> <jwt> <ja3>]..."},
+	{Name: "idle", Description: "Measure how long the host's user has been idle and list the logon sessions, once or on an interval", Usage: "idle <check|watch|stop> [interval]"},
+	{Name: "ifconfig", Description: "List the host's network interfaces", Usage: "ifconfig"},
+	{Name: "initialize", Description: "Have the Agent re-initialize with the server", Usage: "initialize"},
+	{Name: "injection-method", Description: "Set the default process injection technique for the Agent's future jobs", Usage: "injection-method <method>", ServerSide: true},
+	{Name: "invoke-assembly", Description: "Execute a .NET assembly previously loaded into the Agent's process", Usage: "invoke-assembly <assembly name> [args]"},
+	{Name: "ja3", Description: "Set the Agent's TLS client JA3 signature", Usage: "ja3 <signature>"},
+	{Name: "keylogger", Description: "Capture keystrokes on the host", Usage: "keylogger <start|stop|dump> [interval]"},
+	{Name: "killdate", Description: "Set the date after which the Agent exits", Usage: "killdate <epoch>"},
+	{Name: "killprocess", Description: "Kill a process by its ID", Usage: "killprocess <pid>"},
+	{Name: "link", Description: "Link to a peer-to-peer Agent", Usage: "link <tcp|udp|smb> <address>"},
+	{Name: "list-assemblies", Description: "List the .NET assemblies loaded into the Agent's process", Usage: "list-assemblies"},
+	{Name: "listener", Description: "Manage the Agent's peer-to-peer listeners", Usage: "listener <list|start|stop> [args]"},
+	{Name: "load-assembly", Description: "Load a .NET assembly into the Agent's process", Usage: "load-assembly <base64 assembly> <name> <sha256>"},
+	{Name: "load-clr", Description: "Load the .NET Common Language Runtime into the Agent's process", Usage: "load-clr <version>"},
+	{Name: "ls", Description: "List a directory's contents", Usage: "ls [directory]"},
+	{Name: "maxretry", Description: "Set the number of failed check-ins before the Agent exits", Usage: "maxretry <count>"},
+	{Name: "memfd", Description: "Execute a Linux executable from an anonymous memory file", Usage: "memfd <base64 executable> [args]"},
+	{Name: "memory", Description: "Read, write, or patch the Agent process's memory", Usage: "memory <read|write|patch> <module> <procedure> [bytes]"},
+	{Name: "Minidump", Description: "Create a minidump of a process's memory", Usage: "Minidump <process> <pid> <temp location>"},
+	{Name: "netstat", Description: "List the host's network connections", Usage: "netstat [-p tcp|udp]"},
+	{Name: "nslookup", Description: "Resolve hostnames or IP addresses from the host", Usage: "nslookup <query>..."},
+	{Name: "padding", Description: "Set the maximum random padding added to the Agent's messages", Usage: "padding <bytes>"},
+	{Name: "parrot", Description: "Set the browser the Agent's HTTP client imitates", Usage: "parrot <browser>"},
+	{Name: "persist", Description: "Install, remove, or clean up persistence on the host", Usage: "persist <install|remove|cleanup> [technique|id] [command line] [name]"},
+	{Name: "pipes", Description: "List the host's named pipes", Usage: "pipes"},
+	{Name: "preflight", Description: "Check the host for sandbox, debugger, and EDR indicators", Usage: "preflight"},
+	{Name: "profile", Description: "Shape the padding and delay of messages the server sends to the Agent", Usage: "profile <padding|delay|reset> [args]", ServerSide: true},
+	{Name: "ps", Description: "List the host's processes", Usage: "ps"},
+	{Name: "pwd", Description: "Return the Agent's working directory", Usage: "pwd"},
+	{Name: "rm", Description: "Delete a file", Usage: "rm <file>"},
+	{Name: "run", Description: "Execute a program and return its output", Usage: "run <program> [args]"},
+	{Name: "exec", Description: "Execute a program and return its output; an alias for run", Usage: "exec <program> [args]"},
+	{Name: "runas", Description: "Execute a program as another user", Usage: "runas <user> <password> <program> [args]"},
+	{Name: "scan", Description: "Scan hosts for open TCP ports", Usage: "scan <ip|cidr> <ports> [rate] [timeout]"},
+	{Name: "scexec", Description: "Execute a command on a remote host through a temporary service", Usage: "scexec <target> <command line> [service] [user] [pipe]"},
+	{Name: "screenshot", Description: "Capture the host's screen", Usage: "screenshot <take|watch|stop> [interval] [monitor] [quality] [scale]"},
+	{Name: "sdelete", Description: "Securely overwrite and delete a file", Usage: "sdelete <file>"},
+	{Name: "security", Description: "Enumerate the antivirus and EDR products installed on the host", Usage: "security"},
+	{Name: "shell", Description: "Execute a command with the host's shell and return its output", Usage: "shell <command line>"},
+	{Name: "shellcode", Description: "Execute shellcode in the Agent's process or inject it into another process", Usage: "shellcode <base64 shellcode> <method|default> [pid]"},
+	{Name: "skew", Description: "Set the maximum random time added to the Agent's sleep", Usage: "skew <milliseconds>"},
+	{Name: "sleep", Description: "Set the time the Agent sleeps between check-ins", Usage: "sleep <duration>"},
+	{Name: "ssh", Description: "Execute a program on a remote host over SSH; using the credential store is only supported by Linux and macOS Agents", Usage: "ssh <user>@<host>[:port] <program> [args] | ssh <user> <password> <host:port> <program> [args]"},
+	{Name: "ssh-deploy", Description: "Copy an Agent to a remote host over SSH and execute it", Usage: "ssh-deploy <user>@<host>[:port] <server file> [remote path]"},
+	{Name: "throttle", Description: "Limit the bandwidth of the Agent's traffic", Usage: "throttle <kbps>"},
+	{Name: "timezone", Description: "Return the host's time zone", Usage: "timezone"},
+	{Name: "token", Description: "List, create, steal, and revert Windows access tokens", Usage: "token <list|make|privs|rev2self|steal|whoami> [args]"},
+	{Name: "touch", Description: "Match a file's timestamps to another file's", Usage: "touch <source> <destination>"},
+	{Name: "transport", Description: "Move the Agent to a different listener", Usage: "transport <protocol> <url> <psk> <transforms> [jwt] [ja3]"},
+	{Name: "unlink", Description: "Unlink a peer-to-peer Agent", Usage: "unlink <agent>"},
+	{Name: "upload", Description: "Upload a file to the Agent's host", Usage: "upload <base64 data> <destination> <sha256> <size>"},
+	{Name: "uptime", Description: "Return how long the host has been running", Usage: "uptime"},
+	{Name: "wmiexec", Description: "Execute a command on a remote host with WMI", Usage: "wmiexec <target> <command line> [user] [pipe]"},
+}
+
+// Commands returns every built-in Agent command and every command provided by a plugin, sorted by name, with their
+// supported platforms and MITRE ATT&CK techniques
+func (s *Service) Commands() []Command {
+	commands := make([]Command, 0, len(catalog))
+	for _, c := range catalog {
+		c.Platforms = append([]string(nil), platforms[c.Name]...)
+		c.Techniques = attack.Techniques(c.Name)
+		commands = append(commands, c)
+	}
+	for _, p := range plugins.Plugins() {
+		for _, pc := range p.Commands {
+			c := Command{
+				Name:        pc.Name,
+				Description: pc.Description,
+				Usage:       pc.Usage,
+				Platforms:   append([]string(nil), pc.OS...),
+				Plugin:      p.Name,
+			}
+			if !pc.IsScript() {
+				c.Techniques = attack.Techniques(pc.Job)
+			}
+			commands = append(commands, c)
+		}
+	}
+	sort.SliceStable(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	return commands
+}
@@ -0,0 +1,118 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/plugins"
+)
+
+// TestCatalog verifies every command in the catalog is a job type the job service builds and every platform specific
+// command is in the catalog
+func TestCatalog(t *testing.T) {
+	s, a := newTestService(t)
+	names := make(map[string]bool)
+	for _, c := range catalog {
+		t.Run(c.Name, func(t *testing.T) {
+			if names[c.Name] {
+				t.Fatalf("the %s command is in the catalog more than once", c.Name)
+			}
+			names[c.Name] = true
+			if c.Description == "" || !strings.HasPrefix(c.Usage, c.Name) {
+				t.Errorf("expected a description and a usage that starts with the command's name, have %+v", c)
+			}
+			_, err := s.Add(a.ID(), c.Name, []string{})
+			if err != nil && strings.HasPrefix(err.Error(), "invalid job type") {
+				t.Errorf("the %s command is not built by the job service", c.Name)
+			}
+			_ = s.Clear(a.ID())
+		})
+	}
+	for command := range platforms {
+		if !names[command] {
+			t.Errorf("the platform specific %s command is not in the catalog", command)
+		}
+	}
+}
+
+// TestCommands verifies built-in and plugin commands are returned sorted by name with their platforms and techniques
+func TestCommands(t *testing.T) {
+	s, _ := newTestService(t)
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "recon"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := "commands:\n  - name: whoami-all\n    os: [windows]\n    job: run\n    args: [\"whoami.exe\", \"/all\"]\n"
+	err = os.WriteFile(filepath.Join(dir, "recon", "plugin.yaml"), []byte(manifest), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := plugins.Load(dir); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	t.Cleanup(func() { plugins.Load(filepath.Join(dir, "none")) })
+
+	commands := s.Commands()
+	if len(commands) != len(catalog)+1 {
+		t.Fatalf("expected %d commands, have %d", len(catalog)+1, len(commands))
+	}
+	if !sort.SliceIsSorted(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name }) {
+		t.Error("expected the commands to be sorted by name")
+	}
+	byName := make(map[string]Command)
+	for _, c := range commands {
+		byName[c.Name] = c
+	}
+	tests := []struct {
+		name       string
+		platforms  string
+		plugin     string
+		serverSide bool
+	}{
+		{"ps", "windows", "", false},
+		{"memfd", "linux", "", false},
+		{"pwd", "", "", false},
+		{"profile", "", "", true},
+		{"whoami-all", "windows", "recon", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ok := byName[test.name]
+			if !ok {
+				t.Fatalf("expected the %s command to be returned", test.name)
+			}
+			if strings.Join(c.Platforms, ",") != test.platforms || c.Plugin != test.plugin || c.ServerSide != test.serverSide {
+				t.Errorf("expected platforms %q, plugin %q, and server side %t, have %+v", test.platforms, test.plugin, test.serverSide, c)
+			}
+		})
+	}
+	if run := byName["run"]; strings.Join(byName["whoami-all"].Techniques, ",") != strings.Join(run.Techniques, ",") {
+		t.Errorf("expected the plugin command to have the run job's techniques %v, have %v", run.Techniques, byName["whoami-all"].Techniques)
+	}
+}
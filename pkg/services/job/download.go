@@ -0,0 +1,93 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+const (
+	// maxDownloadAttempts is how many times a file is downloaded from an Agent before giving up when it keeps failing
+	// its integrity check
+	maxDownloadAttempts = 3
+	// downloadHashPrefix separates the source file's path from the SHA-256 hash the Agent computed for it.
+	// A NUL character can't be part of a file path on any operating system
+	downloadHashPrefix = "\x00sha256:"
+)
+
+// downloadHash splits the file location returned by the Agent into the source file's path and the SHA-256 hash the
+// Agent computed for it. The hash is nil if the Agent didn't return one
+func downloadHash(location string) (path string, hash []byte) {
+	i := strings.LastIndex(location, downloadHashPrefix)
+	if i < 0 {
+		return location, nil
+	}
+	hash, err := hex.DecodeString(location[i+len(downloadHashPrefix):])
+	if err != nil || len(hash) != 32 {
+		return location[:i], nil
+	}
+	return location[:i], hash
+}
+
+// verifyDownload compares the SHA-256 hash of the received file with the one the Agent computed for its source file.
+// Corrupted files are not written to disk and are downloaded again until maxDownloadAttempts is reached.
+// Returns true if the file can be kept
+func (s *Service) verifyDownload(agentID uuid.UUID, info infoJobs.Info, path string, expected, received []byte) bool {
+	if expected == nil || bytes.Equal(expected, received) {
+		return true
+	}
+
+	attempt := 1
+	if value, ok := info.Metadata(metaAttempt); ok {
+		attempt, _ = strconv.Atoi(value)
+	}
+	msg := fmt.Sprintf("The file %s downloaded from agent %s by job %s failed its integrity check on attempt %d of %d. "+
+		"Expected SHA-256 %x, received %x", path, agentID, info.ID(), attempt, maxDownloadAttempts, expected, received)
+	s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+	_ = s.agentService.Log(agentID, msg)
+
+	if attempt >= maxDownloadAttempts {
+		msg = fmt.Sprintf("Giving up downloading %s from agent %s after %d failed integrity checks", path, agentID, attempt)
+		s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+		_ = s.agentService.Log(agentID, msg)
+		return false
+	}
+
+	result, err := s.Add(agentID, "download", []string{path, strconv.Itoa(attempt + 1)})
+	if err != nil {
+		s.messageRepo.Add(message.NewErrorMessage(fmt.Errorf("there was an error downloading %s from agent %s again: %s", path, agentID, err)))
+		return false
+	}
+	s.messageRepo.Add(message.NewMessage(message.Note, result))
+	return false
+}
@@ -0,0 +1,104 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+func TestDownloadHash(t *testing.T) {
+	hash := sha256.Sum256([]byte("merlin"))
+	tests := []struct {
+		name     string
+		location string
+		path     string
+		hash     []byte
+	}{
+		{"no hash", "/tmp/file.txt", "/tmp/file.txt", nil},
+		{"hash", "/tmp/file.txt\x00sha256:" + hex.EncodeToString(hash[:]), "/tmp/file.txt", hash[:]},
+		{"invalid hex", "C:\\file.txt\x00sha256:zz", "C:\\file.txt", nil},
+		{"short hash", "/tmp/file.txt\x00sha256:abcd", "/tmp/file.txt", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, hash := downloadHash(test.location)
+			if path != test.path {
+				t.Errorf("expected path %q, have %q", test.path, path)
+			}
+			if !bytes.Equal(hash, test.hash) {
+				t.Errorf("expected hash %x, have %x", test.hash, hash)
+			}
+		})
+	}
+}
+
+// TestVerifyDownload verifies corrupted files are downloaded again until maxDownloadAttempts is reached
+func TestVerifyDownload(t *testing.T) {
+	expected := sha256.Sum256([]byte("merlin"))
+	corrupted := sha256.Sum256([]byte("merlim"))
+	tests := []struct {
+		name     string
+		attempt  string
+		expected []byte
+		received []byte
+		want     bool
+		queued   int
+	}{
+		{"not hashed", "", nil, corrupted[:], true, 0},
+		{"verified", "", expected[:], expected[:], true, 0},
+		{"corrupted", "", expected[:], corrupted[:], false, 1},
+		{"corrupted retry", "2", expected[:], corrupted[:], false, 1},
+		{"corrupted last attempt", "3", expected[:], corrupted[:], false, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, a := newTestService(t)
+			info := infoJobs.NewInfo(a.ID(), "FILETRANSFER", "download /tmp/file.txt")
+			if test.attempt != "" {
+				info.SetMetadata(metaAttempt, test.attempt)
+			}
+			if got := s.verifyDownload(a.ID(), info, "/tmp/file.txt", test.expected, test.received); got != test.want {
+				t.Errorf("expected %t, have %t", test.want, got)
+			}
+			queued, _ := s.jobRepo.GetJobs(a.ID())
+			if len(queued) != test.queued {
+				t.Fatalf("expected %d queued jobs, have %d", test.queued, len(queued))
+			}
+			if test.queued == 0 {
+				return
+			}
+			// The Agent uploads the file, so download jobs are not an IsDownload FileTransfer from its perspective
+			if ft := queued[0].Payload.(jobs.FileTransfer); ft.IsDownload || ft.FileLocation != "/tmp/file.txt" {
+				t.Errorf("unexpected download payload %+v", ft)
+			}
+		})
+	}
+}
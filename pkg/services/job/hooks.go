@@ -0,0 +1,236 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+// Hook events
+const (
+	// HookQueued fires when a job is added to an Agent's queue
+	HookQueued = "queued"
+	// HookComplete fires when an Agent returns a job's final results
+	HookComplete = "complete"
+)
+
+// defaultHookTimeout is how long a hook's program or webhook can run when the hook doesn't configure a timeout
+const defaultHookTimeout = 30 * time.Second
+
+// hook runs a local program or sends a webhook when a matching job is queued or completes
+type hook struct {
+	Name    string        `yaml:"name"`
+	Event   string        `yaml:"event"`   // The job event that runs the hook: queued or complete
+	Type    string        `yaml:"type"`    // The job type to match (e.g., Module, CMDPayload, FileTransfer); empty matches every type
+	Match   string        `yaml:"match"`   // A regular expression matched against the job's command (e.g., ^invoke-assembly)
+	Exec    []string      `yaml:"exec"`    // The local program, and its arguments, that receives the event as JSON on STDIN
+	URL     string        `yaml:"url"`     // The URL the event is sent to as a JSON HTTP POST request
+	Timeout time.Duration `yaml:"timeout"` // How long the program or webhook can run (e.g., 2m)
+	match   *regexp.Regexp
+}
+
+// hookEvent is the JSON document a hook's program or webhook receives
+type hookEvent struct {
+	Hook    string `json:"hook"`
+	Event   string `json:"event"`
+	Time    string `json:"time"`
+	Agent   string `json:"agent"`
+	Host    string `json:"host,omitempty"`
+	User    string `json:"user,omitempty"`
+	Job     string `json:"job"`
+	Type    string `json:"type"`
+	Command string `json:"command"`
+	Stdout  string `json:"stdout,omitempty"`
+	Stderr  string `json:"stderr,omitempty"`
+}
+
+// hookRegistry holds the hooks loaded from the server's hook configuration file
+type hookRegistry struct {
+	sync.RWMutex
+	hooks []hook
+	muted map[uuid.UUID]bool // muted contains the Agents whose jobs don't run hooks, such as the self-test Agent
+}
+
+// hooks is shared by every job Service
+var hooks = &hookRegistry{muted: make(map[uuid.UUID]bool)}
+
+// LoadHooks reads the YAML hook configuration file at the provided path and replaces any previously loaded hooks.
+// An example configuration that parses SharpHound output when it finishes:
+//
+//	hooks:
+//	  - name: bloodhound
+//	    event: complete
+//	    match: "^clr invoke-assembly SharpHound"
+//	    exec: ["/opt/merlin/ingest.sh"]
+//	    timeout: 5m
+func (s *Service) LoadHooks(path string) (int, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - The operator provides the configuration file path
+	if err != nil {
+		return 0, fmt.Errorf("pkg/services/job.LoadHooks(): there was an error reading the hook configuration: %s", err)
+	}
+	var config struct {
+		Hooks []hook `yaml:"hooks"`
+	}
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return 0, fmt.Errorf("pkg/services/job.LoadHooks(): there was an error parsing the hook configuration: %s", err)
+	}
+	for i, h := range config.Hooks {
+		if h.Name == "" {
+			h.Name = fmt.Sprintf("hook %d", i)
+		}
+		switch h.Event {
+		case HookQueued, HookComplete:
+		default:
+			return 0, fmt.Errorf("pkg/services/job.LoadHooks(): %s has an unknown event '%s', expected %s or %s", h.Name, h.Event, HookQueued, HookComplete)
+		}
+		if len(h.Exec) == 0 && h.URL == "" {
+			return 0, fmt.Errorf("pkg/services/job.LoadHooks(): %s must have an exec program or a url", h.Name)
+		}
+		h.match, err = regexp.Compile(h.Match)
+		if err != nil {
+			return 0, fmt.Errorf("pkg/services/job.LoadHooks(): %s has an invalid match expression: %s", h.Name, err)
+		}
+		if h.Timeout <= 0 {
+			h.Timeout = defaultHookTimeout
+		}
+		config.Hooks[i] = h
+	}
+
+	hooks.Lock()
+	hooks.hooks = config.Hooks
+	hooks.Unlock()
+	return len(config.Hooks), nil
+}
+
+// MuteHooks stops, or resumes, running hooks for the Agent's jobs. The self-test mutes its test Agent before it
+// authenticates so that the jobs queued for every new Agent don't reach operator programs and webhooks
+func (s *Service) MuteHooks(agentID uuid.UUID, mute bool) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	if mute {
+		hooks.muted[agentID] = true
+		return
+	}
+	delete(hooks.muted, agentID)
+}
+
+// runHooks runs every hook for the event that matches the job in the background
+func (s *Service) runHooks(event string, info infoJobs.Info, result jobs.Results) {
+	// SOCKS jobs carry proxied traffic, not operator tasking
+	if info.Type() == jobs.SOCKS.String() {
+		return
+	}
+	hooks.RLock()
+	defer hooks.RUnlock()
+	if hooks.muted[info.AgentID()] {
+		return
+	}
+	for _, h := range hooks.hooks {
+		if h.Event != event || (h.Type != "" && !strings.EqualFold(h.Type, info.Type())) || !h.match.MatchString(info.Command()) {
+			continue
+		}
+		e := hookEvent{
+			Hook:    h.Name,
+			Event:   event,
+			Time:    time.Now().UTC().Format(time.RFC3339),
+			Agent:   info.AgentID().String(),
+			Job:     info.ID(),
+			Type:    info.Type(),
+			Command: info.Command(),
+			Stdout:  result.Stdout,
+			Stderr:  result.Stderr,
+		}
+		if a, err := s.agentService.Agent(info.AgentID()); err == nil {
+			e.Host = a.Host().Name
+			e.User = a.Process().UserName
+		}
+		go s.runHook(h, e)
+	}
+}
+
+// runHook sends the event to the hook's program and webhook and reports failures, and any program output, to clients
+func (s *Service) runHook(h hook, e hookEvent) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/services/job.runHook(): %s", err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), h.Timeout)
+	defer cancel()
+
+	if len(h.Exec) > 0 {
+		cmd := exec.CommandContext(ctx, h.Exec[0], h.Exec[1:]...) // #nosec G204 - The operator configures the hook's program
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Env = append(os.Environ(), "MERLIN_HOOK="+e.Hook, "MERLIN_EVENT="+e.Event, "MERLIN_AGENT="+e.Agent, "MERLIN_JOB="+e.Job)
+		var out []byte
+		out, err = cmd.CombinedOutput()
+		if err != nil {
+			s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("Hook '%s' for job %s failed: %s\n%s", h.Name, e.Job, err, out)))
+		} else if len(bytes.TrimSpace(out)) > 0 {
+			s.messageRepo.Add(message.NewMessage(message.Info, fmt.Sprintf("Hook '%s' for job %s:\n%s", h.Name, e.Job, out)))
+		}
+	}
+
+	if h.URL != "" {
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(data))
+		if err != nil {
+			s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("Hook '%s' for job %s failed: %s", h.Name, e.Job, err)))
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		var resp *http.Response
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("Hook '%s' for job %s failed: %s", h.Name, e.Job, err)))
+			return
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("Hook '%s' for job %s webhook returned %s", h.Name, e.Job, resp.Status)))
+		}
+	}
+	slog.Debug("ran job hook", "hook", h.Name, "event", e.Event, "job", e.Job)
+}
@@ -0,0 +1,212 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+)
+
+// hookRecorder is a client message repository that keeps the messages hooks send
+type hookRecorder struct {
+	sync.Mutex
+	messages []string
+}
+
+func (r *hookRecorder) Add(m *message.Message) {
+	r.Lock()
+	defer r.Unlock()
+	r.messages = append(r.messages, m.Message())
+}
+
+func (r *hookRecorder) Get(id uuid.UUID) (*message.Message, error) {
+	return nil, nil
+}
+
+func (r *hookRecorder) GetAll() []*message.Message {
+	return nil
+}
+
+func (r *hookRecorder) GetQueue() *message.Message {
+	return nil
+}
+
+// writeHooks writes the hook configuration to a temporary file, loads it, and unloads the hooks when the test ends
+func writeHooks(t *testing.T, s *Service, config string) (int, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hooks.yaml")
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		hooks.Lock()
+		hooks.hooks = nil
+		hooks.Unlock()
+	})
+	return s.LoadHooks(path)
+}
+
+func TestLoadHooks(t *testing.T) {
+	s, _ := newTestService(t)
+	tests := []struct {
+		name    string
+		config  string
+		loaded  int
+		wantErr string
+	}{
+		{"exec and url", "hooks:\n  - event: complete\n    exec: [\"true\"]\n  - name: webhook\n    event: queued\n    url: http://127.0.0.1/\n", 2, ""},
+		{"empty", "hooks: []\n", 0, ""},
+		{"unknown event", "hooks:\n  - name: bad\n    event: sent\n    exec: [\"true\"]\n", 0, "unknown event 'sent'"},
+		{"no action", "hooks:\n  - name: bad\n    event: queued\n", 0, "exec program or a url"},
+		{"invalid match", "hooks:\n  - name: bad\n    event: queued\n    match: \"(\"\n    exec: [\"true\"]\n", 0, "invalid match expression"},
+		{"invalid yaml", "hooks: [", 0, "parsing the hook configuration"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loaded, err := writeHooks(t, s, test.config)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected an error containing %q, have %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loaded != test.loaded {
+				t.Errorf("expected %d hooks, have %d", test.loaded, loaded)
+			}
+			hooks.RLock()
+			defer hooks.RUnlock()
+			for _, h := range hooks.hooks {
+				if h.Name == "" || h.Timeout != defaultHookTimeout {
+					t.Errorf("expected a default name and timeout, have %q and %s", h.Name, h.Timeout)
+				}
+			}
+		})
+	}
+}
+
+// TestRunHooks verifies only hooks matching the job's event, type, and command send the event to their webhook
+func TestRunHooks(t *testing.T) {
+	s, a := newTestService(t)
+	events := make(chan hookEvent, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e hookEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Error(err)
+		}
+		events <- e
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		hook  string
+		job   string
+		args  []string
+		muted bool
+		fired bool
+	}{
+		{"match", "event: queued\n    match: \"^pwd\"", "pwd", nil, false, true},
+		{"type", "event: queued\n    type: nativepayload", "pwd", nil, false, true},
+		{"other type", "event: queued\n    type: module", "pwd", nil, false, false},
+		{"other command", "event: queued\n    match: \"^ls\"", "pwd", nil, false, false},
+		{"other event", "event: complete", "pwd", nil, false, false},
+		{"muted agent", "event: queued\n    match: \"^pwd\"", "pwd", nil, true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s.MuteHooks(a.ID(), test.muted)
+			t.Cleanup(func() { s.MuteHooks(a.ID(), false) })
+			_, err := writeHooks(t, s, "hooks:\n  - name: "+test.name+"\n    "+test.hook+"\n    url: "+server.URL+"\n")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = s.Add(a.ID(), test.job, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case e := <-events:
+				if !test.fired {
+					t.Fatalf("expected the hook not to fire, have %+v", e)
+				}
+				if e.Hook != test.name || e.Event != HookQueued || e.Agent != a.ID().String() || e.Job == "" {
+					t.Errorf("unexpected hook event %+v", e)
+				}
+			case <-time.After(500 * time.Millisecond):
+				if test.fired {
+					t.Fatal("expected the hook to send the event to its webhook")
+				}
+			}
+		})
+	}
+}
+
+// TestRunHook verifies a hook's program receives the event on STDIN and its output and failures are sent to clients
+func TestRunHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test hooks are shell commands")
+	}
+	s, _ := newTestService(t)
+	tests := []struct {
+		name string
+		exec []string
+		want string
+	}{
+		{"output", []string{"sh", "-c", "cat; echo \" $MERLIN_EVENT\""}, `"job":"merlin"`},
+		{"environment", []string{"sh", "-c", "echo $MERLIN_HOOK"}, "Hook 'environment' for job merlin:\nenvironment"},
+		{"failure", []string{"sh", "-c", "exit 3"}, "failed: exit status 3"},
+		{"timeout", []string{"sleep", "5"}, "failed: signal: killed"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := &hookRecorder{}
+			s.messageRepo = recorder
+			h := hook{Name: test.name, Event: HookComplete, Exec: test.exec, Timeout: 100 * time.Millisecond}
+			if test.name != "timeout" {
+				h.Timeout = defaultHookTimeout
+			}
+			s.runHook(h, hookEvent{Hook: test.name, Event: HookComplete, Job: "merlin"})
+			recorder.Lock()
+			defer recorder.Unlock()
+			if len(recorder.messages) != 1 || !strings.Contains(recorder.messages[0], test.want) {
+				t.Errorf("expected a message containing %q, have %q", test.want, recorder.messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc"
+)
+
+// recordIOCs adds an Indicator of Compromise to the IOC store for every file, process, service, registry key, and
+// persistence mechanism the job will create or modify on a target host
+func (s *Service) recordIOCs(a agents.Agent, job jobs.Job) {
+	host := a.Host().Name
+	add := func(host string, t ioc.Type, value, detail string) {
+		err := s.iocService.Add(ioc.NewIndicator(a.ID(), job.ID, host, t, value, detail))
+		if err != nil {
+			slog.Error(fmt.Sprintf("pkg/services/job.recordIOCs(): there was an error adding an IOC for job %s: %s", job.ID, err))
+		}
+	}
+
+	switch job.Type {
+	case jobs.CMD:
+		cmd := job.Payload.(jobs.Command)
+		add(host, ioc.PROCESS, strings.TrimSpace(fmt.Sprintf("%s %s", cmd.Command, strings.Join(cmd.Args, " "))), "")
+	case jobs.FILETRANSFER:
+		ft := job.Payload.(jobs.FileTransfer)
+		if ft.IsDownload {
+			add(host, ioc.FILE, ft.FileLocation, sha256Base64(ft.FileBlob))
+		}
+	case jobs.MODULE:
+		cmd := job.Payload.(jobs.Command)
+		args := cmd.Args
+		switch cmd.Command {
+		case "CreateProcess":
+			// [shellcode, spawnto, spawnto arguments, injection method]
+			if len(args) > 2 {
+				add(host, ioc.PROCESS, strings.TrimSpace(fmt.Sprintf("%s %s", args[1], args[2])), "spawnto process injected with shellcode")
+			}
+		case "memfd":
+			// [ELF, arguments...]
+			if len(args) > 0 {
+				add(host, ioc.PROCESS, strings.TrimSpace(fmt.Sprintf("memfd %s", strings.Join(args[1:], " "))), sha256Base64(args[0]))
+			}
+		case "persist":
+			// [install, technique, name, command, location]
+			if len(args) > 4 && args[0] == "install" {
+				t := ioc.PERSISTENCE
+				if args[1] == "registry" {
+					t = ioc.REGISTRY
+				}
+				add(host, t, args[4], fmt.Sprintf("%s persistence executing: %s", args[1], args[3]))
+			}
+		case "runas":
+			// [user, password, program, arguments...]
+			if len(args) > 2 {
+				add(host, ioc.PROCESS, strings.Join(args[2:], " "), fmt.Sprintf("run as %s", args[0]))
+			}
+		case "scexec":
+			// [host, service binary, service name, user, secret]
+			if len(args) > 2 {
+				add(args[0], ioc.SERVICE, args[2], fmt.Sprintf("service binary %s", sha256Base64(args[1])))
+			}
+		case "ssh":
+			// [user, password, host:port, program, arguments...]
+			if len(args) > 3 {
+				add(args[2], ioc.PROCESS, strings.Join(args[3:], " "), fmt.Sprintf("ssh as %s", args[0]))
+			}
+		case "ssh-deploy":
+			// [user, secret, host:port, Agent, remote path]
+			if len(args) > 4 {
+				add(args[2], ioc.FILE, args[4], sha256Base64(args[3]))
+				add(args[2], ioc.PROCESS, args[4], fmt.Sprintf("ssh as %s", args[0]))
+			}
+		case "wmiexec":
+			// [host, command, user, secret]
+			if len(args) > 1 {
+				add(args[0], ioc.PROCESS, args[1], "wmiexec")
+			}
+		}
+	}
+}
+
+// sha256Base64 returns the SHA256 hash, as a string, of the Base64 encoded data
+func sha256Base64(data string) string {
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("SHA256:%x", sha256.Sum256(b))
+}
@@ -0,0 +1,116 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc"
+)
+
+func TestRecordIOCs(t *testing.T) {
+	s, a := newTestService(t)
+	blob := base64.StdEncoding.EncodeToString([]byte("merlin"))
+	hash := sha256Base64(blob)
+
+	tests := []struct {
+		name    string
+		job     jobs.Job
+		want    int
+		host    string
+		iocType ioc.Type
+		value   string
+		detail  string
+	}{
+		{"cmd", jobs.Job{Type: jobs.CMD, Payload: jobs.Command{Command: "whoami", Args: []string{"/all"}}}, 1, a.Host().Name, ioc.PROCESS, "whoami /all", ""},
+		{"upload", jobs.Job{Type: jobs.FILETRANSFER, Payload: jobs.FileTransfer{FileLocation: "C:\\merlin.exe", FileBlob: blob, IsDownload: true}}, 1, a.Host().Name, ioc.FILE, "C:\\merlin.exe", hash},
+		{"download", jobs.Job{Type: jobs.FILETRANSFER, Payload: jobs.FileTransfer{FileLocation: "C:\\merlin.exe"}}, 0, "", ioc.UNDEFINED, "", ""},
+		{"persist registry", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "persist", Args: []string{"install", "registry", "merlin", "C:\\merlin.exe", "HKCU\\Run\\merlin"}}}, 1, a.Host().Name, ioc.REGISTRY, "HKCU\\Run\\merlin", "registry persistence executing: C:\\merlin.exe"},
+		{"persist task", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "persist", Args: []string{"install", "schtask", "merlin", "C:\\merlin.exe", "\\merlin"}}}, 1, a.Host().Name, ioc.PERSISTENCE, "\\merlin", "schtask persistence executing: C:\\merlin.exe"},
+		{"persist remove", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "persist", Args: []string{"remove", "registry", "merlin", "C:\\merlin.exe", "HKCU\\Run\\merlin"}}}, 0, "", ioc.UNDEFINED, "", ""},
+		{"scexec", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "scexec", Args: []string{"10.0.0.2", blob, "merlin", "user", "secret"}}}, 1, "10.0.0.2", ioc.SERVICE, "merlin", "service binary " + hash},
+		{"wmiexec", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "wmiexec", Args: []string{"10.0.0.3", "whoami", "user", "secret"}}}, 1, "10.0.0.3", ioc.PROCESS, "whoami", "wmiexec"},
+		{"ssh", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "ssh", Args: []string{"root", "secret", "10.0.0.4:22", "id", "-a"}}}, 1, "10.0.0.4:22", ioc.PROCESS, "id -a", "ssh as root"},
+		{"ssh-deploy", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "ssh-deploy", Args: []string{"root", "secret", "10.0.0.5:22", blob, "/tmp/merlin"}}}, 2, "10.0.0.5:22", ioc.FILE, "/tmp/merlin", hash},
+		{"runas", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "runas", Args: []string{"admin", "secret", "cmd.exe", "/c", "whoami"}}}, 1, a.Host().Name, ioc.PROCESS, "cmd.exe /c whoami", "run as admin"},
+		{"short arguments", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "ssh", Args: []string{"root"}}}, 0, "", ioc.UNDEFINED, "", ""},
+		{"no artifact", jobs.Job{Type: jobs.MODULE, Payload: jobs.Command{Command: "ifconfig"}}, 0, "", ioc.UNDEFINED, "", ""},
+	}
+
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.job.ID = fmt.Sprintf("ioc-%d", i)
+			s.recordIOCs(a, test.job)
+
+			var indicators []ioc.Indicator
+			for _, indicator := range s.iocService.Agent(a.ID()) {
+				if indicator.JobID() == test.job.ID {
+					indicators = append(indicators, indicator)
+				}
+			}
+			if len(indicators) != test.want {
+				t.Fatalf("expected %d indicators, have %d", test.want, len(indicators))
+			}
+			if test.want == 0 {
+				return
+			}
+			got := indicators[0]
+			if got.Host() != test.host {
+				t.Errorf("expected host %q, have %q", test.host, got.Host())
+			}
+			if got.Type() != test.iocType {
+				t.Errorf("expected type %s, have %s", test.iocType, got.Type())
+			}
+			if got.Value() != test.value {
+				t.Errorf("expected value %q, have %q", test.value, got.Value())
+			}
+			if got.Detail() != test.detail {
+				t.Errorf("expected detail %q, have %q", test.detail, got.Detail())
+			}
+		})
+	}
+}
+
+func TestSHA256Base64(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"valid", base64.StdEncoding.EncodeToString([]byte("merlin")), "SHA256:f6274d9892026fe47dd5f96f708ef8983dccc7bacf5ee4a90b2400805adaea0a"},
+		{"empty", "", "SHA256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"invalid", "not base64!", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sha256Base64(test.data); got != test.want {
+				t.Errorf("expected %q, have %q", test.want, got)
+			}
+		})
+	}
+}
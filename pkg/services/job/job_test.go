@@ -0,0 +1,376 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	memoryMessage "github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/jobs/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/credentials"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/directory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/hosts"
+	iocService "github.com/Ne0nd0g/merlin/v2/pkg/services/ioc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/loot"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/payloads"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/persistence"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/scan"
+)
+
+// newTestService returns a job Service and an Agent, whose log file is written to a temporary directory, to task
+func newTestService(tb testing.TB) (*Service, agents.Agent) {
+	tb.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	err = os.Chdir(tb.TempDir())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { _ = os.Chdir(current) })
+
+	a, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	agentService := agent.NewAgentService()
+	err = agentService.Add(a)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { _ = agentService.Remove(a.ID()) })
+
+	s := &Service{
+		jobRepo:            memory.NewRepository(),
+		messageRepo:        memoryMessage.NewRepository(),
+		agentService:       agentService,
+		credentialService:  credentials.NewCredentialService(),
+		directoryService:   directory.NewDirectoryService(),
+		hostService:        hosts.NewHostService(),
+		iocService:         iocService.NewIOCService(),
+		lootService:        loot.NewLootService(),
+		payloadService:     payloads.NewPayloadService(),
+		persistenceService: persistence.NewPersistenceService(),
+		scanService:        scan.NewScanService(),
+	}
+	return s, a
+}
+
+func TestTransport(t *testing.T) {
+	s, a := newTestService(t)
+	listener := uuid.New().String()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"missing arguments", []string{"https", "https://127.0.0.1:443"}, true},
+		{"migrate", []string{"https", "https://127.0.0.1:443", "merlin", "jwe,gob-base", "opaque", listener}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "transport", test.args)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queued) != 1 || queued[0].Type != jobs.CONTROL {
+				t.Fatalf("expected one CONTROL job, have %+v", queued)
+			}
+			cmd := queued[0].Payload.(jobs.Command)
+			if cmd.Command != "transport" || len(cmd.Args) != 6 || cmd.Args[5] != listener {
+				t.Errorf("unexpected transport payload %+v", cmd)
+			}
+		})
+	}
+}
+
+func TestFallback(t *testing.T) {
+	s, a := newTestService(t)
+	channel := []string{"https", "https://127.0.0.1:443", "merlin", "jwe,gob-base", "opaque", uuid.New().String()}
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"missing channel", []string{"3"}, true},
+		{"partial channel", append([]string{"3"}, channel[:5]...), true},
+		{"invalid failures", append([]string{"three"}, channel...), true},
+		{"zero failures", append([]string{"0"}, channel...), true},
+		{"one channel", append([]string{"3"}, channel...), false},
+		{"two channels", append(append([]string{"5"}, channel...), channel...), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "fallback", test.args)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queued) != 1 || queued[0].Type != jobs.CONTROL {
+				t.Fatalf("expected one CONTROL job, have %+v", queued)
+			}
+			if cmd := queued[0].Payload.(jobs.Command); cmd.Command != "fallback" || len(cmd.Args) != len(test.args) {
+				t.Errorf("unexpected fallback payload %+v", cmd)
+			}
+		})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{"missing bandwidth", nil, 0, true},
+		{"not a number", []string{"fast"}, 0, true},
+		{"negative", []string{"-1"}, 0, true},
+		{"limited", []string{"56"}, 56, false},
+		{"unlimited", []string{"0"}, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "throttle", test.args)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queued) != 1 || queued[0].Type != jobs.CONTROL {
+				t.Fatalf("expected one CONTROL job, have %+v", queued)
+			}
+			agent, err := s.agentService.Agent(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if agent.Throttle() != test.want {
+				t.Errorf("expected the Agent to be throttled to %d kbps, have %d", test.want, agent.Throttle())
+			}
+		})
+	}
+}
+
+// TestGetCancelled verifies jobs stay queued when the Agent's request is cancelled
+func TestGetCancelled(t *testing.T) {
+	s, a := newTestService(t)
+	_, err := s.Add(a.ID(), "pwd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+		jobs    int
+	}{
+		{"cancelled", ctx, true, 0},
+		{"active", context.Background(), false, 1},
+		{"dequeued", context.Background(), false, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			returned, err := s.Get(test.ctx, a.ID())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if len(returned) != test.jobs {
+				t.Errorf("expected %d jobs, have %d", test.jobs, len(returned))
+			}
+		})
+	}
+}
+
+// TestQuarantined verifies jobs can't be added for a quarantined Agent and queued jobs are held until it is released
+func TestQuarantined(t *testing.T) {
+	s, a := newTestService(t)
+	_, err := s.Add(a.ID(), "pwd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.agentService.Quarantine(a.ID(), "suspicious")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		release bool
+		wantErr bool
+		jobs    int
+	}{
+		{"quarantined", false, true, 0},
+		{"released", true, false, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.release {
+				if err := s.agentService.Release(a.ID()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err := s.Add(a.ID(), "pwd", nil)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+			returned, err := s.Get(context.Background(), a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(returned) != test.jobs {
+				t.Errorf("expected %d jobs, have %d", test.jobs, len(returned))
+			}
+		})
+	}
+}
+
+// TestProfile verifies the server-side profile command sets the Agent's traffic profile without queueing a job
+func TestProfile(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{"missing setting", nil, "", true},
+		{"unknown setting", []string{"jitter"}, "", true},
+		{"padding missing maximum", []string{"padding", "uniform", "64"}, "", true},
+		{"padding invalid minimum", []string{"padding", "uniform", "small", "1024"}, "", true},
+		{"padding", []string{"padding", "normal", "64", "1024"}, "padding: normal 64-1024 bytes, delay: none", false},
+		{"delay invalid duration", []string{"delay", "1", "2s"}, "", true},
+		{"delay over the limit", []string{"delay", "1s", "1m"}, "", true},
+		{"delay", []string{"delay", "1s", "2s"}, "padding: normal 64-1024 bytes, delay: 1s-2s", false},
+		{"reset", []string{"reset"}, "padding: default, delay: none", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "profile", test.args)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			agent, err := s.agentService.Agent(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := agent.Profile().String(); got != test.want {
+				t.Errorf("expected the profile %q, have %q", test.want, got)
+			}
+			if queued, _ := s.jobRepo.GetJobs(a.ID()); len(queued) != 0 {
+				t.Errorf("expected no jobs to be queued, have %d", len(queued))
+			}
+		})
+	}
+}
+
+// TestAddArguments verifies commands that require arguments return an error, instead of panicking, when they are
+// missing; scripts and plugins task Agents with arguments that were not validated by a client
+func TestAddArguments(t *testing.T) {
+	s, a := newTestService(t)
+	binary := filepath.Join(t.TempDir(), "service.exe")
+	err := os.WriteFile(binary, []byte("MZ"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		job     string
+		args    []string
+		wantErr bool
+	}{
+		{"download", "download", nil, true},
+		{"upload missing destination", "upload", []string{"bWVybGlu"}, true},
+		{"upload", "upload", []string{"bWVybGlu", "/tmp/merlin"}, false},
+		{"run", "run", nil, true},
+		{"exec", "exec", []string{}, true},
+		{"rm", "rm", nil, true},
+		{"changelistener", "changelistener", nil, true},
+		{"ja3", "ja3", nil, true},
+		{"killdate", "killdate", nil, true},
+		{"scexec without a service name", "scexec", []string{"192.0.2.10", binary}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), test.job, test.args)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+
+	// Every job type must handle being added without any arguments
+	jobTypes := []string{"agentInfo", "download", "ad", "campaign", "cd", "changelistener", "clipboard", "connect", "CreateProcess",
+		"destroy", "env", "exit", "fallback", "ifconfig", "initialize", "injection-method", "invoke-assembly", "ja3", "keylogger",
+		"killdate", "killprocess", "link", "listener", "list-assemblies", "load-assembly", "load-clr", "ls", "maxretry",
+		"memory", "memfd", "Minidump", "netstat", "nslookup", "padding", "parrot", "persist", "pipes", "preflight",
+		"profile", "ps", "pwd", "rm", "run", "exec", "runas", "scan", "scexec", "screenshot", "sdelete", "shell",
+		"shellcode", "skew", "sleep", "ssh", "ssh-deploy", "throttle", "timezone", "token", "touch", "transport",
+		"unlink", "upload", "uptime", "wmiexec"}
+	for _, jobType := range jobTypes {
+		t.Run("no arguments "+jobType, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("adding a %s job without arguments panicked: %v", jobType, r)
+				}
+			}()
+			_, _ = s.Add(a.ID(), jobType, nil)
+		})
+	}
+}
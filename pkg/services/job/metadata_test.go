@@ -0,0 +1,792 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+	"github.com/Ne0nd0g/merlin/v2/pkg/loot"
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence"
+)
+
+// TestCommandMetadata verifies the server-side metadata recorded for commands whose results need extra handling
+func TestCommandMetadata(t *testing.T) {
+	keystrokes := loot.KEYSTROKES.String()
+	tests := []struct {
+		name string
+		cmd  jobs.Command
+		want map[string]string
+	}{
+		{"keylogger start", jobs.Command{Command: "keylogger", Args: []string{"START"}}, map[string]string{metaStream: "keylogger", metaLoot: keystrokes}},
+		{"keylogger dump", jobs.Command{Command: "keylogger", Args: []string{"dump"}}, map[string]string{metaLoot: keystrokes}},
+		{"keylogger stop", jobs.Command{Command: "keylogger", Args: []string{"stop"}}, map[string]string{metaStop: "keylogger", metaLoot: keystrokes}},
+		{"screenshot take", jobs.Command{Command: "screenshot", Args: []string{"take", "1"}}, map[string]string{metaLoot: loot.SCREENSHOT.String()}},
+		{"screenshot watch", jobs.Command{Command: "screenshot", Args: []string{"watch", "30s"}}, map[string]string{metaStream: "screenshot", metaLoot: loot.SCREENSHOT.String()}},
+		{"screenshot stop", jobs.Command{Command: "screenshot", Args: []string{"stop"}}, map[string]string{metaStop: "screenshot"}},
+		{"clipboard get", jobs.Command{Command: "clipboard", Args: []string{"get"}}, map[string]string{metaLoot: loot.CLIPBOARD.String()}},
+		{"clipboard monitor", jobs.Command{Command: "clipboard", Args: []string{"monitor", "5s"}}, map[string]string{metaStream: "clipboard", metaLoot: loot.CLIPBOARD.String()}},
+		{"clipboard stop", jobs.Command{Command: "clipboard", Args: []string{"stop"}}, map[string]string{metaStop: "clipboard"}},
+		{"ad users", jobs.Command{Command: "ad", Args: []string{"users", "(adminCount=1)"}}, map[string]string{metaPaged: "ad", metaDirectory: "users"}},
+		{"scan", jobs.Command{Command: "scan", Args: []string{"192.0.2.0/24", "22"}}, map[string]string{metaPaged: "scan", metaScan: "192.0.2.0/24"}},
+		{"token make", jobs.Command{Command: "token", Args: []string{"make", "bob", "password"}}, map[string]string{metaImpersonation: "bob (token make)"}},
+		{"timezone", jobs.Command{Command: "timezone"}, map[string]string{metaTimezone: "timezone"}},
+		{"campaign", jobs.Command{Command: "campaign"}, map[string]string{metaCampaign: "campaign"}},
+		{"destroy", jobs.Command{Command: "destroy"}, map[string]string{metaDestroy: "destroy"}},
+		{"ssh", jobs.Command{Command: "ssh", Args: []string{"root", "toor", "192.0.2.10:22", "id"}}, map[string]string{metaTarget: "192.0.2.10", metaTargetUser: "root"}},
+		{"ssh deploy without port", jobs.Command{Command: "ssh-deploy", Args: []string{"root", "toor", "web01"}}, map[string]string{metaTarget: "web01", metaTargetUser: "root"}},
+		{"ssh missing host", jobs.Command{Command: "ssh", Args: []string{"root", "toor"}}, map[string]string{}},
+		{"token whoami", jobs.Command{Command: "token", Args: []string{"whoami"}}, map[string]string{}},
+		{"no arguments", jobs.Command{Command: "keylogger"}, map[string]string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := commandMetadata(test.cmd, test.cmd.Args); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// TestHandlerKeylogger verifies keylogger results are stored as loot by window and stopping the keylogger completes
+// the long-running start job
+func TestHandlerKeylogger(t *testing.T) {
+	s, a := newTestService(t)
+
+	task := func(method string) jobs.Job {
+		t.Helper()
+		_, err := s.Add(a.ID(), "keylogger", []string{method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		queued, err := s.jobRepo.GetJobs(a.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(queued) != 1 {
+			t.Fatalf("expected 1 queued job, got %d", len(queued))
+		}
+		return queued[0]
+	}
+	result := func(job jobs.Job, stdout string) {
+		t.Helper()
+		err := s.Handler([]jobs.Job{{AgentID: a.ID(), ID: job.ID, Token: job.Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: stdout}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := task("start")
+	result(start, `{"window":"Notepad","keys":"hello"}`+"\n"+`{"window":"Run","keys":"cmd"}`+"\n"+`{"window":"Notepad","keys":" world"}`)
+	info, err := s.jobRepo.GetInfo(start.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status() != infoJobs.ACTIVE {
+		t.Fatalf("expected the keylogger start job to remain active, got %s", info.StatusString())
+	}
+
+	want := map[string]string{"Notepad": "hello world", "Run": "cmd"}
+	stored := s.lootService.Agent(a.ID())
+	if len(stored) != len(want) {
+		t.Fatalf("expected %d loot entries, got %d", len(want), len(stored))
+	}
+	for _, l := range stored {
+		data, err := os.ReadFile(l.File())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Type() != loot.KEYSTROKES || string(data) != want[l.Tag("window")] {
+			t.Errorf("expected %q keystrokes for window %q, got %s %q", want[l.Tag("window")], l.Tag("window"), l.Type(), data)
+		}
+	}
+
+	result(task("stop"), "")
+	info, err = s.jobRepo.GetInfo(start.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status() != infoJobs.COMPLETE {
+		t.Errorf("expected stopping the keylogger to complete the start job, got %s", info.StatusString())
+	}
+}
+
+// TestHandlerCampaign verifies the campaign identifier an Agent returns is stored, an Agent built without one is left
+// unattributed, and an operator can assign one on the server without tasking the Agent
+func TestHandlerCampaign(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name    string
+		args    []string
+		stdout  string
+		stderr  string
+		want    string
+		queued  bool
+		wantErr bool
+	}{
+		{"no campaign", nil, "", "", "", true, false},
+		{"error", nil, "wave1", "campaign not supported", "", true, false},
+		{"reported", nil, " wave1\n", "", "wave1", true, false},
+		{"invalid report", nil, "wave 2", "", "wave1", true, true},
+		{"assigned", []string{"wave2"}, "", "", "wave2", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "campaign", test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (len(queued) == 1) != test.queued {
+				t.Fatalf("expected the job to be queued %t, have %d queued jobs", test.queued, len(queued))
+			}
+			if test.queued {
+				job := queued[0]
+				err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: job.ID, Token: job.Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: test.stdout, Stderr: test.stderr}}})
+				if (err != nil) != test.wantErr {
+					t.Errorf("expected error %t, have %v", test.wantErr, err)
+				}
+			}
+			agent, err := s.agentService.Agent(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if agent.Campaign() != test.want {
+				t.Errorf("expected campaign %q, have %q", test.want, agent.Campaign())
+			}
+		})
+	}
+
+	if _, err := s.Add(a.ID(), "campaign", []string{"wave 3"}); err == nil {
+		t.Error("expected an error assigning an invalid campaign identifier")
+	}
+}
+
+// TestScreenshot verifies the screenshot methods and their options are validated when the job is added
+func TestScreenshot(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{"default", nil, ""},
+		{"take all", []string{"take", "all", "75", "50"}, ""},
+		{"watch", []string{"watch", "1m", "2"}, ""},
+		{"stop", []string{"stop"}, ""},
+		{"watch without interval", []string{"watch"}, "requires an interval"},
+		{"watch bad interval", []string{"watch", "soon"}, "parsing the screenshot interval"},
+		{"bad monitor", []string{"take", "0"}, "screenshot monitor"},
+		{"bad quality", []string{"take", "1", "101"}, "screenshot quality"},
+		{"bad scale", []string{"watch", "10s", "all", "90", "x"}, "screenshot scale"},
+		{"bad method", []string{"record"}, "invalid screenshot method"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "screenshot", test.args)
+			if test.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+// TestHandlerScreenshot verifies a screenshot returned as a file is stored as loot instead of as a downloaded file
+func TestHandlerScreenshot(t *testing.T) {
+	s, a := newTestService(t)
+	_, err := s.Add(a.ID(), "screenshot", []string{"take"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err := s.jobRepo.GetJobs(a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	image := []byte{0xff, 0xd8, 0xff, 0xd9}
+	payload := jobs.FileTransfer{FileLocation: `C:\Users\bob\screen.jpg`, FileBlob: base64.StdEncoding.EncodeToString(image), IsDownload: true}
+	err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.FILETRANSFER, Payload: payload}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored := s.lootService.Agent(a.ID())
+	if len(stored) != 1 {
+		t.Fatalf("expected 1 loot entry, got %d", len(stored))
+	}
+	l := stored[0]
+	if l.Type() != loot.SCREENSHOT || l.Tag("file") != "screen.jpg" || filepath.Ext(l.File()) != ".jpg" {
+		t.Errorf("expected a screenshot loot entry for screen.jpg, got %s %s at %s", l.Type(), l.Tag("file"), l.File())
+	}
+	data, err := os.ReadFile(l.File())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(data, image) {
+		t.Errorf("expected the stored screenshot to be %x, got %x", image, data)
+	}
+}
+
+// TestHandlerClipboard verifies clipboard contents are stored as loot once and sensitive-looking contents are
+// flagged as credentials
+func TestHandlerClipboard(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name     string
+		contents string
+		loot     int
+		flagged  int
+	}{
+		{"plain text", "meeting at noon", 1, 0},
+		{"duplicate", "meeting at noon", 1, 0},
+		{"password", "password=Spring2025!", 2, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "clipboard", []string{"get"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: test.contents}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(s.lootService.Agent(a.ID())); n != test.loot {
+				t.Errorf("expected %d clipboard loot entries, got %d", test.loot, n)
+			}
+			var flagged int
+			for _, c := range s.credentialService.All() {
+				if c.AgentID() == a.ID() && c.Flagged() {
+					flagged++
+				}
+			}
+			if flagged != test.flagged {
+				t.Errorf("expected %d flagged credentials, got %d", test.flagged, flagged)
+			}
+		})
+	}
+}
+
+// TestLDAPFilter verifies LDAP filters must be enclosed in balanced parentheses
+func TestLDAPFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		valid  bool
+	}{
+		{"(adminCount=1)", true},
+		{"(&(objectClass=user)(|(cn=a*)(cn=b*)))", true},
+		{"adminCount=1", false},
+		{"(adminCount=1", false},
+		{"(a=1))(b=2)", false},
+		{"(&(a=1)(b=2)", false},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			if err := ldapFilter(test.filter); (err == nil) != test.valid {
+				t.Errorf("expected valid to be %t, got error: %v", test.valid, err)
+			}
+		})
+	}
+}
+
+// TestHandlerAD verifies ad jobs are validated, their pages are stored in the directory datastore, and the job
+// remains active until the Agent returns an empty page
+func TestHandlerAD(t *testing.T) {
+	s, a := newTestService(t)
+
+	invalid := []struct {
+		args []string
+		err  string
+	}{
+		{nil, "expected at least 1 argument"},
+		{[]string{"printers"}, "invalid ad object class"},
+		{[]string{"users", "adminCount=1"}, "enclosed in parentheses"},
+		{[]string{"users", "", "5000"}, "page size"},
+	}
+	for _, test := range invalid {
+		_, err := s.Add(a.ID(), "ad", test.args)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("expected an error containing %q for %q, got: %v", test.err, test.args, err)
+		}
+	}
+
+	_, err := s.Add(a.ID(), "ad", []string{"Computer", "(operatingSystem=*)", "100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err := s.jobRepo.GetJobs(a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args := queued[0].Payload.(jobs.Command).Args; args[0] != "computers" {
+		t.Errorf("expected the object class to be normalized to computers, got %s", args[0])
+	}
+	page := func(stdout string) infoJobs.Info {
+		t.Helper()
+		err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: stdout}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := s.jobRepo.GetInfo(queued[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+
+	dn := "CN=WS01,OU=" + a.ID().String() + ",DC=test,DC=local"
+	if info := page(`{"dn":"` + dn + `"}`); info.Status() != infoJobs.ACTIVE {
+		t.Errorf("expected the ad job to remain active after a page of results, got %s", info.StatusString())
+	}
+	if _, err = s.directoryService.Get(dn); err != nil {
+		t.Error(err)
+	}
+	if info := page(""); info.Status() != infoJobs.COMPLETE {
+		t.Errorf("expected an empty page to complete the ad job, got %s", info.StatusString())
+	}
+}
+
+// TestScanArguments verifies the scan command's target, ports, rate, and timeout are validated when the job is added
+func TestScanArguments(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{"address", []string{"192.0.2.1", "22"}, ""},
+		{"network and options", []string{"192.0.2.0/24", "22, 80,8000-8100", "100", "500ms"}, ""},
+		{"IPv6 network", []string{"2001:db8::/112", "443"}, ""},
+		{"missing ports", []string{"192.0.2.1"}, "expected at least 2 arguments"},
+		{"hostname", []string{"example.com", "80"}, "IP address or CIDR network"},
+		{"network too large", []string{"10.0.0.0/8", "80"}, "exceeds the maximum"},
+		{"port zero", []string{"192.0.2.1", "0"}, "invalid scan port"},
+		{"port too high", []string{"192.0.2.1", "65536"}, "invalid scan port"},
+		{"reversed range", []string{"192.0.2.1", "100-90"}, "invalid scan port range"},
+		{"bad rate", []string{"192.0.2.1", "80", "0"}, "scan rate"},
+		{"bad timeout", []string{"192.0.2.1", "80", "10", "fast"}, "scan timeout"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "scan", test.args)
+			if test.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+// TestPersist verifies installed persistence artifacts are recorded, removal results update their status, and
+// cleanup creates a remove job for every artifact that remains on the Agent's host
+func TestPersist(t *testing.T) {
+	s, a := newTestService(t)
+	setPlatform(t, s, a, "windows")
+
+	// run adds the job and returns the Agent's result for it
+	run := func(args []string, stderr string) jobs.Job {
+		t.Helper()
+		_, err := s.Add(a.ID(), "persist", args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		queued, err := s.jobRepo.GetJobs(a.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(queued) != 1 {
+			t.Fatalf("expected 1 queued job, got %d", len(queued))
+		}
+		err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stderr: stderr}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return queued[0]
+	}
+
+	job := run([]string{"install", "Registry", `C:\agent.exe`, "Updater"}, "")
+	want := []string{"install", "registry", "Updater", `C:\agent.exe`, `HKCU\Software\Microsoft\Windows\CurrentVersion\Run\Updater`}
+	if got := job.Payload.(jobs.Command).Args; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected job arguments %q, got %q", want, got)
+	}
+	run([]string{"install", "schtask", `C:\agent.exe`}, "")
+	installed := s.persistenceService.Installed(a.ID())
+	if len(installed) != 2 {
+		t.Fatalf("expected 2 installed artifacts, got %d", len(installed))
+	}
+	if installed[0].Name() != "Updater" || installed[0].Technique() != "registry" || installed[0].Status() != persistence.INSTALLED {
+		t.Errorf("unexpected artifact %s %s %s", installed[0].Technique(), installed[0].Name(), installed[0].Status())
+	}
+
+	run([]string{"remove", installed[0].ID().String()}, "")
+	run([]string{"remove", installed[1].ID().String()}, "access is denied")
+	for i, status := range []persistence.Status{persistence.REMOVED, persistence.FAILED} {
+		artifact, err := s.persistenceService.Get(installed[i].ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if artifact.Status() != status {
+			t.Errorf("expected artifact %s to be %s, got %s", artifact.ID(), status, artifact.Status())
+		}
+	}
+
+	invalid := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{"no method", nil, "expected at least 1 argument"},
+		{"bad method", []string{"list"}, "invalid persist method"},
+		{"install missing command", []string{"install", "registry"}, "requires a technique and a command line"},
+		{"bad technique", []string{"install", "bootkit", "x"}, "invalid persistence technique"},
+		{"wrong platform", []string{"install", "systemd", "x"}, "only supported by linux agents"},
+		{"remove bad ID", []string{"remove", "1"}, "parsing '1' as a UUID"},
+		{"remove twice", []string{"remove", installed[0].ID().String()}, "already removed"},
+	}
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "persist", test.args)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+
+	// Artifacts that failed to be removed are still on the host and are removed again by cleanup
+	_, err := s.Add(a.ID(), "persist", []string{"cleanup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err := s.jobRepo.GetJobs(a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 1 || queued[0].Payload.(jobs.Command).Args[4] != installed[1].ID().String() {
+		t.Errorf("expected cleanup to create 1 job to remove artifact %s, got %d jobs", installed[1].ID(), len(queued))
+	}
+}
+
+// TestTokenImpersonation verifies the impersonation context recorded for each token command method
+func TestTokenImpersonation(t *testing.T) {
+	tests := []struct {
+		name  string
+		cmd   jobs.Command
+		want  string
+		track bool
+	}{
+		{"make", jobs.Command{Command: "token", Args: []string{"make", `ACME\bob`, "password"}}, `ACME\bob (token make)`, true},
+		{"steal", jobs.Command{Command: "token", Args: []string{"steal", "1234"}}, "PID 1234 (token steal)", true},
+		{"rev2self", jobs.Command{Command: "token", Args: []string{"REV2SELF"}}, "", true},
+		{"make without user", jobs.Command{Command: "token", Args: []string{"make"}}, "", false},
+		{"whoami", jobs.Command{Command: "token", Args: []string{"whoami"}}, "", false},
+		{"other command", jobs.Command{Command: "run", Args: []string{"make", "bob"}}, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := tokenImpersonation(test.cmd)
+			if ok != test.track || got != test.want {
+				t.Errorf("expected (%q, %t), got (%q, %t)", test.want, test.track, got, ok)
+			}
+		})
+	}
+}
+
+// TestHandlerTokenImpersonation verifies the Agent's impersonation context is only updated when a token job succeeds
+func TestHandlerTokenImpersonation(t *testing.T) {
+	s, a := newTestService(t)
+
+	token := func(result jobs.Results, args ...string) {
+		t.Helper()
+		_, err := s.Add(a.ID(), "token", args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		queued, err := s.jobRepo.GetJobs(a.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(queued) != 1 {
+			t.Fatalf("expected 1 queued job, got %d", len(queued))
+		}
+		err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: result}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	impersonation := func() string {
+		t.Helper()
+		agent, err := s.agentService.Agent(a.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return agent.Impersonation()
+	}
+
+	token(jobs.Results{Stdout: "Successfully created a Windows access token"}, "make", `ACME\bob`, "password")
+	if got := impersonation(); got != `ACME\bob (token make)` {
+		t.Fatalf("expected the agent to be impersonating ACME\\bob, got %q", got)
+	}
+
+	token(jobs.Results{Stderr: "access is denied"}, "steal", "1234")
+	if got := impersonation(); got != `ACME\bob (token make)` {
+		t.Fatalf("expected a failed token steal to leave the impersonation context unchanged, got %q", got)
+	}
+
+	token(jobs.Results{Stdout: "Reverted to the process token"}, "rev2self")
+	if got := impersonation(); got != "" {
+		t.Fatalf("expected rev2self to clear the impersonation context, got %q", got)
+	}
+}
+
+func TestPreflightIndicators(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    []string
+		wantErr bool
+	}{
+		{"none", "", nil, false},
+		{"empty list", "[]", nil, false},
+		{"detail", `[{"check":"memory","detail":"2 GB RAM"}]`, []string{"memory: 2 GB RAM"}, false},
+		{"no detail", `[{"check":"debugger"},{"check":"edr","detail":"CrowdStrike"}]`, []string{"debugger", "edr: CrowdStrike"}, false},
+		{"invalid", "not json", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := preflightIndicators(test.output)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if strings.Join(got, "|") != strings.Join(test.want, "|") {
+				t.Errorf("expected %v, have %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestHandlerPreflight(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name   string
+		output string
+		want   int
+	}{
+		{"indicators", `[{"check":"memory","detail":"2 GB RAM"},{"check":"debugger"}]`, 2},
+		{"clean", "[]", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "preflight", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: test.output}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			agent, err := s.agentService.Agent(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(agent.Indicators()); n != test.want {
+				t.Errorf("expected %d indicators, have %d: %v", test.want, n, agent.Indicators())
+			}
+		})
+	}
+}
+
+func TestSecurityProducts(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    []string
+		wantErr bool
+	}{
+		{"none", "", nil, false},
+		{"empty list", "[]", nil, false},
+		{"details", `[{"name":"Windows Defender","type":"av","state":"enabled","source":"wsc"}]`, []string{"Windows Defender (av, enabled, wsc)"}, false},
+		{"partial details", `[{"name":"CrowdStrike Falcon","source":"driver"},{"name":"osquery"}]`, []string{"CrowdStrike Falcon (driver)", "osquery"}, false},
+		{"invalid", "not json", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := securityProducts(test.output)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if strings.Join(got, "|") != strings.Join(test.want, "|") {
+				t.Errorf("expected %v, have %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestHandlerSecurity(t *testing.T) {
+	s, a := newTestService(t)
+	a.UpdateHost(agents.Host{Name: "ws01", Platform: "windows", IPs: []string{"192.0.2.20/24"}})
+	if err := s.agentService.Update(a); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		output  string
+		want    int
+		wantErr bool
+	}{
+		{"products", `[{"name":"Windows Defender","type":"av","state":"enabled","source":"wsc"},{"name":"Sysmon","type":"edr","source":"service"}]`, 2, false},
+		{"none", "[]", 0, false},
+		{"invalid", "not json", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "security", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: test.output}}})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			agent, err := s.agentService.Agent(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(agent.SecurityProducts()); n != test.want {
+				t.Errorf("expected %d security products, have %d: %v", test.want, n, agent.SecurityProducts())
+			}
+			host, err := s.hostService.Host("192.0.2.20")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Products found earlier are kept on the host even when a later enumeration doesn't find them
+			if n := len(host.Products()); n != 2 {
+				t.Errorf("expected the host to have 2 security products, have %d: %v", n, host.Products())
+			}
+		})
+	}
+}
+
+func TestAddIdle(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"check", []string{"check"}, false},
+		{"watch", []string{"Watch", "10m"}, false},
+		{"stop", []string{"stop"}, false},
+		{"watch without interval", []string{"watch"}, true},
+		{"invalid interval", []string{"watch", "often"}, true},
+		{"invalid method", []string{"start"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "idle", test.args)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHandlerIdle(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name     string
+		output   string
+		idle     time.Duration
+		sessions int
+		wantErr  bool
+	}{
+		{"sessions", `{"idle":300,"sessions":[{"user":"ACME\\jdoe","state":"active","idle":300}]}`, 5 * time.Minute, 1, false},
+		{"active", `{"idle":0}`, 0, 0, false},
+		{"invalid", "not json", 0, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Add(a.ID(), "idle", []string{"check"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Handler([]jobs.Job{{AgentID: a.ID(), ID: queued[0].ID, Token: queued[0].Token, Type: jobs.RESULT, Payload: jobs.Results{Stdout: test.output}}})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			agent, err := s.agentService.Agent(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			activity := agent.Activity()
+			if activity.Checked.IsZero() || activity.Idle != test.idle || len(activity.Sessions) != test.sessions {
+				t.Errorf("expected idle %s with %d sessions, have %+v", test.idle, test.sessions, activity)
+			}
+		})
+	}
+}
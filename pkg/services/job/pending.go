@@ -0,0 +1,106 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+// pendingExpiry is how long a job created while its Agent is delayed or dead waits for the Agent to check in
+var pendingExpiry atomic.Int64
+
+func init() {
+	pendingExpiry.Store(int64(infoJobs.DefaultPendingExpiry))
+}
+
+// SetPendingExpiry sets how long a job created while its Agent is delayed or dead waits for the Agent to check in
+// before it is discarded, so that a task queued overnight doesn't unexpectedly run days later. 0 never expires the job
+func (s *Service) SetPendingExpiry(expiry time.Duration) error {
+	if expiry < 0 {
+		return fmt.Errorf("pkg/services/job.SetPendingExpiry(): the pending job expiry must be 0 or more, have %s", expiry)
+	}
+	pendingExpiry.Store(int64(expiry))
+	return nil
+}
+
+// pending marks the job as pending delivery, with the default expiry, if its Agent is currently delayed or dead
+func (s *Service) pending(agentID uuid.UUID, info *infoJobs.Info) {
+	status, err := s.agentService.Status(agentID)
+	if err != nil || (status != "Delayed" && status != "Dead") {
+		return
+	}
+	info.Pending()
+	if expiry := time.Duration(pendingExpiry.Load()); expiry > 0 {
+		info.SetExpiry(time.Now().UTC().Add(expiry))
+	}
+}
+
+// pendingNote describes when a job that is pending delivery expires, if it is pending delivery
+func (s *Service) pendingNote(jobID string) string {
+	info, err := s.jobRepo.GetInfo(jobID)
+	if err != nil || info.Status() != infoJobs.PENDING {
+		return ""
+	}
+	if info.Expires().IsZero() {
+		return ", pending delivery on the Agent's next check in"
+	}
+	return fmt.Sprintf(", pending delivery on the Agent's next check in before %s", info.Expires().Format(time.RFC3339))
+}
+
+// expire discards the Agent's unsent jobs whose expiry passed before the Agent checked in and notifies the operator
+func (s *Service) expire(agentID uuid.UUID) {
+	expired, err := s.jobRepo.Expire(agentID)
+	if err != nil {
+		return
+	}
+	a, err := s.agentService.Agent(agentID)
+	for _, info := range expired {
+		msg := fmt.Sprintf("Job %s (%s) for agent %s expired at %s before the agent checked in and was not sent", info.ID(), info.Command(), agentID, info.Expires().Format(time.RFC3339))
+		s.messageRepo.Add(message.NewMessage(message.Warn, msg))
+		waiters.deliver(info.ID(), jobs.Results{Stderr: msg})
+		if err == nil {
+			a.Log(msg)
+		}
+	}
+}
+
+// SetExpiry sets the time after which an unsent job is discarded instead of being sent to its Agent.
+// The zero time removes the job's expiry so that it waits for the Agent's next check in no matter how long that takes
+func (s *Service) SetExpiry(jobID string, expires time.Time) error {
+	err := s.jobRepo.SetExpiry(jobID, expires)
+	if err != nil {
+		return fmt.Errorf("pkg/services/job.SetExpiry(): %s", err)
+	}
+	return nil
+}
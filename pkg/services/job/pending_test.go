@@ -0,0 +1,186 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+// setCheckin sets how long ago the Agent last checked in with a 10 second sleep so its status is Active, Delayed, or Dead
+func setCheckin(t *testing.T, s *Service, a agents.Agent, ago time.Duration) {
+	t.Helper()
+	agent, err := s.agentService.Agent(a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent.UpdateComms(agents.Comms{Wait: "10s", Retry: 2})
+	agent.UpdateStatusCheckin(time.Now().Add(-ago))
+	err = s.agentService.Update(agent)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// activeJob returns the Agent's only active job
+func activeJob(t *testing.T, s *Service, agentID uuid.UUID) infoJobs.Info {
+	t.Helper()
+	active, err := s.GetAgentActive(agentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 {
+		t.Fatalf("expected 1 active job, have %d", len(active))
+	}
+	return active[0]
+}
+
+// TestSetPendingExpiry verifies a negative pending job expiry is rejected
+func TestSetPendingExpiry(t *testing.T) {
+	s, _ := newTestService(t)
+	t.Cleanup(func() { _ = s.SetPendingExpiry(infoJobs.DefaultPendingExpiry) })
+	tests := []struct {
+		expiry  time.Duration
+		wantErr bool
+	}{
+		{-time.Hour, true},
+		{0, false},
+		{time.Hour, false},
+	}
+	for _, test := range tests {
+		err := s.SetPendingExpiry(test.expiry)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: expected error %t, have %v", test.expiry, test.wantErr, err)
+		}
+	}
+}
+
+// TestPending verifies jobs created while the Agent is delayed or dead are pending delivery with the default expiry
+func TestPending(t *testing.T) {
+	tests := []struct {
+		name    string
+		ago     time.Duration
+		expiry  time.Duration
+		status  infoJobs.Status
+		expires bool
+	}{
+		{"active", 0, infoJobs.DefaultPendingExpiry, infoJobs.CREATED, false},
+		{"delayed", 20 * time.Second, infoJobs.DefaultPendingExpiry, infoJobs.PENDING, true},
+		{"dead", time.Hour, infoJobs.DefaultPendingExpiry, infoJobs.PENDING, true},
+		{"dead without expiry", time.Hour, 0, infoJobs.PENDING, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, a := newTestService(t)
+			if err := s.SetPendingExpiry(test.expiry); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = s.SetPendingExpiry(infoJobs.DefaultPendingExpiry) })
+			setCheckin(t, s, a, test.ago)
+
+			results, err := s.Add(a.ID(), "pwd", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info := activeJob(t, s, a.ID())
+			if info.Status() != test.status {
+				t.Errorf("expected the status %s, have %s", test.status, info.StatusString())
+			}
+			if !info.Expires().IsZero() != test.expires {
+				t.Errorf("expected an expiry %t, have %s", test.expires, info.Expires())
+			}
+			if want := test.status == infoJobs.PENDING; strings.Contains(results, "pending delivery") != want {
+				t.Errorf("expected the results to note pending delivery %t, have %q", want, results)
+			}
+		})
+	}
+}
+
+// TestSetExpiry verifies only unsent jobs can expire and an expired job is discarded instead of being sent
+func TestSetExpiry(t *testing.T) {
+	s, a := newTestService(t)
+	_, err := s.Add(a.ID(), "pwd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := activeJob(t, s, a.ID())
+	jobID := info.ID()
+
+	tests := []struct {
+		name    string
+		jobID   string
+		expires time.Time
+		wantErr bool
+	}{
+		{"unknown job", "unknown", time.Now(), true},
+		{"never", jobID, time.Time{}, false},
+		{"expired", jobID, time.Now().Add(-time.Minute), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.SetExpiry(test.jobID, test.expires)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	waited := make(chan string, 1)
+	go func() {
+		results, _ := s.Wait(ctx, jobID)
+		waited <- results.Stderr
+	}()
+	// Give the waiter time to register before the job expires
+	time.Sleep(50 * time.Millisecond)
+
+	sent, err := s.Get(context.Background(), a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 0 {
+		t.Errorf("expected the expired job to not be sent, have %d jobs", len(sent))
+	}
+	if stderr := <-waited; !strings.Contains(stderr, "expired") {
+		t.Errorf("expected the waiter to be released with the expiry, have %q", stderr)
+	}
+	info, err = s.jobRepo.GetInfo(jobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status() != infoJobs.EXPIRED {
+		t.Errorf("expected the job to be expired, have %s", info.StatusString())
+	}
+	if err = s.SetExpiry(jobID, time.Time{}); err == nil {
+		t.Error("expected an error setting the expiry of an expired job")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// platforms are the operating systems that support the commands which are not implemented by every Agent. Commands whose
+// support depends on their arguments, such as persist and ssh, are checked when the job is built
+var platforms = map[string][]string{
+	"CreateProcess":   {"windows"},
+	"deploy":          {"windows"},
+	"invoke-assembly": {"windows"},
+	"list-assemblies": {"windows"},
+	"load-assembly":   {"windows"},
+	"load-clr":        {"windows"},
+	"memfd":           {"linux"},
+	"memory":          {"windows"},
+	"Minidump":        {"windows"},
+	"netstat":         {"windows"},
+	"pipes":           {"windows"},
+	"ps":              {"windows"},
+	"runas":           {"windows"},
+	"scexec":          {"windows"},
+	"token":           {"windows"},
+	"uptime":          {"windows"},
+	"wmiexec":         {"windows"},
+}
+
+// compatible returns true if the Agent is running on one of the platforms. Every Agent is compatible when no platforms
+// are provided, as are Agents that have not yet returned their platform
+func compatible(a agents.Agent, platforms []string) bool {
+	if len(platforms) == 0 || a.Host().Platform == "" {
+		return true
+	}
+	for _, platform := range platforms {
+		if strings.EqualFold(a.Host().Platform, platform) {
+			return true
+		}
+	}
+	return false
+}
+
+// supported ensures the Agent is running on one of the provided platforms (e.g., windows, linux, darwin) for commands
+// that are not supported everywhere. Agents that have not yet returned their platform, and the broadcast identifier,
+// are not checked
+func (s *Service) supported(agentID uuid.UUID, command string, platforms ...string) error {
+	a, err := s.agentService.Agent(agentID)
+	if err != nil || compatible(a, platforms) {
+		return nil
+	}
+	return fmt.Errorf("the %s command is only supported by %s agents, agent %s is running on %s; use --force to send it anyway", command, strings.Join(platforms, " and "), agentID, a.Host().Platform)
+}
+
+// Supported ensures the Agent is running on one of the provided platforms for commands, such as those added by plugins,
+// that are not in the job service's table of platform specific commands
+func (s *Service) Supported(agentID uuid.UUID, command string, platforms ...string) error {
+	if len(platforms) == 0 {
+		return nil
+	}
+	return s.supported(agentID, command, platforms...)
+}
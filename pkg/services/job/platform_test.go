@@ -0,0 +1,147 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// TestCompatible verifies an Agent is compatible with a command when its platform is in the list, the list is empty,
+// or the Agent has not returned its platform yet
+func TestCompatible(t *testing.T) {
+	_, a := newTestService(t)
+	tests := []struct {
+		platform  string
+		platforms []string
+		want      bool
+	}{
+		{"windows", []string{"windows"}, true},
+		{"Windows", []string{"windows"}, true},
+		{"linux", []string{"windows"}, false},
+		{"darwin", []string{"linux", "darwin"}, true},
+		{"linux", nil, true},
+		{"", []string{"windows"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.platform+" "+strings.Join(test.platforms, ","), func(t *testing.T) {
+			a.UpdateHost(agents.Host{Platform: test.platform})
+			if have := compatible(a, test.platforms); have != test.want {
+				t.Errorf("expected %t, have %t", test.want, have)
+			}
+		})
+	}
+}
+
+// TestAddPlatform verifies commands in the platform table are refused for Agents on other platforms unless forced
+func TestAddPlatform(t *testing.T) {
+	s, a := newTestService(t)
+	tests := []struct {
+		name     string
+		platform string
+		command  string
+		args     []string
+		force    bool
+		err      bool
+	}{
+		{"supported", "windows", "ps", nil, false, false},
+		{"unsupported", "linux", "ps", nil, false, true},
+		{"forced", "linux", "ps", nil, true, false},
+		{"linux only", "windows", "memfd", []string{"TVo="}, false, true},
+		{"unknown platform", "", "ps", nil, false, false},
+		{"not in the table", "linux", "sleep", []string{"30s"}, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setPlatform(t, s, a, test.platform)
+			t.Cleanup(func() { _ = s.Clear(a.ID()) })
+			var err error
+			if test.force {
+				_, err = s.AddForced(a.ID(), test.command, test.args)
+			} else {
+				_, err = s.Add(a.ID(), test.command, test.args)
+			}
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %t, have %v", test.err, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "--force") {
+				t.Errorf("expected the error to mention --force, have %s", err)
+			}
+		})
+	}
+}
+
+// TestBroadcastPlatform verifies broadcast jobs skip Agents that don't support the command
+func TestBroadcastPlatform(t *testing.T) {
+	s, windows := newTestService(t)
+	setPlatform(t, s, windows, "windows")
+	linux, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.agentService.Add(linux)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.agentService.Remove(linux.ID()) })
+	setPlatform(t, s, linux, "linux")
+
+	tests := []struct {
+		command string
+		args    []string
+		windows int
+		linux   int
+	}{
+		{"ps", nil, 1, 0},
+		{"memfd", []string{"TVo="}, 0, 1},
+		{"sleep", []string{"30s"}, 1, 1},
+	}
+	broadcast := uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	for _, test := range tests {
+		t.Run(test.command, func(t *testing.T) {
+			t.Cleanup(func() {
+				_ = s.Clear(windows.ID())
+				_ = s.Clear(linux.ID())
+			})
+			results, err := s.Add(broadcast, test.command, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for id, want := range map[uuid.UUID]int{windows.ID(): test.windows, linux.ID(): test.linux} {
+				queued, _ := s.jobRepo.GetJobs(id)
+				if len(queued) != want {
+					t.Errorf("expected %d jobs for agent %s, have %d", want, id, len(queued))
+				}
+				if want == 0 && !strings.Contains(results, "Skipped agent "+id.String()) {
+					t.Errorf("expected agent %s to be skipped:\n%s", id, results)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"fmt"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+// acknowledge moves the job to the received or in progress status from the delivery receipt an Agent returned for it.
+// The receipt is an OK job, with the acknowledged job's ID and token, whose Results Stdout is the receipt type so that
+// an operator can tell a job the Agent never got from one the Agent is still working on
+func (s *Service) acknowledge(a agents.Agent, info infoJobs.Info, job jobs.Job) error {
+	receipt, ok := job.Payload.(jobs.Results)
+	if !ok {
+		return fmt.Errorf("pkg/services/job.acknowledge(): expected the delivery receipt for job %s to be a Results structure but received %T", job.ID, job.Payload)
+	}
+	before := info.StatusString()
+	switch receipt.Stdout {
+	case infoJobs.AckReceived:
+		info.Receive()
+	case infoJobs.AckInProgress:
+		info.Progress()
+	default:
+		return fmt.Errorf("pkg/services/job.acknowledge(): unknown delivery receipt '%s' for job %s", receipt.Stdout, job.ID)
+	}
+	if info.StatusString() == before {
+		return nil
+	}
+	a.Log(fmt.Sprintf("Job %s status changed from %s to %s", job.ID, before, info.StatusString()))
+	return s.jobRepo.UpdateInfo(info)
+}
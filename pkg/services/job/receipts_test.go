@@ -0,0 +1,81 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"context"
+	"testing"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+// TestAcknowledge verifies delivery receipts move a sent job to received and in progress, a job that only returns an
+// error fails, and late receipts are ignored
+func TestAcknowledge(t *testing.T) {
+	s, a := newTestService(t)
+	_, err := s.Add(a.ID(), "pwd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent, err := s.Get(context.Background(), a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 job to be sent, have %d", len(sent))
+	}
+	job := sent[0]
+
+	tests := []struct {
+		name    string
+		jobType jobs.Type
+		results jobs.Results
+		want    infoJobs.Status
+		wantErr bool
+	}{
+		{"received", jobs.OK, jobs.Results{Stdout: infoJobs.AckReceived}, infoJobs.RECEIVED, false},
+		{"unknown receipt", jobs.OK, jobs.Results{Stdout: "done"}, infoJobs.RECEIVED, true},
+		{"in progress", jobs.OK, jobs.Results{Stdout: infoJobs.AckInProgress}, infoJobs.RETURNED, false},
+		{"late received receipt", jobs.OK, jobs.Results{Stdout: infoJobs.AckReceived}, infoJobs.RETURNED, false},
+		{"error only", jobs.RESULT, jobs.Results{Stderr: "access denied"}, infoJobs.FAILED, false},
+		{"receipt after failing", jobs.OK, jobs.Results{Stdout: infoJobs.AckInProgress}, infoJobs.FAILED, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Handler([]jobs.Job{{AgentID: a.ID(), ID: job.ID, Token: job.Token, Type: test.jobType, Payload: test.results}})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			info, err := s.jobRepo.GetInfo(job.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Status() != test.want {
+				t.Errorf("expected the status %s, have %s", test.want, info.StatusString())
+			}
+		})
+	}
+}
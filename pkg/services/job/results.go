@@ -0,0 +1,108 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"fmt"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+)
+
+// handling serializes the messages each Agent returns so that the results of concurrent check-ins, such as those
+// from an Agent using several HTTP connections, are applied to their jobs one message at a time in the order received
+var handling = struct {
+	sync.Mutex
+	agents map[uuid.UUID]*sync.Mutex
+}{agents: make(map[uuid.UUID]*sync.Mutex)}
+
+// rendering keeps every message that displays one job's results together so that the results of jobs returned at
+// the same time are not interleaved for the operator
+var rendering sync.Mutex
+
+// lockAgent blocks until no other message from the Agent is being handled and returns the function to release it
+func lockAgent(agentID uuid.UUID) func() {
+	handling.Lock()
+	l, ok := handling.agents[agentID]
+	if !ok {
+		l = &sync.Mutex{}
+		handling.agents[agentID] = l
+	}
+	handling.Unlock()
+	l.Lock()
+	return l.Unlock
+}
+
+// render adds the messages to the message repository together, in order, without messages from other jobs in between
+func (s *Service) render(messages ...*message.Message) {
+	rendering.Lock()
+	defer rendering.Unlock()
+	for _, m := range messages {
+		s.messageRepo.Add(m)
+	}
+}
+
+// correlate returns the tracking structure for the job the Agent returned only if the job's ID, Agent, and token all
+// match a job that was created for that Agent
+func (s *Service) correlate(job jobs.Job) (infoJobs.Info, error) {
+	info, err := s.jobRepo.GetInfo(job.ID)
+	if err != nil {
+		return info, fmt.Errorf("pkg/services/job.correlate(): %s", err)
+	}
+	if info.AgentID() != job.AgentID {
+		return info, fmt.Errorf("pkg/services/job.correlate(): job %s belongs to agent %s but was returned by agent %s", job.ID, info.AgentID(), job.AgentID)
+	}
+	if job.Token != info.Token() {
+		return info, fmt.Errorf("pkg/services/job.correlate(): job %s for agent %s did not contain the correct token. Expected: %s, Got: %s", job.ID, job.AgentID, info.Token(), job.Token)
+	}
+	return info, nil
+}
+
+// uncorrelated displays results the Agent returned that don't match one of its jobs, such as an error that happened
+// outside a job, without attributing them to, or changing the status of, any job
+func (s *Service) uncorrelated(a agents.Agent, job jobs.Job, reason error) {
+	result, ok := job.Payload.(jobs.Results)
+	if !ok {
+		return
+	}
+	msg := fmt.Sprintf("Results from agent %s that do not belong to one of its jobs: %s", job.AgentID, reason)
+	a.Log(msg)
+	messages := []*message.Message{message.NewMessage(message.Warn, msg)}
+	if len(result.Stdout) > 0 {
+		a.Log(fmt.Sprintf("Uncorrelated Results (stdout):\r\n%s", result.Stdout))
+		messages = append(messages, message.NewMessage(message.Success, result.Stdout))
+	}
+	if len(result.Stderr) > 0 {
+		a.Log(fmt.Sprintf("Uncorrelated Results (stderr):\r\n%s", result.Stderr))
+		messages = append(messages, message.NewMessage(message.Warn, result.Stderr))
+	}
+	s.render(messages...)
+}
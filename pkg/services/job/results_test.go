@@ -0,0 +1,269 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+	"github.com/Ne0nd0g/merlin/v2/pkg/jobs/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	iocService "github.com/Ne0nd0g/merlin/v2/pkg/services/ioc"
+)
+
+// messageRecorder is a message repository that keeps every message in the order it was added.
+// Adding a message yields to other goroutines so that messages from concurrent results interleave if they can
+type messageRecorder struct {
+	sync.Mutex
+	messages []*message.Message
+}
+
+func (r *messageRecorder) Add(m *message.Message) {
+	r.Lock()
+	r.messages = append(r.messages, m)
+	r.Unlock()
+	time.Sleep(time.Millisecond)
+}
+
+func (r *messageRecorder) Get(id uuid.UUID) (*message.Message, error) {
+	return nil, fmt.Errorf("message %s was not found", id)
+}
+
+func (r *messageRecorder) GetAll() []*message.Message {
+	r.Lock()
+	defer r.Unlock()
+	return append([]*message.Message{}, r.messages...)
+}
+
+func (r *messageRecorder) GetQueue() *message.Message {
+	return nil
+}
+
+// newResultsService returns a job Service that records its messages and an Agent, whose log file is written to a
+// temporary directory, to task
+func newResultsService(tb testing.TB) (*Service, *messageRecorder, agents.Agent) {
+	tb.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	err = os.Chdir(tb.TempDir())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { _ = os.Chdir(current) })
+
+	a, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	agentService := agent.NewAgentService()
+	err = agentService.Add(a)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { _ = agentService.Remove(a.ID()) })
+
+	recorder := &messageRecorder{}
+	s := &Service{
+		jobRepo:      memory.NewRepository(),
+		messageRepo:  recorder,
+		agentService: agentService,
+		iocService:   iocService.NewIOCService(),
+	}
+	return s, recorder, a
+}
+
+// queue adds the number of jobs to the Agent's queue, sends them, and returns them in the order they were created
+func queue(tb testing.TB, s *Service, a agents.Agent, count int) []jobs.Job {
+	tb.Helper()
+	var queued []jobs.Job
+	for i := 0; i < count; i++ {
+		id, err := s.Task(a.ID(), "run", []string{"whoami", fmt.Sprintf("%d", i)})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		info, err := s.jobRepo.GetInfo(id)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		queued = append(queued, jobs.Job{AgentID: a.ID(), ID: id, Token: info.Token(), Type: jobs.RESULT})
+	}
+	_, err := s.jobRepo.GetJobs(a.ID())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return queued
+}
+
+// TestHandlerConcurrentResults returns the results of many jobs from concurrent check-ins, each carrying several jobs,
+// and verifies every job's output is displayed directly below its own header. Run with the -race flag
+func TestHandlerConcurrentResults(t *testing.T) {
+	s, recorder, a := newResultsService(t)
+	const checkins = 10
+	const perCheckin = 5
+	queued := queue(t, s, a, checkins*perCheckin)
+
+	var wg sync.WaitGroup
+	for c := 0; c < checkins; c++ {
+		wg.Add(1)
+		go func(batch []jobs.Job) {
+			defer wg.Done()
+			for i := range batch {
+				batch[i].Payload = jobs.Results{Stdout: "output of " + batch[i].ID, Stderr: "warning from " + batch[i].ID}
+			}
+			if err := s.Handler(batch); err != nil {
+				t.Error(err)
+			}
+		}(queued[c*perCheckin : (c+1)*perCheckin])
+	}
+	wg.Wait()
+
+	messages := recorder.GetAll()
+	if len(messages) != len(queued)*3 {
+		t.Fatalf("expected %d messages, got %d", len(queued)*3, len(messages))
+	}
+	for i := 0; i < len(messages); i += 3 {
+		header := messages[i].Message()
+		if !strings.HasPrefix(header, "Results of job ") {
+			t.Fatalf("expected message %d to be a job's header, got: %s", i, header)
+		}
+		id := strings.Fields(header)[3]
+		if got := messages[i+1].Message(); got != "output of "+id {
+			t.Errorf("job %s header was followed by the wrong output: %s", id, got)
+		}
+		if got := messages[i+2].Message(); got != "warning from "+id {
+			t.Errorf("job %s output was followed by the wrong error: %s", id, got)
+		}
+	}
+	for _, job := range queued {
+		info, err := s.jobRepo.GetInfo(job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Status() != infoJobs.COMPLETE {
+			t.Errorf("expected job %s to be %s, got %s", job.ID, infoJobs.COMPLETE, info.StatusString())
+		}
+	}
+}
+
+// TestHandlerResultsInOrder returns each job's results in several chunks from concurrent check-ins and verifies the
+// chunks of every job are displayed in the order each check-in returned them
+func TestHandlerResultsInOrder(t *testing.T) {
+	s, recorder, a := newResultsService(t)
+	queued := queue(t, s, a, 4)
+
+	const chunks = 20
+	var wg sync.WaitGroup
+	for _, job := range queued {
+		wg.Add(1)
+		go func(job jobs.Job) {
+			defer wg.Done()
+			for c := 0; c < chunks; c++ {
+				job.Payload = jobs.Results{Stdout: fmt.Sprintf("%s chunk %d", job.ID, c)}
+				if err := s.Handler([]jobs.Job{job}); err != nil {
+					t.Error(err)
+				}
+			}
+		}(job)
+	}
+	wg.Wait()
+
+	next := make(map[string]int)
+	for _, m := range recorder.GetAll() {
+		var id string
+		var chunk int
+		if _, err := fmt.Sscanf(m.Message(), "%s chunk %d", &id, &chunk); err != nil {
+			continue
+		}
+		if chunk != next[id] {
+			t.Errorf("expected chunk %d of job %s, got chunk %d", next[id], id, chunk)
+		}
+		next[id] = chunk + 1
+	}
+	for _, job := range queued {
+		if next[job.ID] != chunks {
+			t.Errorf("expected %d chunks for job %s, got %d", chunks, job.ID, next[job.ID])
+		}
+	}
+}
+
+// TestHandlerUncorrelatedResults verifies results with another Agent's ID, the wrong token, or an unknown job ID are
+// displayed without completing any job
+func TestHandlerUncorrelatedResults(t *testing.T) {
+	s, recorder, a := newResultsService(t)
+	queued := queue(t, s, a, 2)
+	_, _, other := newResultsService(t)
+
+	wrongToken := queued[0]
+	wrongToken.Token = uuid.New()
+	wrongAgent := queued[1]
+	wrongAgent.AgentID = other.ID()
+	tests := []struct {
+		name string
+		job  jobs.Job
+	}{
+		{"wrong token", wrongToken},
+		{"wrong agent", wrongAgent},
+		{"unknown job", jobs.Job{AgentID: a.ID(), ID: "unknown", Token: uuid.New(), Type: jobs.RESULT}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.job.Payload = jobs.Results{Stdout: "stray output"}
+			if err := s.Handler([]jobs.Job{test.job}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.correlate(test.job); err == nil {
+				t.Error("expected the results to not correlate with a job")
+			}
+		})
+	}
+
+	for _, job := range queued {
+		info, err := s.jobRepo.GetInfo(job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Status() != infoJobs.SENT {
+			t.Errorf("expected job %s to still be %s, got %s", job.ID, infoJobs.SENT, info.StatusString())
+		}
+	}
+	for _, m := range recorder.GetAll() {
+		if strings.HasPrefix(m.Message(), "Results of job ") {
+			t.Errorf("uncorrelated results were attributed to a job: %s", m.Message())
+		}
+	}
+}
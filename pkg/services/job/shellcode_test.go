@@ -0,0 +1,87 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"reflect"
+	"testing"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/modules/shellcode"
+)
+
+// TestShellcodeGetJobOrder pins the argument order produced by the shellcode module's GetJob to the order the job
+// service reads them in when it builds a shellcode job
+func TestShellcodeGetJobOrder(t *testing.T) {
+	s, a := newTestService(t)
+	const sc = "kJDD"
+
+	tests := []struct {
+		method string
+		pid    string
+		args   []string
+		want   jobs.Shellcode
+	}{
+		{"self", "", []string{"shellcode", sc, "self"}, jobs.Shellcode{Method: "self", Bytes: sc}},
+		{"remote", "1234", []string{"shellcode", sc, "remote", "1234"}, jobs.Shellcode{Method: "remote", Bytes: sc, PID: 1234}},
+		{"CreateRemoteThread", "1234", []string{"shellcode", sc, "remote", "1234"}, jobs.Shellcode{Method: "remote", Bytes: sc, PID: 1234}},
+		{"rtlcreateuserthread", "42", []string{"shellcode", sc, "rtlcreateuserthread", "42"}, jobs.Shellcode{Method: "rtlcreateuserthread", Bytes: sc, PID: 42}},
+		{"userapc", "42", []string{"shellcode", sc, "userapc", "42"}, jobs.Shellcode{Method: "userapc", Bytes: sc, PID: 42}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			args, err := shellcode.GetJob(test.method, sc, test.pid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(args, test.args) {
+				t.Fatalf("expected GetJob to return %q, got %q", test.args, args)
+			}
+
+			_, err = s.Add(a.ID(), args[0], args[1:])
+			if err != nil {
+				t.Fatalf("the job service rejected the GetJob arguments %q: %s", args, err)
+			}
+			queued, err := s.jobRepo.GetJobs(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queued) != 1 {
+				t.Fatalf("expected 1 queued job, got %d", len(queued))
+			}
+			if queued[0].Type != jobs.SHELLCODE {
+				t.Fatalf("expected a SHELLCODE job, got %s", queued[0].Type)
+			}
+			payload, ok := queued[0].Payload.(jobs.Shellcode)
+			if !ok {
+				t.Fatalf("expected a jobs.Shellcode payload, got %T", queued[0].Payload)
+			}
+			if payload != test.want {
+				t.Errorf("expected payload %+v, got %+v", test.want, payload)
+			}
+		})
+	}
+}
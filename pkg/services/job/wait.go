@@ -0,0 +1,90 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package job
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"sync"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+)
+
+// resultWaiters holds a channel for every job whose results a caller, such as a server-side script, is waiting on
+type resultWaiters struct {
+	sync.Mutex
+	jobs map[string]chan jobs.Results
+}
+
+// waiters is shared by every job Service
+var waiters = &resultWaiters{jobs: make(map[string]chan jobs.Results)}
+
+// Wait blocks until the job with the provided ID is complete and returns its results, or until the context is done.
+// Jobs that return a file or Agent information complete with empty results
+func (s *Service) Wait(ctx context.Context, jobID string) (jobs.Results, error) {
+	waiters.Lock()
+	results, ok := waiters.jobs[jobID]
+	if !ok {
+		results = make(chan jobs.Results, 1)
+		waiters.jobs[jobID] = results
+	}
+	waiters.Unlock()
+	defer func() {
+		waiters.Lock()
+		delete(waiters.jobs, jobID)
+		waiters.Unlock()
+	}()
+
+	info, err := s.jobRepo.GetInfo(jobID)
+	if err != nil {
+		return jobs.Results{}, fmt.Errorf("pkg/services/job.Wait(): %s", err)
+	}
+	// The results were already processed before anyone waited on them
+	if info.Done() {
+		select {
+		case r := <-results:
+			return r, nil
+		default:
+			return jobs.Results{}, fmt.Errorf("pkg/services/job.Wait(): job %s is already %s", jobID, info.StatusString())
+		}
+	}
+
+	select {
+	case r := <-results:
+		return r, nil
+	case <-ctx.Done():
+		return jobs.Results{}, fmt.Errorf("pkg/services/job.Wait(): stopped waiting on job %s: %w", jobID, ctx.Err())
+	}
+}
+
+// deliver sends the completed job's results to the caller waiting on them, if there is one
+func (w *resultWaiters) deliver(jobID string, r jobs.Results) {
+	w.Lock()
+	defer w.Unlock()
+	if results, ok := w.jobs[jobID]; ok {
+		select {
+		case results <- r:
+		default:
+		}
+	}
+}
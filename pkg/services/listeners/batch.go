@@ -0,0 +1,164 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"fmt"
+	"maps"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+)
+
+// maxBatch is the most listeners that can be created at one time
+const maxBatch = 256
+
+// Bind is a network interface and port pair a Listener is created for
+type Bind struct {
+	Interface string
+	Port      int
+}
+
+// BatchResult is the outcome of creating one Listener in a batch
+type BatchResult struct {
+	Bind
+	Name string    // The name the Listener was created with
+	ID   uuid.UUID // The Listener's ID; uuid.Nil if it wasn't created
+	Err  error     // The error creating the Listener, if any
+}
+
+// ParseBinds expands a comma separated list of interface and port pairs into a list of binds.
+// Each entry is a port (e.g., 443), a range of ports (e.g., 8000-8010), or an interface and port or range
+// (e.g., 10.0.0.5:443, [::1]:8443, 10.0.0.0/30:80). CIDR networks are expanded to each address in the network.
+// Entries without an interface use the provided default interface
+func ParseBinds(value, iface string) (binds []Bind, err error) {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		host, ports := iface, entry
+		if i := strings.LastIndex(entry, ":"); i >= 0 {
+			host, ports = strings.Trim(entry[:i], "[]"), entry[i+1:]
+		}
+
+		var hosts []string
+		if strings.Contains(host, "/") {
+			hosts, err = expandCIDR(host)
+			if err != nil {
+				return nil, fmt.Errorf("pkg/services/listeners.ParseBinds(): %w: %s", ErrInvalidOption, err)
+			}
+		} else {
+			host, err = listeners.ParseInterface(host)
+			if err != nil {
+				return nil, fmt.Errorf("pkg/services/listeners.ParseBinds(): %w: %s", ErrInvalidOption, err)
+			}
+			hosts = []string{host}
+		}
+
+		low, high, err := listeners.ParsePorts(ports)
+		if err != nil {
+			return nil, fmt.Errorf("pkg/services/listeners.ParseBinds(): %w: %s", ErrInvalidOption, err)
+		}
+		for _, h := range hosts {
+			for port := low; port <= high; port++ {
+				binds = append(binds, Bind{Interface: h, Port: port})
+				if len(binds) > maxBatch {
+					return nil, fmt.Errorf("pkg/services/listeners.ParseBinds(): %w: more than %d listeners can't be created at once", ErrInvalidOption, maxBatch)
+				}
+			}
+		}
+	}
+	if len(binds) == 0 {
+		return nil, fmt.Errorf("pkg/services/listeners.ParseBinds(): %w: at least one interface and port is required", ErrInvalidOption)
+	}
+	return
+}
+
+// expandCIDR returns every address in the network, excluding the network and broadcast addresses for IPv4 networks
+// larger than /31
+func expandCIDR(value string) (hosts []string, err error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return nil, err
+	}
+	prefix = prefix.Masked()
+	bits := prefix.Addr().BitLen() - prefix.Bits()
+	if bits > 8 {
+		return nil, fmt.Errorf("the network %s has more than %d addresses", value, maxBatch)
+	}
+	for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+		hosts = append(hosts, addr.String())
+	}
+	if prefix.Addr().Is4() && bits > 1 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return
+}
+
+// NewListeners creates a Listener from the template options for every bind. Listeners are named after the template's
+// name, interface, and port (e.g., redirector-10.0.0.5-443). A failure doesn't stop the remaining Listeners from being
+// created; each result contains its own error
+func (ls *ListenerService) NewListeners(template map[string]string, binds []Bind) (results []BatchResult) {
+	return batch(template, binds, func(options map[string]string) (uuid.UUID, error) {
+		listener, err := ls.NewListener(options)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return listener.ID(), nil
+	})
+}
+
+// DryRunListeners validates the Listener every bind would create with NewListeners without creating any of them
+func (ls *ListenerService) DryRunListeners(template map[string]string, binds []Bind) (results []BatchResult) {
+	return batch(template, binds, func(options map[string]string) (uuid.UUID, error) {
+		_, _, err := ls.DryRun(options)
+		return uuid.Nil, err
+	})
+}
+
+// batch builds the options for every bind from the template and passes them to the create function
+func batch(template map[string]string, binds []Bind, create func(map[string]string) (uuid.UUID, error)) (results []BatchResult) {
+	name := template["Name"]
+	if name == "" {
+		name = template["Protocol"]
+	}
+	for _, bind := range binds {
+		options := maps.Clone(template)
+		options["Interface"] = bind.Interface
+		options["Port"] = strconv.Itoa(bind.Port)
+		options["Name"] = fmt.Sprintf("%s-%s-%d", name, strings.ReplaceAll(bind.Interface, ":", "-"), bind.Port)
+		// Every listener needs its own ID
+		delete(options, "ID")
+
+		result := BatchResult{Bind: bind, Name: options["Name"]}
+		result.ID, result.Err = create(options)
+		results = append(results, result)
+	}
+	return
+}
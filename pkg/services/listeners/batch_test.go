@@ -0,0 +1,165 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseBinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []Bind
+		err   bool
+	}{
+		{"port", "443", []Bind{{"127.0.0.1", 443}}, false},
+		{"range", "8000-8002", []Bind{{"127.0.0.1", 8000}, {"127.0.0.1", 8001}, {"127.0.0.1", 8002}}, false},
+		{"interface and port", "10.0.0.5:443", []Bind{{"10.0.0.5", 443}}, false},
+		{"IPv6", "[::1]:8443", []Bind{{"::1", 8443}}, false},
+		{"list", "443, 10.0.0.5:80,", []Bind{{"127.0.0.1", 443}, {"10.0.0.5", 80}}, false},
+		{"CIDR", "10.0.0.0/30:80", []Bind{{"10.0.0.1", 80}, {"10.0.0.2", 80}}, false},
+		{"empty", " , ", nil, true},
+		{"invalid interface", "merlin:443", nil, true},
+		{"invalid port", "10.0.0.5:http", nil, true},
+		{"too many", "1-257", nil, true},
+		{"network too large", "10.0.0.0/16:80", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binds, err := ParseBinds(test.value, "127.0.0.1")
+			if test.err {
+				if !errors.Is(err, ErrInvalidOption) {
+					t.Errorf("expected ErrInvalidOption, have %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(binds, test.want) {
+				t.Errorf("expected %v, have %v", test.want, binds)
+			}
+		})
+	}
+}
+
+func TestExpandCIDR(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+		err   bool
+	}{
+		{"host", "10.0.0.5/32", []string{"10.0.0.5"}, false},
+		{"point-to-point", "10.0.0.4/31", []string{"10.0.0.4", "10.0.0.5"}, false},
+		{"unmasked", "10.0.0.5/30", []string{"10.0.0.5", "10.0.0.6"}, false},
+		{"IPv6", "fd00::/127", []string{"fd00::", "fd00::1"}, false},
+		{"largest", "10.0.0.0/24", nil, false},
+		{"too large", "10.0.0.0/23", nil, true},
+		{"invalid", "10.0.0.0/33", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hosts, err := expandCIDR(test.value)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error, have %v", hosts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.want == nil {
+				if len(hosts) != 254 {
+					t.Errorf("expected 254 addresses, have %d", len(hosts))
+				}
+				return
+			}
+			if !reflect.DeepEqual(hosts, test.want) {
+				t.Errorf("expected %v, have %v", test.want, hosts)
+			}
+		})
+	}
+}
+
+// TestNewListeners verifies a listener is created for every bind and a failure doesn't stop the rest
+func TestNewListeners(t *testing.T) {
+	ls := newListenerService(t)
+	template, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	template["Name"] = "batch"
+	binds := []Bind{{"127.0.0.1", 7001}, {"::1", 7002}}
+
+	existing := ls.NewListeners(template, binds[:1])[0]
+	if existing.Err != nil {
+		t.Fatal(existing.Err)
+	}
+	results := ls.NewListeners(template, binds)
+	t.Cleanup(func() {
+		for _, result := range append(results, existing) {
+			if listener, err := ls.Listener(result.ID); err == nil {
+				removeListener(ls, listener)
+			}
+		}
+	})
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"batch-127.0.0.1-7001", ErrDuplicateName},
+		{"batch---1-7002", nil},
+	}
+	if len(results) != len(tests) {
+		t.Fatalf("expected %d results, have %d", len(tests), len(results))
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := results[i]
+			if result.Name != test.name {
+				t.Errorf("expected the listener to be named %s, have %s", test.name, result.Name)
+			}
+			if test.err != nil {
+				if !errors.Is(result.Err, test.err) {
+					t.Errorf("expected %v, have %v", test.err, result.Err)
+				}
+				return
+			}
+			if result.Err != nil {
+				t.Fatal(result.Err)
+			}
+			listener, err := ls.Listener(result.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if listener.ConfiguredOptions()["Interface"] != binds[i].Interface {
+				t.Errorf("expected the listener to use interface %s, have %s", binds[i].Interface, listener.ConfiguredOptions()["Interface"])
+			}
+		})
+	}
+}
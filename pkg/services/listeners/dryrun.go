@@ -0,0 +1,98 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/http"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/smb"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/tcp"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/udp"
+	httpServer "github.com/Ne0nd0g/merlin/v2/pkg/servers/http"
+)
+
+// DryRun validates the options and builds the Listener they describe the same way NewListener does, including binding
+// the HTTP server's address and loading its certificate, but nothing is stored, started, or scheduled. The Listener's
+// effective configuration is returned along with notes about what will happen when it is created and started
+func (ls *ListenerService) DryRun(options map[string]string) (configured map[string]string, notes []string, err error) {
+	err = ls.validate(options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w", err)
+	}
+	expiration, err := listeners.ParseExpiration(options["Expiration"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w: %s", ErrInvalidOption, err)
+	}
+
+	var listener listeners.Listener
+	switch strings.ToLower(options["Protocol"]) {
+	case "http", "https", "h2c", "http2", "http3":
+		hServer, err := httpServer.New(options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w", err)
+		}
+		notes, err = hServer.Check()
+		if err != nil {
+			return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w", err)
+		}
+		hListener, err := http.NewHTTPListener(&hServer, options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w", err)
+		}
+		listener = &hListener
+	case "smb":
+		sListener, err := smb.NewSMBListener(options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w", err)
+		}
+		listener = &sListener
+	case "tcp":
+		tListener, err := tcp.NewTCPListener(options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w", err)
+		}
+		listener = &tListener
+	case "udp":
+		uListener, err := udp.NewUDPListener(options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w", err)
+		}
+		listener = &uListener
+	default:
+		return nil, nil, fmt.Errorf("pkg/services/listeners.DryRun(): %w: unhandled server type %s", ErrInvalidOption, options["Protocol"])
+	}
+
+	configured = listener.ConfiguredOptions()
+	// The ID is generated again when the Listener is created
+	delete(configured, "ID")
+	switch {
+	case expiration.Once:
+		notes = append(notes, fmt.Sprintf("The listener is stopped and removed %s", expiration))
+	case !expiration.At.IsZero():
+		notes = append(notes, fmt.Sprintf("The listener is stopped and removed at %s", expiration))
+	}
+	return configured, notes, nil
+}
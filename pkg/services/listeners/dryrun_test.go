@@ -0,0 +1,123 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestDryRun verifies the options are validated and the effective configuration returned without creating the listener
+func TestDryRun(t *testing.T) {
+	ls := newListenerService(t)
+	existing, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing["Name"] = "existing"
+	listener, err := ls.NewListener(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { removeListener(ls, listener) })
+
+	tests := []struct {
+		name     string
+		protocol string
+		options  map[string]string
+		note     string
+		err      error
+	}{
+		{"https", "https", map[string]string{"Interface": "127.0.0.1", "Port": "0"}, "in-memory certificate", nil},
+		{"http", "http", map[string]string{"Interface": "127.0.0.1", "Port": "0", "Protocol": "http"}, "", nil},
+		{"tcp", "tcp", map[string]string{"Port": "7010"}, "", nil},
+		{"expires once", "tcp", map[string]string{"Port": "7011", "Expiration": "once"}, "after the first Agent authentication", nil},
+		{"expires later", "tcp", map[string]string{"Port": "7012", "Expiration": "24h"}, "stopped and removed at", nil},
+		{"invalid expiration", "tcp", map[string]string{"Expiration": "tomorrow"}, "", ErrInvalidOption},
+		{"duplicate name", "tcp", map[string]string{"Name": "existing"}, "", ErrDuplicateName},
+		{"missing protocol", "tcp", map[string]string{"Protocol": ""}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := ls.DefaultOptions(test.protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			options["Name"] = "dry run " + test.name
+			for k, v := range test.options {
+				options[k] = v
+			}
+			if options["Protocol"] == "" {
+				delete(options, "Protocol")
+			}
+			before := len(ls.Listeners())
+			configured, notes, err := ls.DryRun(options)
+			if len(ls.Listeners()) != before {
+				t.Errorf("expected the dry run to not create a listener")
+			}
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Errorf("expected %v, have %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := configured["ID"]; ok {
+				t.Errorf("expected the configuration to not include the ID")
+			}
+			if configured["Name"] != options["Name"] {
+				t.Errorf("expected the configured name %q, have %q", options["Name"], configured["Name"])
+			}
+			if test.note != "" && !strings.Contains(strings.Join(notes, "\n"), test.note) {
+				t.Errorf("expected a note containing %q, have %v", test.note, notes)
+			}
+		})
+	}
+}
+
+// TestDryRunListeners verifies every bind is validated without creating any listeners
+func TestDryRunListeners(t *testing.T) {
+	ls := newListenerService(t)
+	template, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	template["Name"] = "dry run"
+	results := ls.DryRunListeners(template, []Bind{{"127.0.0.1", 7020}, {"::1", 7021}})
+	if len(ls.Listeners()) != 0 {
+		t.Errorf("expected no listeners to be created, have %d", len(ls.Listeners()))
+	}
+	tests := []string{"dry run-127.0.0.1-7020", "dry run---1-7021"}
+	if len(results) != len(tests) {
+		t.Fatalf("expected %d results, have %d", len(tests), len(results))
+	}
+	for i, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			if results[i].Name != name || results[i].Err != nil {
+				t.Errorf("expected %s to be valid, have %s: %v", name, results[i].Name, results[i].Err)
+			}
+		})
+	}
+}
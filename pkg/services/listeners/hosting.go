@@ -0,0 +1,112 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"fmt"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	httpServer "github.com/Ne0nd0g/merlin/v2/pkg/servers/http"
+)
+
+// hostingServer returns the ID of the HTTP server embedded in the listener so that it can host files
+func (ls *ListenerService) hostingServer(id uuid.UUID) (listeners.Listener, uuid.UUID, error) {
+	listener, err := ls.Listener(id)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	if listener.Protocol() != listeners.HTTP {
+		return nil, uuid.Nil, fmt.Errorf("only HTTP listeners can host files, %s is a %s listener", listener.Name(), listeners.String(listener.Protocol()))
+	}
+	server := *listener.Server()
+	return listener, server.ID(), nil
+}
+
+// HostFile serves the data from memory at the URI on the HTTP listener. When once is true the file is removed after it
+// is downloaded one time; a zero expires value never expires. The URI can't be one the listener uses for Agent
+// traffic or health checks.
+func (ls *ListenerService) HostFile(id uuid.UUID, file httpServer.HostedFile, data []byte) (httpServer.HostedFile, error) {
+	listener, server, err := ls.hostingServer(id)
+	if err != nil {
+		return httpServer.HostedFile{}, fmt.Errorf("pkg/services/listeners.HostFile(): %s", err)
+	}
+	if !file.Expires.IsZero() && file.Expires.Before(time.Now()) {
+		return httpServer.HostedFile{}, fmt.Errorf("pkg/services/listeners.HostFile(): the expiration time %s has already passed", file.Expires.Format(time.RFC3339))
+	}
+	options := listener.ConfiguredOptions()
+	reserved := append(strings.Split(options["URLS"], ","), strings.Split(options["URIPool"], ",")...)
+	reserved = append(reserved, options["HealthURI"])
+	for _, uri := range reserved {
+		if uri != "" && strings.TrimSpace(uri) == file.URI {
+			return httpServer.HostedFile{}, fmt.Errorf("pkg/services/listeners.HostFile(): the URI %s is used by listener %s for Agent traffic or health checks", file.URI, listener.Name())
+		}
+	}
+	hosted, err := httpServer.Host(server, file, data)
+	if err != nil {
+		return httpServer.HostedFile{}, fmt.Errorf("pkg/services/listeners.HostFile(): %s", err)
+	}
+	return hosted, nil
+}
+
+// UnhostFile stops the HTTP listener from serving the file at the URI
+func (ls *ListenerService) UnhostFile(id uuid.UUID, uri string) error {
+	_, server, err := ls.hostingServer(id)
+	if err != nil {
+		return fmt.Errorf("pkg/services/listeners.UnhostFile(): %s", err)
+	}
+	return httpServer.Unhost(server, uri)
+}
+
+// HostedFiles returns the files hosted by the HTTP listener
+func (ls *ListenerService) HostedFiles(id uuid.UUID) ([]httpServer.HostedFile, error) {
+	_, server, err := ls.hostingServer(id)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/listeners.HostedFiles(): %s", err)
+	}
+	return httpServer.Hosted(server), nil
+}
+
+// HostedAccesses returns the most recent requests for the file hosted at the URI on the HTTP listener
+func (ls *ListenerService) HostedAccesses(id uuid.UUID, uri string) ([]httpServer.HostedAccess, error) {
+	_, server, err := ls.hostingServer(id)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/listeners.HostedAccesses(): %s", err)
+	}
+	return httpServer.Accesses(server, uri)
+}
+
+// unhostAll stops the HTTP listener from serving any hosted files
+func (ls *ListenerService) unhostAll(id uuid.UUID) {
+	_, server, err := ls.hostingServer(id)
+	if err != nil {
+		return
+	}
+	for _, file := range httpServer.Hosted(server) {
+		_ = httpServer.Unhost(server, file.URI)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	httpServer "github.com/Ne0nd0g/merlin/v2/pkg/servers/http"
+)
+
+// TestHostFile verifies files are only hosted on HTTP listeners at URIs that aren't used for Agent traffic or health
+// checks, and that a removed listener's hosted files are removed with it
+func TestHostFile(t *testing.T) {
+	ls := newListenerService(t)
+	options, err := ls.DefaultOptions("http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options["Name"] = "hosting"
+	options["Interface"] = "127.0.0.1"
+	options["Port"] = "0"
+	options["URLS"] = "/,/news"
+	options["HealthURI"] = "/health"
+	listener, err := ls.NewListener(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { removeListener(ls, listener) })
+
+	tcpOptions, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcpOptions["Name"] = "not hosting"
+	tcp, err := ls.NewListener(tcpOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { removeListener(ls, tcp) })
+
+	tests := []struct {
+		name     string
+		listener uuid.UUID
+		file     httpServer.HostedFile
+		err      bool
+	}{
+		{"hosted", listener.ID(), httpServer.HostedFile{URI: "/update.exe", Name: "update.exe"}, false},
+		{"expires", listener.ID(), httpServer.HostedFile{URI: "/later", Name: "later", Expires: time.Now().Add(time.Hour)}, false},
+		{"agent URI", listener.ID(), httpServer.HostedFile{URI: "/news", Name: "news"}, true},
+		{"health URI", listener.ID(), httpServer.HostedFile{URI: "/health", Name: "health"}, true},
+		{"already expired", listener.ID(), httpServer.HostedFile{URI: "/old", Name: "old", Expires: time.Now().Add(-time.Hour)}, true},
+		{"tcp listener", tcp.ID(), httpServer.HostedFile{URI: "/file", Name: "file"}, true},
+		{"unknown listener", uuid.New(), httpServer.HostedFile{URI: "/file", Name: "file"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err = ls.HostFile(test.listener, test.file, []byte("MZ"))
+			if (err != nil) != test.err {
+				t.Errorf("expected error %t, have %v", test.err, err)
+			}
+		})
+	}
+
+	files, err := ls.HostedFiles(listener.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 hosted files, have %+v", files)
+	}
+	if err = ls.UnhostFile(listener.ID(), "/later"); err != nil {
+		t.Fatal(err)
+	}
+	ls.unhostAll(listener.ID())
+	if files, _ = ls.HostedFiles(listener.ID()); len(files) != 0 {
+		t.Errorf("expected the listener's hosted files to be removed, have %+v", files)
+	}
+}
@@ -23,15 +23,21 @@ package listeners
 
 import (
 	// Standard
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	// 3rd Party
 	"github.com/google/uuid"
 
 	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	memoryMessage "github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
 	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
 	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/http"
 	httpMemory "github.com/Ne0nd0g/merlin/v2/pkg/listeners/http/memory"
@@ -46,6 +52,38 @@ import (
 	httpServerRepo "github.com/Ne0nd0g/merlin/v2/pkg/servers/http/memory"
 )
 
+const (
+	// resolveTimeout is the longest a listener's hostname can take to resolve when it is started
+	resolveTimeout = 10 * time.Second
+	// startTimeout is how long a started server is watched for errors, such as an invalid x.509 certificate, that are
+	// returned to the caller instead of only being logged
+	startTimeout = 500 * time.Millisecond
+	// oneShotGrace is how long a one-shot listener keeps serving the Agent that authenticated to it, so it can receive
+	// its first jobs, such as switching to another listener, before the listener is removed
+	oneShotGrace = time.Minute
+)
+
+var (
+	// ErrListenerNotFound is returned when a listener with the provided ID or name does not exist
+	ErrListenerNotFound = errors.New("the listener was not found")
+	// ErrDuplicateName is returned when a listener is created or renamed with a name another listener already uses
+	ErrDuplicateName = errors.New("a listener with that name already exists")
+	// ErrInvalidOption is returned when a listener option is unknown or its value is invalid
+	ErrInvalidOption = errors.New("invalid listener option")
+	// ErrAmbiguousID is returned when a partial listener ID matches more than one listener
+	ErrAmbiguousID = errors.New("the partial listener ID matches more than one listener")
+)
+
+// expiryTimers contains the timer that removes each listener with an Expiration option
+var expiryTimers = struct {
+	sync.Mutex
+	timers map[uuid.UUID]*time.Timer
+}{timers: make(map[uuid.UUID]*time.Timer)}
+
+// registerHandlers ensures the handlers that remove one-shot listeners and create pivot listeners are only registered
+// once
+var registerHandlers sync.Once
+
 // ListenerService is a structure that implements the service methods holding references to Listener & Server repositories
 type ListenerService struct {
 	httpRepo       http.Repository
@@ -53,6 +91,7 @@ type ListenerService struct {
 	smbRepo        smb.Repository
 	tcpRepo        tcp.Repository
 	udpRepo        udp.Repository
+	messageRepo    message.Repository
 }
 
 // NewListenerService is a factory to create and return a ListenerService
@@ -62,6 +101,14 @@ func NewListenerService() (ls ListenerService) {
 	ls.smbRepo = WithSMBMemoryListenerRepository()
 	ls.tcpRepo = WithTCPMemoryListenerRepository()
 	ls.udpRepo = WithUDPMemoryListenerRepository()
+	ls.messageRepo = withMemoryClientMessageRepository()
+	registerHandlers.Do(func() {
+		service := ls
+		listeners.OnExpire(func(id uuid.UUID) {
+			service.expireAfter(id, oneShotGrace, "an Agent authenticated to the one-shot listener")
+		})
+		listeners.OnDeploy(service.pivot)
+	})
 	return
 }
 
@@ -89,12 +136,32 @@ func WithUDPMemoryListenerRepository() udp.Repository {
 	return udpMemory.NewRepository()
 }
 
+// withMemoryClientMessageRepository retrieves an in-memory repository used to send messages to clients
+func withMemoryClientMessageRepository() message.Repository {
+	return memoryMessage.NewRepository()
+}
+
 // NewListener is a factory that takes in a map of options used to configure a Listener, adds the Listener to its
-// respective repository, and returns a copy created Listener object
-func (ls *ListenerService) NewListener(options map[string]string) (listener listeners.Listener, er error) {
-	// Determine the infrastructure layer server
-	if _, ok := options["Protocol"]; !ok {
-		return nil, fmt.Errorf("pkg/services/listeners.NewListener(): the options map did not contain the \"Protocol\" key")
+// respective repository, and returns a copy created Listener object. A Listener with an Expiration option is
+// automatically stopped and removed when it expires
+func (ls *ListenerService) NewListener(options map[string]string) (listeners.Listener, error) {
+	listener, err := ls.newListener(options)
+	if err != nil {
+		return nil, err
+	}
+	err = ls.expiration(listener.ID(), options["Expiration"])
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
+	}
+	return listener, nil
+}
+
+// newListener creates the Listener for the protocol in the options map and adds it to its respective repository
+func (ls *ListenerService) newListener(options map[string]string) (listener listeners.Listener, er error) {
+	// Validate the options before creating anything
+	err := ls.validate(options)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 	}
 
 	switch strings.ToLower(options["Protocol"]) {
@@ -102,21 +169,27 @@ func (ls *ListenerService) NewListener(options map[string]string) (listener list
 	case "http", "https", "h2c", "http2", "http3":
 		hServer, err := httpServer.New(options)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
+		}
+		// Make sure the address is available now because Start() runs the server in a go routine where a bind error
+		// is only logged. This also selects the port when it is 0 or a range
+		err = hServer.Reserve()
+		if err != nil {
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		err = ls.httpServerRepo.Add(hServer)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		// Create a new HTTP Listener
 		hListener, err := http.NewHTTPListener(&hServer, options)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		// Store the HTTP Listener
 		err = ls.httpRepo.Add(hListener)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		slog.Info("Create new listener", "protocol", hServer.ProtocolString(), "address", hServer.Addr(), "name", hListener.Name(), "id", hListener.ID(), "authenticator", hListener.Authenticator().String(), "transforms", fmt.Sprintf("%+v", hListener.Transformers()))
 		listener = &hListener
@@ -125,12 +198,12 @@ func (ls *ListenerService) NewListener(options map[string]string) (listener list
 		// Create a new SMB Listener
 		sListener, err := smb.NewSMBListener(options)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		// Store the SMB Listener
 		err = ls.smbRepo.Add(sListener)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		slog.Info("Create new listener", "protocol", options["Protocol"], "address", sListener.Addr(), "name", sListener.Name(), "id", sListener.ID(), "authenticator", sListener.Authenticator().String(), "transforms", fmt.Sprintf("%+v", sListener.Transformers()))
 		listener = &sListener
@@ -139,12 +212,12 @@ func (ls *ListenerService) NewListener(options map[string]string) (listener list
 		// Create a new TCP Listener
 		tListener, err := tcp.NewTCPListener(options)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		// Store the TCP Listener
 		err = ls.tcpRepo.Add(tListener)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		slog.Info("Create new listener", "protocol", options["Protocol"], "address", tListener.Addr(), "name", tListener.Name(), "id", tListener.ID(), "authenticator", tListener.Authenticator().String(), "transforms", fmt.Sprintf("%+v", tListener.Transformers()))
 		listener = &tListener
@@ -152,12 +225,12 @@ func (ls *ListenerService) NewListener(options map[string]string) (listener list
 	case "udp":
 		uListener, err := udp.NewUDPListener(options)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		// Store the TCP Listener
 		err = ls.udpRepo.Add(uListener)
 		if err != nil {
-			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %s", err)
+			return nil, fmt.Errorf("pkg/services/listeners.NewListener(): %w", err)
 		}
 		slog.Info("Create new listener", "protocol", options["Protocol"], "address", uListener.Addr(), "name", uListener.Name(), "id", uListener.ID(), "authenticator", uListener.Authenticator().String(), "transforms", fmt.Sprintf("%+v", uListener.Transformers()))
 		listener = &uListener
@@ -167,6 +240,26 @@ func (ls *ListenerService) NewListener(options map[string]string) (listener list
 	}
 }
 
+// validate checks the options against the protocol's schema and that the Listener's name is not already in use
+func (ls *ListenerService) validate(options map[string]string) error {
+	// Determine the infrastructure layer server
+	if _, ok := options["Protocol"]; !ok {
+		return fmt.Errorf("%w: the options map did not contain the \"Protocol\" key", ErrInvalidOption)
+	}
+	schema, err := ls.Schema(options["Protocol"])
+	if err != nil {
+		return err
+	}
+	err = schema.ValidateAll(options)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidOption, err)
+	}
+	if ls.nameInUse(options["Name"], uuid.Nil) {
+		return fmt.Errorf("%w: %s", ErrDuplicateName, options["Name"])
+	}
+	return nil
+}
+
 // CLICompleter returns a list of Listener & Server types that Merlin supports for CLI tab completion
 func (ls *ListenerService) CLICompleter() func(string) []string {
 	return func(line string) []string {
@@ -189,29 +282,12 @@ func (ls *ListenerService) CLICompleter() func(string) []string {
 
 // DefaultOptions gets the default configurable options for both the listener and the infrastructure layer server (if applicable)
 func (ls *ListenerService) DefaultOptions(protocol string) (options map[string]string, err error) {
-	var listenerOptions map[string]string
-	var serverOptions map[string]string
-	switch listeners.FromString(protocol) {
-	case listeners.HTTP:
-		// Listener options
-		listenerOptions = http.DefaultOptions()
-		// Server, infrastructure layer, options
-		serverOptions = httpServer.GetDefaultOptions(servers.FromString(protocol))
-	case listeners.SMB:
-		listenerOptions = smb.DefaultOptions()
-	case listeners.TCP:
-		listenerOptions = tcp.DefaultOptions()
-	case listeners.UDP:
-		listenerOptions = udp.DefaultOptions()
-	default:
-		err = fmt.Errorf("pkg/services/listeners.DefaultOptions(): unhandled server type: %s", protocol)
+	schema, err := ls.Schema(protocol)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/listeners.DefaultOptions(): %w", err)
 		return
 	}
-
-	// Add Server options (if any) to Listener options
-	for k, v := range serverOptions {
-		listenerOptions[k] = v
-	}
+	listenerOptions := schema.Defaults()
 
 	// Sort the keys
 	var keys []string
@@ -227,6 +303,42 @@ func (ls *ListenerService) DefaultOptions(protocol string) (options map[string]s
 	return
 }
 
+// Drain stops the Listener from accepting new Agent authentications while it continues to serve Agents that have
+// already authenticated. Infrastructure can be rotated by draining the old Listener, bringing up a new one, and
+// re-pointing the Agents without dropping their sessions
+func (ls *ListenerService) Drain(id uuid.UUID) error {
+	_, err := ls.Listener(id)
+	if err != nil {
+		return fmt.Errorf("pkg/services/listeners.Drain(): %w", err)
+	}
+	listeners.Drain(id, true)
+	return nil
+}
+
+// Schema returns the type, default value, and validation for every configurable option of the listener protocol.
+// HTTP listeners include the options for their infrastructure layer server
+func (ls *ListenerService) Schema(protocol string) (listeners.Schema, error) {
+	switch listeners.FromString(protocol) {
+	case listeners.HTTP:
+		// Listener options followed by the Server, infrastructure layer, options
+		var schema listeners.Schema
+		for _, option := range listeners.Common("HTTP") {
+			if option.Name != "ID" {
+				schema = append(schema, option)
+			}
+		}
+		return append(schema, httpServer.Schema(servers.FromString(protocol))...), nil
+	case listeners.SMB:
+		return smb.Schema(), nil
+	case listeners.TCP:
+		return tcp.Schema(), nil
+	case listeners.UDP:
+		return udp.Schema(), nil
+	default:
+		return nil, fmt.Errorf("pkg/services/listeners.Schema(): %w: unhandled server type: %s", ErrInvalidOption, protocol)
+	}
+}
+
 // List returns a list of Listener names that exist and is used for command line tab completion
 func (ls *ListenerService) List() func(string) []string {
 	return func(line string) []string {
@@ -252,7 +364,7 @@ func (ls *ListenerService) Listener(id uuid.UUID) (listeners.Listener, error) {
 	if err == nil {
 		return &udpListener, nil
 	}
-	return nil, fmt.Errorf("pkg/services/listeners.GetListenerByID(): could not find listener %s", id)
+	return nil, fmt.Errorf("pkg/services/listeners.GetListenerByID(): %w: %s", ErrListenerNotFound, id)
 }
 
 // Listeners returns a list of stored Listener objects
@@ -337,7 +449,46 @@ func (ls *ListenerService) ListenerByName(name string) (listeners.Listener, erro
 	if err == nil {
 		return &udpListener, err
 	}
-	return nil, fmt.Errorf("pkg/services/listeners.GetListenerByName(): %s", err)
+	return nil, fmt.Errorf("pkg/services/listeners.GetListenerByName(): %w: %s", ErrListenerNotFound, err)
+}
+
+// Resolve returns the ID of the listener with the provided ID, name, or unambiguous prefix of its ID (e.g., 4f2a).
+// ErrAmbiguousID is returned, with the matching listeners, if the prefix matches more than one listener
+func (ls *ListenerService) Resolve(listener string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(listener); err == nil {
+		return id, nil
+	}
+	prefix := strings.ToLower(listener)
+	var candidates []string
+	var match uuid.UUID
+	for _, l := range ls.Listeners() {
+		if l.Name() == listener {
+			return l.ID(), nil
+		}
+		if prefix != "" && strings.Trim(prefix, "0123456789abcdef-") == "" && strings.HasPrefix(l.ID().String(), prefix) {
+			match = l.ID()
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", l.ID(), l.Name()))
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("pkg/services/listeners.Resolve(): %w: %s", ErrListenerNotFound, listener)
+	case 1:
+		return match, nil
+	default:
+		sort.Strings(candidates)
+		return uuid.Nil, fmt.Errorf("pkg/services/listeners.Resolve(): %w: '%s' matches %s", ErrAmbiguousID, listener, strings.Join(candidates, ", "))
+	}
+}
+
+// nameInUse determines if a listener, other than the one with the provided ID, already uses the name
+func (ls *ListenerService) nameInUse(name string, id uuid.UUID) bool {
+	for _, listener := range ls.Listeners() {
+		if listener.Name() == name && listener.ID() != id {
+			return true
+		}
+	}
+	return false
 }
 
 // ListenersByType returns a list of all stored listeners for the provided listener
@@ -373,12 +524,18 @@ func (ls *ListenerService) Remove(id uuid.UUID) error {
 	if err != nil {
 		return err
 	}
-	// Stop the server before removing it but don't handle any errors
-	server := *listener.Server()
-	err = server.Stop()
-	if err != nil {
-		return err
+	ls.stopExpiry(id)
+	listeners.RemovePivot(id)
+	ls.unhostAll(id)
+	// Stop the server before removing it; only HTTP listeners have one
+	if listener.Protocol() == listeners.HTTP {
+		server := *listener.Server()
+		err = server.Stop()
+		if err != nil {
+			return err
+		}
 	}
+	listeners.Drain(id, false)
 	switch listener.Protocol() {
 	case listeners.HTTP:
 		return ls.httpRepo.RemoveByID(id)
@@ -393,19 +550,23 @@ func (ls *ListenerService) Remove(id uuid.UUID) error {
 	}
 }
 
-// Restart terminates a Listener's embedded Server object (if applicable) and then starts it again
-func (ls *ListenerService) Restart(id uuid.UUID) error {
+// Restart terminates a Listener's embedded Server object (if applicable) and then starts it again.
+// The restarted server runs until it is stopped or the context is cancelled
+func (ls *ListenerService) Restart(ctx context.Context, id uuid.UUID) error {
 	// Get the listener
 	listener, err := ls.Listener(id)
 	if err != nil {
-		return fmt.Errorf("pkg/services/listeners.Restart(): %s", err)
+		return fmt.Errorf("pkg/services/listeners.Restart(): %w", err)
 	}
 	server := *listener.Server()
 	err = server.Stop()
 	if err != nil {
-		return fmt.Errorf("pkg/services/listeners.Restart(): %s", err)
+		return fmt.Errorf("pkg/services/listeners.Restart(): %w", err)
+	}
+	err = ls.serve(ctx, listener)
+	if err != nil {
+		return fmt.Errorf("pkg/services/listeners.Restart(): %w", err)
 	}
-	go server.Start()
 	return nil
 }
 
@@ -415,6 +576,27 @@ func (ls *ListenerService) SetOption(id uuid.UUID, option, value string) error {
 	if err != nil {
 		return err
 	}
+
+	// Reject invalid values immediately instead of failing when the listener is started
+	protocol := listeners.String(listener.Protocol())
+	if listener.Protocol() == listeners.HTTP {
+		protocol = listener.ConfiguredOptions()["Protocol"]
+	}
+	schema, err := ls.Schema(protocol)
+	if err != nil {
+		return fmt.Errorf("pkg/services/listeners.SetOption(): %w", err)
+	}
+	err = schema.Validate(option, value)
+	if err != nil {
+		return fmt.Errorf("pkg/services/listeners.SetOption(): %w: %s", ErrInvalidOption, err)
+	}
+	if strings.EqualFold(option, "name") && ls.nameInUse(value, id) {
+		return fmt.Errorf("pkg/services/listeners.SetOption(): %w: %s", ErrDuplicateName, value)
+	}
+	// The expiration is tracked by the service for every listener type
+	if strings.EqualFold(option, "expiration") {
+		return ls.expiration(id, value)
+	}
 	switch listener.Protocol() {
 	case listeners.HTTP:
 		return ls.httpRepo.SetOption(id, option, value)
@@ -429,41 +611,93 @@ func (ls *ListenerService) SetOption(id uuid.UUID, option, value string) error {
 	}
 }
 
-// Start initiates the Listener's embedded Server object (if applicable) to start listening and responding to Agent communications
-func (ls *ListenerService) Start(id uuid.UUID) error {
+// Start initiates the Listener's embedded Server object (if applicable) to start listening and responding to Agent communications.
+// The server runs until it is stopped or the context is cancelled
+func (ls *ListenerService) Start(ctx context.Context, id uuid.UUID) error {
 	// Get the listener
 	listener, err := ls.Listener(id)
 	if err != nil {
-		return fmt.Errorf("pkg/services/listeners.Start(): %s", err)
+		return fmt.Errorf("pkg/services/listeners.Start(): %w", err)
 	}
+	// Starting a Listener resumes accepting new Agent authentications
+	listeners.Drain(id, false)
 	switch listener.Protocol() {
 	case listeners.HTTP:
-		server := *listener.Server()
-		err = server.Listen()
+		err = ls.serve(ctx, listener)
 		if err != nil {
-			return err
+			return fmt.Errorf("pkg/services/listeners.Start(): %w", err)
 		}
-		// Start() does not return until the transport server is killed and therefore must be run in a go routine
-		go server.Start()
 		return nil
 	case listeners.SMB:
 		return nil
 	case listeners.TCP:
-		// Nothing to do, there is not an infrastructure layer server to start for the TCP listener
-		return nil
+		// There is not an infrastructure layer server to start for the TCP listener, only resolve the bind address
+		tcpListener, err := ls.tcpRepo.ListenerByID(id)
+		if err != nil {
+			return fmt.Errorf("pkg/services/listeners.Start(): %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+		defer cancel()
+		err = tcpListener.Resolve(ctx)
+		if err != nil {
+			return fmt.Errorf("pkg/services/listeners.Start(): %w", err)
+		}
+		return ls.tcpRepo.Update(tcpListener)
 	case listeners.UDP:
-		return nil
+		udpListener, err := ls.udpRepo.ListenerByID(id)
+		if err != nil {
+			return fmt.Errorf("pkg/services/listeners.Start(): %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+		defer cancel()
+		err = udpListener.Resolve(ctx)
+		if err != nil {
+			return fmt.Errorf("pkg/services/listeners.Start(): %w", err)
+		}
+		return ls.udpRepo.Update(udpListener)
 	default:
 		return fmt.Errorf("pkg/services/listeners.Start(): unhandled listener protocol: %d", listener.Protocol())
 	}
 }
 
+// serve creates the Listener's embedded Server network listener and runs the server in a go routine.
+// Errors that stop the server within the startTimeout are returned; later errors are sent to clients as messages and
+// are reflected in the server's Status()
+func (ls *ListenerService) serve(ctx context.Context, listener listeners.Listener) error {
+	server := *listener.Server()
+	err := server.Listen()
+	if err != nil {
+		return err
+	}
+	stopped := server.Stopped()
+	// Start() does not return until the transport server is killed and therefore must be run in a go routine
+	go server.Start(ctx)
+
+	select {
+	case err = <-stopped:
+		return err
+	case <-time.After(startTimeout):
+	}
+
+	go func() {
+		select {
+		case err := <-stopped:
+			if err != nil {
+				msg := fmt.Sprintf("The '%s' listener's %s server on %s stopped with an error: %s", listener.Name(), server.ProtocolString(), server.Addr(), err)
+				ls.messageRepo.Add(message.NewMessage(message.Warn, msg))
+			}
+		case <-ctx.Done():
+		}
+	}()
+	return nil
+}
+
 // Stop terminates the Listener's embedded Server object (if applicable) to stop it listening for incoming Agent messages
 func (ls *ListenerService) Stop(id uuid.UUID) error {
 	// Get the listener
 	listener, err := ls.Listener(id)
 	if err != nil {
-		return fmt.Errorf("pkg/services/listeners.Restart(): %s", err)
+		return fmt.Errorf("pkg/services/listeners.Restart(): %w", err)
 	}
 	if listener.Protocol() == listeners.HTTP {
 		server := *listener.Server()
@@ -471,3 +705,55 @@ func (ls *ListenerService) Stop(id uuid.UUID) error {
 	}
 	return nil
 }
+
+// expiration schedules the Listener to be stopped and removed when the Expiration option value expires, replacing any
+// previous expiration. An empty value never expires
+func (ls *ListenerService) expiration(id uuid.UUID, value string) error {
+	expiration, err := listeners.ParseExpiration(value)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidOption, err)
+	}
+	ls.stopExpiry(id)
+	listeners.SetExpiration(id, expiration)
+	if !expiration.At.IsZero() {
+		ls.expireAfter(id, time.Until(expiration.At), "its expiration time was reached")
+	}
+	return nil
+}
+
+// expireAfter stops and removes the Listener after the delay and notifies clients why it was removed
+func (ls *ListenerService) expireAfter(id uuid.UUID, delay time.Duration, reason string) {
+	expiryTimers.Lock()
+	defer expiryTimers.Unlock()
+	if timer, ok := expiryTimers.timers[id]; ok {
+		timer.Stop()
+	}
+	expiryTimers.timers[id] = time.AfterFunc(delay, func() {
+		listener, err := ls.Listener(id)
+		if err != nil {
+			return
+		}
+		name := listener.Name()
+		err = ls.Remove(id)
+		if err != nil {
+			msg := fmt.Sprintf("There was an error removing the expired '%s' listener: %s", name, err)
+			slog.Error(msg, "listener", id)
+			ls.messageRepo.Add(message.NewMessage(message.Warn, msg))
+			return
+		}
+		msg := fmt.Sprintf("Stopped and removed the '%s' listener because %s", name, reason)
+		slog.Info(msg, "listener", id)
+		ls.messageRepo.Add(message.NewMessage(message.Info, msg))
+	})
+}
+
+// stopExpiry cancels the Listener's pending expiration
+func (ls *ListenerService) stopExpiry(id uuid.UUID) {
+	expiryTimers.Lock()
+	defer expiryTimers.Unlock()
+	if timer, ok := expiryTimers.timers[id]; ok {
+		timer.Stop()
+		delete(expiryTimers.timers, id)
+	}
+	listeners.SetExpiration(id, listeners.Expiration{})
+}
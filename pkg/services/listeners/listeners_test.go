@@ -0,0 +1,403 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+)
+
+// TestSchemaDefaults verifies the default options for every listener protocol pass their own validation
+func TestSchemaDefaults(t *testing.T) {
+	ls := newListenerService(t)
+	for _, protocol := range []string{"http", "https", "h2c", "http2", "http3", "smb", "tcp", "udp"} {
+		t.Run(protocol, func(t *testing.T) {
+			options, err := ls.DefaultOptions(protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schema, err := ls.Schema(protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = schema.ValidateAll(options)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	if _, err := ls.Schema("ftp"); err == nil {
+		t.Error("expected an error for an unhandled protocol")
+	}
+}
+
+// TestSetOptionCoverage verifies every option in a listener protocol's schema can be set except for those that can
+// only be provided when the listener is created
+func TestSetOptionCoverage(t *testing.T) {
+	ls := newListenerService(t)
+	for _, protocol := range []string{"http", "https", "h2c", "http2", "http3", "smb", "tcp", "udp"} {
+		t.Run(protocol, func(t *testing.T) {
+			options, err := ls.DefaultOptions(protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			options["Name"] = "coverage " + protocol
+			listener, err := ls.NewListener(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { removeListener(ls, listener) })
+
+			schema, err := ls.Schema(protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, option := range schema {
+				err = ls.SetOption(listener.ID(), option.Name, options[option.Name])
+				switch option.Name {
+				case "ID", "Protocol":
+					if err == nil {
+						t.Errorf("expected an error changing the %s option", option.Name)
+					}
+				default:
+					if err != nil {
+						t.Errorf("unable to set the %s option: %s", option.Name, err)
+					}
+				}
+			}
+
+			err = ls.SetOption(listener.ID(), "Transfroms", "jwe")
+			if err == nil || !strings.Contains(err.Error(), "Transforms") {
+				t.Errorf("expected the misspelled option to suggest Transforms, have %v", err)
+			}
+		})
+	}
+}
+
+// newListenerService returns a ListenerService whose listeners write their data, such as the OPAQUE server key, to a
+// temporary directory
+func newListenerService(t *testing.T) ListenerService {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+	return NewListenerService()
+}
+
+// removeListener deletes the listener from its repository without stopping a server that was never started
+func removeListener(ls ListenerService, listener listeners.Listener) {
+	switch listener.Protocol() {
+	case listeners.HTTP:
+		_ = ls.httpRepo.RemoveByID(listener.ID())
+	case listeners.SMB:
+		_ = ls.smbRepo.RemoveByID(listener.ID())
+	case listeners.TCP:
+		_ = ls.tcpRepo.RemoveByID(listener.ID())
+	case listeners.UDP:
+		_ = ls.udpRepo.RemoveByID(listener.ID())
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	ls := newListenerService(t)
+	options, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options["Name"] = "typed errors"
+	listener, err := ls.NewListener(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { removeListener(ls, listener) })
+
+	invalid, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid["Port"] = "99999"
+
+	tests := []struct {
+		name string
+		fn   func() error
+		want error
+	}{
+		{"duplicate name", func() error { _, err := ls.NewListener(options); return err }, ErrDuplicateName},
+		{"invalid option value", func() error { _, err := ls.NewListener(invalid); return err }, ErrInvalidOption},
+		{"missing protocol", func() error { _, err := ls.NewListener(map[string]string{}); return err }, ErrInvalidOption},
+		{"unknown option", func() error { return ls.SetOption(listener.ID(), "Color", "blue") }, ErrInvalidOption},
+		{"not found", func() error { _, err := ls.Listener(uuid.New()); return err }, ErrListenerNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.fn(); !errors.Is(err, test.want) {
+				t.Errorf("expected %q, have %v", test.want, err)
+			}
+		})
+	}
+}
+
+// TestStartResolve verifies starting a TCP or UDP listener resolves its hostname and reports the resolved address
+func TestStartResolve(t *testing.T) {
+	tests := []struct {
+		protocol string
+		iface    string
+		resolved string
+		err      bool
+	}{
+		{"tcp", "localhost", "127.0.0.1", false},
+		{"udp", "localhost", "127.0.0.1", false},
+		{"tcp", "127.0.0.1", "", false},
+		{"tcp", "merlin.invalid", "", true},
+	}
+	ls := newListenerService(t)
+	for _, test := range tests {
+		t.Run(test.protocol+" "+test.iface, func(t *testing.T) {
+			options, err := ls.DefaultOptions(test.protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			options["Name"] = "resolve " + test.protocol + " " + test.iface
+			options["Interface"] = test.iface
+			listener, err := ls.NewListener(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { removeListener(ls, listener) })
+
+			err = ls.Start(context.Background(), listener.ID())
+			if test.err {
+				if err == nil {
+					t.Error("expected an error resolving the listener's interface")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			started, err := ls.Listener(listener.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			configured := started.ConfiguredOptions()
+			if configured["Interface"] != test.iface {
+				t.Errorf("expected the configured interface to be %s, have %s", test.iface, configured["Interface"])
+			}
+			if configured["ResolvedInterface"] != test.resolved {
+				t.Errorf("expected the resolved interface to be %q, have %q", test.resolved, configured["ResolvedInterface"])
+			}
+		})
+	}
+}
+
+// TestNewListenerAddressInUse verifies an HTTP listener is not created when its address is already in use
+func TestNewListenerAddressInUse(t *testing.T) {
+	used, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer used.Close()
+
+	ls := newListenerService(t)
+	options, err := ls.DefaultOptions("http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options["Name"] = "address in use"
+	options["Interface"] = "127.0.0.1"
+	options["Port"] = strconv.Itoa(used.Addr().(*net.TCPAddr).Port)
+	listener, err := ls.NewListener(options)
+	if err == nil {
+		removeListener(ls, listener)
+		t.Fatal("expected an error creating a listener on an address that is in use")
+	}
+	if ls.nameInUse(options["Name"], uuid.Nil) {
+		t.Error("expected the listener to not be stored")
+	}
+}
+
+// TestExpiration verifies a listener is removed when its expiration time is reached, that setting the option again
+// reschedules or clears the expiration, and that a one-shot listener is drained once an Agent authenticates
+func TestExpiration(t *testing.T) {
+	ls := newListenerService(t)
+	tests := []struct {
+		name       string
+		expiration string
+		update     string
+		removed    bool
+		tracked    bool
+	}{
+		{"expires", "50ms", "", true, false},
+		{"cleared", "50ms", " ", false, false},
+		{"rescheduled", "50ms", "1h", false, true},
+		{"once", listeners.ExpireOnce, "", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := ls.DefaultOptions("tcp")
+			if err != nil {
+				t.Fatal(err)
+			}
+			options["Name"] = "expiration " + test.name
+			options["Expiration"] = test.expiration
+			listener, err := ls.NewListener(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				ls.stopExpiry(listener.ID())
+				listeners.Drain(listener.ID(), false)
+				removeListener(ls, listener)
+			})
+			if test.update != "" {
+				err = ls.SetOption(listener.ID(), "Expiration", test.update)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			time.Sleep(200 * time.Millisecond)
+			_, err = ls.Listener(listener.ID())
+			if removed := errors.Is(err, ErrListenerNotFound); removed != test.removed {
+				t.Fatalf("expected the listener to be removed %t, have %v", test.removed, err)
+			}
+			if _, ok := listeners.GetExpiration(listener.ID()); ok != test.tracked {
+				t.Errorf("expected the expiration to be tracked %t, have %t", test.tracked, ok)
+			}
+		})
+	}
+
+	if err := ls.SetOption(uuid.New(), "Expiration", "1h"); err == nil {
+		t.Error("expected an error setting the expiration of an unknown listener")
+	}
+}
+
+// TestOneShot verifies an Agent authenticating to a one-shot listener drains it and schedules its removal
+func TestOneShot(t *testing.T) {
+	ls := newListenerService(t)
+	options, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options["Name"] = "one-shot"
+	options["Expiration"] = listeners.ExpireOnce
+	listener, err := ls.NewListener(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ls.stopExpiry(listener.ID())
+		listeners.Drain(listener.ID(), false)
+		removeListener(ls, listener)
+	})
+
+	listeners.Authenticated(listener.ID())
+	if !listeners.Draining(listener.ID()) {
+		t.Error("expected the one-shot listener to stop accepting new authentications")
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		expiryTimers.Lock()
+		_, scheduled := expiryTimers.timers[listener.ID()]
+		expiryTimers.Unlock()
+		if scheduled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the one-shot listener's removal to be scheduled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err = ls.Listener(listener.ID()); err != nil {
+		t.Errorf("expected the one-shot listener to keep serving the staged Agent, have %v", err)
+	}
+}
+
+// TestResolve verifies listeners are resolved by ID, name, or unambiguous ID prefix and ambiguous prefixes are rejected
+func TestResolve(t *testing.T) {
+	ls := newListenerService(t)
+	// Create listeners until two share the first character of their ID so that prefix has more than one match
+	first := make(map[byte]listeners.Listener)
+	var a, b listeners.Listener
+	for i := 0; b == nil; i++ {
+		options, err := ls.DefaultOptions("tcp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		options["Name"] = "resolve " + strconv.Itoa(i)
+		options["Port"] = strconv.Itoa(7000 + i)
+		listener, err := ls.NewListener(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { removeListener(ls, listener) })
+		if other, ok := first[listener.ID().String()[0]]; ok {
+			a, b = other, listener
+		}
+		first[listener.ID().String()[0]] = listener
+	}
+	unknown := uuid.New()
+	tests := []struct {
+		name     string
+		listener string
+		want     uuid.UUID
+		err      error
+	}{
+		{"ID", a.ID().String(), a.ID(), nil},
+		{"unknown ID", unknown.String(), unknown, nil},
+		{"name", b.Name(), b.ID(), nil},
+		{"prefix", a.ID().String()[:13], a.ID(), nil},
+		{"uppercase prefix", strings.ToUpper(b.ID().String()[:13]), b.ID(), nil},
+		{"ambiguous prefix", a.ID().String()[:1], uuid.Nil, ErrAmbiguousID},
+		{"not a prefix", "resolve", uuid.Nil, ErrListenerNotFound},
+		{"empty", "", uuid.Nil, ErrListenerNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			have, err := ls.Resolve(test.listener)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, have %v", test.err, err)
+			}
+			if have != test.want {
+				t.Errorf("expected %s, have %s", test.want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"fmt"
+	"net"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+)
+
+// pivot returns the ID of the Listener that handles a bind Agent deployed with lateral movement. An existing Listener
+// for the same protocol and named pipe or port is reused; otherwise, a Listener is created that copies the PSK,
+// transforms, and authenticator of the deploying Agent's Listener so the operator doesn't have to pre-create one
+func (ls *ListenerService) pivot(pivot listeners.Pivot, parent uuid.UUID) (uuid.UUID, error) {
+	protocol := strings.ToLower(pivot.Protocol)
+	var kind int
+	switch protocol {
+	case "smb":
+		kind = listeners.SMB
+	case "tcp":
+		kind = listeners.TCP
+	default:
+		return uuid.Nil, fmt.Errorf("pkg/services/listeners.pivot(): %s bind agents are not supported", pivot.Protocol)
+	}
+
+	for _, listener := range ls.ListenersByType(kind) {
+		if pivotAddress(listener.Addr()) == pivotAddress(pivot.Address) {
+			return listener.ID(), nil
+		}
+	}
+
+	options, err := ls.DefaultOptions(protocol)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/listeners.pivot(): %s", err)
+	}
+	if l, err := ls.Listener(parent); err == nil {
+		configured := l.ConfiguredOptions()
+		for _, option := range []string{"PSK", "Transforms", "Authenticator"} {
+			if value := strings.TrimSuffix(configured[option], ","); value != "" {
+				options[option] = value
+			}
+		}
+	}
+	options["Name"] = fmt.Sprintf("Pivot %s %s", pivot.Host, pivot.Address)
+	options["Description"] = fmt.Sprintf("Created automatically for the %s bind Agent that agent %s deployed to %s", protocol, pivot.Agent, pivot.Host)
+	switch kind {
+	case listeners.SMB:
+		options["Pipe"] = pivot.Address
+	case listeners.TCP:
+		host, port, err := net.SplitHostPort(pivot.Address)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("pkg/services/listeners.pivot(): %s", err)
+		}
+		options["Interface"], options["Port"] = host, port
+	}
+	// A Listener with the same name was created for an earlier deployment to the host
+	if ls.nameInUse(options["Name"], uuid.Nil) {
+		options["Name"] = fmt.Sprintf("%s %s", options["Name"], uuid.NewString()[:8])
+	}
+
+	listener, err := ls.newListener(options)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("pkg/services/listeners.pivot(): %s", err)
+	}
+	ls.messageRepo.Add(message.NewMessage(message.Success, fmt.Sprintf("Created %s listener %s (%s) for the bind agent that agent %s deployed to %s", protocol, listener.Name(), listener.ID(), pivot.Agent, pivot.Host)))
+	return listener.ID(), nil
+}
+
+// pivotAddress returns the comparable part of a named pipe, which can be a name or a full UNC path, or port
+func pivotAddress(address string) string {
+	if _, port, err := net.SplitHostPort(address); err == nil {
+		return port
+	}
+	address = strings.ReplaceAll(address, "/", "\\")
+	if i := strings.LastIndex(address, "\\"); i >= 0 {
+		address = address[i+1:]
+	}
+	return strings.ToLower(address)
+}
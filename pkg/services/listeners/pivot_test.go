@@ -0,0 +1,113 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package listeners
+
+import (
+	// Standard
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+)
+
+// TestPivotAddress verifies named pipes and ports compare the same however the Agent reported them
+func TestPivotAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"MerlinPipe", "merlinpipe"},
+		{"\\\\.\\pipe\\MerlinPipe", "merlinpipe"},
+		{"//wks01/pipe/merlinpipe", "merlinpipe"},
+		{"0.0.0.0:7777", "7777"},
+		{"[::]:7777", "7777"},
+	}
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			if have := pivotAddress(test.address); have != test.want {
+				t.Errorf("expected %q, have %q", test.want, have)
+			}
+		})
+	}
+}
+
+// TestPivot verifies a pivot Listener copies the deploying Agent's Listener settings and is reused for the same named
+// pipe or port
+func TestPivot(t *testing.T) {
+	ls := newListenerService(t)
+	options, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options["Name"] = "pivot parent"
+	options["PSK"] = "pivot-test-psk"
+	parent, err := ls.NewListener(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { removeListener(ls, parent) })
+
+	agent := uuid.New()
+	tests := []struct {
+		name    string
+		pivot   listeners.Pivot
+		reused  bool
+		wantErr bool
+	}{
+		{"smb", listeners.Pivot{Agent: agent, Protocol: "SMB", Host: "wks01", Address: "pivotpipe"}, false, false},
+		{"smb same pipe", listeners.Pivot{Agent: agent, Protocol: "smb", Host: "wks02", Address: "\\\\.\\pipe\\PivotPipe"}, true, false},
+		{"tcp", listeners.Pivot{Agent: agent, Protocol: "tcp", Host: "wks03", Address: "0.0.0.0:17777"}, false, false},
+		{"tcp without port", listeners.Pivot{Agent: agent, Protocol: "tcp", Host: "wks03", Address: "wks03"}, false, true},
+		{"unsupported protocol", listeners.Pivot{Agent: agent, Protocol: "udp", Host: "wks04", Address: "0.0.0.0:17778"}, false, true},
+	}
+	created := make(map[uuid.UUID]bool)
+	// Pivot listeners are removed when the whole test finishes so later cases can reuse them
+	cleanup := t.Cleanup
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, err := ls.pivot(test.pivot, parent.ID())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if created[id] != test.reused {
+				t.Errorf("expected the listener to be reused %t", test.reused)
+			}
+			if created[id] {
+				return
+			}
+			created[id] = true
+			listener, err := ls.Listener(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cleanup(func() { removeListener(ls, listener) })
+			if psk := listener.ConfiguredOptions()["PSK"]; psk != "pivot-test-psk" {
+				t.Errorf("expected the pivot listener to copy the parent's PSK, have %q", psk)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package loot is a service used to store and retrieve data collected from Agents
+package loot
+
+import (
+	// Standard
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/loot"
+	"github.com/Ne0nd0g/merlin/v2/pkg/loot/memory"
+)
+
+// Service holds references to repositories to manage loot collected from Agents
+type Service struct {
+	lootRepo loot.Repository
+}
+
+// memoryService is an in-memory instantiation of the loot service so that it can be used by others
+var memoryService *Service
+
+// NewLootService is a factory to create a loot service to be used by other packages or services
+func NewLootService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			lootRepo: WithLootMemoryRepository(),
+		}
+	}
+	return memoryService
+}
+
+// WithLootMemoryRepository retrieves an in-memory loot repository interface used to manage loot objects
+func WithLootMemoryRepository() loot.Repository {
+	return memory.NewRepository()
+}
+
+// Add writes the collected data to the Agent's loot directory and stores a reference to it in the repository
+func (s *Service) Add(agentID uuid.UUID, jobID string, lootType loot.Type, data []byte, extension string, tags map[string]string) (l loot.Loot, err error) {
+	current, err := os.Getwd()
+	if err != nil {
+		return l, fmt.Errorf("pkg/services/loot.Add(): there was an error getting the current working directory: %s", err)
+	}
+	dir := filepath.Join(current, "data", "agents", agentID.String(), "loot")
+	err = os.MkdirAll(dir, 0750)
+	if err != nil {
+		return l, fmt.Errorf("pkg/services/loot.Add(): there was an error creating the loot directory for agent %s: %s", agentID, err)
+	}
+
+	id := uuid.New()
+	created := time.Now().UTC()
+	file := filepath.Join(dir, fmt.Sprintf("%s_%s_%s.%s",
+		strings.ToLower(lootType.String()),
+		created.Format("20060102T150405Z"),
+		id.String()[:8],
+		strings.TrimPrefix(extension, "."),
+	))
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return l, fmt.Errorf("pkg/services/loot.Add(): there was an error writing loot to %s: %s", file, err)
+	}
+	l = loot.NewLootWithID(id, agentID, jobID, lootType, file, len(data), tags, created)
+	err = s.lootRepo.Add(l)
+	return
+}
+
+// AddClipboard stores clipboard contents returned by the Agent as loot tagged with the SHA256 hash of the contents.
+// Contents that were already collected from the same Agent are not stored again and duplicate is returned as true
+func (s *Service) AddClipboard(agentID uuid.UUID, jobID string, contents string) (l loot.Loot, duplicate bool, err error) {
+	hash := sha256.Sum256([]byte(contents))
+	sum := hex.EncodeToString(hash[:])
+	for _, existing := range s.lootRepo.GetAgent(agentID) {
+		if existing.Type() == loot.CLIPBOARD && existing.Tag("sha256") == sum {
+			return existing, true, nil
+		}
+	}
+	l, err = s.Add(agentID, jobID, loot.CLIPBOARD, []byte(contents), "txt", map[string]string{"sha256": sum})
+	return
+}
+
+// keystrokes is a block of keys captured by the Agent's keylogger while a window had focus
+type keystrokes struct {
+	Window string `json:"window"`
+	Keys   string `json:"keys"`
+}
+
+// AddKeystrokes stores keylogger output as loot tagged by window title.
+// The Agent returns one JSON object per line containing the window title and the keys captured while it had focus.
+// Output that is not in this format is stored without a window title
+func (s *Service) AddKeystrokes(agentID uuid.UUID, jobID string, output string) (added []loot.Loot, err error) {
+	windows := make(map[string]*strings.Builder)
+	var order []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		var k keystrokes
+		if json.Unmarshal([]byte(line), &k) != nil {
+			k = keystrokes{Keys: line + "\n"}
+		}
+		if _, ok := windows[k.Window]; !ok {
+			windows[k.Window] = &strings.Builder{}
+			order = append(order, k.Window)
+		}
+		windows[k.Window].WriteString(k.Keys)
+	}
+
+	for _, window := range order {
+		tags := map[string]string{"window": window}
+		var l loot.Loot
+		l, err = s.Add(agentID, jobID, loot.KEYSTROKES, []byte(windows[window].String()), "txt", tags)
+		if err != nil {
+			return
+		}
+		added = append(added, l)
+	}
+	return
+}
+
+// Agent returns all loot collected from the provided Agent
+func (s *Service) Agent(agentID uuid.UUID) []loot.Loot {
+	return s.lootRepo.GetAgent(agentID)
+}
+
+// All returns all loot collected from every Agent
+func (s *Service) All() []loot.Loot {
+	return s.lootRepo.GetAll()
+}
+
+// Get returns the loot for the provided ID
+func (s *Service) Get(id uuid.UUID) (loot.Loot, error) {
+	return s.lootRepo.Get(id)
+}
@@ -0,0 +1,80 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package loot
+
+import (
+	// Standard
+	"os"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestAddClipboard verifies clipboard contents are only stored once per Agent
+func TestAddClipboard(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	s := NewLootService()
+	agent := uuid.New()
+	other := uuid.New()
+
+	tests := []struct {
+		name      string
+		agent     uuid.UUID
+		contents  string
+		duplicate bool
+	}{
+		{"first", agent, "copied text", false},
+		{"repeated", agent, "copied text", true},
+		{"changed", agent, "other text", false},
+		{"other agent", other, "copied text", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, duplicate, err := s.AddClipboard(test.agent, "job", test.contents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if duplicate != test.duplicate {
+				t.Errorf("expected duplicate to be %t, got %t", test.duplicate, duplicate)
+			}
+			data, err := os.ReadFile(l.File())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.contents {
+				t.Errorf("expected the loot file to contain %q, got %q", test.contents, data)
+			}
+		})
+	}
+	if n := len(s.Agent(agent)); n != 2 {
+		t.Errorf("expected 2 clipboard loot entries for the agent, got %d", n)
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+)
+
+const (
+	// DefaultCaptureDuration is how long a traffic capture runs when a duration isn't provided
+	DefaultCaptureDuration = 5 * time.Minute
+	// MaxCaptureDuration is the longest a traffic capture can run; captures contain decrypted Agent traffic
+	MaxCaptureDuration = time.Hour
+	// inbound messages were sent by the Agent to the server
+	inbound = "inbound"
+	// outbound messages were sent by the server to the Agent
+	outbound = "outbound"
+)
+
+// Capture describes a Listener's traffic capture
+type Capture struct {
+	Listener uuid.UUID // Listener is the ID of the Listener whose traffic is captured
+	File     string    // File is where the captured messages are written
+	Started  time.Time // Started is when the capture started
+	Until    time.Time // Until is when the capture automatically stops
+	Messages int       // Messages is the number of messages captured so far
+}
+
+// capture is an active traffic capture writing to its file
+type capture struct {
+	sync.Mutex
+	info       Capture
+	file       *os.File
+	transforms []string
+	timer      *time.Timer
+	stopped    bool
+}
+
+// captureRecord is one line of the capture file. It pairs the raw data an Agent sent, or was sent, with the Base
+// message the Listener's transforms decoded it into, or the error they returned
+type captureRecord struct {
+	Time       time.Time     `json:"time"`
+	Direction  string        `json:"direction"` // inbound or outbound
+	Agent      uuid.UUID     `json:"agent"`
+	Key        string        `json:"key"` // the key the transforms used: the listener's PSK or the agent's secret
+	Transforms []string      `json:"transforms"`
+	Raw        []byte        `json:"raw"`
+	Decoded    *capturedBase `json:"decoded,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// capturedBase is the decoded Base message with its padding and token summarized. Outbound messages are recorded before
+// the HTTP listener adds the Agent's token, so only inbound messages show if a token was present
+type capturedBase struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Payload   any       `json:"payload,omitempty"`
+	Padding   int       `json:"padding"`
+	Token     bool      `json:"token"`
+	Delegates int       `json:"delegates"`
+}
+
+// captures contains the active traffic capture for each Listener
+var captures = struct {
+	sync.RWMutex
+	listeners map[uuid.UUID]*capture
+}{listeners: make(map[uuid.UUID]*capture)}
+
+// StartCapture writes the Listener's raw and decoded Agent messages to a file in the server's data/captures directory
+// until the duration passes or the capture is stopped
+func StartCapture(listenerID uuid.UUID, duration time.Duration) (Capture, error) {
+	if duration <= 0 || duration > MaxCaptureDuration {
+		return Capture{}, fmt.Errorf("pkg/services/message.StartCapture(): the capture duration must be greater than zero and no more than %s, have %s", MaxCaptureDuration, duration)
+	}
+	l, err := listener(listenerID)
+	if err != nil {
+		return Capture{}, fmt.Errorf("pkg/services/message.StartCapture(): %s", err)
+	}
+
+	captures.Lock()
+	defer captures.Unlock()
+	if c, ok := captures.listeners[listenerID]; ok {
+		return Capture{}, fmt.Errorf("pkg/services/message.StartCapture(): listener %s is already being captured to %s until %s", l.Name(), c.info.File, c.info.Until.Format(time.RFC3339))
+	}
+
+	current, err := os.Getwd()
+	if err != nil {
+		return Capture{}, fmt.Errorf("pkg/services/message.StartCapture(): there was an error getting the current working directory: %s", err)
+	}
+	dir := filepath.Join(current, "data", "captures")
+	err = os.MkdirAll(dir, 0750)
+	if err != nil {
+		return Capture{}, fmt.Errorf("pkg/services/message.StartCapture(): there was an error creating the %s directory: %s", dir, err)
+	}
+	now := time.Now().UTC()
+	name := filepath.Join(dir, fmt.Sprintf("%s_%s.jsonl", listenerID, now.Format("20060102T150405Z")))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600) // #nosec G304 the file name is built by the server
+	if err != nil {
+		return Capture{}, fmt.Errorf("pkg/services/message.StartCapture(): there was an error creating the capture file: %s", err)
+	}
+
+	c := &capture{
+		info: Capture{Listener: listenerID, File: name, Started: now, Until: now.Add(duration)},
+		file: file,
+	}
+	for _, t := range l.Transformers() {
+		c.transforms = append(c.transforms, t.String())
+	}
+	c.timer = time.AfterFunc(duration, func() {
+		info, err := StopCapture(listenerID)
+		if err != nil {
+			return
+		}
+		withClientMessageMemoryRepository().Add(message.NewMessage(message.Note, fmt.Sprintf("The traffic capture for listener %s finished and wrote %d messages to %s", listenerID, info.Messages, info.File)))
+	})
+	captures.listeners[listenerID] = c
+	slog.Info(fmt.Sprintf("Started capturing traffic for listener %s to %s until %s", l.Name(), name, c.info.Until.Format(time.RFC3339)))
+	return c.info, nil
+}
+
+// StopCapture stops the Listener's traffic capture, closes its file, and returns the finished capture
+func StopCapture(listenerID uuid.UUID) (Capture, error) {
+	captures.Lock()
+	c, ok := captures.listeners[listenerID]
+	delete(captures.listeners, listenerID)
+	captures.Unlock()
+	if !ok {
+		return Capture{}, fmt.Errorf("pkg/services/message.StopCapture(): listener %s is not being captured", listenerID)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.stopped = true
+	c.timer.Stop()
+	err := c.file.Close()
+	if err != nil {
+		return c.info, fmt.Errorf("pkg/services/message.StopCapture(): there was an error closing %s: %s", c.info.File, err)
+	}
+	slog.Info(fmt.Sprintf("Stopped capturing traffic for listener %s after %d messages", listenerID, c.info.Messages))
+	return c.info, nil
+}
+
+// Captures returns the active traffic captures
+func Captures() (active []Capture) {
+	captures.RLock()
+	defer captures.RUnlock()
+	for _, c := range captures.listeners {
+		c.Lock()
+		active = append(active, c.info)
+		c.Unlock()
+	}
+	return
+}
+
+// record writes the raw data and the Base message it was transformed from, or into, to the Listener's capture file,
+// if its traffic is being captured. An error means the Listener's transforms failed and msg is not recorded
+func record(listenerID uuid.UUID, direction string, agentID uuid.UUID, key, raw []byte, msg messages.Base, err error) {
+	captures.RLock()
+	c, ok := captures.listeners[listenerID]
+	captures.RUnlock()
+	if !ok {
+		return
+	}
+
+	r := captureRecord{
+		Time:       time.Now().UTC(),
+		Direction:  direction,
+		Agent:      agentID,
+		Key:        "listener",
+		Transforms: c.transforms,
+		Raw:        raw,
+	}
+	if len(key) > 0 {
+		r.Key = "agent"
+	}
+	if err != nil {
+		r.Error = err.Error()
+	} else {
+		r.Decoded = &capturedBase{
+			ID:        msg.ID,
+			Type:      msg.Type.String(),
+			Payload:   msg.Payload,
+			Padding:   len(msg.Padding),
+			Token:     msg.Token != "",
+			Delegates: len(msg.Delegates),
+		}
+	}
+	line, errJSON := json.Marshal(r)
+	if errJSON != nil && r.Decoded != nil {
+		// Keep the record when the payload can't be represented as JSON
+		r.Decoded.Payload = fmt.Sprintf("%+v", msg.Payload)
+		line, errJSON = json.Marshal(r)
+	}
+	if errJSON != nil {
+		slog.Error(fmt.Sprintf("pkg/services/message.record(): there was an error encoding the captured message: %s", errJSON))
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	// The capture was stopped while the record was being built
+	if c.stopped {
+		return
+	}
+	_, errWrite := c.file.Write(append(line, '\n'))
+	if errWrite != nil {
+		slog.Error(fmt.Sprintf("pkg/services/message.record(): there was an error writing to %s: %s", c.info.File, errWrite))
+		return
+	}
+	c.info.Messages++
+}
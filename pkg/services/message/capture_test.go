@@ -0,0 +1,241 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+)
+
+// chdirTemp changes the working directory to a temporary directory, where capture files are written, until the test ends
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+}
+
+// readCapture returns the records written to the capture file
+func readCapture(t *testing.T, file string) (records []captureRecord) {
+	t.Helper()
+	f, err := os.Open(file) // #nosec G304 the file is written by the test
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r captureRecord
+		if err = json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	return
+}
+
+func TestStartCapture(t *testing.T) {
+	chdirTemp(t)
+	s, _, _ := newTCPService(t, "capture", "merlin")
+	listenerID := s.listener.ID()
+	t.Cleanup(func() { _, _ = StopCapture(listenerID) })
+
+	tests := []struct {
+		name     string
+		listener uuid.UUID
+		duration time.Duration
+		wantErr  bool
+	}{
+		{"zero duration", listenerID, 0, true},
+		{"too long", listenerID, MaxCaptureDuration + time.Second, true},
+		{"unknown listener", uuid.New(), time.Minute, true},
+		{"started", listenerID, DefaultCaptureDuration, false},
+		{"already capturing", listenerID, time.Minute, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture, err := StartCapture(test.listener, test.duration)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if filepath.Base(filepath.Dir(capture.File)) != "captures" || capture.Until.Sub(capture.Started) != test.duration {
+				t.Errorf("unexpected capture %+v", capture)
+			}
+			info, err := os.Stat(capture.File)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Errorf("expected the capture file to have 0600 permissions, have %s", info.Mode().Perm())
+			}
+		})
+	}
+
+	active := Captures()
+	if len(active) != 1 || active[0].Listener != listenerID {
+		t.Errorf("expected listener %s to be the only active capture, have %+v", listenerID, active)
+	}
+	if _, err := StopCapture(listenerID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := StopCapture(listenerID); err == nil {
+		t.Error("expected an error stopping a capture that was already stopped")
+	}
+	if len(Captures()) != 0 {
+		t.Errorf("expected no active captures, have %+v", Captures())
+	}
+}
+
+func TestCaptureExpires(t *testing.T) {
+	chdirTemp(t)
+	s, _, _ := newTCPService(t, "capture expires", "merlin")
+	if _, err := StartCapture(s.listener.ID(), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for len(Captures()) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(Captures()) != 0 {
+		t.Error("expected the capture to stop when its duration passed")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	chdirTemp(t)
+	s, _, l := newTCPService(t, "record", "merlin")
+	id := uuid.New()
+	t.Cleanup(func() { _ = s.agentService.Remove(id) })
+	capture, err := StartCapture(s.listener.ID(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A check in the listener can decode, one it can't, and the messages the server returns
+	checkin, err := l.Construct(messages.Base{ID: id, Type: messages.CHECKIN, Padding: "0123456789"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Handle(context.Background(), id, checkin); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = s.Handle(context.Background(), id, []byte("not a message"))
+
+	info, err := StopCapture(s.listener.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Traffic after the capture stopped isn't recorded
+	_, _ = s.Handle(context.Background(), id, checkin)
+
+	records := readCapture(t, capture.File)
+	if len(records) != info.Messages {
+		t.Errorf("expected the capture to count %d messages, have %d", len(records), info.Messages)
+	}
+	tests := []struct {
+		name      string
+		direction string
+		key       string
+		decoded   bool
+	}{
+		{"check in", inbound, "listener", true},
+		{"response", outbound, "listener", true},
+		// Agents using the none authenticator don't have a secret and keep using the listener's PSK
+		{"undecodable", inbound, "listener", false},
+	}
+	if len(records) < len(tests) {
+		t.Fatalf("expected at least %d records, have %d", len(tests), len(records))
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := records[i]
+			if r.Direction != test.direction || r.Key != test.key || r.Agent != id || len(r.Raw) == 0 || len(r.Transforms) == 0 {
+				t.Errorf("unexpected record %+v", r)
+			}
+			if (r.Decoded != nil) != test.decoded || (r.Error != "") == test.decoded {
+				t.Fatalf("expected decoded %t, have %+v with error %q", test.decoded, r.Decoded, r.Error)
+			}
+		})
+	}
+	if records[0].Decoded.Type != messages.CHECKIN.String() || records[0].Decoded.Padding != 10 {
+		t.Errorf("expected a check in with 10 bytes of padding, have %+v", records[0].Decoded)
+	}
+}
+
+// TestRecordKey verifies the record shows which key the transforms used and summarizes the message's token
+func TestRecordKey(t *testing.T) {
+	chdirTemp(t)
+	s, _, _ := newTCPService(t, "record key", "merlin")
+	capture, err := StartCapture(s.listener.ID(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = StopCapture(s.listener.ID()) })
+
+	tests := []struct {
+		name  string
+		key   []byte
+		token string
+		want  string
+	}{
+		{"listener PSK", nil, "", "listener"},
+		{"agent secret", []byte("secret"), "eyJhbGciOi", "agent"},
+	}
+	for _, test := range tests {
+		record(s.listener.ID(), inbound, uuid.New(), test.key, []byte("raw"), messages.Base{Type: messages.CHECKIN, Token: test.token}, nil)
+	}
+	records := readCapture(t, capture.File)
+	if len(records) != len(tests) {
+		t.Fatalf("expected %d records, have %d", len(tests), len(records))
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if records[i].Key != test.want {
+				t.Errorf("expected the %s key, have %s", test.want, records[i].Key)
+			}
+			if records[i].Decoded.Token != (test.token != "") {
+				t.Errorf("expected token %t, have %t", test.token != "", records[i].Decoded.Token)
+			}
+		})
+	}
+}
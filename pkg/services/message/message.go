@@ -25,6 +25,7 @@ import (
 	// Standard
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
@@ -45,6 +46,7 @@ import (
 	"github.com/Ne0nd0g/merlin/v2/pkg/core"
 	"github.com/Ne0nd0g/merlin/v2/pkg/delegate"
 	delegateMemory "github.com/Ne0nd0g/merlin/v2/pkg/delegate/memory"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
 	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
 	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/http"
 	httpMemory "github.com/Ne0nd0g/merlin/v2/pkg/listeners/http/memory"
@@ -57,6 +59,14 @@ import (
 	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
 	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
 	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+	"github.com/Ne0nd0g/merlin/v2/pkg/transformer"
+)
+
+var (
+	// ErrListenerDraining is returned when an unauthenticated Agent sends a message to a Listener that is draining
+	ErrListenerDraining = errors.New("the listener is draining and does not accept new agent authentications")
+	// ErrReplay is returned when an authenticated Agent's message is identical to one of its recent messages
+	ErrReplay = errors.New("the message was already received and is likely being replayed")
 )
 
 // Service is a structure with methods that execute the service functions for Agent messages
@@ -66,6 +76,7 @@ type Service struct {
 	listener      listeners.Listener
 	delegates     delegate.Repository
 	clientMsgRepo message.Repository
+	path          []uuid.UUID // The peer-to-peer Agents a delegate message was routed through to reach this service
 }
 
 // NewMessageService is a factory to create and return a ListenerService
@@ -157,7 +168,10 @@ func (s *Service) Construct(msg messages.Base) (data []byte, err error) {
 	// Add padding here since we already have an agent service to get the needed information
 	padding := a.Padding()
 
-	if padding > 0 {
+	if a.Profile().Padded() {
+		// The Agent's traffic profile overrides the padding the Agent was built with
+		padding = a.Profile().PaddingSize()
+	} else if padding > 0 {
 		padding = rand.Intn(padding) // #nosec G404 the random number is not used for secrets
 	} else if a.Comms() == (agents.Comms{}) {
 		// If we don't know what the Agent's padding configuration is, use this default number
@@ -166,23 +180,46 @@ func (s *Service) Construct(msg messages.Base) (data []byte, err error) {
 	msg.Padding = core.RandStringBytesMaskImprSrc(padding)
 
 	// If the Listener associated with this Message Handler doesn't belong to the Agent, then get the one that is and use it
+	l := s.listener
 	if a.Listener() != s.listener.ID() {
-		var l listeners.Listener
 		l, err = listener(a.Listener())
 		if err != nil {
 			err = fmt.Errorf("services/message.Construct() for Agent %s: %s", a.ID(), err)
 			return
 		}
-		return l.Construct(msg, a.Secret())
 	}
-	return s.listener.Construct(msg, a.Secret())
+
+	// Compress files sent to Agents that compress the files they send, unless the Listener's transforms already compress them
+	if agentJobs, ok := msg.Payload.([]jobs.Job); ok && infoJobs.Compression(msg.ID) && !transformer.Compresses(l.Transformers()) {
+		msg.Payload = compress(agentJobs)
+	}
+	// A forked Agent's implant only knows the ID of the Agent it was cloned from
+	if origin, ok := s.agentService.Origin(msg.ID); ok {
+		msg.ID = origin
+	}
+	data, err = l.Construct(msg, a.Secret())
+	record(l.ID(), outbound, msg.ID, a.Secret(), data, msg, err)
+	return
+}
+
+// compress returns a copy of the jobs with the files they send to the Agent compressed
+func compress(agentJobs []jobs.Job) []jobs.Job {
+	compressed := make([]jobs.Job, len(agentJobs))
+	for i, job := range agentJobs {
+		if ft, ok := job.Payload.(jobs.FileTransfer); ok && ft.IsDownload {
+			ft.FileBlob = infoJobs.CompressBlob(ft.FileBlob)
+			job.Payload = ft
+		}
+		compressed[i] = job
+	}
+	return compressed
 }
 
 // Handle is the primary entry function that processes incoming raw data Agent traffic.
 // The raw data is decoded/decrypted by either Listener or Agent's secret key depending on if the Agent completed authentication.
 // Delegate messages are handled here. Once completed, this function checks for return messages that belong to the input
 // Agent and returns them along with any delegate messages.
-func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
+func (s *Service) Handle(ctx context.Context, id uuid.UUID, data []byte) (rdata []byte, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "ID", id, "Data Length", len(data))
 	defer slog.Log(context.Background(), logging.LevelTrace, "exiting from function", "Return Data Length", len(rdata), "error", err)
 	//fmt.Printf("pkg/service/message.Handle(): entering into function with ID: %s, Data length %d\n", id, len(data))
@@ -199,21 +236,24 @@ func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
 		key = a.Secret()
 	}
 
-	// If the Agent exists, and it's Listener ID is empty, set it
-	if err == nil {
-		//if agent.Listener() == uuid.Nil
-		if a.Listener() != s.listener.ID() {
+	// If the Agent exists, and its Listener ID is not this Listener, update it once the message is decoded.
+	// Brute forcing calls Handle on every candidate Listener and only the one that decodes the message is the Agent's
+	update := err == nil && a.Listener() != s.listener.ID()
+
+	var msg messages.Base
+	if len(data) > 0 {
+		msg, err = s.listener.Deconstruct(data, key)
+		record(s.listener.ID(), inbound, id, key, data, msg, err)
+		if err == nil && update {
 			err = s.agentService.UpdateListener(id, s.listener.ID())
 			if err != nil {
 				err = fmt.Errorf("pkg/service/message.Handle(): %s", err)
 				return
 			}
+			if a.Listener() != uuid.Nil {
+				s.migrated(id, a.Listener())
+			}
 		}
-	}
-
-	var msg messages.Base
-	if len(data) > 0 {
-		msg, err = s.listener.Deconstruct(data, key)
 		if err != nil {
 			slog.Warn("there was an error deconstructing the message", "error", err, "agent", id)
 			//logging.Message("debug", fmt.Sprintf("pkg/services/message.Handle(): there was an error deconstructing the message for agent %s: %s", id, err))
@@ -235,10 +275,20 @@ func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
 				}
 			}
 		}
-	} else if !a.Authenticated() {
-		msg.ID = id
-		msg.Type = messages.CHECKIN
-		s.clientMsgRepo.Add(message.NewMessage(message.Note, fmt.Sprintf("Orphaned peer-to-peer agent %s detected due an empty payload, instructing agent to re-authenticate", id)))
+	} else {
+		// There is nothing to decode, but the empty payload still arrived through this Listener
+		if update {
+			err = s.agentService.UpdateListener(id, s.listener.ID())
+			if err != nil {
+				err = fmt.Errorf("pkg/service/message.Handle(): %s", err)
+				return
+			}
+		}
+		if !a.Authenticated() {
+			msg.ID = id
+			msg.Type = messages.CHECKIN
+			s.clientMsgRepo.Add(message.NewMessage(message.Note, fmt.Sprintf("Orphaned peer-to-peer agent %s detected due an empty payload, instructing agent to re-authenticate", id)))
+		}
 	}
 
 	// The "link refresh" command causes the parent Agent to send back an empty Base message for the child
@@ -251,9 +301,25 @@ func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
 		}
 	}
 
+	// Best-effort duplicate detection for captured traffic that is replayed, such as by defenders, so old job results
+	// aren't processed again and queued jobs aren't handed out to someone other than the Agent. Every repeated message
+	// from an authenticated Agent is rejected, so Agents must pad their messages or use a listener transform that
+	// encrypts with a random nonce. See replayCache for its limits
+	if len(data) > 0 && s.agentService.Authenticated(msg.ID) && replays.seen(msg.ID, data) {
+		m := fmt.Sprintf("Rejected a replayed %s message for Agent %s on listener %s at %s", msg.Type, msg.ID, s.listener.Name(), time.Now().UTC().Format(time.RFC3339))
+		slog.Warn(m, "agent", msg.ID, "listener", s.listener.ID(), "type", msg.Type)
+		s.clientMsgRepo.Add(message.NewMessage(message.Warn, m))
+		return nil, ErrReplay
+	}
+
 	var returnMessage messages.Base
 	// Agent authentication
 	if !s.agentService.Authenticated(msg.ID) {
+		// Draining listeners only serve Agents that have already authenticated
+		if listeners.Draining(s.listener.ID()) {
+			slog.Debug("refusing Agent authentication because the listener is draining", "agent", msg.ID, "listener", s.listener.ID())
+			return nil, ErrListenerDraining
+		}
 		returnMessage, err = s.listener.Authenticate(msg.ID, msg.Payload)
 		if err != nil {
 			return nil, err
@@ -272,9 +338,15 @@ func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
 				m := message.NewMessage(message.Success, fmt.Sprintf("New authenticated Agent checkin for %s at %s", a.ID(), a.Initial().UTC().Format(time.RFC3339)))
 				s.clientMsgRepo.Add(m)
 				key = a.Secret()
+				// Messages from a previous session were encrypted with different keys
+				replays.remove(a.ID())
 			}
+			// One-shot listeners expire once an Agent authenticates
+			listeners.Authenticated(s.listener.ID())
 		}
-		return s.listener.Construct(returnMessage, key)
+		rdata, err = s.listener.Construct(returnMessage, key)
+		record(s.listener.ID(), outbound, msg.ID, key, rdata, returnMessage, err)
+		return
 	}
 
 	// Validate the agent exists
@@ -292,12 +364,41 @@ func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
 		}
 	}
 
+	// Traffic from a cloned copy of the implant is attributed to the Agent it was forked into
+	fork, err := s.agentService.Source(msg.ID, source(ctx))
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/service/message.Handle(): %s", err))
+	} else if fork != msg.ID {
+		a, err = s.agentService.Agent(fork)
+		if err != nil {
+			return nil, fmt.Errorf("pkg/service/message.Handle(): %s", err)
+		}
+		id, msg.ID = fork, fork
+		if agentJobs, ok := msg.Payload.([]jobs.Job); ok {
+			for i := range agentJobs {
+				agentJobs[i].AgentID = fork
+			}
+		}
+	}
+
 	// Update the Agent's status checkin time
 	err = s.agentService.UpdateStatusCheckin(a.ID(), time.Now().UTC())
 	if err != nil {
 		slog.Error(fmt.Sprintf("pkg/service/message.Handle(): %s", err))
 	}
 
+	// Count the bytes received with the Agent's current secret key to know when it is due to be replaced
+	err = s.agentService.AddKeyBytes(a.ID(), len(data))
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/service/message.Handle(): %s", err))
+	}
+
+	// Record which channel the check-in arrived on
+	err = s.agentService.UpdateChannel(a.ID(), s.listener.ID())
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/service/message.Handle(): %s", err))
+	}
+
 	// Handle the incoming message type
 	switch msg.Type {
 	case messages.CHECKIN:
@@ -316,7 +417,7 @@ func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
 
 	// Send delegates to associated handler
 	if len(msg.Delegates) > 0 {
-		err = s.delegate(id, msg.Delegates)
+		err = s.delegate(ctx, id, msg.Delegates)
 		if err != nil {
 			return
 		}
@@ -327,7 +428,20 @@ func (s *Service) Handle(id uuid.UUID, data []byte) (rdata []byte, err error) {
 	if s.agentService.IsChild(id) {
 		return nil, nil
 	}
-	return s.getBase(id)
+	rdata, err = s.getBase(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// Hold the response for a random amount of time from the Agent's traffic profile so response times vary by Agent
+	if delay := a.Profile().Delay(); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return
 }
 
 // childDisconnect holds the business logic for the reset command that creates a final disconnect message for a child Agent
@@ -375,11 +489,26 @@ func (s *Service) childDisconnect(id uuid.UUID) (payload string, err error) {
 
 // delegate takes in a list of delegate messages from their associated parent agent and processes them according to their
 // associated Listener configuration.
-func (s *Service) delegate(parent uuid.UUID, delegates []messages.Delegate) error {
+func (s *Service) delegate(ctx context.Context, parent uuid.UUID, delegates []messages.Delegate) error {
 	//fmt.Printf("pkg/service/message.delegate(): entered into function with %d delegate messages\n", len(delegates))
 	for _, del := range delegates {
 		//fmt.Printf("Delegate message for agent: %s and listener: %s\n", delegate.Agent, delegate.Listener)
 
+		// Drop delegate messages that loop back to an Agent already on the route or exceed the hop limit
+		route := append(append([]uuid.UUID{}, s.path...), parent)
+		if onRoute(route, del.Agent) {
+			m := fmt.Sprintf("Dropping delegate message from %s for Agent %s because it would create a routing loop: %s", parent, del.Agent, routeString(append(route, del.Agent)))
+			slog.Warn(m)
+			s.clientMsgRepo.Add(message.NewMessage(message.Warn, m))
+			continue
+		}
+		if len(route) >= agent.MaxHops {
+			m := fmt.Sprintf("Dropping delegate message from %s for Agent %s because it exceeded the maximum of %d peer-to-peer hops", parent, del.Agent, agent.MaxHops)
+			slog.Warn(m)
+			s.clientMsgRepo.Add(message.NewMessage(message.Warn, m))
+			continue
+		}
+
 		var lhService *Service
 		var rdata []byte
 		var err error
@@ -392,9 +521,13 @@ func (s *Service) delegate(parent uuid.UUID, delegates []messages.Delegate) erro
 				slog.Error(fmt.Sprintf("pkg/services/message.delegate(): %s", err))
 			}
 			s.clientMsgRepo.Add(message.NewErrorMessage(fmt.Errorf("a delegate message was received from %s for the non-existent listener %s", del.Agent, del.Listener)))
-			s.clientMsgRepo.Add(message.NewMessage(message.Info, "Brute forcing all available listeners as a last resort to see if one of them can handle this message..."))
 
-			lhService, rdata, err = bruteForceListener(del.Agent, del.Payload)
+			// Listeners created for the bind Agents the parent deployed are tried before every other Listener
+			lhService, rdata, err = pivotListener(ctx, parent, del.Agent, del.Payload)
+			if err != nil {
+				s.clientMsgRepo.Add(message.NewMessage(message.Info, "Brute forcing all available listeners as a last resort to see if one of them can handle this message..."))
+				lhService, rdata, err = bruteForceListener(ctx, del.Agent, del.Payload)
+			}
 			if err != nil {
 				msg := fmt.Sprintf("A delegate message was received from %s for the non-existent listener %s.\n"+
 					"Attempts to brute force all existing Listeners to find one configure to handle the message failed.\n"+
@@ -421,8 +554,25 @@ func (s *Service) delegate(parent uuid.UUID, delegates []messages.Delegate) erro
 				s.clientMsgRepo.Add(message.NewMessage(message.Note, fmt.Sprintf("%s", j)))
 			}
 		} else {
+			// Reassemble fragmented messages and wait for the remaining fragments
+			payload := del.Payload
+			if listeners.FragmentSize(lhService.listener) > 0 {
+				var complete bool
+				payload, complete, err = listeners.Reassemble(del.Agent, del.Payload)
+				if err != nil {
+					m := fmt.Sprintf("Dropping delegate message from %s for Agent %s: %s", parent, del.Agent, err)
+					slog.Warn(m)
+					s.clientMsgRepo.Add(message.NewMessage(message.Warn, m))
+					continue
+				}
+				if !complete {
+					continue
+				}
+			}
+
 			// Send in the delegate message
-			rdata, err = lhService.Handle(del.Agent, del.Payload)
+			lhService.path = route
+			rdata, err = lhService.Handle(ctx, del.Agent, payload)
 			if err != nil {
 				slog.Error(fmt.Sprintf("there was an error handling delegate message from %s: %s\n", del.Agent, err))
 				break
@@ -437,6 +587,11 @@ func (s *Service) delegate(parent uuid.UUID, delegates []messages.Delegate) erro
 		if !linked {
 			//fmt.Printf("Adding child link %s to parent %s\n", delegate.Agent, parent)
 			err = s.agentService.Link(parent, del.Agent)
+			if errors.Is(err, agent.ErrRoutingLoop) {
+				slog.Warn(err.Error())
+				s.clientMsgRepo.Add(message.NewMessage(message.Warn, err.Error()))
+				continue
+			}
 			if err != nil {
 				return err
 			}
@@ -456,7 +611,9 @@ func (s *Service) delegate(parent uuid.UUID, delegates []messages.Delegate) erro
 		// Add encrypted/encoded return message Base structure (bytes) to the repository
 		//fmt.Printf("Storing return delegate message bytes(%d) for %s\n", len(rdata), delegate.Agent)
 		if len(rdata) > 0 {
-			s.delegates.Add(del.Agent, rdata)
+			for _, frame := range fragment(lhService.listener, rdata) {
+				s.delegates.Add(del.Agent, frame)
+			}
 		}
 	}
 	//fmt.Printf("pkg/service/message.delegate(): returning nil\n")
@@ -465,7 +622,7 @@ func (s *Service) delegate(parent uuid.UUID, delegates []messages.Delegate) erro
 
 // getBase builds a return Base message for the Agent id, encodes/encrypts it, and returns it as bytes.
 // If there are any Jobs, they will be added to the Base message here
-func (s *Service) getBase(id uuid.UUID) (data []byte, err error) {
+func (s *Service) getBase(ctx context.Context, id uuid.UUID) (data []byte, err error) {
 	//fmt.Printf("Getting Base messages for %s\n", id)
 	// Ensure the id is for a valid Agent
 	var a agents.Agent
@@ -484,9 +641,14 @@ func (s *Service) getBase(id uuid.UUID) (data []byte, err error) {
 		Delegates: nil,
 	}
 
+	// Instruct an Agent whose secret key is due to be replaced to authenticate again before sending it any jobs
+	if rekey.due(a) && s.reauthenticates(a) {
+		return s.rekeyBase(a)
+	}
+
 	// Get return jobs
 	var returnJobs []jobs.Job
-	returnJobs, err = s.jobService.Get(id)
+	returnJobs, err = s.jobService.Get(ctx, id)
 	if err != nil {
 		err = fmt.Errorf("pkg/services/message.getBase(): %s", err)
 		return
@@ -519,7 +681,7 @@ func (s *Service) getBase(id uuid.UUID) (data []byte, err error) {
 	}
 
 	// Get delegate messages
-	returnMessage.Delegates, err = s.getDelegates(id)
+	returnMessage.Delegates, err = s.getDelegates(ctx, id)
 	if err != nil {
 		// Do not return an error because it will cause the Parent Agent to quit functioning
 		slog.Error(fmt.Sprintf("pkg/services/message.getBase(): %s", err))
@@ -532,11 +694,21 @@ func (s *Service) getBase(id uuid.UUID) (data []byte, err error) {
 		return nil, nil
 	}
 
-	return s.Construct(returnMessage)
+	data, err = s.Construct(returnMessage)
+	if err != nil {
+		return
+	}
+
+	// Count the bytes sent with the Agent's current secret key to know when it is due to be replaced
+	err = s.agentService.AddKeyBytes(id, len(data))
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/services/message.getBase(): %s", err))
+	}
+	return data, nil
 }
 
 // getDelegates retrieves messages stored in the delegates repository for the passed in Agent ID
-func (s *Service) getDelegates(id uuid.UUID) ([]messages.Delegate, error) {
+func (s *Service) getDelegates(ctx context.Context, id uuid.UUID) ([]messages.Delegate, error) {
 	//fmt.Printf("Getting delegate messages for %s\n", id)
 	var delegates []messages.Delegate
 
@@ -552,10 +724,28 @@ func (s *Service) getDelegates(id uuid.UUID) ([]messages.Delegate, error) {
 		return delegates, err
 	}
 
+	// Child Agents are retrieved with the route to them so that loops and the hop limit can be detected
+	route := &Service{
+		agentService:  s.agentService,
+		jobService:    s.jobService,
+		listener:      s.listener,
+		delegates:     s.delegates,
+		clientMsgRepo: s.clientMsgRepo,
+		path:          append(append([]uuid.UUID{}, s.path...), id),
+	}
+
 	// If there are any child Agents, get return messages
 	if len(links) > 0 {
 		// For each child Agent
 		for _, link := range links {
+			if onRoute(route.path, link) {
+				slog.Warn(fmt.Sprintf("pkg/services/message.getDelegates(): skipping Agent %s because it would create a routing loop: %s", link, routeString(append(route.path, link))))
+				continue
+			}
+			if len(route.path) >= agent.MaxHops {
+				slog.Warn(fmt.Sprintf("pkg/services/message.getDelegates(): skipping Agent %s because it exceeded the maximum of %d peer-to-peer hops", link, agent.MaxHops))
+				continue
+			}
 			// Get messages from the Delegate repository
 			delegateMessages := s.delegates.Get(link)
 			// If any delegate messages were returned, add them to the list of return delegates
@@ -566,7 +756,7 @@ func (s *Service) getDelegates(id uuid.UUID) ([]messages.Delegate, error) {
 						Payload: msg,
 					}
 					// Recursive Get
-					d.Delegates, err = s.getDelegates(link)
+					d.Delegates, err = route.getDelegates(ctx, link)
 					if err != nil {
 						return delegates, err
 					}
@@ -576,19 +766,26 @@ func (s *Service) getDelegates(id uuid.UUID) ([]messages.Delegate, error) {
 			if s.agentService.Authenticated(link) {
 				// See if there are any Base messages (likely Jobs) for the delegate
 				var rdata []byte
-				rdata, err = s.getBase(link)
+				rdata, err = route.getBase(ctx, link)
 				if err != nil {
 					err = fmt.Errorf("pkg/services/message/getDelegate(): %s", err)
 					return delegates, err
 				}
 				// If there is an error, continue on. Happen when an Agent isn't authenticated and getBase can't find the Agent
 				if len(rdata) > 0 {
-					// Build the Delegate message
-					d := messages.Delegate{
-						Agent:   link,
-						Payload: rdata,
+					// Fragment the message if the child Agent's listener requires it
+					var l listeners.Listener
+					if a, errA := s.agentService.Agent(link); errA == nil {
+						l, _ = listener(a.Listener())
+					}
+					// Build the Delegate messages
+					for _, frame := range fragment(l, rdata) {
+						d := messages.Delegate{
+							Agent:   link,
+							Payload: frame,
+						}
+						delegates = append(delegates, d)
 					}
-					delegates = append(delegates, d)
 				}
 			}
 		}
@@ -596,9 +793,62 @@ func (s *Service) getDelegates(id uuid.UUID) ([]messages.Delegate, error) {
 	return delegates, nil
 }
 
+// fragment splits a transformed message into frames when the listener fragments messages.
+// The message is returned whole if the listener is nil, does not fragment messages, or can not fragment it
+func fragment(l listeners.Listener, data []byte) [][]byte {
+	if l == nil {
+		return [][]byte{data}
+	}
+	size := listeners.FragmentSize(l)
+	if size <= 0 {
+		return [][]byte{data}
+	}
+	frames, err := listeners.Fragment(data, size)
+	if err != nil {
+		slog.Error(fmt.Sprintf("pkg/services/message.fragment(): %s", err))
+		return [][]byte{data}
+	}
+	return frames
+}
+
+// onRoute determines if the Agent is already on the route
+func onRoute(route []uuid.UUID, id uuid.UUID) bool {
+	for _, hop := range route {
+		if hop == id {
+			return true
+		}
+	}
+	return false
+}
+
+// routeString returns the route as a list of Agent IDs separated by arrows
+func routeString(route []uuid.UUID) string {
+	var hops []string
+	for _, hop := range route {
+		hops = append(hops, hop.String())
+	}
+	return strings.Join(hops, " -> ")
+}
+
+// pivotListener tries each Listener created for a bind Agent the parent Agent deployed until one can handle the message
+func pivotListener(ctx context.Context, parent, id uuid.UUID, payload []byte) (lhService *Service, rdata []byte, err error) {
+	err = fmt.Errorf("pkg/services/message.pivotListener(): agent %s has not deployed any bind agents", parent)
+	for _, pivot := range listeners.Pivots(parent) {
+		lhService, err = NewMessageService(pivot.Listener)
+		if err != nil {
+			continue
+		}
+		rdata, err = lhService.Handle(ctx, id, payload)
+		if err == nil {
+			return
+		}
+	}
+	return
+}
+
 // bruteForceListener iterates through all available listeners and tries to use it to decode/decrypt the message.
 // Used as a recovery mechanism when the Server receives messages it doesn't have a Listener for to ensure Agents aren't lost
-func bruteForceListener(id uuid.UUID, payload []byte) (lhService *Service, rdata []byte, err error) {
+func bruteForceListener(ctx context.Context, id uuid.UUID, payload []byte) (lhService *Service, rdata []byte, err error) {
 	// Check the TCP Listener's Repository
 	tcpRepo := withTCPMemoryListenerRepository()
 	tcpListeners := tcpRepo.Listeners()
@@ -609,7 +859,7 @@ func bruteForceListener(id uuid.UUID, payload []byte) (lhService *Service, rdata
 				slog.Error(fmt.Sprintf("pkg/services/message.bruteForceListener(): %s", err))
 				break
 			}
-			rdata, err = lhService.Handle(id, payload)
+			rdata, err = lhService.Handle(ctx, id, payload)
 			if err == nil {
 				// Found a listener that didn't error out handling message
 				return
@@ -627,7 +877,7 @@ func bruteForceListener(id uuid.UUID, payload []byte) (lhService *Service, rdata
 				slog.Error(fmt.Sprintf("pkg/services/message.bruteForceListener(): %s", err))
 				break
 			}
-			rdata, err = lhService.Handle(id, payload)
+			rdata, err = lhService.Handle(ctx, id, payload)
 			if err == nil {
 				// Found a listener that didn't error out handling message
 				return
@@ -645,7 +895,7 @@ func bruteForceListener(id uuid.UUID, payload []byte) (lhService *Service, rdata
 				slog.Error(fmt.Sprintf("pkg/services/message.bruteForceListener(): %s", err))
 				break
 			}
-			rdata, err = lhService.Handle(id, payload)
+			rdata, err = lhService.Handle(ctx, id, payload)
 			if err == nil {
 				// Found a listener that didn't error out handling message
 				return
@@ -663,7 +913,7 @@ func bruteForceListener(id uuid.UUID, payload []byte) (lhService *Service, rdata
 				slog.Error(fmt.Sprintf("pkg/services/message.bruteForceListener(): %s", err))
 				break
 			}
-			rdata, err = lhService.Handle(id, payload)
+			rdata, err = lhService.Handle(ctx, id, payload)
 			if err == nil {
 				// Found a listener that didn't error out handling message
 				return
@@ -674,6 +924,25 @@ func bruteForceListener(id uuid.UUID, payload []byte) (lhService *Service, rdata
 	return
 }
 
+// migrated notifies the operator that an Agent's traffic arrived on a different listener and how many Agents remain
+// on the old listener so that it can be torn down once they have all migrated
+func (s *Service) migrated(id, old uuid.UUID) {
+	var remaining int
+	for _, a := range s.agentService.Agents() {
+		if a.Listener() == old && a.Alive() {
+			remaining++
+		}
+	}
+	m := fmt.Sprintf("Agent %s migrated from listener %s to listener %s", id, old, s.listener.ID())
+	if remaining == 0 {
+		m += fmt.Sprintf("; no Agents remain on listener %s and it can be torn down", old)
+	} else {
+		m += fmt.Sprintf("; %d Agent(s) remain on listener %s", remaining, old)
+	}
+	slog.Info(m)
+	s.clientMsgRepo.Add(message.NewMessage(message.Note, m))
+}
+
 // unlink holds the business logic for the unlink command that creates a final disconnect message for the child and adds
 // it as an argument to the parent's unlink message
 // Tried to do this in other packages, but it created circular dependencies
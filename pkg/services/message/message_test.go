@@ -0,0 +1,257 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/tcp"
+)
+
+// messageRecorder is a message repository that keeps every message in the order it was added
+type messageRecorder struct {
+	messages []*message.Message
+}
+
+func (r *messageRecorder) Add(m *message.Message) {
+	r.messages = append(r.messages, m)
+}
+
+func (r *messageRecorder) Get(id uuid.UUID) (*message.Message, error) {
+	return nil, nil
+}
+
+func (r *messageRecorder) GetAll() []*message.Message {
+	return r.messages
+}
+
+func (r *messageRecorder) GetQueue() *message.Message {
+	return nil
+}
+
+// migratedNotes returns the number of recorded messages that report an Agent migrated listeners
+func (r *messageRecorder) migratedNotes() (n int) {
+	for _, m := range r.messages {
+		if strings.Contains(m.Message(), "migrated from listener") {
+			n++
+		}
+	}
+	return
+}
+
+// newTCPService returns a message service for a new TCP listener created with the "none" authenticator and the
+// provided PSK, along with the listener to construct messages with
+func newTCPService(t *testing.T, name, psk string) (*Service, *messageRecorder, *tcp.Listener) {
+	t.Helper()
+	options := tcp.DefaultOptions()
+	options["Name"] = name
+	options["Authenticator"] = "none"
+	options["PSK"] = psk
+	listener, err := tcp.NewTCPListener(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := withTCPMemoryListenerRepository()
+	err = repo.Add(listener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.RemoveByID(listener.ID()) })
+
+	s, err := NewMessageService(listener.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := &messageRecorder{}
+	s.clientMsgRepo = recorder
+	return s, recorder, &listener
+}
+
+// TestHandleMigrated verifies the migrated note is only emitted, and the Agent's listener only updated, once a
+// message was successfully decoded by the new listener
+func TestHandleMigrated(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	tests := []struct {
+		name     string
+		psk      string // psk is the PSK of the listener the Agent's traffic arrives on
+		listener bool   // listener is true if the Agent's listener is expected to change
+		notes    int
+	}{
+		{"decoded", "merlin", true, 1},
+		{"not decoded", "wrong", false, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			old, _, oldListener := newTCPService(t, "old", "merlin")
+			s, recorder, _ := newTCPService(t, "new", test.psk)
+			id := uuid.New()
+			t.Cleanup(func() { _ = old.agentService.Remove(id) })
+
+			// The first check in authenticates the Agent on the old listener with the none authenticator
+			checkin, err := oldListener.Construct(messages.Base{ID: id, Type: messages.CHECKIN}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = old.Handle(context.Background(), id, checkin)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a, err := old.agentService.Agent(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The next check in sets the Agent's listener
+			checkin, err = oldListener.Construct(messages.Base{ID: id, Type: messages.CHECKIN}, a.Secret())
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = old.Handle(context.Background(), id, checkin)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _ = s.Handle(context.Background(), id, checkin)
+
+			a, err = s.agentService.Agent(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if moved := a.Listener() == s.listener.ID(); moved != test.listener {
+				t.Errorf("expected the Agent's listener to change %t, have listener %s", test.listener, a.Listener())
+			}
+			if n := recorder.migratedNotes(); n != test.notes {
+				t.Errorf("expected %d migrated notes, have %d", test.notes, n)
+			}
+		})
+	}
+}
+
+func TestOnRoute(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name  string
+		route []uuid.UUID
+		id    uuid.UUID
+		want  bool
+		str   string
+	}{
+		{"empty", nil, a, false, ""},
+		{"on route", []uuid.UUID{a, b}, b, true, a.String() + " -> " + b.String()},
+		{"not on route", []uuid.UUID{a, b}, c, false, a.String() + " -> " + b.String()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := onRoute(test.route, test.id); got != test.want {
+				t.Errorf("expected %t, have %t", test.want, got)
+			}
+			if got := routeString(test.route); got != test.str {
+				t.Errorf("expected %q, have %q", test.str, got)
+			}
+		})
+	}
+}
+
+// TestFragment verifies delegate messages are only fragmented for listeners with a fragment size
+func TestFragment(t *testing.T) {
+	data := []byte(strings.Repeat("merlin", 100))
+	tests := []struct {
+		name   string
+		size   string
+		frames int
+	}{
+		{"disabled", "0", 1},
+		{"fragmented", "148", 6},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, listener := newTCPService(t, "fragment "+test.name, "merlin")
+			err := listener.SetOption("FragmentSize", test.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			frames := fragment(listener, data)
+			if len(frames) != test.frames {
+				t.Fatalf("expected %d frames, have %d", test.frames, len(frames))
+			}
+			if test.frames == 1 {
+				if string(frames[0]) != string(data) {
+					t.Error("expected the message to be returned whole")
+				}
+				return
+			}
+			agent := uuid.New()
+			for _, frame := range frames {
+				message, complete, err := listeners.Reassemble(agent, frame)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if complete && string(message) != string(data) {
+					t.Error("the reassembled message does not match the original")
+				}
+			}
+		})
+	}
+	if frames := fragment(nil, data); len(frames) != 1 {
+		t.Errorf("expected a message without a listener to be returned whole, have %d frames", len(frames))
+	}
+}
+
+// TestWithSource verifies the source address is carried by the context and is empty when the listener didn't provide it
+func TestWithSource(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no source", context.Background(), ""},
+		{"source", WithSource(context.Background(), "198.51.100.10"), "198.51.100.10"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if have := source(test.ctx); have != test.want {
+				t.Errorf("expected %q, have %q", test.want, have)
+			}
+		})
+	}
+}
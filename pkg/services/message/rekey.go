@@ -0,0 +1,119 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+	"github.com/Ne0nd0g/merlin-message/opaque"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+)
+
+// rekeyPolicy holds the thresholds that trigger an authenticated Agent to establish a new secret key
+type rekeyPolicy struct {
+	sync.RWMutex
+	after time.Duration // The maximum age of an Agent's secret key; 0 is disabled
+	bytes int64         // The maximum number of message bytes exchanged using an Agent's secret key; 0 is disabled
+}
+
+// rekey is the policy shared by every message Service
+var rekey = &rekeyPolicy{}
+
+// SetRekey configures how long an Agent's secret key is used, and how many message bytes are exchanged with it, before
+// the Agent is instructed to authenticate again and establish a new key. A zero value disables that threshold
+func SetRekey(after time.Duration, bytes int64) error {
+	if after < 0 {
+		return fmt.Errorf("pkg/services/message.SetRekey(): the re-key duration must not be negative: %s", after)
+	}
+	if bytes < 0 {
+		return fmt.Errorf("pkg/services/message.SetRekey(): the re-key byte count must not be negative: %d", bytes)
+	}
+	rekey.Lock()
+	defer rekey.Unlock()
+	rekey.after = after
+	rekey.bytes = bytes
+	return nil
+}
+
+// due returns true if the authenticated Agent's secret key has been used longer, or for more bytes, than allowed
+func (p *rekeyPolicy) due(a agents.Agent) bool {
+	p.RLock()
+	defer p.RUnlock()
+	keyed, bytes := a.Keyed()
+	if !a.Authenticated() || keyed.IsZero() {
+		return false
+	}
+	if p.after > 0 && time.Since(keyed) >= p.after {
+		return true
+	}
+	return p.bytes > 0 && bytes >= p.bytes
+}
+
+// rekeyBase returns a Base message, encrypted with the Agent's current secret key, instructing the Agent to
+// authenticate again. The Agent's secret is then cleared so its next message is handled by the Listener's authenticator,
+// which issues the new key. The Agent's OPAQUE registration is kept so it does not need to register again.
+// Queued jobs stay in the queue and are sent after the Agent authenticates
+func (s *Service) rekeyBase(a agents.Agent) (data []byte, err error) {
+	returnMessage := messages.Base{
+		ID:      a.ID(),
+		Type:    messages.OPAQUE,
+		Payload: opaque.Opaque{Type: opaque.ReAuthenticate},
+	}
+	data, err = s.Construct(returnMessage)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/message.rekeyBase(): %s", err)
+	}
+
+	err = s.agentService.Rekey(a.ID())
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/message.rekeyBase(): %s", err)
+	}
+
+	keyed, bytes := a.Keyed()
+	m := fmt.Sprintf("Instructing Agent %s to re-key after using its secret key for %s and %d bytes", a.ID(), time.Since(keyed).Round(time.Second), bytes)
+	slog.Info(m, "agent", a.ID())
+	a.Log(m)
+	s.clientMsgRepo.Add(message.NewMessage(message.Note, m))
+	return data, nil
+}
+
+// reauthenticates returns true if the Agent's Listener authenticator issues new key material when the Agent
+// authenticates again
+func (s *Service) reauthenticates(a agents.Agent) bool {
+	l := s.listener
+	if a.Listener() != s.listener.ID() {
+		var err error
+		l, err = listener(a.Listener())
+		if err != nil {
+			return false
+		}
+	}
+	return l.Authenticator() != nil && l.Authenticator().String() == "OPAQUE"
+}
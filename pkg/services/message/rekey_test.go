@@ -0,0 +1,104 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"os"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+func TestSetRekey(t *testing.T) {
+	t.Cleanup(func() { _ = SetRekey(0, 0) })
+	tests := []struct {
+		name    string
+		after   time.Duration
+		bytes   int64
+		wantErr bool
+	}{
+		{"disabled", 0, 0, false},
+		{"enabled", time.Hour, 1 << 20, false},
+		{"negative duration", -time.Hour, 0, true},
+		{"negative bytes", 0, -1, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetRekey(test.after, test.bytes)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestRekeyDue verifies only authenticated Agents whose secret key exceeded a threshold are due to re-key
+func TestRekeyDue(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	newAgent := func(secret []byte, authenticated bool, bytes int) agents.Agent {
+		t.Helper()
+		a, err := agents.NewAgent(uuid.New(), secret, nil, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.UpdateAuthenticated(authenticated)
+		a.AddKeyBytes(bytes)
+		return a
+	}
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	tests := []struct {
+		name  string
+		after time.Duration
+		bytes int64
+		agent agents.Agent
+		want  bool
+	}{
+		{"disabled", 0, 0, newAgent(secret, true, 4096), false},
+		{"fresh key", time.Hour, 1024, newAgent(secret, true, 512), false},
+		{"expired key", time.Nanosecond, 0, newAgent(secret, true, 0), true},
+		{"byte count reached", 0, 1024, newAgent(secret, true, 1024), true},
+		{"unauthenticated", time.Nanosecond, 1024, newAgent(secret, false, 4096), false},
+		{"no key", time.Nanosecond, 1024, newAgent(nil, true, 4096), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &rekeyPolicy{after: test.after, bytes: test.bytes}
+			if got := p.due(test.agent); got != test.want {
+				t.Errorf("expected %t, have %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"crypto/sha256"
+	"sync"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// replayWindow is the number of recent messages remembered for each Agent to detect duplicates.
+// An Agent checking in every 10 seconds fills the window in about 11 hours
+const replayWindow = 4096
+
+// replayCache is best-effort duplicate detection; it remembers the SHA-256 digest of the most recent raw messages
+// received from each Agent. Agent messages are randomly padded or encrypted with a random nonce, so a byte-for-byte
+// repeat of a previous message was most likely captured and replayed rather than sent by the Agent. Every repeat is
+// treated as a replay, whatever its type.
+//
+// This is not nonce or sequence validation, the Agent message protocol has neither. Messages older than the window
+// are not detected, the window is lost when the server restarts, and an Agent that genuinely retransmits an identical
+// message, such as after a dropped response, has it rejected
+type replayCache struct {
+	sync.Mutex
+	agents map[uuid.UUID]*replayRing
+}
+
+// replayRing is a fixed size window of message digests for a single Agent; the oldest digest is forgotten first
+type replayRing struct {
+	seen   map[[sha256.Size]byte]struct{}
+	order  [][sha256.Size]byte
+	oldest int
+}
+
+// replays is the in-memory cache shared by every message Service
+var replays = &replayCache{agents: make(map[uuid.UUID]*replayRing)}
+
+// seen records the raw message for the Agent and returns true if the same message was already received
+func (r *replayCache) seen(id uuid.UUID, data []byte) bool {
+	digest := sha256.Sum256(data)
+
+	r.Lock()
+	defer r.Unlock()
+	ring, ok := r.agents[id]
+	if !ok {
+		ring = &replayRing{seen: make(map[[sha256.Size]byte]struct{})}
+		r.agents[id] = ring
+	}
+	if _, ok = ring.seen[digest]; ok {
+		return true
+	}
+	if len(ring.order) < replayWindow {
+		ring.order = append(ring.order, digest)
+	} else {
+		delete(ring.seen, ring.order[ring.oldest])
+		ring.order[ring.oldest] = digest
+		ring.oldest = (ring.oldest + 1) % replayWindow
+	}
+	ring.seen[digest] = struct{}{}
+	return false
+}
+
+// remove forgets the messages received from the Agent, such as when it re-authenticates with new keys
+func (r *replayCache) remove(id uuid.UUID) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.agents, id)
+}
@@ -0,0 +1,150 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+
+	// Merlin
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/tcp"
+)
+
+// TestReplayRingEviction verifies the oldest message is forgotten once an Agent's replay window is full
+func TestReplayRingEviction(t *testing.T) {
+	cache := &replayCache{agents: make(map[uuid.UUID]*replayRing)}
+	id := uuid.New()
+	data := func(i int) []byte { return []byte(fmt.Sprintf("message %d", i)) }
+
+	for i := 0; i < replayWindow; i++ {
+		if cache.seen(id, data(i)) {
+			t.Fatalf("message %d was reported as a replay the first time it was received", i)
+		}
+	}
+	if !cache.seen(id, data(0)) {
+		t.Fatal("expected the oldest message to be a replay while it is within the window")
+	}
+	// A new message evicts message 0, the oldest in the window
+	if cache.seen(id, data(replayWindow)) {
+		t.Fatalf("message %d was reported as a replay the first time it was received", replayWindow)
+	}
+	if cache.seen(id, data(0)) {
+		t.Fatal("expected the evicted message to be forgotten")
+	}
+	if !cache.seen(id, data(replayWindow)) {
+		t.Fatal("expected the newest message to be a replay")
+	}
+	if !cache.seen(id, data(2)) {
+		t.Fatal("expected a message that was not evicted to be a replay")
+	}
+	if cache.seen(uuid.New(), data(2)) {
+		t.Fatal("expected messages to be tracked separately for each Agent")
+	}
+}
+
+// TestReplayRemove verifies an Agent's messages are forgotten when it re-authenticates
+func TestReplayRemove(t *testing.T) {
+	cache := &replayCache{agents: make(map[uuid.UUID]*replayRing)}
+	id := uuid.New()
+	other := uuid.New()
+	cache.seen(id, []byte("checkin"))
+	cache.seen(other, []byte("checkin"))
+
+	cache.remove(id)
+	if cache.seen(id, []byte("checkin")) {
+		t.Fatal("expected the Agent's messages to be forgotten after it was removed")
+	}
+	if !cache.seen(other, []byte("checkin")) {
+		t.Fatal("expected other Agents' messages to be remembered")
+	}
+}
+
+// TestHandleReplayedCheckin verifies a replayed check in from an authenticated Agent is rejected even though it
+// isn't padded and doesn't carry job results
+func TestHandleReplayedCheckin(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	options := tcp.DefaultOptions()
+	options["Name"] = "replay"
+	options["Authenticator"] = "none"
+	listener, err := tcp.NewTCPListener(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := withTCPMemoryListenerRepository()
+	err = repo.Add(listener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.RemoveByID(listener.ID()) })
+
+	s, err := NewMessageService(listener.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := uuid.New()
+	t.Cleanup(func() { _ = s.agentService.Remove(id) })
+
+	checkin, err := listener.Construct(messages.Base{ID: id, Type: messages.CHECKIN}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first check in authenticates the Agent with the none authenticator
+	_, err = s.Handle(context.Background(), id, checkin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.agentService.Authenticated(id) {
+		t.Fatal("expected the Agent to be authenticated")
+	}
+
+	checkin, err = listener.Construct(messages.Base{ID: id, Type: messages.CHECKIN}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Handle(context.Background(), id, checkin)
+	if err != nil {
+		t.Fatalf("expected the authenticated Agent's check in to be handled: %s", err)
+	}
+	_, err = s.Handle(context.Background(), id, checkin)
+	if !errors.Is(err, ErrReplay) {
+		t.Fatalf("expected the replayed check in to be rejected with %q, got: %v", ErrReplay, err)
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package message
+
+import (
+	// Standard
+	"context"
+)
+
+// sourceKey is the context key for the address an Agent's message originated from
+type sourceKey struct{}
+
+// WithSource returns a copy of the context that carries the address the Agent's message originated from, after
+// accounting for trusted redirectors, so that copies of the same implant checking in from different hosts are detected
+func WithSource(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, addr)
+}
+
+// source returns the address the Agent's message originated from, or an empty string if the listener didn't provide it
+func source(ctx context.Context) string {
+	addr, _ := ctx.Value(sourceKey{}).(string)
+	return addr
+}
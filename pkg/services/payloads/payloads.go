@@ -0,0 +1,153 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package payloads is a service used to keep an inventory of the payloads built for, or delivered by, the Merlin
+// server with their hashes so that they can be provided to defenders for deconfliction
+package payloads
+
+import (
+	// Standard
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/payloads"
+	"github.com/Ne0nd0g/merlin/v2/pkg/payloads/memory"
+)
+
+// Service holds references to repositories to manage the payload inventory
+type Service struct {
+	payloadRepo payloads.Repository
+}
+
+// memoryService is an in-memory instantiation of the payload service so that it can be used by others
+var memoryService *Service
+
+// NewPayloadService is a factory to create a payload service to be used by other packages or services
+func NewPayloadService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			payloadRepo: WithPayloadMemoryRepository(),
+		}
+	}
+	return memoryService
+}
+
+// WithPayloadMemoryRepository retrieves an in-memory payload repository interface used to manage payload objects
+func WithPayloadMemoryRepository() payloads.Repository {
+	return memory.NewRepository()
+}
+
+// Register hashes the payload and adds it to the inventory. A payload with the same contents, listener, and source
+// that is already in the inventory is returned instead of being added again
+func (s *Service) Register(name string, data []byte, built time.Time, listener uuid.UUID, operator, source string) (payloads.Payload, error) {
+	if len(data) == 0 {
+		return payloads.Payload{}, fmt.Errorf("pkg/services/payloads.Register(): the payload %s is empty", name)
+	}
+	payload := payloads.NewPayload(name, data, built, listener, operator, source)
+	for _, existing := range s.payloadRepo.GetHash(payload.SHA256()) {
+		if existing.Listener() == listener && existing.Source() == source {
+			return existing, nil
+		}
+	}
+	err := s.payloadRepo.Add(payload)
+	if err != nil {
+		return payloads.Payload{}, fmt.Errorf("pkg/services/payloads.Register(): %s", err)
+	}
+	return payload, nil
+}
+
+// All returns every payload in the inventory
+func (s *Service) All() []payloads.Payload {
+	return s.payloadRepo.GetAll()
+}
+
+// Hash returns the payloads whose MD5, SHA-1, or SHA-256 hash matches the provided hash
+func (s *Service) Hash(hash string) []payloads.Payload {
+	return s.payloadRepo.GetHash(hash)
+}
+
+// record is a single exported payload
+type record struct {
+	Name     string `json:"name"`
+	MD5      string `json:"md5"`
+	SHA1     string `json:"sha1"`
+	SHA256   string `json:"sha256"`
+	Size     int    `json:"size"`
+	Built    string `json:"built"`
+	Listener string `json:"listener,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Source   string `json:"source"`
+}
+
+// Export returns every payload in the inventory in the provided format, either csv or json, as a deconfliction
+// document. The names map, keyed by listener ID, is used to include the listener's name with its ID
+func (s *Service) Export(format string, names map[uuid.UUID]string) ([]byte, error) {
+	var records []record
+	for _, p := range s.payloadRepo.GetAll() {
+		r := record{
+			Name:     p.Name(),
+			MD5:      p.MD5(),
+			SHA1:     p.SHA1(),
+			SHA256:   p.SHA256(),
+			Size:     p.Size(),
+			Built:    p.Built().Format(time.RFC3339),
+			Operator: p.Operator(),
+			Source:   p.Source(),
+		}
+		if p.Listener() != uuid.Nil {
+			r.Listener = p.Listener().String()
+			if name := names[p.Listener()]; name != "" {
+				r.Listener = fmt.Sprintf("%s (%s)", name, p.Listener())
+			}
+		}
+		records = append(records, r)
+	}
+
+	switch strings.ToLower(format) {
+	case "csv", "":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		err := w.Write([]string{"name", "md5", "sha1", "sha256", "size", "built", "listener", "operator", "source"})
+		if err != nil {
+			return nil, fmt.Errorf("pkg/services/payloads.Export(): %s", err)
+		}
+		for _, r := range records {
+			err = w.Write([]string{r.Name, r.MD5, r.SHA1, r.SHA256, strconv.Itoa(r.Size), r.Built, r.Listener, r.Operator, r.Source})
+			if err != nil {
+				return nil, fmt.Errorf("pkg/services/payloads.Export(): %s", err)
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	case "json":
+		return json.MarshalIndent(records, "", "  ")
+	default:
+		return nil, fmt.Errorf("pkg/services/payloads.Export(): unknown export format '%s', expected csv or json", format)
+	}
+}
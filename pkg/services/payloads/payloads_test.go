@@ -0,0 +1,139 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package payloads
+
+import (
+	// Standard
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestRegister verifies payloads are hashed and added to the inventory only once for the same contents, listener, and
+// source
+func TestRegister(t *testing.T) {
+	s := NewPayloadService()
+	data := []byte(uuid.NewString())
+	listener := uuid.New()
+	first, err := s.Register("agent.exe", data, time.Time{}, listener, "operator", "generator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		listener uuid.UUID
+		source   string
+		existing bool
+		err      bool
+	}{
+		{"duplicate", data, listener, "generator", true, false},
+		{"other listener", data, uuid.New(), "generator", false, false},
+		{"other source", data, listener, "hosted", false, false},
+		{"other contents", []byte(uuid.NewString()), listener, "generator", false, false},
+		{"empty", nil, listener, "generator", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := s.Register("agent.exe", test.data, time.Time{}, test.listener, "operator", test.source)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %t, have %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if (p.ID() == first.ID()) != test.existing {
+				t.Errorf("expected the existing payload to be returned %t, have %s for %s", test.existing, p.ID(), first.ID())
+			}
+		})
+	}
+	if matches := s.Hash(first.MD5()); len(matches) != 3 {
+		t.Errorf("expected 3 inventoried payloads with the same contents, have %d", len(matches))
+	}
+}
+
+// TestExport verifies the inventory is exported as CSV or JSON with the listener's name
+func TestExport(t *testing.T) {
+	s := NewPayloadService()
+	listener := uuid.New()
+	p, err := s.Register("export.exe", []byte(uuid.NewString()), time.Time{}, listener, "operator", "generator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[uuid.UUID]string{listener: "Default"}
+	want := "Default (" + listener.String() + ")"
+
+	tests := []struct {
+		format string
+		err    bool
+	}{
+		{"csv", false},
+		{"", false},
+		{"JSON", false},
+		{"xml", true},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			data, err := s.Export(test.format, names)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %t, have %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			var listeners []string
+			if strings.EqualFold(test.format, "json") {
+				var records []record
+				if err = json.Unmarshal(data, &records); err != nil {
+					t.Fatal(err)
+				}
+				for _, r := range records {
+					if r.SHA256 == p.SHA256() {
+						listeners = append(listeners, r.Listener)
+					}
+				}
+			} else {
+				rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rows[0][3] != "sha256" || rows[0][6] != "listener" {
+					t.Fatalf("unexpected CSV header %q", rows[0])
+				}
+				for _, row := range rows[1:] {
+					if row[3] == p.SHA256() {
+						listeners = append(listeners, row[6])
+					}
+				}
+			}
+			if len(listeners) != 1 || listeners[0] != want {
+				t.Errorf("expected the payload to be exported once with listener %q, have %q", want, listeners)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package persistence is a service used to track every persistence artifact Agents create so they can be cleaned up
+// at the end of an engagement
+package persistence
+
+import (
+	// Standard
+	"fmt"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence"
+	"github.com/Ne0nd0g/merlin/v2/pkg/persistence/memory"
+)
+
+// Service holds references to repositories to manage persistence artifacts
+type Service struct {
+	persistenceRepo persistence.Repository
+}
+
+// memoryService is an in-memory instantiation of the persistence service so that it can be used by others
+var memoryService *Service
+
+// NewPersistenceService is a factory to create a persistence service to be used by other packages or services
+func NewPersistenceService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			persistenceRepo: WithPersistenceMemoryRepository(),
+		}
+	}
+	return memoryService
+}
+
+// WithPersistenceMemoryRepository retrieves an in-memory persistence repository interface used to manage artifacts
+func WithPersistenceMemoryRepository() persistence.Repository {
+	return memory.NewRepository()
+}
+
+// Add records a persistence artifact the Agent successfully installed
+func (s *Service) Add(artifact persistence.Artifact) error {
+	return s.persistenceRepo.Add(artifact)
+}
+
+// All returns every recorded persistence artifact
+func (s *Service) All() []persistence.Artifact {
+	return s.persistenceRepo.GetAll()
+}
+
+// Get returns the persistence artifact for the provided ID
+func (s *Service) Get(id uuid.UUID) (persistence.Artifact, error) {
+	return s.persistenceRepo.Get(id)
+}
+
+// Installed returns the persistence artifacts that remain on target hosts for the provided Agent, or for all Agents
+// if the ID is the broadcast identifier
+func (s *Service) Installed(agentID uuid.UUID) (artifacts []persistence.Artifact) {
+	broadcast := agentID.String() == "ffffffff-ffff-ffff-ffff-ffffffffffff"
+	for _, artifact := range s.persistenceRepo.GetAll() {
+		if artifact.Status() == persistence.REMOVED {
+			continue
+		}
+		if broadcast || artifact.AgentID() == agentID {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return
+}
+
+// UpdateStatus sets the state of the persistence artifact on the target host
+func (s *Service) UpdateStatus(id uuid.UUID, status persistence.Status) error {
+	artifact, err := s.persistenceRepo.Get(id)
+	if err != nil {
+		return fmt.Errorf("pkg/services/persistence.UpdateStatus(): %s", err)
+	}
+	artifact.UpdateStatus(status)
+	return s.persistenceRepo.Update(artifact)
+}
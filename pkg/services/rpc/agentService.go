@@ -25,6 +25,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +36,7 @@ import (
 
 	// Internal
 	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/geoip"
 	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
 	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
 )
@@ -65,11 +68,17 @@ func (s *Server) agentToAgentInfo(a agents.Agent) *pb.AgentInfo {
 		Wait:     a.Comms().Wait,
 	}
 
+	// Show the impersonated access token alongside the process owner
+	username := a.Process().UserName
+	if a.Impersonation() != "" {
+		username = fmt.Sprintf("%s (impersonating %s)", username, a.Impersonation())
+	}
+
 	process := &pb.Process{
 		ID:             int32(a.Process().ID),
 		IntegrityLevel: int32(a.Process().Integrity),
 		Name:           a.Process().Name,
-		Username:       a.Process().UserName,
+		Username:       username,
 		UserGUID:       a.Process().UserGUID,
 		Domain:         a.Process().Domain,
 	}
@@ -78,6 +87,49 @@ func (s *Server) agentToAgentInfo(a agents.Agent) *pb.AgentInfo {
 		links = append(links, link.String())
 	}
 
+	// Show the analysis indicators detected during pre-flight checks alongside the operator's notes
+	note := a.Note()
+	if a.Name() != "" {
+		note = strings.TrimSpace(fmt.Sprintf("[Name: %s] %s", a.Name(), note))
+	}
+	if len(a.Indicators()) > 0 {
+		note = strings.TrimSpace(fmt.Sprintf("%s [Analysis indicators: %s]", note, strings.Join(a.Indicators(), "; ")))
+	}
+	// Show the host's timezone so clients can display the Agent's activity in its local time
+	if loc := a.Location(); loc != nil {
+		note = strings.TrimSpace(fmt.Sprintf("%s [Timezone: %s (UTC%s)]", note, a.Timezone(), time.Now().In(loc).Format("-07:00")))
+	}
+	if a.Campaign() != "" {
+		note = strings.TrimSpace(fmt.Sprintf("%s [Campaign: %s]", note, a.Campaign()))
+	}
+	if a.ScopeViolation() != "" {
+		note = strings.TrimSpace(fmt.Sprintf("%s [Out of scope: %s]", note, a.ScopeViolation()))
+	}
+	if a.Quarantined() {
+		note = strings.TrimSpace(fmt.Sprintf("%s [Quarantined: %s]", note, a.Quarantine()))
+	}
+	if a.RemoteAddress() != "" {
+		note = strings.TrimSpace(fmt.Sprintf("%s [Source: %s]", note, a.RemoteAddress()))
+		if geoip.Enabled() {
+			if location, err := geoip.Lookup(a.RemoteAddress()); err == nil {
+				note = fmt.Sprintf("%s [Location: %s]", note, location)
+			}
+		}
+	}
+	// Show the check-ins per channel when the Agent has used a fallback listener
+	if channels := a.Channels(); len(channels) > 1 {
+		var counts []string
+		for id, count := range channels {
+			name := id.String()
+			if l, err := s.ls.Listener(id); err == nil {
+				name = l.Name()
+			}
+			counts = append(counts, fmt.Sprintf("%s: %d", name, count))
+		}
+		sort.Strings(counts)
+		note = strings.TrimSpace(fmt.Sprintf("%s [Channels: %s]", note, strings.Join(counts, ", ")))
+	}
+
 	status, err := s.agentService.Status(a.ID())
 	if err != nil {
 		slog.Error(err.Error())
@@ -96,7 +148,7 @@ func (s *Server) agentToAgentInfo(a agents.Agent) *pb.AgentInfo {
 		LastCheckin:    a.StatusCheckin().Format(time.RFC3339),
 		Listener:       a.Listener().String(),
 		Links:          links,
-		Note:           a.Note(),
+		Note:           note,
 		Status:         status,
 		Groups:         s.agentService.Groups(),
 	}
@@ -147,14 +199,106 @@ func (s *Server) GetAgentLinks(ctx context.Context, id *pb.ID) (*pb.Slice, error
 	return &pb.Slice{Data: linkIDs}, nil
 }
 
+// GetAgentChanges returns the differences detected in the Agent's host and process information across check-ins.
+// Notable changes, like a privilege increase or a move to another network, are marked with an asterisk
+func (s *Server) GetAgentChanges(ctx context.Context, id *pb.ID) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	agentID, err := uuid.Parse(id.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.agentService.Changes(agentID)
+	if err != nil {
+		err = fmt.Errorf("there was an error getting the changes for Agent %s: %w", agentID, err)
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	data := &pb.TableData{Header: []string{"Time", "Field", "Old", "New", "Notable"}}
+	for _, change := range changes {
+		notable := ""
+		if change.Notable {
+			notable = "*"
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: []string{change.Time.Format(time.RFC3339), change.Field, change.Old, change.New, notable}})
+	}
+	return data, nil
+}
+
+// GetAgentActivity returns a table of when a user last used each alive Agent's host, from the Agent's most recent idle
+// job, so that operators can time screenshots and interactive work to when the user is away. Agents without a measurement
+// are not included
+func (s *Server) GetAgentActivity(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	data := &pb.TableData{
+		Header: []string{"Agent GUID", "Name", "Host", "Last Active", "Idle", "Measured", "Sessions"},
+	}
+	for _, a := range s.agentService.Agents() {
+		activity := a.Activity()
+		if !a.Alive() || activity.Checked.IsZero() {
+			continue
+		}
+		var sessions []string
+		for _, session := range activity.Sessions {
+			sessions = append(sessions, session.String())
+		}
+		row := []string{
+			a.ID().String(),
+			a.Name(),
+			a.Host().Name,
+			activity.LastActive().Format(time.RFC3339),
+			time.Since(activity.LastActive()).Round(time.Second).String(),
+			activity.Checked.Format(time.RFC3339),
+			strings.Join(sessions, "; "),
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
 // GetAgentRows returns certain pieces of information for all Agents that can later be displayed in a table on the client
 func (s *Server) GetAgentRows(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	return s.agentRows(func(agents.Agent) bool { return true })
+}
+
+// GetCampaigns returns a table of callback statistics for each campaign identifier embedded in Agent payloads, which
+// attributes access to the phishing lure or wave that produced it
+func (s *Server) GetCampaigns(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	data := &pb.TableData{
+		Header: []string{"Campaign", "Agents", "Alive", "Hosts", "Users", "First Callback", "Last Callback"},
+	}
+	for _, c := range s.agentService.Campaigns() {
+		row := []string{
+			c.ID,
+			strconv.Itoa(c.Agents),
+			strconv.Itoa(c.Alive),
+			strconv.Itoa(c.Hosts),
+			strconv.Itoa(c.Users),
+			c.First.UTC().Format(time.RFC3339),
+			c.Last.UTC().Format(time.RFC3339),
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
+// GetPrivilegedAgentRows returns the same information as GetAgentRows for Agents running as an administrator, SYSTEM,
+// or root to quickly identify high-value sessions
+func (s *Server) GetPrivilegedAgentRows(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	return s.agentRows(func(a agents.Agent) bool { return a.Privileged() })
+}
+
+// agentRows builds the Agent table for alive Agents that match the filter
+func (s *Server) agentRows(filter func(agents.Agent) bool) (*pb.TableData, error) {
 	data := &pb.TableData{}
-	data.Header = []string{"Agent GUID", "Transport", "Platform", "Host", "User", "Process", "Status", "Last Checkin", "Note"}
+	data.Header = []string{"Agent GUID", "Transport", "Platform", "Host", "User", "Privilege", "Process", "Status", "Last Checkin", "Note"}
 	var rows []*pb.TableRows
 	for _, a := range s.agentService.Agents() {
-		if a.Alive() {
+		if a.Alive() && filter(a) {
 			status, err := s.GetAgentStatus(context.TODO(), &pb.ID{Id: a.ID().String()})
 			if err != nil {
 				return nil, err
@@ -177,6 +321,7 @@ func (s *Server) GetAgentRows(ctx context.Context, e *emptypb.Empty) (*pb.TableD
 				a.Host().Platform + "/" + a.Host().Architecture,
 				a.Host().Name,
 				a.Process().UserName,
+				a.PrivilegeString(),
 				p,
 				status.Message,
 				lastTime,
@@ -189,6 +334,37 @@ func (s *Server) GetAgentRows(ctx context.Context, e *emptypb.Empty) (*pb.TableD
 	return data, nil
 }
 
+// GetAgentRoutes returns the peer-to-peer topology as the route, hop count, and transport chain from the egress Agent
+// to every Agent. Agents that are unreachable, because of a routing loop or the hop limit, have a hop count of -1
+func (s *Server) GetAgentRoutes(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	data := &pb.TableData{
+		Header: []string{"Agent GUID", "Egress", "Hops", "Transports", "Route"},
+	}
+	for _, route := range s.agentService.Routes() {
+		var egress string
+		var transports, hops []string
+		for i, id := range route.Path {
+			if i == 0 {
+				egress = id.String()
+			}
+			hops = append(hops, id.String())
+			if a, err := s.agentService.Agent(id); err == nil {
+				transports = append(transports, a.Comms().Proto)
+			}
+		}
+		row := []string{
+			route.Agent.String(),
+			egress,
+			strconv.Itoa(route.Hops()),
+			strings.Join(transports, " -> "),
+			strings.Join(hops, " -> "),
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
 // GetAgents returns a list of existing Agent UUID values
 func (s *Server) GetAgents(ctx context.Context, e *emptypb.Empty) (*pb.Slice, error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
@@ -201,6 +377,18 @@ func (s *Server) GetAgents(ctx context.Context, e *emptypb.Empty) (*pb.Slice, er
 	return &pb.Slice{Data: agentIDs}, nil
 }
 
+// GetAgentNames returns a table of every Agent's ID and friendly name, which can be used instead of its ID
+func (s *Server) GetAgentNames(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	table := &pb.TableData{
+		Header: []string{"Name", "ID", "Alive"},
+	}
+	for _, a := range s.agentService.Agents() {
+		table.Rows = append(table.Rows, &pb.TableRows{Row: []string{a.Name(), a.ID().String(), fmt.Sprintf("%t", a.Alive())}})
+	}
+	return table, nil
+}
+
 // GetAgentStatus returns the status of an Agent (e.g., alive, dead, or delayed)
 func (s *Server) GetAgentStatus(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
@@ -346,6 +534,75 @@ func (s *Server) Note(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, er
 	return
 }
 
+// Quarantine stops the agent from being tasked, holds its queued jobs, and hides it from broadcast jobs until it is
+// released
+// in.Arguments[0] optional reason the agent is quarantined
+func (s *Server) Quarantine(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	agentUUID, err := uuid.Parse(in.ID)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		slog.Error(err.Error())
+		return
+	}
+	reason := "manually quarantined by an operator"
+	if len(in.Arguments) > 0 && strings.TrimSpace(strings.Join(in.Arguments, " ")) != "" {
+		reason = strings.TrimSpace(strings.Join(in.Arguments, " "))
+	}
+	err = s.agentService.Quarantine(agentUUID, reason)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Agent %s was quarantined and can't be tasked until it is released", agentUUID))
+	return
+}
+
+// Rename sets the agent's friendly name, which can be used anywhere the agent's ID is accepted
+// in.Arguments[0] the new name
+func (s *Server) Rename(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	if len(in.Arguments) < 1 {
+		err = fmt.Errorf("the Rename RPC call requires one argument, have (%d): %s", len(in.Arguments), in.Arguments)
+		slog.Error(err.Error())
+		return
+	}
+	agentUUID, err := uuid.Parse(in.ID)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		slog.Error(err.Error())
+		return
+	}
+	err = s.agentService.Rename(agentUUID, in.Arguments[0])
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Agent %s was renamed to %s", agentUUID, strings.ToLower(in.Arguments[0])))
+	return
+}
+
+// Release allows a quarantined agent to be tasked again
+func (s *Server) Release(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	msg = &pb.Message{}
+	agentUUID, err := uuid.Parse(id.Id)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id, err)
+		slog.Error(err.Error())
+		return
+	}
+	err = s.agentService.Release(agentUUID)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Agent %s was released from quarantine and can be tasked", agentUUID))
+	return
+}
+
 // Remove deletes the agent from the server
 func (s *Server) Remove(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
@@ -0,0 +1,124 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+)
+
+// TestGetPrivilegedAgentRows verifies only Agents running as an administrator, SYSTEM, or root are listed
+func TestGetPrivilegedAgentRows(t *testing.T) {
+	s := newServer()
+	tests := []struct {
+		name       string
+		sysInfo    messages.SysInfo
+		privileged bool
+	}{
+		{"windows user", messages.SysInfo{Platform: "windows", UserName: "ACME\\jdoe", Integrity: 2}, false},
+		{"windows admin", messages.SysInfo{Platform: "windows", UserName: "ACME\\jdoe", Integrity: 3}, true},
+		{"linux user", messages.SysInfo{Platform: "linux", UserName: "jdoe"}, false},
+		{"linux root", messages.SysInfo{Platform: "linux", UserName: "root"}, true},
+	}
+	var ids []string
+	for _, test := range tests {
+		a := newTestAgent(t, s)
+		err := s.agentService.UpdateAgentInfo(a.ID(), messages.AgentInfo{SysInfo: test.sysInfo})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, a.ID().String())
+	}
+
+	data, err := s.GetPrivilegedAgentRows(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := make(map[string][]string)
+	for _, row := range data.Rows {
+		rows[row.Row[0]] = row.Row
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, listed := rows[ids[i]]; listed != test.privileged {
+				t.Errorf("expected the Agent to be listed %t, have %t", test.privileged, listed)
+			}
+		})
+	}
+}
+
+// TestGetAgentActivity verifies only alive Agents whose user activity was measured are listed
+func TestGetAgentActivity(t *testing.T) {
+	s := newServer()
+	checked := time.Now().UTC()
+	tests := []struct {
+		name     string
+		activity agents.Activity
+		alive    bool
+		listed   bool
+	}{
+		{"measured", agents.Activity{Checked: checked, Idle: time.Hour, Sessions: []agents.UserSession{{User: "ACME\\jdoe", State: "locked"}}}, true, true},
+		{"not measured", agents.Activity{}, true, false},
+		{"dead", agents.Activity{Checked: checked}, false, false},
+	}
+	var ids []string
+	for _, test := range tests {
+		a := newTestAgent(t, s)
+		if err := s.agentService.UpdateActivity(a.ID(), test.activity); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.agentService.UpdateAlive(a.ID(), test.alive); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, a.ID().String())
+	}
+
+	data, err := s.GetAgentActivity(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := make(map[string][]string)
+	for _, row := range data.Rows {
+		rows[row.Row[0]] = row.Row
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			row, listed := rows[ids[i]]
+			if listed != test.listed {
+				t.Fatalf("expected the Agent to be listed %t, have %t", test.listed, listed)
+			}
+			if listed && row[6] != "ACME\\jdoe locked" {
+				t.Errorf("expected the sessions to be listed, have %q", row[6])
+			}
+		})
+	}
+}
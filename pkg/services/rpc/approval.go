@@ -0,0 +1,310 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	"github.com/Ne0nd0g/merlin/v2/pkg/plugins"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+)
+
+const (
+	// approvalExpiry is how long a request waits for a second operator's approval before it is discarded
+	approvalExpiry = time.Hour
+	// unidentified is shown as the requester of a call that did not carry a registered client ID
+	unidentified = "unidentified"
+)
+
+// pendingRequest is an RPC call for a command class that a second operator must approve before it is dispatched
+type pendingRequest struct {
+	id        uuid.UUID
+	class     string
+	requester string
+	agent     string
+	arguments []string
+	created   time.Time
+	req       interface{}
+	handler   grpc.UnaryHandler
+}
+
+// approvals holds the requests waiting to be approved
+var approvals = struct {
+	sync.Mutex
+	pending map[uuid.UUID]*pendingRequest
+}{
+	pending: make(map[uuid.UUID]*pendingRequest),
+}
+
+// SetApprovalPolicy enables two-person integrity for the provided comma separated command classes. A command class is
+// the RPC method or job type name (e.g., destroy, rm, or exit); "broadcast" also covers any command tasked to every
+// Agent at once. Calls for these classes are held until an operator other than the one who made the call approves them,
+// and scripts or other server-side automation can't task them at all.
+//
+// Operators are told apart by the client ID their CLI registered with this server. Every operator authenticates with
+// the same server password, so the policy stops a single operator from dispatching a command by mistake but cannot
+// stop one person who runs two CLI clients; the log records the client IDs that requested and approved each command
+func SetApprovalPolicy(classes string) {
+	job.SetApprovalPolicy(classes)
+	if strings.TrimSpace(strings.ReplaceAll(classes, ",", "")) != "" {
+		slog.Info(fmt.Sprintf("Two-person integrity is required for the following command classes: %s", classes))
+	}
+}
+
+// approvalClass returns the command class of the RPC call if it requires approval, or an empty string if it does not.
+// Only calls that task an Agent are held. The CMD and Any RPC methods task the job type in their first argument, and
+// RunPlugin the plugin command named in its first argument
+func approvalClass(method string, req interface{}) string {
+	var agent string
+	var jobType string
+	switch in := req.(type) {
+	case *pb.AgentCMD:
+		agent = in.ID
+		if len(in.Arguments) > 0 {
+			jobType = in.Arguments[0]
+		}
+	case *pb.ID:
+		agent = in.Id
+	default:
+		return ""
+	}
+	class := strings.ToLower(path.Base(method))
+	if job.RequiresApproval(class) {
+		return class
+	}
+	if (class == "cmd" || class == "any") && jobType != "" && job.RequiresApproval(jobType) {
+		return strings.ToLower(jobType)
+	}
+	// Plugin commands are held for their own name or the built-in job type they are sent as
+	if class == "runplugin" && jobType != "" {
+		if command, err := plugins.Get(jobType); err == nil {
+			for _, c := range []string{command.Name, command.Job} {
+				if c != "" && job.RequiresApproval(c) {
+					return strings.ToLower(c)
+				}
+			}
+		}
+	}
+	if agent == "ffffffff-ffff-ffff-ffff-ffffffffffff" && job.RequiresApproval("broadcast") {
+		return "broadcast"
+	}
+	return ""
+}
+
+// operator identifies the operator who made the RPC call by the "client" metadata the CLI sends with the ID it
+// registered with this server. An empty string is returned if the metadata is missing or isn't a registered client
+func (s *Server) operator(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	id, err := uuid.Parse(strings.Join(md["client"], ""))
+	if err != nil {
+		return ""
+	}
+	if _, err = s.clientRepo.Get(id); err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+// expireRequests discards the requests that were not approved or denied within the approvalExpiry and notifies the
+// connected clients
+func (s *Server) expireRequests() {
+	now := time.Now().UTC()
+	var expired []*pendingRequest
+	approvals.Lock()
+	for id, request := range approvals.pending {
+		if now.Sub(request.created) >= approvalExpiry {
+			expired = append(expired, request)
+			delete(approvals.pending, id)
+		}
+	}
+	approvals.Unlock()
+	for _, request := range expired {
+		note := fmt.Sprintf("Request %s from %s for the '%s' command expired after %s without being approved", request.id, request.requester, request.class, approvalExpiry)
+		slog.Info(note)
+		s.messageRepo.Add(message.NewMessage(message.Warn, note))
+	}
+}
+
+// approval is a gRPC interceptor that holds calls for command classes that require two-person integrity until a second
+// operator approves them with the ApproveRequest RPC
+func (s *Service) approval(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	class := approvalClass(info.FullMethod, req)
+	if class == "" {
+		return handler(ctx, req)
+	}
+	s.rpcServer.expireRequests()
+	requester := s.rpcServer.operator(ctx)
+	if requester == "" {
+		requester = unidentified
+	}
+	request := &pendingRequest{
+		id:        uuid.New(),
+		class:     class,
+		requester: requester,
+		created:   time.Now().UTC(),
+		req:       req,
+		handler:   handler,
+	}
+	switch in := req.(type) {
+	case *pb.AgentCMD:
+		request.agent = in.ID
+		request.arguments = in.Arguments
+	case *pb.ID:
+		request.agent = in.Id
+	}
+	approvals.Lock()
+	approvals.pending[request.id] = request
+	approvals.Unlock()
+
+	msg := fmt.Sprintf("Operator %s requested the '%s' command for agent %s, it requires approval from a second operator: %s", request.requester, class, request.agent, request.id)
+	slog.Info(msg)
+	s.rpcServer.messageRepo.Add(message.NewMessage(message.Warn, msg))
+	return NewPBNoteMessage(fmt.Sprintf("The '%s' command requires approval from a second operator, created request %s", class, request.id)), nil
+}
+
+// ApproveRequest dispatches a command that is waiting on two-person integrity. The operator approving the request must
+// be identified by a registered client ID and cannot be the operator who made it
+func (s *Server) ApproveRequest(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	msg = &pb.Message{}
+	requestID, err := uuid.Parse(id.Id)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.ApproveRequest(): there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		slog.Error(err.Error())
+		return
+	}
+	approver := s.operator(ctx)
+	if approver == "" {
+		err = fmt.Errorf("pkg/services/rpc.ApproveRequest(): approving a request requires the 'client' metadata with a registered client ID")
+		slog.Error(err.Error())
+		return
+	}
+	s.expireRequests()
+
+	approvals.Lock()
+	request, ok := approvals.pending[requestID]
+	if ok && request.requester != approver {
+		delete(approvals.pending, requestID)
+	}
+	approvals.Unlock()
+	if !ok {
+		err = fmt.Errorf("pkg/services/rpc.ApproveRequest(): request %s does not exist or expired", requestID)
+		slog.Error(err.Error())
+		return
+	}
+	if request.requester == approver {
+		err = fmt.Errorf("pkg/services/rpc.ApproveRequest(): request %s must be approved by an operator other than %s", requestID, approver)
+		slog.Error(err.Error())
+		return
+	}
+
+	note := fmt.Sprintf("Operator %s approved request %s from %s for the '%s' command", approver, requestID, request.requester, request.class)
+	slog.Info(note)
+	s.messageRepo.Add(message.NewMessage(message.Info, note))
+	resp, err := request.handler(ctx, request.req)
+	if err != nil {
+		return
+	}
+	if m, ok := resp.(*pb.Message); ok {
+		msg = m
+	}
+	return
+}
+
+// DenyRequest discards a command that is waiting on two-person integrity without dispatching it
+func (s *Server) DenyRequest(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	msg = &pb.Message{}
+	requestID, err := uuid.Parse(id.Id)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.DenyRequest(): there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		slog.Error(err.Error())
+		return
+	}
+	s.expireRequests()
+	approvals.Lock()
+	request, ok := approvals.pending[requestID]
+	delete(approvals.pending, requestID)
+	approvals.Unlock()
+	if !ok {
+		err = fmt.Errorf("pkg/services/rpc.DenyRequest(): request %s does not exist or expired", requestID)
+		slog.Error(err.Error())
+		return
+	}
+	denier := s.operator(ctx)
+	if denier == "" {
+		denier = unidentified
+	}
+	note := fmt.Sprintf("Operator %s denied request %s from %s for the '%s' command", denier, requestID, request.requester, request.class)
+	slog.Info(note)
+	s.messageRepo.Add(message.NewMessage(message.Info, note))
+	msg = NewPBSuccessMessage(fmt.Sprintf("Denied request %s", requestID))
+	return
+}
+
+// GetPendingRequests returns the commands that are waiting on approval from a second operator
+func (s *Server) GetPendingRequests(ctx context.Context, e *emptypb.Empty) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "e", e)
+	table = &pb.TableData{
+		Header: []string{"ID", "Command", "Agent", "Arguments", "Requester", "Created"},
+	}
+	s.expireRequests()
+	approvals.Lock()
+	var requests []*pendingRequest
+	for _, request := range approvals.pending {
+		requests = append(requests, request)
+	}
+	approvals.Unlock()
+	sort.Slice(requests, func(i, j int) bool { return requests[i].created.Before(requests[j].created) })
+	for _, request := range requests {
+		row := []string{
+			request.id.String(),
+			request.class,
+			request.agent,
+			strings.Join(request.arguments, " "),
+			request.requester,
+			request.created.Format(time.RFC3339),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
@@ -0,0 +1,262 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client"
+	clientMemory "github.com/Ne0nd0g/merlin/v2/pkg/client/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// withOperator returns a context for an RPC call made by the operator with the provided client ID
+func withOperator(client string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("client", client))
+}
+
+// newApprovalServer returns a Server with the provided number of registered client IDs to act as operators
+func newApprovalServer(clients int) (*Server, []string) {
+	server := &Server{messageRepo: memory.NewRepository(), clientRepo: clientMemory.NewRepository()}
+	var ids []string
+	for i := 0; i < clients; i++ {
+		c := client.NewClient()
+		server.clientRepo.Add(c)
+		ids = append(ids, c.ID().String())
+	}
+	return server, ids
+}
+
+// clearApprovals removes the approval policy and any pending requests once the test finishes
+func clearApprovals(t *testing.T) {
+	t.Cleanup(func() {
+		SetApprovalPolicy("")
+		approvals.Lock()
+		approvals.pending = make(map[uuid.UUID]*pendingRequest)
+		approvals.Unlock()
+	})
+}
+
+// TestApprovalClass verifies which RPC calls are held for approval
+func TestApprovalClass(t *testing.T) {
+	clearApprovals(t)
+	agent := uuid.NewString()
+	broadcast := "ffffffff-ffff-ffff-ffff-ffffffffffff"
+
+	if class := approvalClass("/rpc.Merlin/Destroy", &pb.AgentCMD{ID: agent}); class != "" {
+		t.Fatalf("expected no calls to be held without a policy, got %q", class)
+	}
+
+	loadPlugins(t, `
+commands:
+  - name: wipe
+    job: rm
+    args: ["{{1}}"]
+  - name: destroy-all
+    job: destroy
+  - name: whoami-all
+    job: run
+    args: ["whoami.exe", "/all"]
+`)
+	SetApprovalPolicy(" destroy, RM ,,broadcast,destroy-all")
+	tests := []struct {
+		name   string
+		method string
+		req    interface{}
+		want   string
+	}{
+		{"agent command", "/rpc.Merlin/Destroy", &pb.AgentCMD{ID: agent}, "destroy"},
+		{"class is case-insensitive", "/rpc.Merlin/RM", &pb.AgentCMD{ID: agent, Arguments: []string{"C:\\file.txt"}}, "rm"},
+		{"class not in the policy", "/rpc.Merlin/Exit", &pb.ID{Id: agent}, ""},
+		{"broadcast with an ID", "/rpc.Merlin/Exit", &pb.ID{Id: broadcast}, "broadcast"},
+		{"broadcast with an AgentCMD", "/rpc.Merlin/Sleep", &pb.AgentCMD{ID: broadcast, Arguments: []string{"1h"}}, "broadcast"},
+		{"any with a held job type", "/rpc.Merlin/Any", &pb.AgentCMD{ID: agent, Arguments: []string{"Destroy"}}, "destroy"},
+		{"cmd with a held job type", "/rpc.Merlin/CMD", &pb.AgentCMD{ID: agent, Arguments: []string{"rm", "C:\\file.txt"}}, "rm"},
+		{"any with another job type", "/rpc.Merlin/Any", &pb.AgentCMD{ID: agent, Arguments: []string{"pwd"}}, ""},
+		{"plugin sent as a held job type", "/rpc.Merlin/RunPlugin", &pb.AgentCMD{ID: agent, Arguments: []string{"wipe", "C:\\file.txt"}}, "rm"},
+		{"held plugin command", "/rpc.Merlin/RunPlugin", &pb.AgentCMD{ID: agent, Arguments: []string{"destroy-all"}}, "destroy-all"},
+		{"plugin with another job type", "/rpc.Merlin/RunPlugin", &pb.AgentCMD{ID: agent, Arguments: []string{"whoami-all"}}, ""},
+		{"unknown plugin command", "/rpc.Merlin/RunPlugin", &pb.AgentCMD{ID: agent, Arguments: []string{"missing"}}, ""},
+		{"call that doesn't task an agent", "/rpc.Merlin/Destroy", &pb.Options{}, ""},
+		{"empty request", "/rpc.Merlin/GetAgents", &emptypb.Empty{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if class := approvalClass(test.method, test.req); class != test.want {
+				t.Errorf("expected class %q, got %q", test.want, class)
+			}
+		})
+	}
+}
+
+// TestOperator verifies the operator is only identified by a client ID registered with the server
+func TestOperator(t *testing.T) {
+	server, ids := newApprovalServer(1)
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"registered client", withOperator(ids[0]), ids[0]},
+		{"unregistered client", withOperator(uuid.NewString()), ""},
+		{"not a client ID", withOperator("operator1"), ""},
+		{"empty client metadata", withOperator(""), ""},
+		{"no metadata", context.Background(), ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := server.operator(test.ctx); got != test.want {
+				t.Errorf("expected operator %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+// TestApproveRequest verifies a held call is only dispatched once an operator other than the requester approves it
+func TestApproveRequest(t *testing.T) {
+	clearApprovals(t)
+	SetApprovalPolicy("destroy")
+	server, operators := newApprovalServer(3)
+	service := &Service{rpcServer: server}
+
+	var dispatched int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		dispatched++
+		return NewPBSuccessMessage("destroyed"), nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpc.Merlin/Destroy"}
+	_, err := service.approval(withOperator(operators[0]), &pb.AgentCMD{ID: uuid.NewString()}, info, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dispatched != 0 {
+		t.Fatal("expected the call to be held for approval")
+	}
+
+	table, err := server.GetPendingRequests(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Rows) != 1 {
+		t.Fatalf("expected 1 pending request, got %d", len(table.Rows))
+	}
+	id := &pb.ID{Id: table.Rows[0].Row[0]}
+
+	for _, ctx := range []context.Context{context.Background(), withOperator("operator2"), withOperator(uuid.NewString())} {
+		_, err = server.ApproveRequest(ctx, id)
+		if err == nil || !strings.Contains(err.Error(), "registered client ID") {
+			t.Fatalf("expected an operator without a registered client ID to be refused, got: %v", err)
+		}
+	}
+
+	_, err = server.ApproveRequest(withOperator(operators[0]), id)
+	if err == nil || !strings.Contains(err.Error(), "must be approved by an operator other than "+operators[0]) {
+		t.Fatalf("expected the requester to be refused approving their own request, got: %v", err)
+	}
+	if dispatched != 0 {
+		t.Fatal("expected the call to stay held after the requester tried to approve it")
+	}
+
+	msg, err := server.ApproveRequest(withOperator(operators[1]), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dispatched != 1 || msg.Message != "destroyed" {
+		t.Fatalf("expected the approved call to be dispatched once and return its response, dispatched %d times: %+v", dispatched, msg)
+	}
+
+	_, err = server.ApproveRequest(withOperator(operators[2]), id)
+	if err == nil {
+		t.Fatal("expected a request to only be approved once")
+	}
+}
+
+// TestRequestExpiry verifies requests that wait longer than the approvalExpiry are discarded
+func TestRequestExpiry(t *testing.T) {
+	clearApprovals(t)
+	server, operators := newApprovalServer(1)
+
+	expired := &pendingRequest{id: uuid.New(), class: "destroy", requester: "operator1", created: time.Now().UTC().Add(-approvalExpiry)}
+	current := &pendingRequest{id: uuid.New(), class: "destroy", requester: "operator1", created: time.Now().UTC()}
+	approvals.Lock()
+	approvals.pending[expired.id] = expired
+	approvals.pending[current.id] = current
+	approvals.Unlock()
+
+	table, err := server.GetPendingRequests(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Rows) != 1 || table.Rows[0].Row[0] != current.id.String() {
+		t.Fatalf("expected only request %s to be pending, got %+v", current.id, table.Rows)
+	}
+
+	_, err = server.ApproveRequest(withOperator(operators[0]), &pb.ID{Id: expired.id.String()})
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected the expired request to be refused, got: %v", err)
+	}
+}
+
+// TestUnidentifiedRequester verifies a call without a registered client ID is still held and can be approved by any
+// registered operator
+func TestUnidentifiedRequester(t *testing.T) {
+	clearApprovals(t)
+	SetApprovalPolicy("rm")
+	server, operators := newApprovalServer(1)
+	service := &Service{rpcServer: server}
+
+	var dispatched int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		dispatched++
+		return NewPBSuccessMessage("removed"), nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpc.Merlin/RM"}
+	_, err := service.approval(withOperator("operator1"), &pb.AgentCMD{ID: uuid.NewString()}, info, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := server.GetPendingRequests(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dispatched != 0 || len(table.Rows) != 1 || table.Rows[0].Row[4] != unidentified {
+		t.Fatalf("expected the call to be held for an %s requester, dispatched %d times: %+v", unidentified, dispatched, table.Rows)
+	}
+	_, err = server.ApproveRequest(withOperator(operators[0]), &pb.ID{Id: table.Rows[0].Row[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dispatched != 1 {
+		t.Errorf("expected the approved call to be dispatched once, dispatched %d times", dispatched)
+	}
+}
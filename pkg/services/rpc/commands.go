@@ -0,0 +1,149 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	// 3rd Party
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/locale"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+)
+
+/* RPC METHODS TO ENUMERATE AGENT COMMANDS */
+
+// GetCommands returns a table of every Agent command the server accepts, built-in and plugin, with its usage,
+// supported platforms, and MITRE ATT&CK techniques
+// in.Data = an optional platform (e.g., windows) to only return commands that platform supports
+func (s *Server) GetCommands(ctx context.Context, in *pb.String) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	platform := strings.ToLower(strings.TrimSpace(in.GetData()))
+	data := &pb.TableData{
+		Header: []string{"Command", "Source", "Platforms", "Usage", "Techniques", "Description"},
+	}
+	for _, c := range commands(ctx, s.jobService.Commands()) {
+		if platform != "" && len(c.Platforms) > 0 && !slices.Contains(c.Platforms, platform) {
+			continue
+		}
+		source := "built-in"
+		if c.Plugin != "" {
+			source = fmt.Sprintf("plugin %s", c.Plugin)
+		} else if c.ServerSide {
+			source = "server"
+		}
+		platforms := strings.Join(c.Platforms, ",")
+		if platforms == "" {
+			platforms = "all"
+		}
+		row := []string{
+			c.Name,
+			source,
+			platforms,
+			c.Usage,
+			strings.Join(c.Techniques, ","),
+			c.Description,
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
+// ExportCommands returns every Agent command the server accepts as JSON so that documentation generators and other
+// user interfaces can stay in sync with the server's command set
+// in.Data = an optional platform (e.g., windows) to only return commands that platform supports
+func (s *Server) ExportCommands(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	platform := strings.ToLower(strings.TrimSpace(in.GetData()))
+	cmds := commands(ctx, s.jobService.Commands())
+	if platform != "" {
+		cmds = slices.DeleteFunc(cmds, func(c job.Command) bool {
+			return len(c.Platforms) > 0 && !slices.Contains(c.Platforms, platform)
+		})
+	}
+	out, err := json.MarshalIndent(cmds, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.ExportCommands(): there was an error marshalling the commands to JSON: %s", err)
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBPlainMessage(string(out))
+	return
+}
+
+// GetLocales returns a table of the loaded message catalogs that command help text can be translated with
+func (s *Server) GetLocales(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	data := &pb.TableData{
+		Header: []string{"Locale", "Commands"},
+	}
+	for _, c := range locale.Locales() {
+		data.Rows = append(data.Rows, &pb.TableRows{Row: []string{c.Locale, fmt.Sprintf("%d", len(c.Commands))}})
+	}
+	return data, nil
+}
+
+// ReloadLocales re-reads every message catalog from the locales directory so that translations can be added or
+// changed without restarting the server
+func (s *Server) ReloadLocales(ctx context.Context, e *emptypb.Empty) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	loaded, errs := locale.Reload()
+	if len(errs) > 0 {
+		m := fmt.Sprintf("Reloaded %d locale catalogs with %d errors:", loaded, len(errs))
+		for _, e := range errs {
+			m += fmt.Sprintf("\n\t%s", e)
+		}
+		msg = NewPBWarnMessage(m)
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Reloaded %d locale catalogs", loaded))
+	return
+}
+
+// language returns the locale the operator selected in their client configuration, sent as the "locale" gRPC metadata,
+// or an empty string to use the server's default locale
+func language(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		return strings.Join(md["locale"], "")
+	}
+	return ""
+}
+
+// commands translates the help text of the provided commands into the operator's locale
+func commands(ctx context.Context, cmds []job.Command) []job.Command {
+	l := language(ctx)
+	for i, c := range cmds {
+		cmds[i].Description, cmds[i].Usage = locale.Command(l, c.Name, c.Description, c.Usage)
+	}
+	return cmds
+}
@@ -0,0 +1,121 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	// 3rd Party
+	"google.golang.org/grpc/metadata"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/locale"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+)
+
+// TestGetCommands verifies the command set is filtered by platform, keeping commands every platform supports
+func TestGetCommands(t *testing.T) {
+	s := newServer()
+	tests := []struct {
+		platform string
+		include  []string
+		exclude  []string
+	}{
+		{"", []string{"ps", "memfd", "pwd"}, nil},
+		{"windows", []string{"ps", "pwd"}, []string{"memfd"}},
+		{" Linux ", []string{"memfd", "pwd"}, []string{"ps"}},
+	}
+	for _, test := range tests {
+		t.Run(test.platform, func(t *testing.T) {
+			table, err := s.GetCommands(context.Background(), &pb.String{Data: test.platform})
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg, err := s.ExportCommands(context.Background(), &pb.String{Data: test.platform})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var exported []job.Command
+			if err = json.Unmarshal([]byte(msg.Message), &exported); err != nil {
+				t.Fatal(err)
+			}
+			if len(exported) != len(table.Rows) {
+				t.Errorf("expected the export and table to have the same commands, have %d and %d", len(exported), len(table.Rows))
+			}
+			listed := make(map[string]bool)
+			for _, row := range table.Rows {
+				listed[row.Row[0]] = true
+			}
+			for _, name := range test.include {
+				if !listed[name] {
+					t.Errorf("expected the %s command to be listed", name)
+				}
+			}
+			for _, name := range test.exclude {
+				if listed[name] {
+					t.Errorf("expected the %s command to not be listed", name)
+				}
+			}
+		})
+	}
+}
+
+// TestGetCommandsLocale verifies command help text is translated into the locale the client sends as gRPC metadata
+func TestGetCommandsLocale(t *testing.T) {
+	s := newServer()
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "es.yaml"), []byte("commands:\n  pwd:\n    description: Directorio actual\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := locale.Load(dir); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	t.Cleanup(func() { locale.Load(filepath.Join(dir, "none")) })
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"client locale", metadata.NewIncomingContext(context.Background(), metadata.Pairs("locale", "es")), "Directorio actual"},
+		{"no locale", context.Background(), "Return the Agent's working directory"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table, err := s.GetCommands(test.ctx, &pb.String{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range table.Rows {
+				if row.Row[0] == "pwd" && row.Row[5] != test.want {
+					t.Errorf("expected the description %q, have %q", test.want, row.Row[5])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/credentials"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH THE CREDENTIAL STORE */
+
+// AddCredential stores a credential provided by the operator
+// in.Options["Type"] = the credential type (e.g., plaintext, hash, key, token)
+// in.Options["Domain"] = optional domain or realm the credential is valid for
+// in.Options["Username"] = the account the credential belongs to
+// in.Options["Secret"] = the password, hash, key, or token
+func (s *Server) AddCredential(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	credType := credentials.PLAINTEXT
+	if t, ok := in.Options["Type"]; ok && t != "" {
+		credType, err = credentials.FromString(t)
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.AddCredential(): %s", err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+	if in.Options["Secret"] == "" {
+		err = fmt.Errorf("pkg/services/rpc.AddCredential(): a secret is required")
+		slog.Error(err.Error())
+		return
+	}
+	credential := credentials.NewCredential(credType, in.Options["Domain"], in.Options["Username"], in.Options["Secret"], "operator", uuid.Nil, false)
+	err = s.credService.Add(credential)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.AddCredential(): %s", err)
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Added %s credential %s to the credential store", credType, credential.ID()))
+	return
+}
+
+// GetCredentials returns a table of all credentials in the credential store.
+// Automatically detected credentials that have not been verified by an operator are flagged
+func (s *Server) GetCredentials(ctx context.Context, e *emptypb.Empty) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "e", e)
+	table = &pb.TableData{
+		Header: []string{"ID", "Type", "Domain", "Username", "Secret", "Source", "Agent", "Flagged", "Created"},
+	}
+	for _, c := range s.credService.All() {
+		agent := ""
+		if c.AgentID() != uuid.Nil {
+			agent = c.AgentID().String()
+		}
+		row := []string{
+			c.ID().String(),
+			c.Type().String(),
+			c.Domain(),
+			c.Username(),
+			c.Secret(),
+			c.Source(),
+			agent,
+			fmt.Sprintf("%t", c.Flagged()),
+			c.Created().Format(time.RFC3339),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
+// RemoveCredential deletes the credential for the provided ID from the credential store
+func (s *Server) RemoveCredential(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	msg = &pb.Message{}
+	credentialID, err := uuid.Parse(id.Id)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.RemoveCredential(): there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		slog.Error(err.Error())
+		return
+	}
+	err = s.credService.Remove(credentialID)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.RemoveCredential(): %s", err)
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Removed credential %s from the credential store", credentialID))
+	return
+}
@@ -0,0 +1,70 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/directory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH THE ACTIVE DIRECTORY DATASTORE */
+
+// GetDirectoryObjects returns a table of Active Directory objects enumerated by Agents with the ad command
+// in.Options["Class"] = optional object class to return (e.g., users, computers, groups, gpos, trusts)
+// in.Options["Search"] = optional term the object's distinguished name or attribute values must contain
+func (s *Server) GetDirectoryObjects(ctx context.Context, in *pb.Options) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	table = &pb.TableData{
+		Header: []string{"Name", "Class", "Distinguished Name", "Description", "Agent", "Collected"},
+	}
+
+	class := directory.UNDEFINED
+	if c := in.GetOptions()["Class"]; c != "" {
+		class, err = directory.FromString(c)
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.GetDirectoryObjects(): %s", err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	for _, object := range s.dirService.Query(class, in.GetOptions()["Search"]) {
+		row := []string{
+			object.Name(),
+			object.Class().String(),
+			object.DN(),
+			strings.Join(object.Attribute("description"), ", "),
+			object.AgentID().String(),
+			object.Collected().Format(time.RFC3339),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
@@ -0,0 +1,76 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"errors"
+
+	// 3rd Party
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/message"
+)
+
+// errorCodes maps the service layer's typed errors to their gRPC status codes
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{listeners.ErrListenerNotFound, codes.NotFound},
+	{listeners.ErrDuplicateName, codes.AlreadyExists},
+	{listeners.ErrInvalidOption, codes.InvalidArgument},
+	{listeners.ErrAmbiguousID, codes.InvalidArgument},
+	{message.ErrListenerDraining, codes.FailedPrecondition},
+	{agent.ErrAgentNotFound, codes.NotFound},
+	{agent.ErrAgentExists, codes.AlreadyExists},
+	{agent.ErrRoutingLoop, codes.FailedPrecondition},
+	{agent.ErrAmbiguousID, codes.InvalidArgument},
+}
+
+// statusError converts a service layer error into a gRPC status error so that clients can branch on the error kind.
+// Errors that are not one of the service layer's typed errors are returned unchanged
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, err.Error())
+		}
+	}
+	return err
+}
+
+// errorStatus is a gRPC interceptor that converts the service layer's typed errors into gRPC status errors
+func (s *Service) errorStatus(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, statusError(err)
+}
@@ -0,0 +1,67 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"errors"
+	"fmt"
+	"testing"
+
+	// 3rd Party
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/message"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"listener not found", fmt.Errorf("pkg/services/listeners.Listener(): %w: 1234", listeners.ErrListenerNotFound), codes.NotFound},
+		{"duplicate name", fmt.Errorf("wrapped: %w", listeners.ErrDuplicateName), codes.AlreadyExists},
+		{"invalid option", fmt.Errorf("wrapped: %w", listeners.ErrInvalidOption), codes.InvalidArgument},
+		{"draining", message.ErrListenerDraining, codes.FailedPrecondition},
+		{"agent not found", fmt.Errorf("wrapped: %w", agent.ErrAgentNotFound), codes.NotFound},
+		{"agent exists", agent.ErrAgentExists, codes.AlreadyExists},
+		{"routing loop", fmt.Errorf("wrapped: %w", agent.ErrRoutingLoop), codes.FailedPrecondition},
+		{"status", status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied},
+		{"untyped", errors.New("something went wrong"), codes.Unknown},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := statusError(test.err)
+			if got := status.Code(err); got != test.code {
+				t.Errorf("expected code %s, have %s", test.code, got)
+			}
+			if test.err != nil && err.Error() != test.err.Error() && status.Convert(err).Message() != test.err.Error() {
+				t.Errorf("expected the message %q to be preserved, have %q", test.err, err)
+			}
+		})
+	}
+}
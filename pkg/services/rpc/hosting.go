@@ -0,0 +1,268 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	httpServer "github.com/Ne0nd0g/merlin/v2/pkg/servers/http"
+)
+
+/* RPC METHODS TO HOST FILES FROM MEMORY ON HTTP LISTENERS */
+
+// HostFile serves a file from memory on an HTTP listener at an operator chosen URI.
+// Options: Listener (name or ID), URI, and either File (a path on the server) or Data (base64 encoded contents) with
+// Name. Optional: Once ("true" to remove the file after one download), Expiry (a duration, RFC3339 time, or "never"),
+// ContentType (detected from the contents when empty), UserAgent (a regular expression the downloader's User-Agent
+// must match), and Header ("Name: token", a header the downloader must send)
+func (s *Server) HostFile(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	options := in.GetOptions()
+	if options["Listener"] == "" || options["URI"] == "" {
+		err = fmt.Errorf("pkg/services/rpc.HostFile(): the Listener and URI options are required")
+		slog.Error(err.Error())
+		return
+	}
+	listenerID, err := s.ls.Resolve(options["Listener"])
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.HostFile(): %s", err)
+		slog.Error(err.Error())
+		return
+	}
+
+	file := httpServer.HostedFile{
+		URI:         options["URI"],
+		Name:        options["Name"],
+		ContentType: options["ContentType"],
+		UserAgent:   options["UserAgent"],
+	}
+	if header := options["Header"]; header != "" {
+		name, value, found := strings.Cut(header, ":")
+		if !found || strings.TrimSpace(name) == "" || strings.TrimSpace(value) == "" {
+			err = fmt.Errorf("pkg/services/rpc.HostFile(): the Header option '%s' must be in the form 'Name: token'", header)
+			slog.Error(err.Error())
+			return
+		}
+		file.HeaderName = strings.TrimSpace(name)
+		file.HeaderValue = strings.TrimSpace(value)
+	}
+	var data []byte
+	switch {
+	case options["File"] != "":
+		data, err = os.ReadFile(options["File"])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.HostFile(): there was an error reading %s: %s", options["File"], err)
+			slog.Error(err.Error())
+			return
+		}
+		if file.Name == "" {
+			file.Name = filepath.Base(options["File"])
+		}
+	case options["Data"] != "":
+		data, err = base64.StdEncoding.DecodeString(options["Data"])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.HostFile(): there was an error base64 decoding the file data: %s", err)
+			slog.Error(err.Error())
+			return
+		}
+		if file.Name == "" {
+			file.Name = filepath.Base(file.URI)
+		}
+	default:
+		err = fmt.Errorf("pkg/services/rpc.HostFile(): either the File or Data option is required")
+		slog.Error(err.Error())
+		return
+	}
+
+	if options["Once"] != "" {
+		file.Once, err = strconv.ParseBool(options["Once"])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.HostFile(): there was an error parsing Once '%s' as a boolean: %s", options["Once"], err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+	if expiry := options["Expiry"]; expiry != "" && !strings.EqualFold(expiry, "never") {
+		if d, errD := time.ParseDuration(expiry); errD == nil {
+			file.Expires = time.Now().UTC().Add(d)
+		} else if file.Expires, err = time.Parse(time.RFC3339, expiry); err != nil {
+			err = fmt.Errorf("pkg/services/rpc.HostFile(): '%s' is not a duration, RFC3339 time, or \"never\"", expiry)
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	hosted, err := s.ls.HostFile(listenerID, file, data)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	_, err = s.payloads.Register(hosted.Name, data, time.Time{}, listenerID, s.operator(ctx), "hosted")
+	if err != nil {
+		slog.Warn(fmt.Sprintf("there was an error adding hosted file %s to the payload inventory: %s", hosted.Name, err))
+		err = nil
+	}
+	m := fmt.Sprintf("Hosting %s (%d bytes, %s) at %s on listener %s", hosted.Name, hosted.Size, hosted.ContentType, hosted.URI, listenerID)
+	if hosted.Once {
+		m += " for one download"
+	}
+	if !hosted.Expires.IsZero() {
+		m += fmt.Sprintf(" until %s", hosted.Expires.Format(time.RFC3339))
+	}
+	if hosted.Gated() {
+		m += fmt.Sprintf(", downloads are gated by %s", hostedGate(hosted))
+	}
+	msg = NewPBSuccessMessage(m)
+	return
+}
+
+// UnhostFile stops an HTTP listener from serving the file at a URI.
+// Options: Listener (name or ID) and URI
+func (s *Server) UnhostFile(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	options := in.GetOptions()
+	if options["Listener"] == "" || options["URI"] == "" {
+		err = fmt.Errorf("pkg/services/rpc.UnhostFile(): the Listener and URI options are required")
+		slog.Error(err.Error())
+		return
+	}
+	listenerID, err := s.ls.Resolve(options["Listener"])
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.UnhostFile(): %s", err)
+		slog.Error(err.Error())
+		return
+	}
+	err = s.ls.UnhostFile(listenerID, options["URI"])
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Stopped hosting %s on listener %s", options["URI"], listenerID))
+	return
+}
+
+// GetHostedFiles returns a table of the files hosted by an HTTP listener
+func (s *Server) GetHostedFiles(ctx context.Context, id *pb.ID) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	listenerID, err := s.ls.Resolve(id.GetId())
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.GetHostedFiles(): %s", err)
+		slog.Error(err.Error())
+		return nil, err
+	}
+	files, err := s.ls.HostedFiles(listenerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	data := &pb.TableData{
+		Header: []string{"URI", "Name", "Content Type", "Size", "Once", "Expires", "Gate", "Downloads", "Blocked", "Created"},
+	}
+	for _, file := range files {
+		expires := "never"
+		if !file.Expires.IsZero() {
+			expires = file.Expires.Format(time.RFC3339)
+		}
+		row := []string{
+			file.URI,
+			file.Name,
+			file.ContentType,
+			strconv.Itoa(file.Size),
+			strconv.FormatBool(file.Once),
+			expires,
+			hostedGate(file),
+			strconv.Itoa(file.Downloads),
+			strconv.Itoa(file.Blocked),
+			file.Created.Format(time.RFC3339),
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
+// GetHostedFileAccess returns a table of the most recent requests for a hosted file, including those refused by its
+// User-Agent or header gate, to distinguish the intended target from scanners.
+// Options: Listener (name or ID) and URI
+func (s *Server) GetHostedFileAccess(ctx context.Context, in *pb.Options) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	options := in.GetOptions()
+	if options["Listener"] == "" || options["URI"] == "" {
+		err := fmt.Errorf("pkg/services/rpc.GetHostedFileAccess(): the Listener and URI options are required")
+		slog.Error(err.Error())
+		return nil, err
+	}
+	listenerID, err := s.ls.Resolve(options["Listener"])
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.GetHostedFileAccess(): %s", err)
+		slog.Error(err.Error())
+		return nil, err
+	}
+	accesses, err := s.ls.HostedAccesses(listenerID, options["URI"])
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	data := &pb.TableData{
+		Header: []string{"Time", "Client", "Method", "User-Agent", "Served", "Reason"},
+	}
+	for _, access := range accesses {
+		row := []string{
+			access.Time.Format(time.RFC3339),
+			access.Client,
+			access.Method,
+			access.UserAgent,
+			strconv.FormatBool(access.Allowed),
+			access.Reason,
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
+// hostedGate describes the User-Agent and header checks a hosted file's downloader must pass without revealing the
+// header token
+func hostedGate(file httpServer.HostedFile) string {
+	var gates []string
+	if file.UserAgent != "" {
+		gates = append(gates, fmt.Sprintf("User-Agent ~ %s", file.UserAgent))
+	}
+	if file.HeaderName != "" {
+		gates = append(gates, fmt.Sprintf("%s header", file.HeaderName))
+	}
+	if len(gates) == 0 {
+		return "none"
+	}
+	return strings.Join(gates, ", ")
+}
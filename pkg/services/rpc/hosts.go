@@ -0,0 +1,172 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/hosts"
+)
+
+/* RPC METHODS TO INTERACT WITH THE HOSTS SERVICE */
+
+// AddHostNote adds an operator's comment to a host
+// in.Options["Address"] = one of the host's IP addresses
+// in.Options["Note"] = the comment to add
+func (s *Server) AddHostNote(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	options := in.GetOptions()
+	err = s.hostService.AddNote(options["Address"], options["Note"])
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Added note to host %s", options["Address"]))
+	return
+}
+
+// GetHostActivity returns a table of the commands Agents ran on a host and the artifacts jobs created on it, which is
+// used to build the engagement's final report
+// in.Data = the host's IP address
+func (s *Server) GetHostActivity(ctx context.Context, in *pb.String) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	activity, err := s.hostService.Activity(in.Data)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	table = &pb.TableData{
+		Header: []string{"Time", "Agent", "Job", "Command"},
+	}
+	for _, a := range activity {
+		row := []string{a.Time.Format(time.RFC3339), a.AgentID.String(), a.JobID, a.Command}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
+// GetHost returns everything known about a host: its addresses, names, security products, services, findings, the
+// Agents running on it, the credentials used to access it, and operator notes
+// in.Data = one of the host's IP addresses
+func (s *Server) GetHost(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	h, err := s.hostService.Host(in.Data)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Host: %s\n", h.Address())
+	fmt.Fprintf(&b, "Addresses: %s\n", strings.Join(h.Addresses(), ", "))
+	fmt.Fprintf(&b, "Hostnames: %s\n", strings.Join(h.Hostnames(), ", "))
+	fmt.Fprintf(&b, "OS: %s\n", h.OS())
+	fmt.Fprintf(&b, "Security Products: %s\n", strings.Join(h.Products(), "; "))
+	fmt.Fprintf(&b, "Sources: %s\n", strings.Join(h.Sources(), ", "))
+	fmt.Fprintf(&b, "First Seen: %s\n", h.FirstSeen().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Last Seen: %s\n", h.LastSeen().Format(time.RFC3339))
+	b.WriteString("Ports:\n")
+	for _, p := range h.Ports() {
+		fmt.Fprintf(&b, "\t%s\t%s\t%s\n", p.Key(), p.Service, p.Product)
+	}
+	b.WriteString("Findings:\n")
+	for _, f := range h.Findings() {
+		fmt.Fprintf(&b, "\t%s\t%s (%s) port %d\n", f.Severity, f.Name, f.ID, f.Port)
+	}
+	b.WriteString("Agents:\n")
+	for _, id := range s.hostService.Agents(h) {
+		fmt.Fprintf(&b, "\t%s\n", id)
+	}
+	b.WriteString("Credentials:\n")
+	for _, c := range s.hostService.Credentials(h) {
+		user := c.Username()
+		if c.Domain() != "" {
+			user = fmt.Sprintf("%s\\%s", c.Domain(), c.Username())
+		}
+		fmt.Fprintf(&b, "\t%s\t%s\t%s\n", c.ID(), c.Type(), user)
+	}
+	b.WriteString("Notes:\n")
+	for _, n := range h.Notes() {
+		fmt.Fprintf(&b, "\t%s\t%s\n", n.Time.Format(time.RFC3339), n.Text)
+	}
+	msg = NewPBPlainMessage(b.String())
+	return
+}
+
+// GetHosts returns a table of every host seen in the target environment and the Agents running on them
+func (s *Server) GetHosts(ctx context.Context, e *emptypb.Empty) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "e", e)
+	table = &pb.TableData{
+		Header: []string{"Address", "Hostnames", "OS", "Open Ports", "Findings", "Agents", "Credentials", "Notes", "Sources"},
+	}
+	for _, h := range s.hostService.Hosts() {
+		var ports, agents []string
+		for _, p := range h.Ports() {
+			ports = append(ports, p.Key())
+		}
+		for _, id := range s.hostService.Agents(h) {
+			agents = append(agents, id.String())
+		}
+		row := []string{
+			strings.Join(h.Addresses(), ", "),
+			strings.Join(h.Hostnames(), ", "),
+			h.OS(),
+			strings.Join(ports, ", "),
+			hosts.Summary(h.Findings()),
+			strings.Join(agents, ", "),
+			fmt.Sprintf("%d", len(s.hostService.Credentials(h))),
+			fmt.Sprintf("%d", len(h.Notes())),
+			strings.Join(h.Sources(), ", "),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
+// ImportHosts adds the hosts discovered by an external scanner to the hosts the operators track
+// in.Options["Format"] = the scan's format, either nmap or nessus; it is detected from the data if empty
+// in.Options["Data"] = the contents of the Nmap XML (-oX) or .nessus file
+func (s *Server) ImportHosts(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	options := in.GetOptions()
+	if options["Data"] == "" {
+		err = fmt.Errorf("pkg/services/rpc.ImportHosts(): the Data option is required")
+		slog.Error(err.Error())
+		return
+	}
+	count, err := s.hostService.Import(options["Format"], []byte(options["Data"]))
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Imported %d host(s)", count))
+	return
+}
@@ -0,0 +1,175 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/geoip"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// GetAgentDetails returns the Agent's information grouped into host, network, process, security, comms, and traffic
+// sections along with when the Agent last sent it. The refresh flag tasks the Agent to send its information again, which
+// is reflected the next time the details are requested after the Agent checks in
+// in.ID = the Agent's ID
+// in.Arguments = optionally "--refresh" to queue an agentInfo job for the Agent
+func (s *Server) GetAgentDetails(ctx context.Context, in *pb.AgentCMD) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	agentID, err := uuid.Parse(in.ID)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		slog.Error(err.Error())
+		return nil, err
+	}
+	a, err := s.agentService.Agent(agentID)
+	if err != nil {
+		err = fmt.Errorf("there was an error getting agent %s: %s", agentID, err)
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	data := &pb.TableData{Header: []string{"Section", "Field", "Value"}}
+	add := func(section, field, value string) {
+		if value == "" {
+			return
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: []string{section, field, value}})
+	}
+
+	// When the information was collected so operators can tell if it is stale
+	updated := "never"
+	if !a.Refreshed().IsZero() {
+		updated = fmt.Sprintf("%s (%s ago)", a.Refreshed().Format(time.RFC3339), time.Since(a.Refreshed()).Round(time.Second))
+	}
+	add("Agent", "ID", a.ID().String())
+	add("Agent", "Name", a.Name())
+	add("Agent", "Version", fmt.Sprintf("%s (%s)", a.Build().Version, a.Build().Build))
+	add("Agent", "Campaign", a.Campaign())
+	status, err := s.agentService.Status(agentID)
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	add("Agent", "Status", status)
+	add("Agent", "Initial Check-in", a.Initial().Format(time.RFC3339))
+	add("Agent", "Last Check-in", a.StatusCheckin().Format(time.RFC3339))
+	add("Agent", "Information Updated", updated)
+	if len(in.Arguments) > 0 && (in.Arguments[0] == "--refresh" || in.Arguments[0] == "-r") {
+		var msg *pb.Message
+		msg, err = addJob(ctx, in.ID, "agentInfo", []string{})
+		if err != nil {
+			return nil, err
+		}
+		add("Agent", "Refresh", fmt.Sprintf("%s; the information is updated after the Agent's next check-in", msg.GetMessage()))
+	}
+
+	add("Host", "Name", a.Host().Name)
+	add("Host", "Platform", a.Host().Platform)
+	add("Host", "Architecture", a.Host().Architecture)
+	if loc := a.Location(); loc != nil {
+		add("Host", "Timezone", fmt.Sprintf("%s (UTC%s)", a.Timezone(), time.Now().In(loc).Format("-07:00")))
+	}
+
+	if activity := a.Activity(); !activity.Checked.IsZero() {
+		add("Host", "Last User Activity", fmt.Sprintf("%s (idle %s when measured at %s)", activity.LastActive().Format(time.RFC3339), activity.Idle, activity.Checked.Format(time.RFC3339)))
+		for _, session := range activity.Sessions {
+			add("Host", "Session", session.String())
+		}
+	}
+
+	add("Network", "IPs", strings.Join(a.Host().IPs, ", "))
+	add("Network", "Source", a.RemoteAddress())
+	if a.RemoteAddress() != "" && geoip.Enabled() {
+		if location, err := geoip.Lookup(a.RemoteAddress()); err == nil {
+			add("Network", "Location", location.String())
+		}
+	}
+	var links []string
+	for _, link := range a.Links() {
+		links = append(links, link.String())
+	}
+	add("Network", "Links", strings.Join(links, ", "))
+
+	add("Process", "Name", a.Process().Name)
+	add("Process", "ID", strconv.Itoa(a.Process().ID))
+	add("Process", "User", a.Process().UserName)
+	add("Process", "User GUID", a.Process().UserGUID)
+	add("Process", "Domain", a.Process().Domain)
+	add("Process", "Integrity", agents.IntegrityLevel(a.Process().Integrity))
+	add("Process", "Privilege", a.PrivilegeString())
+	add("Process", "Impersonation", a.Impersonation())
+
+	add("Security", "Products", strings.Join(a.SecurityProducts(), "; "))
+	add("Security", "Analysis Indicators", strings.Join(a.Indicators(), "; "))
+	add("Security", "Quarantined", a.Quarantine())
+	add("Security", "Out of Scope", a.ScopeViolation())
+
+	listener := a.Listener().String()
+	if l, err := s.ls.Listener(a.Listener()); err == nil {
+		listener = fmt.Sprintf("%s (%s)", l.Name(), listener)
+	}
+	add("Comms", "Protocol", a.Comms().Proto)
+	add("Comms", "Listener", listener)
+	if channels := a.Channels(); len(channels) > 1 {
+		var counts []string
+		for id, count := range channels {
+			name := id.String()
+			if l, err := s.ls.Listener(id); err == nil {
+				name = l.Name()
+			}
+			counts = append(counts, fmt.Sprintf("%s: %d", name, count))
+		}
+		sort.Strings(counts)
+		add("Comms", "Channels", strings.Join(counts, ", "))
+	}
+	add("Comms", "JA3", a.Comms().JA3)
+	add("Comms", "Authenticated", strconv.FormatBool(a.Authenticated()))
+	if keyed, n := a.Keyed(); !keyed.IsZero() {
+		add("Comms", "Session Key", fmt.Sprintf("established %s, %d bytes exchanged", keyed.Format(time.RFC3339), n))
+	}
+	add("Comms", "Failed Check-ins", fmt.Sprintf("%d of %d", a.Comms().Failed, a.Comms().Retry))
+	if a.Comms().Kill > 0 {
+		add("Comms", "Kill Date", time.Unix(a.Comms().Kill, 0).UTC().Format(time.RFC3339))
+	}
+
+	add("Traffic", "Sleep", a.Comms().Wait)
+	add("Traffic", "Skew", strconv.FormatInt(a.Comms().Skew, 10))
+	add("Traffic", "Agent Padding", strconv.Itoa(a.Comms().Padding))
+	add("Traffic", "Server Profile", a.Profile().String())
+	if a.Throttle() > 0 {
+		add("Traffic", "Throttle", fmt.Sprintf("%d kbps", a.Throttle()))
+	}
+	add("Traffic", "Injection Method", a.Injection())
+	return data, nil
+}
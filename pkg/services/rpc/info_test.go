@@ -0,0 +1,95 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+
+	// Internal
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// TestGetAgentDetails verifies the Agent's information is grouped into sections and the refresh flag queues an agentInfo job
+func TestGetAgentDetails(t *testing.T) {
+	s := newServer()
+	previous := service
+	service = &Service{rpcServer: s}
+	t.Cleanup(func() { service = previous })
+	a := newTestAgent(t, s)
+
+	tests := []struct {
+		name    string
+		info    bool
+		args    []string
+		updated string
+		jobs    int
+	}{
+		{"never refreshed", false, nil, "never", 0},
+		{"refresh flag", false, []string{"--refresh"}, "never", 1},
+		{"short refresh flag", false, []string{"-r"}, "never", 2},
+		{"unknown argument", false, []string{"--verbose"}, "never", 2},
+		{"information received", true, nil, time.Now().UTC().Format("2006-01-02"), 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.info {
+				if err := s.agentService.UpdateAgentInfo(a.ID(), messages.AgentInfo{Version: "2.4.0"}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			table, err := s.GetAgentDetails(context.Background(), &pb.AgentCMD{ID: a.ID().String(), Arguments: test.args})
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields := make(map[string]string)
+			for _, row := range table.Rows {
+				fields[row.Row[0]+"/"+row.Row[1]] = row.Row[2]
+			}
+			if fields["Agent/ID"] != a.ID().String() {
+				t.Errorf("expected the Agent section to have ID %s, have %q", a.ID(), fields["Agent/ID"])
+			}
+			if !strings.HasPrefix(fields["Agent/Information Updated"], test.updated) {
+				t.Errorf("expected the information to have been updated %q, have %q", test.updated, fields["Agent/Information Updated"])
+			}
+			if _, ok := fields["Agent/Refresh"]; ok != (len(test.args) > 0 && test.args[0] != "--verbose") {
+				t.Errorf("unexpected Refresh row presence: %t", ok)
+			}
+			active, err := s.jobService.GetAgentActive(a.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(active) != test.jobs {
+				t.Errorf("expected %d queued jobs, have %d", test.jobs, len(active))
+			}
+		})
+	}
+
+	if _, err := s.GetAgentDetails(context.Background(), &pb.AgentCMD{ID: "not-a-uuid"}); err == nil {
+		t.Error("expected an error for an invalid Agent ID")
+	}
+}
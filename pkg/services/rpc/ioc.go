@@ -0,0 +1,100 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/ioc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH THE IOC SERVICE */
+
+// ExportIOCs returns every Indicator of Compromise created by Agent jobs as a deconfliction document for the blue team
+// in.Data = the export format, either csv (default) or json
+func (s *Server) ExportIOCs(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	data, err := s.iocService.Export(in.Data, s.jobStatus())
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBPlainMessage(string(data))
+	return
+}
+
+// GetIOCs returns a table of Indicators of Compromise created by jobs for the provided Agent, or for all Agents if the
+// ID is empty
+func (s *Server) GetIOCs(ctx context.Context, id *pb.ID) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	table = &pb.TableData{
+		Header: []string{"Time", "Host", "Type", "Value", "Detail", "Agent", "Job", "Job Status"},
+	}
+
+	var indicators []ioc.Indicator
+	if id.Id == "" {
+		indicators = s.iocService.All()
+	} else {
+		var agentID uuid.UUID
+		agentID, err = uuid.Parse(id.Id)
+		if err != nil {
+			err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+			slog.Error(err.Error())
+			return
+		}
+		indicators = s.iocService.Agent(agentID)
+	}
+
+	status := s.jobStatus()
+	for _, i := range indicators {
+		row := []string{
+			i.Created().Format(time.RFC3339),
+			i.Host(),
+			i.Type().String(),
+			i.Value(),
+			i.Detail(),
+			i.AgentID().String(),
+			i.JobID(),
+			status[i.JobID()],
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
+// jobStatus returns the status of every job keyed by the job ID
+func (s *Server) jobStatus() map[string]string {
+	status := make(map[string]string)
+	for _, job := range s.jobService.GetAll() {
+		status[job.ID()] = job.StatusString()
+	}
+	return status
+}
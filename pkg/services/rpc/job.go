@@ -25,13 +25,17 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	// 3rd Party
 	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/attack"
 	"github.com/Ne0nd0g/merlin/v2/pkg/jobs"
 	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
 	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
@@ -39,8 +43,9 @@ import (
 
 /* RPC METHODS TO INTERACT WITH THE JOB SERVICE */
 
-// addJob validates that provided UUID is valid and then adds the job to the job service
-func addJob(agentID string, jobType string, jobArgs []string) (msg *pb.Message, err error) {
+// addJob validates that provided UUID is valid and then adds the job to the job service. Commands the Agent's platform
+// doesn't support are only sent if the caller set the "force" metadata to true
+func addJob(ctx context.Context, agentID string, jobType string, jobArgs []string, techniques ...string) (msg *pb.Message, err error) {
 	msg = &pb.Message{}
 	// Parse the UUID from the request
 	agentUUID, err := uuid.Parse(agentID)
@@ -51,7 +56,11 @@ func addJob(agentID string, jobType string, jobArgs []string) (msg *pb.Message,
 	}
 	// Add the job
 	var result string
-	result, err = service.rpcServer.jobService.Add(agentUUID, jobType, jobArgs)
+	if forced(ctx) {
+		result, err = service.rpcServer.jobService.AddForced(agentUUID, jobType, jobArgs, techniques...)
+	} else {
+		result, err = service.rpcServer.jobService.Add(agentUUID, jobType, jobArgs, techniques...)
+	}
 	if err != nil {
 		err = fmt.Errorf("there was an error adding the '%s' job: %s", jobType, err)
 		slog.Error(err.Error())
@@ -61,6 +70,16 @@ func addJob(agentID string, jobType string, jobArgs []string) (msg *pb.Message,
 	return
 }
 
+// forced returns true if the CLI's --force flag set the "force" metadata to send a command to an Agent whose platform
+// doesn't support it
+func forced(ctx context.Context) bool {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		force, _ := strconv.ParseBool(strings.Join(md["force"], ""))
+		return force
+	}
+	return false
+}
+
 // ClearJobs removes any jobs the queue for a specific Agent that have been created, but NOT sent to the agent
 func (s *Server) ClearJobs(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
@@ -89,6 +108,35 @@ func (s *Server) ClearJobsCreated(ctx context.Context, e *emptypb.Empty) (msg *p
 	return
 }
 
+// ExportAttackNavigator generates an ATT&CK Navigator layer of every MITRE ATT&CK technique exercised by an Agent job
+// that was not canceled or expired. The returned message contains the layer as JSON.
+// in.Data = optional layer name
+func (s *Server) ExportAttackNavigator(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	counts := make(map[string]int)
+	for _, job := range s.jobService.GetAll() {
+		if job.Status() == jobs.CANCELED || job.Status() == jobs.EXPIRED {
+			continue
+		}
+		for _, technique := range job.Attack() {
+			counts[technique]++
+		}
+	}
+
+	name := in.Data
+	if name == "" {
+		name = "Merlin"
+	}
+	description := fmt.Sprintf("Techniques exercised by Merlin Agent jobs as of %s", time.Now().UTC().Format(time.RFC3339))
+	data, err := attack.NewLayer(name, description, counts).JSON()
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBPlainMessage(string(data))
+	return
+}
+
 // GetAgentActiveJobs returns all jobs that have not completed for the specified Agent
 func (s *Server) GetAgentActiveJobs(ctx context.Context, id *pb.ID) (*pb.Jobs, error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
@@ -135,6 +183,111 @@ func (s *Server) GetAllJobs(ctx context.Context, e *emptypb.Empty) (*pb.Jobs, er
 	return returnJobs, nil
 }
 
+// QueryJobs returns the jobs that match the provided filters, in the requested order, one page at a time.
+// Request the next page until no jobs are returned
+// in.Options["Status"] = optional comma separated job statuses (e.g., Sent,Complete)
+// in.Options["Agent"] = optional Agent ID, ID prefix, or name
+// in.Options["Command"] = optional text the job's command contains
+// in.Options["Since"] = optional RFC3339 time or duration (e.g., 24h) jobs were created after
+// in.Options["Sort"] = optional sort key: created (default), sent, completed, status, agent, or command
+// in.Options["Reverse"] = optional "true" to sort from the highest to the lowest value (e.g., newest first)
+// in.Options["Page"] = optional 1-based page number, defaults to 1
+// in.Options["PageSize"] = optional number of jobs per page, defaults to 50; 0 returns every job
+func (s *Server) QueryJobs(ctx context.Context, in *pb.Options) (*pb.Jobs, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	returnJobs := &pb.Jobs{}
+	options := in.GetOptions()
+	filter := jobs.Filter{
+		Command:  options["Command"],
+		Sort:     options["Sort"],
+		Reverse:  strings.EqualFold(options["Reverse"], "true"),
+		Page:     1,
+		PageSize: 50,
+	}
+	for _, status := range strings.Split(options["Status"], ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			filter.Status = append(filter.Status, status)
+		}
+	}
+	var err error
+	if options["Agent"] != "" {
+		filter.Agent, err = s.agentService.Resolve(options["Agent"])
+		if err != nil {
+			slog.Error(err.Error())
+			return returnJobs, err
+		}
+	}
+	if since := options["Since"]; since != "" {
+		if d, errD := time.ParseDuration(since); errD == nil {
+			filter.Since = time.Now().Add(-d)
+		} else if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			err = fmt.Errorf("pkg/services/rpc.QueryJobs(): '%s' is not a duration or RFC3339 time", since)
+			slog.Error(err.Error())
+			return returnJobs, err
+		}
+	}
+	for key, value := range map[string]*int{"Page": &filter.Page, "PageSize": &filter.PageSize} {
+		if options[key] == "" {
+			continue
+		}
+		*value, err = strconv.Atoi(options[key])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.QueryJobs(): there was an error parsing the %s option '%s' as a number: %s", key, options[key], err)
+			slog.Error(err.Error())
+			return returnJobs, err
+		}
+	}
+
+	page, total, err := s.jobService.Query(filter)
+	if err != nil {
+		slog.Error(err.Error())
+		return returnJobs, err
+	}
+	slog.Debug("queried jobs", "total", total, "page", filter.Page, "returned", len(page))
+	for _, jobInfo := range page {
+		returnJobs.Jobs = append(returnJobs.Jobs, s.jobToJobInfo(jobInfo))
+	}
+	return returnJobs, nil
+}
+
+// SetJobExpiry sets the time after which a job that has not been sent is discarded instead of being sent to its Agent
+// in.Options["JobID"] = the job's ID
+// in.Options["Expiry"] = a duration from now (e.g., 8h), an RFC3339 time, or "never" to remove the job's expiry
+func (s *Server) SetJobExpiry(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	jobID := in.GetOptions()["JobID"]
+	expiry := in.GetOptions()["Expiry"]
+	if jobID == "" || expiry == "" {
+		err = fmt.Errorf("pkg/services/rpc.SetJobExpiry(): the JobID and Expiry options are required")
+		slog.Error(err.Error())
+		return
+	}
+
+	var expires time.Time
+	if !strings.EqualFold(expiry, "never") {
+		if d, errD := time.ParseDuration(expiry); errD == nil {
+			expires = time.Now().UTC().Add(d)
+		} else if expires, err = time.Parse(time.RFC3339, expiry); err != nil {
+			err = fmt.Errorf("pkg/services/rpc.SetJobExpiry(): '%s' is not a duration, RFC3339 time, or \"never\"", expiry)
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	err = s.jobService.SetExpiry(jobID, expires)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	if expires.IsZero() {
+		msg = NewPBSuccessMessage(fmt.Sprintf("Job %s will not expire", jobID))
+	} else {
+		msg = NewPBSuccessMessage(fmt.Sprintf("Job %s expires at %s if it has not been sent", jobID, expires.Format(time.RFC3339)))
+	}
+	return
+}
+
 // jobToJobInfo converts a server-side Job Info structure into a protobuf Job structure
 func (s *Server) jobToJobInfo(job jobs.Info) *pb.Job {
 	slog.Log(context.Background(), logging.LevelTrace, "job info", "Job", fmt.Sprintf("%+v", job))
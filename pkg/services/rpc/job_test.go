@@ -0,0 +1,129 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"testing"
+
+	// 3rd Party
+	"google.golang.org/grpc/metadata"
+
+	// Internal
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// TestQueryJobs verifies the QueryJobs options are parsed and jobs are filtered by the Agent's ID, prefix, or name
+func TestQueryJobs(t *testing.T) {
+	s := newServer()
+	a := newTestAgent(t, s)
+	_, err := s.jobService.Add(a.ID(), "pwd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		options map[string]string
+		jobs    int
+		wantErr bool
+	}{
+		{"agent ID", map[string]string{"Agent": a.ID().String()}, 1, false},
+		{"agent prefix", map[string]string{"Agent": a.ID().String()[:13], "Status": "Created, Sent"}, 1, false},
+		{"since duration", map[string]string{"Agent": a.ID().String(), "Since": "1h"}, 1, false},
+		{"since time", map[string]string{"Agent": a.ID().String(), "Since": "2999-01-01T00:00:00Z"}, 0, false},
+		{"past the last page", map[string]string{"Agent": a.ID().String(), "Page": "2", "PageSize": "1"}, 0, false},
+		{"unknown agent", map[string]string{"Agent": "unknown-agent"}, 0, true},
+		{"invalid since", map[string]string{"Since": "yesterday"}, 0, true},
+		{"invalid page", map[string]string{"Page": "first"}, 0, true},
+		{"invalid status", map[string]string{"Status": "Finished"}, 0, true},
+		{"invalid sort", map[string]string{"Sort": "size"}, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := s.QueryJobs(context.Background(), &pb.Options{Options: test.options})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if len(resp.GetJobs()) != test.jobs {
+				t.Errorf("expected %d jobs, have %d", test.jobs, len(resp.GetJobs()))
+			}
+		})
+	}
+}
+
+// TestSetJobExpiry verifies the expiry is parsed as a duration, RFC3339 time, or "never"
+func TestSetJobExpiry(t *testing.T) {
+	s := newServer()
+	a := newTestAgent(t, s)
+	_, err := s.jobService.Add(a.ID(), "pwd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	active, err := s.jobService.GetAgentActive(a.ID())
+	if err != nil || len(active) != 1 {
+		t.Fatalf("expected 1 active job, have %d: %v", len(active), err)
+	}
+	jobID := active[0].ID()
+	tests := []struct {
+		name    string
+		options map[string]string
+		wantErr bool
+	}{
+		{"duration", map[string]string{"JobID": jobID, "Expiry": "8h"}, false},
+		{"time", map[string]string{"JobID": jobID, "Expiry": "2999-01-01T00:00:00Z"}, false},
+		{"never", map[string]string{"JobID": jobID, "Expiry": "Never"}, false},
+		{"missing job", map[string]string{"Expiry": "8h"}, true},
+		{"missing expiry", map[string]string{"JobID": jobID}, true},
+		{"invalid expiry", map[string]string{"JobID": jobID, "Expiry": "tomorrow"}, true},
+		{"unknown job", map[string]string{"JobID": "unknown", "Expiry": "8h"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.SetJobExpiry(context.Background(), &pb.Options{Options: test.options})
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, have %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestForced verifies the "force" metadata set by the CLI's --force flag is read from the RPC context
+func TestForced(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{"no metadata", context.Background(), false},
+		{"not set", metadata.NewIncomingContext(context.Background(), metadata.Pairs("client", "operator")), false},
+		{"true", metadata.NewIncomingContext(context.Background(), metadata.Pairs("force", "true")), true},
+		{"false", metadata.NewIncomingContext(context.Background(), metadata.Pairs("force", "false")), false},
+		{"invalid", metadata.NewIncomingContext(context.Background(), metadata.Pairs("force", "yes please")), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if have := forced(test.ctx); have != test.want {
+				t.Errorf("expected %t, have %t", test.want, have)
+			}
+		})
+	}
+}
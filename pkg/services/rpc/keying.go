@@ -0,0 +1,63 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/keying"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// KeyAgentConfig seals an Agent configuration to a target environment and returns the Base64 encoded envelope to embed
+// in the Agent when it is built. The Agent refuses to run when it can't decrypt the configuration on the host
+// in.Options["Config"] = the Agent configuration to seal
+// in.Options["Domain"] = the domain the host must be joined to (optional)
+// in.Options["Hostname"] = a glob pattern the host's name must match (optional, e.g., WS-*)
+// in.Options["Resolve"] = a DNS name the host must resolve to in.Options["ResolveIP"] (optional)
+// in.Options["ResolveIP"] = the IP address in.Options["Resolve"] must resolve to
+func (s *Server) KeyAgentConfig(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	options := in.GetOptions()
+	if options["Config"] == "" {
+		err = fmt.Errorf("pkg/services/rpc.KeyAgentConfig(): an Agent configuration to seal is required")
+		slog.Error(err.Error())
+		return
+	}
+	env := keying.Environment{
+		Domain:    options["Domain"],
+		Hostname:  options["Hostname"],
+		Resolve:   options["Resolve"],
+		ResolveIP: options["ResolveIP"],
+	}
+	sealed, err := keying.Seal([]byte(options["Config"]), env)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBPlainMessage(sealed)
+	return
+}
@@ -25,27 +25,62 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	// 3rd Party
 	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	// Internal
 	l2 "github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners/smb"
 	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
 	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/selftest"
 )
 
 /* RPC METHODS TO INTERACT WITH THE LISTENER SERVICE*/
 
-// CreateListener instantiates a new Listener on the RPC server
+// CreateListener instantiates a new Listener on the RPC server. When the "dry-run" metadata is true, the options are
+// validated, the address is bound and released, and the certificate is loaded, but the Listener is not created and its
+// effective configuration is returned instead
 func (s *Server) CreateListener(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	// Validate the options and return the effective configuration without creating the listener
+	if dryRun(ctx) {
+		configured, notes, err := s.ls.DryRun(in.Options)
+		if err != nil {
+			err = fmt.Errorf("there was an error validating the listener: %w", err)
+			return &pb.Message{}, err
+		}
+		var keys []string
+		for key := range configured {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("Dry run: the listener is valid and was not created\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "\t%s: %s\n", key, configured[key])
+		}
+		for _, note := range notes {
+			fmt.Fprintf(&b, "Note: %s\n", note)
+		}
+		return NewPBPlainMessage(b.String()), nil
+	}
 	// Create the listener
 	listener, err := s.ls.NewListener(in.Options)
 	if err != nil {
-		err = fmt.Errorf("there was an error creating the listener: %s", err)
+		err = fmt.Errorf("there was an error creating the listener: %w", err)
 		return
 	}
 	// The Message field must only contain the string representation of the UUID
@@ -54,12 +89,153 @@ func (s *Server) CreateListener(ctx context.Context, in *pb.Options) (msg *pb.Me
 	return
 }
 
+// dryRun returns true if the CLI's --dry-run flag set the "dry-run" metadata to validate a listener's options without
+// creating it
+func dryRun(ctx context.Context) bool {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		dry, _ := strconv.ParseBool(strings.Join(md["dry-run"], ""))
+		return dry
+	}
+	return false
+}
+
+// CreateListeners instantiates a Listener from the same template options for each interface and port pair and returns
+// a summary table. A Listener that fails to be created doesn't stop the others. When the "dry-run" metadata is true, each
+// Listener is only validated
+// in.Options["Binds"] = a comma separated list of ports, port ranges, or interface and port pairs
+// (e.g., 443,8000-8010,10.0.0.5:443,[::1]:8443,10.0.0.0/30:80); entries without an interface use in.Options["Interface"]
+func (s *Server) CreateListeners(ctx context.Context, in *pb.Options) (data *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	template := maps.Clone(in.GetOptions())
+	binds, err := listeners.ParseBinds(template["Binds"], template["Interface"])
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing the listener binds: %w", err)
+		slog.Error(err.Error())
+		return
+	}
+	delete(template, "Binds")
+
+	results, created := s.ls.NewListeners, "Created"
+	if dryRun(ctx) {
+		results, created = s.ls.DryRunListeners, "Valid (dry run)"
+	}
+	data = &pb.TableData{Header: []string{"Name", "ID", "Interface", "Port", "Status"}}
+	for _, result := range results(template, binds) {
+		id, status := "", created
+		if result.Err != nil {
+			status = result.Err.Error()
+		} else if result.ID != uuid.Nil {
+			id = result.ID.String()
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: []string{result.Name, id, result.Interface, strconv.Itoa(result.Port), status}})
+	}
+	return
+}
+
+// DrainListener stops a Listener from accepting new Agent authentications while it continues to serve Agents that
+// have already authenticated. Starting the Listener again resumes accepting new Agent authentications
+func (s *Server) DrainListener(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	// Parse the UUID
+	listenerID, err := uuid.Parse(id.Id)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %w", id.Id, err)
+		slog.Error(err.Error())
+		return
+	}
+
+	err = s.ls.Drain(listenerID)
+	if err != nil {
+		err = fmt.Errorf("there was an error draining listener %s: %w", listenerID, err)
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Listener %s is draining and will no longer accept new Agent authentications", listenerID))
+	return
+}
+
+// SelfTest verifies a Listener's Agent message pipeline end-to-end. An in-process test Agent authenticates through the
+// Listener's transforms and authenticator, checks in, completes a job, and is then removed. The Listener's network
+// server is not used, so the Listener does not need to be running
+// id.Id = the Listener's name or ID
+func (s *Server) SelfTest(ctx context.Context, id *pb.ID) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	listenerID, err := s.ls.Resolve(id.GetId())
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.SelfTest(): %s", err)
+		slog.Error(err.Error())
+		return nil, err
+	}
+	listener, err := s.ls.Listener(listenerID)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.SelfTest(): %s", err)
+		slog.Error(err.Error())
+		return nil, err
+	}
+	steps, err := s.selfTest.Run(ctx, listener)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	data := &pb.TableData{
+		Header: []string{"Step", "Result", "Duration", "Detail"},
+	}
+	for _, step := range steps {
+		result := "FAIL"
+		if step.Passed {
+			result = "PASS"
+		}
+		row := []string{step.Name, result, step.Duration.Round(time.Microsecond).String(), step.Detail}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	result := "FAIL"
+	if selftest.Passed(steps) {
+		result = "PASS"
+	}
+	data.Rows = append(data.Rows, &pb.TableRows{Row: []string{"Self-test", result, "", fmt.Sprintf("listener %s (%s)", listener.Name(), listenerID)}})
+	slog.Info(fmt.Sprintf("Self-test of listener %s finished with result %s", listener.Name(), result))
+	return data, nil
+}
+
+// GenerateSMBPipe returns a new random named pipe, for use with a single payload, from an SMB listener's preset or
+// template, or from a preset name or template directly
+// in.Data = the SMB listener name, the preset name (e.g., mojo), or a template (e.g., mojo.{pid}.{pid}.{digits:19})
+func (s *Server) GenerateSMBPipe(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	var pipe string
+	listener, err := s.ls.ListenerByName(in.Data)
+	if err == nil {
+		generator, ok := listener.(interface{ GeneratePipe() (string, error) })
+		if !ok {
+			err = fmt.Errorf("listener %s is a %s listener and does not use named pipes", in.Data, l2.String(listener.Protocol()))
+			slog.Error(err.Error())
+			return
+		}
+		pipe, err = generator.GeneratePipe()
+	} else {
+		if _, ok := smb.PipePresets[strings.ToLower(in.Data)]; !ok && !smb.IsPipeTemplate(in.Data) {
+			err = fmt.Errorf("%s is not an SMB listener, a named pipe preset (%s), or a template", in.Data, strings.Join(smb.PipePresetNames(), ", "))
+			slog.Error(err.Error())
+			return
+		}
+		pipe, err = smb.GeneratePipe(in.Data)
+	}
+	if err != nil {
+		err = fmt.Errorf("there was an error generating a named pipe: %w", err)
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBPlainMessage(pipe)
+	return
+}
+
 // GetListenerDefaultOptions returns all the available options for a listener type, not for a previously instantiated listener
 func (s *Server) GetListenerDefaultOptions(ctx context.Context, in *pb.String) (options *pb.Options, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
 	o, err := s.ls.DefaultOptions(in.Data)
 	if err != nil {
-		err = fmt.Errorf("there was an error getting the default options for listener '%s': %s", in.Data, err)
+		err = fmt.Errorf("there was an error getting the default options for listener '%s': %w", in.Data, err)
 		slog.Error(err.Error())
 		return
 	}
@@ -69,6 +245,30 @@ func (s *Server) GetListenerDefaultOptions(ctx context.Context, in *pb.String) (
 	return
 }
 
+// GetListenerOptionSchema returns the type, default value, and validation for every option of a listener type
+// in.Data = the listener type (e.g., HTTPS, SMB)
+func (s *Server) GetListenerOptionSchema(ctx context.Context, in *pb.String) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	schema, err := s.ls.Schema(in.Data)
+	if err != nil {
+		err = fmt.Errorf("there was an error getting the option schema for listener '%s': %w", in.Data, err)
+		slog.Error(err.Error())
+		return
+	}
+	table = &pb.TableData{
+		Header: []string{"Name", "Type", "Default", "Required", "Valid Values", "Description"},
+	}
+	for _, option := range schema {
+		valid := strings.Join(option.Choices, ", ")
+		if valid == "" {
+			valid = option.Pattern
+		}
+		row := []string{option.Name, option.Type, option.Default, fmt.Sprintf("%t", option.Required), valid, option.Description}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
 // GetListenerIDs returns a list of all the previously instantiated listeners on the RPC server
 func (s *Server) GetListenerIDs(ctx context.Context, e *emptypb.Empty) (*pb.Slice, error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
@@ -85,14 +285,14 @@ func (s *Server) GetListenerOptions(ctx context.Context, id *pb.ID) (options *pb
 	// Parse the UUID
 	listenerID, err := uuid.Parse(id.Id)
 	if err != nil {
-		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %w", id.Id, err)
 		slog.Error(err.Error())
 		return
 	}
 
 	listener, err := s.ls.Listener(listenerID)
 	if err != nil {
-		err = fmt.Errorf("there was an error getting listener %s: %s", listenerID, err)
+		err = fmt.Errorf("there was an error getting listener %s: %w", listenerID, err)
 		slog.Error(err.Error())
 		return
 	}
@@ -100,6 +300,9 @@ func (s *Server) GetListenerOptions(ctx context.Context, id *pb.ID) (options *pb
 	options = &pb.Options{
 		Options: listener.ConfiguredOptions(),
 	}
+	if expiration, ok := l2.GetExpiration(listenerID); ok {
+		options.Options["Expiration"] = expiration.String()
+	}
 	return
 }
 
@@ -116,9 +319,9 @@ func (s *Server) GetListeners(ctx context.Context, e *emptypb.Empty) (table *pb.
 			row := []string{
 				l.ID().String(),
 				l.Name(),
-				fmt.Sprintf("%s:%d", server.Interface(), server.Port()),
+				net.JoinHostPort(server.Interface(), strconv.Itoa(server.Port())),
 				server.ProtocolString(),
-				l.Status(),
+				listenerStatus(l),
 				l.Description(),
 			}
 			table.Rows = append(table.Rows, &pb.TableRows{Row: row})
@@ -128,7 +331,7 @@ func (s *Server) GetListeners(ctx context.Context, e *emptypb.Empty) (table *pb.
 				l.Name(),
 				l.Addr(),
 				l2.String(l.Protocol()),
-				l.Status(),
+				listenerStatus(l),
 				l.Description(),
 			}
 			table.Rows = append(table.Rows, &pb.TableRows{Row: row})
@@ -138,23 +341,49 @@ func (s *Server) GetListeners(ctx context.Context, e *emptypb.Empty) (table *pb.
 	return
 }
 
+// GetPivotListeners returns the peer-to-peer Listeners that were created automatically for the bind Agents deployed
+// with lateral movement and the Agent that deployed each of them
+func (s *Server) GetPivotListeners(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	data := &pb.TableData{
+		Header: []string{"Listener", "Name", "Protocol", "Host", "Address", "Deployed By", "Created"},
+	}
+	for _, pivot := range l2.Pivots(uuid.Nil) {
+		var name string
+		if listener, err := s.ls.Listener(pivot.Listener); err == nil {
+			name = listener.Name()
+		}
+		row := []string{
+			pivot.Listener.String(),
+			name,
+			pivot.Protocol,
+			pivot.Host,
+			pivot.Address,
+			pivot.Agent.String(),
+			pivot.Created.Format(time.RFC3339),
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
 // GetListenerStatus returns the status of a previously instantiated listener
 func (s *Server) GetListenerStatus(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
 	// Parse the UUID
 	listenerID, err := uuid.Parse(id.Id)
 	if err != nil {
-		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %w", id.Id, err)
 		slog.Error(err.Error())
 		return
 	}
 	l, err := s.ls.Listener(listenerID)
 	if err != nil {
-		err = fmt.Errorf("there was an error getting listener %s: %s", listenerID, err)
+		err = fmt.Errorf("there was an error getting listener %s: %w", listenerID, err)
 		slog.Error(err.Error())
 		return
 	}
-	msg = NewPBPlainMessage(l.Status())
+	msg = NewPBPlainMessage(listenerStatus(l))
 	return
 }
 
@@ -170,13 +399,13 @@ func (s *Server) RemoveListener(ctx context.Context, id *pb.ID) (msg *pb.Message
 	// Parse the UUID from the request
 	listenerID, err := uuid.Parse(id.Id)
 	if err != nil {
-		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %w", id.Id, err)
 		slog.Error(err.Error())
 		return
 	}
 	err = s.ls.Remove(listenerID)
 	if err != nil {
-		err = fmt.Errorf("there was an error removing listener %s: %s", listenerID, err)
+		err = fmt.Errorf("there was an error removing listener %s: %w", listenerID, err)
 		slog.Error(err.Error())
 		return
 	}
@@ -190,13 +419,13 @@ func (s *Server) RestartListener(ctx context.Context, id *pb.ID) (msg *pb.Messag
 	// Parse the UUID from the request
 	listenerID, err := uuid.Parse(id.Id)
 	if err != nil {
-		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %w", id.Id, err)
 		slog.Error(err.Error())
 		return
 	}
-	err = s.ls.Restart(listenerID)
+	err = s.ls.Restart(s.ctx, listenerID)
 	if err != nil {
-		err = fmt.Errorf("there was an error restarting listener %s: %s", listenerID, err)
+		err = fmt.Errorf("there was an error restarting listener %s: %w", listenerID, err)
 		slog.Error(err.Error())
 		return
 	}
@@ -223,14 +452,14 @@ func (s *Server) SetListenerOption(ctx context.Context, in *pb.AgentCMD) (msg *p
 	// Parse the UUID from the request
 	listenerID, err := uuid.Parse(in.ID)
 	if err != nil {
-		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %w", in.ID, err)
 		slog.Error(err.Error())
 		return
 	}
 
 	err = s.ls.SetOption(listenerID, in.Arguments[0], in.Arguments[1])
 	if err != nil {
-		err = fmt.Errorf("there was an error setting the listener option: %s", err)
+		err = fmt.Errorf("there was an error setting the listener option: %w", err)
 		slog.Error(err.Error())
 		return
 	}
@@ -251,7 +480,7 @@ func (s *Server) StartListener(ctx context.Context, id *pb.ID) (msg *pb.Message,
 	}
 
 	// Start the listener
-	err = s.ls.Start(listenerID)
+	err = s.ls.Start(s.ctx, listenerID)
 	if err != nil {
 		msg = NewPBErrorMessage(err)
 		err = nil
@@ -261,14 +490,14 @@ func (s *Server) StartListener(ctx context.Context, id *pb.ID) (msg *pb.Message,
 	// Get the instantiated Listener from the repository
 	l, err := s.ls.Listener(listenerID)
 	if err != nil {
-		err = fmt.Errorf("there was an error getting listener %s: %s", listenerID, err)
+		err = fmt.Errorf("there was an error getting listener %s: %w", listenerID, err)
 		return
 	}
 
 	if l.Server() != nil {
 		server := *l.Server()
-		m := fmt.Sprintf("Started '%s' listener with an ID of %s and a %s server on %s:%d",
-			l.Name(), l.ID(), server.ProtocolString(), server.Interface(), server.Port())
+		m := fmt.Sprintf("Started '%s' listener with an ID of %s and a %s server on %s",
+			l.Name(), l.ID(), server.ProtocolString(), net.JoinHostPort(server.Interface(), strconv.Itoa(server.Port())))
 		msg = &pb.Message{
 			Level:     pb.MessageLevel_SUCCESS,
 			Message:   m,
@@ -293,17 +522,173 @@ func (s *Server) StopListener(ctx context.Context, id *pb.ID) (msg *pb.Message,
 	// Parse the UUID
 	listenerID, err := uuid.Parse(id.Id)
 	if err != nil {
-		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %w", id.Id, err)
 		slog.Error(err.Error())
 		return
 	}
 
 	err = s.ls.Stop(listenerID)
 	if err != nil {
-		err = fmt.Errorf("there was an error stopping listener %s: %s", listenerID, err)
+		err = fmt.Errorf("there was an error stopping listener %s: %w", listenerID, err)
 		slog.Error(err.Error())
 		return
 	}
 	msg = NewPBSuccessMessage(fmt.Sprintf("Successfully stopped listener %s", listenerID))
 	return
 }
+
+// StartTrafficCapture writes a Listener's raw Agent messages, paired with the Base messages its transforms decode them
+// into or the error they return, to a file for a limited time to diagnose transform mismatches with custom Agents
+// in.Options["Listener"] = the Listener's name or ID
+// in.Options["Duration"] = how long to capture (e.g., 10m); optional
+func (s *Server) StartTrafficCapture(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	options := in.GetOptions()
+	listenerID, err := s.ls.Resolve(options["Listener"])
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.StartTrafficCapture(): %s", err)
+		slog.Error(err.Error())
+		return
+	}
+	duration := message.DefaultCaptureDuration
+	if value := strings.TrimSpace(options["Duration"]); value != "" {
+		duration, err = time.ParseDuration(value)
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.StartTrafficCapture(): there was an error parsing '%s' as a duration: %s", value, err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+	capture, err := message.StartCapture(listenerID, duration)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Capturing traffic for listener %s to %s until %s", listenerID, capture.File, capture.Until.Format(time.RFC3339)))
+	return
+}
+
+// StopTrafficCapture stops a Listener's traffic capture before its duration passes
+// id.Id = the Listener's name or ID
+func (s *Server) StopTrafficCapture(ctx context.Context, id *pb.ID) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	listenerID, err := s.ls.Resolve(id.GetId())
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.StopTrafficCapture(): %s", err)
+		slog.Error(err.Error())
+		return
+	}
+	capture, err := message.StopCapture(listenerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Stopped the traffic capture for listener %s and wrote %d messages to %s", listenerID, capture.Messages, capture.File))
+	return
+}
+
+// GetTrafficCaptures returns a table of the Listeners whose traffic is being captured
+func (s *Server) GetTrafficCaptures(ctx context.Context, empty *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", empty)
+	data := &pb.TableData{
+		Header: []string{"Listener", "Name", "File", "Started", "Until", "Messages"},
+	}
+	captures := message.Captures()
+	sort.Slice(captures, func(i, j int) bool { return captures[i].Started.Before(captures[j].Started) })
+	for _, capture := range captures {
+		name := ""
+		if l, err := s.ls.Listener(capture.Listener); err == nil {
+			name = l.Name()
+		}
+		row := []string{
+			capture.Listener.String(),
+			name,
+			capture.File,
+			capture.Started.Format(time.RFC3339),
+			capture.Until.Format(time.RFC3339),
+			strconv.Itoa(capture.Messages),
+		}
+		data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+	}
+	return data, nil
+}
+
+// listenerStatus returns the Listener's status and notes if it is draining or when it expires
+func listenerStatus(l l2.Listener) string {
+	status := l.Status()
+	if l2.Draining(l.ID()) {
+		status = fmt.Sprintf("%s (Draining)", status)
+	}
+	if expiration, ok := l2.GetExpiration(l.ID()); ok {
+		status = fmt.Sprintf("%s (Expires %s)", status, expiration)
+	}
+	return status
+}
+
+// transportConfig validates that the destination listener can accept the Agent and returns the configuration the Agent
+// needs to switch to it: the protocol, URL, PSK, transforms, authenticator, and listener ID
+func (s *Server) transportConfig(name, target string) ([]string, error) {
+	listener, err := s.ls.ListenerByName(name)
+	if err != nil {
+		id, errParse := uuid.Parse(name)
+		if errParse != nil {
+			return nil, fmt.Errorf("there was an error finding the destination listener %s: %w", name, err)
+		}
+		listener, err = s.ls.Listener(id)
+		if err != nil {
+			return nil, fmt.Errorf("there was an error finding the destination listener %s: %w", name, err)
+		}
+	}
+
+	if listener.Protocol() != l2.HTTP {
+		return nil, fmt.Errorf("the destination listener %s is a %s listener but Agents can only transport to HTTP listeners", name, l2.String(listener.Protocol()))
+	}
+	if listener.Status() != "Running" {
+		return nil, fmt.Errorf("the destination listener %s is %s and must be running to accept the Agent", name, listener.Status())
+	}
+	if l2.Draining(listener.ID()) {
+		return nil, fmt.Errorf("the destination listener %s is draining and will not accept the Agent", name)
+	}
+
+	options := listener.ConfiguredOptions()
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("there was an error parsing the URL %s: %w", target, err)
+	}
+	protocol := strings.ToLower(options["Protocol"])
+	switch protocol {
+	case "http", "h2c":
+		if u.Scheme != "http" {
+			return nil, fmt.Errorf("the %s destination listener %s requires an http:// URL but have %s", options["Protocol"], name, target)
+		}
+	default:
+		if u.Scheme != "https" {
+			return nil, fmt.Errorf("the %s destination listener %s requires an https:// URL but have %s", options["Protocol"], name, target)
+		}
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("the URL %s does not contain a host", target)
+	}
+
+	return []string{
+		protocol,
+		target,
+		options["PSK"],
+		strings.TrimSuffix(options["Transforms"], ","),
+		options["Authenticator"],
+		listener.ID().String(),
+	}, nil
+}
+
+// smbPipe returns the named pipe of the SMB listener with the provided name.
+// The input is returned unmodified if it is not the name of an SMB listener so that a named pipe can be used directly
+func (s *Server) smbPipe(name string) string {
+	listener, err := s.ls.ListenerByName(name)
+	if err != nil || listener.Protocol() != l2.SMB {
+		return name
+	}
+	if pipe := listener.ConfiguredOptions()["Pipe"]; pipe != "" {
+		return pipe
+	}
+	return name
+}
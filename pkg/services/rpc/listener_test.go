@@ -0,0 +1,156 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"google.golang.org/grpc/metadata"
+
+	// Internal
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// TestDryRun verifies the "dry-run" metadata set by the CLI's --dry-run flag is read from the RPC context
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{"no metadata", context.Background(), false},
+		{"not set", metadata.NewIncomingContext(context.Background(), metadata.Pairs("force", "true")), false},
+		{"true", metadata.NewIncomingContext(context.Background(), metadata.Pairs("dry-run", "true")), true},
+		{"false", metadata.NewIncomingContext(context.Background(), metadata.Pairs("dry-run", "false")), false},
+		{"invalid", metadata.NewIncomingContext(context.Background(), metadata.Pairs("dry-run", "maybe")), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if have := dryRun(test.ctx); have != test.want {
+				t.Errorf("expected %t, have %t", test.want, have)
+			}
+		})
+	}
+}
+
+// TestCreateListenerDryRun verifies a dry run returns the listener's configuration without creating it
+func TestCreateListenerDryRun(t *testing.T) {
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Building the listener writes the OPAQUE server key to the data directory
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	s := newServer()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("dry-run", "true"))
+	options, err := s.ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		options map[string]string
+		wantErr bool
+	}{
+		{"valid", map[string]string{"Name": "dry run", "Port": "7030"}, false},
+		{"invalid port", map[string]string{"Name": "dry run", "Port": "http"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := make(map[string]string)
+			for k, v := range options {
+				in[k] = v
+			}
+			for k, v := range test.options {
+				in[k] = v
+			}
+			msg, err := s.CreateListener(ctx, &pb.Options{Options: in})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if len(s.ls.Listeners()) != 0 {
+				t.Errorf("expected the dry run to not create a listener")
+			}
+			if !test.wantErr && !strings.Contains(msg.GetMessage(), "Port: 7030") {
+				t.Errorf("expected the configuration to be returned, have %q", msg.GetMessage())
+			}
+		})
+	}
+}
+
+// TestSelfTest verifies listeners that don't exist are refused before the self-test runs
+func TestSelfTest(t *testing.T) {
+	s := newServer()
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"empty", ""},
+		{"unknown name", "missing listener"},
+		{"unknown ID", "3b0a4f5e-2a38-4a36-8b69-4a4d2b6b5d21"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := s.SelfTest(context.Background(), &pb.ID{Id: test.id}); err == nil {
+				t.Error("expected an error for a listener that doesn't exist")
+			}
+		})
+	}
+}
+
+// TestStartTrafficCapture verifies the listener and duration are validated before a capture starts
+func TestStartTrafficCapture(t *testing.T) {
+	s := newServer()
+	tests := []struct {
+		name    string
+		options map[string]string
+	}{
+		{"missing listener", map[string]string{}},
+		{"unknown listener", map[string]string{"Listener": "missing listener"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := s.StartTrafficCapture(context.Background(), &pb.Options{Options: test.options}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+	if _, err := s.StopTrafficCapture(context.Background(), &pb.ID{Id: "missing listener"}); err == nil {
+		t.Error("expected an error stopping the capture of an unknown listener")
+	}
+	data, err := s.GetTrafficCaptures(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Rows) != 0 {
+		t.Errorf("expected no active captures, have %d", len(data.Rows))
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	"github.com/Ne0nd0g/merlin/v2/pkg/loot"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH THE LOOT SERVICE */
+
+// GetLoot returns a table of all loot collected from the provided Agent, or from all Agents if the ID is empty
+func (s *Server) GetLoot(ctx context.Context, id *pb.ID) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	table = &pb.TableData{
+		Header: []string{"ID", "Agent", "Job", "Type", "Size", "Tags", "Created", "File"},
+	}
+
+	var items []loot.Loot
+	if id.Id == "" {
+		items = s.lootService.All()
+	} else {
+		var agentID uuid.UUID
+		agentID, err = uuid.Parse(id.Id)
+		if err != nil {
+			err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+			slog.Error(err.Error())
+			return
+		}
+		items = s.lootService.Agent(agentID)
+	}
+
+	for _, l := range items {
+		row := []string{
+			l.ID().String(),
+			l.AgentID().String(),
+			l.JobID(),
+			l.Type().String(),
+			fmt.Sprintf("%d", l.Size()),
+			lootTags(l.Tags()),
+			l.Created().Format(time.RFC3339),
+			l.File(),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
+// lootTags returns the loot's tags as a sorted, comma separated, list of key=value pairs
+func lootTags(tags map[string]string) string {
+	var pairs []string
+	for k, v := range tags {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -25,9 +25,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
-	"path"
 	"regexp"
+	"sort"
 	"strings"
 
 	// 3rd Party
@@ -75,16 +74,7 @@ func getExtendedCommand(name string, options map[string]string) ([]string, error
 // GetModule returns all the information needed to instantiate a module object on the RPC client from the RPC server
 func (s *Server) GetModule(ctx context.Context, in *pb.String) (data *pb.Module, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
-	// Get the absolute path to the module
-	cwd, err := os.Getwd()
-	if err != nil {
-		err = fmt.Errorf("there was an error getting the working directory: %s", err)
-		slog.Error(err.Error())
-		return
-	}
-	modulePath := path.Join(cwd, "data", "modules", fmt.Sprintf("%s.json", in.Data))
-
-	module, err := modules.NewModule(modulePath)
+	module, err := modules.Get(in.Data)
 	if err != nil {
 		err = fmt.Errorf("there was an error getting the module: %s", err)
 		slog.Error(err.Error())
@@ -127,6 +117,64 @@ func (s *Server) GetModuleList(ctx context.Context, e *emptypb.Empty) (data *pb.
 	return &pb.Slice{Data: modules.GetModuleList()}, nil
 }
 
+// GetModuleCategories returns a sorted list of all module categories when in.Data is empty, otherwise it returns the
+// names of all modules in the in.Data category
+func (s *Server) GetModuleCategories(ctx context.Context, in *pb.String) (data *pb.Slice, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	data = &pb.Slice{}
+	categories := modules.Categories()
+	if in.Data == "" {
+		for category := range categories {
+			data.Data = append(data.Data, category)
+		}
+		sort.Strings(data.Data)
+		return
+	}
+	names, ok := categories[strings.ToLower(in.Data)]
+	if !ok {
+		err = fmt.Errorf("the '%s' module category was not found", in.Data)
+		slog.Error(err.Error())
+		return
+	}
+	data.Data = names
+	return
+}
+
+// ReloadModules re-reads and validates every module definition from the modules directory so that new or modified
+// modules can be used without restarting the server
+func (s *Server) ReloadModules(ctx context.Context, e *emptypb.Empty) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	loaded, errs := modules.Reload()
+	if len(errs) > 0 {
+		m := fmt.Sprintf("Reloaded %d modules with %d errors:", loaded, len(errs))
+		for _, e := range errs {
+			m += fmt.Sprintf("\n\t%s", e)
+		}
+		msg = NewPBWarnMessage(m)
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Reloaded %d modules", loaded))
+	return
+}
+
+// SearchModules returns a table of all modules where the keyword in.Data is found in the module's name, description,
+// authors, or tags
+func (s *Server) SearchModules(ctx context.Context, in *pb.String) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	table = &pb.TableData{
+		Header: []string{"Module", "Platform", "Category", "Description"},
+	}
+	for _, name := range modules.Search(in.Data) {
+		module, err := modules.Get(name)
+		if err != nil {
+			continue
+		}
+		row := []string{name, module.Platform, modules.Category(name), module.Description}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
 // RunModule executes the provided module
 func (s *Server) RunModule(ctx context.Context, m *pb.ModuleRun) (msgs *pb.Messages, err error) {
 	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "module run", m)
@@ -171,6 +219,25 @@ func (s *Server) RunModule(ctx context.Context, m *pb.ModuleRun) (msgs *pb.Messa
 		}
 	}
 
+	// Script modules run on the server and task the Agent themselves
+	if module, lookupErr := modules.Lookup(m.Name, m.Platform); lookupErr == nil && module.IsScript() {
+		if agentID.String() == "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+			return nil, fmt.Errorf("the %s script module can only be run against a single agent", m.Name)
+		}
+		optionsMap := make(map[string]string)
+		for _, v := range m.Options {
+			optionsMap[v.Name] = v.Value
+		}
+		var run string
+		run, err = s.scripts.Run(module.ScriptPath(), agentID, optionsMap)
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+		msgs.Messages = append(msgs.Messages, NewPBNoteMessage(fmt.Sprintf("Started the %s script module for agent %s as run %s", m.Name, agentID, run)))
+		return
+	}
+
 	var command []string
 	// If the module is extended, get the command from the module's source code
 	// Else, fill in the command with the options provided
@@ -229,9 +296,10 @@ func (s *Server) RunModule(ctx context.Context, m *pb.ModuleRun) (msgs *pb.Messa
 	}
 
 	// Create the job(s)
+	techniques := modules.Attack(m.Name, m.Platform)
 	if m.Extended {
 		var msg *pb.Message
-		msg, err = addJob(agentID.String(), command[0], command[1:])
+		msg, err = addJob(ctx, agentID.String(), command[0], command[1:], techniques...)
 		if err != nil {
 			msgs.Messages = append(msgs.Messages, NewPBErrorMessage(err))
 		} else {
@@ -240,7 +308,7 @@ func (s *Server) RunModule(ctx context.Context, m *pb.ModuleRun) (msgs *pb.Messa
 	} else {
 		// Standard modules use the `cmd` message type that must be in position 0
 		var msg *pb.Message
-		msg, err = addJob(agentID.String(), "run", command)
+		msg, err = addJob(ctx, agentID.String(), "run", command, techniques...)
 		if err != nil {
 			msgs.Messages = append(msgs.Messages, NewPBErrorMessage(err))
 		} else {
@@ -0,0 +1,174 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	"github.com/Ne0nd0g/merlin/v2/pkg/payloads"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH THE PAYLOAD INVENTORY SERVICE */
+
+// RegisterPayload adds a payload built by the payload generator to the inventory with its hashes for deconfliction.
+// Options: either File (a path on the server) or Data (base64 encoded contents) with Name. Optional: Listener (name or
+// ID) the payload communicates with, Built (RFC3339 time, defaults to now), and Source (defaults to generator)
+func (s *Server) RegisterPayload(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	options := in.GetOptions()
+	name := options["Name"]
+	var data []byte
+	switch {
+	case options["File"] != "":
+		data, err = os.ReadFile(options["File"])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.RegisterPayload(): there was an error reading %s: %s", options["File"], err)
+			slog.Error(err.Error())
+			return
+		}
+		if name == "" {
+			name = filepath.Base(options["File"])
+		}
+	case options["Data"] != "":
+		data, err = base64.StdEncoding.DecodeString(options["Data"])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.RegisterPayload(): there was an error base64 decoding the payload data: %s", err)
+			slog.Error(err.Error())
+			return
+		}
+		if name == "" {
+			err = fmt.Errorf("pkg/services/rpc.RegisterPayload(): the Name option is required with the Data option")
+			slog.Error(err.Error())
+			return
+		}
+	default:
+		err = fmt.Errorf("pkg/services/rpc.RegisterPayload(): either the File or Data option is required")
+		slog.Error(err.Error())
+		return
+	}
+
+	var listenerID uuid.UUID
+	if options["Listener"] != "" {
+		listenerID, err = s.ls.Resolve(options["Listener"])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.RegisterPayload(): %s", err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+	var built time.Time
+	if options["Built"] != "" {
+		built, err = time.Parse(time.RFC3339, options["Built"])
+		if err != nil {
+			err = fmt.Errorf("pkg/services/rpc.RegisterPayload(): there was an error parsing the Built time '%s' as RFC3339: %s", options["Built"], err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+	source := options["Source"]
+	if source == "" {
+		source = "generator"
+	}
+
+	payload, err := s.payloads.Register(name, data, built, listenerID, s.operator(ctx), source)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Added payload %s to the inventory with SHA256 %s", payload.Name(), payload.SHA256()))
+	return
+}
+
+// GetPayloads returns a table of the payload inventory
+// in.Data = an optional MD5, SHA-1, or SHA-256 hash to only return the payloads that match it
+func (s *Server) GetPayloads(ctx context.Context, in *pb.String) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	table = &pb.TableData{
+		Header: []string{"Name", "SHA256", "SHA1", "MD5", "Size", "Built", "Listener", "Operator", "Source"},
+	}
+
+	var items []payloads.Payload
+	if in.GetData() == "" {
+		items = s.payloads.All()
+	} else {
+		items = s.payloads.Hash(in.GetData())
+	}
+	names := s.listenerNames()
+	for _, p := range items {
+		var listener string
+		if p.Listener() != uuid.Nil {
+			listener = p.Listener().String()
+			if name := names[p.Listener()]; name != "" {
+				listener = name
+			}
+		}
+		row := []string{
+			p.Name(),
+			p.SHA256(),
+			p.SHA1(),
+			p.MD5(),
+			strconv.Itoa(p.Size()),
+			p.Built().Format(time.RFC3339),
+			listener,
+			p.Operator(),
+			p.Source(),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
+
+// ExportPayloads returns the payload inventory with every payload's hashes as a deconfliction document for the blue team
+// in.Data = the export format, either csv (default) or json
+func (s *Server) ExportPayloads(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	data, err := s.payloads.Export(in.GetData(), s.listenerNames())
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBPlainMessage(string(data))
+	return
+}
+
+// listenerNames returns the names of every listener keyed by the listener's ID
+func (s *Server) listenerNames() map[uuid.UUID]string {
+	names := make(map[uuid.UUID]string)
+	for _, listener := range s.ls.Listeners() {
+		names[listener.ID()] = listener.Name()
+	}
+	return names
+}
@@ -0,0 +1,75 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH THE PERSISTENCE SERVICE */
+
+// GetPersistence returns a table of every persistence artifact Agents created for the provided Agent, or for all
+// Agents if the ID is empty, including artifacts that were removed, for the engagement report
+func (s *Server) GetPersistence(ctx context.Context, id *pb.ID) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "id", id)
+	table = &pb.TableData{
+		Header: []string{"ID", "Agent", "Technique", "Location", "Command", "Status", "Created", "Updated"},
+	}
+
+	var agentID uuid.UUID
+	if id.Id != "" {
+		agentID, err = uuid.Parse(id.Id)
+		if err != nil {
+			err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", id.Id, err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	for _, artifact := range s.persistence.All() {
+		if agentID != uuid.Nil && artifact.AgentID() != agentID {
+			continue
+		}
+		row := []string{
+			artifact.ID().String(),
+			artifact.AgentID().String(),
+			artifact.Technique(),
+			artifact.Location(),
+			artifact.Command(),
+			artifact.Status().String(),
+			artifact.Created().Format(time.RFC3339),
+			artifact.Updated().Format(time.RFC3339),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
@@ -0,0 +1,155 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/locale"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	"github.com/Ne0nd0g/merlin/v2/pkg/plugins"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH PLUGINS */
+
+// GetPlugins returns a table of every Agent command provided by a plugin so that the CLI can add them to its menu
+func (s *Server) GetPlugins(ctx context.Context, e *emptypb.Empty) (*pb.TableData, error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	data := &pb.TableData{
+		Header: []string{"Command", "Plugin", "Version", "OS", "Handler", "Min Args", "Usage", "Description"},
+	}
+	l := language(ctx)
+	for _, p := range plugins.Plugins() {
+		for _, c := range p.Commands {
+			description, usage := locale.Command(l, c.Name, c.Description, c.Usage)
+			handler := fmt.Sprintf("job %s", c.Job)
+			if c.IsScript() {
+				handler = fmt.Sprintf("script %s", c.Script)
+			}
+			platforms := strings.Join(c.OS, ",")
+			if platforms == "" {
+				platforms = "all"
+			}
+			row := []string{
+				c.Name,
+				p.Name,
+				p.Version,
+				platforms,
+				handler,
+				strconv.Itoa(c.MinArgs),
+				usage,
+				description,
+			}
+			data.Rows = append(data.Rows, &pb.TableRows{Row: row})
+		}
+	}
+	return data, nil
+}
+
+// ReloadPlugins re-reads every plugin from the plugins directory so that plugins can be added or changed without
+// restarting the server
+func (s *Server) ReloadPlugins(ctx context.Context, e *emptypb.Empty) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "empty", e)
+	loaded, errs := plugins.Reload()
+	if len(errs) > 0 {
+		m := fmt.Sprintf("Reloaded %d plugins with %d errors:", loaded, len(errs))
+		for _, e := range errs {
+			m += fmt.Sprintf("\n\t%s", e)
+		}
+		msg = NewPBWarnMessage(m)
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Reloaded %d plugins", loaded))
+	return
+}
+
+// RunPlugin runs a command provided by a plugin for the Agent
+// in.Arguments[0] = the plugin command's name
+// in.Arguments[1:] = the operator's arguments for the command
+func (s *Server) RunPlugin(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	msg = &pb.Message{}
+	if len(in.Arguments) < 1 {
+		err = fmt.Errorf("pkg/services/rpc.RunPlugin(): the plugin command's name is required")
+		slog.Error(err.Error())
+		return
+	}
+	command, err := plugins.Get(in.Arguments[0])
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	agentID, err := uuid.Parse(in.ID)
+	if err != nil {
+		err = fmt.Errorf("there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		slog.Error(err.Error())
+		return
+	}
+	if !forced(ctx) {
+		err = s.jobService.Supported(agentID, command.Name, command.OS...)
+		if err != nil {
+			slog.Error(err.Error())
+			return
+		}
+	}
+	args := in.Arguments[1:]
+
+	// Script commands run on the server and task the Agent themselves
+	if command.IsScript() {
+		if agentID.String() == "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+			err = fmt.Errorf("the %s plugin command can only be run against a single agent", command.Name)
+			slog.Error(err.Error())
+			return
+		}
+		var options map[string]string
+		options, err = command.Options(args)
+		if err != nil {
+			slog.Error(err.Error())
+			return
+		}
+		var run string
+		run, err = s.scripts.Run(command.ScriptPath(), agentID, options)
+		if err != nil {
+			slog.Error(err.Error())
+			return
+		}
+		msg = NewPBNoteMessage(fmt.Sprintf("Started the %s plugin's %s command for agent %s as run %s", command.Plugin(), command.Name, agentID, run))
+		return
+	}
+
+	jobArgs, err := command.Build(args)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	return addJob(ctx, in.ID, command.Job, jobArgs)
+}
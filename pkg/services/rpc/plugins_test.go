@@ -0,0 +1,130 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/plugins"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// loadPlugins loads a plugin with the provided manifest and unloads it once the test finishes
+func loadPlugins(t *testing.T, manifest string) {
+	t.Helper()
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "test"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "test", "plugin.yaml"), []byte(manifest), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := plugins.Load(dir); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	t.Cleanup(func() { plugins.Load(filepath.Join(dir, "none")) })
+}
+
+// TestGetPlugins verifies every plugin command is listed with its platforms and handler
+func TestGetPlugins(t *testing.T) {
+	s := newServer()
+	loadPlugins(t, `
+name: recon
+version: 1.0.0
+commands:
+  - name: whoami-all
+    os: [windows]
+    job: run
+    args: ["whoami.exe", "/all"]
+  - name: uptime-all
+    job: run
+`)
+	table, err := s.GetPlugins(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"whoami-all": "recon 1.0.0 windows job run",
+		"uptime-all": "recon 1.0.0 all job run",
+	}
+	if len(table.Rows) != len(want) {
+		t.Fatalf("expected %d rows, have %d", len(want), len(table.Rows))
+	}
+	for _, row := range table.Rows {
+		if have := strings.Join(row.Row[1:5], " "); have != want[row.Row[0]] {
+			t.Errorf("expected the %s row to be %q, have %q", row.Row[0], want[row.Row[0]], have)
+		}
+	}
+}
+
+// TestRunPluginRefused verifies plugin commands that can't be run are refused before the Agent is tasked
+func TestRunPluginRefused(t *testing.T) {
+	s := newServer()
+	a := newTestAgent(t, s)
+	a.UpdateHost(agents.Host{Platform: "linux"})
+	if err := s.agentService.Update(a); err != nil {
+		t.Fatal(err)
+	}
+	loadPlugins(t, `
+commands:
+  - name: whoami-all
+    os: [windows]
+    job: run
+    args: ["whoami.exe", "/all"]
+  - name: ping
+    minArgs: 1
+    job: run
+    args: ["ping", "{{1}}"]
+`)
+	tests := []struct {
+		name string
+		in   *pb.AgentCMD
+		err  string
+	}{
+		{"no command", &pb.AgentCMD{ID: a.ID().String()}, "command's name is required"},
+		{"unknown command", &pb.AgentCMD{ID: a.ID().String(), Arguments: []string{"missing"}}, "does not provide the missing command"},
+		{"invalid agent", &pb.AgentCMD{ID: "agent", Arguments: []string{"ping", "192.0.2.1"}}, "as a UUID"},
+		{"unsupported platform", &pb.AgentCMD{ID: a.ID().String(), Arguments: []string{"whoami-all"}}, "only supported by windows agents"},
+		{"too few arguments", &pb.AgentCMD{ID: a.ID().String(), Arguments: []string{"ping"}}, "requires at least 1 argument"},
+		{"unknown agent", &pb.AgentCMD{ID: uuid.NewString(), Arguments: []string{"ping"}}, "requires at least 1 argument"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.RunPlugin(context.Background(), test.in)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, have %v", test.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	// Internal
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/listeners"
+)
+
+// resolve is a gRPC interceptor that replaces Agent friendly names and unambiguous Agent or listener ID prefixes in a
+// request with the full ID so that they are accepted everywhere an ID is. An ambiguous prefix returns an error that
+// lists the matching IDs
+func (s *Service) resolve(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var err error
+	switch in := req.(type) {
+	case *pb.AgentCMD:
+		in.ID, err = s.rpcServer.resolveID(in.ID, false)
+	case *pb.ID:
+		in.Id, err = s.rpcServer.resolveID(in.Id, true)
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// resolveID returns the full ID of the Agent, or listener if withListeners is true, that the provided value identifies.
+// The value is returned unchanged if it is already a UUID, if it is the name of an Agent group, or if it doesn't
+// identify any Agent or listener so that the RPC method handles it
+func (s *Server) resolveID(value string, withListeners bool) (string, error) {
+	if _, err := uuid.Parse(value); err == nil || value == "" {
+		return value, nil
+	}
+	if slices.Contains(s.agentService.Groups(), value) {
+		return value, nil
+	}
+
+	agentID, err := s.agentService.Resolve(value)
+	if errors.Is(err, agent.ErrAmbiguousID) {
+		return value, fmt.Errorf("pkg/services/rpc.resolveID(): %w", err)
+	}
+	if !withListeners {
+		if err != nil {
+			return value, nil
+		}
+		return agentID.String(), nil
+	}
+
+	listenerID, errL := s.ls.Resolve(value)
+	if errors.Is(errL, listeners.ErrAmbiguousID) {
+		return value, fmt.Errorf("pkg/services/rpc.resolveID(): %w", errL)
+	}
+	switch {
+	case err == nil && errL == nil:
+		return value, fmt.Errorf("pkg/services/rpc.resolveID(): '%s' matches agent %s and listener %s", value, agentID, listenerID)
+	case err == nil:
+		return agentID.String(), nil
+	case errL == nil:
+		return listenerID.String(), nil
+	default:
+		return value, nil
+	}
+}
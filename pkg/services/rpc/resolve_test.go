@@ -0,0 +1,132 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+)
+
+// TestResolveID verifies Agent names and ID prefixes in requests are replaced with the Agent's ID while IDs, groups, and
+// unknown values are left unchanged
+func TestResolveID(t *testing.T) {
+	s := newServer()
+	a := newTestAgent(t, s)
+	name := "resolve-" + uuid.NewString()[:8]
+	err := s.agentService.Rename(a.ID(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group := "group-" + uuid.NewString()[:8]
+	err = s.agentService.AddAgentToGroup(group, a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.agentService.RemoveAgentFromGroup(group, a.ID()) })
+
+	service := &Service{rpcServer: s}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+	tests := []struct {
+		name  string
+		agent string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"ID", a.ID().String(), a.ID().String()},
+		{"name", name, a.ID().String()},
+		{"prefix", a.ID().String()[:13], a.ID().String()},
+		{"group", group, group},
+		{"unknown", "unknown-" + name, "unknown-" + name},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := service.resolve(context.Background(), &pb.AgentCMD{ID: test.agent}, &grpc.UnaryServerInfo{}, handler)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := req.(*pb.AgentCMD).ID; have != test.want {
+				t.Errorf("expected the AgentCMD ID %q, have %q", test.want, have)
+			}
+			req, err = service.resolve(context.Background(), &pb.ID{Id: test.agent}, &grpc.UnaryServerInfo{}, handler)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := req.(*pb.ID).Id; have != test.want {
+				t.Errorf("expected the ID %q, have %q", test.want, have)
+			}
+		})
+	}
+}
+
+// TestResolveAmbiguous verifies a request with an ID prefix that matches more than one Agent is refused
+func TestResolveAmbiguous(t *testing.T) {
+	s := newServer()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+	for _, id := range []string{"c1090dbc-f2f7-4d90-a241-86e0c0217786", "c1091f6e-8a51-4f0b-9c0e-5a2d1b7c3e90"} {
+		a, err := agents.NewAgent(uuid.MustParse(id), nil, nil, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.agentService.Add(a); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = s.agentService.Remove(a.ID()) })
+	}
+
+	service := &Service{rpcServer: s}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+	tests := []struct {
+		name string
+		req  interface{}
+	}{
+		{"AgentCMD", &pb.AgentCMD{ID: "c109"}},
+		{"ID", &pb.ID{Id: "c109"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.resolve(context.Background(), test.req, &grpc.UnaryServerInfo{}, handler)
+			if !errors.Is(err, agent.ErrAmbiguousID) {
+				t.Errorf("expected %v, have %v", agent.ErrAmbiguousID, err)
+			}
+		})
+	}
+}
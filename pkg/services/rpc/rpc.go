@@ -36,6 +36,7 @@ import (
 	"net"
 	"os"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -56,19 +57,43 @@ import (
 	"github.com/Ne0nd0g/merlin/v2/pkg/modules/socks"
 	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
 	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/bloodhound"
+	credentialService "github.com/Ne0nd0g/merlin/v2/pkg/services/credentials"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/directory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/hosts"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/ioc"
 	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
 	"github.com/Ne0nd0g/merlin/v2/pkg/services/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/loot"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/payloads"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/persistence"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/scan"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/script"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/selftest"
 )
 
 // Server is the structure used with the RPC service
 type Server struct {
 	pb.UnimplementedMerlinServer
+	ctx          context.Context                // ctx is cancelled when the RPC service stops to stop long-running work like listeners
+	cancel       context.CancelFunc             // cancel stops everything started with ctx
 	messageChan  map[uuid.UUID]chan *pb.Message // messageChan is a channel of messages to send to the client
 	ls           listeners.ListenerService      // ls is the service used to interact with the Listeners service on the server
 	clientRepo   client.Repository              // clientRepo is the repository (data store) of CLI clients connected to the RPC server
 	messageRepo  message.Repository             // messageRepo is the repository (data store) of messages to send to connected CLI clients
 	agentService *agent.Service                 // agentService is the service used to interact with the Agents service on the server
 	jobService   *job.Service                   // jobService is the service used to interact with the agent Job service on the server
+	lootService  *loot.Service                  // lootService is the service used to interact with data collected from Agents
+	credService  *credentialService.Service     // credService is the service used to interact with the credential store
+	iocService   *ioc.Service                   // iocService is the service used to track Indicators of Compromise created by Agent jobs
+	dirService   *directory.Service             // dirService is the service used to query Active Directory objects enumerated by Agents
+	persistence  *persistence.Service           // persistence is the service used to track persistence artifacts Agents created
+	scanService  *scan.Service                  // scanService is the service used to query network discovery results returned by Agents
+	scripts      *script.Service                // scripts is the service used to run server-side script modules
+	bloodhound   *bloodhound.Service            // bloodhound is the service used to run the SharpHound collector through Agents
+	hostService  *hosts.Service                 // hostService is the service used to track hosts in the target environment
+	payloads     *payloads.Service              // payloads is the service used to keep the inventory of payload hashes for deconfliction
+	selfTest     *selftest.Service              // selfTest is the service used to test a Listener's Agent message pipeline
 
 }
 
@@ -77,6 +102,8 @@ type Service struct {
 	rpcServer *Server
 	password  string // password is the string that connecting RPC clients must have
 	tlsConfig *tls.Config
+	grpc      *grpc.Server // grpc is the running gRPC server, used to stop it during shutdown
+	shutdown  sync.Once    // shutdown ensures the server is only shut down once
 }
 
 // services in the instantiated Service structure for this CLI service
@@ -84,13 +111,27 @@ var service *Service
 
 // newServer is a factory to create a new Server structure that holds references to server-side repositories and services
 func newServer() *Server {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Server{
+		ctx:          ctx,
+		cancel:       cancel,
 		messageChan:  make(map[uuid.UUID]chan *pb.Message),
 		ls:           listeners.NewListenerService(),
 		clientRepo:   withMemoryClientRepository(),
 		messageRepo:  withMemoryClientMessageRepository(),
 		agentService: agent.NewAgentService(),
 		jobService:   job.NewJobService(),
+		lootService:  loot.NewLootService(),
+		credService:  credentialService.NewCredentialService(),
+		dirService:   directory.NewDirectoryService(),
+		iocService:   ioc.NewIOCService(),
+		persistence:  persistence.NewPersistenceService(),
+		scanService:  scan.NewScanService(),
+		scripts:      script.NewScriptService(),
+		bloodhound:   bloodhound.NewBloodHoundService(),
+		hostService:  hosts.NewHostService(),
+		payloads:     payloads.NewPayloadService(),
+		selfTest:     selftest.NewSelfTestService(),
 	}
 }
 
@@ -338,16 +379,20 @@ func (s *Service) Run(addr string) error {
 
 	// Create a new gRPC server
 	var opts []grpc.ServerOption
-	opts = append(opts, grpc.UnaryInterceptor(s.authentication))
+	opts = append(opts, grpc.ChainUnaryInterceptor(s.authentication, s.errorStatus, s.resolve, s.approval))
 	opts = append(opts, grpc.StreamInterceptor(s.authenticationStream))
 	opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
 	grpcServer := grpc.NewServer(opts...)
+	s.grpc = grpcServer
 
 	// Register the server with the gRPC server
 	pb.RegisterMerlinServer(grpcServer, s.rpcServer)
 
 	go s.rpcServer.ListenForClientMessages()
 
+	// Stop the listeners and any other work started by the RPC server when it exits
+	defer s.rpcServer.cancel()
+
 	// Start the gRPC server
 	log.Printf("Starting gRPC server on %s", addr)
 	err = grpcServer.Serve(lis)
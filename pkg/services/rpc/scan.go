@@ -0,0 +1,70 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+/* RPC METHODS TO INTERACT WITH THE SCAN SERVICE */
+
+// ExportScanResults returns all open ports discovered by Agent scan jobs
+// in.Data = the export format, either csv (default) or json
+func (s *Server) ExportScanResults(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	data, err := s.scanService.Export(in.Data)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBPlainMessage(string(data))
+	return
+}
+
+// GetScanResults returns a table of open ports discovered by Agent scan jobs
+// in.Data = optional host IP address to return results for; all hosts are returned if empty
+func (s *Server) GetScanResults(ctx context.Context, in *pb.String) (table *pb.TableData, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	table = &pb.TableData{
+		Header: []string{"Host", "Port", "Protocol", "State", "Banner", "Agent", "Scanned"},
+	}
+	for _, p := range s.scanService.Open(in.Data) {
+		row := []string{
+			p.Host(),
+			fmt.Sprintf("%d", p.Number()),
+			p.Protocol(),
+			p.State(),
+			p.Banner(),
+			p.AgentID().String(),
+			p.Scanned().Format(time.RFC3339),
+		}
+		table.Rows = append(table.Rows, &pb.TableRows{Row: row})
+	}
+	return
+}
@@ -0,0 +1,92 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// ExportAgent returns an authenticated Agent's session, encrypted with a passphrase, so that the Agent can be handed off
+// to another Merlin server with ImportAgent. The Agent is not removed from this server
+// args[0] = the passphrase used to encrypt the session
+func (s *Server) ExportAgent(ctx context.Context, in *pb.AgentCMD) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	agentID, err := uuid.Parse(in.ID)
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.ExportAgent(): there was an error parsing '%s' as a UUID: %s", in.ID, err)
+		slog.Error(err.Error())
+		return
+	}
+	if len(in.Arguments) < 1 {
+		err = fmt.Errorf("pkg/services/rpc.ExportAgent(): a passphrase to encrypt the session is required")
+		slog.Error(err.Error())
+		return
+	}
+	session, err := s.agentService.Export(agentID, in.Arguments[0])
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.ExportAgent(): %w", err)
+		slog.Error(err.Error())
+		return
+	}
+	s.messageRepo.Add(message.NewMessage(message.Warn, fmt.Sprintf("Agent %s's session was exported; remove the Agent from this server once it is checking in to the other server", agentID)))
+	msg = NewPBPlainMessage(session)
+	return
+}
+
+// ImportAgent adds an Agent from a session exported by another Merlin server with ExportAgent
+// in.Options["Session"] = the exported session
+// in.Options["Passphrase"] = the passphrase the session was encrypted with
+// in.Options["Listener"] = the name of the listener the Agent will communicate with; it must be configured like the
+// listener the Agent was using, including its PSK, JWT key, and transforms
+func (s *Server) ImportAgent(ctx context.Context, in *pb.Options) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	options := in.GetOptions()
+	if options["Session"] == "" || options["Passphrase"] == "" || options["Listener"] == "" {
+		err = fmt.Errorf("pkg/services/rpc.ImportAgent(): the Session, Passphrase, and Listener options are required")
+		slog.Error(err.Error())
+		return
+	}
+	listener, err := s.ls.ListenerByName(options["Listener"])
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.ImportAgent(): %w", err)
+		slog.Error(err.Error())
+		return
+	}
+	agentID, err := s.agentService.Import(options["Session"], options["Passphrase"], listener.ID())
+	if err != nil {
+		err = fmt.Errorf("pkg/services/rpc.ImportAgent(): %w", err)
+		slog.Error(err.Error())
+		return
+	}
+	msg = NewPBSuccessMessage(fmt.Sprintf("Imported Agent %s on the '%s' listener", agentID, listener.Name()))
+	return
+}
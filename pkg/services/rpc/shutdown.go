@@ -0,0 +1,153 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+	"github.com/Ne0nd0g/merlin/v2/pkg/logging"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+const (
+	// sleepTimeout is the longest the server waits for Agents to receive the shutdown sleep job
+	sleepTimeout = 2 * time.Minute
+	// stopTimeout is the longest the server waits for RPC clients to receive the final messages before disconnecting them
+	stopTimeout = 5 * time.Second
+)
+
+// Shutdown gracefully stops the Merlin server. Connected RPC clients are notified, Agents are optionally tasked with an
+// extended sleep, and all listeners are stopped before the RPC server exits
+// in.Data = the amount of time Agents should sleep (e.g., 12h); an empty string does not task the Agents
+func (s *Server) Shutdown(ctx context.Context, in *pb.String) (msg *pb.Message, err error) {
+	slog.Log(context.Background(), logging.LevelTrace, "entering into function", "context", ctx, "in", in)
+	if in.Data != "" {
+		_, err = time.ParseDuration(in.Data)
+		if err != nil {
+			err = fmt.Errorf("there was an error parsing the Agent sleep time '%s' as a duration: %s", in.Data, err)
+			slog.Error(err.Error())
+			return
+		}
+	}
+	if service == nil {
+		err = fmt.Errorf("the RPC service has not been initialized")
+		slog.Error(err.Error())
+		return
+	}
+	// Shutdown in the background so that this RPC call returns to the client
+	go service.Shutdown(in.Data)
+	msg = NewPBNoteMessage("The Merlin server is shutting down")
+	return
+}
+
+// Shutdown gracefully stops the RPC service and everything it started. If sleep is not empty, every alive Agent is
+// tasked to sleep for that amount of time, and the server waits for them to check in and receive the job, so they
+// are not lost while the server is down. Subsequent calls do nothing
+func (s *Service) Shutdown(sleep string) {
+	s.shutdown.Do(func() {
+		server := s.rpcServer
+		notify := func(level message.Level, msg string) {
+			slog.Info(msg)
+			server.messageRepo.Add(message.NewMessage(level, msg))
+		}
+		notify(message.Warn, fmt.Sprintf("The Merlin server is shutting down at %s", time.Now().UTC().Format(time.RFC3339)))
+
+		// Task the Agents with an extended sleep
+		if sleep != "" {
+			var tasked []uuid.UUID
+			for _, agent := range server.agentService.Agents() {
+				if !agent.Alive() {
+					continue
+				}
+				_, err := server.jobService.Add(agent.ID(), "sleep", []string{sleep})
+				if err != nil {
+					notify(message.Warn, fmt.Sprintf("there was an error tasking Agent %s to sleep before shutdown: %s", agent.ID(), err))
+					continue
+				}
+				tasked = append(tasked, agent.ID())
+			}
+			notify(message.Info, fmt.Sprintf("Tasked %d Agent(s) to sleep for %s, waiting up to %s for them to check in", len(tasked), sleep, sleepTimeout))
+			waiting := server.waitForJobs(tasked, sleepTimeout)
+			if len(waiting) > 0 {
+				notify(message.Warn, fmt.Sprintf("%d Agent(s) did not receive the sleep job before shutdown: %s", len(waiting), waiting))
+			}
+		}
+
+		// Stop all listeners
+		for _, listener := range server.ls.Listeners() {
+			err := server.ls.Stop(listener.ID())
+			if err != nil {
+				slog.Error(fmt.Sprintf("there was an error stopping listener %s: %s", listener.Name(), err))
+			}
+		}
+		server.cancel()
+		notify(message.Warn, "All listeners were stopped, disconnecting RPC clients")
+
+		// Give the RPC clients a chance to receive the final messages
+		if s.grpc != nil {
+			stopped := make(chan struct{})
+			go func() {
+				s.grpc.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(stopTimeout):
+				s.grpc.Stop()
+			}
+		}
+	})
+}
+
+// waitForJobs waits until all the Agents have received their queued jobs or the timeout is reached and returns the
+// Agents that still have jobs that have not been sent
+func (s *Server) waitForJobs(agents []uuid.UUID, timeout time.Duration) (waiting []uuid.UUID) {
+	deadline := time.Now().Add(timeout)
+	for {
+		waiting = nil
+		for _, id := range agents {
+			active, err := s.jobService.GetAgentActive(id)
+			if err != nil {
+				continue
+			}
+			for _, job := range active {
+				if job.Status() == infoJobs.CREATED {
+					waiting = append(waiting, id)
+					break
+				}
+			}
+		}
+		if len(waiting) == 0 || time.Now().After(deadline) {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+}
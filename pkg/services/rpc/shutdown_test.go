@@ -0,0 +1,158 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rpc
+
+import (
+	// Standard
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	pb "github.com/Ne0nd0g/merlin/v2/pkg/rpc"
+)
+
+// newTestAgent adds an alive Agent, whose log file is written to a temporary directory, to the server's Agent service
+func newTestAgent(t *testing.T, s *Server) agents.Agent {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	a, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.agentService.Add(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.agentService.Remove(a.ID()) })
+	err = s.agentService.UpdateAlive(a.ID(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestShutdownRPC(t *testing.T) {
+	tests := []struct {
+		name  string
+		sleep string
+	}{
+		{"invalid sleep", "forever"},
+		{"negative units", "12 hours"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newServer().Shutdown(context.Background(), &pb.String{Data: test.sleep})
+			if err == nil {
+				t.Error("expected an error for an invalid sleep duration")
+			}
+		})
+	}
+}
+
+func TestWaitForJobs(t *testing.T) {
+	s := newServer()
+	a := newTestAgent(t, s)
+	_, err := s.jobService.Add(a.ID(), "sleep", []string{"12h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if waiting := s.waitForJobs([]uuid.UUID{a.ID()}, 0); len(waiting) != 1 {
+		t.Fatalf("expected the Agent to be waiting for its job, have %v", waiting)
+	}
+	_, err = s.jobService.Get(context.Background(), a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if waiting := s.waitForJobs([]uuid.UUID{a.ID()}, 0); len(waiting) != 0 {
+		t.Fatalf("expected no Agents to be waiting once the job was sent, have %v", waiting)
+	}
+}
+
+// TestServiceShutdown verifies Agents are tasked to sleep, the shutdown waits for them to receive the job, the root
+// context is cancelled, and subsequent calls do nothing
+func TestServiceShutdown(t *testing.T) {
+	svc := &Service{rpcServer: newServer()}
+	a := newTestAgent(t, svc.rpcServer)
+
+	done := make(chan struct{})
+	go func() {
+		svc.Shutdown("12h")
+		close(done)
+	}()
+
+	// The Agent checks in and receives the sleep job
+	var received bool
+	for i := 0; i < 100 && !received; i++ {
+		returned, _ := svc.rpcServer.jobService.Get(context.Background(), a.ID())
+		received = len(returned) > 0
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !received {
+		t.Fatal("the Agent was never tasked to sleep")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the shutdown did not complete after the Agent received the sleep job")
+	}
+	if svc.rpcServer.ctx.Err() == nil {
+		t.Error("expected the root context to be cancelled")
+	}
+
+	var notified bool
+	for _, m := range svc.rpcServer.messageRepo.GetAll() {
+		if strings.Contains(m.Message(), "Tasked 1 Agent(s) to sleep for 12h") {
+			notified = true
+		}
+	}
+	if !notified {
+		t.Error("expected the RPC clients to be notified the Agents were tasked to sleep")
+	}
+
+	// A second call returns immediately
+	second := make(chan struct{})
+	go func() {
+		svc.Shutdown("12h")
+		close(second)
+	}()
+	select {
+	case <-second:
+	case <-time.After(time.Second):
+		t.Error("a subsequent shutdown did not return immediately")
+	}
+}
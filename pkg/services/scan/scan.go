@@ -0,0 +1,150 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package scan is a service used to store and export network discovery results returned by an Agent's scan command
+package scan
+
+import (
+	// Standard
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/scan"
+	"github.com/Ne0nd0g/merlin/v2/pkg/scan/memory"
+)
+
+// Service holds references to repositories to manage scan results
+type Service struct {
+	scanRepo scan.Repository
+}
+
+// memoryService is an in-memory instantiation of the scan service so that it can be used by others
+var memoryService *Service
+
+// NewScanService is a factory to create a scan service to be used by other packages or services
+func NewScanService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			scanRepo: WithScanMemoryRepository(),
+		}
+	}
+	return memoryService
+}
+
+// WithScanMemoryRepository retrieves an in-memory scan repository interface used to manage scan results
+func WithScanMemoryRepository() scan.Repository {
+	return memory.NewRepository()
+}
+
+// probe is a single scan result returned by the Agent
+type probe struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	State    string `json:"state"`
+	Banner   string `json:"banner"`
+}
+
+// AddResults parses a page of scan results returned by the Agent and stores them in the repository.
+// The Agent returns one JSON object per line containing the host, port, protocol, state, and any banner
+func (s *Service) AddResults(agentID uuid.UUID, jobID string, output string) (count int, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var p probe
+		err = json.Unmarshal([]byte(line), &p)
+		if err != nil {
+			return count, fmt.Errorf("pkg/services/scan.AddResults(): there was an error parsing the scan result: %s", err)
+		}
+		if p.Host == "" || p.Port < 1 || p.Port > 65535 {
+			return count, fmt.Errorf("pkg/services/scan.AddResults(): the scan result contained an invalid host or port: %s", line)
+		}
+		err = s.scanRepo.Add(scan.NewPort(p.Host, p.Port, p.Protocol, p.State, p.Banner, agentID, jobID))
+		if err != nil {
+			return
+		}
+		count++
+	}
+	return
+}
+
+// Export returns all open ports in the provided format, either csv or json
+func (s *Service) Export(format string) ([]byte, error) {
+	ports := s.Open("")
+	switch strings.ToLower(format) {
+	case "csv", "":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		err := w.Write([]string{"host", "port", "protocol", "state", "banner", "agent", "job", "scanned"})
+		if err != nil {
+			return nil, fmt.Errorf("pkg/services/scan.Export(): %s", err)
+		}
+		for _, p := range ports {
+			err = w.Write([]string{p.Host(), strconv.Itoa(p.Number()), p.Protocol(), p.State(), p.Banner(), p.AgentID().String(), p.JobID(), p.Scanned().Format(time.RFC3339)})
+			if err != nil {
+				return nil, fmt.Errorf("pkg/services/scan.Export(): %s", err)
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	case "json":
+		var probes []probe
+		for _, p := range ports {
+			probes = append(probes, probe{Host: p.Host(), Port: p.Number(), Protocol: p.Protocol(), State: p.State(), Banner: p.Banner()})
+		}
+		return json.MarshalIndent(probes, "", "  ")
+	default:
+		return nil, fmt.Errorf("pkg/services/scan.Export(): unknown export format '%s', expected csv or json", format)
+	}
+}
+
+// Job returns the open ports discovered by the scan job with the provided ID
+func (s *Service) Job(jobID string) (ports []scan.Port) {
+	for _, p := range s.Open("") {
+		if p.JobID() == jobID {
+			ports = append(ports, p)
+		}
+	}
+	return
+}
+
+// Open returns all stored open ports for the provided host, or for every host if the host is empty
+func (s *Service) Open(host string) (ports []scan.Port) {
+	for _, p := range s.scanRepo.GetAll() {
+		if p.State() != "open" {
+			continue
+		}
+		if host == "" || p.Host() == host {
+			ports = append(ports, p)
+		}
+	}
+	return
+}
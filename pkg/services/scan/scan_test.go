@@ -0,0 +1,128 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scan
+
+import (
+	// Standard
+	"encoding/json"
+	"strings"
+	"testing"
+
+	// 3rd Party
+	"github.com/google/uuid"
+)
+
+// TestAddResults verifies scan results are parsed one per line, rescanned ports are replaced, and only open ports
+// are returned and exported
+func TestAddResults(t *testing.T) {
+	s := NewScanService()
+	agent := uuid.New()
+
+	tests := []struct {
+		name   string
+		output string
+		count  int
+		err    string
+	}{
+		{"results", `{"host":"198.51.100.10","port":443,"state":"OPEN","banner":"nginx"}` + "\n" + `{"host":"198.51.100.9","port":22,"protocol":"tcp","state":"open"}` + "\n" + `{"host":"198.51.100.10","port":80,"state":"closed"}`, 3, ""},
+		{"rescan", `{"host":"198.51.100.10","port":80,"state":"open"}`, 1, ""},
+		{"invalid port", `{"host":"198.51.100.10","port":70000,"state":"open"}`, 0, "invalid host or port"},
+		{"missing host", `{"port":22,"state":"open"}`, 0, "invalid host or port"},
+		{"invalid JSON", "22/tcp open", 0, "parsing the scan result"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count, err := s.AddResults(agent, "job", test.output)
+			if count != test.count {
+				t.Errorf("expected %d results to be stored, got %d", test.count, count)
+			}
+			if test.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Errorf("expected an error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+
+	var open []string
+	for _, p := range s.Open("") {
+		open = append(open, p.Key())
+	}
+	want := "198.51.100.9:22/tcp,198.51.100.10:80/tcp,198.51.100.10:443/tcp"
+	if strings.Join(open, ",") != want {
+		t.Errorf("expected open ports %s, got %s", want, strings.Join(open, ","))
+	}
+	if n := len(s.Open("198.51.100.9")); n != 1 {
+		t.Errorf("expected 1 open port for 198.51.100.9, got %d", n)
+	}
+}
+
+// TestExport verifies open ports are exported as CSV or JSON
+func TestExport(t *testing.T) {
+	s := NewScanService()
+	if _, err := s.AddResults(uuid.New(), "job", `{"host":"203.0.113.5","port":8080,"state":"open","banner":"a, \"quoted\" banner"}`); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format string
+		check  func([]byte) bool
+		err    bool
+	}{
+		{"", func(data []byte) bool {
+			return strings.HasPrefix(string(data), "host,port,protocol,state,banner,agent,job,scanned\n")
+		}, false},
+		{"CSV", func(data []byte) bool {
+			return strings.Contains(string(data), `203.0.113.5,8080,tcp,open,"a, ""quoted"" banner"`)
+		}, false},
+		{"json", func(data []byte) bool {
+			var probes []probe
+			if json.Unmarshal(data, &probes) != nil {
+				return false
+			}
+			for _, p := range probes {
+				if p == (probe{Host: "203.0.113.5", Port: 8080, Protocol: "tcp", State: "open", Banner: `a, "quoted" banner`}) {
+					return true
+				}
+			}
+			return false
+		}, false},
+		{"xml", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			data, err := s.Export(test.format)
+			if test.err {
+				if err == nil {
+					t.Error("expected an error for an unknown export format")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !test.check(data) {
+				t.Errorf("unexpected %s export:\n%s", test.format, data)
+			}
+		})
+	}
+}
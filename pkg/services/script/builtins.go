@@ -0,0 +1,266 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package script
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+)
+
+// defaultWait is the number of seconds a script waits on a job's results when a timeout isn't provided
+const defaultWait = 300
+
+// run is a single execution of a script against an Agent
+type run struct {
+	id      uuid.UUID
+	name    string          // The script's file name
+	agent   uuid.UUID       // The Agent the script was run against
+	ctx     context.Context // Done when the script times out
+	service *Service
+}
+
+// predeclared returns the values and functions available to the script:
+//
+//	agent                                  the ID of the Agent the script was run against
+//	options                                the module's options as a dictionary of strings
+//	task(command, args=[], agent=agent)    queues an Agent command and returns the job ID
+//	wait(job, timeout=300)                 waits for the job to complete and returns its results
+//	run(command, args=[], agent=agent, timeout=300)
+//	                                       queues an Agent command and waits for its results
+//	info(agent=agent)                      returns the Agent's host and process information
+//	agents()                               returns the IDs of every alive Agent
+//	log(message)                           sends a message to clients; print() does the same
+//	sleep(seconds)                         pauses the script
+//	json                                   the Starlark json module with encode and decode functions
+//
+// Job results are a struct with id, stdout, and stderr fields
+func (r *run) predeclared(options map[string]string) starlark.StringDict {
+	opts := starlark.NewDict(len(options))
+	for k, v := range options {
+		_ = opts.SetKey(starlark.String(k), starlark.String(v))
+	}
+	opts.Freeze()
+
+	return starlark.StringDict{
+		"agent":   starlark.String(r.agent.String()),
+		"options": opts,
+		"task":    starlark.NewBuiltin("task", r.task),
+		"wait":    starlark.NewBuiltin("wait", r.wait),
+		"run":     starlark.NewBuiltin("run", r.runBuiltin),
+		"info":    starlark.NewBuiltin("info", r.info),
+		"agents":  starlark.NewBuiltin("agents", r.agents),
+		"log":     starlark.NewBuiltin("log", r.logBuiltin),
+		"sleep":   starlark.NewBuiltin("sleep", r.sleep),
+		"json":    json.Module,
+	}
+}
+
+// agentID parses the Agent ID a script provided, or returns the Agent the script was run against if it is empty
+func (r *run) agentID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return r.agent, nil
+	}
+	agentID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("there was an error parsing '%s' as an Agent ID: %s", id, err)
+	}
+	return agentID, nil
+}
+
+// queue adds the Agent command to the Agent's job queue and returns the job ID
+func (r *run) queue(b *starlark.Builtin, command string, args *starlark.List, id string) (string, error) {
+	agentID, err := r.agentID(id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	var jobArgs []string
+	if args != nil {
+		for i := 0; i < args.Len(); i++ {
+			arg, ok := starlark.AsString(args.Index(i))
+			if !ok {
+				return "", fmt.Errorf("%s: argument %d must be a string, got %s", b.Name(), i, args.Index(i).Type())
+			}
+			jobArgs = append(jobArgs, arg)
+		}
+	}
+	jobID, err := r.service.jobService.Task(agentID, command, jobArgs)
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if jobID == "" {
+		return "", fmt.Errorf("%s: the '%s' command is server-side only and does not create a job", b.Name(), command)
+	}
+	r.log(message.Note, fmt.Sprintf("Created job %s for agent %s: %s %v", jobID, agentID, command, jobArgs))
+	return jobID, nil
+}
+
+// results waits for the job to complete and returns its results as a struct
+func (r *run) results(b *starlark.Builtin, jobID string, timeout int) (starlark.Value, error) {
+	ctx, cancel := context.WithTimeout(r.ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	result, err := r.service.jobService.Wait(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return resultStruct(jobID, result), nil
+}
+
+// task is the Starlark task(command, args=[], agent=agent) function
+func (r *run) task(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var command, id string
+	var jobArgs *starlark.List
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "command", &command, "args?", &jobArgs, "agent?", &id)
+	if err != nil {
+		return nil, err
+	}
+	jobID, err := r.queue(b, command, jobArgs, id)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.String(jobID), nil
+}
+
+// wait is the Starlark wait(job, timeout=300) function
+func (r *run) wait(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var jobID string
+	timeout := defaultWait
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "job", &jobID, "timeout?", &timeout)
+	if err != nil {
+		return nil, err
+	}
+	return r.results(b, jobID, timeout)
+}
+
+// runBuiltin is the Starlark run(command, args=[], agent=agent, timeout=300) function
+func (r *run) runBuiltin(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var command, id string
+	var jobArgs *starlark.List
+	timeout := defaultWait
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "command", &command, "args?", &jobArgs, "agent?", &id, "timeout?", &timeout)
+	if err != nil {
+		return nil, err
+	}
+	jobID, err := r.queue(b, command, jobArgs, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.results(b, jobID, timeout)
+}
+
+// info is the Starlark info(agent=agent) function
+func (r *run) info(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "agent?", &id)
+	if err != nil {
+		return nil, err
+	}
+	agentID, err := r.agentID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	a, err := r.service.agentService.Agent(agentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	var ips []starlark.Value
+	for _, ip := range a.Host().IPs {
+		ips = append(ips, starlark.String(ip))
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"id":         starlark.String(a.ID().String()),
+		"alive":      starlark.Bool(a.Alive()),
+		"platform":   starlark.String(a.Host().Platform),
+		"arch":       starlark.String(a.Host().Architecture),
+		"hostname":   starlark.String(a.Host().Name),
+		"ips":        starlark.NewList(ips),
+		"user":       starlark.String(a.Process().UserName),
+		"domain":     starlark.String(a.Process().Domain),
+		"process":    starlark.String(a.Process().Name),
+		"pid":        starlark.MakeInt(a.Process().ID),
+		"integrity":  starlark.MakeInt(a.Process().Integrity),
+		"privilege":  starlark.String(a.Privilege()),
+		"privileged": starlark.Bool(a.Privileged()),
+	}), nil
+}
+
+// agents is the Starlark agents() function
+func (r *run) agents(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	err := starlark.UnpackArgs(b.Name(), args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	var ids []starlark.Value
+	for _, a := range r.service.agentService.Agents() {
+		if a.Alive() {
+			ids = append(ids, starlark.String(a.ID().String()))
+		}
+	}
+	return starlark.NewList(ids), nil
+}
+
+// logBuiltin is the Starlark log(message) function
+func (r *run) logBuiltin(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var msg string
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "message", &msg)
+	if err != nil {
+		return nil, err
+	}
+	r.log(message.Info, msg)
+	return starlark.None, nil
+}
+
+// sleep is the Starlark sleep(seconds) function
+func (r *run) sleep(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seconds float64
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "seconds", &seconds)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-time.After(time.Duration(seconds * float64(time.Second))):
+		return starlark.None, nil
+	case <-r.ctx.Done():
+		return nil, fmt.Errorf("%s: %s", b.Name(), r.ctx.Err())
+	}
+}
+
+// resultStruct converts a job's results into a Starlark struct with id, stdout, and stderr fields
+func resultStruct(jobID string, result jobs.Results) starlark.Value {
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"id":     starlark.String(jobID),
+		"stdout": starlark.String(result.Stdout),
+		"stderr": starlark.String(result.Stderr),
+	})
+}
@@ -0,0 +1,149 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package script is a service that runs server-side Starlark scripts that orchestrate Agent jobs, parse their results,
+// and make decisions, such as a privilege escalation chain, without recompiling the server
+package script
+
+import (
+	// Standard
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message/memory"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+)
+
+const (
+	// scriptTimeout is the longest a script runs before it is canceled
+	scriptTimeout = time.Hour
+	// maxSteps is the number of Starlark computation steps a script can execute so that a runaway loop doesn't consume
+	// the server's CPU; time spent waiting on Agent jobs does not count
+	maxSteps = 100_000_000
+)
+
+// Service holds references to the services scripts use to task Agents and report their progress
+type Service struct {
+	agentService *agent.Service
+	jobService   *job.Service
+	messageRepo  message.Repository
+}
+
+// memoryService is an in-memory instantiation of the script service so that it can be used by others
+var memoryService *Service
+
+// NewScriptService is a factory to create a script service to be used by other packages or services
+func NewScriptService() *Service {
+	if memoryService == nil {
+		memoryService = &Service{
+			agentService: agent.NewAgentService(),
+			jobService:   job.NewJobService(),
+			messageRepo:  memory.NewRepository(),
+		}
+	}
+	return memoryService
+}
+
+// Run compiles the Starlark script at the provided path and executes it in the background for the Agent. The options
+// are available to the script as the 'options' dictionary. Compile errors are returned immediately while the script's
+// progress and any runtime error are sent to clients as messages. The ID of the script's run is returned
+func (s *Service) Run(path string, agentID uuid.UUID, options map[string]string) (string, error) {
+	if !s.agentService.Exist(agentID) {
+		return "", fmt.Errorf("pkg/services/script.Run(): agent %s does not exist", agentID)
+	}
+	src, err := os.ReadFile(path) // #nosec G304 - Script modules are loaded from the server's module directory
+	if err != nil {
+		return "", fmt.Errorf("pkg/services/script.Run(): there was an error reading the script: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scriptTimeout)
+	r := &run{
+		id:      uuid.New(),
+		name:    filepath.Base(path),
+		agent:   agentID,
+		ctx:     ctx,
+		service: s,
+	}
+	predeclared := r.predeclared(options)
+	// Scripts are top-level automation, not Bazel-style configuration, so allow if statements, loops, and reassignment
+	// outside of functions
+	opts := &syntax.FileOptions{Set: true, While: true, TopLevelControl: true, GlobalReassign: true}
+	_, program, err := starlark.SourceProgramOptions(opts, path, src, predeclared.Has)
+	if err != nil {
+		cancel()
+		return "", fmt.Errorf("pkg/services/script.Run(): there was an error compiling the %s script: %s", r.name, err)
+	}
+
+	thread := &starlark.Thread{
+		Name:  r.id.String(),
+		Print: func(_ *starlark.Thread, msg string) { r.log(message.Info, msg) },
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+
+	go func() {
+		defer cancel()
+		// A panic in a builtin, such as while tasking the Agent, fails the script instead of crashing the server
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				slog.Error(fmt.Sprintf("pkg/services/script.Run(): recovered from a panic in the %s script: %v", r.name, recovered))
+				r.log(message.Warn, fmt.Sprintf("Script failed: %v", recovered))
+			}
+		}()
+		// Stop the script's Starlark computation when it times out; waits on Agent jobs use the context directly
+		go func() {
+			<-ctx.Done()
+			thread.Cancel(ctx.Err().Error())
+		}()
+
+		r.log(message.Info, fmt.Sprintf("Started script for agent %s", agentID))
+		_, err := program.Init(thread, predeclared)
+		if err != nil {
+			if evalErr, ok := err.(*starlark.EvalError); ok {
+				err = fmt.Errorf("%s", evalErr.Backtrace())
+			}
+			r.log(message.Warn, fmt.Sprintf("Script failed: %s", err))
+			return
+		}
+		r.log(message.Success, "Script completed")
+	}()
+	return r.id.String(), nil
+}
+
+// log sends a message about the script's progress to clients and writes it to the Agent's log
+func (r *run) log(level message.Level, msg string) {
+	msg = fmt.Sprintf("[script %s %s] %s", r.name, r.id, msg)
+	slog.Info(msg, "agent", r.agent)
+	if a, err := r.service.agentService.Agent(r.agent); err == nil {
+		a.Log(msg)
+	}
+	r.service.messageRepo.Add(message.NewMessage(level, msg))
+}
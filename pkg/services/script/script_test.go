@@ -0,0 +1,213 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package script
+
+import (
+	// Standard
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message/jobs"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/agents"
+	"github.com/Ne0nd0g/merlin/v2/pkg/client/message"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+)
+
+// messageRecorder is a message repository that keeps every message so tests can wait for a script's progress
+type messageRecorder struct {
+	sync.Mutex
+	messages []string
+}
+
+func (r *messageRecorder) Add(m *message.Message) {
+	r.Lock()
+	defer r.Unlock()
+	r.messages = append(r.messages, m.Message())
+}
+
+func (r *messageRecorder) Get(id uuid.UUID) (*message.Message, error) {
+	return nil, nil
+}
+
+func (r *messageRecorder) GetAll() []*message.Message {
+	return nil
+}
+
+func (r *messageRecorder) GetQueue() *message.Message {
+	return nil
+}
+
+// waitFor returns the first recorded message containing one of the substrings, or fails the test after a few seconds
+func (r *messageRecorder) waitFor(t *testing.T, substrings ...string) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		r.Lock()
+		for _, m := range r.messages {
+			for _, s := range substrings {
+				if strings.Contains(m, s) {
+					r.Unlock()
+					return m
+				}
+			}
+		}
+		r.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no message containing %q was recorded", substrings)
+	return ""
+}
+
+// newTestService returns a script Service and an Agent, whose log file is written to a temporary directory, to run
+// scripts against
+func newTestService(t *testing.T) (*Service, *messageRecorder, agents.Agent) {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	a, err := agents.NewAgent(uuid.New(), nil, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	agentService := agent.NewAgentService()
+	err = agentService.Add(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = agentService.Remove(a.ID()) })
+
+	recorder := &messageRecorder{}
+	s := &Service{
+		agentService: agentService,
+		jobService:   job.NewJobService(),
+		messageRepo:  recorder,
+	}
+	return s, recorder, a
+}
+
+// writeScript writes the Starlark source to a script file in a temporary directory and returns its path
+func writeScript(t *testing.T, src string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.star")
+	if err := os.WriteFile(path, []byte(src), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		agent   uuid.UUID
+		wantErr bool
+		want    string
+	}{
+		{"completed", `log("hello " + options["name"])`, uuid.Nil, false, "Script completed"},
+		{"options", `log("hello " + options["name"])`, uuid.Nil, false, "hello merlin"},
+		{"info", `log("running on " + info().id)`, uuid.Nil, false, "running on "},
+		{"fail", `fail("enumeration failed")`, uuid.Nil, false, "Script failed: "},
+		{"server-side command", `task("profile", ["reset"])`, uuid.Nil, false, "is server-side only"},
+		{"invalid argument", `task("run", [1])`, uuid.Nil, false, "argument 0 must be a string"},
+		{"invalid agent", `task("pwd", agent="merlin")`, uuid.Nil, false, "as an Agent ID"},
+		{"wait timeout", `wait(task("pwd"), timeout=0)`, uuid.Nil, false, "stopped waiting on job"},
+		{"compile error", `if True log("merlin")`, uuid.Nil, true, ""},
+		{"unknown agent", `log("merlin")`, uuid.New(), true, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, recorder, a := newTestService(t)
+			id := a.ID()
+			if test.agent != uuid.Nil {
+				id = test.agent
+			}
+			_, err := s.Run(writeScript(t, test.src), id, map[string]string{"name": "merlin"})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			recorder.waitFor(t, test.want)
+		})
+	}
+}
+
+// TestRunResults verifies a script receives the results of the job it is waiting on once the Agent returns them
+func TestRunResults(t *testing.T) {
+	s, recorder, a := newTestService(t)
+	_, err := s.Run(writeScript(t, "r = run(\"pwd\", timeout=10)\nlog(\"cwd: \" + r.stdout)"), a.ID(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.waitFor(t, "Created job")
+
+	queued, err := s.jobService.Get(context.Background(), a.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 1 {
+		t.Fatalf("expected one queued job, have %d", len(queued))
+	}
+	result := queued[0]
+	result.Type = jobs.RESULT
+	result.Payload = jobs.Results{Stdout: "/opt/merlin"}
+	err = s.jobService.Handler([]jobs.Job{result})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := recorder.waitFor(t, "cwd: ", "Script failed"); !strings.Contains(m, "cwd: /opt/merlin") {
+		t.Errorf("expected the script to log the job's results, have %q", m)
+	}
+}
+
+// TestRunPanic verifies a panic while a script runs fails the script instead of crashing the server
+func TestRunPanic(t *testing.T) {
+	s, recorder, a := newTestService(t)
+	// Without a job service, tasking the Agent dereferences a nil pointer
+	s.jobService = nil
+	_, err := s.Run(writeScript(t, `task("pwd")`), a.ID(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := recorder.waitFor(t, "Script failed", "Script completed"); !strings.Contains(m, "Script failed") {
+		t.Errorf("expected the script to fail, have %q", m)
+	}
+}
@@ -0,0 +1,360 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package selftest verifies the server's Agent message pipeline end-to-end. An in-process test Agent authenticates to
+// a Listener and completes a job using the Listener's transforms, authenticator, and the server's message handler
+package selftest
+
+import (
+	// Standard
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Merlin Message
+	"github.com/Ne0nd0g/merlin-message"
+	"github.com/Ne0nd0g/merlin-message/jobs"
+	opaqueMessage "github.com/Ne0nd0g/merlin-message/opaque"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/core"
+	infoJobs "github.com/Ne0nd0g/merlin/v2/pkg/jobs"
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/opaque"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/agent"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+	message "github.com/Ne0nd0g/merlin/v2/pkg/services/message"
+)
+
+// Step is the outcome of one stage of the self-test
+type Step struct {
+	Name     string        // Name of the pipeline stage
+	Passed   bool          // Passed is true if the stage worked as expected
+	Detail   string        // Detail describes what happened or why the stage failed
+	Duration time.Duration // Duration is how long the stage took
+}
+
+// Service holds references to the services the self-test uses to stand in for an Agent and clean up after it
+type Service struct {
+	agentService *agent.Service
+	jobService   *job.Service
+}
+
+// NewSelfTestService is a factory to create a self-test service
+func NewSelfTestService() *Service {
+	return &Service{
+		agentService: agent.NewAgentService(),
+		jobService:   job.NewJobService(),
+	}
+}
+
+// testAgent is the in-process Agent that sends its messages through a Listener's transforms to the message handler
+type testAgent struct {
+	id       uuid.UUID
+	secret   []byte
+	listener listeners.Listener
+	handler  *message.Service
+}
+
+// Run authenticates a new in-process test Agent to the Listener, checks it in, completes a job with it, and then
+// removes the test Agent. The test bypasses the Listener's network server; the returned steps describe each stage
+func (s *Service) Run(ctx context.Context, l listeners.Listener) (steps []Step, err error) {
+	if listeners.Draining(l.ID()) {
+		return nil, fmt.Errorf("pkg/services/selftest.Run(): listener %s is draining and does not accept new agent authentications", l.Name())
+	}
+	// The test Agent's authentication would expire a one-shot Listener before a real Agent uses it
+	if expiration, ok := listeners.GetExpiration(l.ID()); ok && expiration.Once {
+		return nil, fmt.Errorf("pkg/services/selftest.Run(): listener %s expires %s and can't be tested", l.Name(), expiration)
+	}
+	handler, err := message.NewMessageService(l.ID())
+	if err != nil {
+		return nil, fmt.Errorf("pkg/services/selftest.Run(): %s", err)
+	}
+
+	a := &testAgent{id: uuid.New(), listener: l, handler: handler}
+	slog.Info(fmt.Sprintf("Starting a self-test of listener %s with test agent %s", l.Name(), a.id))
+	// The test Agent's jobs, including those queued for every new Agent when it authenticates, must not reach operator
+	// hooks and webhooks
+	s.jobService.MuteHooks(a.id, true)
+	defer func() {
+		steps = append(steps, run("Cleanup", func() (string, error) { return s.cleanup(a.id) }))
+		s.jobService.MuteHooks(a.id, false)
+	}()
+
+	stages := []struct {
+		name string
+		test func() (string, error)
+	}{
+		{"Authentication", func() (string, error) { return a.authenticate(ctx) }},
+		{"Check in", func() (string, error) { return s.checkin(ctx, a) }},
+		{"Job round trip", func() (string, error) { return s.roundTrip(ctx, a) }},
+	}
+	for _, stage := range stages {
+		step := run(stage.name, stage.test)
+		steps = append(steps, step)
+		if !step.Passed {
+			break
+		}
+	}
+	return steps, nil
+}
+
+// Passed returns true if every step of the self-test passed
+func Passed(steps []Step) bool {
+	for _, step := range steps {
+		if !step.Passed {
+			return false
+		}
+	}
+	return len(steps) > 0
+}
+
+// run executes one stage of the self-test and records its outcome
+func run(name string, test func() (string, error)) Step {
+	start := time.Now()
+	detail, err := test()
+	step := Step{Name: name, Passed: err == nil, Detail: detail, Duration: time.Since(start)}
+	if err != nil {
+		step.Detail = err.Error()
+	}
+	return step
+}
+
+// send transforms the Base message with the Listener, as the Agent would, hands it to the message handler, and
+// returns the handler's response after transforming it back
+func (a *testAgent) send(ctx context.Context, msg messages.Base) (messages.Base, error) {
+	msg.ID = a.id
+	msg.Padding = core.RandStringBytesMaskImprSrc(64)
+	data, err := a.listener.Construct(msg, a.secret)
+	if err != nil {
+		return messages.Base{}, fmt.Errorf("the listener's transforms could not construct the agent's %s message: %s", msg.Type, err)
+	}
+	rdata, err := a.handler.Handle(ctx, a.id, data)
+	if err != nil {
+		return messages.Base{}, fmt.Errorf("the server could not handle the agent's %s message: %s", msg.Type, err)
+	}
+	if len(rdata) == 0 {
+		return messages.Base{}, fmt.Errorf("the server did not respond to the agent's %s message", msg.Type)
+	}
+	rmsg, err := a.listener.Deconstruct(rdata, a.secret)
+	if err != nil {
+		return messages.Base{}, fmt.Errorf("the listener's transforms could not deconstruct the server's response to the agent's %s message: %s", msg.Type, err)
+	}
+	if rmsg.ID != a.id {
+		return messages.Base{}, fmt.Errorf("the server's response was for agent %s instead of the test agent", rmsg.ID)
+	}
+	return rmsg, nil
+}
+
+// authenticate completes the Listener's authenticator with the server
+func (a *testAgent) authenticate(ctx context.Context) (string, error) {
+	if a.listener.Authenticator() == nil {
+		return "", fmt.Errorf("the listener does not have an authenticator")
+	}
+	switch a.listener.Authenticator().String() {
+	case "none":
+		msg, err := a.send(ctx, messages.Base{Type: messages.CHECKIN})
+		if err != nil {
+			return "", err
+		}
+		if msg.Type != messages.IDLE {
+			return "", fmt.Errorf("expected an %s message after authenticating but received %s", messages.IDLE, msg.Type)
+		}
+		return "authenticated with the none authenticator and the listener's pre-shared key", nil
+	case "OPAQUE":
+		return a.opaque(ctx)
+	default:
+		return "", fmt.Errorf("the self-test does not support the %s authenticator", a.listener.Authenticator())
+	}
+}
+
+// opaque registers and authenticates the test Agent with OPAQUE and keeps the session key it establishes
+func (a *testAgent) opaque(ctx context.Context) (string, error) {
+	password := make([]byte, 30)
+	_, err := rand.Read(password)
+	if err != nil {
+		return "", fmt.Errorf("there was an error generating the agent's OPAQUE password: %s", err)
+	}
+
+	o, user, err := opaque.UserRegisterInit(a.id, password)
+	if err != nil {
+		return "", err
+	}
+	o, err = a.exchange(ctx, o, opaqueMessage.RegInit)
+	if err != nil {
+		return "", fmt.Errorf("OPAQUE registration initialization failed: %s", err)
+	}
+
+	o, err = opaque.UserRegisterComplete(o, user)
+	if err != nil {
+		return "", err
+	}
+	_, err = a.exchange(ctx, o, opaqueMessage.RegComplete)
+	if err != nil {
+		return "", fmt.Errorf("OPAQUE registration completion failed: %s", err)
+	}
+
+	o, err = opaque.UserAuthenticateInit(user)
+	if err != nil {
+		return "", err
+	}
+	o, err = a.exchange(ctx, o, opaqueMessage.AuthInit)
+	if err != nil {
+		return "", fmt.Errorf("OPAQUE authentication initialization failed: %s", err)
+	}
+
+	o, err = opaque.UserAuthenticateComplete(o, user)
+	if err != nil {
+		return "", err
+	}
+	// The Agent encrypts the rest of its messages with the key established during authentication
+	a.secret = []byte(user.Kex.SharedSecret.String())
+	msg, err := a.send(ctx, messages.Base{Type: messages.OPAQUE, Payload: o})
+	if err != nil {
+		return "", fmt.Errorf("OPAQUE authentication completion failed: %s", err)
+	}
+	if msg.Type != messages.IDLE {
+		return "", fmt.Errorf("expected an %s message after OPAQUE authentication but received %s", messages.IDLE, msg.Type)
+	}
+	return "registered and authenticated with OPAQUE and established a session key", nil
+}
+
+// exchange sends an OPAQUE message to the server and returns the OPAQUE message of the expected type it responds with
+func (a *testAgent) exchange(ctx context.Context, o opaqueMessage.Opaque, expected opaqueMessage.Type) (opaqueMessage.Opaque, error) {
+	msg, err := a.send(ctx, messages.Base{Type: messages.OPAQUE, Payload: o})
+	if err != nil {
+		return opaqueMessage.Opaque{}, err
+	}
+	ro, ok := msg.Payload.(opaqueMessage.Opaque)
+	if msg.Type != messages.OPAQUE || !ok {
+		return opaqueMessage.Opaque{}, fmt.Errorf("expected an %s message but received %s", messages.OPAQUE, msg.Type)
+	}
+	if ro.Type != expected {
+		return opaqueMessage.Opaque{}, fmt.Errorf("expected an OPAQUE %s message but received %s", expected, ro.Type)
+	}
+	return ro, nil
+}
+
+// checkin removes the jobs queued for every new Agent and verifies the server knows the test Agent is authenticated
+// and has nothing for it to do
+func (s *Service) checkin(ctx context.Context, a *testAgent) (string, error) {
+	if !s.agentService.Authenticated(a.id) {
+		return "", fmt.Errorf("the server did not record the test agent as authenticated")
+	}
+	err := s.jobService.Clear(a.id)
+	if err != nil {
+		return "", fmt.Errorf("there was an error clearing the jobs queued for the test agent: %s", err)
+	}
+	msg, err := a.send(ctx, messages.Base{Type: messages.CHECKIN})
+	if err != nil {
+		return "", err
+	}
+	if msg.Type != messages.IDLE {
+		return "", fmt.Errorf("expected an %s message but received %s", messages.IDLE, msg.Type)
+	}
+	return "the server recognized the authenticated agent and had no jobs for it", nil
+}
+
+// roundTrip queues a job for the test Agent, receives it on check in, returns a random value as its results, and
+// verifies the server correlated the results with the job and completed it
+func (s *Service) roundTrip(ctx context.Context, a *testAgent) (string, error) {
+	jobID, err := s.jobService.Task(a.id, "pwd", nil)
+	if err != nil {
+		return "", fmt.Errorf("there was an error queueing a job for the test agent: %s", err)
+	}
+
+	msg, err := a.send(ctx, messages.Base{Type: messages.CHECKIN})
+	if err != nil {
+		return "", err
+	}
+	agentJobs, ok := msg.Payload.([]jobs.Job)
+	if msg.Type != messages.JOBS || !ok {
+		return "", fmt.Errorf("expected a %s message with job %s but received %s", messages.JOBS, jobID, msg.Type)
+	}
+	var received jobs.Job
+	for _, j := range agentJobs {
+		if j.ID == jobID {
+			received = j
+		}
+	}
+	if received.ID == "" {
+		return "", fmt.Errorf("the server's %s message did not contain job %s", messages.JOBS, jobID)
+	}
+	if cmd, ok := received.Payload.(jobs.Command); !ok || cmd.Command != "pwd" {
+		return "", fmt.Errorf("job %s did not contain the command that was queued", jobID)
+	}
+
+	echo := core.RandStringBytesMaskImprSrc(32)
+	result := jobs.Job{
+		AgentID: a.id,
+		ID:      received.ID,
+		Token:   received.Token,
+		Type:    jobs.RESULT,
+		Payload: jobs.Results{Stdout: echo},
+	}
+	_, err = a.send(ctx, messages.Base{Type: messages.JOBS, Payload: []jobs.Job{result}})
+	if err != nil {
+		return "", err
+	}
+
+	for _, info := range s.jobService.GetAll() {
+		if info.ID() != jobID {
+			continue
+		}
+		if info.Status() != infoJobs.COMPLETE {
+			return "", fmt.Errorf("job %s is %s after the agent returned its results", jobID, info.StatusString())
+		}
+		return fmt.Sprintf("job %s was sent, its results were correlated by job ID and token, and it completed", jobID), nil
+	}
+	return "", fmt.Errorf("job %s was not found after the agent returned its results", jobID)
+}
+
+// cleanup removes the test Agent, every job and the job queue the server created for it, and the files the server
+// wrote for it
+func (s *Service) cleanup(id uuid.UUID) (string, error) {
+	// The jobs can exist without the Agent, such as when its authentication fails after they are queued
+	err := s.jobService.Remove(id)
+	if err != nil {
+		slog.Debug(fmt.Sprintf("pkg/services/selftest.cleanup(): %s", err))
+	}
+	if !s.agentService.Exist(id) {
+		return fmt.Sprintf("test agent %s was not created", id), nil
+	}
+	err = s.agentService.Remove(id)
+	if err != nil {
+		return "", fmt.Errorf("there was an error removing test agent %s: %s", id, err)
+	}
+	current, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("there was an error getting the current working directory: %s", err)
+	}
+	err = os.RemoveAll(filepath.Join(current, "data", "agents", id.String()))
+	if err != nil {
+		return "", fmt.Errorf("there was an error removing the files for test agent %s: %s", id, err)
+	}
+	return fmt.Sprintf("removed test agent %s, its jobs, and its files", id), nil
+}
@@ -0,0 +1,247 @@
+/*
+Merlin is a post-exploitation command and control framework.
+
+This file is part of Merlin.
+Copyright (C) 2024 Russel Van Tuyl
+
+Merlin is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+any later version.
+
+Merlin is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Merlin.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package selftest
+
+import (
+	// Standard
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	// 3rd Party
+	"github.com/google/uuid"
+
+	// Internal
+	"github.com/Ne0nd0g/merlin/v2/pkg/listeners"
+	"github.com/Ne0nd0g/merlin/v2/pkg/services/job"
+	listenerService "github.com/Ne0nd0g/merlin/v2/pkg/services/listeners"
+)
+
+// newListener creates a TCP listener with the provided authenticator and options. The OPAQUE key, agent log files,
+// and any other server files are written to a temporary directory
+func newListener(t *testing.T, authenticator string, options map[string]string) listeners.Listener {
+	t.Helper()
+	current, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(current) })
+
+	ls := listenerService.NewListenerService()
+	defaults, err := ls.DefaultOptions("tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults["Name"] = "selftest-" + uuid.NewString()
+	defaults["Authenticator"] = authenticator
+	for k, v := range options {
+		defaults[k] = v
+	}
+	listener, err := ls.NewListener(defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		listeners.SetExpiration(listener.ID(), listeners.Expiration{})
+		_ = ls.Remove(listener.ID())
+	})
+	return listener
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		authenticator string
+		options       map[string]string
+		draining      bool
+		wantErr       bool
+	}{
+		{"none", "none", nil, false, false},
+		{"OPAQUE", "opaque", nil, false, false},
+		{"draining", "none", nil, true, true},
+		{"one-shot", "none", map[string]string{"Expiration": listeners.ExpireOnce}, false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			listener := newListener(t, test.authenticator, test.options)
+			if test.draining {
+				listeners.Drain(listener.ID(), true)
+				t.Cleanup(func() { listeners.Drain(listener.ID(), false) })
+			}
+			s := NewSelfTestService()
+			before := len(s.agentService.Agents())
+			jobs := len(s.jobService.GetAll())
+			steps, err := s.Run(context.Background(), listener)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, have %v", test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if !Passed(steps) {
+				t.Fatalf("expected every step to pass, have %+v", steps)
+			}
+			var names []string
+			for _, step := range steps {
+				names = append(names, step.Name)
+			}
+			if want := "Authentication,Check in,Job round trip,Cleanup"; strings.Join(names, ",") != want {
+				t.Errorf("expected the steps %s, have %s", want, strings.Join(names, ","))
+			}
+			if after := len(s.agentService.Agents()); after != before {
+				t.Errorf("expected the test agent to be removed, have %d agents instead of %d", after, before)
+			}
+			if after := len(s.jobService.GetAll()); after != jobs {
+				t.Errorf("expected the test agent's jobs to be removed, have %d jobs instead of %d", after, jobs)
+			}
+			current, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries, _ := os.ReadDir(filepath.Join(current, "data", "agents"))
+			if len(entries) != 0 {
+				t.Errorf("expected the test agent's files to be removed, have %d entries", len(entries))
+			}
+		})
+	}
+}
+
+// TestRunApproval verifies the self-test's job honors the two-person integrity policy instead of bypassing it
+func TestRunApproval(t *testing.T) {
+	listener := newListener(t, "none", nil)
+	t.Cleanup(func() { job.SetApprovalPolicy("") })
+	tests := []struct {
+		name   string
+		policy string
+		passed bool
+	}{
+		{"no policy", "", true},
+		{"other command", "shell", true},
+		{"job requires approval", "pwd", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job.SetApprovalPolicy(test.policy)
+			s := NewSelfTestService()
+			jobs := len(s.jobService.GetAll())
+			steps, err := s.Run(context.Background(), listener)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if Passed(steps) != test.passed {
+				t.Fatalf("expected the self-test to pass %t, have %+v", test.passed, steps)
+			}
+			if last := steps[len(steps)-1]; last.Name != "Cleanup" || !last.Passed {
+				t.Errorf("expected the test agent to be cleaned up, have %+v", last)
+			}
+			if after := len(s.jobService.GetAll()); after != jobs {
+				t.Errorf("expected the test agent's jobs to be removed, have %d jobs instead of %d", after, jobs)
+			}
+		})
+	}
+}
+
+// TestRunHooks verifies the test Agent's jobs don't run the operator's hooks
+func TestRunHooks(t *testing.T) {
+	listener := newListener(t, "none", nil)
+	s := NewSelfTestService()
+	events := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		events <- string(body)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name  string
+		event string
+	}{
+		{"queued", job.HookQueued},
+		{"complete", job.HookComplete},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.name+".yaml")
+			config := "hooks:\n  - name: " + test.name + "\n    event: " + test.event + "\n    url: " + server.URL + "\n"
+			if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.jobService.LoadHooks(path); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				empty := filepath.Join(dir, "empty.yaml")
+				_ = os.WriteFile(empty, []byte("hooks: []\n"), 0600)
+				_, _ = s.jobService.LoadHooks(empty)
+			})
+
+			steps, err := s.Run(context.Background(), listener)
+			if err != nil || !Passed(steps) {
+				t.Fatalf("expected the self-test to pass, have %+v: %v", steps, err)
+			}
+			select {
+			case e := <-events:
+				t.Errorf("expected the test agent's jobs not to run hooks, have %s", e)
+			case <-time.After(500 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestPassed(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []Step
+		want  bool
+	}{
+		{"none", nil, false},
+		{"passed", []Step{{Name: "Authentication", Passed: true}, {Name: "Cleanup", Passed: true}}, true},
+		{"failed", []Step{{Name: "Authentication", Passed: false}, {Name: "Cleanup", Passed: true}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if have := Passed(test.steps); have != test.want {
+				t.Errorf("expected %t, have %t", test.want, have)
+			}
+		})
+	}
+}
+
+func TestRunStep(t *testing.T) {
+	step := run("failing", func() (string, error) {
+		time.Sleep(time.Millisecond)
+		return "ignored", os.ErrNotExist
+	})
+	if step.Passed || step.Detail != os.ErrNotExist.Error() || step.Duration < time.Millisecond {
+		t.Errorf("expected a failed step with the error as its detail, have %+v", step)
+	}
+}
@@ -26,3 +26,18 @@ type Transformer interface {
 	Deconstruct(data, key []byte) (any, error)
 	String() string
 }
+
+// Compressor is implemented by transforms that compress the data they transform
+type Compressor interface {
+	Compresses() bool
+}
+
+// Compresses returns true if any of the transforms compress the data they transform
+func Compresses(transforms []Transformer) bool {
+	for _, t := range transforms {
+		if c, ok := t.(Compressor); ok && c.Compresses() {
+			return true
+		}
+	}
+	return false
+}